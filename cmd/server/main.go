@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"laundry-backend/internal/handlers"
+	"laundry-backend/internal/notification"
 	"laundry-backend/internal/repositories"
 	"laundry-backend/internal/routes"
 	"laundry-backend/internal/services"
+	"laundry-backend/internal/storage"
 
 	"laundry-backend/internal/config"
 	"laundry-backend/internal/db"
@@ -46,18 +49,113 @@ func main() {
 	userRepo := repositories.NewUserRepository(dbConn)
 	categoryRepo := repositories.NewCategoryRepository(dbConn)
 	serviceRepo := repositories.NewServiceRepository(dbConn)
+	pricingRuleRepo := repositories.NewPricingRuleRepository(dbConn)
+	addonRepo := repositories.NewAddonRepository(dbConn)
+	promotionRepo := repositories.NewPromotionRepository(dbConn)
+	membershipRepo := repositories.NewMembershipRepository(dbConn)
+	walletRepo := repositories.NewWalletRepository(dbConn)
+	taxRepo := repositories.NewTaxRepository(dbConn)
+	receiptRepo := repositories.NewReceiptRepository(dbConn)
+	orderStatusRepo := repositories.NewOrderStatusRepository(dbConn)
+	tagRepo := repositories.NewTagRepository(dbConn)
+	notificationRepo := repositories.NewNotificationRepository(dbConn)
+	webhookRepo := repositories.NewWebhookRepository(dbConn)
+	idempotencyRepo := repositories.NewIdempotencyRepository(dbConn)
+	searchRepo := repositories.NewSearchRepository(dbConn)
+	importRepo := repositories.NewImportRepository(dbConn)
+	outletRepo := repositories.NewOutletRepository(dbConn)
+	shiftRepo := repositories.NewShiftRepository(dbConn)
+	expenseRepo := repositories.NewExpenseRepository(dbConn)
+	reportRepo := repositories.NewReportRepository(dbConn)
+	inventoryRepo := repositories.NewInventoryRepository(dbConn)
+	capacityRepo := repositories.NewCapacityRepository(dbConn)
+	washBatchRepo := repositories.NewWashBatchRepository(dbConn)
+	slaRepo := repositories.NewSLARepository(dbConn)
+	complaintRepo := repositories.NewComplaintRepository(dbConn)
+	mediaRepo := repositories.NewMediaRepository(dbConn)
+	orderRepo := repositories.NewOrderRepository(dbConn)
+	paymentRepo := repositories.NewPaymentRepository(dbConn)
+
+	// Kanal pengiriman notifikasi (WhatsApp / SMS / log) sesuai NOTIFICATION_DRIVER
+	notifier, err := notification.New(cfg.NOTIFICATION)
+	if err != nil {
+		log.Fatalf("❌ Gagal menyiapkan kanal notifikasi: %v", err)
+	}
+
+	// Penyimpanan file media (disk lokal / S3-compatible) sesuai MEDIA_DRIVER
+	mediaStorage, err := storage.New(cfg.MEDIA)
+	if err != nil {
+		log.Fatalf("❌ Gagal menyiapkan penyimpanan media: %v", err)
+	}
 
 	// B. Service Layer (Business Logic)
-	authService := services.NewAuthService(authRepo, userRepo, cfg)
-	userService := services.NewUserService(userRepo)
+	authService := services.NewAuthService(authRepo, userRepo, outletRepo, cfg)
+	userService := services.NewUserService(userRepo, outletRepo)
 	categoryService := services.NewCategoryService(categoryRepo)
 	serviceService := services.NewServiceService(serviceRepo)
+	pricingRuleService := services.NewPricingRuleService(pricingRuleRepo, serviceRepo, addonRepo)
+	addonService := services.NewAddonService(addonRepo, serviceRepo)
+	promotionService := services.NewPromotionService(promotionRepo, membershipRepo, serviceRepo, categoryRepo, pricingRuleService)
+	membershipService := services.NewMembershipService(membershipRepo)
+	walletService := services.NewWalletService(walletRepo, membershipRepo, cfg)
+	taxService := services.NewTaxService(taxRepo, serviceRepo, categoryRepo, pricingRuleService, promotionService)
+	receiptService := services.NewReceiptService(receiptRepo, cfg)
+	notificationService := services.NewNotificationService(notificationRepo, notifier, cfg)
+	webhookService := services.NewWebhookService(webhookRepo, cfg)
+	inventoryService := services.NewInventoryService(inventoryRepo, serviceRepo)
+	tagService := services.NewTagService(tagRepo, orderStatusRepo, notificationService, webhookService, inventoryService)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, cfg)
+	searchService := services.NewSearchService(searchRepo)
+	importService := services.NewImportService(importRepo, categoryRepo, serviceRepo)
+	outletService := services.NewOutletService(outletRepo, serviceRepo)
+	shiftService := services.NewShiftService(shiftRepo)
+	expenseService := services.NewExpenseService(expenseRepo)
+	reportService := services.NewReportService(reportRepo)
+	capacityService := services.NewCapacityService(capacityRepo, pricingRuleService, taxService, cfg)
+	washBatchService := services.NewWashBatchService(washBatchRepo, capacityRepo, orderStatusRepo, notificationService, webhookService, inventoryService, cfg)
+	slaService := services.NewSLAService(slaRepo, orderStatusRepo, webhookService, cfg)
+	complaintService := services.NewComplaintService(complaintRepo, orderStatusRepo, expenseRepo, promotionRepo, walletService)
+	mediaService := services.NewMediaService(mediaRepo, mediaStorage, cfg)
+	orderService := services.NewOrderService(orderRepo, orderStatusRepo, shiftRepo, pricingRuleService, promotionService, taxService, capacityService, walletService, notificationService, webhookService, cfg)
+	paymentService := services.NewPaymentService(paymentRepo, shiftRepo, walletService, notificationService, webhookService)
 
 	// C. Handler Layer (HTTP Transport)
 	authHandler := handlers.NewAuthHandler(authService)
 	userHandler := handlers.NewUserHandler(userService)
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
 	serviceHandler := handlers.NewServiceHandler(serviceService)
+	pricingRuleHandler := handlers.NewPricingRuleHandler(pricingRuleService)
+	addonHandler := handlers.NewAddonHandler(addonService)
+	promotionHandler := handlers.NewPromotionHandler(promotionService)
+	membershipHandler := handlers.NewMembershipHandler(membershipService)
+	walletHandler := handlers.NewWalletHandler(walletService)
+	taxHandler := handlers.NewTaxHandler(taxService)
+	receiptHandler := handlers.NewReceiptHandler(receiptService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+	importHandler := handlers.NewImportHandler(importService)
+	outletHandler := handlers.NewOutletHandler(outletService)
+	shiftHandler := handlers.NewShiftHandler(shiftService)
+	expenseHandler := handlers.NewExpenseHandler(expenseService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	inventoryHandler := handlers.NewInventoryHandler(inventoryService)
+	capacityHandler := handlers.NewCapacityHandler(capacityService)
+	washBatchHandler := handlers.NewWashBatchHandler(washBatchService)
+	slaHandler := handlers.NewSLAHandler(slaService)
+	complaintHandler := handlers.NewComplaintHandler(complaintService)
+	mediaHandler := handlers.NewMediaHandler(mediaService)
+	orderHandler := handlers.NewOrderHandler(orderService)
+	paymentHandler := handlers.NewPaymentHandler(paymentService)
+
+	// D. Background Worker (Pengirim antrean notifikasi & webhook, pembersih idempotency key, scheduler SLA)
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	defer stopWorker()
+	go notificationService.RunWorker(workerCtx)
+	go webhookService.RunWorker(workerCtx)
+	go idempotencyService.RunCleaner(workerCtx)
+	go slaService.RunScheduler(workerCtx)
 
 	// ==========================================
 	// 4. SETUP SERVER & ROUTES
@@ -74,6 +172,29 @@ func main() {
 	routes.SetupUserRoutes(v1, userHandler, authRepo, cfg)
 	routes.SetupCategoryRoutes(v1, categoryHandler, authRepo, cfg)
 	routes.SetupServiceRoutes(v1, serviceHandler, authRepo, cfg)
+	routes.SetupPricingRuleRoutes(v1, pricingRuleHandler, authRepo, cfg)
+	routes.SetupAddonRoutes(v1, addonHandler, authRepo, cfg)
+	routes.SetupPromotionRoutes(v1, promotionHandler, authRepo, cfg)
+	routes.SetupMembershipRoutes(v1, membershipHandler, authRepo, cfg)
+	routes.SetupWalletRoutes(v1, walletHandler, membershipHandler, authRepo, idempotencyRepo, cfg)
+	routes.SetupTaxRoutes(v1, taxHandler, authRepo, cfg)
+	routes.SetupReceiptRoutes(v1, receiptHandler, authRepo, cfg)
+	routes.SetupTagRoutes(v1, tagHandler, authRepo, idempotencyRepo, cfg)
+	routes.SetupNotificationRoutes(v1, notificationHandler, authRepo, cfg)
+	routes.SetupWebhookRoutes(v1, webhookHandler, authRepo, cfg)
+	routes.SetupSearchRoutes(v1, searchHandler, authRepo, cfg)
+	routes.SetupImportRoutes(v1, importHandler, authRepo, cfg)
+	routes.SetupOutletRoutes(v1, outletHandler, authRepo, cfg)
+	routes.SetupShiftRoutes(v1, shiftHandler, authRepo, cfg)
+	routes.SetupExpenseRoutes(v1, expenseHandler, reportHandler, authRepo, cfg)
+	routes.SetupInventoryRoutes(v1, inventoryHandler, authRepo, cfg)
+	routes.SetupCapacityRoutes(v1, capacityHandler, authRepo, cfg)
+	routes.SetupWashBatchRoutes(v1, washBatchHandler, authRepo, idempotencyRepo, cfg)
+	routes.SetupSLARoutes(v1, slaHandler, authRepo, cfg)
+	routes.SetupComplaintRoutes(v1, complaintHandler, authRepo, idempotencyRepo, cfg)
+	routes.SetupMediaRoutes(v1, mediaHandler, authRepo, cfg)
+	routes.SetupOrderRoutes(v1, orderHandler, authRepo, idempotencyRepo, cfg)
+	routes.SetupPaymentRoutes(v1, paymentHandler, authRepo, idempotencyRepo, cfg)
 
 	// ==========================================
 	// 5. START THE SERVER
@@ -0,0 +1,264 @@
+// Package capacity berisi model kapasitas workshop dan kalkulator estimasi selesai (ETA) pesanan.
+//
+// Kalkulator ini murni (tanpa database): pemanggil menyiapkan daftar mesin, kalender operasional,
+// dan antrean outlet, lalu EstimateReadyAt menjadwalkan pesanan baru di belakang antrean tersebut.
+package capacity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"laundry-backend/internal/models"
+)
+
+// ErrNoOpeningHours dikembalikan jika tidak ada jam buka sama sekali dalam horizon perencanaan.
+var ErrNoOpeningHours = errors.New("outlet has no opening hours within the planning horizon")
+
+// horizonDays adalah batas pencarian jam buka ke depan (mencegah loop tanpa akhir saat semua hari tutup).
+const horizonDays = 366
+
+// window adalah jam buka satu hari, dihitung sebagai offset dari tengah malam.
+type window struct {
+	open  time.Duration
+	close time.Duration
+}
+
+// Calendar adalah jam operasional mingguan + hari libur satu outlet.
+type Calendar struct {
+	alwaysOpen bool            // Outlet tanpa pengaturan jam dianggap buka 24 jam
+	days       [7]*window      // Index time.Weekday; nil = tutup
+	holidays   map[string]bool // Tanggal libur (YYYY-MM-DD)
+	loc        *time.Location  // Zona waktu outlet
+}
+
+// NewCalendar menyusun kalender dari jam operasional & hari libur outlet.
+// Tanpa jam operasional sama sekali outlet dianggap buka 24 jam (hari libur tetap tutup).
+func NewCalendar(hours []models.OperatingHour, holidays []time.Time, loc *time.Location) (*Calendar, error) {
+
+	c := &Calendar{
+		alwaysOpen: len(hours) == 0,
+		holidays:   make(map[string]bool, len(holidays)),
+		loc:        loc,
+	}
+
+	for _, h := range hours {
+		if h.Weekday < 0 || h.Weekday > 6 {
+			return nil, fmt.Errorf("invalid weekday %d", h.Weekday)
+		}
+		if h.IsClosed {
+			continue
+		}
+
+		open, err := ParseClock(h.OpenTime)
+		if err != nil {
+			return nil, err
+		}
+		closeAt, err := ParseClock(h.CloseTime)
+		if err != nil {
+			return nil, err
+		}
+		if closeAt <= open {
+			return nil, fmt.Errorf("close time must be after open time on weekday %d", h.Weekday)
+		}
+		c.days[h.Weekday] = &window{open: open, close: closeAt}
+	}
+
+	for _, d := range holidays {
+		c.holidays[d.Format("2006-01-02")] = true
+	}
+
+	return c, nil
+}
+
+// ParseClock mengubah "HH:MM" / "HH:MM:SS" menjadi offset dari tengah malam.
+func ParseClock(value string) (time.Duration, error) {
+
+	var h, m, s int
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid clock time %q", value)
+	}
+	if _, err := fmt.Sscanf(parts[0]+" "+parts[1], "%d %d", &h, &m); err != nil {
+		return 0, fmt.Errorf("invalid clock time %q", value)
+	}
+	if len(parts) == 3 {
+		if _, err := fmt.Sscanf(parts[2], "%d", &s); err != nil {
+			return 0, fmt.Errorf("invalid clock time %q", value)
+		}
+	}
+	if h < 0 || m < 0 || m > 59 || s < 0 || s > 59 || h*3600+m*60+s > 24*3600 {
+		return 0, fmt.Errorf("invalid clock time %q", value)
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second, nil
+}
+
+// windowOn mengembalikan jam buka pada tanggal 'day' (tengah malam), ok = false jika tutup/libur.
+func (c *Calendar) windowOn(day time.Time) (start, end time.Time, ok bool) {
+
+	if c.holidays[day.Format("2006-01-02")] {
+		return time.Time{}, time.Time{}, false
+	}
+	if c.alwaysOpen {
+		return day, day.AddDate(0, 0, 1), true
+	}
+
+	w := c.days[day.Weekday()]
+	if w == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return day.Add(w.open), day.Add(w.close), true
+}
+
+// AddWorkingTime menjumlahkan 'work' jam kerja efektif mulai 'from', melompati jam tutup & hari libur.
+// Dengan work = 0 hasilnya adalah saat buka terdekat (from itu sendiri jika outlet sedang buka).
+func (c *Calendar) AddWorkingTime(from time.Time, work time.Duration) (time.Time, error) {
+
+	from = from.In(c.loc)
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, c.loc)
+
+	for i := 0; i < horizonDays; i++ {
+		if start, end, ok := c.windowOn(day); ok {
+			if start.Before(from) {
+				start = from
+			}
+			if start.Before(end) {
+				available := end.Sub(start)
+				if work <= available {
+					return start.Add(work), nil
+				}
+				work -= available
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}, ErrNoOpeningHours
+}
+
+// NextOpen mengembalikan saat buka terdekat pada atau setelah t.
+func (c *Calendar) NextOpen(t time.Time) (time.Time, error) {
+	return c.AddWorkingTime(t, 0)
+}
+
+// OpenDuration menghitung total jam buka di antara from dan to (dipakai laporan utilisasi mesin).
+func (c *Calendar) OpenDuration(from, to time.Time) time.Duration {
+
+	from, to = from.In(c.loc), to.In(c.loc)
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, c.loc)
+
+	total := time.Duration(0)
+	for day.Before(to) {
+		if start, end, ok := c.windowOn(day); ok {
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if start.Before(end) {
+				total += end.Sub(start)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return total
+}
+
+// ThroughputKgPerHour menghitung kapasitas workshop: SUM(capacity_kg x 60 / cycle_minutes) per jenis mesin,
+// lalu diambil jenis yang paling lambat (cucian harus dicuci lalu dikeringkan). Mesin maintenance tidak dihitung.
+// 0 = belum ada mesin.
+func ThroughputKgPerHour(machines []models.Machine) float64 {
+
+	perType := make(map[string]float64)
+	for _, m := range machines {
+		if !m.IsActive || m.UnderMaintenance || m.CapacityKg <= 0 || m.CycleMinutes <= 0 {
+			continue
+		}
+		perType[m.MachineType] += m.CapacityKg * 60 / float64(m.CycleMinutes)
+	}
+
+	throughput := 0.0
+	for _, kgPerHour := range perType {
+		if throughput == 0 || kgPerHour < throughput {
+			throughput = kgPerHour
+		}
+	}
+
+	return throughput
+}
+
+// Input adalah data yang dibutuhkan untuk menjadwalkan satu pesanan baru.
+type Input struct {
+	Now           time.Time
+	DurationHours int     // MAX durasi layanan pesanan (setelah add-on Express)
+	BacklogKg     float64 // Beban pesanan 'pending' & 'in-progress' yang sudah mengantre di outlet
+	LoadKg        float64 // Beban pesanan baru
+	Machines      []models.Machine
+	Calendar      *Calendar
+}
+
+// Estimate adalah hasil penjadwalan satu pesanan.
+type Estimate struct {
+	ThroughputKgPerHour float64    // 0 = mesin belum diatur, antrean tidak diperhitungkan
+	WorkHours           float64    // Jam kerja mesin untuk antrean + pesanan baru
+	ProcessingDoneAt    *time.Time // Perkiraan mesin selesai mengerjakan pesanan baru
+	MinimumReadyAt      time.Time  // Now + duration_hours (janji layanan paling cepat)
+	ReadyAt             time.Time  // Estimasi siap diambil (dalam jam buka)
+}
+
+// EstimateReadyAt menjadwalkan pesanan baru di belakang antrean outlet.
+//
+// Aturan:
+//   - Mesin bekerja hanya pada jam buka; antrean + pesanan baru dikerjakan berurutan (FIFO)
+//     dengan kecepatan ThroughputKgPerHour.
+//   - Pesanan tidak bisa siap lebih cepat dari Now + duration_hours layanannya.
+//   - Waktu siap digeser ke saat buka terdekat agar pelanggan bisa mengambilnya.
+//   - Tanpa mesin aktif hasilnya sama dengan perhitungan lama (Now + duration_hours), tetap dalam jam buka.
+func EstimateReadyAt(in Input) (*Estimate, error) {
+
+	est := &Estimate{
+		ThroughputKgPerHour: math.Round(ThroughputKgPerHour(in.Machines)*100) / 100,
+		MinimumReadyAt:      in.Now.Add(time.Duration(in.DurationHours) * time.Hour),
+	}
+	readyAt := est.MinimumReadyAt
+
+	// 1. Jadwalkan antrean + pesanan baru pada jam kerja mesin
+	if est.ThroughputKgPerHour > 0 {
+		hours := (in.BacklogKg + in.LoadKg) / est.ThroughputKgPerHour
+		est.WorkHours = math.Round(hours*100) / 100
+
+		doneAt, err := in.Calendar.AddWorkingTime(in.Now, time.Duration(hours*float64(time.Hour)))
+		if err != nil {
+			return nil, err
+		}
+		doneAt = ceilMinute(doneAt)
+		est.ProcessingDoneAt = &doneAt
+
+		if doneAt.After(readyAt) {
+			readyAt = doneAt
+		}
+	}
+
+	// 2. Siap diambil hanya saat outlet buka
+	readyAt, err := in.Calendar.NextOpen(ceilMinute(readyAt))
+	if err != nil {
+		return nil, err
+	}
+	est.ReadyAt = readyAt
+
+	return est, nil
+}
+
+// ceilMinute membulatkan waktu ke atas ke menit penuh.
+func ceilMinute(t time.Time) time.Time {
+	truncated := t.Truncate(time.Minute)
+	if truncated.Equal(t) {
+		return t
+	}
+	return truncated.Add(time.Minute)
+}
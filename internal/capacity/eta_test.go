@@ -0,0 +1,251 @@
+package capacity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/models"
+)
+
+var wib = time.FixedZone("WIB", 7*60*60)
+
+// at membuat waktu WIB; 2026-01-05 adalah hari Senin.
+func at(day, hour, minute int) time.Time {
+	return time.Date(2026, 1, day, hour, minute, 0, 0, wib)
+}
+
+// weekdayHours: Senin–Jumat 08:00–17:00, Sabtu 08:00–12:00, Minggu tutup.
+func weekdayHours() []models.OperatingHour {
+	hours := []models.OperatingHour{{Weekday: 0, IsClosed: true}}
+	for d := 1; d <= 5; d++ {
+		hours = append(hours, models.OperatingHour{Weekday: d, OpenTime: "08:00:00", CloseTime: "17:00:00"})
+	}
+	return append(hours, models.OperatingHour{Weekday: 6, OpenTime: "08:00", CloseTime: "12:00"})
+}
+
+func mustCalendar(t *testing.T, hours []models.OperatingHour, holidays ...time.Time) *Calendar {
+	t.Helper()
+	c, err := NewCalendar(hours, holidays, wib)
+	if err != nil {
+		t.Fatalf("NewCalendar: %v", err)
+	}
+	return c
+}
+
+func TestAddWorkingTime(t *testing.T) {
+
+	tuesdayHoliday := time.Date(2026, 1, 6, 0, 0, 0, 0, wib)
+
+	tests := []struct {
+		name     string
+		hours    []models.OperatingHour
+		holidays []time.Time
+		from     time.Time
+		work     time.Duration
+		want     time.Time
+	}{
+		{name: "fits inside opening hours", hours: weekdayHours(), from: at(5, 9, 0), work: 2 * time.Hour, want: at(5, 11, 0)},
+		{name: "ends exactly at closing", hours: weekdayHours(), from: at(5, 15, 0), work: 2 * time.Hour, want: at(5, 17, 0)},
+		{name: "wraps past closing to next morning", hours: weekdayHours(), from: at(5, 16, 0), work: 2 * time.Hour, want: at(6, 9, 0)},
+		{name: "starts before opening", hours: weekdayHours(), from: at(5, 6, 0), work: time.Hour, want: at(5, 9, 0)},
+		{name: "starts after closing", hours: weekdayHours(), from: at(5, 18, 0), work: time.Hour, want: at(6, 9, 0)},
+		{name: "skips short saturday and closed sunday", hours: weekdayHours(), from: at(9, 16, 0), work: 6 * time.Hour, want: at(12, 9, 0)},
+		{name: "skips holiday", hours: weekdayHours(), holidays: []time.Time{tuesdayHoliday}, from: at(5, 16, 0), work: 2 * time.Hour, want: at(7, 9, 0)},
+		{name: "zero work on closed sunday waits for monday", hours: weekdayHours(), from: at(11, 10, 0), work: 0, want: at(12, 8, 0)},
+		{name: "zero work while open returns from", hours: weekdayHours(), from: at(5, 10, 30), work: 0, want: at(5, 10, 30)},
+		{name: "always open wraps midnight", hours: nil, from: at(5, 23, 0), work: 2 * time.Hour, want: at(6, 1, 0)},
+		{name: "always open still closes on holiday", hours: nil, holidays: []time.Time{tuesdayHoliday}, from: at(5, 23, 0), work: 2 * time.Hour, want: at(7, 1, 0)},
+		{name: "converts from another zone", hours: weekdayHours(), from: time.Date(2026, 1, 5, 2, 0, 0, 0, time.UTC), work: time.Hour, want: at(5, 10, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mustCalendar(t, tt.hours, tt.holidays...).AddWorkingTime(tt.from, tt.work)
+			if err != nil {
+				t.Fatalf("AddWorkingTime: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("AddWorkingTime(%v, %v) = %v, want %v", tt.from, tt.work, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddWorkingTimeNoOpeningHours(t *testing.T) {
+
+	closed := make([]models.OperatingHour, 0, 7)
+	for d := 0; d <= 6; d++ {
+		closed = append(closed, models.OperatingHour{Weekday: d, IsClosed: true})
+	}
+
+	_, err := mustCalendar(t, closed).AddWorkingTime(at(5, 9, 0), time.Hour)
+	if !errors.Is(err, ErrNoOpeningHours) {
+		t.Fatalf("error = %v, want ErrNoOpeningHours", err)
+	}
+}
+
+func TestNewCalendarRejectsInvalidHours(t *testing.T) {
+
+	tests := []struct {
+		name string
+		hour models.OperatingHour
+	}{
+		{name: "weekday out of range", hour: models.OperatingHour{Weekday: 7, OpenTime: "08:00", CloseTime: "17:00"}},
+		{name: "close before open", hour: models.OperatingHour{Weekday: 1, OpenTime: "17:00", CloseTime: "08:00"}},
+		{name: "bad clock", hour: models.OperatingHour{Weekday: 1, OpenTime: "8am", CloseTime: "17:00"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCalendar([]models.OperatingHour{tt.hour}, nil, wib); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "08:00", want: 8 * time.Hour},
+		{in: "17:30:15", want: 17*time.Hour + 30*time.Minute + 15*time.Second},
+		{in: "24:00", want: 24 * time.Hour},
+		{in: "24:01", wantErr: true},
+		{in: "12:60", wantErr: true},
+		{in: "12", wantErr: true},
+		{in: "ab:cd", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseClock(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseClock(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if err == nil && got != tt.want {
+			t.Fatalf("ParseClock(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOpenDuration(t *testing.T) {
+
+	c := mustCalendar(t, weekdayHours())
+
+	// Jumat 12:00 → Senin 10:00 = Jumat 5 jam + Sabtu 4 jam + Senin 2 jam
+	if got := c.OpenDuration(at(9, 12, 0), at(12, 10, 0)); got != 11*time.Hour {
+		t.Fatalf("OpenDuration = %v, want 11h", got)
+	}
+}
+
+func TestThroughputKgPerHour(t *testing.T) {
+
+	machines := []models.Machine{
+		{MachineType: models.MachineWasher, CapacityKg: 10, CycleMinutes: 60, IsActive: true},
+		{MachineType: models.MachineWasher, CapacityKg: 10, CycleMinutes: 60, IsActive: true, UnderMaintenance: true},
+		{MachineType: models.MachineDryer, CapacityKg: 10, CycleMinutes: 30, IsActive: true},
+		{MachineType: models.MachineDryer, CapacityKg: 8, CycleMinutes: 40, IsActive: false},
+	}
+
+	// Washer 10 kg/jam (yang maintenance tidak dihitung), dryer 20 kg/jam → washer yang paling lambat
+	if got := ThroughputKgPerHour(machines); got != 10 {
+		t.Fatalf("ThroughputKgPerHour = %v, want 10", got)
+	}
+	if got := ThroughputKgPerHour(nil); got != 0 {
+		t.Fatalf("ThroughputKgPerHour(nil) = %v, want 0", got)
+	}
+}
+
+func TestEstimateReadyAt(t *testing.T) {
+
+	machines := []models.Machine{
+		{MachineType: models.MachineWasher, CapacityKg: 10, CycleMinutes: 60, IsActive: true},
+		{MachineType: models.MachineDryer, CapacityKg: 10, CycleMinutes: 30, IsActive: true},
+	}
+
+	tests := []struct {
+		name           string
+		in             Input
+		wantReady      time.Time
+		wantProcessing *time.Time
+		wantWorkHours  float64
+	}{
+		{
+			name:      "no machines falls back to duration hours",
+			in:        Input{Now: at(5, 10, 0), DurationHours: 24, LoadKg: 5},
+			wantReady: at(6, 10, 0),
+		},
+		{
+			name:      "no machines moves ready time after closing to next opening",
+			in:        Input{Now: at(5, 16, 30), DurationHours: 3, LoadKg: 5},
+			wantReady: at(6, 8, 0),
+		},
+		{
+			name:      "no machines skips closed sunday",
+			in:        Input{Now: at(10, 10, 0), DurationHours: 24, LoadKg: 5},
+			wantReady: at(12, 8, 0),
+		},
+		{
+			name:           "backlog wraps past closing",
+			in:             Input{Now: at(5, 15, 0), DurationHours: 3, BacklogKg: 30, LoadKg: 10, Machines: machines},
+			wantReady:      at(6, 10, 0),
+			wantProcessing: ptrTime(at(6, 10, 0)),
+			wantWorkHours:  4,
+		},
+		{
+			name:           "duration hours dominates a short queue",
+			in:             Input{Now: at(5, 9, 0), DurationHours: 6, LoadKg: 10, Machines: machines},
+			wantReady:      at(5, 15, 0),
+			wantProcessing: ptrTime(at(5, 10, 0)),
+			wantWorkHours:  1,
+		},
+		{
+			name:           "processing time rounds up to whole minute",
+			in:             Input{Now: at(5, 9, 0), LoadKg: 1.05, Machines: machines},
+			wantReady:      at(5, 9, 7),
+			wantProcessing: ptrTime(at(5, 9, 7)),
+			wantWorkHours:  0.11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Calendar = mustCalendar(t, weekdayHours())
+
+			got, err := EstimateReadyAt(tt.in)
+			if err != nil {
+				t.Fatalf("EstimateReadyAt: %v", err)
+			}
+			if !got.ReadyAt.Equal(tt.wantReady) {
+				t.Fatalf("ReadyAt = %v, want %v", got.ReadyAt, tt.wantReady)
+			}
+			if (got.ProcessingDoneAt == nil) != (tt.wantProcessing == nil) ||
+				(got.ProcessingDoneAt != nil && !got.ProcessingDoneAt.Equal(*tt.wantProcessing)) {
+				t.Fatalf("ProcessingDoneAt = %v, want %v", got.ProcessingDoneAt, tt.wantProcessing)
+			}
+			if got.WorkHours != tt.wantWorkHours {
+				t.Fatalf("WorkHours = %v, want %v", got.WorkHours, tt.wantWorkHours)
+			}
+			if want := tt.in.Now.Add(time.Duration(tt.in.DurationHours) * time.Hour); !got.MinimumReadyAt.Equal(want) {
+				t.Fatalf("MinimumReadyAt = %v, want %v", got.MinimumReadyAt, want)
+			}
+		})
+	}
+}
+
+func TestEstimateReadyAtNoOpeningHours(t *testing.T) {
+
+	closed := []models.OperatingHour{}
+	for d := 0; d <= 6; d++ {
+		closed = append(closed, models.OperatingHour{Weekday: d, IsClosed: true})
+	}
+
+	_, err := EstimateReadyAt(Input{Now: at(5, 9, 0), DurationHours: 24, LoadKg: 3, Calendar: mustCalendar(t, closed)})
+	if !errors.Is(err, ErrNoOpeningHours) {
+		t.Fatalf("error = %v, want ErrNoOpeningHours", err)
+	}
+}
+
+func ptrTime(t time.Time) *time.Time { return &t }
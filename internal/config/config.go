@@ -3,17 +3,36 @@ package config
 import (
 	"log"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// DBTimeZone & Location adalah zona waktu bisnis yang tetap (WIB, tanpa DST).
+// Sesi MySQL memakai DBTimeZone sehingga DATE_FORMAT/DATE() pada kolom TIMESTAMP membagi hari
+// di zona yang sama dengan periode laporan yang disusun di Go memakai Location.
+const DBTimeZone = "+07:00"
+
+var Location = time.FixedZone("WIB", 7*60*60)
+
 type Config struct {
 	APP  AppConfig
 	DB   DBConfig
 	JWT  JWTConfig
 	CORS CORSConfig
 	LOG  LOGConfig
+
+	LOYALTY      LoyaltyConfig
+	SHOP         ShopConfig
+	NOTIFICATION NotificationConfig
+	WEBHOOK      WebhookConfig
+	IDEMPOTENCY  IdempotencyConfig
+	CAPACITY     CapacityConfig
+	SLA          SLAConfig
+	MEDIA        MediaConfig
 }
 
 type AppConfig struct {
@@ -48,6 +67,83 @@ type LOGConfig struct {
 	Level string
 }
 
+// LoyaltyConfig mengatur konversi poin loyalitas.
+type LoyaltyConfig struct {
+	EarnAmount int // Nominal belanja (Rp) untuk mendapat 1 poin
+	PointValue int // Nilai tukar 1 poin (Rp) saat ditukar menjadi potongan
+}
+
+// ShopConfig adalah identitas outlet yang tercetak di kepala & kaki nota.
+type ShopConfig struct {
+	Name            string
+	Address         string
+	Phone           string
+	ReceiptFooter   string
+	TrackingBaseURL string // URL publik frontend, QR nota mengarah ke {TrackingBaseURL}/track/{invoice_number}
+}
+
+// NotificationConfig mengatur kanal & worker pengiriman notifikasi pelanggan.
+type NotificationConfig struct {
+	Driver          string // log, whatsapp, atau sms
+	GatewayURL      string // Endpoint HTTP gateway WhatsApp/SMS
+	GatewayToken    string // Dikirim sebagai header Authorization
+	Sender          string // Nomor/ID pengirim (opsional, tergantung gateway)
+	LogFile         string // Tujuan driver log; kosong = stdout
+	PollIntervalSec int
+	BatchSize       int
+	MaxAttempts     int
+	RetryBaseSec    int // Jeda retry pertama, berlipat dua di setiap percobaan
+}
+
+// WebhookConfig mengatur worker pengiriman webhook keluar.
+type WebhookConfig struct {
+	TimeoutSec      int // Batas waktu satu request ke endpoint penerima
+	PollIntervalSec int
+	BatchSize       int
+	MaxAttempts     int
+	RetryBaseSec    int // Jeda retry pertama, berlipat dua di setiap percobaan
+}
+
+// IdempotencyConfig mengatur penyimpanan header Idempotency-Key.
+type IdempotencyConfig struct {
+	TTLHours           int // Lama balasan disimpan untuk diputar ulang
+	LockSec            int // Batas kunci request yang masih berjalan; lewat dari ini dianggap mati
+	CleanupIntervalMin int // Jeda pembersihan key kedaluwarsa
+}
+
+// CapacityConfig mengatur model kapasitas workshop untuk estimasi selesai pesanan.
+type CapacityConfig struct {
+	PieceLoadGrams int // Beban mesin setara satu item pcs (item kg memakai beratnya sendiri)
+}
+
+// SLAConfig mengatur scheduler pemantau SLA pesanan (terlambat & tidak diambil).
+type SLAConfig struct {
+	CheckIntervalMin int   // Jeda antar evaluasi aturan SLA
+	OverdueGraceMin  int   // Toleransi setelah estimated_ready_at sebelum pesanan dianggap terlambat
+	UnclaimedDays    []int // Ambang umur cucian siap ambil (hari), urut naik, cth: 7,14,30
+	BatchSize        int   // Jumlah pesanan yang diproses per putaran query
+}
+
+// MediaConfig mengatur penyimpanan & validasi foto yang diunggah (bukti antar, kondisi item, foto karyawan).
+type MediaConfig struct {
+	Driver          string // local atau s3
+	LocalDir        string // Folder penyimpanan driver local
+	PublicBaseURL   string // URL publik endpoint GET /media/files (driver local)
+	SigningSecret   string // Kunci HMAC URL bertanda tangan driver local
+	SignedURLTTLMin int    // Masa berlaku URL file yang dibagikan ke klien
+	MaxUploadMB     int
+	MinDimension    int // Sisi terpendek minimal (piksel)
+	MaxDimension    int // Sisi terpanjang maksimal (piksel), mencegah decompression bomb
+	ThumbnailSize   int // Sisi terpanjang thumbnail (piksel)
+
+	S3Endpoint  string // cth: https://s3.ap-southeast-1.amazonaws.com atau http://127.0.0.1:9000 (MinIO)
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PathStyle bool // true = {endpoint}/{bucket}/{key} (MinIO), false = {bucket}.{host}/{key}
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -73,6 +169,25 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// getEnvAsIntList membaca daftar angka positif dipisah koma (unik, urut naik).
+func getEnvAsIntList(key string, defaultValue []int) []int {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	values := []int{}
+	for _, part := range strings.Split(valueStr, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || value <= 0 || slices.Contains(values, value) {
+			continue
+		}
+		values = append(values, value)
+	}
+	slices.Sort(values)
+	return values
+}
+
 func LoadConfig() *Config {
 	err := godotenv.Load()
 	if err != nil {
@@ -107,5 +222,65 @@ func LoadConfig() *Config {
 		LOG: LOGConfig{
 			Level: getEnv("LOG_LEVEL", "info"),
 		},
+		LOYALTY: LoyaltyConfig{
+			EarnAmount: getEnvAsInt("LOYALTY_EARN_AMOUNT", 10000),
+			PointValue: getEnvAsInt("LOYALTY_POINT_VALUE", 100),
+		},
+		SHOP: ShopConfig{
+			Name:            getEnv("SHOP_NAME", "VIP Laundry"),
+			Address:         getEnv("SHOP_ADDRESS", ""),
+			Phone:           getEnv("SHOP_PHONE", ""),
+			ReceiptFooter:   getEnv("RECEIPT_FOOTER", "Terima kasih atas kepercayaan Anda"),
+			TrackingBaseURL: getEnv("TRACKING_BASE_URL", "http://localhost:3000"),
+		},
+		NOTIFICATION: NotificationConfig{
+			Driver:          getEnv("NOTIFICATION_DRIVER", "log"),
+			GatewayURL:      getEnv("NOTIFICATION_GATEWAY_URL", ""),
+			GatewayToken:    getEnv("NOTIFICATION_GATEWAY_TOKEN", ""),
+			Sender:          getEnv("NOTIFICATION_SENDER", ""),
+			LogFile:         getEnv("NOTIFICATION_LOG_FILE", ""),
+			PollIntervalSec: getEnvAsInt("NOTIFICATION_POLL_INTERVAL_SECONDS", 5),
+			BatchSize:       getEnvAsInt("NOTIFICATION_BATCH_SIZE", 20),
+			MaxAttempts:     getEnvAsInt("NOTIFICATION_MAX_ATTEMPTS", 5),
+			RetryBaseSec:    getEnvAsInt("NOTIFICATION_RETRY_BASE_SECONDS", 30),
+		},
+		WEBHOOK: WebhookConfig{
+			TimeoutSec:      getEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", 10),
+			PollIntervalSec: getEnvAsInt("WEBHOOK_POLL_INTERVAL_SECONDS", 5),
+			BatchSize:       getEnvAsInt("WEBHOOK_BATCH_SIZE", 20),
+			MaxAttempts:     getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			RetryBaseSec:    getEnvAsInt("WEBHOOK_RETRY_BASE_SECONDS", 60),
+		},
+		IDEMPOTENCY: IdempotencyConfig{
+			TTLHours:           getEnvAsInt("IDEMPOTENCY_TTL_HOURS", 24),
+			LockSec:            getEnvAsInt("IDEMPOTENCY_LOCK_SECONDS", 60),
+			CleanupIntervalMin: getEnvAsInt("IDEMPOTENCY_CLEANUP_INTERVAL_MINUTES", 60),
+		},
+		CAPACITY: CapacityConfig{
+			PieceLoadGrams: getEnvAsInt("CAPACITY_PIECE_LOAD_GRAMS", 1000),
+		},
+		SLA: SLAConfig{
+			CheckIntervalMin: getEnvAsInt("SLA_CHECK_INTERVAL_MINUTES", 5),
+			OverdueGraceMin:  getEnvAsInt("SLA_OVERDUE_GRACE_MINUTES", 0),
+			UnclaimedDays:    getEnvAsIntList("SLA_UNCLAIMED_THRESHOLD_DAYS", []int{7, 14, 30}),
+			BatchSize:        getEnvAsInt("SLA_BATCH_SIZE", 100),
+		},
+		MEDIA: MediaConfig{
+			Driver:          getEnv("MEDIA_DRIVER", "local"),
+			LocalDir:        getEnv("MEDIA_LOCAL_DIR", "./storage/media"),
+			PublicBaseURL:   getEnv("MEDIA_PUBLIC_BASE_URL", "http://localhost:8080/api/v1/media/files"),
+			SigningSecret:   getEnv("MEDIA_SIGNING_SECRET", getEnv("JWT_SECRET", "")),
+			SignedURLTTLMin: getEnvAsInt("MEDIA_SIGNED_URL_TTL_MINUTES", 15),
+			MaxUploadMB:     getEnvAsInt("MEDIA_MAX_UPLOAD_MB", 10),
+			MinDimension:    getEnvAsInt("MEDIA_MIN_IMAGE_DIMENSION", 200),
+			MaxDimension:    getEnvAsInt("MEDIA_MAX_IMAGE_DIMENSION", 6000),
+			ThumbnailSize:   getEnvAsInt("MEDIA_THUMBNAIL_SIZE", 320),
+			S3Endpoint:      getEnv("MEDIA_S3_ENDPOINT", ""),
+			S3Region:        getEnv("MEDIA_S3_REGION", "us-east-1"),
+			S3Bucket:        getEnv("MEDIA_S3_BUCKET", ""),
+			S3AccessKey:     getEnv("MEDIA_S3_ACCESS_KEY", ""),
+			S3SecretKey:     getEnv("MEDIA_S3_SECRET_KEY", ""),
+			S3PathStyle:     getEnvBool("MEDIA_S3_PATH_STYLE", true),
+		},
 	}
 }
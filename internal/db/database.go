@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net/url"
 	"time"
 
 	"laundry-backend/internal/config"
@@ -12,12 +13,14 @@ import (
 )
 
 func ConnectDB(cfg *config.Config) (*sql.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&charset=utf8mb4&loc=Asia%%2FJakarta",
+	// time_zone sesi dipaku ke zona bisnis agar agregasi per hari tidak bergantung setelan server MySQL
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&charset=utf8mb4&loc=Asia%%2FJakarta&time_zone=%s",
 		cfg.DB.User,
 		cfg.DB.Password,
 		cfg.DB.Host,
 		cfg.DB.Port,
 		cfg.DB.Name,
+		url.QueryEscape("'"+config.DBTimeZone+"'"),
 	)
 
 	db, err := sql.Open("mysql", dsn)
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// CreateAddonRequest digunakan saat Owner menambah add-on baru (POST /addons)
+type CreateAddonRequest struct {
+	Code             string       `json:"code" binding:"required"`
+	AddonName        string       `json:"addon_name" binding:"required"`
+	ChargeType       string       `json:"charge_type" binding:"required,oneof=percentage fixed"`
+	ChargeValue      money.Amount `json:"charge_value" binding:"min=0"`
+	ChargeBasis      string       `json:"charge_basis" binding:"omitempty,oneof=per_unit per_order"`
+	MaxDurationHours *int         `json:"max_duration_hours" binding:"omitempty,min=1"`
+	ServiceIDs       []int64      `json:"service_ids" binding:"required,min=1,dive,gt=0"`
+}
+
+// UpdateAddonRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+// Jika service_ids dikirim, seluruh daftar layanan terhubung akan diganti.
+type UpdateAddonRequest struct {
+	Code             *string       `json:"code"`
+	AddonName        *string       `json:"addon_name"`
+	ChargeType       *string       `json:"charge_type" binding:"omitempty,oneof=percentage fixed"`
+	ChargeValue      *money.Amount `json:"charge_value" binding:"omitempty,min=0"`
+	ChargeBasis      *string       `json:"charge_basis" binding:"omitempty,oneof=per_unit per_order"`
+	MaxDurationHours *int          `json:"max_duration_hours" binding:"omitempty,min=1"`
+	ServiceIDs       []int64       `json:"service_ids" binding:"omitempty,min=1,dive,gt=0"`
+	IsActive         *bool         `json:"is_active"`
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// AddonSummaryResponse untuk endpoint List (GET /addons)
+type AddonSummaryResponse struct {
+	ID               int64        `json:"id"`
+	Code             string       `json:"code"`
+	AddonName        string       `json:"addon_name"`
+	ChargeType       string       `json:"charge_type"`
+	ChargeValue      money.Amount `json:"charge_value"`
+	ChargeBasis      string       `json:"charge_basis"`
+	MaxDurationHours *int         `json:"max_duration_hours"`
+	IsActive         bool         `json:"is_active"`
+}
+
+// AddonDetailResponse untuk endpoint Detail (GET /addons/:id)
+type AddonDetailResponse struct {
+	ID               int64        `json:"id"`
+	Code             string       `json:"code"`
+	AddonName        string       `json:"addon_name"`
+	ChargeType       string       `json:"charge_type"`
+	ChargeValue      money.Amount `json:"charge_value"`
+	ChargeBasis      string       `json:"charge_basis"`
+	MaxDurationHours *int         `json:"max_duration_hours"`
+	IsActive         bool         `json:"is_active"`
+	ServiceIDs       []int64      `json:"service_ids"`
+	CreatedAt        string       `json:"created_at"`
+	UpdatedAt        *string      `json:"updated_at"`
+}
+
+// AddonListResponse untuk balasan GET List lengkap dengan Pagination
+type AddonListResponse struct {
+	Data []AddonSummaryResponse `json:"data"`
+	Meta response.MetaData      `json:"meta"`
+}
+
+// AddonChargeResponse adalah biaya satu add-on pada simulasi harga
+type AddonChargeResponse struct {
+	AddonID     int64        `json:"addon_id"`
+	AddonName   string       `json:"addon_name"`
+	ChargeType  string       `json:"charge_type"`
+	ChargeValue money.Amount `json:"charge_value"`
+	ChargeBasis string       `json:"charge_basis"`
+	Amount      money.Amount `json:"amount"`
+}
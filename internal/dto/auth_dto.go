@@ -27,11 +27,13 @@ type AuthUserSummary struct {
 	FullName string `json:"full_name"` // Added: Frontend usually needs this for display
 	Username string `json:"username"`
 	Role     string `json:"role"`
+	OutletID *int64 `json:"outlet_id"` // Active outlet carried by the token (null = all outlets, owner only)
 }
 
 // RefreshTokenRequest defines the payload for requesting a new access token.
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
+	OutletID     *int64 `json:"outlet_id" binding:"omitempty,min=0"` // Owner only: keep the active outlet (0 = all outlets). Ignored for other roles.
 }
 
 // RefreshTokenResponse returns a new access token.
@@ -40,6 +42,12 @@ type RefreshTokenResponse struct {
 	TokenType   string `json:"token_type"`
 	AccessToken string `json:"access_token"`
 	ExpiresIn   int    `json:"expires_in"`
+	OutletID    *int64 `json:"outlet_id"` // Active outlet carried by the new token (null = all outlets)
+}
+
+// SwitchOutletRequest defines the payload for POST /auth/switch-outlet (owner only).
+type SwitchOutletRequest struct {
+	OutletID *int64 `json:"outlet_id" binding:"required,min=0"` // 0 = all outlets (consolidated)
 }
 
 // AuthMeResponse defines the user profile structure for the /auth/me endpoint.
@@ -49,6 +57,7 @@ type AuthMeResponse struct {
 	Username    string `json:"username"`
 	Email       string `json:"email"` // Added: Usually profile needs email
 	Role        string `json:"role"`
+	OutletID    *int64 `json:"outlet_id"`    // Home outlet (null for owners not bound to an outlet)
 	PhoneNumber string `json:"phone_number"` // Added: Complete profile info
 	IsActive    bool   `json:"is_active"`    // Changed: int -> bool (Consistency with User Module)
 	CreatedAt   string `json:"created_at"`
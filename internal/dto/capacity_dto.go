@@ -0,0 +1,125 @@
+package dto
+
+// ==========================================
+// 1. REQUEST DTO (Input from Client)
+// ==========================================
+
+// CreateMachineRequest untuk endpoint POST /machines (mesin dibuat di outlet aktif)
+type CreateMachineRequest struct {
+	MachineName  string  `json:"machine_name" binding:"required,min=2,max=100"`
+	MachineType  string  `json:"machine_type" binding:"required,oneof=washer dryer"`
+	CapacityKg   float64 `json:"capacity_kg" binding:"required,gt=0,max=1000"`
+	CycleMinutes int     `json:"cycle_minutes" binding:"required,min=1,max=1440"`
+}
+
+// UpdateMachineRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+type UpdateMachineRequest struct {
+	MachineName  *string  `json:"machine_name" binding:"omitempty,min=2,max=100"`
+	MachineType  *string  `json:"machine_type" binding:"omitempty,oneof=washer dryer"`
+	CapacityKg   *float64 `json:"capacity_kg" binding:"omitempty,gt=0,max=1000"`
+	CycleMinutes *int     `json:"cycle_minutes" binding:"omitempty,min=1,max=1440"`
+	IsActive     *bool    `json:"is_active"`
+}
+
+// UpdateMachineMaintenanceRequest untuk endpoint PATCH /machines/:id/maintenance
+type UpdateMachineMaintenanceRequest struct {
+	UnderMaintenance *bool   `json:"under_maintenance" binding:"required"`
+	Note             *string `json:"note" binding:"omitempty,max=255"` // Alasan, cth: "Bearing bunyi"
+}
+
+// OperatingHourRequest adalah jam buka satu hari (weekday 0 = Minggu ... 6 = Sabtu)
+type OperatingHourRequest struct {
+	Weekday   *int   `json:"weekday" binding:"required,min=0,max=6"`
+	OpenTime  string `json:"open_time" binding:"omitempty,datetime=15:04"`
+	CloseTime string `json:"close_time" binding:"omitempty,datetime=15:04"`
+	IsClosed  bool   `json:"is_closed"` // true = tutup seharian, open_time & close_time diabaikan
+}
+
+// ReplaceOperatingHoursRequest untuk endpoint PUT /capacity/operating-hours.
+// Kirim ketujuh hari sekaligus, atau list kosong agar outlet kembali dianggap buka 24 jam.
+type ReplaceOperatingHoursRequest struct {
+	Days []OperatingHourRequest `json:"days" binding:"omitempty,dive"`
+}
+
+// CreateHolidayRequest untuk endpoint POST /capacity/holidays
+type CreateHolidayRequest struct {
+	HolidayDate string `json:"holiday_date" binding:"required,datetime=2006-01-02"`
+	Description string `json:"description" binding:"required,min=3,max=150"`
+	AllOutlets  bool   `json:"all_outlets"` // true = libur nasional (semua outlet), false = hanya outlet aktif
+}
+
+// ==========================================
+// 2. RESPONSE DTO (Output to Client)
+// ==========================================
+
+// MachineResponse adalah data satu mesin
+type MachineResponse struct {
+	ID           int64   `json:"id"`
+	OutletID     int64   `json:"outlet_id"`
+	MachineName  string  `json:"machine_name"`
+	MachineType  string  `json:"machine_type"`
+	CapacityKg   float64 `json:"capacity_kg"`
+	CycleMinutes int     `json:"cycle_minutes"`
+	KgPerHour    float64 `json:"kg_per_hour"`
+	IsActive     bool    `json:"is_active"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    *string `json:"updated_at"`
+
+	UnderMaintenance bool    `json:"under_maintenance"`
+	MaintenanceNote  *string `json:"maintenance_note"`
+	MaintenanceSince *string `json:"maintenance_since"`
+}
+
+// OperatingHourResponse adalah jam buka satu hari
+type OperatingHourResponse struct {
+	Weekday   int     `json:"weekday"`
+	DayName   string  `json:"day_name"`
+	OpenTime  *string `json:"open_time"`  // null jika tutup
+	CloseTime *string `json:"close_time"` // null jika tutup
+	IsClosed  bool    `json:"is_closed"`
+}
+
+// OperatingHoursResponse adalah jadwal mingguan satu outlet
+type OperatingHoursResponse struct {
+	OutletID   int64                   `json:"outlet_id"`
+	AlwaysOpen bool                    `json:"always_open"` // true = belum diatur, dianggap buka 24 jam
+	Days       []OperatingHourResponse `json:"days"`
+}
+
+// HolidayResponse adalah data satu hari libur
+type HolidayResponse struct {
+	ID          int64  `json:"id"`
+	OutletID    *int64 `json:"outlet_id"` // null = semua outlet
+	HolidayDate string `json:"holiday_date"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// WorkloadResponse untuk endpoint GET /capacity/workload (antrean & kapasitas outlet aktif)
+type WorkloadResponse struct {
+	OutletID            int64   `json:"outlet_id"`
+	QueuedOrders        int64   `json:"queued_orders"`
+	BacklogKg           float64 `json:"backlog_kg"`
+	ThroughputKgPerHour float64 `json:"throughput_kg_per_hour"`
+	BacklogHours        float64 `json:"backlog_hours"`
+	BacklogClearsAt     *string `json:"backlog_clears_at"` // null jika mesin belum diatur
+}
+
+// ReadyEstimateResponse adalah rincian estimasi selesai satu pesanan
+type ReadyEstimateResponse struct {
+	DurationHours       int     `json:"duration_hours"`
+	LoadKg              float64 `json:"load_kg"`
+	QueuedOrders        int64   `json:"queued_orders"`
+	BacklogKg           float64 `json:"backlog_kg"`
+	ThroughputKgPerHour float64 `json:"throughput_kg_per_hour"` // 0 = mesin belum diatur
+	WorkHours           float64 `json:"work_hours"`
+	MinimumReadyAt      string  `json:"minimum_ready_at"`   // created_at + duration_hours (perhitungan lama)
+	ProcessingDoneAt    *string `json:"processing_done_at"` // null jika mesin belum diatur
+	EstimatedReadyAt    string  `json:"estimated_ready_at"`
+}
+
+// OrderQuoteResponse untuk endpoint POST /orders/quote
+type OrderQuoteResponse struct {
+	Totals   OrderTotalsResponse   `json:"totals"`
+	Estimate ReadyEstimateResponse `json:"estimate"`
+}
@@ -9,7 +9,7 @@ import "laundry-backend/pkg/response"
 // CreateCategoryRequest untuk payload POST /categories
 type CreateCategoryRequest struct {
 	CategoryName string  `json:"category_name" binding:"required,min=3,max=150"`
-	Description  *string `json:"description" binding:"max=255"`
+	Description  *string `json:"description" binding:"omitempty,max=255"`
 }
 
 // UpdateCategoryRequest untuk payload PUT /categories/:id
@@ -40,6 +40,7 @@ type CategoryDetailResponse struct {
 	CategoryName string  `json:"category_name"`
 	Description  *string `json:"description"`
 	IsActive     bool    `json:"is_active"`
+	Version      int64   `json:"version"` // Sama dengan header ETag
 	CreatedAt    string  `json:"created_at"`
 	UpdatedAt    *string `json:"updated_at"`
 }
@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// ==========================================
+// 1. REQUEST DTO (Input from Client)
+// ==========================================
+
+// ComplaintPhotoRequest adalah satu foto bukti (referensi URL / path, bukan file-nya)
+type ComplaintPhotoRequest struct {
+	PhotoURL string  `json:"photo_url" binding:"required,max=500"`
+	Caption  *string `json:"caption" binding:"omitempty,max=255"`
+}
+
+// CreateComplaintRequest untuk endpoint POST /complaints (outlet mengikuti outlet pesanan)
+type CreateComplaintRequest struct {
+	OrderID       int64                   `json:"order_id" binding:"required,min=1"`
+	OrderItemID   *int64                  `json:"order_item_id" binding:"omitempty,min=1"` // Kosong = komplain seluruh pesanan
+	ComplaintType string                  `json:"complaint_type" binding:"required,oneof=damage missing rework other"`
+	Description   string                  `json:"description" binding:"required,min=3,max=2000"`
+	Photos        []ComplaintPhotoRequest `json:"photos" binding:"omitempty,max=10,dive"`
+}
+
+// AddComplaintPhotosRequest untuk endpoint POST /complaints/:id/photos
+type AddComplaintPhotosRequest struct {
+	Photos []ComplaintPhotoRequest `json:"photos" binding:"required,min=1,max=10,dive"`
+}
+
+// UpdateComplaintStatusRequest untuk endpoint PATCH /complaints/:id/status (penyelesaian lewat /resolve)
+type UpdateComplaintStatusRequest struct {
+	Status string  `json:"status" binding:"required,oneof=investigating rejected"`
+	Notes  *string `json:"notes" binding:"omitempty,max=1000"`
+}
+
+// ResolveComplaintRequest untuk endpoint POST /complaints/:id/resolve.
+// Field yang dipakai bergantung pada resolution_type:
+//   - rework       : order_item_ids (opsional), estimated_ready_at (opsional)
+//   - compensation : amount & compensation_method (wajib)
+//   - voucher      : amount (wajib), voucher_valid_days (opsional)
+type ResolveComplaintRequest struct {
+	ResolutionType     string        `json:"resolution_type" binding:"required,oneof=rework compensation voucher"`
+	Notes              *string       `json:"notes" binding:"omitempty,max=1000"`
+	OrderItemIDs       []int64       `json:"order_item_ids" binding:"omitempty,dive,gt=0"`
+	EstimatedReadyAt   *string       `json:"estimated_ready_at"` // Format: YYYY-MM-DD HH:MM:SS
+	Amount             *money.Amount `json:"amount" binding:"omitempty,gt=0"`
+	CompensationMethod string        `json:"compensation_method" binding:"omitempty,oneof=cash transfer deposit"`
+	VoucherValidDays   int           `json:"voucher_valid_days" binding:"omitempty,min=1,max=365"`
+}
+
+// ==========================================
+// 2. RESPONSE DTO (Output to Client)
+// ==========================================
+
+// ComplaintPhotoResponse adalah satu foto bukti komplain
+type ComplaintPhotoResponse struct {
+	ID         int64   `json:"id"`
+	PhotoURL   string  `json:"photo_url"`
+	Caption    *string `json:"caption"`
+	UploadedBy int64   `json:"uploaded_by"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+// ComplaintHistoryResponse adalah satu baris riwayat status komplain
+type ComplaintHistoryResponse struct {
+	PreviousStatus *string `json:"previous_status"`
+	NewStatus      string  `json:"new_status"`
+	ActorID        *int64  `json:"actor_id"`
+	ActorName      *string `json:"actor_name"`
+	Notes          *string `json:"notes"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// ComplaintResolutionResponse adalah hasil penyelesaian komplain (null selama belum resolved)
+type ComplaintResolutionResponse struct {
+	ResolutionType      string        `json:"resolution_type"`
+	Notes               *string       `json:"notes"`
+	ReworkOrderID       *int64        `json:"rework_order_id"`
+	ReworkInvoiceNumber *string       `json:"rework_invoice_number"`
+	CompensationAmount  *money.Amount `json:"compensation_amount"`
+	CompensationMethod  *string       `json:"compensation_method"`
+	ExpenseID           *int64        `json:"expense_id"`
+	WalletEntryID       *int64        `json:"wallet_entry_id"`
+	VoucherPromotionID  *int64        `json:"voucher_promotion_id"`
+	VoucherCode         *string       `json:"voucher_code"`
+	ResolvedBy          *int64        `json:"resolved_by"`
+	ResolvedByName      *string       `json:"resolved_by_name"`
+	ResolvedAt          *string       `json:"resolved_at"`
+}
+
+// ComplaintResponse adalah data satu komplain (photos & history hanya terisi di detail)
+type ComplaintResponse struct {
+	ID              int64                        `json:"id"`
+	OutletID        int64                        `json:"outlet_id"`
+	OrderID         int64                        `json:"order_id"`
+	InvoiceNumber   string                       `json:"invoice_number"`
+	CustomerID      *int64                       `json:"customer_id"`
+	CustomerName    *string                      `json:"customer_name"`
+	OrderItemID     *int64                       `json:"order_item_id"`
+	ServiceName     *string                      `json:"service_name"`
+	ComplaintType   string                       `json:"complaint_type"`
+	Description     string                       `json:"description"`
+	ComplaintStatus string                       `json:"complaint_status"`
+	Resolution      *ComplaintResolutionResponse `json:"resolution"`
+	PhotoCount      int                          `json:"photo_count"`
+	ReportedBy      int64                        `json:"reported_by"`
+	ReportedByName  string                       `json:"reported_by_name"`
+	CreatedAt       string                       `json:"created_at"`
+	UpdatedAt       *string                      `json:"updated_at"`
+	Photos          []ComplaintPhotoResponse     `json:"photos,omitempty"`
+	History         []ComplaintHistoryResponse   `json:"history,omitempty"`
+}
+
+// ComplaintListResponse untuk balasan daftar komplain lengkap dengan Pagination
+type ComplaintListResponse struct {
+	Data []ComplaintResponse `json:"data"`
+	Meta response.MetaData   `json:"meta"`
+}
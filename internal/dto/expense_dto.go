@@ -0,0 +1,114 @@
+package dto
+
+import (
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// ==========================================
+// 1. REQUEST DTO (Input from Client)
+// ==========================================
+
+// CreateExpenseCategoryRequest untuk endpoint POST /expense-categories
+type CreateExpenseCategoryRequest struct {
+	CategoryName string  `json:"category_name" binding:"required,min=3,max=100"`
+	Description  *string `json:"description" binding:"omitempty,max=255"`
+}
+
+// UpdateExpenseCategoryRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+type UpdateExpenseCategoryRequest struct {
+	CategoryName *string `json:"category_name" binding:"omitempty,min=3,max=100"`
+	Description  *string `json:"description" binding:"omitempty,max=255"`
+	IsActive     *bool   `json:"is_active"`
+}
+
+// CreateExpenseRequest untuk endpoint POST /expenses (outlet diambil dari outlet aktif token)
+type CreateExpenseRequest struct {
+	ExpenseCategoryID int64        `json:"expense_category_id" binding:"required,min=1"`
+	Amount            money.Amount `json:"amount" binding:"required,gt=0"`
+	ExpenseDate       string       `json:"expense_date" binding:"required,datetime=2006-01-02"`
+	Description       *string      `json:"description" binding:"omitempty,max=255"`
+	AttachmentRef     *string      `json:"attachment_ref" binding:"omitempty,max=255"` // Nomor nota / URL bukti
+}
+
+// UpdateExpenseRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+type UpdateExpenseRequest struct {
+	ExpenseCategoryID *int64        `json:"expense_category_id" binding:"omitempty,min=1"`
+	Amount            *money.Amount `json:"amount" binding:"omitempty,gt=0"`
+	ExpenseDate       *string       `json:"expense_date" binding:"omitempty,datetime=2006-01-02"`
+	Description       *string       `json:"description" binding:"omitempty,max=255"`
+	AttachmentRef     *string       `json:"attachment_ref" binding:"omitempty,max=255"`
+}
+
+// ==========================================
+// 2. RESPONSE DTO (Output to Client)
+// ==========================================
+
+// ExpenseCategoryResponse adalah data satu kategori biaya
+type ExpenseCategoryResponse struct {
+	ID           int64   `json:"id"`
+	CategoryName string  `json:"category_name"`
+	Description  *string `json:"description"`
+	IsActive     bool    `json:"is_active"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    *string `json:"updated_at"`
+}
+
+// ExpenseResponse adalah data satu biaya operasional
+type ExpenseResponse struct {
+	ID                int64        `json:"id"`
+	OutletID          int64        `json:"outlet_id"`
+	ExpenseCategoryID int64        `json:"expense_category_id"`
+	CategoryName      string       `json:"category_name"`
+	Amount            money.Amount `json:"amount"`
+	ExpenseDate       string       `json:"expense_date"`
+	Description       *string      `json:"description"`
+	AttachmentRef     *string      `json:"attachment_ref"`
+	CreatedBy         int64        `json:"created_by"`
+	UpdatedBy         *int64       `json:"updated_by"`
+	CreatedAt         string       `json:"created_at"`
+	UpdatedAt         *string      `json:"updated_at"`
+}
+
+// ExpenseListResponse acts as a container for the Service layer to return data + pagination.
+type ExpenseListResponse struct {
+	Data []ExpenseResponse `json:"data"`
+	Meta response.MetaData `json:"meta"`
+}
+
+// ProfitReportPeriod adalah rentang tanggal laporan laba
+type ProfitReportPeriod struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// ProfitReportRowResponse adalah laba satu periode (hari / minggu Senin-Minggu / bulan)
+type ProfitReportRowResponse struct {
+	PeriodStart string       `json:"period_start"`
+	PeriodEnd   string       `json:"period_end"`
+	PaidOrders  int64        `json:"paid_orders"`
+	Revenue     money.Amount `json:"revenue"`
+	Expenses    money.Amount `json:"expenses"`
+	Profit      money.Amount `json:"profit"`
+}
+
+// ProfitExpenseCategoryResponse adalah total biaya satu kategori dalam laporan laba
+type ProfitExpenseCategoryResponse struct {
+	ExpenseCategoryID int64        `json:"expense_category_id"`
+	CategoryName      string       `json:"category_name"`
+	Amount            money.Amount `json:"amount"`
+}
+
+// ProfitReportResponse untuk endpoint laporan laba (GET /reports/profit).
+// revenue = pendapatan bersih nota lunas (grand_total - tax_total), profit = revenue - expenses.
+type ProfitReportResponse struct {
+	Period           ProfitReportPeriod              `json:"period"`
+	GroupBy          string                          `json:"group_by"`
+	OutletID         *int64                          `json:"outlet_id"` // null = konsolidasi semua outlet
+	PaidOrders       int64                           `json:"paid_orders"`
+	Revenue          money.Amount                    `json:"revenue"`
+	Expenses         money.Amount                    `json:"expenses"`
+	Profit           money.Amount                    `json:"profit"`
+	ExpenseBreakdown []ProfitExpenseCategoryResponse `json:"expense_breakdown"`
+	Periods          []ProfitReportRowResponse       `json:"periods"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// ImportQuery adalah parameter POST /imports/{entity}?dry_run=&on_error=
+type ImportQuery struct {
+	DryRun  bool   `form:"dry_run"`                                       // true = hanya validasi, tidak ada yang ditulis
+	OnError string `form:"on_error" binding:"omitempty,oneof=abort skip"` // Default abort: satu baris gagal = tidak ada yang ditulis
+}
+
+// ImportCustomerRequest adalah satu baris CSV import pelanggan.
+// Endpoint pembuatan pelanggan satuan belum ada, jadi aturannya didefinisikan di sini mengikuti kolom tabel customers.
+type ImportCustomerRequest struct {
+	FullName    string  `json:"full_name" binding:"required,min=3,max=150"`
+	PhoneNumber string  `json:"phone_number" binding:"required,min=8,max=30"`
+	Address     *string `json:"address" binding:"omitempty,max=255"`
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// ImportRowResult adalah hasil validasi/penulisan satu baris file
+type ImportRowResult struct {
+	Line   int      `json:"line"`             // Nomor baris di file (header = 1)
+	Key    string   `json:"key"`              // Kode layanan / nama kategori / nomor HP, untuk memudahkan pencarian di file
+	Status string   `json:"status"`           // valid, invalid, created, skipped
+	ID     *int64   `json:"id,omitempty"`     // ID baru (hanya status created)
+	Errors []string `json:"errors,omitempty"` // Alasan gagal validasi
+}
+
+// ImportReport adalah laporan per baris untuk POST /imports/{entity}
+type ImportReport struct {
+	Entity      string            `json:"entity"`
+	DryRun      bool              `json:"dry_run"`
+	OnError     string            `json:"on_error"`
+	Committed   bool              `json:"committed"` // true jika transaksi sudah di-commit
+	TotalRows   int               `json:"total_rows"`
+	ValidRows   int               `json:"valid_rows"`
+	InvalidRows int               `json:"invalid_rows"`
+	CreatedRows int               `json:"created_rows"`
+	Rows        []ImportRowResult `json:"rows"`
+}
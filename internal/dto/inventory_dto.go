@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"laundry-backend/pkg/quantity"
+	"laundry-backend/pkg/response"
+)
+
+// ==========================================
+// 1. REQUEST DTO (Input from Client)
+// ==========================================
+
+// CreateInventoryItemRequest untuk endpoint POST /inventory/items
+type CreateInventoryItemRequest struct {
+	ItemName string            `json:"item_name" binding:"required,min=3,max=100"`
+	Unit     string            `json:"unit" binding:"required,min=1,max=20"` // cth: ml, gram, pcs
+	MinStock quantity.Quantity `json:"min_stock" binding:"min=0"`
+}
+
+// UpdateInventoryItemRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+type UpdateInventoryItemRequest struct {
+	ItemName *string            `json:"item_name" binding:"omitempty,min=3,max=100"`
+	Unit     *string            `json:"unit" binding:"omitempty,min=1,max=20"`
+	MinStock *quantity.Quantity `json:"min_stock" binding:"omitempty,min=0"`
+	IsActive *bool              `json:"is_active"`
+}
+
+// StockInRequest untuk endpoint POST /inventory/items/:id/stock-in (barang masuk ke outlet aktif)
+type StockInRequest struct {
+	Quantity quantity.Quantity `json:"quantity" binding:"required,gt=0"`
+	Reason   *string           `json:"reason" binding:"omitempty,max=255"` // cth: nomor nota pembelian
+}
+
+// StockAdjustmentRequest untuk endpoint POST /inventory/items/:id/adjustments.
+// QuantityChange bertanda: positif menambah, negatif mengurangi stok outlet aktif.
+type StockAdjustmentRequest struct {
+	QuantityChange quantity.Quantity `json:"quantity_change" binding:"required,ne=0"`
+	Reason         string            `json:"reason" binding:"required,min=3,max=255"`
+}
+
+// ServiceConsumptionRequest adalah satu baris resep pemakaian bahan
+type ServiceConsumptionRequest struct {
+	InventoryItemID int64             `json:"inventory_item_id" binding:"required,min=1"`
+	QuantityPerUnit quantity.Quantity `json:"quantity_per_unit" binding:"required,gt=0"` // Per kg / per pcs sesuai unit layanan
+}
+
+// ReplaceServiceConsumptionsRequest untuk endpoint PUT /inventory/recipes/:service_id (mengganti seluruh resep)
+type ReplaceServiceConsumptionsRequest struct {
+	Items []ServiceConsumptionRequest `json:"items" binding:"omitempty,dive"`
+}
+
+// ==========================================
+// 2. RESPONSE DTO (Output to Client)
+// ==========================================
+
+// InventoryItemResponse adalah data satu bahan beserta stok outlet aktif (konsolidasi: total semua outlet)
+type InventoryItemResponse struct {
+	ID        int64             `json:"id"`
+	ItemName  string            `json:"item_name"`
+	Unit      string            `json:"unit"`
+	MinStock  quantity.Quantity `json:"min_stock"`
+	Stock     quantity.Quantity `json:"stock"`
+	IsLow     bool              `json:"is_low"`
+	IsActive  bool              `json:"is_active"`
+	CreatedAt string            `json:"created_at"`
+	UpdatedAt *string           `json:"updated_at"`
+}
+
+// LowStockResponse adalah satu bahan yang stoknya menipis di satu outlet
+type LowStockResponse struct {
+	OutletID   int64             `json:"outlet_id"`
+	OutletName string            `json:"outlet_name"`
+	ItemID     int64             `json:"inventory_item_id"`
+	ItemName   string            `json:"item_name"`
+	Unit       string            `json:"unit"`
+	Stock      quantity.Quantity `json:"stock"`
+	MinStock   quantity.Quantity `json:"min_stock"`
+	Shortfall  quantity.Quantity `json:"shortfall"` // min_stock - stock
+}
+
+// InventoryMovementResponse adalah satu baris ledger mutasi stok
+type InventoryMovementResponse struct {
+	ID              int64             `json:"id"`
+	OutletID        int64             `json:"outlet_id"`
+	InventoryItemID int64             `json:"inventory_item_id"`
+	ItemName        string            `json:"item_name"`
+	Unit            string            `json:"unit"`
+	MovementType    string            `json:"movement_type"`
+	Direction       string            `json:"direction"`
+	Quantity        quantity.Quantity `json:"quantity"`
+	BalanceBefore   quantity.Quantity `json:"balance_before"`
+	BalanceAfter    quantity.Quantity `json:"balance_after"`
+	OrderID         *int64            `json:"order_id"`
+	Reason          string            `json:"reason"`
+	ActorID         *int64            `json:"actor_id"`
+	CreatedAt       string            `json:"created_at"`
+}
+
+// InventoryMovementListResponse acts as a container for the Service layer to return data + pagination.
+type InventoryMovementListResponse struct {
+	Data []InventoryMovementResponse `json:"data"`
+	Meta response.MetaData           `json:"meta"`
+}
+
+// ServiceConsumptionResponse adalah satu baris resep pemakaian bahan
+type ServiceConsumptionResponse struct {
+	InventoryItemID int64             `json:"inventory_item_id"`
+	ItemName        string            `json:"item_name"`
+	Unit            string            `json:"unit"`
+	QuantityPerUnit quantity.Quantity `json:"quantity_per_unit"`
+}
+
+// ServiceRecipeResponse adalah resep lengkap satu layanan
+type ServiceRecipeResponse struct {
+	ServiceID   int64                        `json:"service_id"`
+	ServiceName string                       `json:"service_name"`
+	ServiceUnit string                       `json:"service_unit"` // Resep dihitung per kg atau per pcs
+	Items       []ServiceConsumptionResponse `json:"items"`
+}
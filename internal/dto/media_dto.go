@@ -0,0 +1,48 @@
+package dto
+
+// ==========================================
+// 1. REQUEST DTO (Input from Client)
+// ==========================================
+
+// UploadMediaRequest adalah field form multipart POST /media (file dikirim di field "file")
+type UploadMediaRequest struct {
+	OwnerType string  `form:"owner_type" binding:"required,oneof=delivery order_item user"`
+	OwnerID   int64   `form:"owner_id" binding:"required,min=1"`
+	Caption   *string `form:"caption" binding:"omitempty,max=255"`
+}
+
+// MediaOwnerQuery adalah query GET /media (daftar foto satu pemilik)
+type MediaOwnerQuery struct {
+	OwnerType string `form:"owner_type" binding:"required,oneof=delivery order_item user"`
+	OwnerID   int64  `form:"owner_id" binding:"required,min=1"`
+}
+
+// SignedFileQuery adalah query URL bertanda tangan GET /media/files/{key} (driver local)
+type SignedFileQuery struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required,len=64"`
+}
+
+// ==========================================
+// 2. RESPONSE DTO (Output to Client)
+// ==========================================
+
+// MediaResponse adalah metadata satu foto beserta URL baca yang kedaluwarsa
+type MediaResponse struct {
+	ID             int64   `json:"id"`
+	OwnerType      string  `json:"owner_type"`
+	OwnerID        int64   `json:"owner_id"`
+	OutletID       *int64  `json:"outlet_id"`
+	ContentType    string  `json:"content_type"`
+	SizeBytes      int64   `json:"size_bytes"`
+	Width          int     `json:"width"`
+	Height         int     `json:"height"`
+	ChecksumSHA256 string  `json:"checksum_sha256"`
+	Caption        *string `json:"caption"`
+	URL            string  `json:"url"`           // URL bertanda tangan file asli
+	ThumbnailURL   string  `json:"thumbnail_url"` // URL bertanda tangan thumbnail JPEG
+	URLExpiresAt   string  `json:"url_expires_at"`
+	UploadedBy     int64   `json:"uploaded_by"`
+	UploadedByName string  `json:"uploaded_by_name"`
+	CreatedAt      string  `json:"created_at"`
+}
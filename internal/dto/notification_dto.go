@@ -0,0 +1,65 @@
+package dto
+
+import "laundry-backend/pkg/response"
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// UpdateNotificationTemplateRequest digunakan Owner untuk mengubah template pesan (PUT /notification-templates/:event)
+type UpdateNotificationTemplateRequest struct {
+	Body     *string `json:"body" binding:"omitempty,min=1"`
+	IsActive *bool   `json:"is_active"`
+}
+
+// PreviewNotificationTemplateRequest digunakan untuk mencoba template dengan data contoh sebelum disimpan
+type PreviewNotificationTemplateRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// NotificationOutboxQuery adalah filter daftar antrean pesan (GET /notifications)
+type NotificationOutboxQuery struct {
+	Status  string `form:"status" binding:"omitempty,oneof=pending processing sent failed skipped"`
+	OrderID int64  `form:"order_id" binding:"omitempty,gt=0"`
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// NotificationTemplateResponse untuk endpoint List & Update template
+type NotificationTemplateResponse struct {
+	EventType string  `json:"event_type"`
+	Body      string  `json:"body"`
+	IsActive  bool    `json:"is_active"`
+	UpdatedBy *int64  `json:"updated_by"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt *string `json:"updated_at"`
+}
+
+// NotificationPreviewResponse adalah hasil render template dengan data contoh
+type NotificationPreviewResponse struct {
+	Message string `json:"message"`
+}
+
+// OutboxMessageResponse adalah satu baris antrean pesan keluar
+type OutboxMessageResponse struct {
+	ID            int64   `json:"id"`
+	EventType     string  `json:"event_type"`
+	OrderID       int64   `json:"order_id"`
+	Recipient     string  `json:"recipient"`
+	Status        string  `json:"status"`
+	Channel       *string `json:"channel"`
+	Message       *string `json:"message"`
+	Attempts      int     `json:"attempts"`
+	NextAttemptAt string  `json:"next_attempt_at"`
+	LastError     *string `json:"last_error"`
+	SentAt        *string `json:"sent_at"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+// OutboxListResponse untuk balasan daftar antrean pesan lengkap dengan Pagination
+type OutboxListResponse struct {
+	Data []OutboxMessageResponse `json:"data"`
+	Meta response.MetaData       `json:"meta"`
+}
@@ -0,0 +1,201 @@
+package dto
+
+import (
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// ==========================================
+// 1. REQUEST DTO (Input from Client)
+// ==========================================
+
+// CreateOrderItemRequest adalah satu item cucian pada POST /orders.
+// Layanan kiloan wajib mengirim weight_kg, layanan satuan wajib mengirim quantity.
+type CreateOrderItemRequest struct {
+	ServiceID int64    `json:"service_id" binding:"required,gt=0"`
+	Quantity  *int     `json:"quantity" binding:"omitempty,gt=0"`
+	WeightKg  *float64 `json:"weight_kg" binding:"omitempty,gt=0"`
+	QtyPieces *int     `json:"qty_pieces" binding:"omitempty,gte=0"`
+	ItemNotes *string  `json:"item_notes" binding:"omitempty,max=255"`
+	AddonIDs  []int64  `json:"addon_ids"`
+}
+
+// CreateOrderDeliveryRequest berisi data pengantaran (wajib jika is_delivery = 1)
+type CreateOrderDeliveryRequest struct {
+	ShippingCost money.Amount `json:"shipping_cost"`
+}
+
+// CreateOrderPaymentRequest berisi pembayaran di muka saat pesanan dibuat (opsional)
+type CreateOrderPaymentRequest struct {
+	Method         *string      `json:"method" binding:"omitempty,oneof=cash transfer qris ewallet deposit"`
+	AmountReceived money.Amount `json:"amount_received"`
+	ReferenceNo    *string      `json:"reference_no" binding:"omitempty,max=100"`
+}
+
+// CreateOrderRequest untuk endpoint POST /orders
+type CreateOrderRequest struct {
+	CustomerID      *int64                      `json:"customer_id" binding:"omitempty,gt=0"`
+	CustomerName    *string                     `json:"customer_name" binding:"omitempty,min=2,max=150"`
+	CustomerPhone   *string                     `json:"customer_phone" binding:"omitempty,min=6,max=30"`
+	CustomerAddress *string                     `json:"customer_address"`
+	IsDelivery      int                         `json:"is_delivery" binding:"omitempty,oneof=0 1"`
+	Notes           *string                     `json:"notes"`
+	VoucherCode     *string                     `json:"voucher_code" binding:"omitempty,max=50"`
+	RedeemPoints    int                         `json:"redeem_points" binding:"omitempty,gte=0"`
+	Deliveries      *CreateOrderDeliveryRequest `json:"deliveries"`
+	OrderItems      []CreateOrderItemRequest    `json:"order_items" binding:"required,min=1,dive"`
+	Payment         *CreateOrderPaymentRequest  `json:"payment"`
+}
+
+// SettlePaymentRequest untuk endpoint PATCH /payments/{id} (pelunasan tagihan 'pending')
+type SettlePaymentRequest struct {
+	Method         string       `json:"method" binding:"required,oneof=cash transfer qris ewallet deposit"`
+	AmountReceived money.Amount `json:"amount_received"`
+	ReferenceNo    *string      `json:"reference_no" binding:"omitempty,max=100"`
+}
+
+// ==========================================
+// 2. RESPONSE DTO (Output to Client)
+// ==========================================
+
+// OrderCustomerResponse adalah data pelanggan pada nota (snapshot saat pesanan dibuat)
+type OrderCustomerResponse struct {
+	ID      *int64  `json:"id"`
+	Name    *string `json:"name"`
+	Phone   *string `json:"phone"`
+	Address *string `json:"address"`
+}
+
+// OrderItemAddonResponse adalah biaya satu add-on pada item pesanan
+type OrderItemAddonResponse struct {
+	AddonID   *int64       `json:"addon_id"`
+	AddonName string       `json:"addon_name"`
+	Amount    money.Amount `json:"amount"`
+}
+
+// OrderItemResponse adalah satu item pesanan
+type OrderItemResponse struct {
+	ID          int64                    `json:"id"`
+	ServiceID   int64                    `json:"service_id"`
+	ServiceName string                   `json:"service_name"`
+	ItemNotes   *string                  `json:"item_notes"`
+	Quantity    *int                     `json:"quantity"`
+	QtyPieces   *int                     `json:"qty_pieces"`
+	WeightKg    *float64                 `json:"weight_kg"`
+	Unit        string                   `json:"unit"`
+	UnitPrice   money.Amount             `json:"unit_price"`
+	Subtotal    money.Amount             `json:"subtotal"`
+	Addons      []OrderItemAddonResponse `json:"addons"`
+}
+
+// PaymentResponse adalah data satu pembayaran
+type PaymentResponse struct {
+	ID             int64        `json:"id"`
+	OrderID        int64        `json:"order_id"`
+	Method         *string      `json:"method"`
+	Amount         money.Amount `json:"amount"`
+	AmountReceived money.Amount `json:"amount_received"`
+	AmountChange   money.Amount `json:"amount_change"`
+	ReferenceNo    *string      `json:"reference_no"`
+	Status         string       `json:"status"`
+	CreatedBy      int64        `json:"created_by"`
+	CollectedBy    *int64       `json:"collected_by"`
+	CollectedAt    *string      `json:"collected_at"`
+	PaidAt         *string      `json:"paid_at"`
+	CreatedAt      string       `json:"created_at"`
+}
+
+// OrderDeliveryResponse adalah data pengantaran pesanan
+type OrderDeliveryResponse struct {
+	ID                 int64        `json:"id"`
+	ShippingCost       money.Amount `json:"shipping_cost"`
+	CourierID          *int64       `json:"courier_id"`
+	CourierName        *string      `json:"courier_name"`
+	CourierPhone       *string      `json:"courier_phone"`
+	CourierDepartedAt  *string      `json:"courier_departed_at"`
+	CourierArrivedAt   *string      `json:"courier_arrived_at"`
+	CODCollectedAmount money.Amount `json:"cod_collected_amount"`
+}
+
+// OrderStatusHistoryResponse adalah satu baris riwayat status pesanan
+type OrderStatusHistoryResponse struct {
+	ID             int64   `json:"id"`
+	PreviousStatus *string `json:"previous_status"`
+	NewStatus      string  `json:"new_status"`
+	ActorName      *string `json:"actor_name"`
+	ActorRole      *string `json:"actor_role"`
+	Notes          *string `json:"notes"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// OrderDetailResponse adalah balasan POST /orders
+type OrderDetailResponse struct {
+	ID                 int64                        `json:"id"`
+	InvoiceNumber      string                       `json:"invoice_number"`
+	OutletID           int64                        `json:"outlet_id"`
+	IsDelivery         int                          `json:"is_delivery"`
+	Subtotal           money.Amount                 `json:"subtotal"`
+	DiscountTotal      money.Amount                 `json:"discount_total"`
+	ServiceChargeTotal money.Amount                 `json:"service_charge_total"`
+	TaxTotal           money.Amount                 `json:"tax_total"`
+	GrandTotal         money.Amount                 `json:"grand_total"`
+	PaymentStatus      string                       `json:"payment_status"`
+	StatusInternal     string                       `json:"status_internal"`
+	EstimatedReadyAt   *string                      `json:"estimated_ready_at"`
+	Notes              *string                      `json:"notes"`
+	CreatedBy          int64                        `json:"created_by"`
+	CreatedByName      *string                      `json:"created_by_name"`
+	CreatedAt          string                       `json:"created_at"`
+	UpdatedAt          *string                      `json:"updated_at"`
+	Customer           OrderCustomerResponse        `json:"customer"`
+	OrderItems         []OrderItemResponse          `json:"order_items"`
+	Payment            *PaymentResponse             `json:"payment"`
+	Delivery           *OrderDeliveryResponse       `json:"delivery"`
+	StatusHistory      []OrderStatusHistoryResponse `json:"status_history"`
+}
+
+// OrderSummaryDeliveryResponse adalah ringkasan pengantaran pada daftar pesanan
+type OrderSummaryDeliveryResponse struct {
+	ID           int64        `json:"id"`
+	ShippingCost money.Amount `json:"shipping_cost"`
+}
+
+// OrderSummaryResponse adalah satu baris daftar pesanan (GET /orders)
+type OrderSummaryResponse struct {
+	ID                 int64                         `json:"id"`
+	InvoiceNumber      string                        `json:"invoice_number"`
+	OutletID           int64                         `json:"outlet_id"`
+	IsDelivery         int                           `json:"is_delivery"`
+	Subtotal           money.Amount                  `json:"subtotal"`
+	DiscountTotal      money.Amount                  `json:"discount_total"`
+	ServiceChargeTotal money.Amount                  `json:"service_charge_total"`
+	TaxTotal           money.Amount                  `json:"tax_total"`
+	GrandTotal         money.Amount                  `json:"grand_total"`
+	PaymentStatus      string                        `json:"payment_status"`
+	StatusInternal     string                        `json:"status_internal"`
+	EstimatedReadyAt   *string                       `json:"estimated_ready_at"`
+	CreatedBy          int64                         `json:"created_by"`
+	CreatedByName      *string                       `json:"created_by_name"`
+	CreatedAt          string                        `json:"created_at"`
+	UpdatedAt          *string                       `json:"updated_at"`
+	Customer           OrderCustomerResponse         `json:"customer"`
+	Delivery           *OrderSummaryDeliveryResponse `json:"delivery"`
+}
+
+// OrderListResponse untuk balasan daftar pesanan lengkap dengan Pagination
+type OrderListResponse struct {
+	Data []OrderSummaryResponse `json:"data"`
+	Meta response.MetaData      `json:"meta"`
+}
+
+// PaymentSummaryResponse adalah satu baris daftar pembayaran (GET /payments) beserta nomor notanya
+type PaymentSummaryResponse struct {
+	PaymentResponse
+	InvoiceNumber string `json:"invoice_number"`
+}
+
+// PaymentListResponse untuk balasan daftar pembayaran lengkap dengan Pagination
+type PaymentListResponse struct {
+	Data []PaymentSummaryResponse `json:"data"`
+	Meta response.MetaData        `json:"meta"`
+}
@@ -0,0 +1,56 @@
+package dto
+
+import "laundry-backend/pkg/money"
+
+// ==========================================
+// 1. REQUEST DTO (Input from Client)
+// ==========================================
+
+// CreateOutletRequest untuk endpoint POST /outlets
+type CreateOutletRequest struct {
+	Code        string  `json:"code" binding:"required,max=20"`
+	OutletName  string  `json:"outlet_name" binding:"required,min=3,max=150"`
+	Address     *string `json:"address"`
+	PhoneNumber *string `json:"phone_number" binding:"omitempty,numeric,max=30"`
+}
+
+// UpdateOutletRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+type UpdateOutletRequest struct {
+	Code        *string `json:"code" binding:"omitempty,min=1,max=20"`
+	OutletName  *string `json:"outlet_name" binding:"omitempty,min=3,max=150"`
+	Address     *string `json:"address"`
+	PhoneNumber *string `json:"phone_number" binding:"omitempty,numeric,max=30"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+// SetServiceOutletPriceRequest untuk endpoint PUT /outlets/:id/service-prices/:serviceId
+type SetServiceOutletPriceRequest struct {
+	Price money.Amount `json:"price" binding:"required,min=0"`
+}
+
+// ==========================================
+// 2. RESPONSE DTO (Output to Client)
+// ==========================================
+
+// OutletResponse adalah data satu outlet
+type OutletResponse struct {
+	ID          int64   `json:"id"`
+	Code        string  `json:"code"`
+	OutletName  string  `json:"outlet_name"`
+	Address     *string `json:"address"`
+	PhoneNumber *string `json:"phone_number"`
+	IsActive    bool    `json:"is_active"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   *string `json:"updated_at"`
+}
+
+// ServiceOutletPriceResponse adalah harga khusus satu layanan di sebuah outlet
+type ServiceOutletPriceResponse struct {
+	ServiceID   int64        `json:"service_id"`
+	ServiceCode string       `json:"service_code"`
+	ServiceName string       `json:"service_name"`
+	OutletID    int64        `json:"outlet_id"`
+	Price       money.Amount `json:"price"`
+	BasePrice   money.Amount `json:"base_price"` // Harga dasar layanan (services.price)
+	UpdatedAt   string       `json:"updated_at"`
+}
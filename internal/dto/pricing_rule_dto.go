@@ -0,0 +1,75 @@
+package dto
+
+import "laundry-backend/pkg/money"
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// CreatePricingRuleRequest digunakan saat Owner menambah aturan harga (POST /services/:id/pricing-rules)
+// Kolom yang wajib diisi bergantung pada rule_type dan divalidasi ulang di layer Service.
+type CreatePricingRuleRequest struct {
+	RuleType     string        `json:"rule_type" binding:"required,oneof=minimum rounding tier"`
+	MinQuantity  *float64      `json:"min_quantity" binding:"omitempty,gt=0"`
+	RoundingStep *float64      `json:"rounding_step" binding:"omitempty,gt=0"`
+	UnitPrice    *money.Amount `json:"unit_price" binding:"omitempty,min=0"`
+}
+
+// UpdatePricingRuleRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+// rule_type sengaja tidak bisa diubah, hapus lalu buat aturan baru jika jenisnya berbeda.
+type UpdatePricingRuleRequest struct {
+	MinQuantity  *float64      `json:"min_quantity" binding:"omitempty,gt=0"`
+	RoundingStep *float64      `json:"rounding_step" binding:"omitempty,gt=0"`
+	UnitPrice    *money.Amount `json:"unit_price" binding:"omitempty,min=0"`
+	IsActive     *bool         `json:"is_active"`
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// PricingRuleResponse untuk endpoint List, Create, dan Update aturan harga
+type PricingRuleResponse struct {
+	ID           int64         `json:"id"`
+	ServiceID    int64         `json:"service_id"`
+	RuleType     string        `json:"rule_type"`
+	MinQuantity  *float64      `json:"min_quantity"`
+	RoundingStep *float64      `json:"rounding_step"`
+	UnitPrice    *money.Amount `json:"unit_price"`
+	IsActive     bool          `json:"is_active"`
+	CreatedAt    string        `json:"created_at"`
+	UpdatedAt    *string       `json:"updated_at"`
+}
+
+// PriceBreakdownResponse adalah satu baris penjelasan perhitungan harga
+type PriceBreakdownResponse struct {
+	Step        string       `json:"step"`
+	Description string       `json:"description"`
+	Quantity    float64      `json:"quantity"`
+	UnitPrice   money.Amount `json:"unit_price"`
+}
+
+// PriceQuoteResponse untuk endpoint simulasi harga (GET /services/:id/price-quote)
+type PriceQuoteResponse struct {
+	ServiceID        int64                    `json:"service_id"`
+	ServiceName      string                   `json:"service_name"`
+	Unit             string                   `json:"unit"`
+	ActualQuantity   float64                  `json:"actual_quantity"`
+	BillableQuantity float64                  `json:"billable_quantity"`
+	UnitPrice        money.Amount             `json:"unit_price"`
+	Subtotal         money.Amount             `json:"subtotal"`
+	Breakdown        []PriceBreakdownResponse `json:"breakdown"`
+	Addons           []AddonChargeResponse    `json:"addons"`
+	AddonTotal       money.Amount             `json:"addon_total"`
+	LineTotal        money.Amount             `json:"line_total"`
+	DurationHours    int                      `json:"duration_hours"`
+	EstimatedReadyAt string                   `json:"estimated_ready_at"`
+}
+
+// QuoteItemRequest adalah satu item keranjang yang akan dihitung harganya
+// (dipakai oleh simulasi promosi dan pembuatan pesanan).
+type QuoteItemRequest struct {
+	ServiceID int64   `json:"service_id" binding:"required"`
+	Quantity  float64 `json:"quantity" binding:"required,gt=0"`
+	AddonIDs  []int64 `json:"addon_ids"`
+}
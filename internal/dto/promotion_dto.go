@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// CreatePromotionRequest digunakan saat Owner membuat promosi baru (POST /promotions)
+// Kosongkan code untuk promo otomatis, isi code untuk kode voucher.
+// Format tanggal: "2006-01-02 15:04:05" (waktu lokal outlet).
+type CreatePromotionRequest struct {
+	Code             *string       `json:"code"`
+	PromoName        string        `json:"promo_name" binding:"required"`
+	Description      *string       `json:"description"`
+	DiscountType     string        `json:"discount_type" binding:"required,oneof=percentage fixed"`
+	DiscountValue    money.Amount  `json:"discount_value" binding:"required,gt=0"`
+	MaxDiscount      *money.Amount `json:"max_discount" binding:"omitempty,gt=0"`
+	MinSpend         money.Amount  `json:"min_spend" binding:"min=0"`
+	ScopeType        string        `json:"scope_type" binding:"omitempty,oneof=all service category"`
+	TargetIDs        []int64       `json:"target_ids" binding:"omitempty,dive,gt=0"`
+	ValidDays        []int         `json:"valid_days" binding:"omitempty,dive,min=1,max=7"`
+	StartsAt         string        `json:"starts_at" binding:"required"`
+	EndsAt           *string       `json:"ends_at"`
+	UsageLimit       *int          `json:"usage_limit" binding:"omitempty,min=1"`
+	PerCustomerLimit *int          `json:"per_customer_limit" binding:"omitempty,min=1"`
+}
+
+// UpdatePromotionRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+// Jika target_ids atau valid_days dikirim, seluruh daftar lama akan diganti (array kosong = hapus batasan hari).
+type UpdatePromotionRequest struct {
+	Code             *string       `json:"code"`
+	PromoName        *string       `json:"promo_name"`
+	Description      *string       `json:"description"`
+	DiscountType     *string       `json:"discount_type" binding:"omitempty,oneof=percentage fixed"`
+	DiscountValue    *money.Amount `json:"discount_value" binding:"omitempty,gt=0"`
+	MaxDiscount      *money.Amount `json:"max_discount" binding:"omitempty,gt=0"`
+	MinSpend         *money.Amount `json:"min_spend" binding:"omitempty,min=0"`
+	ScopeType        *string       `json:"scope_type" binding:"omitempty,oneof=all service category"`
+	TargetIDs        []int64       `json:"target_ids" binding:"omitempty,dive,gt=0"`
+	ValidDays        []int         `json:"valid_days" binding:"omitempty,dive,min=1,max=7"`
+	StartsAt         *string       `json:"starts_at"`
+	EndsAt           *string       `json:"ends_at"`
+	UsageLimit       *int          `json:"usage_limit" binding:"omitempty,min=1"`
+	PerCustomerLimit *int          `json:"per_customer_limit" binding:"omitempty,min=1"`
+	IsActive         *bool         `json:"is_active"`
+}
+
+// ValidatePromotionRequest digunakan kasir untuk simulasi diskon saat checkout (POST /promotions/validate)
+type ValidatePromotionRequest struct {
+	Code       *string            `json:"code"`
+	CustomerID *int64             `json:"customer_id" binding:"omitempty,gt=0"`
+	Items      []QuoteItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// PromotionSummaryResponse untuk endpoint List (GET /promotions)
+type PromotionSummaryResponse struct {
+	ID            int64        `json:"id"`
+	Code          *string      `json:"code"`
+	PromoName     string       `json:"promo_name"`
+	DiscountType  string       `json:"discount_type"`
+	DiscountValue money.Amount `json:"discount_value"`
+	ScopeType     string       `json:"scope_type"`
+	StartsAt      string       `json:"starts_at"`
+	EndsAt        *string      `json:"ends_at"`
+	UsageLimit    *int         `json:"usage_limit"`
+	UsageCount    int          `json:"usage_count"`
+	IsActive      bool         `json:"is_active"`
+}
+
+// PromotionDetailResponse untuk endpoint Detail (GET /promotions/:id)
+type PromotionDetailResponse struct {
+	ID               int64         `json:"id"`
+	Code             *string       `json:"code"`
+	PromoName        string        `json:"promo_name"`
+	Description      *string       `json:"description"`
+	DiscountType     string        `json:"discount_type"`
+	DiscountValue    money.Amount  `json:"discount_value"`
+	MaxDiscount      *money.Amount `json:"max_discount"`
+	MinSpend         money.Amount  `json:"min_spend"`
+	ScopeType        string        `json:"scope_type"`
+	TargetIDs        []int64       `json:"target_ids"`
+	ValidDays        []int         `json:"valid_days"`
+	StartsAt         string        `json:"starts_at"`
+	EndsAt           *string       `json:"ends_at"`
+	UsageLimit       *int          `json:"usage_limit"`
+	UsageCount       int           `json:"usage_count"`
+	PerCustomerLimit *int          `json:"per_customer_limit"`
+	CustomerID       *int64        `json:"customer_id"` // Terisi jika voucher khusus satu pelanggan
+	Explanation      string        `json:"explanation"`
+	IsActive         bool          `json:"is_active"`
+	CreatedAt        string        `json:"created_at"`
+	UpdatedAt        *string       `json:"updated_at"`
+}
+
+// PromotionListResponse untuk balasan GET List lengkap dengan Pagination
+type PromotionListResponse struct {
+	Data []PromotionSummaryResponse `json:"data"`
+	Meta response.MetaData          `json:"meta"`
+}
+
+// AppliedDiscountResponse adalah satu diskon yang berhasil diterapkan ke keranjang
+type AppliedDiscountResponse struct {
+	Source      string       `json:"source"` // membership, promotion, voucher
+	PromotionID int64        `json:"promotion_id"`
+	Code        *string      `json:"code"`
+	PromoName   string       `json:"promo_name"`
+	Amount      money.Amount `json:"amount"`
+	Explanation string       `json:"explanation"`
+}
+
+// PromotionValidationResponse untuk endpoint simulasi diskon (POST /promotions/validate)
+type PromotionValidationResponse struct {
+	Subtotal      money.Amount              `json:"subtotal"`
+	Discounts     []AppliedDiscountResponse `json:"discounts"`
+	DiscountTotal money.Amount              `json:"discount_total"`
+	Total         money.Amount              `json:"total"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// ReceiptQuery adalah parameter cetak nota (GET /orders/{id}/receipt?format=&paper=)
+type ReceiptQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=pdf escpos text"`
+	Paper  int    `form:"paper" binding:"omitempty,oneof=58 80"` // Lebar kertas thermal (mm), untuk escpos & text
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// PrintFile adalah hasil render nota/label dalam bentuk byte mentah (bukan JSON)
+type PrintFile struct {
+	ContentType string
+	FileName    string
+	Content     []byte
+}
@@ -0,0 +1,32 @@
+package dto
+
+// ==========================================
+// RESPONSE DTO (Output to Client)
+// ==========================================
+
+// EmployeeReportPeriod adalah rentang tanggal laporan produktivitas karyawan
+type EmployeeReportPeriod struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	TotalDays int    `json:"total_days"`
+}
+
+// EmployeePerformanceResponse adalah produktivitas & komplain satu karyawan
+type EmployeePerformanceResponse struct {
+	EmployeeID     int64   `json:"employee_id"`
+	Name           string  `json:"name"`
+	Role           string  `json:"role"`
+	TotalActivity  int64   `json:"total_activity"`
+	Activity       string  `json:"activity"`
+	AveragePerDay  float64 `json:"average_per_day"`
+	ComplaintCount int64   `json:"complaint_count"` // Komplain (bukan rejected) atas pesanan yang pernah ditangani karyawan ini
+}
+
+// EmployeeReportResponse untuk endpoint laporan karyawan (GET /reports/employees)
+type EmployeeReportResponse struct {
+	ReportPeriod       EmployeeReportPeriod          `json:"report_period"`
+	OutletID           *int64                        `json:"outlet_id"` // null = konsolidasi semua outlet
+	CashierPerformance []EmployeePerformanceResponse `json:"cashier_performance"`
+	StaffPerformance   []EmployeePerformanceResponse `json:"staff_performance"`
+	CourierPerformance []EmployeePerformanceResponse `json:"courier_performance"`
+}
@@ -0,0 +1,66 @@
+package dto
+
+import "laundry-backend/pkg/money"
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// SearchQuery adalah parameter kotak pencarian global (GET /search?q=&types=&limit=)
+type SearchQuery struct {
+	Q     string `form:"q" binding:"required,max=100"`
+	Types string `form:"types"`                                  // Dipisah koma: orders,customers,services (kosong = semua yang diizinkan role)
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=20"` // Maksimal hasil per jenis, default 5
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// SearchOrderResult adalah satu pesanan di hasil pencarian
+type SearchOrderResult struct {
+	ID            int64        `json:"id"`
+	InvoiceNumber string       `json:"invoice_number"`
+	CustomerName  *string      `json:"customer_name"`
+	CustomerPhone *string      `json:"customer_phone"`
+	Status        string       `json:"status_internal"`
+	PaymentStatus string       `json:"payment_status"`
+	GrandTotal    money.Amount `json:"grand_total"`
+	IsDelivery    bool         `json:"is_delivery"`
+	MatchedItem   *string      `json:"matched_item_notes"` // Catatan item yang cocok, null jika cocok di data pesanan
+	Score         float64      `json:"score"`
+	CreatedAt     string       `json:"created_at"`
+}
+
+// SearchCustomerResult adalah satu pelanggan di hasil pencarian
+type SearchCustomerResult struct {
+	ID          int64   `json:"id"`
+	FullName    string  `json:"full_name"`
+	PhoneNumber string  `json:"phone_number"`
+	IsActive    bool    `json:"is_active"`
+	Score       float64 `json:"score"`
+}
+
+// SearchServiceResult adalah satu layanan di hasil pencarian
+type SearchServiceResult struct {
+	ID          int64        `json:"id"`
+	Code        string       `json:"code"`
+	ServiceName string       `json:"service_name"`
+	Unit        string       `json:"unit"`
+	Price       money.Amount `json:"price"`
+	IsActive    bool         `json:"is_active"`
+	Score       float64      `json:"score"`
+}
+
+// SearchGroup adalah hasil satu jenis entitas, terurut dari skor tertinggi
+type SearchGroup struct {
+	Type  string      `json:"type"` // orders / customers / services
+	Count int         `json:"count"`
+	Items interface{} `json:"items"`
+}
+
+// SearchResponse untuk endpoint pencarian global (GET /search)
+type SearchResponse struct {
+	Query  string        `json:"query"`
+	Groups []SearchGroup `json:"groups"`
+}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"laundry-backend/pkg/money"
 	"laundry-backend/pkg/response"
 )
 
@@ -10,23 +11,23 @@ import (
 
 // CreateServiceRequest digunakan saat Owner menambah layanan baru (POST /services)
 type CreateServiceRequest struct {
-	CategoryID    int64   `json:"category_id" binding:"required"`
-	Code          string  `json:"code" binding:"required"`
-	ServiceName   string  `json:"service_name" binding:"required"`
-	Unit          string  `json:"unit" binding:"required,oneof=kg pcs"`
-	Price         float64 `json:"price" binding:"required,min=0"`
-	DurationHours int     `json:"duration_hours" binding:"required,min=1"`
+	CategoryID    int64        `json:"category_id" binding:"required"`
+	Code          string       `json:"code" binding:"required"`
+	ServiceName   string       `json:"service_name" binding:"required"`
+	Unit          string       `json:"unit" binding:"required,oneof=kg pcs"`
+	Price         money.Amount `json:"price" binding:"required,min=0"`
+	DurationHours int          `json:"duration_hours" binding:"required,min=1"`
 }
 
 // UpdateServiceRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
 type UpdateServiceRequest struct {
-	CategoryID    *int64   `json:"category_id"`
-	Code          *string  `json:"code"`
-	ServiceName   *string  `json:"service_name"`
-	Unit          *string  `json:"unit" binding:"omitempty,oneof=kg pcs"`
-	Price         *float64 `json:"price" binding:"omitempty,min=0"`
-	DurationHours *int     `json:"duration_hours" binding:"omitempty,min=1"`
-	IsActive      *bool    `json:"is_active"` // Menggunakan *bool agar bisa mendeteksi jika user mengirim 'false'
+	CategoryID    *int64        `json:"category_id"`
+	Code          *string       `json:"code"`
+	ServiceName   *string       `json:"service_name"`
+	Unit          *string       `json:"unit" binding:"omitempty,oneof=kg pcs"`
+	Price         *money.Amount `json:"price" binding:"omitempty,min=0"`
+	DurationHours *int          `json:"duration_hours" binding:"omitempty,min=1"`
+	IsActive      *bool         `json:"is_active"` // Menggunakan *bool agar bisa mendeteksi jika user mengirim 'false'
 }
 
 // ==========================================
@@ -40,7 +41,7 @@ type ServiceSummaryResponse struct {
 	Code          string                  `json:"code"`
 	ServiceName   string                  `json:"service_name"`
 	Unit          string                  `json:"unit"`
-	Price         float64                 `json:"price"`
+	Price         money.Amount            `json:"price"`
 	DurationHours int                     `json:"duration_hours"`
 	IsActive      bool                    `json:"is_active"`
 	Category      *NestedCategoryResponse `json:"category"`
@@ -53,9 +54,10 @@ type ServiceDetailResponse struct {
 	Code          string                  `json:"code"`
 	ServiceName   string                  `json:"service_name"`
 	Unit          string                  `json:"unit"`
-	Price         float64                 `json:"price"`
+	Price         money.Amount            `json:"price"`
 	DurationHours int                     `json:"duration_hours"`
 	IsActive      bool                    `json:"is_active"`
+	Version       int64                   `json:"version"` // Sama dengan header ETag
 	CreatedAt     string                  `json:"created_at"`
 	UpdatedAt     *string                 `json:"updated_at"` // TANPA omitempty, agar jika nil, JSON tetap mencetak "null"
 	Category      *NestedCategoryResponse `json:"category"`
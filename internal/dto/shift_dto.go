@@ -0,0 +1,91 @@
+package dto
+
+import "laundry-backend/pkg/money"
+
+// ==========================================
+// 1. REQUEST DTO (Input from Client)
+// ==========================================
+
+// OpenShiftRequest untuk endpoint POST /shifts/open (modal awal laci kas)
+type OpenShiftRequest struct {
+	OpeningFloat money.Amount `json:"opening_float" binding:"min=0"`
+	Note         *string      `json:"note" binding:"omitempty,max=255"`
+}
+
+// CloseShiftRequest untuk endpoint POST /shifts/current/close (uang fisik yang dihitung kasir)
+type CloseShiftRequest struct {
+	ClosingCounted *money.Amount `json:"closing_counted" binding:"required,min=0"`
+	Note           *string       `json:"note" binding:"omitempty,max=255"`
+}
+
+// CreatePettyCashPayoutRequest untuk endpoint POST /shifts/current/payouts
+type CreatePettyCashPayoutRequest struct {
+	Amount      money.Amount `json:"amount" binding:"required,gt=0"`
+	Description string       `json:"description" binding:"required,min=3,max=255"`
+}
+
+// ==========================================
+// 2. RESPONSE DTO (Output to Client)
+// ==========================================
+
+// ShiftResponse adalah data satu shift kasir.
+// Untuk shift yang masih terbuka, cash_sales, petty_cash_total & expected_cash adalah total berjalan.
+type ShiftResponse struct {
+	ID             int64         `json:"id"`
+	OutletID       int64         `json:"outlet_id"`
+	CashierID      int64         `json:"cashier_id"`
+	CashierName    string        `json:"cashier_name"`
+	Status         string        `json:"status"`
+	OpeningFloat   money.Amount  `json:"opening_float"`
+	CashSales      money.Amount  `json:"cash_sales"`
+	PettyCashTotal money.Amount  `json:"petty_cash_total"`
+	ExpectedCash   money.Amount  `json:"expected_cash"` // opening_float + cash_sales - petty_cash_total
+	ClosingCounted *money.Amount `json:"closing_counted"`
+	Variance       *money.Amount `json:"variance"`    // closing_counted - expected_cash (minus = kurang)
+	Discrepancy    *string       `json:"discrepancy"` // balanced | short | over (null selama shift terbuka)
+	PaymentCount   int64         `json:"payment_count"`
+	OpenNote       *string       `json:"open_note"`
+	CloseNote      *string       `json:"close_note"`
+	OpenedAt       string        `json:"opened_at"`
+	ClosedAt       *string       `json:"closed_at"`
+	ClosedBy       *int64        `json:"closed_by"`
+}
+
+// PettyCashPayoutResponse adalah satu pengeluaran kas kecil
+type PettyCashPayoutResponse struct {
+	ID          int64        `json:"id"`
+	ShiftID     int64        `json:"shift_id"`
+	Amount      money.Amount `json:"amount"`
+	Description string       `json:"description"`
+	CreatedBy   int64        `json:"created_by"`
+	CreatedAt   string       `json:"created_at"`
+}
+
+// ShiftDetailResponse adalah shift beserta rincian kas kecilnya
+type ShiftDetailResponse struct {
+	ShiftResponse
+	Payouts []PettyCashPayoutResponse `json:"payouts"`
+}
+
+// ShiftReportPeriod adalah rentang tanggal buka shift pada laporan shift
+type ShiftReportPeriod struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// ShiftReportResponse untuk endpoint laporan shift kasir (GET /reports/shifts).
+// Shift dengan |variance| > tolerance ditandai short/over dan dihitung di discrepancy_count.
+type ShiftReportResponse struct {
+	Period           ShiftReportPeriod `json:"period"`
+	OutletID         *int64            `json:"outlet_id"` // null = konsolidasi semua outlet
+	Tolerance        money.Amount      `json:"tolerance"`
+	TotalShifts      int               `json:"total_shifts"`
+	OpenShifts       int               `json:"open_shifts"`
+	DiscrepancyCount int               `json:"discrepancy_count"`
+	TotalExpected    money.Amount      `json:"total_expected"` // Shift yang sudah ditutup saja
+	TotalCounted     money.Amount      `json:"total_counted"`
+	TotalShort       money.Amount      `json:"total_short"` // Jumlah selisih kurang (angka positif)
+	TotalOver        money.Amount      `json:"total_over"`
+	NetVariance      money.Amount      `json:"net_variance"` // total_counted - total_expected
+	Shifts           []ShiftResponse   `json:"shifts"`
+}
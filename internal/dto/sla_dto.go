@@ -0,0 +1,91 @@
+package dto
+
+import "laundry-backend/pkg/response"
+
+// ==========================================
+// 1. REQUEST DTO (Input from Client)
+// ==========================================
+
+// OverdueOrderQuery adalah filter daftar pesanan terlambat (GET /orders/overdue)
+type OverdueOrderQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending in-progress"`
+}
+
+// UnclaimedOrderQuery adalah filter daftar cucian belum diambil (GET /orders/unclaimed)
+type UnclaimedOrderQuery struct {
+	MinDays int `form:"min_days" binding:"omitempty,min=0,max=3650"` // 0 = semua pesanan ready-pickup
+}
+
+// SLAAlertQuery adalah filter kotak masuk peringatan SLA (GET /orders/sla-alerts)
+type SLAAlertQuery struct {
+	AlertType    string `form:"alert_type" binding:"omitempty,oneof=overdue unclaimed"`
+	Acknowledged *bool  `form:"acknowledged"` // Kosong = semua; false = belum ditindaklanjuti
+}
+
+// ==========================================
+// 2. RESPONSE DTO (Output to Client)
+// ==========================================
+
+// OverdueOrderResponse adalah satu pesanan yang melewati estimasi selesai
+type OverdueOrderResponse struct {
+	OrderID          int64   `json:"order_id"`
+	OutletID         int64   `json:"outlet_id"`
+	InvoiceNumber    string  `json:"invoice_number"`
+	CustomerName     *string `json:"customer_name"`
+	CustomerPhone    *string `json:"customer_phone"`
+	IsDelivery       bool    `json:"is_delivery"`
+	PaymentStatus    string  `json:"payment_status"`
+	StatusInternal   string  `json:"status_internal"`
+	EstimatedReadyAt string  `json:"estimated_ready_at"`
+	LateMinutes      int64   `json:"late_minutes"` // Selisih sekarang dengan estimated_ready_at
+	FlaggedAt        *string `json:"flagged_at"`   // Saat scheduler mengirim event order.overdue
+}
+
+// OverdueOrderListResponse untuk balasan daftar pesanan terlambat lengkap dengan Pagination
+type OverdueOrderListResponse struct {
+	Data []OverdueOrderResponse `json:"data"`
+	Meta response.MetaData      `json:"meta"`
+}
+
+// UnclaimedOrderResponse adalah satu pesanan ready-pickup yang belum diambil
+type UnclaimedOrderResponse struct {
+	OrderID          int64   `json:"order_id"`
+	OutletID         int64   `json:"outlet_id"`
+	InvoiceNumber    string  `json:"invoice_number"`
+	CustomerName     *string `json:"customer_name"`
+	CustomerPhone    *string `json:"customer_phone"`
+	PaymentStatus    string  `json:"payment_status"`
+	ReadySince       string  `json:"ready_since"`
+	DaysUnclaimed    int     `json:"days_unclaimed"`
+	ThresholdReached *int    `json:"threshold_reached"` // Ambang SLA_UNCLAIMED_THRESHOLD_DAYS tertinggi yang terlewati
+	FlaggedDays      *int    `json:"flagged_days"`      // Ambang yang sudah dikirim sebagai event order.unclaimed
+	FlaggedAt        *string `json:"flagged_at"`
+}
+
+// UnclaimedOrderListResponse untuk balasan daftar cucian belum diambil lengkap dengan Pagination
+type UnclaimedOrderListResponse struct {
+	Data []UnclaimedOrderResponse `json:"data"`
+	Meta response.MetaData        `json:"meta"`
+}
+
+// SLAAlertResponse adalah satu peringatan SLA terbuka di kotak masuk aplikasi
+type SLAAlertResponse struct {
+	ID                 int64   `json:"id"`
+	OutletID           int64   `json:"outlet_id"`
+	OrderID            int64   `json:"order_id"`
+	InvoiceNumber      string  `json:"invoice_number"`
+	AlertType          string  `json:"alert_type"`
+	ThresholdDays      int     `json:"threshold_days"`  // 0 untuk overdue
+	StatusInternal     string  `json:"status_internal"` // Status pesanan saat peringatan dicatat
+	ReferenceAt        string  `json:"reference_at"`    // estimated_ready_at (overdue) / siap diambil sejak (unclaimed)
+	TriggeredAt        string  `json:"triggered_at"`
+	AcknowledgedBy     *int64  `json:"acknowledged_by"`
+	AcknowledgedByName *string `json:"acknowledged_by_name"`
+	AcknowledgedAt     *string `json:"acknowledged_at"`
+}
+
+// SLAAlertListResponse untuk balasan kotak masuk peringatan SLA lengkap dengan Pagination
+type SLAAlertListResponse struct {
+	Data []SLAAlertResponse `json:"data"`
+	Meta response.MetaData  `json:"meta"`
+}
@@ -0,0 +1,65 @@
+package dto
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// TagLabelQuery adalah parameter cetak label tag (GET /orders/{id}/tags/labels?format=&symbology=&paper=)
+type TagLabelQuery struct {
+	Format    string `form:"format" binding:"omitempty,oneof=pdf escpos"`
+	Symbology string `form:"symbology" binding:"omitempty,oneof=code128 qr"`
+	Paper     int    `form:"paper" binding:"omitempty,oneof=58 80"`
+}
+
+// ScanRequest digunakan staff setelah memindai tag (POST /scan/{tag}).
+// Body kosong hanya menampilkan pesanan; status & counted_pieces bisa dikirim bersamaan.
+type ScanRequest struct {
+	Status        *string `json:"status" binding:"omitempty,oneof=in-progress ready-pickup ready-delivery being-delivered finished-delivery picked-up cancelled"`
+	CountedPieces *int    `json:"counted_pieces" binding:"omitempty,min=0"`
+	Notes         *string `json:"notes" binding:"omitempty,max=255"`
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// OrderItemTagResponse adalah satu item pesanan beserta tag-nya
+type OrderItemTagResponse struct {
+	OrderItemID    int64   `json:"order_item_id"`
+	TagCode        *string `json:"tag_code"` // null jika item belum diberi tag
+	ServiceName    string  `json:"service_name"`
+	Unit           string  `json:"unit"`
+	Quantity       float64 `json:"quantity"`
+	DeclaredPieces *int    `json:"declared_pieces"`
+	CountedPieces  *int    `json:"counted_pieces"`
+	CountedAt      *string `json:"counted_at"`
+	LastScannedAt  *string `json:"last_scanned_at"`
+}
+
+// OrderTagsResponse untuk endpoint daftar & pembuatan tag (GET/POST /orders/{id}/tags)
+type OrderTagsResponse struct {
+	OrderID       int64                  `json:"order_id"`
+	InvoiceNumber string                 `json:"invoice_number"`
+	Items         []OrderItemTagResponse `json:"items"`
+}
+
+// ScanOrderResponse adalah ringkasan pesanan milik tag yang dipindai
+type ScanOrderResponse struct {
+	ID               int64   `json:"id"`
+	InvoiceNumber    string  `json:"invoice_number"`
+	CustomerName     *string `json:"customer_name"`
+	IsDelivery       bool    `json:"is_delivery"`
+	PaymentStatus    string  `json:"payment_status"`
+	StatusInternal   string  `json:"status_internal"`
+	EstimatedReadyAt *string `json:"estimated_ready_at"`
+}
+
+// ScanResponse untuk endpoint scan tag (POST /scan/{tag})
+type ScanResponse struct {
+	Order         ScanOrderResponse      `json:"order"`
+	ScannedItem   OrderItemTagResponse   `json:"scanned_item"`
+	Items         []OrderItemTagResponse `json:"items"`
+	NextStatuses  []string               `json:"next_statuses"` // Status tujuan yang boleh dipilih role ini
+	StatusChanged bool                   `json:"status_changed"`
+	PieceMismatch bool                   `json:"piece_mismatch"` // true jika counted_pieces berbeda dari qty_pieces
+}
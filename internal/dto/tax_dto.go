@@ -0,0 +1,129 @@
+package dto
+
+import "laundry-backend/pkg/money"
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// TaxRateTargetRequest adalah satu cakupan tarif: layanan/kategori yang dikenai atau dikecualikan
+type TaxRateTargetRequest struct {
+	TargetType string `json:"target_type" binding:"required,oneof=service category"`
+	TargetID   int64  `json:"target_id" binding:"required,gt=0"`
+	IsExempt   bool   `json:"is_exempt"`
+}
+
+// CreateTaxRateRequest digunakan saat Owner menambah tarif pajak / service charge (POST /tax-rates)
+type CreateTaxRateRequest struct {
+	Code      string                 `json:"code" binding:"required,max=30"`
+	TaxName   string                 `json:"tax_name" binding:"required"`
+	TaxKind   string                 `json:"tax_kind" binding:"required,oneof=tax service_charge"`
+	Rate      money.Percent          `json:"rate" binding:"gt=0,max=10000"` // Validator membaca satuan 0.01% (10000 = 100%)
+	PriceMode string                 `json:"price_mode" binding:"omitempty,oneof=exclusive inclusive"`
+	ScopeType string                 `json:"scope_type" binding:"omitempty,oneof=all selected"`
+	Targets   []TaxRateTargetRequest `json:"targets" binding:"omitempty,dive"`
+}
+
+// UpdateTaxRateRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+// Jika targets dikirim, seluruh daftar lama akan diganti (array kosong = hapus semua cakupan).
+type UpdateTaxRateRequest struct {
+	Code      *string                `json:"code" binding:"omitempty,max=30"`
+	TaxName   *string                `json:"tax_name"`
+	TaxKind   *string                `json:"tax_kind" binding:"omitempty,oneof=tax service_charge"`
+	Rate      *money.Percent         `json:"rate" binding:"omitempty,gt=0,max=10000"`
+	PriceMode *string                `json:"price_mode" binding:"omitempty,oneof=exclusive inclusive"`
+	ScopeType *string                `json:"scope_type" binding:"omitempty,oneof=all selected"`
+	Targets   []TaxRateTargetRequest `json:"targets" binding:"omitempty,dive"`
+	IsActive  *bool                  `json:"is_active"`
+}
+
+// OrderTotalsRequest digunakan kasir untuk simulasi total checkout lengkap dengan diskon & pajak (POST /tax-rates/preview)
+type OrderTotalsRequest struct {
+	Code       *string            `json:"code"`
+	CustomerID *int64             `json:"customer_id" binding:"omitempty,gt=0"`
+	Items      []QuoteItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// TaxRateTargetResponse adalah satu cakupan tarif
+type TaxRateTargetResponse struct {
+	TargetType string `json:"target_type"`
+	TargetID   int64  `json:"target_id"`
+	IsExempt   bool   `json:"is_exempt"`
+}
+
+// TaxRateResponse untuk endpoint List & Detail (GET /tax-rates)
+type TaxRateResponse struct {
+	ID        int64                   `json:"id"`
+	Code      string                  `json:"code"`
+	TaxName   string                  `json:"tax_name"`
+	TaxKind   string                  `json:"tax_kind"`
+	Rate      money.Percent           `json:"rate"`
+	PriceMode string                  `json:"price_mode"`
+	ScopeType string                  `json:"scope_type"`
+	Targets   []TaxRateTargetResponse `json:"targets"`
+	IsActive  bool                    `json:"is_active"`
+	CreatedAt string                  `json:"created_at"`
+	UpdatedAt *string                 `json:"updated_at"`
+}
+
+// TaxChargeResponse adalah total satu tarif pada sebuah pesanan
+type TaxChargeResponse struct {
+	TaxRateID   *int64        `json:"tax_rate_id"`
+	TaxName     string        `json:"tax_name"`
+	TaxKind     string        `json:"tax_kind"`
+	Rate        money.Percent `json:"rate"`
+	PriceMode   string        `json:"price_mode"`
+	TaxableBase money.Amount  `json:"taxable_base"`
+	Amount      money.Amount  `json:"amount"`
+}
+
+// OrderTotalsResponse untuk endpoint simulasi total checkout (POST /tax-rates/preview)
+// grand_total = subtotal - discount_total + service charge & pajak dengan mode exclusive.
+type OrderTotalsResponse struct {
+	Subtotal           money.Amount              `json:"subtotal"`
+	Discounts          []AppliedDiscountResponse `json:"discounts"`
+	DiscountTotal      money.Amount              `json:"discount_total"`
+	Charges            []TaxChargeResponse       `json:"charges"`
+	ServiceChargeTotal money.Amount              `json:"service_charge_total"`
+	TaxTotal           money.Amount              `json:"tax_total"`
+	GrandTotal         money.Amount              `json:"grand_total"`
+	NetRevenue         money.Amount              `json:"net_revenue"`
+}
+
+// TaxReportPeriod adalah rentang tanggal laporan pajak
+type TaxReportPeriod struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// TaxReportOutletResponse adalah ringkasan nota lunas satu outlet di dalam laporan pajak
+type TaxReportOutletResponse struct {
+	OutletID        int64        `json:"outlet_id"`
+	OutletCode      string       `json:"outlet_code"`
+	OutletName      string       `json:"outlet_name"`
+	TotalOrdersPaid int64        `json:"total_orders_paid"`
+	GrossSales      money.Amount `json:"gross_sales"`
+	TaxCollected    money.Amount `json:"tax_collected"`
+	GrandTotal      money.Amount `json:"grand_total"`
+	NetRevenue      money.Amount `json:"net_revenue"`
+}
+
+// TaxReportResponse untuk endpoint laporan pajak (GET /reports/taxes)
+// Memisahkan pendapatan bersih outlet dari pajak yang harus disetor.
+type TaxReportResponse struct {
+	Period             TaxReportPeriod           `json:"period"`
+	OutletID           *int64                    `json:"outlet_id"` // null = konsolidasi semua outlet
+	TotalOrdersPaid    int64                     `json:"total_orders_paid"`
+	GrossSales         money.Amount              `json:"gross_sales"` // SUM(subtotal)
+	DiscountTotal      money.Amount              `json:"discount_total"`
+	ServiceChargeTotal money.Amount              `json:"service_charge_total"`
+	TaxCollected       money.Amount              `json:"tax_collected"`
+	GrandTotal         money.Amount              `json:"grand_total"`
+	NetRevenue         money.Amount              `json:"net_revenue"` // grand_total - tax_collected
+	Breakdown          []TaxChargeResponse       `json:"breakdown"`
+	Outlets            []TaxReportOutletResponse `json:"outlets"` // Rincian per outlet
+}
@@ -14,6 +14,7 @@ type CreateUserRequest struct {
 	Password    string `json:"password" binding:"required,min=8"`
 	PhoneNumber string `json:"phone_number" binding:"required,numeric,max=30"`
 	Role        string `json:"role" binding:"required,oneof=owner cashier staff courier"`
+	OutletID    *int64 `json:"outlet_id" binding:"omitempty,min=1"` // Required for every role except owner
 }
 
 // UpdateUserRequest defines the payload for updating an existing employee profile.
@@ -25,6 +26,7 @@ type UpdateUserRequest struct {
 	Password    string `json:"password" binding:"omitempty,min=8"`
 	PhoneNumber string `json:"phone_number" binding:"omitempty,numeric,max=30"`
 	Role        string `json:"role" binding:"omitempty,oneof=owner cashier staff courier"`
+	OutletID    *int64 `json:"outlet_id" binding:"omitempty,min=1"` // Owner only: move the employee to another outlet
 	IsActive    *bool  `json:"is_active" binding:"omitempty"`
 }
 
@@ -39,6 +41,7 @@ type UserSummaryResponse struct {
 	FullName string `json:"full_name"`
 	Username string `json:"username"`
 	Role     string `json:"role"`
+	OutletID *int64 `json:"outlet_id"`
 	IsActive bool   `json:"is_active"`
 }
 
@@ -50,8 +53,10 @@ type UserDetailResponse struct {
 	Username    string `json:"username"`
 	Email       string `json:"email"`
 	Role        string `json:"role"`
+	OutletID    *int64 `json:"outlet_id"` // null = owner not bound to an outlet
 	PhoneNumber string `json:"phone_number"`
 	IsActive    bool   `json:"is_active"`
+	Version     int64  `json:"version"` // Same value as the ETag header
 	LastLoginAt string `json:"last_login_at"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at,omitempty"`
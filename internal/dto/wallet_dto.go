@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// CreateMembershipTierRequest digunakan saat Owner membuat level member (POST /membership-tiers)
+type CreateMembershipTierRequest struct {
+	TierName        string        `json:"tier_name" binding:"required"`
+	DiscountPercent money.Percent `json:"discount_percent" binding:"min=0,max=10000"` // Validator membaca satuan 0.01% (10000 = 100%)
+	MaxDiscount     *money.Amount `json:"max_discount" binding:"omitempty,gt=0"`
+	PointMultiplier *float64      `json:"point_multiplier" binding:"omitempty,gt=0"`
+}
+
+// UpdateMembershipTierRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+type UpdateMembershipTierRequest struct {
+	TierName        *string        `json:"tier_name"`
+	DiscountPercent *money.Percent `json:"discount_percent" binding:"omitempty,min=0,max=10000"`
+	MaxDiscount     *money.Amount  `json:"max_discount" binding:"omitempty,gt=0"`
+	PointMultiplier *float64       `json:"point_multiplier" binding:"omitempty,gt=0"`
+	IsActive        *bool          `json:"is_active"`
+}
+
+// AssignMembershipRequest digunakan saat Owner mengubah level member pelanggan (PUT /customers/:id/membership)
+// Kirim membership_tier_id: null untuk mencabut keanggotaan.
+type AssignMembershipRequest struct {
+	MembershipTierID *int64 `json:"membership_tier_id" binding:"omitempty,gt=0"`
+}
+
+// WalletTopUpRequest digunakan kasir saat pelanggan menyetor deposit (POST /customers/:id/wallet/top-ups)
+type WalletTopUpRequest struct {
+	Amount money.Amount `json:"amount" binding:"required,gt=0"`
+	Method string       `json:"method" binding:"required,oneof=cash transfer qris ewallet"`
+	Reason string       `json:"reason"`
+}
+
+// WalletAdjustmentRequest digunakan Owner untuk koreksi saldo (POST /customers/:id/wallet/adjustments)
+// Amount positif menambah saldo, negatif mengurangi saldo.
+type WalletAdjustmentRequest struct {
+	Amount money.Amount `json:"amount" binding:"required"`
+	Reason string       `json:"reason" binding:"required"`
+}
+
+// PointAdjustmentRequest digunakan Owner untuk koreksi poin (POST /customers/:id/points/adjustments)
+// Points positif menambah poin, negatif mengurangi poin.
+type PointAdjustmentRequest struct {
+	Points int    `json:"points" binding:"required"`
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// MembershipTierResponse untuk endpoint List, Create, dan Update level member
+type MembershipTierResponse struct {
+	ID              int64         `json:"id"`
+	TierName        string        `json:"tier_name"`
+	DiscountPercent money.Percent `json:"discount_percent"`
+	MaxDiscount     *money.Amount `json:"max_discount"`
+	PointMultiplier float64       `json:"point_multiplier"`
+	IsActive        bool          `json:"is_active"`
+	CreatedAt       string        `json:"created_at"`
+	UpdatedAt       *string       `json:"updated_at"`
+}
+
+// WalletEntryResponse adalah satu baris mutasi saldo deposit
+type WalletEntryResponse struct {
+	ID            int64        `json:"id"`
+	EntryType     string       `json:"entry_type"`
+	Direction     string       `json:"direction"`
+	Amount        money.Amount `json:"amount"`
+	BalanceBefore money.Amount `json:"balance_before"`
+	BalanceAfter  money.Amount `json:"balance_after"`
+	OrderID       *int64       `json:"order_id"`
+	PaymentMethod *string      `json:"payment_method"`
+	Reason        string       `json:"reason"`
+	ActorID       *int64       `json:"actor_id"`
+	CreatedAt     string       `json:"created_at"`
+}
+
+// PointEntryResponse adalah satu baris mutasi poin loyalitas
+type PointEntryResponse struct {
+	ID            int64  `json:"id"`
+	EntryType     string `json:"entry_type"`
+	Direction     string `json:"direction"`
+	Points        int    `json:"points"`
+	BalanceBefore int    `json:"balance_before"`
+	BalanceAfter  int    `json:"balance_after"`
+	OrderID       *int64 `json:"order_id"`
+	Reason        string `json:"reason"`
+	ActorID       *int64 `json:"actor_id"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// WalletResponse untuk endpoint ringkasan dompet pelanggan (GET /customers/:id/wallet)
+type WalletResponse struct {
+	CustomerID         int64                   `json:"customer_id"`
+	FullName           string                  `json:"full_name"`
+	PhoneNumber        string                  `json:"phone_number"`
+	Membership         *MembershipTierResponse `json:"membership"`
+	Balance            money.Amount            `json:"balance"`
+	Points             int                     `json:"points"`
+	PointValue         money.Amount            `json:"point_value"`  // Nilai tukar 1 poin (Rp)
+	PointsWorth        money.Amount            `json:"points_worth"` // Points x PointValue
+	RecentTransactions []WalletEntryResponse   `json:"recent_transactions"`
+	RecentPoints       []PointEntryResponse    `json:"recent_points"`
+}
+
+// WalletLedgerResponse untuk balasan riwayat mutasi saldo lengkap dengan Pagination
+type WalletLedgerResponse struct {
+	Data []WalletEntryResponse `json:"data"`
+	Meta response.MetaData     `json:"meta"`
+}
+
+// PointLedgerResponse untuk balasan riwayat mutasi poin lengkap dengan Pagination
+type PointLedgerResponse struct {
+	Data []PointEntryResponse `json:"data"`
+	Meta response.MetaData    `json:"meta"`
+}
@@ -0,0 +1,98 @@
+package dto
+
+import "laundry-backend/pkg/response"
+
+// ==========================================
+// 1. REQUEST DTO (Input from Client)
+// ==========================================
+
+// StartWashBatchRequest untuk endpoint POST /wash-batches.
+// codes berisi hasil scan: kode tag item (satu item) atau nomor nota (semua item pesanan).
+type StartWashBatchRequest struct {
+	MachineID int64    `json:"machine_id" binding:"required,min=1"`
+	Codes     []string `json:"codes" binding:"required,min=1,max=100,dive,required,max=50"`
+	Notes     *string  `json:"notes" binding:"omitempty,max=255"`
+}
+
+// ==========================================
+// 2. RESPONSE DTO (Output to Client)
+// ==========================================
+
+// WashBatchItemResponse adalah satu item pesanan di dalam batch
+type WashBatchItemResponse struct {
+	OrderID       int64   `json:"order_id"`
+	InvoiceNumber string  `json:"invoice_number"`
+	OrderItemID   int64   `json:"order_item_id"`
+	TagCode       *string `json:"tag_code"`
+	ServiceName   string  `json:"service_name"`
+	Unit          string  `json:"unit"`
+	Quantity      float64 `json:"quantity"`
+	LoadKg        float64 `json:"load_kg"`
+}
+
+// WashBatchOrderResponse adalah status pesanan setelah batch dimulai/selesai
+type WashBatchOrderResponse struct {
+	OrderID        int64  `json:"order_id"`
+	InvoiceNumber  string `json:"invoice_number"`
+	PreviousStatus string `json:"previous_status"`
+	StatusInternal string `json:"status_internal"`
+	StatusChanged  bool   `json:"status_changed"`
+}
+
+// WashBatchResponse adalah data satu batch mesin
+type WashBatchResponse struct {
+	ID              int64   `json:"id"`
+	OutletID        int64   `json:"outlet_id"`
+	MachineID       int64   `json:"machine_id"`
+	MachineName     string  `json:"machine_name"`
+	MachineType     string  `json:"machine_type"`
+	BatchStatus     string  `json:"batch_status"`
+	LoadKg          float64 `json:"load_kg"`
+	ItemCount       int64   `json:"item_count"`
+	Notes           *string `json:"notes"`
+	StartedBy       int64   `json:"started_by"`
+	StartedByName   string  `json:"started_by_name"`
+	StartedAt       string  `json:"started_at"`
+	FinishedBy      *int64  `json:"finished_by"`
+	FinishedByName  *string `json:"finished_by_name"`
+	FinishedAt      *string `json:"finished_at"`
+	DurationMinutes *int64  `json:"duration_minutes"` // null selama batch masih berjalan
+
+	Items  []WashBatchItemResponse  `json:"items,omitempty"`  // Hanya di detail, start & finish
+	Orders []WashBatchOrderResponse `json:"orders,omitempty"` // Hanya di start & finish
+}
+
+// WashBatchListResponse acts as a container for the Service layer to return data + pagination.
+type WashBatchListResponse struct {
+	Data []WashBatchResponse `json:"data"`
+	Meta response.MetaData   `json:"meta"`
+}
+
+// MachineUtilizationPeriod adalah rentang tanggal laporan utilisasi mesin
+type MachineUtilizationPeriod struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// MachineUtilizationRowResponse adalah pemakaian satu mesin dalam periode laporan
+type MachineUtilizationRowResponse struct {
+	MachineID          int64   `json:"machine_id"`
+	OutletID           int64   `json:"outlet_id"`
+	MachineName        string  `json:"machine_name"`
+	MachineType        string  `json:"machine_type"`
+	IsActive           bool    `json:"is_active"`
+	UnderMaintenance   bool    `json:"under_maintenance"`
+	BatchCount         int64   `json:"batch_count"`
+	LoadKg             float64 `json:"load_kg"`
+	BusyMinutes        int64   `json:"busy_minutes"`        // Lama mesin berjalan (dipotong ke periode laporan)
+	OpenMinutes        int64   `json:"open_minutes"`        // Jam buka outlet dalam periode laporan
+	UtilizationPercent float64 `json:"utilization_percent"` // busy_minutes / open_minutes x 100
+	AvgFillPercent     float64 `json:"avg_fill_percent"`    // Rata-rata muatan batch / capacity_kg x 100
+}
+
+// MachineUtilizationReportResponse untuk endpoint GET /reports/machine-utilization
+type MachineUtilizationReportResponse struct {
+	Period   MachineUtilizationPeriod        `json:"period"`
+	OutletID *int64                          `json:"outlet_id"` // null = konsolidasi semua outlet
+	Machines []MachineUtilizationRowResponse `json:"machines"`
+}
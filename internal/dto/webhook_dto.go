@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"encoding/json"
+	"laundry-backend/pkg/response"
+)
+
+// ==========================================
+// REQUEST DTO (Data yang masuk dari Frontend)
+// ==========================================
+
+// CreateWebhookRequest digunakan Owner untuk mendaftarkan endpoint penerima (POST /webhooks)
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=500"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Events      []string `json:"events" binding:"required,min=1,dive,oneof=order.created order.status_changed payment.confirmed delivery.finished order.overdue order.unclaimed"`
+}
+
+// UpdateWebhookRequest menggunakan pointer (*) untuk mendukung Partial Update (PUT)
+// Jika events dikirim, seluruh daftar lama akan diganti.
+type UpdateWebhookRequest struct {
+	URL         *string  `json:"url" binding:"omitempty,url,max=500"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Events      []string `json:"events" binding:"omitempty,min=1,dive,oneof=order.created order.status_changed payment.confirmed delivery.finished order.overdue order.unclaimed"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+// WebhookDeliveryQuery adalah filter log pengiriman (GET /webhooks/:id/deliveries)
+type WebhookDeliveryQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending processing delivered failed"`
+}
+
+// ==========================================
+// RESPONSE DTO (Data yang keluar ke Frontend)
+// ==========================================
+
+// WebhookEndpointResponse untuk endpoint List, Detail, dan Update
+// Secret tidak pernah ditampilkan utuh, hanya 4 karakter terakhir.
+type WebhookEndpointResponse struct {
+	ID          int64    `json:"id"`
+	URL         string   `json:"url"`
+	Description *string  `json:"description"`
+	Events      []string `json:"events"`
+	IsActive    bool     `json:"is_active"`
+	SecretHint  string   `json:"secret_hint"`
+	CreatedBy   *int64   `json:"created_by"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   *string  `json:"updated_at"`
+}
+
+// WebhookSecretResponse untuk Create & Rotate Secret: satu-satunya saat secret utuh dikembalikan
+type WebhookSecretResponse struct {
+	WebhookEndpointResponse
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryResponse adalah satu baris log pengiriman
+// Payload hanya diisi pada endpoint detail.
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	EndpointID     int64           `json:"endpoint_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  string          `json:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status"`
+	ResponseBody   *string         `json:"response_body"`
+	LastError      *string         `json:"last_error"`
+	DurationMs     *int            `json:"duration_ms"`
+	DeliveredAt    *string         `json:"delivered_at"`
+	ReplayOf       *int64          `json:"replay_of"`
+	CreatedAt      string          `json:"created_at"`
+	Payload        json.RawMessage `json:"payload,omitempty"`
+}
+
+// WebhookDeliveryListResponse untuk balasan log pengiriman lengkap dengan Pagination
+type WebhookDeliveryListResponse struct {
+	Data []WebhookDeliveryResponse `json:"data"`
+	Meta response.MetaData         `json:"meta"`
+}
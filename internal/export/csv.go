@@ -0,0 +1,86 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// csvFlushEvery mengatur seberapa sering buffer CSV dikirim ke klien
+const csvFlushEvery = 200
+
+// utf8BOM membuat Excel di Windows membaca file sebagai UTF-8 (nama pelanggan beraksen, dsb.)
+const utf8BOM = "\xEF\xBB\xBF"
+
+type csvWriter struct {
+	out     io.Writer
+	csv     *csv.Writer
+	lang    string
+	columns []Column
+	started bool
+	rows    int
+}
+
+func newCSVWriter(w io.Writer, lang string) *csvWriter {
+	return &csvWriter{out: w, csv: csv.NewWriter(w), lang: lang}
+}
+
+func (w *csvWriter) SetColumns(columns []Column) {
+	w.columns = columns
+}
+
+// start menulis BOM & baris judul sekali saja.
+func (w *csvWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	if _, err := io.WriteString(w.out, utf8BOM); err != nil {
+		return err
+	}
+	return w.csv.Write(labels(w.columns, w.lang))
+}
+
+func (w *csvWriter) WriteRow(values ...interface{}) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	record := make([]string, len(values))
+	for i, v := range values {
+		c := toCell(v, w.lang)
+		record[i] = c.text
+		if !c.numeric {
+			record[i] = escapeFormula(c.text)
+		}
+	}
+	if err := w.csv.Write(record); err != nil {
+		return err
+	}
+
+	// Kirim berkala agar klien langsung menerima data & memori server tetap kecil
+	w.rows++
+	if w.rows%csvFlushEvery == 0 {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+func (w *csvWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// escapeFormula mencegah CSV injection: teks yang diawali = + - @ dianggap rumus oleh spreadsheet,
+// jadi diberi awalan tanda kutip tunggal.
+func escapeFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
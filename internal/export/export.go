@@ -0,0 +1,201 @@
+// Package export menulis data list & laporan ke CSV atau XLSX untuk pembukuan owner di spreadsheet.
+//
+// Writer menulis baris demi baris langsung ke io.Writer (response HTTP), sehingga export ribuan
+// baris tidak perlu ditampung di memori. Judul kolom tersedia dalam Bahasa Indonesia & Inggris,
+// nominal rupiah ditulis sebagai angka polos, dan waktu ditampilkan dalam zona Asia/Jakarta.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"laundry-backend/pkg/money"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format export yang didukung
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// MIME type untuk content negotiation (header Accept) & Content-Type balasan
+const (
+	MimeCSV  = "text/csv"
+	MimeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Bahasa judul kolom
+const (
+	LangID = "id"
+	LangEN = "en"
+)
+
+// ErrUnsupportedFormat dikembalikan jika format bukan csv atau xlsx.
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// dateTimeLayout adalah format waktu di file export (zona Asia/Jakarta)
+const dateTimeLayout = "2006-01-02 15:04:05"
+
+// jakarta adalah zona waktu outlet. WIB tidak mengenal DST, jadi FixedZone aman jika tzdata tidak tersedia.
+var jakarta = loadJakarta()
+
+func loadJakarta() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Jakarta"); err == nil {
+		return loc
+	}
+	return time.FixedZone("WIB", 7*60*60)
+}
+
+// Column adalah satu kolom export beserta judulnya dalam dua bahasa.
+type Column struct {
+	ID string // Judul Bahasa Indonesia (default)
+	EN string // Judul Bahasa Inggris
+}
+
+// Writer menulis satu tabel export.
+//
+// SetColumns hanya menyimpan judul; judul baru benar-benar ditulis bersama baris pertama (atau saat Close
+// jika data kosong). Dengan begitu pemanggil masih bisa membalas error JSON selama belum ada baris yang ditulis.
+type Writer interface {
+	SetColumns(columns []Column)
+	WriteRow(values ...interface{}) error
+	Close() error
+}
+
+// NewWriter membuat Writer sesuai format. lang menentukan bahasa judul kolom (LangID / LangEN).
+func NewWriter(format string, w io.Writer, lang string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, lang), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, lang), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// Negotiate menentukan format export dari query ?format= (prioritas) atau header Accept.
+// Mengembalikan false jika klien meminta JSON biasa. Format ?format= yang tidak dikenal mengembalikan error.
+func Negotiate(formatParam, accept string) (string, bool, error) {
+	switch strings.ToLower(strings.TrimSpace(formatParam)) {
+	case FormatCSV:
+		return FormatCSV, true, nil
+	case FormatXLSX:
+		return FormatXLSX, true, nil
+	case "", "json":
+	default:
+		return "", false, fmt.Errorf("%w: %s", ErrUnsupportedFormat, formatParam)
+	}
+
+	if formatParam == "" {
+		accept = strings.ToLower(accept)
+		switch {
+		case strings.Contains(accept, MimeCSV):
+			return FormatCSV, true, nil
+		case strings.Contains(accept, MimeXLSX):
+			return FormatXLSX, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// LangFromAcceptLanguage memilih bahasa judul kolom dari header Accept-Language (default Bahasa Indonesia).
+func LangFromAcceptLanguage(header string) string {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(header)), LangEN) {
+		return LangEN
+	}
+	return LangID
+}
+
+// ContentType mengembalikan MIME type balasan untuk format export.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return MimeXLSX
+	}
+	return MimeCSV + "; charset=utf-8"
+}
+
+// FileName menyusun nama file unduhan, cth: services-20260120-101500.csv
+func FileName(base, format string, now time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", base, now.In(jakarta).Format("20060102-150405"), format)
+}
+
+// labels mengambil judul kolom sesuai bahasa.
+func labels(columns []Column, lang string) []string {
+	out := make([]string, len(columns))
+	for i, col := range columns {
+		out[i] = col.ID
+		if lang == LangEN && col.EN != "" {
+			out[i] = col.EN
+		}
+	}
+	return out
+}
+
+// cell adalah nilai sel yang sudah dinormalisasi: teks atau angka.
+type cell struct {
+	text    string
+	numeric bool
+}
+
+// toCell mengubah nilai Go menjadi isi sel. Nominal & angka ditulis polos (tanpa pemisah ribuan/Rp),
+// waktu dikonversi ke Asia/Jakarta, bool menjadi Ya/Tidak sesuai bahasa, pointer nil menjadi sel kosong.
+func toCell(value interface{}, lang string) cell {
+	switch v := value.(type) {
+	case nil:
+		return cell{}
+	case bool:
+		switch {
+		case lang == LangEN && v:
+			return cell{text: "Yes"}
+		case lang == LangEN:
+			return cell{text: "No"}
+		case v:
+			return cell{text: "Ya"}
+		default:
+			return cell{text: "Tidak"}
+		}
+	case string:
+		return cell{text: v}
+	case *string:
+		if v == nil {
+			return cell{}
+		}
+		return cell{text: *v}
+	case money.Amount:
+		return cell{text: v.String(), numeric: true}
+	case *money.Amount:
+		if v == nil {
+			return cell{}
+		}
+		return cell{text: v.String(), numeric: true}
+	case money.Percent:
+		return cell{text: v.String(), numeric: true}
+	case int:
+		return cell{text: strconv.Itoa(v), numeric: true}
+	case int64:
+		return cell{text: strconv.FormatInt(v, 10), numeric: true}
+	case *int64:
+		if v == nil {
+			return cell{}
+		}
+		return cell{text: strconv.FormatInt(*v, 10), numeric: true}
+	case float64:
+		return cell{text: strconv.FormatFloat(v, 'f', -1, 64), numeric: true}
+	case time.Time:
+		if v.IsZero() {
+			return cell{}
+		}
+		return cell{text: v.In(jakarta).Format(dateTimeLayout)}
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return cell{}
+		}
+		return cell{text: v.In(jakarta).Format(dateTimeLayout)}
+	default:
+		return cell{text: fmt.Sprint(v)}
+	}
+}
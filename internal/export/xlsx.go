@@ -0,0 +1,157 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// XLSX ditulis sebagai paket OOXML minimal: satu sheet dengan inline string (tanpa sharedStrings),
+// sehingga setiap baris bisa langsung ditulis ke entri zip tanpa menunggu seluruh data terkumpul.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`
+
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Data" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+
+	// Style 1 = judul kolom tebal
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs></styleSheet>`
+
+	xlsxSheetHead = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	xlsxSheetTail = `</sheetData></worksheet>`
+)
+
+type xlsxWriter struct {
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	lang    string
+	columns []Column
+	started bool
+}
+
+func newXLSXWriter(w io.Writer, lang string) *xlsxWriter {
+	return &xlsxWriter{zip: zip.NewWriter(w), lang: lang}
+}
+
+func (w *xlsxWriter) SetColumns(columns []Column) {
+	w.columns = columns
+}
+
+// start menulis bagian statis paket lalu membuka entri sheet & menulis baris judul.
+func (w *xlsxWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	// 1. Bagian statis (kecil, ditulis di depan agar entri sheet bisa di-stream sampai akhir)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		f, err := w.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+
+	// 2. Entri sheet tetap terbuka sampai Close
+	f, err := w.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	w.sheet = bufio.NewWriter(f)
+	if _, err := w.sheet.WriteString(xlsxSheetHead); err != nil {
+		return err
+	}
+
+	// 3. Baris judul (style tebal)
+	w.sheet.WriteString("<row>")
+	for _, label := range labels(w.columns, w.lang) {
+		w.sheet.WriteString(`<c t="inlineStr" s="1"><is><t xml:space="preserve">`)
+		w.sheet.WriteString(xmlText(label))
+		w.sheet.WriteString("</t></is></c>")
+	}
+	_, err = w.sheet.WriteString("</row>")
+	return err
+}
+
+func (w *xlsxWriter) WriteRow(values ...interface{}) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	w.sheet.WriteString("<row>")
+	for _, v := range values {
+		c := toCell(v, w.lang)
+		switch {
+		case c.text == "":
+			w.sheet.WriteString("<c/>")
+		case c.numeric:
+			w.sheet.WriteString("<c><v>")
+			w.sheet.WriteString(c.text)
+			w.sheet.WriteString("</v></c>")
+		default:
+			w.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			w.sheet.WriteString(xmlText(c.text))
+			w.sheet.WriteString("</t></is></c>")
+		}
+	}
+	_, err := w.sheet.WriteString("</row>")
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if _, err := w.sheet.WriteString(xlsxSheetTail); err != nil {
+		return err
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+// xmlText meng-escape teks untuk isi elemen XML dan membuang karakter kontrol yang dilarang XML 1.0.
+func xmlText(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		switch {
+		case r == '&':
+			b.WriteString("&amp;")
+		case r == '<':
+			b.WriteString("&lt;")
+		case r == '>':
+			b.WriteString("&gt;")
+		case r == '\t' || r == '\n' || r == '\r':
+			b.WriteRune(r)
+		case r < 0x20 || r == utf8.RuneError || r == 0xFFFE || r == 0xFFFF:
+			// karakter tidak valid di XML, dilewati
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
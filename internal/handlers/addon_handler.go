@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AddonHandler struct {
+	addonService services.AddonService
+}
+
+func NewAddonHandler(addonService services.AddonService) *AddonHandler {
+	return &AddonHandler{addonService: addonService}
+}
+
+func (h *AddonHandler) HandleCreateAddon(c *gin.Context) {
+
+	var req dto.CreateAddonRequest
+
+	// 1. Validasi Payload JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Eksekusi Service dengan membawa Context
+	res, err := h.addonService.CreateAddon(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid add-on data", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Add-on code already exists", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateAddon: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create add-on", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Add-on created successfully", res)
+}
+
+func (h *AddonHandler) HandleGetAddonList(c *gin.Context) {
+
+	// 1. Ambil nilai dari URL Query Parameters
+	search := c.Query("search")
+	status := c.Query("status")
+
+	// Kasir hanya boleh melihat add-on yang aktif
+	if c.GetString("role") == "cashier" {
+		status = "1"
+	}
+
+	// 2. Konversi tipe data dengan "Safety Net"
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+	serviceID, _ := strconv.ParseInt(c.Query("service_id"), 10, 64)
+
+	// 3. Panggil Service
+	res, err := h.addonService.GetAddonList(c.Request.Context(), page, perPage, search, status, serviceID)
+	if err != nil {
+		fmt.Printf("[ERROR] GetAddonList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve add-ons", nil)
+		return
+	}
+
+	// 4. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Add-ons retrieved successfully", res.Data, res.Meta)
+}
+
+func (h *AddonHandler) HandleGetAddonDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.addonService.GetAddonDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Add-on not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetAddonDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve add-on detail", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Add-on detail retrieved successfully", res)
+}
+
+func (h *AddonHandler) HandleUpdateAddon(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Ambil Data JSON dari Body
+	var req dto.UpdateAddonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.addonService.ModifyAddon(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid add-on data", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Add-on not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Add-on code already taken", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] ModifyAddon: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update add-on", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Add-on updated successfully", res)
+}
+
+func (h *AddonHandler) HandleDeleteAddon(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service untuk menonaktifkan add-on
+	if err := h.addonService.DeactivateAddon(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Add-on not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeactivateAddon: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete add-on", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Add-on deleted successfully", map[string]int64{"id": id})
+}
@@ -42,6 +42,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			response.ErrorResponse(c, http.StatusUnauthorized, response.CodeInvalidCredentials, "Invalid username or password", nil)
 		} else if errors.Is(err, response.ErrAccountInactive) {
 			response.ErrorResponse(c, http.StatusForbidden, response.CodeAccountInactive, "Your account is inactive", nil)
+		} else if errors.Is(err, response.ErrForbidden) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "Your account is not assigned to an outlet", nil)
 		} else {
 			response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "An unexpected error occurred", nil)
 		}
@@ -74,6 +76,10 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 			response.ErrorResponse(c, http.StatusUnauthorized, response.CodeTokenExpired, "Session expired, please login again", nil)
 		} else if errors.Is(err, response.ErrUserNotFound) {
 			response.ErrorResponse(c, http.StatusUnauthorized, response.CodeUserNotFound, "User account not found", nil)
+		} else if errors.Is(err, response.ErrForbidden) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "Your account is not assigned to an outlet", nil)
+		} else if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid outlet", err.Error())
 		} else {
 			response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "An unexpected error occurred", nil)
 		}
@@ -153,3 +159,51 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 	// 3. Success Response
 	response.SuccessOK(c, "User profile retrieved successfully", res)
 }
+
+// SwitchOutlet issues a new access token scoped to another outlet (0 = all outlets).
+// @Summary Switch Active Outlet (Owner only)
+// @Router /api/v1/auth/switch-outlet [post]
+func (h *AuthHandler) SwitchOutlet(c *gin.Context) {
+
+	var req dto.SwitchOutletRequest
+
+	// 1. Validate Input
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid input format", err.Error())
+		return
+	}
+
+	// 2. Extract UserID from Context (Set by Middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorResponse(c, http.StatusUnauthorized, response.CodeUnauthorized, "User context missing", nil)
+		return
+	}
+
+	// 3. Call Service
+	res, err := h.authService.SwitchOutlet(c.Request.Context(), userID.(int64), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid outlet", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrForbidden) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "Only owners can switch outlets", nil)
+			return
+		}
+		if errors.Is(err, response.ErrAccountInactive) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeAccountInactive, "Your account is inactive", nil)
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusUnauthorized, response.CodeUserNotFound, "User account not found", nil)
+			return
+		}
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to switch outlet", nil)
+		return
+	}
+
+	// 4. Success Response
+	response.SuccessOK(c, "Active outlet switched successfully", res)
+}
@@ -0,0 +1,395 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CapacityHandler struct {
+	capacityService services.CapacityService
+}
+
+func NewCapacityHandler(capacityService services.CapacityService) *CapacityHandler {
+	return &CapacityHandler{capacityService: capacityService}
+}
+
+// --- MACHINES ---
+
+// HandleCreateMachine handles POST /api/v1/machines.
+func (h *CapacityHandler) HandleCreateMachine(c *gin.Context) {
+
+	// 1. Validasi Payload JSON
+	var req dto.CreateMachineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.capacityService.CreateMachine(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot register machine", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Machine name already exists at this outlet", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateMachine: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create machine", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Machine created successfully", res)
+}
+
+// HandleGetMachineList handles GET /api/v1/machines?status=.
+func (h *CapacityHandler) HandleGetMachineList(c *gin.Context) {
+
+	// 1. Selain owner hanya melihat mesin aktif; owner bebas memfilter status ("", "1", "0")
+	status := c.Query("status")
+	if c.GetString("role") != "owner" {
+		status = "1"
+	}
+
+	// 2. Panggil Service
+	res, err := h.capacityService.GetMachineList(c.Request.Context(), status)
+	if err != nil {
+		fmt.Printf("[ERROR] GetMachineList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve machines", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Machines retrieved successfully", res)
+}
+
+// HandleGetMachineDetail handles GET /api/v1/machines/:id.
+func (h *CapacityHandler) HandleGetMachineDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.capacityService.GetMachineDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Machine not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetMachineDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve machine", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Machine retrieved successfully", res)
+}
+
+// HandleUpdateMachine handles PUT /api/v1/machines/:id.
+func (h *CapacityHandler) HandleUpdateMachine(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.UpdateMachineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.capacityService.ModifyMachine(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Machine not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Machine name already taken at this outlet", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] ModifyMachine: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update machine", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Machine updated successfully", res)
+}
+
+// HandleDeleteMachine handles DELETE /api/v1/machines/:id (soft delete).
+func (h *CapacityHandler) HandleDeleteMachine(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service untuk menonaktifkan mesin
+	if err := h.capacityService.DeactivateMachine(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Machine not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeactivateMachine: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete machine", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Machine deleted successfully", map[string]int64{"id": id})
+}
+
+// HandleSetMachineMaintenance handles PATCH /api/v1/machines/:id/maintenance.
+func (h *CapacityHandler) HandleSetMachineMaintenance(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.UpdateMachineMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.capacityService.SetMachineMaintenance(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Machine not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] SetMachineMaintenance: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update machine maintenance", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Machine maintenance updated successfully", res)
+}
+
+// --- OPERATING CALENDAR ---
+
+// HandleGetOperatingHours handles GET /api/v1/capacity/operating-hours.
+func (h *CapacityHandler) HandleGetOperatingHours(c *gin.Context) {
+
+	res, err := h.capacityService.GetOperatingHours(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot retrieve operating hours", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetOperatingHours: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve operating hours", nil)
+		return
+	}
+
+	response.SuccessOK(c, "Operating hours retrieved successfully", res)
+}
+
+// HandleReplaceOperatingHours handles PUT /api/v1/capacity/operating-hours.
+func (h *CapacityHandler) HandleReplaceOperatingHours(c *gin.Context) {
+
+	// 1. Validasi Payload JSON
+	var req dto.ReplaceOperatingHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.capacityService.ReplaceOperatingHours(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid operating hours", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] ReplaceOperatingHours: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update operating hours", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Operating hours updated successfully", res)
+}
+
+// HandleGetHolidays handles GET /api/v1/capacity/holidays?start_date=&end_date=.
+func (h *CapacityHandler) HandleGetHolidays(c *gin.Context) {
+
+	res, err := h.capacityService.GetHolidays(c.Request.Context(), c.Query("start_date"), c.Query("end_date"))
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid query parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetHolidays: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve holidays", nil)
+		return
+	}
+
+	response.SuccessOK(c, "Holidays retrieved successfully", res)
+}
+
+// HandleCreateHoliday handles POST /api/v1/capacity/holidays.
+func (h *CapacityHandler) HandleCreateHoliday(c *gin.Context) {
+
+	// 1. Validasi Payload JSON
+	var req dto.CreateHolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.capacityService.CreateHoliday(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot add holiday", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Holiday already registered on this date", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateHoliday: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create holiday", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Holiday created successfully", res)
+}
+
+// HandleDeleteHoliday handles DELETE /api/v1/capacity/holidays/:id.
+func (h *CapacityHandler) HandleDeleteHoliday(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	if err := h.capacityService.RemoveHoliday(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Holiday not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] RemoveHoliday: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete holiday", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Holiday deleted successfully", map[string]int64{"id": id})
+}
+
+// --- WORKLOAD & ETA ---
+
+// HandleGetWorkload handles GET /api/v1/capacity/workload.
+func (h *CapacityHandler) HandleGetWorkload(c *gin.Context) {
+
+	res, err := h.capacityService.GetWorkload(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot calculate workload", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetWorkload: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to calculate workload", nil)
+		return
+	}
+
+	response.SuccessOK(c, "Workload retrieved successfully", res)
+}
+
+// HandleQuoteOrder handles POST /api/v1/orders/quote (total checkout + estimasi selesai, tanpa menyimpan apa pun).
+func (h *CapacityHandler) HandleQuoteOrder(c *gin.Context) {
+
+	var req dto.OrderTotalsRequest
+
+	// 1. Validasi Payload JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.capacityService.QuoteOrder(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot quote order", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Voucher code not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrPromotionExhausted) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodePromotionExhausted, "Voucher quota has been used up", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrPromotionNotApplicable) {
+			response.ErrorResponse(c, http.StatusUnprocessableEntity, response.CodePromotionNotApplicable, "Voucher cannot be applied to this cart", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] QuoteOrder: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to quote order", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Order quoted successfully", res)
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
 	"laundry-backend/internal/services"
+	"laundry-backend/pkg/listquery"
 	"laundry-backend/pkg/response"
 	"net/http"
 	"strconv"
@@ -55,13 +57,8 @@ func (h *CategoryHandler) HandleCreateCategory(c *gin.Context) {
 
 func (h *CategoryHandler) HandleGetCategoryList(c *gin.Context) {
 
-	// 1. Ambil nilai dari URL Query Parameters
-	pageStr := c.DefaultQuery("page", "1")
-	perPageStr := c.DefaultQuery("per_page", "10")
-	search := c.Query("search")
-	status := c.Query("status") // <-- Ini asalnya bisa kosong (""), "1", atau "0"
-	sortBy := c.Query("sort_by")
-	sortOrder := c.Query("order")
+	// 1. Ambil parameter list (page/cursor, per_page, search, status, sort_by, order)
+	params := listquery.ParseParams(c.Request.URL.Query(), "status") // status bisa kosong (""), "1", atau "0"
 
 	// ===============================================================
 	// 🛡️ [TAMBAHAN VIP-7: ROLE-BASED FILTERING]
@@ -73,36 +70,45 @@ func (h *CategoryHandler) HandleGetCategoryList(c *gin.Context) {
 	// Jika Kasir, PAKSA status menjadi "1" (Hanya Aktif).
 	// Meskipun Kasir iseng ngetik URL: ?status=0, kita timpa jadi 1.
 	if userRole == "cashier" {
-		status = "1"
+		params.Filters["status"] = "1"
 	}
 	// Jika Owner, biarkan variabel status apa adanya.
 	// Owner bisa melihat semua (status=""), yang aktif saja (status="1"),
 	// atau yang sudah dihapus saja (status="0").
 	// ===============================================================
 
-	// 2. Konversi tipe data dengan "Safety Net" (Keamanan Ekstra)
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1 // Fallback aman: jika user iseng masukin huruf atau angka minus, paksa jadi halaman 1
+	// 2. Panggil Koki (Service): export CSV/XLSX jika diminta (?format= / Accept), selain itu JSON per halaman
+	format, ok := negotiateExport(c)
+	if !ok {
+		return
 	}
 
-	perPage, err := strconv.Atoi(perPageStr)
-	if err != nil || perPage < 1 {
-		perPage = 10 // Fallback aman: paksa jadi 10 item per halaman
+	var result *dto.CategoryListResponse
+	var err error
+	if format != "" {
+		err = streamExport(c, "categories", format, func(w export.Writer) error {
+			return h.categoryService.ExportCategoryList(c.Request.Context(), params, w)
+		})
+	} else {
+		result, err = h.categoryService.GetCategoryList(c.Request.Context(), params)
 	}
-
-	// 3. Panggil Koki (Service)
-	// [PERBAIKAN 1] Gunakan h.categoryService
-	result, err := h.categoryService.GetCategoryList(c.Request.Context(), page, perPage, search, status, sortBy, sortOrder)
 	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid list parameters", err.Error())
+			return
+		}
+
 		fmt.Printf("[ERROR] GetCategoryList: %v\n", err)
 
 		// [PERBAIKAN 2] Gunakan String Code (CodeInternalServer) untuk balasan ke Frontend
 		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve categories", nil)
 		return
 	}
+	if format != "" {
+		return
+	}
 
-	// 4. Sukses dengan Meta (Pagination)
+	// 3. Sukses dengan Meta (Pagination)
 	response.SuccessMeta(c, "Categories retrieved successfully", result.Data, result.Meta)
 }
 
@@ -135,7 +141,8 @@ func (h *CategoryHandler) HandleGetCategoryDetail(c *gin.Context) {
 		return
 	}
 
-	// 3. Sukses
+	// 3. Sukses (ETag dipakai klien sebagai If-Match saat update)
+	setETag(c, result.Version)
 	response.SuccessOK(c, "Category detail retrieved successfully", result)
 }
 
@@ -158,9 +165,15 @@ func (h *CategoryHandler) HandleUpdateCategory(c *gin.Context) {
 		return
 	}
 
+	// Versi yang diharapkan klien (header If-Match, opsional)
+	expectedVersion, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// 3. Panggil Koki (Service)
 	// [PERBAIKAN] Gunakan h.categoryService
-	result, err := h.categoryService.ModifyCategory(c.Request.Context(), id, req)
+	result, err := h.categoryService.ModifyCategory(c.Request.Context(), id, req, expectedVersion)
 	if err != nil {
 		// [PERBAIKAN] Gunakan errors.Is untuk cek Not Found
 		if errors.Is(err, response.ErrNotFound) {
@@ -168,6 +181,12 @@ func (h *CategoryHandler) HandleUpdateCategory(c *gin.Context) {
 			return
 		}
 
+		// Kategori sudah diubah Owner lain sejak dibaca
+		if errors.Is(err, response.ErrPreconditionFailed) {
+			respondPreconditionFailed(c)
+			return
+		}
+
 		// [PERBAIKAN] Gunakan errors.Is untuk cek Duplicate (Misal nama barunya bentrok)
 		if errors.Is(err, response.ErrDuplicate) {
 			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Category name already taken", nil)
@@ -182,6 +201,7 @@ func (h *CategoryHandler) HandleUpdateCategory(c *gin.Context) {
 	}
 
 	// 4. Sukses
+	setETag(c, result.Version)
 	response.SuccessOK(c, "Category updated successfully", result)
 }
 
@@ -196,9 +216,15 @@ func (h *CategoryHandler) HandleDeleteCategory(c *gin.Context) {
 		return
 	}
 
-	// 2. Panggil Koki (Service) untuk menonaktifkan kategori
+	// 2. Ambil versi yang diharapkan (If-Match)
+	expectedVersion, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	// 3. Panggil Koki (Service) untuk menonaktifkan kategori
 	// [PERBAIKAN] Gunakan h.categoryService
-	err = h.categoryService.DeactivateCategory(c.Request.Context(), id)
+	err = h.categoryService.DeactivateCategory(c.Request.Context(), id, expectedVersion)
 	if err != nil {
 		// [PERBAIKAN] Gunakan errors.Is untuk cek Not Found
 		if errors.Is(err, response.ErrNotFound) {
@@ -207,6 +233,11 @@ func (h *CategoryHandler) HandleDeleteCategory(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, response.ErrPreconditionFailed) {
+			respondPreconditionFailed(c)
+			return
+		}
+
 		fmt.Printf("[ERROR] DeactivateCategory: %v\n", err)
 
 		// [PERBAIKAN] Gunakan String Code (CodeInternalServer)
@@ -214,7 +245,7 @@ func (h *CategoryHandler) HandleDeleteCategory(c *gin.Context) {
 		return
 	}
 
-	// 3. Sukses
+	// 4. Sukses
 	// Catatan: Mengembalikan map berisi ID yang dihapus adalah praktik yang sangat bagus!
 	response.SuccessOK(c, "Category deleted successfully", map[string]int64{"id": id})
 }
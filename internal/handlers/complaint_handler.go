@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ComplaintHandler struct {
+	complaintService services.ComplaintService
+}
+
+func NewComplaintHandler(complaintService services.ComplaintService) *ComplaintHandler {
+	return &ComplaintHandler{complaintService: complaintService}
+}
+
+// HandleCreateComplaint handles POST /api/v1/complaints.
+func (h *ComplaintHandler) HandleCreateComplaint(c *gin.Context) {
+
+	// 1. Ambil identitas pelapor dari Auth Middleware
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.CreateComplaintRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.complaintService.CreateComplaint(c.Request.Context(), req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot create complaint", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Order not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateComplaint: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create complaint", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Complaint created successfully", res)
+}
+
+// HandleGetComplaintList handles GET /api/v1/complaints.
+func (h *ComplaintHandler) HandleGetComplaintList(c *gin.Context) {
+
+	// 1. Ambil parameter list (page/cursor, per_page, search, filter, sort_by, order)
+	params := listquery.ParseParams(c.Request.URL.Query(), "order_id", "complaint_type", "complaint_status", "resolution_type", "reported_by", "start_date", "end_date", "outlet_id")
+
+	// 2. Panggil Service
+	res, err := h.complaintService.GetComplaints(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid list parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetComplaints: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve complaints", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Complaints retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleGetComplaintDetail handles GET /api/v1/complaints/:id.
+func (h *ComplaintHandler) HandleGetComplaintDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.complaintService.GetComplaintDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Complaint not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetComplaintDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve complaint", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Complaint retrieved successfully", res)
+}
+
+// HandleAddComplaintPhotos handles POST /api/v1/complaints/:id/photos.
+func (h *ComplaintHandler) HandleAddComplaintPhotos(c *gin.Context) {
+
+	// 1. Ambil ID dari URL & identitas pengunggah
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.AddComplaintPhotosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.complaintService.AddPhotos(c.Request.Context(), id, req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Complaint not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrInvalidTransition) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeInvalidTransition, "Complaint is already closed", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] AddComplaintPhotos: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to add complaint photos", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Complaint photos added successfully", res)
+}
+
+// HandleUpdateComplaintStatus handles PATCH /api/v1/complaints/:id/status.
+func (h *ComplaintHandler) HandleUpdateComplaintStatus(c *gin.Context) {
+
+	// 1. Ambil ID dari URL & identitas petugas
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.UpdateComplaintStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.complaintService.UpdateStatus(c.Request.Context(), id, req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Complaint not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrInvalidTransition) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeInvalidTransition, "Status transition is not allowed", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] UpdateComplaintStatus: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update complaint status", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Complaint status updated successfully", res)
+}
+
+// HandleResolveComplaint handles POST /api/v1/complaints/:id/resolve.
+func (h *ComplaintHandler) HandleResolveComplaint(c *gin.Context) {
+
+	// 1. Ambil ID dari URL & identitas owner
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+	actorRole := c.GetString("role")
+
+	// 2. Validasi Payload JSON
+	var req dto.ResolveComplaintRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.complaintService.ResolveComplaint(c.Request.Context(), id, req, actorID, actorRole)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot resolve complaint", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Complaint not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrInvalidTransition) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeInvalidTransition, "Complaint is already closed", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] ResolveComplaint: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to resolve complaint", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Complaint resolved successfully", res)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag menulis header ETag dari kolom version, cth: ETag: "3"
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// parseIfMatch membaca header If-Match menjadi versi yang diharapkan klien.
+// Tanpa header atau "*" → nil (tanpa syarat). Hanya satu entity tag yang didukung;
+// nilai yang tidak bisa dibaca tidak mungkin cocok, jadi langsung dibalas 412.
+func parseIfMatch(c *gin.Context) (*int64, bool) {
+	version, ok := ifMatchVersion(c.GetHeader("If-Match"))
+	if !ok {
+		respondPreconditionFailed(c)
+		return nil, false
+	}
+	return version, true
+}
+
+// ifMatchVersion mengurai nilai If-Match. If-Match memakai strong comparison (RFC 9110 §13.1.1),
+// sehingga weak tag (W/"3") tidak pernah cocok dan ditolak, sama seperti nilai yang tidak bisa dibaca.
+func ifMatchVersion(header string) (*int64, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, true
+	}
+	if strings.HasPrefix(header, "W/") {
+		return nil, false
+	}
+
+	unquoted, err := strconv.Unquote(header)
+	if err != nil {
+		unquoted = header
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	return &version, true
+}
+
+// respondPreconditionFailed membalas 412 saat data sudah diubah request lain sejak dibaca klien.
+func respondPreconditionFailed(c *gin.Context) {
+	response.ErrorResponse(c, http.StatusPreconditionFailed, response.CodePreconditionFailed, "Resource has been modified by another request", "Reload the latest data and retry with its ETag in If-Match")
+}
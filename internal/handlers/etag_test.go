@@ -0,0 +1,43 @@
+package handlers
+
+import "testing"
+
+func TestIfMatchVersion(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		header string
+		want   *int64
+		wantOK bool
+	}{
+		{name: "absent", header: "", want: nil, wantOK: true},
+		{name: "wildcard", header: "*", want: nil, wantOK: true},
+		{name: "strong quoted", header: `"3"`, want: int64Ptr(3), wantOK: true},
+		{name: "strong unquoted", header: "3", want: int64Ptr(3), wantOK: true},
+		{name: "surrounding spaces", header: ` "12" `, want: int64Ptr(12), wantOK: true},
+		{name: "weak tag rejected", header: `W/"3"`, wantOK: false},
+		{name: "not a version", header: `"abc"`, wantOK: false},
+		{name: "list not supported", header: `"3", "4"`, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ifMatchVersion(tt.header)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("version = %v, want %v", versionOf(got), versionOf(tt.want))
+			}
+		})
+	}
+}
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func versionOf(v *int64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExpenseHandler struct {
+	expenseService services.ExpenseService
+}
+
+func NewExpenseHandler(expenseService services.ExpenseService) *ExpenseHandler {
+	return &ExpenseHandler{expenseService: expenseService}
+}
+
+// --- EXPENSE CATEGORIES ---
+
+// HandleCreateExpenseCategory handles POST /api/v1/expense-categories.
+func (h *ExpenseHandler) HandleCreateExpenseCategory(c *gin.Context) {
+
+	// 1. Validasi Payload JSON
+	var req dto.CreateExpenseCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.expenseService.CreateCategory(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Expense category name already exists", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateExpenseCategory: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create expense category", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Expense category created successfully", res)
+}
+
+// HandleGetExpenseCategoryList handles GET /api/v1/expense-categories?status=.
+func (h *ExpenseHandler) HandleGetExpenseCategoryList(c *gin.Context) {
+
+	// 1. Kasir hanya melihat kategori aktif; owner bebas memfilter status ("", "1", "0")
+	status := c.Query("status")
+	if c.GetString("role") == "cashier" {
+		status = "1"
+	}
+
+	// 2. Panggil Service
+	res, err := h.expenseService.GetCategoryList(c.Request.Context(), status)
+	if err != nil {
+		fmt.Printf("[ERROR] GetExpenseCategoryList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve expense categories", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Expense categories retrieved successfully", res)
+}
+
+// HandleGetExpenseCategoryDetail handles GET /api/v1/expense-categories/:id.
+func (h *ExpenseHandler) HandleGetExpenseCategoryDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.expenseService.GetCategoryDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Expense category not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetExpenseCategoryDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve expense category", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Expense category retrieved successfully", res)
+}
+
+// HandleUpdateExpenseCategory handles PUT /api/v1/expense-categories/:id.
+func (h *ExpenseHandler) HandleUpdateExpenseCategory(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.UpdateExpenseCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.expenseService.ModifyCategory(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Expense category not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Expense category name already taken", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] ModifyExpenseCategory: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update expense category", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Expense category updated successfully", res)
+}
+
+// HandleDeleteExpenseCategory handles DELETE /api/v1/expense-categories/:id (soft delete).
+func (h *ExpenseHandler) HandleDeleteExpenseCategory(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service untuk menonaktifkan kategori
+	if err := h.expenseService.DeactivateCategory(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Expense category not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeactivateExpenseCategory: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete expense category", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Expense category deleted successfully", map[string]int64{"id": id})
+}
+
+// --- EXPENSES ---
+
+// HandleCreateExpense handles POST /api/v1/expenses.
+func (h *ExpenseHandler) HandleCreateExpense(c *gin.Context) {
+
+	// 1. Ambil ID pencatat dari token
+	requesterID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.CreateExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.expenseService.RecordExpense(c.Request.Context(), req, requesterID)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot record expense", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] RecordExpense: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to record expense", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Expense recorded successfully", res)
+}
+
+// HandleGetExpenseList handles GET /api/v1/expenses.
+func (h *ExpenseHandler) HandleGetExpenseList(c *gin.Context) {
+
+	// 1. Ambil parameter list (page/cursor, per_page, search, filter kategori/pencatat/tanggal, sort_by, order)
+	params := listquery.ParseParams(c.Request.URL.Query(), "expense_category_id", "created_by", "start_date", "end_date", "outlet_id")
+
+	// 2. Panggil Service
+	res, err := h.expenseService.GetExpenses(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid list parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetExpenses: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve expenses", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Expenses retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleGetExpenseDetail handles GET /api/v1/expenses/:id.
+func (h *ExpenseHandler) HandleGetExpenseDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.expenseService.GetExpenseDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Expense not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetExpenseDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve expense", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Expense retrieved successfully", res)
+}
+
+// HandleUpdateExpense handles PUT /api/v1/expenses/:id.
+func (h *ExpenseHandler) HandleUpdateExpense(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Ambil identitas peminta dari token
+	requesterID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 3. Validasi Payload JSON
+	var req dto.UpdateExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 4. Panggil Service
+	res, err := h.expenseService.ModifyExpense(c.Request.Context(), id, req, requesterID, c.GetString("role"))
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Expense not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrForbidden) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "You can only modify expenses you recorded", nil)
+			return
+		}
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot update expense", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] ModifyExpense: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update expense", nil)
+		return
+	}
+
+	// 5. Sukses
+	response.SuccessOK(c, "Expense updated successfully", res)
+}
+
+// HandleDeleteExpense handles DELETE /api/v1/expenses/:id.
+func (h *ExpenseHandler) HandleDeleteExpense(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Ambil identitas peminta dari token
+	requesterID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 3. Panggil Service
+	if err := h.expenseService.RemoveExpense(c.Request.Context(), id, requesterID, c.GetString("role")); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Expense not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrForbidden) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "You can only delete expenses you recorded", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] RemoveExpense: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete expense", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Expense deleted successfully", map[string]int64{"id": id})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"fmt"
+	"laundry-backend/internal/export"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// negotiateExport membaca ?format=csv|xlsx (prioritas) atau header Accept.
+// format kosong berarti klien meminta JSON biasa. ok=false berarti format tidak dikenal dan sudah dibalas 400.
+func negotiateExport(c *gin.Context) (string, bool) {
+	c.Header("Vary", "Accept")
+
+	format, isExport, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid export format", "format must be one of: json, csv, xlsx")
+		return "", false
+	}
+	if !isExport {
+		return "", true
+	}
+	return format, true
+}
+
+// exportResponseWriter menunda status & header unduhan sampai byte pertama ditulis,
+// sehingga error validasi/database sebelum baris pertama masih bisa dibalas sebagai JSON biasa.
+type exportResponseWriter struct {
+	c           *gin.Context
+	contentType string
+	fileName    string
+	started     bool
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, w.fileName))
+		w.c.Header("Cache-Control", "no-store")
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
+
+// streamExport menjalankan run dengan Writer export yang menulis langsung ke response.
+// Error dikembalikan ke handler hanya jika belum ada byte yang terkirim (handler membalas JSON seperti biasa);
+// jika unduhan sudah berjalan, status tidak bisa diubah lagi sehingga error hanya dicatat
+// (file CSV terpotong, file XLSX tidak bisa dibuka karena zip tidak ditutup).
+func streamExport(c *gin.Context, baseName, format string, run func(w export.Writer) error) error {
+	out := &exportResponseWriter{
+		c:           c,
+		contentType: export.ContentType(format),
+		fileName:    export.FileName(baseName, format, time.Now()),
+	}
+
+	w, err := export.NewWriter(format, out, export.LangFromAcceptLanguage(c.GetHeader("Accept-Language")))
+	if err != nil {
+		return err
+	}
+
+	if err := run(w); err != nil {
+		if !out.started {
+			return err
+		}
+		fmt.Printf("[ERROR] Export %s: %v\n", baseName, err)
+		c.Abort()
+		return nil
+	}
+
+	if err := w.Close(); err != nil {
+		fmt.Printf("[ERROR] Export %s.Close: %v\n", baseName, err)
+		c.Abort()
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize membatasi ukuran file CSV import (1.000 baris master data jauh di bawah batas ini)
+const maxImportFileSize = 2 << 20 // 2 MB
+
+type ImportHandler struct {
+	importService services.ImportService
+}
+
+func NewImportHandler(importService services.ImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+// HandleImport handles POST /api/v1/imports/:entity?dry_run=&on_error=.
+// File CSV dikirim sebagai multipart field "file" atau langsung sebagai body (Content-Type: text/csv).
+func (h *ImportHandler) HandleImport(c *gin.Context) {
+
+	// 1. Validasi Query Parameter
+	var query dto.ImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid import parameters", err.Error())
+		return
+	}
+
+	// 2. Ambil file (multipart atau raw body), dibatasi ukurannya
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	var file io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid import file", "multipart field 'file' is required (max 2 MB)")
+			return
+		}
+		f, err := header.Open()
+		if err != nil {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid import file", err.Error())
+			return
+		}
+		defer f.Close()
+		file = f
+	}
+
+	// 3. Panggil Service
+	res, err := h.importService.Import(c.Request.Context(), c.Param("entity"), file, query)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.ErrorResponse(c, http.StatusRequestEntityTooLarge, response.CodeValidation, "Import file is too large", "max 2 MB")
+			return
+		}
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid import file", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] Import: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to import data", nil)
+		return
+	}
+
+	// 4. Balasan sesuai mode
+	switch {
+	case res.DryRun:
+		response.SuccessOK(c, "Import validated (dry run), nothing was written", res)
+	case !res.Committed:
+		response.ErrorResponse(c, http.StatusUnprocessableEntity, response.CodeValidation, "Import rejected: some rows are invalid, nothing was written", res)
+	default:
+		response.SuccessCreated(c, "Import completed successfully", res)
+	}
+}
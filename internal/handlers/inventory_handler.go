@@ -0,0 +1,359 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InventoryHandler struct {
+	inventoryService services.InventoryService
+}
+
+func NewInventoryHandler(inventoryService services.InventoryService) *InventoryHandler {
+	return &InventoryHandler{inventoryService: inventoryService}
+}
+
+// --- ITEMS ---
+
+// HandleCreateItem handles POST /api/v1/inventory/items.
+func (h *InventoryHandler) HandleCreateItem(c *gin.Context) {
+
+	// 1. Validasi Payload JSON
+	var req dto.CreateInventoryItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.inventoryService.CreateItem(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Inventory item name already exists", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateInventoryItem: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create inventory item", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Inventory item created successfully", res)
+}
+
+// HandleGetItemList handles GET /api/v1/inventory/items?status=.
+func (h *InventoryHandler) HandleGetItemList(c *gin.Context) {
+
+	// 1. Selain owner hanya melihat bahan aktif; owner bebas memfilter status ("", "1", "0")
+	status := c.Query("status")
+	if c.GetString("role") != "owner" {
+		status = "1"
+	}
+
+	// 2. Panggil Service
+	res, err := h.inventoryService.GetItemList(c.Request.Context(), status)
+	if err != nil {
+		fmt.Printf("[ERROR] GetInventoryItemList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve inventory items", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Inventory items retrieved successfully", res)
+}
+
+// HandleGetItemDetail handles GET /api/v1/inventory/items/:id.
+func (h *InventoryHandler) HandleGetItemDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.inventoryService.GetItemDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Inventory item not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetInventoryItemDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve inventory item", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Inventory item retrieved successfully", res)
+}
+
+// HandleUpdateItem handles PUT /api/v1/inventory/items/:id.
+func (h *InventoryHandler) HandleUpdateItem(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.UpdateInventoryItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.inventoryService.ModifyItem(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Inventory item not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Inventory item name already taken", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] ModifyInventoryItem: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update inventory item", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Inventory item updated successfully", res)
+}
+
+// HandleDeleteItem handles DELETE /api/v1/inventory/items/:id (soft delete).
+func (h *InventoryHandler) HandleDeleteItem(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service untuk menonaktifkan bahan
+	if err := h.inventoryService.DeactivateItem(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Inventory item not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeactivateInventoryItem: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete inventory item", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Inventory item deleted successfully", map[string]int64{"id": id})
+}
+
+// HandleGetLowStock handles GET /api/v1/inventory/low-stock.
+func (h *InventoryHandler) HandleGetLowStock(c *gin.Context) {
+
+	res, err := h.inventoryService.GetLowStock(c.Request.Context())
+	if err != nil {
+		fmt.Printf("[ERROR] GetLowStock: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve low stock items", nil)
+		return
+	}
+
+	response.SuccessOK(c, "Low stock items retrieved successfully", res)
+}
+
+// --- MOVEMENTS ---
+
+// HandleStockIn handles POST /api/v1/inventory/items/:id/stock-in.
+func (h *InventoryHandler) HandleStockIn(c *gin.Context) {
+
+	// 1. Ambil ID bahan & pencatat
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.StockInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.inventoryService.RecordStockIn(c.Request.Context(), id, req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot record stock in", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] RecordStockIn: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to record stock in", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Stock in recorded successfully", res)
+}
+
+// HandleAdjustStock handles POST /api/v1/inventory/items/:id/adjustments.
+func (h *InventoryHandler) HandleAdjustStock(c *gin.Context) {
+
+	// 1. Ambil ID bahan & pencatat
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.StockAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.inventoryService.AdjustStock(c.Request.Context(), id, req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrInsufficientStock) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeInsufficientStock, "Adjustment would make the stock negative", nil)
+			return
+		}
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot adjust stock", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] AdjustStock: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to adjust stock", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Stock adjusted successfully", res)
+}
+
+// HandleGetMovements handles GET /api/v1/inventory/movements.
+func (h *InventoryHandler) HandleGetMovements(c *gin.Context) {
+
+	// 1. Ambil parameter list (page/cursor, per_page, search, filter bahan/jenis/pesanan/tanggal, sort_by, order)
+	params := listquery.ParseParams(c.Request.URL.Query(), "inventory_item_id", "movement_type", "order_id", "actor_id", "start_date", "end_date", "outlet_id")
+
+	// 2. Panggil Service
+	res, err := h.inventoryService.GetMovements(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid list parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetInventoryMovements: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve stock movements", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Stock movements retrieved successfully", res.Data, res.Meta)
+}
+
+// --- RECIPES ---
+
+// HandleGetRecipe handles GET /api/v1/inventory/recipes/:service_id.
+func (h *InventoryHandler) HandleGetRecipe(c *gin.Context) {
+
+	// 1. Ambil ID layanan dari URL Path
+	serviceID, err := strconv.ParseInt(c.Param("service_id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "Service ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.inventoryService.GetServiceRecipe(c.Request.Context(), serviceID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Service not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetServiceRecipe: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve service recipe", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Service recipe retrieved successfully", res)
+}
+
+// HandleReplaceRecipe handles PUT /api/v1/inventory/recipes/:service_id.
+func (h *InventoryHandler) HandleReplaceRecipe(c *gin.Context) {
+
+	// 1. Ambil ID layanan dari URL Path
+	serviceID, err := strconv.ParseInt(c.Param("service_id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "Service ID must be a number")
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.ReplaceServiceConsumptionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.inventoryService.ReplaceServiceRecipe(c.Request.Context(), serviceID, req)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Service not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid recipe", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] ReplaceServiceRecipe: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update service recipe", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Service recipe updated successfully", res)
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/media"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// multipartOverhead memberi ruang untuk field form & boundary multipart di atas batas ukuran file
+const multipartOverhead = 1 << 20 // 1 MB
+
+type MediaHandler struct {
+	mediaService services.MediaService
+}
+
+func NewMediaHandler(mediaService services.MediaService) *MediaHandler {
+	return &MediaHandler{mediaService: mediaService}
+}
+
+// HandleUploadMedia handles POST /api/v1/media (multipart: owner_type, owner_id, caption, file).
+func (h *MediaHandler) HandleUploadMedia(c *gin.Context) {
+
+	// 1. Ambil identitas pengunggah dari Auth Middleware
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+	actorRole := c.GetString("role")
+	maxBytes := h.mediaService.MaxUploadBytes()
+	maxLabel := fmt.Sprintf("max %d MB", maxBytes>>20)
+
+	// 2. Batasi ukuran body & validasi field form
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+multipartOverhead)
+	var req dto.UploadMediaRequest
+	if err := c.ShouldBindWith(&req, binding.FormMultipart); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.ErrorResponse(c, http.StatusRequestEntityTooLarge, response.CodeValidation, "File is too large", maxLabel)
+			return
+		}
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Baca file
+	header, err := c.FormFile("file")
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid media file", "multipart field 'file' is required")
+		return
+	}
+	if header.Size > maxBytes {
+		response.ErrorResponse(c, http.StatusRequestEntityTooLarge, response.CodeValidation, "File is too large", maxLabel)
+		return
+	}
+	f, err := header.Open()
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid media file", err.Error())
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxBytes+1))
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid media file", err.Error())
+		return
+	}
+
+	// 4. Panggil Service
+	res, err := h.mediaService.UploadMedia(c.Request.Context(), req, data, actorID, actorRole)
+	if err != nil {
+		if errors.Is(err, media.ErrUnsupportedType) {
+			response.ErrorResponse(c, http.StatusUnsupportedMediaType, response.CodeValidation, "Unsupported media type", "only JPEG and PNG images are accepted")
+			return
+		}
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid media file", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Media owner not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrForbidden) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "You cannot attach media to this resource", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] UploadMedia: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to upload media", nil)
+		return
+	}
+
+	// 5. Sukses
+	response.SuccessCreated(c, "Media uploaded successfully", res)
+}
+
+// HandleGetMediaList handles GET /api/v1/media?owner_type=&owner_id=.
+func (h *MediaHandler) HandleGetMediaList(c *gin.Context) {
+
+	// 1. Validasi Query Parameter & identitas peminta
+	var query dto.MediaOwnerQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid media filter", err.Error())
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.mediaService.GetOwnerMedia(c.Request.Context(), query, actorID, c.GetString("role"))
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Media owner not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrForbidden) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "You cannot view media of this resource", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetMediaList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve media", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Media retrieved successfully", res)
+}
+
+// HandleGetMediaDetail handles GET /api/v1/media/:id.
+func (h *MediaHandler) HandleGetMediaDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path & identitas peminta
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.mediaService.GetMediaDetail(c.Request.Context(), id, actorID, c.GetString("role"))
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Media not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrForbidden) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "You cannot view this media", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetMediaDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve media", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Media retrieved successfully", res)
+}
+
+// HandleDeleteMedia handles DELETE /api/v1/media/:id.
+func (h *MediaHandler) HandleDeleteMedia(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path & identitas peminta
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service
+	if err := h.mediaService.RemoveMedia(c.Request.Context(), id, actorID, c.GetString("role")); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Media not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrForbidden) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "You can only delete media you uploaded", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeleteMedia: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete media", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Media deleted successfully", nil)
+}
+
+// HandleGetSignedFile handles GET /api/v1/media/files/*key?expires=&signature= (public, driver local).
+func (h *MediaHandler) HandleGetSignedFile(c *gin.Context) {
+
+	// 1. Validasi tanda tangan URL
+	var query dto.SignedFileQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "Invalid or expired link", nil)
+		return
+	}
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	// 2. Panggil Service
+	file, err := h.mediaService.OpenSignedFile(c.Request.Context(), key, query)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "File not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrForbidden) {
+			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "Invalid or expired link", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetSignedFile: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to read file", nil)
+		return
+	}
+	defer file.Close()
+
+	// 3. Kirim file (cache browser tidak boleh melewati masa berlaku link)
+	maxAge := max(query.Expires-time.Now().Unix(), 0)
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, -1, contentType, file, map[string]string{
+		"Cache-Control":          fmt.Sprintf("private, max-age=%d", maxAge),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
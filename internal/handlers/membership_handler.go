@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MembershipHandler struct {
+	membershipService services.MembershipService
+}
+
+func NewMembershipHandler(membershipService services.MembershipService) *MembershipHandler {
+	return &MembershipHandler{membershipService: membershipService}
+}
+
+func (h *MembershipHandler) HandleCreateTier(c *gin.Context) {
+
+	var req dto.CreateMembershipTierRequest
+
+	// 1. Validasi Payload JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Eksekusi Service dengan membawa Context
+	res, err := h.membershipService.CreateTier(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Membership tier name already exists", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateTier: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create membership tier", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Membership tier created successfully", res)
+}
+
+func (h *MembershipHandler) HandleGetTierList(c *gin.Context) {
+
+	// 1. Ambil filter status (Kasir hanya boleh melihat level yang aktif)
+	status := c.Query("status")
+	if c.GetString("role") == "cashier" {
+		status = "1"
+	}
+
+	// 2. Panggil Service
+	res, err := h.membershipService.GetTierList(c.Request.Context(), status)
+	if err != nil {
+		fmt.Printf("[ERROR] GetTierList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve membership tiers", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Membership tiers retrieved successfully", res)
+}
+
+func (h *MembershipHandler) HandleGetTierDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.membershipService.GetTierDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Membership tier not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetTierDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve membership tier", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Membership tier retrieved successfully", res)
+}
+
+func (h *MembershipHandler) HandleUpdateTier(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Ambil Data JSON dari Body
+	var req dto.UpdateMembershipTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.membershipService.ModifyTier(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Membership tier not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Membership tier name already taken", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] ModifyTier: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update membership tier", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Membership tier updated successfully", res)
+}
+
+func (h *MembershipHandler) HandleDeleteTier(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service untuk menonaktifkan level
+	if err := h.membershipService.DeactivateTier(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Membership tier not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeactivateTier: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete membership tier", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Membership tier deleted successfully", map[string]int64{"id": id})
+}
+
+// HandleAssignMembership handles PUT /api/v1/customers/:id/membership.
+func (h *MembershipHandler) HandleAssignMembership(c *gin.Context) {
+
+	// 1. Ambil ID pelanggan dari URL Path
+	customerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Ambil Data JSON dari Body
+	var req dto.AssignMembershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	if err := h.membershipService.AssignCustomerTier(c.Request.Context(), customerID, req); err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid membership tier", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Customer not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] AssignCustomerTier: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update customer membership", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Customer membership updated successfully", gin.H{
+		"customer_id":        customerID,
+		"membership_tier_id": req.MembershipTierID,
+	})
+}
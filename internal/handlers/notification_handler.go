@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService services.NotificationService
+}
+
+func NewNotificationHandler(notificationService services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// HandleGetTemplates handles GET /api/v1/notification-templates.
+func (h *NotificationHandler) HandleGetTemplates(c *gin.Context) {
+
+	// 1. Panggil Service
+	res, err := h.notificationService.GetTemplates(c.Request.Context())
+	if err != nil {
+		fmt.Printf("[ERROR] GetNotificationTemplates: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve notification templates", nil)
+		return
+	}
+
+	// 2. Sukses
+	response.SuccessOK(c, "Notification templates retrieved successfully", res)
+}
+
+// HandleUpdateTemplate handles PUT /api/v1/notification-templates/:event.
+func (h *NotificationHandler) HandleUpdateTemplate(c *gin.Context) {
+
+	// 1. Ambil ID Owner dari token
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.UpdateNotificationTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.notificationService.UpdateTemplate(c.Request.Context(), c.Param("event"), req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid notification template", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Notification event not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] UpdateNotificationTemplate: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update notification template", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Notification template updated successfully", res)
+}
+
+// HandlePreviewTemplate handles POST /api/v1/notification-templates/preview.
+func (h *NotificationHandler) HandlePreviewTemplate(c *gin.Context) {
+
+	// 1. Validasi Payload JSON
+	var req dto.PreviewNotificationTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.notificationService.PreviewTemplate(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid notification template", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] PreviewNotificationTemplate: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to preview notification template", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Notification template rendered successfully", res)
+}
+
+// HandleGetOutbox handles GET /api/v1/notifications.
+func (h *NotificationHandler) HandleGetOutbox(c *gin.Context) {
+
+	// 1. Validasi filter & pagination
+	var query dto.NotificationOutboxQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid notification filter", err.Error())
+		return
+	}
+	page, perPage := parsePagination(c)
+
+	// 2. Panggil Service
+	res, err := h.notificationService.GetOutbox(c.Request.Context(), query, page, perPage)
+	if err != nil {
+		fmt.Printf("[ERROR] GetNotificationOutbox: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve notifications", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Notifications retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleRetryNotification handles POST /api/v1/notifications/:id/retry.
+func (h *NotificationHandler) HandleRetryNotification(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.notificationService.RetryMessage(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Notification cannot be retried", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Notification not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] RetryNotification: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retry notification", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Notification queued for retry", res)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderHandler struct {
+	orderService services.OrderService
+}
+
+func NewOrderHandler(orderService services.OrderService) *OrderHandler {
+	return &OrderHandler{orderService: orderService}
+}
+
+// HandleCreateOrder handles POST /api/v1/orders.
+func (h *OrderHandler) HandleCreateOrder(c *gin.Context) {
+
+	// 1. Ambil ID & role kasir
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+	actorRole := c.GetString("role")
+
+	// 2. Validasi Payload JSON
+	var req dto.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.orderService.CreateOrder(c.Request.Context(), req, actorID, actorRole)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid order", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Service, add-on or voucher not found", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrPromotionExhausted) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodePromotionExhausted, "Voucher quota has been used up", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrPromotionNotApplicable) {
+			response.ErrorResponse(c, http.StatusUnprocessableEntity, response.CodePromotionNotApplicable, "Voucher cannot be applied to this cart", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrShiftNotOpen) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeShiftNotOpen, "Open a shift before accepting cash payments", nil)
+			return
+		}
+		if errors.Is(err, response.ErrInsufficientBalance) {
+			response.ErrorResponse(c, http.StatusUnprocessableEntity, response.CodeInsufficientBalance, "Wallet balance is not sufficient", nil)
+			return
+		}
+		if errors.Is(err, response.ErrInsufficientPoints) {
+			response.ErrorResponse(c, http.StatusUnprocessableEntity, response.CodeInsufficientPoints, "Point balance is not sufficient", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Order conflicts with existing data", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateOrder: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create order", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Order created successfully", res)
+}
+
+// HandleGetOrderList handles GET /api/v1/orders (JSON, or CSV/XLSX via ?format= / Accept).
+func (h *OrderHandler) HandleGetOrderList(c *gin.Context) {
+
+	// 1. Ambil parameter list (page/cursor, per_page, search, filter, sort_by, order)
+	params := listquery.ParseParams(c.Request.URL.Query(), "status_internal", "payment_status", "is_delivery", "customer_id", "created_by", "start_date", "end_date", "outlet_id")
+	format, ok := negotiateExport(c)
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service (export streams every matching row, JSON returns one page)
+	var res *dto.OrderListResponse
+	var err error
+	if format != "" {
+		err = streamExport(c, "orders", format, func(w export.Writer) error {
+			return h.orderService.ExportOrders(c.Request.Context(), params, w)
+		})
+	} else {
+		res, err = h.orderService.GetOrders(c.Request.Context(), params)
+	}
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid list parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetOrders: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve orders", nil)
+		return
+	}
+	if format != "" {
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Orders retrieved successfully", res.Data, res.Meta)
+}
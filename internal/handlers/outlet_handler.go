@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OutletHandler struct {
+	outletService services.OutletService
+}
+
+func NewOutletHandler(outletService services.OutletService) *OutletHandler {
+	return &OutletHandler{outletService: outletService}
+}
+
+func (h *OutletHandler) HandleCreateOutlet(c *gin.Context) {
+
+	var req dto.CreateOutletRequest
+
+	// 1. Validasi Payload JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Eksekusi Service dengan membawa Context
+	res, err := h.outletService.CreateOutlet(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid outlet data", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Outlet code already exists", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateOutlet: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create outlet", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Outlet created successfully", res)
+}
+
+func (h *OutletHandler) HandleGetOutletList(c *gin.Context) {
+
+	// 1. Panggil Service (filter status opsional: 1 = aktif, 0 = non-aktif)
+	res, err := h.outletService.GetOutletList(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		fmt.Printf("[ERROR] GetOutletList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve outlets", nil)
+		return
+	}
+
+	// 2. Sukses
+	response.SuccessOK(c, "Outlets retrieved successfully", res)
+}
+
+func (h *OutletHandler) HandleGetOutletDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Selain owner hanya boleh melihat outlet tempatnya bertugas
+	if c.GetString("role") != "owner" && id != c.GetInt64("outlet_id") {
+		response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Outlet not found", nil)
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.outletService.GetOutletDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Outlet not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetOutletDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve outlet", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Outlet retrieved successfully", res)
+}
+
+func (h *OutletHandler) HandleUpdateOutlet(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Ambil Data JSON dari Body
+	var req dto.UpdateOutletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.outletService.ModifyOutlet(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid outlet data", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Outlet not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Outlet code already taken", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] ModifyOutlet: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update outlet", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Outlet updated successfully", res)
+}
+
+func (h *OutletHandler) HandleDeleteOutlet(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service untuk menonaktifkan outlet
+	if err := h.outletService.DeactivateOutlet(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeValidation, "Outlet is still in use", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Outlet not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeactivateOutlet: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete outlet", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Outlet deleted successfully", map[string]int64{"id": id})
+}
+
+// HandleGetServicePrices handles GET /api/v1/outlets/:id/service-prices.
+func (h *OutletHandler) HandleGetServicePrices(c *gin.Context) {
+
+	// 1. Ambil ID outlet dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.outletService.GetServicePrices(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Outlet not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetServicePrices: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve outlet service prices", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Outlet service prices retrieved successfully", res)
+}
+
+// HandleSetServicePrice handles PUT /api/v1/outlets/:id/service-prices/:serviceId.
+func (h *OutletHandler) HandleSetServicePrice(c *gin.Context) {
+
+	// 1. Ambil ID outlet & layanan dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	serviceID, err := strconv.ParseInt(c.Param("serviceId"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid Service ID Format", "Service ID must be a number")
+		return
+	}
+
+	// 2. Ambil Data JSON dari Body
+	var req dto.SetServiceOutletPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.outletService.SetServicePrice(c.Request.Context(), id, serviceID, req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid service", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Outlet not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] SetServicePrice: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to save outlet service price", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Outlet service price saved successfully", res)
+}
+
+// HandleRemoveServicePrice handles DELETE /api/v1/outlets/:id/service-prices/:serviceId.
+func (h *OutletHandler) HandleRemoveServicePrice(c *gin.Context) {
+
+	// 1. Ambil ID outlet & layanan dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	serviceID, err := strconv.ParseInt(c.Param("serviceId"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid Service ID Format", "Service ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service (outlet kembali memakai harga dasar layanan)
+	if err := h.outletService.RemoveServicePrice(c.Request.Context(), id, serviceID); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Outlet service price not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] RemoveServicePrice: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete outlet service price", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Outlet service price deleted successfully", gin.H{"outlet_id": id, "service_id": serviceID})
+}
+
+// --- HELPER FUNCTION ---
+
+// scopeReportOutlet membaca ?outlet_id= pada endpoint laporan owner dan mengganti outlet aktif token:
+// kosong = ikut token, "all" / 0 = konsolidasi semua outlet, angka = satu outlet.
+// ok=false berarti parameter tidak valid dan sudah dibalas 400.
+func scopeReportOutlet(c *gin.Context) bool {
+	param := c.Query("outlet_id")
+	if param == "" {
+		return true
+	}
+
+	outletID := outlet.All
+	if param != "all" {
+		id, err := strconv.ParseInt(param, 10, 64)
+		if err != nil || id < 0 {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid outlet_id", "outlet_id must be a number or 'all'")
+			return false
+		}
+		outletID = id
+	}
+
+	c.Request = c.Request.WithContext(outlet.WithScope(c.Request.Context(), outletID))
+	return true
+}
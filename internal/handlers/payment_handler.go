@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentHandler struct {
+	paymentService services.PaymentService
+}
+
+func NewPaymentHandler(paymentService services.PaymentService) *PaymentHandler {
+	return &PaymentHandler{paymentService: paymentService}
+}
+
+// HandleGetPaymentList handles GET /api/v1/payments (JSON, or CSV/XLSX via ?format= / Accept).
+func (h *PaymentHandler) HandleGetPaymentList(c *gin.Context) {
+
+	// 1. Ambil parameter list (page/cursor, per_page, search, filter, sort_by, order)
+	params := listquery.ParseParams(c.Request.URL.Query(), "status", "method", "order_id", "shift_id", "start_date", "end_date", "outlet_id")
+	format, ok := negotiateExport(c)
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service (export streams every matching row, JSON returns one page)
+	var res *dto.PaymentListResponse
+	var err error
+	if format != "" {
+		err = streamExport(c, "payments", format, func(w export.Writer) error {
+			return h.paymentService.ExportPayments(c.Request.Context(), params, w)
+		})
+	} else {
+		res, err = h.paymentService.GetPayments(c.Request.Context(), params)
+	}
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid list parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetPayments: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve payments", nil)
+		return
+	}
+	if format != "" {
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Payments retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleSettlePayment handles PATCH /api/v1/payments/:id.
+func (h *PaymentHandler) HandleSettlePayment(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path & ID kasir
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.SettlePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.paymentService.SettlePayment(c.Request.Context(), id, req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid payment", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Payment not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrInvalidTransition) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeInvalidTransition, "Payment is not pending", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrShiftNotOpen) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeShiftNotOpen, "Open a shift before accepting cash payments", nil)
+			return
+		}
+		if errors.Is(err, response.ErrInsufficientBalance) {
+			response.ErrorResponse(c, http.StatusUnprocessableEntity, response.CodeInsufficientBalance, "Wallet balance is not sufficient", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] SettlePayment: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update payment", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Payment updated successfully", res)
+}
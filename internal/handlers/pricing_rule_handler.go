@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PricingRuleHandler struct {
+	pricingRuleService services.PricingRuleService
+}
+
+func NewPricingRuleHandler(pricingRuleService services.PricingRuleService) *PricingRuleHandler {
+	return &PricingRuleHandler{pricingRuleService: pricingRuleService}
+}
+
+// HandleGetRuleList handles GET /api/v1/services/:id/pricing-rules.
+func (h *PricingRuleHandler) HandleGetRuleList(c *gin.Context) {
+
+	// 1. Ambil ID layanan dari URL Path
+	serviceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.pricingRuleService.GetRuleList(c.Request.Context(), serviceID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Service not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetRuleList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve pricing rules", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Pricing rules retrieved successfully", res)
+}
+
+// HandleCreateRule handles POST /api/v1/services/:id/pricing-rules.
+func (h *PricingRuleHandler) HandleCreateRule(c *gin.Context) {
+
+	// 1. Ambil ID layanan dari URL Path
+	serviceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.CreatePricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.pricingRuleService.CreateRule(c.Request.Context(), serviceID, req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid pricing rule", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Service not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "An active rule of the same type already exists", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateRule: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create pricing rule", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Pricing rule created successfully", res)
+}
+
+// HandleUpdateRule handles PUT /api/v1/services/:id/pricing-rules/:rule_id.
+func (h *PricingRuleHandler) HandleUpdateRule(c *gin.Context) {
+
+	// 1. Ambil ID layanan & ID aturan dari URL Path
+	serviceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	ruleID, err := strconv.ParseInt(c.Param("rule_id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "Rule ID must be a number")
+		return
+	}
+
+	// 2. Ambil Data JSON dari Body
+	var req dto.UpdatePricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.pricingRuleService.ModifyRule(c.Request.Context(), serviceID, ruleID, req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid pricing rule", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Pricing rule not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "An active rule of the same type already exists", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] ModifyRule: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update pricing rule", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Pricing rule updated successfully", res)
+}
+
+// HandleDeleteRule handles DELETE /api/v1/services/:id/pricing-rules/:rule_id.
+func (h *PricingRuleHandler) HandleDeleteRule(c *gin.Context) {
+
+	// 1. Ambil ID layanan & ID aturan dari URL Path
+	serviceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	ruleID, err := strconv.ParseInt(c.Param("rule_id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "Rule ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	if err := h.pricingRuleService.DeactivateRule(c.Request.Context(), serviceID, ruleID); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Pricing rule not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeactivateRule: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete pricing rule", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Pricing rule deleted successfully", map[string]int64{"id": ruleID})
+}
+
+// HandleQuotePrice handles GET /api/v1/services/:id/price-quote?quantity=&addon_ids=.
+func (h *PricingRuleHandler) HandleQuotePrice(c *gin.Context) {
+
+	// 1. Ambil ID layanan dari URL Path
+	serviceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Ambil berat/jumlah dari Query Parameter
+	quantity, err := strconv.ParseFloat(c.Query("quantity"), 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid quantity", "quantity must be a number")
+		return
+	}
+
+	// 3. Ambil daftar add-on terpilih (opsional, dipisah koma: addon_ids=1,3)
+	var addonIDs []int64
+	if raw := c.Query("addon_ids"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			addonID, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+			if err != nil {
+				response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid addon_ids", "addon_ids must be a comma-separated list of numbers")
+				return
+			}
+			addonIDs = append(addonIDs, addonID)
+		}
+	}
+
+	// 4. Panggil Service
+	res, err := h.pricingRuleService.QuotePrice(c.Request.Context(), serviceID, quantity, addonIDs)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid price quote request", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Service not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] QuotePrice: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to calculate price", nil)
+		return
+	}
+
+	// 5. Sukses
+	response.SuccessOK(c, "Price calculated successfully", res)
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionHandler struct {
+	promotionService services.PromotionService
+}
+
+func NewPromotionHandler(promotionService services.PromotionService) *PromotionHandler {
+	return &PromotionHandler{promotionService: promotionService}
+}
+
+func (h *PromotionHandler) HandleCreatePromotion(c *gin.Context) {
+
+	var req dto.CreatePromotionRequest
+
+	// 1. Validasi Payload JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Eksekusi Service dengan membawa Context
+	res, err := h.promotionService.CreatePromotion(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid promotion data", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Voucher code already exists", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreatePromotion: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create promotion", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Promotion created successfully", res)
+}
+
+func (h *PromotionHandler) HandleGetPromotionList(c *gin.Context) {
+
+	// 1. Ambil nilai dari URL Query Parameters
+	search := c.Query("search")
+	status := c.Query("status")
+
+	// 2. Konversi tipe data dengan "Safety Net"
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+
+	// 3. Panggil Service
+	res, err := h.promotionService.GetPromotionList(c.Request.Context(), page, perPage, search, status)
+	if err != nil {
+		fmt.Printf("[ERROR] GetPromotionList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve promotions", nil)
+		return
+	}
+
+	// 4. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Promotions retrieved successfully", res.Data, res.Meta)
+}
+
+func (h *PromotionHandler) HandleGetPromotionDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.promotionService.GetPromotionDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Promotion not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetPromotionDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve promotion detail", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Promotion detail retrieved successfully", res)
+}
+
+func (h *PromotionHandler) HandleUpdatePromotion(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Ambil Data JSON dari Body
+	var req dto.UpdatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.promotionService.ModifyPromotion(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid promotion data", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Promotion not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Voucher code already taken", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] ModifyPromotion: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update promotion", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Promotion updated successfully", res)
+}
+
+func (h *PromotionHandler) HandleDeletePromotion(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service untuk menonaktifkan promosi
+	if err := h.promotionService.DeactivatePromotion(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Promotion not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeactivatePromotion: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete promotion", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Promotion deleted successfully", map[string]int64{"id": id})
+}
+
+// HandleValidatePromotion handles POST /api/v1/promotions/validate (simulasi diskon saat checkout).
+func (h *PromotionHandler) HandleValidatePromotion(c *gin.Context) {
+
+	var req dto.ValidatePromotionRequest
+
+	// 1. Validasi Payload JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.promotionService.ValidatePromotion(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid cart items", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Voucher code not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrPromotionExhausted) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodePromotionExhausted, "Voucher quota has been used up", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrPromotionNotApplicable) {
+			response.ErrorResponse(c, http.StatusUnprocessableEntity, response.CodePromotionNotApplicable, "Voucher cannot be applied to this cart", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] ValidatePromotion: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to validate promotion", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Promotion validated successfully", res)
+}
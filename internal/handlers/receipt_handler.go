@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReceiptHandler struct {
+	receiptService services.ReceiptService
+}
+
+func NewReceiptHandler(receiptService services.ReceiptService) *ReceiptHandler {
+	return &ReceiptHandler{receiptService: receiptService}
+}
+
+// HandleGetOrderReceipt handles GET /api/v1/orders/:id/receipt?format=pdf|escpos|text&paper=58|80.
+// Response berupa file mentah (bukan JSON) agar bisa langsung dikirim ke printer.
+func (h *ReceiptHandler) HandleGetOrderReceipt(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Validasi Query Parameter
+	var query dto.ReceiptQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid receipt parameters", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	file, err := h.receiptService.RenderOrderReceipt(c.Request.Context(), id, query)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid receipt parameters", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Order not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] RenderOrderReceipt: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to render receipt", nil)
+		return
+	}
+
+	// 4. Sukses: kirim file mentah
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler struct {
+	reportService services.ReportService
+}
+
+func NewReportHandler(reportService services.ReportService) *ReportHandler {
+	return &ReportHandler{reportService: reportService}
+}
+
+// HandleGetProfitReport handles GET /api/v1/reports/profit?start_date=&end_date=&group_by=&outlet_id=.
+func (h *ReportHandler) HandleGetProfitReport(c *gin.Context) {
+
+	// 1. Ambil rentang tanggal (default: hari ini), pengelompokan (default: day) & outlet laporan
+	today := time.Now().Format("2006-01-02")
+	startDate := c.DefaultQuery("start_date", today)
+	endDate := c.DefaultQuery("end_date", startDate)
+	groupBy := c.DefaultQuery("group_by", services.ReportGroupDay)
+	if !scopeReportOutlet(c) {
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.reportService.GetProfitReport(c.Request.Context(), startDate, endDate, groupBy)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid report parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetProfitReport: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve profit report", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Profit report retrieved successfully", res)
+}
+
+// HandleGetEmployeeReport handles GET /api/v1/reports/employees?start_date=&end_date=&outlet_id=.
+func (h *ReportHandler) HandleGetEmployeeReport(c *gin.Context) {
+
+	// 1. Ambil rentang tanggal (default: hari ini) & outlet laporan
+	today := time.Now().Format("2006-01-02")
+	startDate := c.DefaultQuery("start_date", today)
+	endDate := c.DefaultQuery("end_date", startDate)
+	if !scopeReportOutlet(c) {
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.reportService.GetEmployeeReport(c.Request.Context(), startDate, endDate)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid report parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetEmployeeReport: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to generate employee report", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Employee productivity report generated successfully", res)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchService services.SearchService
+}
+
+func NewSearchHandler(searchService services.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// HandleSearch handles GET /api/v1/search?q=&types=&limit=.
+// Hasil dikelompokkan per jenis entitas dan disaring sesuai role (kurir hanya melihat antaran miliknya).
+func (h *SearchHandler) HandleSearch(c *gin.Context) {
+
+	// 1. Ambil identitas user dari Auth Middleware
+	requesterID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+	requesterRole := c.GetString("role")
+
+	// 2. Validasi Query Parameter
+	var query dto.SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid search parameters", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.searchService.Search(c.Request.Context(), query, requesterID, requesterRole)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid search parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] Search: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to search", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Search results retrieved successfully", res)
+}
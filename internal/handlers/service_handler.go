@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
 	"laundry-backend/internal/services"
+	"laundry-backend/pkg/listquery"
 	"laundry-backend/pkg/response"
 	"net/http"
 	"strconv"
@@ -53,13 +55,8 @@ func (h *ServiceHandler) HandleCreateService(c *gin.Context) {
 
 func (h *ServiceHandler) HandleGetServiceList(c *gin.Context) {
 
-	// 1. Ambil nilai dari URL Query Parameters
-	pageStr := c.DefaultQuery("page", "1")
-	perPageStr := c.DefaultQuery("per_page", "10")
-	search := c.Query("search")
-	status := c.Query("status")
-	sortBy := c.Query("sort_by")
-	sortOrder := c.Query("sort_order")
+	// 1. Ambil parameter list (page/cursor, per_page, search, status, sort_by, sort_order)
+	params := listquery.ParseParams(c.Request.URL.Query(), "status")
 
 	// ===============================================================
 	// 🛡️ [TAMBAHAN VIP-7: ROLE-BASED FILTERING]
@@ -68,31 +65,41 @@ func (h *ServiceHandler) HandleGetServiceList(c *gin.Context) {
 
 	// Jika Kasir, PAKSA status menjadi "1" (Hanya Aktif).
 	if userRole == "cashier" {
-		status = "1"
+		params.Filters["status"] = "1"
 	}
 	// ===============================================================
 
-	// 2. Konversi tipe data dengan "Safety Net" (Keamanan Ekstra)
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1 // Fallback aman
+	// 2. Panggil Koki (Service): export CSV/XLSX jika diminta (?format= / Accept), selain itu JSON per halaman
+	format, ok := negotiateExport(c)
+	if !ok {
+		return
 	}
 
-	perPage, err := strconv.Atoi(perPageStr)
-	if err != nil || perPage < 1 {
-		perPage = 10 // Fallback aman
+	var res *dto.ServiceListResponse
+	var err error
+	if format != "" {
+		err = streamExport(c, "services", format, func(w export.Writer) error {
+			return h.serviceService.ExportServiceList(c.Request.Context(), params, w)
+		})
+	} else {
+		res, err = h.serviceService.GetServiceList(c.Request.Context(), params)
 	}
-
-	// 3. Panggil Koki (Service)
-	res, err := h.serviceService.GetServiceList(c.Request.Context(), page, perPage, search, status, sortBy, sortOrder)
 	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid list parameters", err.Error())
+			return
+		}
+
 		fmt.Printf("[ERROR] GetServiceList: %v\n", err)
 
 		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve services", nil)
 		return
 	}
+	if format != "" {
+		return
+	}
 
-	// 4. Sukses dengan Meta (Pagination)
+	// 3. Sukses dengan Meta (Pagination)
 	response.SuccessMeta(c, "Services retrieved successfully", res.Data, res.Meta)
 }
 
@@ -120,7 +127,8 @@ func (h *ServiceHandler) HandleGetServiceDetail(c *gin.Context) {
 		return
 	}
 
-	// 3. Sukses
+	// 3. Sukses (ETag dipakai klien sebagai If-Match saat update)
+	setETag(c, res.Version)
 	response.SuccessOK(c, "Service detail retrieved successfully", res)
 }
 
@@ -134,21 +142,30 @@ func (h *ServiceHandler) HandleUpdateService(c *gin.Context) {
 		return
 	}
 
-	// 2. Ambil Data JSON dari Body
+	// 2. Ambil Data JSON dari Body & versi yang diharapkan (If-Match)
 	var req dto.UpdateServiceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
 		return
 	}
+	expectedVersion, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
 
 	// 3. Panggil Koki (Service)
-	res, err := h.serviceService.ModifyService(c.Request.Context(), id, req)
+	res, err := h.serviceService.ModifyService(c.Request.Context(), id, req, expectedVersion)
 	if err != nil {
 		if errors.Is(err, response.ErrNotFound) {
 			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Service not found", nil)
 			return
 		}
 
+		if errors.Is(err, response.ErrPreconditionFailed) {
+			respondPreconditionFailed(c)
+			return
+		}
+
 		if errors.Is(err, response.ErrDuplicate) {
 			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Service code or name already taken", nil)
 			return
@@ -161,6 +178,7 @@ func (h *ServiceHandler) HandleUpdateService(c *gin.Context) {
 	}
 
 	// 4. Sukses
+	setETag(c, res.Version)
 	response.SuccessOK(c, "Service updated successfully", res)
 }
 
@@ -174,20 +192,31 @@ func (h *ServiceHandler) HandleDeleteService(c *gin.Context) {
 		return
 	}
 
-	// 2. Panggil Koki (Service) untuk menonaktifkan layanan
-	err = h.serviceService.DeactivateService(c.Request.Context(), id)
+	// 2. Ambil versi yang diharapkan (If-Match)
+	expectedVersion, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	// 3. Panggil Koki (Service) untuk menonaktifkan layanan
+	err = h.serviceService.DeactivateService(c.Request.Context(), id, expectedVersion)
 	if err != nil {
 		if errors.Is(err, response.ErrNotFound) {
 			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Service not found", nil)
 			return
 		}
 
+		if errors.Is(err, response.ErrPreconditionFailed) {
+			respondPreconditionFailed(c)
+			return
+		}
+
 		fmt.Printf("[ERROR] DeactivateService: %v\n", err)
 
 		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete service", nil)
 		return
 	}
 
-	// 3. Sukses
+	// 4. Sukses
 	response.SuccessOK(c, "Service deleted successfully", map[string]int64{"id": id})
 }
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShiftHandler struct {
+	shiftService services.ShiftService
+}
+
+func NewShiftHandler(shiftService services.ShiftService) *ShiftHandler {
+	return &ShiftHandler{shiftService: shiftService}
+}
+
+// HandleOpenShift handles POST /api/v1/shifts/open.
+func (h *ShiftHandler) HandleOpenShift(c *gin.Context) {
+
+	// 1. Ambil ID kasir dari token
+	cashierID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.OpenShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.shiftService.OpenShift(c.Request.Context(), cashierID, req)
+	if err != nil {
+		if errors.Is(err, response.ErrShiftAlreadyOpen) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeShiftAlreadyOpen, "You already have an open shift", nil)
+			return
+		}
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot open shift", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] OpenShift: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to open shift", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Shift opened successfully", res)
+}
+
+// HandleGetCurrentShift handles GET /api/v1/shifts/current.
+func (h *ShiftHandler) HandleGetCurrentShift(c *gin.Context) {
+
+	// 1. Ambil ID kasir dari token
+	cashierID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.shiftService.GetCurrentShift(c.Request.Context(), cashierID)
+	if err != nil {
+		if errors.Is(err, response.ErrShiftNotOpen) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeShiftNotOpen, "You have no open shift", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetCurrentShift: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve shift", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Shift retrieved successfully", res)
+}
+
+// HandleCreatePayout handles POST /api/v1/shifts/current/payouts.
+func (h *ShiftHandler) HandleCreatePayout(c *gin.Context) {
+
+	// 1. Ambil ID kasir dari token
+	cashierID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.CreatePettyCashPayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.shiftService.RecordPayout(c.Request.Context(), cashierID, req)
+	if err != nil {
+		if errors.Is(err, response.ErrShiftNotOpen) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeShiftNotOpen, "Open a shift before recording petty cash", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] RecordPayout: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to record petty cash payout", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Petty cash payout recorded successfully", res)
+}
+
+// HandleCloseShift handles POST /api/v1/shifts/current/close.
+func (h *ShiftHandler) HandleCloseShift(c *gin.Context) {
+
+	// 1. Ambil ID kasir dari token
+	cashierID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.CloseShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service (rekonsiliasi laci kas)
+	res, err := h.shiftService.CloseShift(c.Request.Context(), cashierID, req)
+	if err != nil {
+		if errors.Is(err, response.ErrShiftNotOpen) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeShiftNotOpen, "You have no open shift", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CloseShift: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to close shift", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Shift closed successfully", res)
+}
+
+// HandleGetShiftDetail handles GET /api/v1/shifts/:id.
+func (h *ShiftHandler) HandleGetShiftDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Ambil identitas peminta dari token
+	requesterID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.shiftService.GetShiftDetail(c.Request.Context(), id, requesterID, c.GetString("role"))
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Shift not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetShiftDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve shift", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Shift retrieved successfully", res)
+}
+
+// HandleGetShiftReport handles GET /api/v1/reports/shifts?start_date=&end_date=&outlet_id=&tolerance=.
+func (h *ShiftHandler) HandleGetShiftReport(c *gin.Context) {
+
+	// 1. Ambil rentang tanggal (default: hari ini), outlet laporan & toleransi selisih (default: 0)
+	today := time.Now().Format("2006-01-02")
+	startDate := c.DefaultQuery("start_date", today)
+	endDate := c.DefaultQuery("end_date", startDate)
+	if !scopeReportOutlet(c) {
+		return
+	}
+
+	var tolerance money.Amount
+	if raw := c.Query("tolerance"); raw != "" {
+		parsed, err := money.Parse(raw)
+		if err != nil {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid tolerance", "tolerance must be a plain number, e.g. 5000")
+			return
+		}
+		tolerance = parsed
+	}
+
+	// 2. Panggil Service
+	res, err := h.shiftService.GetShiftReport(c.Request.Context(), startDate, endDate, tolerance)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid report parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetShiftReport: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve shift report", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Shift report retrieved successfully", res)
+}
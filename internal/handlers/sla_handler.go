@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SLAHandler struct {
+	slaService services.SLAService
+}
+
+func NewSLAHandler(slaService services.SLAService) *SLAHandler {
+	return &SLAHandler{slaService: slaService}
+}
+
+// HandleGetOverdueOrders handles GET /api/v1/orders/overdue.
+func (h *SLAHandler) HandleGetOverdueOrders(c *gin.Context) {
+
+	// 1. Validasi filter & pagination
+	var query dto.OverdueOrderQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid overdue order filter", err.Error())
+		return
+	}
+	page, perPage := parsePagination(c)
+
+	// 2. Panggil Service
+	res, err := h.slaService.GetOverdueOrders(c.Request.Context(), query, page, perPage)
+	if err != nil {
+		fmt.Printf("[ERROR] GetOverdueOrders: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve overdue orders", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Overdue orders retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleGetUnclaimedOrders handles GET /api/v1/orders/unclaimed.
+func (h *SLAHandler) HandleGetUnclaimedOrders(c *gin.Context) {
+
+	// 1. Validasi filter & pagination
+	var query dto.UnclaimedOrderQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid unclaimed order filter", err.Error())
+		return
+	}
+	page, perPage := parsePagination(c)
+
+	// 2. Panggil Service
+	res, err := h.slaService.GetUnclaimedOrders(c.Request.Context(), query, page, perPage)
+	if err != nil {
+		fmt.Printf("[ERROR] GetUnclaimedOrders: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve unclaimed orders", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Unclaimed orders retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleGetAlerts handles GET /api/v1/orders/sla-alerts.
+func (h *SLAHandler) HandleGetAlerts(c *gin.Context) {
+
+	// 1. Validasi filter & pagination
+	var query dto.SLAAlertQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid SLA alert filter", err.Error())
+		return
+	}
+	page, perPage := parsePagination(c)
+
+	// 2. Panggil Service
+	res, err := h.slaService.GetAlerts(c.Request.Context(), query, page, perPage)
+	if err != nil {
+		fmt.Printf("[ERROR] GetSLAAlerts: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve SLA alerts", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "SLA alerts retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleAcknowledgeAlert handles PATCH /api/v1/orders/sla-alerts/:id/acknowledge.
+func (h *SLAHandler) HandleAcknowledgeAlert(c *gin.Context) {
+
+	// 1. Ambil ID user dari token & ID peringatan dari URL
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.slaService.AcknowledgeAlert(c.Request.Context(), id, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "SLA alert not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] AcknowledgeSLAAlert: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to acknowledge SLA alert", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "SLA alert acknowledged successfully", res)
+}
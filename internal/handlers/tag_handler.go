@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	tagService services.TagService
+}
+
+func NewTagHandler(tagService services.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+// HandleGenerateTags handles POST /api/v1/orders/:id/tags.
+func (h *TagHandler) HandleGenerateTags(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service (item yang sudah punya tag tidak diubah)
+	res, err := h.tagService.GenerateTags(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Order cannot be tagged", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Order not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GenerateTags: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to generate tags", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Tags generated successfully", res)
+}
+
+// HandleGetOrderTags handles GET /api/v1/orders/:id/tags.
+func (h *TagHandler) HandleGetOrderTags(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.tagService.GetOrderTags(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Order not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetOrderTags: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve tags", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Tags retrieved successfully", res)
+}
+
+// HandleGetTagLabels handles GET /api/v1/orders/:id/tags/labels?format=pdf|escpos&symbology=code128|qr&paper=58|80.
+// Response berupa file mentah (bukan JSON) agar bisa langsung dikirim ke printer label.
+func (h *TagHandler) HandleGetTagLabels(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Validasi Query Parameter
+	var query dto.TagLabelQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid label parameters", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	file, err := h.tagService.RenderLabels(c.Request.Context(), id, query)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid label parameters", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Order not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] RenderLabels: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to render labels", nil)
+		return
+	}
+
+	// 4. Sukses: kirim file mentah
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// HandleScanTag handles POST /api/v1/scan/:tag.
+func (h *TagHandler) HandleScanTag(c *gin.Context) {
+
+	// 1. Ambil identitas user dari Auth Middleware
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+	actorRole := c.GetString("role")
+
+	// 2. Body opsional: kosong = hanya melihat pesanan
+	var req dto.ScanRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+			return
+		}
+	}
+
+	// 3. Panggil Service
+	res, err := h.tagService.Scan(c.Request.Context(), c.Param("tag"), req, actorID, actorRole)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Tag not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrInvalidTransition) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeInvalidTransition, "Status transition is not allowed", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] ScanTag: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to process scan", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Tag scanned successfully", res)
+}
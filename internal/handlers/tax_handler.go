@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaxHandler struct {
+	taxService services.TaxService
+}
+
+func NewTaxHandler(taxService services.TaxService) *TaxHandler {
+	return &TaxHandler{taxService: taxService}
+}
+
+func (h *TaxHandler) HandleCreateTaxRate(c *gin.Context) {
+
+	var req dto.CreateTaxRateRequest
+
+	// 1. Validasi Payload JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Eksekusi Service dengan membawa Context
+	res, err := h.taxService.CreateTaxRate(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid tax rate data", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Tax rate code already exists", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateTaxRate: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create tax rate", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Tax rate created successfully", res)
+}
+
+func (h *TaxHandler) HandleGetTaxRateList(c *gin.Context) {
+
+	// 1. Ambil filter status (Kasir hanya boleh melihat tarif yang aktif)
+	status := c.Query("status")
+	if c.GetString("role") == "cashier" {
+		status = "1"
+	}
+
+	// 2. Panggil Service
+	res, err := h.taxService.GetTaxRateList(c.Request.Context(), status)
+	if err != nil {
+		fmt.Printf("[ERROR] GetTaxRateList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve tax rates", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Tax rates retrieved successfully", res)
+}
+
+func (h *TaxHandler) HandleGetTaxRateDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.taxService.GetTaxRateDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Tax rate not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetTaxRateDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve tax rate detail", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Tax rate detail retrieved successfully", res)
+}
+
+func (h *TaxHandler) HandleUpdateTaxRate(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Ambil Data JSON dari Body
+	var req dto.UpdateTaxRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.taxService.ModifyTaxRate(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid tax rate data", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Tax rate not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrDuplicate) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Tax rate code already taken", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] ModifyTaxRate: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update tax rate", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Tax rate updated successfully", res)
+}
+
+func (h *TaxHandler) HandleDeleteTaxRate(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service untuk menonaktifkan tarif
+	if err := h.taxService.DeactivateTaxRate(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Tax rate not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeactivateTaxRate: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete tax rate", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Tax rate deleted successfully", map[string]int64{"id": id})
+}
+
+func (h *TaxHandler) HandlePreviewOrderTotals(c *gin.Context) {
+
+	var req dto.OrderTotalsRequest
+
+	// 1. Validasi Payload JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.taxService.PreviewOrderTotals(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid cart items", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Voucher code not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrPromotionExhausted) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodePromotionExhausted, "Voucher quota has been used up", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrPromotionNotApplicable) {
+			response.ErrorResponse(c, http.StatusUnprocessableEntity, response.CodePromotionNotApplicable, "Voucher cannot be applied to this cart", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] PreviewOrderTotals: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to calculate order totals", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Order totals calculated successfully", res)
+}
+
+// HandleGetTaxReport handles GET /api/v1/reports/taxes?start_date=&end_date=&outlet_id= (JSON ringkasan,
+// atau CSV/XLSX rincian per nota via ?format= / Accept).
+func (h *TaxHandler) HandleGetTaxReport(c *gin.Context) {
+
+	// 1. Ambil rentang tanggal dari Query (default: hari ini) & outlet laporan (default: outlet aktif token)
+	today := time.Now().Format("2006-01-02")
+	startDate := c.DefaultQuery("start_date", today)
+	endDate := c.DefaultQuery("end_date", startDate)
+	if !scopeReportOutlet(c) {
+		return
+	}
+
+	// 2. Panggil Service
+	format, ok := negotiateExport(c)
+	if !ok {
+		return
+	}
+
+	var res *dto.TaxReportResponse
+	var err error
+	if format != "" {
+		err = streamExport(c, "tax-report", format, func(w export.Writer) error {
+			return h.taxService.ExportTaxReport(c.Request.Context(), startDate, endDate, w)
+		})
+	} else {
+		res, err = h.taxService.GetTaxReport(c.Request.Context(), startDate, endDate)
+	}
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid date range", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetTaxReport: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve tax report", nil)
+		return
+	}
+	if format != "" {
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Tax report retrieved successfully", res)
+}
@@ -3,7 +3,9 @@ package handlers
 import (
 	"errors"
 	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
 	"laundry-backend/internal/services"
+	"laundry-backend/pkg/listquery"
 	"laundry-backend/pkg/response"
 	"net/http"
 	"strconv"
@@ -39,6 +41,10 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "User data already exists", "Username, email, or phone number is already taken")
 			return
 		}
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid outlet assignment", err.Error())
+			return
+		}
 
 		// Default Internal Error
 		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "An unexpected server error occurred", nil)
@@ -49,23 +55,38 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	response.SuccessCreated(c, "User account created successfully", res)
 }
 
-// GetListUsers handles GET /api/v1/users.
+// GetListUsers handles GET /api/v1/users (JSON, or CSV/XLSX via ?format= / Accept).
 // Access: Owner only.
 func (h *UserHandler) GetListUsers(c *gin.Context) {
 
-	// 1. Parse Query Parameters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
-	search := c.Query("search")
-	role := c.Query("role")
-	status, _ := strconv.Atoi(c.DefaultQuery("status", "-1"))
+	// 1. Parse Query Parameters (page/cursor, per_page, search, role, status, outlet_id, sort_by, sort_order)
+	params := listquery.ParseParams(c.Request.URL.Query(), "role", "status", "outlet_id")
+	format, ok := negotiateExport(c)
+	if !ok {
+		return
+	}
 
-	// 2. Call Service
-	res, err := h.userService.GetUsers(c.Request.Context(), page, perPage, search, role, status)
+	// 2. Call Service (export streams every matching row, JSON returns one page)
+	var res *dto.UserListResponse
+	var err error
+	if format != "" {
+		err = streamExport(c, "users", format, func(w export.Writer) error {
+			return h.userService.ExportUsers(c.Request.Context(), params, w)
+		})
+	} else {
+		res, err = h.userService.GetUsers(c.Request.Context(), params)
+	}
 	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid list parameters", err.Error())
+			return
+		}
 		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "An unexpected server error occurred", nil)
 		return
 	}
+	if format != "" {
+		return
+	}
 
 	// 3. Success Response with Meta
 	response.SuccessMeta(c, "Users retrieved successfully", res.Data, res.Meta)
@@ -94,7 +115,8 @@ func (h *UserHandler) GetDetailUser(c *gin.Context) {
 		return
 	}
 
-	// 3. Success Response
+	// 3. Success Response (ETag is sent back as If-Match on update)
+	setETag(c, res.Version)
 	response.SuccessOK(c, "User detail retrieved successfully", res)
 }
 
@@ -133,8 +155,14 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	// Expected version from the optional If-Match header
+	expectedVersion, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// 4. Call Service with Requester Context
-	res, err := h.userService.ModifyUserData(c.Request.Context(), targetID, req, requesterID, requesterRole.(string))
+	res, err := h.userService.ModifyUserData(c.Request.Context(), targetID, req, requesterID, requesterRole.(string), expectedVersion)
 	if err != nil {
 		// [FIX] Map Specific Errors menggunakan errors.Is
 		if errors.Is(err, response.ErrForbidden) {
@@ -149,12 +177,21 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 			response.ErrorResponse(c, http.StatusConflict, response.CodeDuplicate, "Data conflict detected (duplicate entry)", "Username, email, or phone number is already taken")
 			return
 		}
+		if errors.Is(err, response.ErrPreconditionFailed) {
+			respondPreconditionFailed(c)
+			return
+		}
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid outlet assignment", err.Error())
+			return
+		}
 
 		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "An unexpected server error occurred", nil)
 		return
 	}
 
 	// 5. Success Response
+	setETag(c, res.Version)
 	response.SuccessOK(c, "User updated successfully", res)
 }
 
@@ -182,8 +219,14 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	// 3. Call Service
-	if err := h.userService.DeactivateUserAccount(c.Request.Context(), targetID, requesterID); err != nil {
+	// 3. Expected version (If-Match)
+	expectedVersion, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	// 4. Call Service
+	if err := h.userService.DeactivateUserAccount(c.Request.Context(), targetID, requesterID, expectedVersion); err != nil {
 		// [FIX] Gunakan errors.Is
 		if errors.Is(err, response.ErrForbidden) {
 			response.ErrorResponse(c, http.StatusForbidden, response.CodeForbidden, "Action not permitted (cannot delete self)", nil)
@@ -193,12 +236,16 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "User not found", nil)
 			return
 		}
+		if errors.Is(err, response.ErrPreconditionFailed) {
+			respondPreconditionFailed(c)
+			return
+		}
 
 		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "An unexpected server error occurred", nil)
 		return
 	}
 
-	// 4. Success Response
+	// 5. Success Response
 	// We return the ID of the deleted user as data.
 	response.SuccessOK(c, "User account deactivated successfully", gin.H{"id": targetID})
 }
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WalletHandler struct {
+	walletService services.WalletService
+}
+
+func NewWalletHandler(walletService services.WalletService) *WalletHandler {
+	return &WalletHandler{walletService: walletService}
+}
+
+// HandleGetWallet handles GET /api/v1/customers/:id/wallet.
+func (h *WalletHandler) HandleGetWallet(c *gin.Context) {
+
+	// 1. Ambil ID pelanggan dari URL Path
+	customerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.walletService.GetWallet(c.Request.Context(), customerID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Customer not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetWallet: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve wallet", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Wallet retrieved successfully", res)
+}
+
+// HandleGetWalletLedger handles GET /api/v1/customers/:id/wallet/transactions.
+func (h *WalletHandler) HandleGetWalletLedger(c *gin.Context) {
+
+	// 1. Ambil ID pelanggan & pagination
+	customerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	page, perPage := parsePagination(c)
+
+	// 2. Panggil Service
+	res, err := h.walletService.GetWalletLedger(c.Request.Context(), customerID, page, perPage)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Customer not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetWalletLedger: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve wallet transactions", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Wallet transactions retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleGetPointLedger handles GET /api/v1/customers/:id/points/transactions.
+func (h *WalletHandler) HandleGetPointLedger(c *gin.Context) {
+
+	// 1. Ambil ID pelanggan & pagination
+	customerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	page, perPage := parsePagination(c)
+
+	// 2. Panggil Service
+	res, err := h.walletService.GetPointLedger(c.Request.Context(), customerID, page, perPage)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Customer not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetPointLedger: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve point transactions", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Point transactions retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleTopUp handles POST /api/v1/customers/:id/wallet/top-ups.
+func (h *WalletHandler) HandleTopUp(c *gin.Context) {
+
+	// 1. Ambil ID pelanggan & ID kasir
+	customerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.WalletTopUpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.walletService.TopUp(c.Request.Context(), customerID, req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Customer not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] TopUp: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to top up wallet", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Wallet topped up successfully", res)
+}
+
+// HandleAdjustBalance handles POST /api/v1/customers/:id/wallet/adjustments.
+func (h *WalletHandler) HandleAdjustBalance(c *gin.Context) {
+
+	// 1. Ambil ID pelanggan & ID owner
+	customerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.WalletAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.walletService.AdjustBalance(c.Request.Context(), customerID, req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid adjustment", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Customer not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrInsufficientBalance) {
+			response.ErrorResponse(c, http.StatusUnprocessableEntity, response.CodeInsufficientBalance, "Wallet balance is not sufficient", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] AdjustBalance: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to adjust wallet balance", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Wallet balance adjusted successfully", res)
+}
+
+// HandleAdjustPoints handles POST /api/v1/customers/:id/points/adjustments.
+func (h *WalletHandler) HandleAdjustPoints(c *gin.Context) {
+
+	// 1. Ambil ID pelanggan & ID owner
+	customerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.PointAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.walletService.AdjustPoints(c.Request.Context(), customerID, req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Customer not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrInsufficientPoints) {
+			response.ErrorResponse(c, http.StatusUnprocessableEntity, response.CodeInsufficientPoints, "Point balance is not sufficient", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] AdjustPoints: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to adjust points", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Points adjusted successfully", res)
+}
+
+// --- HELPER FUNCTION ---
+
+// getRequesterID mengambil ID user login dari Auth Middleware.
+// Jika gagal, response error sudah dikirim dan pemanggil cukup return.
+func getRequesterID(c *gin.Context) (int64, bool) {
+	requesterIDRaw, ok := c.Get("user_id")
+	if !ok {
+		response.ErrorResponse(c, http.StatusUnauthorized, response.CodeUnauthorized, "Invalid authentication context", nil)
+		return 0, false
+	}
+
+	requesterID, okAssert := requesterIDRaw.(int64)
+	if !okAssert {
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Invalid user ID type (expected int64)", nil)
+		return 0, false
+	}
+
+	return requesterID, true
+}
+
+// parsePagination membaca page & per_page dengan "Safety Net" nilai default.
+func parsePagination(c *gin.Context) (int, int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+	return page, perPage
+}
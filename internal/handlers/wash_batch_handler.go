@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WashBatchHandler struct {
+	washBatchService services.WashBatchService
+}
+
+func NewWashBatchHandler(washBatchService services.WashBatchService) *WashBatchHandler {
+	return &WashBatchHandler{washBatchService: washBatchService}
+}
+
+// HandleStartWashBatch handles POST /api/v1/wash-batches.
+func (h *WashBatchHandler) HandleStartWashBatch(c *gin.Context) {
+
+	// 1. Ambil identitas operator dari Auth Middleware
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+	actorRole := c.GetString("role")
+
+	// 2. Validasi Payload JSON
+	var req dto.StartWashBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.washBatchService.StartBatch(c.Request.Context(), req, actorID, actorRole)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot start wash batch", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Machine not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrMachineUnavailable) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeMachineUnavailable, "Machine is not available", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrWashBatchConflict) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeWashBatchConflict, "Item is already in a running batch", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrInvalidTransition) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeInvalidTransition, "Status transition is not allowed", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] StartWashBatch: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to start wash batch", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Wash batch started successfully", res)
+}
+
+// HandleFinishWashBatch handles POST /api/v1/wash-batches/:id/finish.
+func (h *WashBatchHandler) HandleFinishWashBatch(c *gin.Context) {
+
+	// 1. Ambil ID dari URL & identitas operator
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+	actorRole := c.GetString("role")
+
+	// 2. Panggil Service
+	res, err := h.washBatchService.FinishBatch(c.Request.Context(), id, actorID, actorRole)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Cannot finish wash batch", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Wash batch not found", nil)
+			return
+		}
+		if errors.Is(err, response.ErrWashBatchConflict) {
+			response.ErrorResponse(c, http.StatusConflict, response.CodeWashBatchConflict, "Wash batch is already finished", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] FinishWashBatch: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to finish wash batch", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Wash batch finished successfully", res)
+}
+
+// HandleGetWashBatchList handles GET /api/v1/wash-batches.
+func (h *WashBatchHandler) HandleGetWashBatchList(c *gin.Context) {
+
+	// 1. Ambil parameter list (page/cursor, per_page, filter mesin/status/operator/pesanan/tanggal, sort_by, order)
+	params := listquery.ParseParams(c.Request.URL.Query(), "machine_id", "batch_status", "started_by", "order_id", "start_date", "end_date", "outlet_id")
+
+	// 2. Panggil Service
+	res, err := h.washBatchService.GetBatches(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid list parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetWashBatches: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve wash batches", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Wash batches retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleGetWashBatchDetail handles GET /api/v1/wash-batches/:id.
+func (h *WashBatchHandler) HandleGetWashBatchDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.washBatchService.GetBatchDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Wash batch not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetWashBatchDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve wash batch", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Wash batch retrieved successfully", res)
+}
+
+// HandleGetMachineUtilization handles GET /api/v1/reports/machine-utilization?start_date=&end_date=&outlet_id=.
+func (h *WashBatchHandler) HandleGetMachineUtilization(c *gin.Context) {
+
+	// 1. Ambil rentang tanggal (default: hari ini) & outlet laporan
+	today := time.Now().Format("2006-01-02")
+	startDate := c.DefaultQuery("start_date", today)
+	endDate := c.DefaultQuery("end_date", startDate)
+	if !scopeReportOutlet(c) {
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.washBatchService.GetMachineUtilization(c.Request.Context(), startDate, endDate)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid report parameters", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] GetMachineUtilization: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve machine utilization report", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Machine utilization report retrieved successfully", res)
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/services"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookService services.WebhookService
+}
+
+func NewWebhookHandler(webhookService services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// HandleCreateWebhook handles POST /api/v1/webhooks.
+func (h *WebhookHandler) HandleCreateWebhook(c *gin.Context) {
+
+	// 1. Ambil ID Owner dari token
+	actorID, ok := getRequesterID(c)
+	if !ok {
+		return
+	}
+
+	// 2. Validasi Payload JSON
+	var req dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.webhookService.CreateEndpoint(c.Request.Context(), req, actorID)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid webhook data", err.Error())
+			return
+		}
+
+		fmt.Printf("[ERROR] CreateWebhook: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to create webhook", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessCreated(c, "Webhook created successfully", res)
+}
+
+// HandleGetWebhookList handles GET /api/v1/webhooks.
+func (h *WebhookHandler) HandleGetWebhookList(c *gin.Context) {
+
+	// 1. Panggil Service
+	res, err := h.webhookService.GetEndpoints(c.Request.Context())
+	if err != nil {
+		fmt.Printf("[ERROR] GetWebhookList: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve webhooks", nil)
+		return
+	}
+
+	// 2. Sukses
+	response.SuccessOK(c, "Webhooks retrieved successfully", res)
+}
+
+// HandleGetWebhookDetail handles GET /api/v1/webhooks/:id.
+func (h *WebhookHandler) HandleGetWebhookDetail(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, ok := parseWebhookParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.webhookService.GetEndpointDetail(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Webhook not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetWebhookDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve webhook detail", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Webhook detail retrieved successfully", res)
+}
+
+// HandleUpdateWebhook handles PUT /api/v1/webhooks/:id.
+func (h *WebhookHandler) HandleUpdateWebhook(c *gin.Context) {
+
+	// 1. Ambil ID dari URL
+	id, ok := parseWebhookParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// 2. Ambil Data JSON dari Body
+	var req dto.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request payload", err.Error())
+		return
+	}
+
+	// 3. Panggil Service
+	res, err := h.webhookService.UpdateEndpoint(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, response.ErrValidation) {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid webhook data", err.Error())
+			return
+		}
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Webhook not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] UpdateWebhook: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to update webhook", nil)
+		return
+	}
+
+	// 4. Sukses
+	response.SuccessOK(c, "Webhook updated successfully", res)
+}
+
+// HandleDeleteWebhook handles DELETE /api/v1/webhooks/:id.
+func (h *WebhookHandler) HandleDeleteWebhook(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, ok := parseWebhookParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service
+	if err := h.webhookService.DeleteEndpoint(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Webhook not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] DeleteWebhook: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to delete webhook", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Webhook deleted successfully", map[string]int64{"id": id})
+}
+
+// HandleRotateWebhookSecret handles POST /api/v1/webhooks/:id/rotate-secret.
+func (h *WebhookHandler) HandleRotateWebhookSecret(c *gin.Context) {
+
+	// 1. Ambil ID dari URL Path
+	id, ok := parseWebhookParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.webhookService.RotateSecret(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Webhook not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] RotateWebhookSecret: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to rotate webhook secret", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Webhook secret rotated successfully", res)
+}
+
+// HandleGetWebhookDeliveries handles GET /api/v1/webhooks/:id/deliveries.
+func (h *WebhookHandler) HandleGetWebhookDeliveries(c *gin.Context) {
+
+	// 1. Ambil ID, filter & pagination
+	id, ok := parseWebhookParam(c, "id")
+	if !ok {
+		return
+	}
+	var query dto.WebhookDeliveryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid delivery filter", err.Error())
+		return
+	}
+	page, perPage := parsePagination(c)
+
+	// 2. Panggil Service
+	res, err := h.webhookService.GetDeliveries(c.Request.Context(), id, query, page, perPage)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Webhook not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetWebhookDeliveries: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve webhook deliveries", nil)
+		return
+	}
+
+	// 3. Sukses dengan Meta (Pagination)
+	response.SuccessMeta(c, "Webhook deliveries retrieved successfully", res.Data, res.Meta)
+}
+
+// HandleGetWebhookDeliveryDetail handles GET /api/v1/webhooks/:id/deliveries/:deliveryId.
+func (h *WebhookHandler) HandleGetWebhookDeliveryDetail(c *gin.Context) {
+
+	// 1. Ambil ID endpoint & pengiriman
+	id, ok := parseWebhookParam(c, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseWebhookParam(c, "deliveryId")
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.webhookService.GetDeliveryDetail(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Webhook delivery not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] GetWebhookDeliveryDetail: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to retrieve webhook delivery", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessOK(c, "Webhook delivery retrieved successfully", res)
+}
+
+// HandleReplayWebhookDelivery handles POST /api/v1/webhooks/:id/deliveries/:deliveryId/replay.
+func (h *WebhookHandler) HandleReplayWebhookDelivery(c *gin.Context) {
+
+	// 1. Ambil ID endpoint & pengiriman
+	id, ok := parseWebhookParam(c, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseWebhookParam(c, "deliveryId")
+	if !ok {
+		return
+	}
+
+	// 2. Panggil Service
+	res, err := h.webhookService.ReplayDelivery(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			response.ErrorResponse(c, http.StatusNotFound, response.CodeNotFound, "Webhook delivery not found", nil)
+			return
+		}
+
+		fmt.Printf("[ERROR] ReplayWebhookDelivery: %v\n", err)
+
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Failed to replay webhook delivery", nil)
+		return
+	}
+
+	// 3. Sukses
+	response.SuccessCreated(c, "Webhook delivery queued for replay", res)
+}
+
+// parseWebhookParam membaca ID numerik dari URL Path dan langsung membalas 400 jika tidak valid.
+func parseWebhookParam(c *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil {
+		response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid ID Format", "ID must be a number")
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,134 @@
+// Package imports membaca file CSV untuk import massal master data (layanan, kategori, pelanggan)
+// dan memvalidasi setiap baris dengan aturan `binding` yang sama seperti DTO request JSON.
+package imports
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MaxRows adalah batas baris data per file (tidak termasuk header)
+const MaxRows = 1000
+
+// Error pembacaan file; service membungkusnya dengan response.ErrValidation
+var (
+	ErrEmptyFile     = errors.New("file is empty")
+	ErrTooManyRows   = fmt.Errorf("file has more than %d rows", MaxRows)
+	ErrMissingColumn = errors.New("missing required column")
+)
+
+// Row adalah satu baris data CSV. Line adalah nomor baris di file (header = baris 1).
+type Row struct {
+	Line   int
+	Values map[string]string
+}
+
+// Get mengambil nilai kolom yang sudah di-trim (kosong jika kolom tidak ada).
+func (r Row) Get(column string) string {
+	return r.Values[column]
+}
+
+// Optional mengembalikan nil untuk sel kosong, cocok untuk field pointer DTO.
+func (r Row) Optional(column string) *string {
+	value := r.Values[column]
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// ReadCSV membaca seluruh baris file. Judul kolom dinormalisasi (huruf kecil, spasi → underscore),
+// BOM UTF-8 dari Excel dibuang, dan pemisah `;` (Excel berbahasa Indonesia) dikenali otomatis.
+// Baris yang seluruh selnya kosong dilewati.
+func ReadCSV(r io.Reader, required []string) ([]Row, error) {
+
+	// 1. Deteksi pemisah dari baris judul
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(3); string(bom) == "\xEF\xBB\xBF" {
+		br.Discard(3)
+	}
+	firstLine, _ := br.Peek(4096) // Peek mengembalikan isi yang tersedia walau file lebih pendek
+	if len(firstLine) == 0 {
+		return nil, ErrEmptyFile
+	}
+	if idx := strings.IndexAny(string(firstLine), "\r\n"); idx >= 0 {
+		firstLine = firstLine[:idx]
+	}
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1 // Jumlah kolom dicek sendiri agar pesan error per baris lebih jelas
+	reader.TrimLeadingSpace = true
+	if strings.Count(string(firstLine), ";") > strings.Count(string(firstLine), ",") {
+		reader.Comma = ';'
+	}
+
+	// 2. Baca & normalisasi judul kolom
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmptyFile
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	for i, h := range header {
+		header[i] = normalizeHeader(h)
+	}
+
+	present := make(map[string]bool, len(header))
+	for _, h := range header {
+		present[h] = true
+	}
+	for _, col := range required {
+		if !present[col] {
+			return nil, fmt.Errorf("%w: %s", ErrMissingColumn, col)
+		}
+	}
+
+	// 3. Baca baris data
+	rows := make([]Row, 0, 64)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line, Values: make(map[string]string, len(header))}
+		blank := true
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			if value != "" {
+				blank = false
+			}
+			row.Values[header[i]] = value
+		}
+		if blank {
+			continue
+		}
+
+		if len(rows) == MaxRows {
+			return nil, ErrTooManyRows
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// normalizeHeader mengubah "Service Name" / " service_name " menjadi "service_name".
+func normalizeHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(h))
+	return strings.Join(strings.FieldsFunc(h, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "_")
+}
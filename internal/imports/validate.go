@@ -0,0 +1,82 @@
+package imports
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// fieldError adalah bagian dari validator.FieldError yang dibutuhkan untuk menyusun pesan per kolom.
+type fieldError interface {
+	StructField() string
+	Tag() string
+	Param() string
+	Kind() reflect.Kind
+}
+
+// Validate menjalankan aturan tag `binding` milik DTO (validator yang sama dengan ShouldBindJSON)
+// dan mengembalikan pesan per kolom, cth: "price: must be at least 0". Nil jika valid.
+func Validate(obj interface{}) []string {
+	err := binding.Validator.ValidateStruct(obj)
+	if err == nil {
+		return nil
+	}
+
+	// validator.ValidationErrors adalah slice FieldError; dibaca lewat reflect agar paket validator
+	// tidak perlu diimpor langsung.
+	v := reflect.ValueOf(err)
+	if v.Kind() != reflect.Slice {
+		return []string{err.Error()}
+	}
+
+	messages := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		fe, ok := v.Index(i).Interface().(fieldError)
+		if !ok {
+			messages = append(messages, fmt.Sprint(v.Index(i).Interface()))
+			continue
+		}
+		messages = append(messages, columnName(obj, fe.StructField())+": "+ruleMessage(fe.Tag(), fe.Param(), fe.Kind()))
+	}
+	return messages
+}
+
+// columnName memetakan nama field struct ke nama kolom CSV (tag json).
+func columnName(obj interface{}, field string) string {
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if f, ok := t.FieldByName(field); ok {
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return field
+}
+
+// ruleMessage menerjemahkan tag validator yang dipakai DTO master data menjadi pesan singkat.
+// Untuk teks, min/max adalah panjang karakter.
+func ruleMessage(tag, param string, kind reflect.Kind) string {
+	unit := ""
+	if kind == reflect.String {
+		unit = " characters"
+	}
+
+	switch tag {
+	case "required":
+		return "is required"
+	case "min":
+		return "must be at least " + param + unit
+	case "max":
+		return "must be at most " + param + unit
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(param, " ", ", ")
+	case "numeric":
+		return "must contain digits only"
+	default:
+		return "failed on the '" + tag + "' rule"
+	}
+}
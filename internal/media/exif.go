@@ -0,0 +1,144 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+)
+
+// exifOrientationTag adalah tag Orientation (0x0112) di IFD0 EXIF.
+const exifOrientationTag = 0x0112
+
+// jpegOrientation membaca tag EXIF Orientation (1–8) dari segmen APP1 JPEG.
+// Mengembalikan 1 (tanpa rotasi) untuk PNG, JPEG tanpa EXIF, atau EXIF yang rusak.
+func jpegOrientation(data []byte) int {
+
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// 1. Telusuri segmen sampai APP1 "Exif" (berhenti di awal data gambar)
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		if marker == 0xFF { // Byte pengisi
+			pos++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 { // SOS / EOI
+			return 1
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) { // Marker tanpa panjang
+			pos += 2
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return 1
+		}
+		if segment := data[pos+4 : end]; marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		pos = end
+	}
+
+	return 1
+}
+
+// tiffOrientation membaca Orientation dari header TIFF di dalam segmen EXIF.
+func tiffOrientation(tiff []byte) int {
+
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	// 2. Urutan byte: "II" little-endian, "MM" big-endian
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 1
+	}
+
+	// 3. Cari tag Orientation di IFD0 (entri 12 byte: tag, tipe, jumlah, nilai)
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+		if value := int(order.Uint16(tiff[entry+8:])); value >= 1 && value <= 8 {
+			return value
+		}
+		return 1
+	}
+
+	return 1
+}
+
+// orientedImage menampilkan src sesuai EXIF Orientation tanpa menyalin piksel.
+// Orientasi 5–8 menukar lebar & tinggi (foto HP yang diambil tegak).
+type orientedImage struct {
+	src         image.Image
+	orientation int
+}
+
+// applyOrientation membungkus src agar piksel (0,0) adalah pojok kiri atas foto seperti yang dilihat pengguna.
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+	return &orientedImage{src: src, orientation: orientation}
+}
+
+func (o *orientedImage) ColorModel() color.Model { return o.src.ColorModel() }
+
+func (o *orientedImage) Bounds() image.Rectangle {
+	b := o.src.Bounds()
+	if o.orientation >= 5 {
+		return image.Rect(0, 0, b.Dy(), b.Dx())
+	}
+	return image.Rect(0, 0, b.Dx(), b.Dy())
+}
+
+func (o *orientedImage) At(x, y int) color.Color {
+	b := o.src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	sx, sy := x, y
+	switch o.orientation {
+	case 2: // Cermin horizontal
+		sx = w - 1 - x
+	case 3: // Putar 180°
+		sx, sy = w-1-x, h-1-y
+	case 4: // Cermin vertikal
+		sy = h - 1 - y
+	case 5: // Transpose
+		sx, sy = y, x
+	case 6: // Putar 90° searah jarum jam
+		sx, sy = y, h-1-x
+	case 7: // Transverse
+		sx, sy = w-1-y, h-1-x
+	case 8: // Putar 90° berlawanan jarum jam
+		sx, sy = w-1-y, x
+	}
+	return o.src.At(b.Min.X+sx, b.Min.Y+sy)
+}
@@ -0,0 +1,135 @@
+// Package media memeriksa foto yang diunggah (jenis file asli, dimensi) dan membuat thumbnail,
+// hanya memakai paket image bawaan Go (JPEG & PNG).
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // Registrasi decoder PNG untuk image.Decode
+	"net/http"
+)
+
+// Jenis file yang diterima (dideteksi dari isi file, bukan header Content-Type klien)
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+)
+
+// thumbnailQuality adalah kualitas JPEG thumbnail (cukup tajam untuk daftar foto, tetap kecil)
+const thumbnailQuality = 80
+
+// thumbnailSamples adalah jumlah titik sampel per sisi untuk setiap piksel thumbnail (4x4 = 16 titik)
+const thumbnailSamples = 4
+
+var (
+	// ErrUnsupportedType menandai file yang bukan JPEG / PNG.
+	ErrUnsupportedType = errors.New("unsupported image type")
+
+	// ErrInvalidDimensions menandai gambar yang terlalu kecil / terlalu besar.
+	ErrInvalidDimensions = errors.New("invalid image dimensions")
+)
+
+// Info adalah hasil pemeriksaan satu gambar.
+type Info struct {
+	ContentType string
+	Extension   string // Tanpa titik, cth: jpg
+	Width       int    // Sesudah EXIF Orientation diterapkan (foto tegak dari HP tetap tegak)
+	Height      int
+}
+
+// Inspect mendeteksi jenis file dari isinya dan membaca dimensi tanpa men-decode seluruh gambar.
+// Sisi terpendek minimal minSide dan sisi terpanjang maksimal maxSide piksel.
+func Inspect(data []byte, minSide, maxSide int) (*Info, error) {
+
+	// 1. Deteksi jenis dari magic bytes
+	info := &Info{ContentType: http.DetectContentType(data)}
+	switch info.ContentType {
+	case ContentTypeJPEG:
+		info.Extension = "jpg"
+	case ContentTypePNG:
+		info.Extension = "png"
+	default:
+		return nil, fmt.Errorf("%w: %s (only JPEG and PNG are accepted)", ErrUnsupportedType, info.ContentType)
+	}
+
+	// 2. Baca header gambar (murah, tidak mengalokasikan piksel)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+	info.Width, info.Height = cfg.Width, cfg.Height
+	if info.ContentType == ContentTypeJPEG && jpegOrientation(data) >= 5 {
+		info.Width, info.Height = cfg.Height, cfg.Width
+	}
+
+	// 3. Validasi dimensi
+	if min(info.Width, info.Height) < minSide {
+		return nil, fmt.Errorf("%w: %dx%d, shortest side must be at least %d px", ErrInvalidDimensions, info.Width, info.Height, minSide)
+	}
+	if max(info.Width, info.Height) > maxSide {
+		return nil, fmt.Errorf("%w: %dx%d, longest side must be at most %d px", ErrInvalidDimensions, info.Width, info.Height, maxSide)
+	}
+
+	return info, nil
+}
+
+// Thumbnail mengecilkan gambar agar sisi terpanjangnya maksimal size piksel dan mengembalikannya sebagai JPEG.
+// Gambar yang sudah kecil tidak diperbesar. Area transparan PNG diberi latar putih.
+// EXIF Orientation diterapkan sebelum dikecilkan karena thumbnail JPEG baru tidak membawa EXIF.
+func Thumbnail(data []byte, size int) ([]byte, error) {
+	decoded, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+	src := decoded
+	if format == "jpeg" {
+		src = applyOrientation(decoded, jpegOrientation(data))
+	}
+
+	// 1. Hitung ukuran thumbnail (rasio dipertahankan)
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dstW, dstH := srcW, srcH
+	if longest := max(srcW, srcH); longest > size {
+		dstW = max(1, srcW*size/longest)
+		dstH = max(1, srcH*size/longest)
+	}
+
+	// 2. Setiap piksel tujuan = rata-rata grid sampel di area sumbernya (box filter sederhana)
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		for x := 0; x < dstW; x++ {
+			var r, g, b, n uint64
+			for sy := 0; sy < thumbnailSamples; sy++ {
+				py := bounds.Min.Y + ((2*y+1)*thumbnailSamples+2*sy+1-thumbnailSamples)*srcH/(2*dstH*thumbnailSamples)
+				for sx := 0; sx < thumbnailSamples; sx++ {
+					px := bounds.Min.X + ((2*x+1)*thumbnailSamples+2*sx+1-thumbnailSamples)*srcW/(2*dstW*thumbnailSamples)
+					cr, cg, cb, ca := src.At(clamp(px, bounds.Min.X, bounds.Max.X-1), clamp(py, bounds.Min.Y, bounds.Max.Y-1)).RGBA()
+
+					// Warna premultiplied alpha digabung dengan latar putih
+					white := 0xffff - uint64(ca)
+					r += uint64(cr) + white
+					g += uint64(cg) + white
+					b += uint64(cb) + white
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n >> 8), G: uint8(g / n >> 8), B: uint8(b / n >> 8), A: 0xff})
+		}
+	}
+
+	// 3. Encode JPEG
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("media.Thumbnail.Encode: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func clamp(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
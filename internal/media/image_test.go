@@ -0,0 +1,159 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+var (
+	red  = color.RGBA{R: 0xff, A: 0xff}
+	blue = color.RGBA{B: 0xff, A: 0xff}
+)
+
+// halfJPEG membuat JPEG 32x16: separuh kiri merah, separuh kanan biru.
+func halfJPEG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 32, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 32; x++ {
+			if x < 16 {
+				img.SetRGBA(x, y, red)
+			} else {
+				img.SetRGBA(x, y, blue)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withOrientation menyisipkan segmen APP1 EXIF berisi satu tag Orientation tepat setelah SOI.
+func withOrientation(data []byte, orientation uint16, order binary.ByteOrder) []byte {
+	tiff := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], exifOrientationTag)
+	order.PutUint16(tiff[12:], 3) // SHORT
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func TestJPEGOrientation(t *testing.T) {
+
+	plain := halfJPEG(t)
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "no exif", data: plain, want: 1},
+		{name: "little endian rotate 90", data: withOrientation(plain, 6, binary.LittleEndian), want: 6},
+		{name: "big endian rotate 270", data: withOrientation(plain, 8, binary.BigEndian), want: 8},
+		{name: "out of range value", data: withOrientation(plain, 9, binary.LittleEndian), want: 1},
+		{name: "not a jpeg", data: []byte("\x89PNG\r\n\x1a\n"), want: 1},
+		{name: "truncated segment", data: []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x40, 0x00, 'E'}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jpegOrientation(tt.data); got != tt.want {
+				t.Fatalf("jpegOrientation = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThumbnailAppliesOrientation(t *testing.T) {
+
+	plain := halfJPEG(t)
+
+	tests := []struct {
+		name         string
+		orientation  uint16
+		wantW, wantH int
+		wantFirst    color.RGBA // Warna di awal foto: kiri (landscape) atau atas (portrait)
+	}{
+		{name: "normal", orientation: 1, wantW: 32, wantH: 16, wantFirst: red},
+		{name: "rotate 180", orientation: 3, wantW: 32, wantH: 16, wantFirst: blue},
+		{name: "rotate 90 clockwise", orientation: 6, wantW: 16, wantH: 32, wantFirst: red},
+		{name: "rotate 90 counter-clockwise", orientation: 8, wantW: 16, wantH: 32, wantFirst: blue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := withOrientation(plain, tt.orientation, binary.LittleEndian)
+
+			info, err := Inspect(data, 1, 100)
+			if err != nil {
+				t.Fatalf("Inspect: %v", err)
+			}
+			if info.Width != tt.wantW || info.Height != tt.wantH {
+				t.Fatalf("Inspect = %dx%d, want %dx%d", info.Width, info.Height, tt.wantW, tt.wantH)
+			}
+
+			thumb, err := Thumbnail(data, 100)
+			if err != nil {
+				t.Fatalf("Thumbnail: %v", err)
+			}
+			img, err := jpeg.Decode(bytes.NewReader(thumb))
+			if err != nil {
+				t.Fatalf("decode thumbnail: %v", err)
+			}
+			if b := img.Bounds(); b.Dx() != tt.wantW || b.Dy() != tt.wantH {
+				t.Fatalf("thumbnail = %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.wantW, tt.wantH)
+			}
+
+			first, last := img.At(2, 2), img.At(tt.wantW-3, tt.wantH-3)
+			if !near(first, tt.wantFirst) || near(last, tt.wantFirst) {
+				t.Fatalf("corners = %v / %v, want the %v half first", first, last, tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestThumbnailDownscalesLongestSide(t *testing.T) {
+
+	thumb, err := Thumbnail(withOrientation(halfJPEG(t), 6, binary.BigEndian), 8)
+	if err != nil {
+		t.Fatalf("Thumbnail: %v", err)
+	}
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(thumb))
+	if err != nil {
+		t.Fatalf("decode thumbnail: %v", err)
+	}
+	if cfg.Width != 4 || cfg.Height != 8 {
+		t.Fatalf("thumbnail = %dx%d, want 4x8", cfg.Width, cfg.Height)
+	}
+}
+
+// near membandingkan warna dengan toleransi kompresi JPEG.
+func near(c color.Color, want color.RGBA) bool {
+	r, g, b, _ := c.RGBA()
+	diff := func(got uint32, want uint8) bool {
+		d := int(got>>8) - int(want)
+		return d > -48 && d < 48
+	}
+	return diff(r, want.R) && diff(g, want.G) && diff(b, want.B)
+}
@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"laundry-backend/internal/config"
+	"laundry-backend/internal/outlet"
 	"laundry-backend/internal/repositories"
 	"laundry-backend/pkg/response"
 	"laundry-backend/pkg/utils"
@@ -57,14 +58,25 @@ func AuthMiddleware(authRepo repositories.AuthRepository, cfg *config.Config) gi
 			return
 		}
 
-		// 6. [BARU] Simpan data penting ke Context agar bisa dipakai di Handler Logout
+		// 6. Selain owner wajib terikat outlet (token lama tanpa outlet harus login ulang)
+		if claims.OutletID == outlet.All && claims.Role != "owner" {
+			response.ErrorResponse(c, http.StatusUnauthorized, response.CodeInvalidToken, "Unauthorized: Token has no outlet", "Please login again")
+			c.Abort()
+			return
+		}
+
+		// 7. [BARU] Simpan data penting ke Context agar bisa dipakai di Handler Logout
 		c.Set("user_id", claims.UserID)
 		c.Set("username", claims.Username)
 		c.Set("role", claims.Role)
+		c.Set("outlet_id", claims.OutletID)
 		c.Set("jti", claims.ID)             // Penting untuk Logout
 		c.Set("exp", claims.ExpiresAt.Time) // Penting untuk Logout
 
-		// 7. Lanjutkan ke proses berikutnya
+		// 8. Batasi query repository ke outlet aktif (dibaca lewat context.Context request)
+		c.Request = c.Request.WithContext(outlet.WithScope(c.Request.Context(), claims.OutletID))
+
+		// 9. Lanjutkan ke proses berikutnya
 		c.Next()
 	}
 }
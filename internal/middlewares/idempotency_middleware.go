@@ -0,0 +1,213 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Header idempotensi
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotencyReplayed = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength mengikuti panjang kolom idempotency_keys.idempotency_key.
+const maxIdempotencyKeyLength = 255
+
+// completeAttempts & completeRetryDelay mengatur percobaan ulang penyimpanan balasan.
+// Variabel (bukan konstanta) agar test tidak perlu menunggu jeda sungguhan.
+var (
+	completeAttempts   = 3
+	completeRetryDelay = 100 * time.Millisecond
+)
+
+// IdempotencyMiddleware mencegah request mutasi dijalankan dua kali saat aplikasi kasir me-retry.
+//
+// Request dengan header Idempotency-Key yang sama (per user):
+//   - payload identik & request pertama sudah selesai → balasan tersimpan diputar ulang
+//   - payload berbeda → 409 IDEMPOTENCY_KEY_MISMATCH
+//   - request pertama masih berjalan → 409 IDEMPOTENCY_KEY_IN_PROGRESS (coba lagi setelah Retry-After)
+//
+// Tanpa header, request diproses seperti biasa. Wajib dipasang setelah AuthMiddleware.
+func IdempotencyMiddleware(idempotencyRepo repositories.IdempotencyRepository, cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		// 1. Tanpa header: tidak ada perlindungan, lanjut seperti biasa
+		key := c.GetHeader(HeaderIdempotencyKey)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid Idempotency-Key header", fmt.Sprintf("Idempotency-Key must be at most %d characters", maxIdempotencyKeyLength))
+			c.Abort()
+			return
+		}
+
+		// 2. Key berlaku per user (dipasang oleh AuthMiddleware)
+		userID, ok := c.Get("user_id")
+		requesterID, okAssert := userID.(int64)
+		if !ok || !okAssert {
+			response.ErrorResponse(c, http.StatusUnauthorized, response.CodeUnauthorized, "Unauthorized", "User ID not found in context")
+			c.Abort()
+			return
+		}
+
+		// 3. Baca body lalu kembalikan agar handler tetap bisa membacanya
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidation, "Invalid request body", err.Error())
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// 4. Klaim key. Unique index memastikan hanya satu dari request kembar yang lolos.
+		ctx := c.Request.Context()
+		now := time.Now()
+		record := &models.IdempotencyKey{
+			UserID:        requesterID,
+			Key:           key,
+			RequestMethod: c.Request.Method,
+			RequestPath:   c.Request.URL.RequestURI(),
+			RequestHash:   hashIdempotentRequest(c.Request.Method, c.Request.URL.RequestURI(), body),
+			ExpiresAt:     now.Add(time.Duration(cfg.IDEMPOTENCY.LockSec) * time.Second),
+		}
+		reserved, err := idempotencyRepo.Reserve(ctx, record, now)
+		if err != nil {
+			fmt.Printf("[ERROR] IdempotencyMiddleware.Reserve: %v\n", err)
+			response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Internal server error during idempotency check", nil)
+			c.Abort()
+			return
+		}
+
+		// 5. Key sudah dipegang request lain
+		if !reserved {
+			replayIdempotentResponse(c, idempotencyRepo, requesterID, key, record.RequestHash)
+			c.Abort()
+			return
+		}
+
+		// 6. Request pertama: jalankan handler sambil merekam balasannya
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		// 7. Setelah handler berjalan key TIDAK PERNAH dilepas: mutasinya mungkin sudah ter-commit
+		// (termasuk pada 5xx yang terjadi setelah commit). Baris yang tetap 'processing' membuat retry
+		// mendapat 409 sampai lock IDEMPOTENCY_LOCK_SECONDS habis.
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		// 8. Simpan balasan non-5xx untuk diputar ulang selama TTL (walau klien sudah memutus koneksi)
+		saveCtx := context.WithoutCancel(ctx)
+		completedAt := time.Now()
+		expiresAt := completedAt.Add(time.Duration(cfg.IDEMPOTENCY.TTLHours) * time.Hour)
+		if err := completeWithRetry(saveCtx, idempotencyRepo, record.ID, status, writer.Header().Get("Content-Type"), writer.body.Bytes(), completedAt, expiresAt); err != nil {
+			fmt.Printf("[ERROR] IdempotencyMiddleware.Complete: %v\n", err)
+		}
+	}
+}
+
+// completeWithRetry mencoba menyimpan balasan beberapa kali dengan jeda bertambah.
+// Jika tetap gagal, baris dibiarkan 'processing' (bukan dihapus) sehingga mutasi tidak dijalankan dua kali.
+func completeWithRetry(ctx context.Context, idempotencyRepo repositories.IdempotencyRepository, id int64, status int, contentType string, body []byte, completedAt, expiresAt time.Time) error {
+
+	var err error
+	for attempt := 1; attempt <= completeAttempts; attempt++ {
+		if err = idempotencyRepo.Complete(ctx, id, status, contentType, body, completedAt, expiresAt); err == nil {
+			return nil
+		}
+		if attempt < completeAttempts {
+			time.Sleep(time.Duration(attempt) * completeRetryDelay)
+		}
+	}
+
+	return fmt.Errorf("complete after %d attempts: %w", completeAttempts, err)
+}
+
+// replayIdempotentResponse membalas request yang key-nya sudah dipegang request lain.
+func replayIdempotentResponse(c *gin.Context, idempotencyRepo repositories.IdempotencyRepository, userID int64, key, requestHash string) {
+
+	// 1. Ambil rekaman request pertama
+	record, err := idempotencyRepo.FindByKey(c.Request.Context(), userID, key)
+	if err != nil {
+		// Baris baru saja dibersihkan karena kedaluwarsa → minta klien mencoba lagi
+		if errors.Is(err, response.ErrNotFound) {
+			respondIdempotencyInProgress(c)
+			return
+		}
+
+		fmt.Printf("[ERROR] IdempotencyMiddleware.FindByKey: %v\n", err)
+		response.ErrorResponse(c, http.StatusInternalServerError, response.CodeInternalServer, "Internal server error during idempotency check", nil)
+		return
+	}
+
+	// 2. Key dipakai ulang untuk request yang berbeda
+	if record.RequestHash != requestHash {
+		response.ErrorResponse(c, http.StatusConflict, response.CodeIdempotencyMismatch, "Idempotency-Key was already used for a different request", "Generate a new Idempotency-Key for every new operation")
+		return
+	}
+
+	// 3. Request pertama masih berjalan (atau gagal tanpa balasan tersimpan sampai lock habis)
+	if record.Status != models.IdempotencyCompleted || record.ResponseStatus == nil {
+		respondIdempotencyInProgress(c)
+		return
+	}
+
+	// 4. Putar ulang balasan tersimpan
+	contentType := "application/json; charset=utf-8"
+	if record.ResponseContentType != nil && *record.ResponseContentType != "" {
+		contentType = *record.ResponseContentType
+	}
+	c.Header(HeaderIdempotencyReplayed, "true")
+	c.Data(*record.ResponseStatus, contentType, record.ResponseBody)
+}
+
+func respondIdempotencyInProgress(c *gin.Context) {
+	c.Header("Retry-After", "1")
+	response.ErrorResponse(c, http.StatusConflict, response.CodeIdempotencyInProgress, "A request with this Idempotency-Key is still being processed", "Retry the same request shortly")
+}
+
+// hashIdempotentRequest membuat sidik SHA-256 dari method, path (termasuk query), dan body request.
+func hashIdempotentRequest(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte("\n"))
+	hash.Write([]byte(path))
+	hash.Write([]byte("\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// idempotencyWriter merekam body balasan sambil tetap meneruskannya ke klien.
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
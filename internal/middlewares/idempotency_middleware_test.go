@@ -0,0 +1,142 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeIdempotencyRepo menyimpan satu key di memori dan menghitung pemanggilan Complete.
+type fakeIdempotencyRepo struct {
+	repositories.IdempotencyRepository
+	reserved      bool
+	completeErrs  []error // Error per percobaan Complete secara berurutan; habis = sukses
+	completeCalls int
+	completed     bool
+}
+
+func (f *fakeIdempotencyRepo) Reserve(ctx context.Context, key *models.IdempotencyKey, now time.Time) (bool, error) {
+	if f.reserved {
+		return false, nil
+	}
+	f.reserved = true
+	key.ID = 1
+	return true, nil
+}
+
+func (f *fakeIdempotencyRepo) Complete(ctx context.Context, id int64, status int, contentType string, body []byte, completedAt, expiresAt time.Time) error {
+	f.completeCalls++
+	if f.completeCalls <= len(f.completeErrs) {
+		return f.completeErrs[f.completeCalls-1]
+	}
+	f.completed = true
+	return nil
+}
+
+func newIdempotencyTestRouter(repo *fakeIdempotencyRepo, handlerStatus int, handlerRuns *int) *gin.Engine {
+
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{IDEMPOTENCY: config.IdempotencyConfig{TTLHours: 24, LockSec: 60}}
+
+	router := gin.New()
+	router.POST("/orders",
+		func(c *gin.Context) { c.Set("user_id", int64(7)) },
+		IdempotencyMiddleware(repo, cfg),
+		func(c *gin.Context) {
+			*handlerRuns++
+			c.JSON(handlerStatus, gin.H{"ok": handlerStatus < 300})
+		},
+	)
+	return router
+}
+
+func sendIdempotent(router *gin.Engine) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"amount":1}`))
+	req.Header.Set(HeaderIdempotencyKey, "key-1")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyMiddlewareRetriesComplete(t *testing.T) {
+
+	completeRetryDelay = time.Millisecond
+	defer func() { completeRetryDelay = 100 * time.Millisecond }()
+
+	repo := &fakeIdempotencyRepo{completeErrs: []error{errors.New("deadlock"), errors.New("deadlock")}}
+	runs := 0
+	rec := sendIdempotent(newIdempotencyTestRouter(repo, http.StatusCreated, &runs))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if repo.completeCalls != 3 || !repo.completed {
+		t.Fatalf("Complete calls = %d (completed %v), want 3 and completed", repo.completeCalls, repo.completed)
+	}
+}
+
+func TestIdempotencyMiddlewareKeepsKeyWhenCompleteFails(t *testing.T) {
+
+	completeRetryDelay = time.Millisecond
+	defer func() { completeRetryDelay = 100 * time.Millisecond }()
+
+	failure := errors.New("connection lost")
+	repo := &fakeIdempotencyRepo{completeErrs: []error{failure, failure, failure}}
+	runs := 0
+	router := newIdempotencyTestRouter(repo, http.StatusCreated, &runs)
+
+	sendIdempotent(router)
+	if repo.completeCalls != completeAttempts {
+		t.Fatalf("Complete calls = %d, want %d", repo.completeCalls, completeAttempts)
+	}
+
+	// Key tetap dipegang: retry tidak boleh menjalankan handler (mutasi) untuk kedua kalinya.
+	repo.IdempotencyRepository = stillProcessingRepo{}
+	rec := sendIdempotent(router)
+	if runs != 1 {
+		t.Fatalf("handler ran %d times, want 1", runs)
+	}
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("retry status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestIdempotencyMiddlewareKeepsKeyOnServerError(t *testing.T) {
+
+	repo := &fakeIdempotencyRepo{}
+	runs := 0
+	router := newIdempotencyTestRouter(repo, http.StatusInternalServerError, &runs)
+
+	sendIdempotent(router)
+	if repo.completeCalls != 0 {
+		t.Fatalf("Complete calls = %d, want 0 for a 5xx response", repo.completeCalls)
+	}
+
+	repo.IdempotencyRepository = stillProcessingRepo{}
+	if rec := sendIdempotent(router); rec.Code != http.StatusConflict || runs != 1 {
+		t.Fatalf("retry status = %d (handler runs %d), want 409 and 1 run", rec.Code, runs)
+	}
+}
+
+// stillProcessingRepo mengembalikan baris yang masih 'processing' untuk request kedua.
+type stillProcessingRepo struct {
+	repositories.IdempotencyRepository
+}
+
+func (stillProcessingRepo) FindByKey(ctx context.Context, userID int64, key string) (*models.IdempotencyKey, error) {
+	return &models.IdempotencyKey{
+		ID:          1,
+		Status:      models.IdempotencyProcessing,
+		RequestHash: hashIdempotentRequest(http.MethodPost, "/orders", []byte(`{"amount":1}`)),
+	}, nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Jenis biaya dan dasar perhitungan add-on
+const (
+	AddonChargePercentage = "percentage" // Persentase dari subtotal item, cth: Express +50%
+	AddonChargeFixed      = "fixed"      // Nominal tetap, cth: Pewangi premium +Rp2.000
+
+	AddonBasisPerUnit  = "per_unit"  // Dikalikan jumlah ditagih (per kg / per pcs)
+	AddonBasisPerOrder = "per_order" // Dikenakan satu kali per pesanan
+)
+
+// ServiceAddon merepresentasikan struktur tabel 'service_addons' di database
+type ServiceAddon struct {
+	ID               int64        `db:"id"`
+	Code             string       `db:"code"`
+	AddonName        string       `db:"addon_name"`
+	ChargeType       string       `db:"charge_type"`        // Enum: 'percentage' atau 'fixed'
+	ChargeValue      money.Amount `db:"charge_value"`       // Persen (50 = 50%) atau nominal rupiah
+	ChargeBasis      string       `db:"charge_basis"`       // Enum: 'per_unit' atau 'per_order' (hanya untuk fixed)
+	MaxDurationHours *int         `db:"max_duration_hours"` // Jika diisi, durasi layanan dipersingkat maksimal sebesar ini (Express)
+	IsActive         bool         `db:"is_active"`
+	CreatedAt        time.Time    `db:"created_at"`
+	UpdatedAt        *time.Time   `db:"updated_at"`
+}
+
+// ServiceAddonWithServices menampung add-on beserta daftar ID layanan yang terhubung
+type ServiceAddonWithServices struct {
+	ServiceAddon
+	ServiceIDs []int64
+}
+
+// OrderItemAddon merepresentasikan struktur tabel 'order_item_addons' di database.
+// Nama dan tarif disalin (snapshot) agar nota lama tidak berubah ketika katalog diubah.
+type OrderItemAddon struct {
+	ID          int64        `db:"id"`
+	OrderItemID int64        `db:"order_item_id"`
+	AddonID     *int64       `db:"addon_id"`
+	AddonName   string       `db:"addon_name"`
+	ChargeType  string       `db:"charge_type"`
+	ChargeValue money.Amount `db:"charge_value"`
+	ChargeBasis string       `db:"charge_basis"`
+	Amount      money.Amount `db:"amount"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Jenis mesin workshop
+const (
+	MachineWasher = "washer" // Mesin cuci
+	MachineDryer  = "dryer"  // Mesin pengering
+)
+
+// Machine merepresentasikan struktur tabel 'machines' di database
+type Machine struct {
+	ID           int64      `db:"id"`
+	OutletID     int64      `db:"outlet_id"`
+	MachineName  string     `db:"machine_name"`
+	MachineType  string     `db:"machine_type"`  // Enum: 'washer', 'dryer'
+	CapacityKg   float64    `db:"capacity_kg"`   // Muatan maksimal satu siklus
+	CycleMinutes int        `db:"cycle_minutes"` // Lama satu siklus
+	IsActive     bool       `db:"is_active"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    *time.Time `db:"updated_at"`
+
+	// Mesin rusak/diperbaiki: tidak dihitung kapasitas & tidak bisa memulai batch
+	UnderMaintenance bool       `db:"under_maintenance"`
+	MaintenanceNote  *string    `db:"maintenance_note"`
+	MaintenanceSince *time.Time `db:"maintenance_since"`
+}
+
+// OperatingHour merepresentasikan struktur tabel 'outlet_operating_hours' (jam buka satu hari) di database
+type OperatingHour struct {
+	OutletID  int64  `db:"outlet_id"`
+	Weekday   int    `db:"weekday"`    // 0 = Minggu ... 6 = Sabtu (time.Weekday)
+	OpenTime  string `db:"open_time"`  // Format HH:MM:SS
+	CloseTime string `db:"close_time"` // Format HH:MM:SS
+	IsClosed  bool   `db:"is_closed"`
+}
+
+// OutletHoliday merepresentasikan struktur tabel 'outlet_holidays' di database
+type OutletHoliday struct {
+	ID          int64     `db:"id"`
+	OutletID    *int64    `db:"outlet_id"` // NULL = berlaku untuk semua outlet
+	HolidayDate time.Time `db:"holiday_date"`
+	Description string    `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// WorkshopBacklog adalah antrean pekerjaan satu outlet (pesanan 'pending' & 'in-progress')
+type WorkshopBacklog struct {
+	OrderCount int64
+	LoadKg     float64 // Berat item kg + jumlah item pcs x beban per pcs
+}
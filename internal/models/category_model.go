@@ -10,4 +10,5 @@ type ServiceCategory struct {
 	IsActive     bool       `json:"is_active"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    *time.Time `json:"updated_at"`
+	Version      int64      `json:"version"` // Naik setiap update (ETag / If-Match)
 }
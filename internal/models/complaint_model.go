@@ -0,0 +1,118 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Jenis, status, dan penyelesaian komplain pelanggan
+const (
+	ComplaintDamage  = "damage"  // Noda / rusak / luntur
+	ComplaintMissing = "missing" // Barang hilang (cth: kaus kaki sebelah)
+	ComplaintRework  = "rework"  // Hasil kurang bersih, minta dicuci ulang
+	ComplaintOther   = "other"
+
+	ComplaintOpen          = "open"
+	ComplaintInvestigating = "investigating"
+	ComplaintResolved      = "resolved"
+	ComplaintRejected      = "rejected"
+
+	ResolutionRework       = "rework"       // Pesanan anak tanpa biaya
+	ResolutionCompensation = "compensation" // Ganti rugi uang
+	ResolutionVoucher      = "voucher"      // Kode voucher sekali pakai
+
+	CompensationCash     = "cash"     // Dicatat sebagai biaya operasional
+	CompensationTransfer = "transfer" // Dicatat sebagai biaya operasional
+	CompensationDeposit  = "deposit"  // Masuk ke saldo deposit pelanggan
+
+	// CompensationExpenseCategory adalah kategori biaya (seed migrasi) untuk kompensasi tunai / transfer
+	CompensationExpenseCategory = "Kompensasi Pelanggan"
+)
+
+// Complaint merepresentasikan struktur tabel 'complaints' di database
+type Complaint struct {
+	ID                 int64         `db:"id"`
+	OutletID           int64         `db:"outlet_id"`
+	OrderID            int64         `db:"order_id"`
+	InvoiceNumber      string        `db:"invoice_number"` // Dari JOIN orders
+	CustomerID         *int64        `db:"customer_id"`    // Dari JOIN orders
+	CustomerName       *string       `db:"customer_name"`  // Dari JOIN orders / customers
+	OrderItemID        *int64        `db:"order_item_id"`
+	ServiceName        *string       `db:"service_name"` // Dari JOIN order_items / services
+	ComplaintType      string        `db:"complaint_type"`
+	Description        string        `db:"description"`
+	ComplaintStatus    string        `db:"complaint_status"`
+	ResolutionType     *string       `db:"resolution_type"`
+	ResolutionNote     *string       `db:"resolution_note"`
+	ReworkOrderID      *int64        `db:"rework_order_id"`
+	ReworkInvoice      *string       `db:"rework_invoice"` // Dari JOIN orders (pesanan anak)
+	CompensationAmount *money.Amount `db:"compensation_amount"`
+	CompensationMethod *string       `db:"compensation_method"`
+	ExpenseID          *int64        `db:"expense_id"`
+	WalletEntryID      *int64        `db:"wallet_entry_id"`
+	VoucherPromotionID *int64        `db:"voucher_promotion_id"`
+	VoucherCode        *string       `db:"voucher_code"` // Dari JOIN promotions
+	ReportedBy         int64         `db:"reported_by"`
+	ReportedByName     string        `db:"reported_by_name"` // Dari JOIN users
+	ResolvedBy         *int64        `db:"resolved_by"`
+	ResolvedByName     *string       `db:"resolved_by_name"` // Dari JOIN users
+	ResolvedAt         *time.Time    `db:"resolved_at"`
+	PhotoCount         int           `db:"photo_count"` // Dihitung dari complaint_photos
+	CreatedAt          time.Time     `db:"created_at"`
+	UpdatedAt          *time.Time    `db:"updated_at"`
+
+	Photos  []ComplaintPhoto
+	History []ComplaintStatusHistory
+}
+
+// ComplaintPhoto merepresentasikan struktur tabel 'complaint_photos' di database
+type ComplaintPhoto struct {
+	ID          int64     `db:"id"`
+	ComplaintID int64     `db:"complaint_id"`
+	PhotoURL    string    `db:"photo_url"`
+	Caption     *string   `db:"caption"`
+	UploadedBy  int64     `db:"uploaded_by"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// ComplaintStatusHistory merepresentasikan struktur tabel 'complaint_status_history' di database
+type ComplaintStatusHistory struct {
+	ID             int64     `db:"id"`
+	ComplaintID    int64     `db:"complaint_id"`
+	PreviousStatus *string   `db:"previous_status"`
+	NewStatus      string    `db:"new_status"`
+	ActorID        *int64    `db:"actor_id"`
+	ActorName      *string   `db:"actor_name"` // Dari JOIN users
+	Notes          *string   `db:"notes"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// ComplaintOrder adalah potongan data pesanan yang dibutuhkan saat mencatat komplain
+type ComplaintOrder struct {
+	ID             int64
+	OutletID       int64
+	InvoiceNumber  string
+	CustomerID     *int64
+	StatusInternal string
+	ItemIDs        []int64
+}
+
+// ReworkOrder adalah pesanan anak (cuci ulang tanpa biaya) yang dibuat dari penyelesaian komplain
+type ReworkOrder struct {
+	ID               int64
+	ParentOrderID    int64
+	InvoiceNumber    string
+	OrderItemIDs     []int64 // Item pesanan induk yang disalin
+	EstimatedReadyAt *time.Time
+	Notes            *string
+	CreatedBy        int64
+	CreatedAt        time.Time
+}
+
+// EmployeeActivity adalah jumlah aktivitas satu karyawan dalam periode laporan
+type EmployeeActivity struct {
+	UserID   int64
+	FullName string
+	Role     string
+	Count    int64
+}
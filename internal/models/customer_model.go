@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Customer represents the `customers` table (master pelanggan outlet).
+type Customer struct {
+	ID          int64      `db:"id"`
+	FullName    string     `db:"full_name"`
+	PhoneNumber string     `db:"phone_number"` // Unik, disimpan sesuai ketikan kasir
+	Address     *string    `db:"address"`
+	IsActive    bool       `db:"is_active"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+}
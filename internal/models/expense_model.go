@@ -0,0 +1,46 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// ExpenseCategory merepresentasikan struktur tabel 'expense_categories' di database
+type ExpenseCategory struct {
+	ID           int64      `db:"id"`
+	CategoryName string     `db:"category_name"`
+	Description  *string    `db:"description"`
+	IsActive     bool       `db:"is_active"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    *time.Time `db:"updated_at"`
+}
+
+// Expense merepresentasikan struktur tabel 'expenses' (biaya operasional outlet) di database
+type Expense struct {
+	ID                int64        `db:"id"`
+	OutletID          int64        `db:"outlet_id"`
+	ExpenseCategoryID int64        `db:"expense_category_id"`
+	CategoryName      string       `db:"category_name"` // Dari JOIN expense_categories
+	Amount            money.Amount `db:"amount"`
+	ExpenseDate       time.Time    `db:"expense_date"` // Tanggal biaya dikeluarkan (bukan tanggal input)
+	Description       *string      `db:"description"`
+	AttachmentRef     *string      `db:"attachment_ref"` // Nomor nota / URL bukti
+	CreatedBy         int64        `db:"created_by"`
+	UpdatedBy         *int64       `db:"updated_by"`
+	CreatedAt         time.Time    `db:"created_at"`
+	UpdatedAt         *time.Time   `db:"updated_at"`
+}
+
+// DailyAmount adalah total per tanggal (YYYY-MM-DD) untuk laporan laba
+type DailyAmount struct {
+	Date   string
+	Amount money.Amount
+	Count  int64
+}
+
+// ExpenseCategoryTotal adalah total biaya per kategori untuk laporan laba
+type ExpenseCategoryTotal struct {
+	ExpenseCategoryID int64
+	CategoryName      string
+	Amount            money.Amount
+}
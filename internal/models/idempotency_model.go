@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Status baris idempotency key
+const (
+	IdempotencyProcessing = "processing" // Request pertama masih berjalan
+	IdempotencyCompleted  = "completed"  // Balasan sudah disimpan & siap diputar ulang
+)
+
+// IdempotencyKey merepresentasikan struktur tabel 'idempotency_keys' di database
+type IdempotencyKey struct {
+	ID                  int64      `db:"id"`
+	UserID              int64      `db:"user_id"`
+	Key                 string     `db:"idempotency_key"`
+	RequestMethod       string     `db:"request_method"`
+	RequestPath         string     `db:"request_path"`
+	RequestHash         string     `db:"request_hash"` // SHA-256 dari method, path, dan body request
+	Status              string     `db:"status"`
+	ResponseStatus      *int       `db:"response_status"`
+	ResponseContentType *string    `db:"response_content_type"`
+	ResponseBody        []byte     `db:"response_body"`
+	ExpiresAt           time.Time  `db:"expires_at"`
+	CreatedAt           time.Time  `db:"created_at"`
+	CompletedAt         *time.Time `db:"completed_at"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// Entitas yang bisa diimpor lewat POST /imports/{entity}
+const (
+	ImportEntityCategories = "categories"
+	ImportEntityServices   = "services"
+	ImportEntityCustomers  = "customers"
+)
+
+// Perilaku commit jika ada baris yang gagal validasi
+const (
+	ImportOnErrorAbort = "abort" // Tidak ada baris yang ditulis (default)
+	ImportOnErrorSkip  = "skip"  // Baris valid tetap ditulis, baris gagal dilewati
+)
+
+// Status per baris di laporan import
+const (
+	ImportRowValid   = "valid"   // Lolos validasi (dry run / belum ditulis)
+	ImportRowInvalid = "invalid" // Gagal validasi
+	ImportRowCreated = "created" // Sudah ditulis ke database
+	ImportRowSkipped = "skipped" // Gagal validasi & dilewati saat commit (on_error=skip)
+)
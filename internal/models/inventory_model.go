@@ -0,0 +1,72 @@
+package models
+
+import (
+	"laundry-backend/pkg/quantity"
+	"time"
+)
+
+// Jenis & arah mutasi stok bahan habis pakai
+const (
+	InventoryMovementStockIn     = "stock_in"    // Barang masuk (pembelian / kiriman gudang)
+	InventoryMovementConsumption = "consumption" // Dipakai otomatis saat pesanan mulai dikerjakan
+	InventoryMovementAdjustment  = "adjustment"  // Koreksi manual (stock opname, rusak, hilang)
+
+	InventoryIn  = "in"  // Menambah stok
+	InventoryOut = "out" // Mengurangi stok
+)
+
+// InventoryItem merepresentasikan struktur tabel 'inventory_items' di database
+type InventoryItem struct {
+	ID        int64             `db:"id"`
+	ItemName  string            `db:"item_name"`
+	Unit      string            `db:"unit"`      // Satuan bebas, cth: ml, gram, pcs
+	MinStock  quantity.Quantity `db:"min_stock"` // Batas peringatan stok menipis
+	IsActive  bool              `db:"is_active"`
+	CreatedAt time.Time         `db:"created_at"`
+	UpdatedAt *time.Time        `db:"updated_at"`
+}
+
+// InventoryStock adalah stok berjalan satu bahan di satu outlet (tabel 'inventory_stocks' + data bahan)
+type InventoryStock struct {
+	OutletID   int64
+	OutletName string
+	Item       InventoryItem
+	Quantity   quantity.Quantity
+}
+
+// InventoryMovement merepresentasikan struktur tabel 'inventory_movements' di database.
+// Quantity selalu positif, arah mutasi ditentukan oleh Direction.
+type InventoryMovement struct {
+	ID              int64             `db:"id"`
+	OutletID        int64             `db:"outlet_id"`
+	InventoryItemID int64             `db:"inventory_item_id"`
+	ItemName        string            `db:"item_name"`     // Dari JOIN inventory_items
+	Unit            string            `db:"unit"`          // Dari JOIN inventory_items
+	MovementType    string            `db:"movement_type"` // Enum: 'stock_in', 'consumption', 'adjustment'
+	Direction       string            `db:"direction"`     // Enum: 'in', 'out'
+	Quantity        quantity.Quantity `db:"quantity"`
+	BalanceBefore   quantity.Quantity `db:"balance_before"`
+	BalanceAfter    quantity.Quantity `db:"balance_after"`
+	OrderID         *int64            `db:"order_id"`
+	Reason          string            `db:"reason"`
+	ActorID         *int64            `db:"actor_id"`
+	CreatedAt       time.Time         `db:"created_at"`
+}
+
+// ServiceConsumption merepresentasikan struktur tabel 'service_consumptions' (resep pemakaian bahan) di database
+type ServiceConsumption struct {
+	ID              int64             `db:"id"`
+	ServiceID       int64             `db:"service_id"`
+	InventoryItemID int64             `db:"inventory_item_id"`
+	ItemName        string            `db:"item_name"` // Dari JOIN inventory_items
+	Unit            string            `db:"unit"`      // Dari JOIN inventory_items
+	QuantityPerUnit quantity.Quantity `db:"quantity_per_unit"`
+	CreatedAt       time.Time         `db:"created_at"`
+}
+
+// OrderConsumption adalah total pemakaian satu bahan untuk satu pesanan (hasil resep x berat/jumlah item)
+type OrderConsumption struct {
+	OutletID        int64
+	InventoryItemID int64
+	Quantity        quantity.Quantity
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Pemilik media (media.owner_type)
+const (
+	MediaOwnerDelivery  = "delivery"   // Foto bukti antar (kurir)
+	MediaOwnerOrderItem = "order_item" // Foto kondisi item saat diterima / komplain
+	MediaOwnerUser      = "user"       // Foto profil / identitas karyawan
+)
+
+// Media merepresentasikan struktur tabel 'media' di database
+type Media struct {
+	ID             int64     `db:"id"`
+	OutletID       *int64    `db:"outlet_id"`
+	OwnerType      string    `db:"owner_type"`
+	OwnerID        int64     `db:"owner_id"`
+	ObjectKey      string    `db:"object_key"`    // Key file asli di storage
+	ThumbnailKey   string    `db:"thumbnail_key"` // Key thumbnail JPEG di storage
+	ContentType    string    `db:"content_type"`
+	SizeBytes      int64     `db:"size_bytes"`
+	Width          int       `db:"width"`
+	Height         int       `db:"height"`
+	ChecksumSHA256 string    `db:"checksum_sha256"`
+	Caption        *string   `db:"caption"`
+	UploadedBy     int64     `db:"uploaded_by"`
+	UploadedByName string    `db:"uploaded_by_name"` // Dari JOIN users
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// MediaOwner adalah potongan data pemilik media yang dibutuhkan untuk cek akses
+type MediaOwner struct {
+	Type      string
+	ID        int64
+	OutletID  *int64
+	CourierID *int64 // Kurir yang ditugaskan (khusus delivery)
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Jenis event notifikasi pelanggan (satu template per event)
+const (
+	NotificationOrderCreated        = "order_created"
+	NotificationOrderReadyPickup    = "order_ready_pickup"
+	NotificationOrderReadyDelivery  = "order_ready_delivery"
+	NotificationOrderBeingDelivered = "order_being_delivered"
+	NotificationPaymentConfirmed    = "payment_confirmed"
+)
+
+// Status baris antrean notifikasi
+const (
+	OutboxPending    = "pending"    // Menunggu dikirim (atau menunggu jadwal retry)
+	OutboxProcessing = "processing" // Sedang diambil worker
+	OutboxSent       = "sent"       // Berhasil diterima gateway
+	OutboxFailed     = "failed"     // Gagal setelah batas percobaan
+	OutboxSkipped    = "skipped"    // Template dinonaktifkan Owner, pesan tidak dikirim
+)
+
+// NotificationTemplate merepresentasikan struktur tabel 'notification_templates' di database
+type NotificationTemplate struct {
+	ID        int64      `db:"id"`
+	EventType string     `db:"event_type"`
+	Body      string     `db:"body"` // Sintaks text/template, cth: "Halo {{.CustomerName}}"
+	IsActive  bool       `db:"is_active"`
+	UpdatedBy *int64     `db:"updated_by"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+// OutboxMessage merepresentasikan struktur tabel 'notification_outbox' di database
+type OutboxMessage struct {
+	ID            int64      `db:"id"`
+	EventType     string     `db:"event_type"`
+	OrderID       int64      `db:"order_id"`
+	Recipient     string     `db:"recipient"` // Nomor HP pelanggan
+	Payload       []byte     `db:"payload"`   // JSON snapshot data template saat event terjadi
+	Status        string     `db:"status"`
+	Channel       *string    `db:"channel"` // Kanal yang benar-benar dipakai (whatsapp/sms/log)
+	Message       *string    `db:"message"` // Isi pesan yang sudah dirender
+	Attempts      int        `db:"attempts"`
+	NextAttemptAt time.Time  `db:"next_attempt_at"`
+	LastError     *string    `db:"last_error"`
+	SentAt        *time.Time `db:"sent_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+// NotificationContact menampung data pesanan & kontak pelanggan untuk mengisi template.
+type NotificationContact struct {
+	OrderID          int64
+	InvoiceNumber    string
+	CustomerName     *string
+	Phone            *string // orders.customer_phone, atau nomor HP pelanggan terdaftar
+	IsDelivery       bool
+	GrandTotal       money.Amount
+	PaymentStatus    string
+	StatusInternal   string
+	EstimatedReadyAt *time.Time
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Status pengerjaan pesanan (kolom 'orders.status_internal')
+const (
+	OrderStatusPending          = "pending"
+	OrderStatusInProgress       = "in-progress"
+	OrderStatusReadyPickup      = "ready-pickup"
+	OrderStatusReadyDelivery    = "ready-delivery"
+	OrderStatusBeingDelivered   = "being-delivered"
+	OrderStatusFinishedDelivery = "finished-delivery"
+	OrderStatusPickedUp         = "picked-up"
+	OrderStatusCancelled        = "cancelled"
+)
+
+// Status pembayaran pada level pesanan (kolom 'orders.payment_status')
+const (
+	PaymentStatusUnpaid     = "unpaid"
+	PaymentStatusPaid       = "paid"
+	PaymentStatusCODPending = "cod_pending"
+)
+
+// Status & metode pembayaran (tabel 'payments')
+const (
+	PaymentPending   = "pending"
+	PaymentConfirmed = "confirmed"
+	PaymentVoid      = "void"
+
+	PaymentMethodCash     = "cash"
+	PaymentMethodTransfer = "transfer"
+	PaymentMethodQRIS     = "qris"
+	PaymentMethodEWallet  = "ewallet"
+)
+
+// Order merepresentasikan struktur tabel 'orders' di database (nota induk)
+type Order struct {
+	ID                 int64        `db:"id"`
+	InvoiceNumber      string       `db:"invoice_number"`
+	OutletID           int64        `db:"outlet_id"`
+	CustomerID         *int64       `db:"customer_id"`
+	CustomerName       *string      `db:"customer_name"` // Snapshot saat pesanan dibuat
+	CustomerPhone      *string      `db:"customer_phone"`
+	CustomerAddress    *string      `db:"customer_address"`
+	IsDelivery         bool         `db:"is_delivery"`
+	Subtotal           money.Amount `db:"subtotal"`
+	DiscountTotal      money.Amount `db:"discount_total"`
+	ServiceChargeTotal money.Amount `db:"service_charge_total"`
+	TaxTotal           money.Amount `db:"tax_total"`
+	GrandTotal         money.Amount `db:"grand_total"`
+	PaymentStatus      string       `db:"payment_status"`
+	StatusInternal     string       `db:"status_internal"`
+	EstimatedReadyAt   *time.Time   `db:"estimated_ready_at"`
+	Notes              *string      `db:"notes"`
+	CreatedBy          int64        `db:"created_by"`
+	CreatedAt          time.Time    `db:"created_at"`
+	UpdatedAt          *time.Time   `db:"updated_at"`
+}
+
+// OrderItem merepresentasikan struktur tabel 'order_items' di database.
+// Layanan kiloan mengisi WeightKg, layanan satuan mengisi Quantity.
+type OrderItem struct {
+	ID        int64        `db:"id"`
+	OrderID   int64        `db:"order_id"`
+	ServiceID int64        `db:"service_id"`
+	ItemNotes *string      `db:"item_notes"`
+	Quantity  *int         `db:"quantity"`
+	QtyPieces *int         `db:"qty_pieces"` // Jumlah helai untuk pelacakan (bukan dasar tagihan)
+	WeightKg  *float64     `db:"weight_kg"`
+	UnitPrice money.Amount `db:"unit_price"`
+	Subtotal  money.Amount `db:"subtotal"` // Hasil kalkulator harga + add-on
+	Addons    []OrderItemAddon
+}
+
+// Payment merepresentasikan struktur tabel 'payments' di database
+type Payment struct {
+	ID             int64        `db:"id"`
+	OrderID        int64        `db:"order_id"`
+	OutletID       int64        `db:"outlet_id"`
+	ShiftID        *int64       `db:"shift_id"`
+	Method         *string      `db:"method"` // Enum: 'cash', 'transfer', 'qris', 'ewallet', 'deposit'
+	Amount         money.Amount `db:"amount"` // Selalu sama dengan orders.grand_total
+	AmountReceived money.Amount `db:"amount_received"`
+	AmountChange   money.Amount `db:"amount_change"`
+	ReferenceNo    *string      `db:"reference_no"`
+	Status         string       `db:"status"`  // Enum: 'pending', 'confirmed', 'void'
+	PaidAt         *time.Time   `db:"paid_at"` // Diisi saat status menjadi 'confirmed'
+	CreatedBy      int64        `db:"created_by"`
+	CollectedBy    *int64       `db:"collected_by"`
+	CollectedAt    *time.Time   `db:"collected_at"`
+	CreatedAt      time.Time    `db:"created_at"`
+	UpdatedAt      *time.Time   `db:"updated_at"`
+}
+
+// Delivery merepresentasikan struktur tabel 'deliveries' di database
+type Delivery struct {
+	ID                 int64        `db:"id"`
+	OrderID            int64        `db:"order_id"`
+	OutletID           int64        `db:"outlet_id"`
+	ShippingCost       money.Amount `db:"shipping_cost"`
+	CourierID          *int64       `db:"courier_id"`
+	CourierName        *string      // Hasil JOIN users
+	CourierPhone       *string      // Hasil JOIN users
+	CourierDepartedAt  *time.Time   `db:"courier_departed_at"`
+	CourierArrivedAt   *time.Time   `db:"courier_arrived_at"`
+	CODCollectedAmount money.Amount `db:"cod_collected_amount"`
+	CreatedAt          time.Time    `db:"created_at"`
+}
+
+// OrderItemDetail adalah satu item pesanan beserta nama & satuan layanannya
+type OrderItemDetail struct {
+	OrderItem
+	ServiceName string
+	Unit        string
+}
+
+// StatusHistoryDetail adalah satu riwayat status beserta nama pelakunya
+type StatusHistoryDetail struct {
+	StatusHistory
+	ActorName *string
+}
+
+// PaymentSettlement adalah tagihan yang sedang dilunasi beserta data nota induknya (hasil query, bukan tabel)
+type PaymentSettlement struct {
+	Payment
+	InvoiceNumber string
+	CustomerID    *int64
+}
+
+// OrderDetail adalah pesanan lengkap dengan item, pembayaran terakhir, pengantaran, dan riwayat status
+type OrderDetail struct {
+	Order
+	CreatedByName *string
+	Items         []OrderItemDetail
+	Payment       *Payment  // Pembayaran terakhir yang tidak di-void (boleh kosong)
+	Delivery      *Delivery // Hanya untuk pesanan antar
+	History       []StatusHistoryDetail
+}
+
+// OrderSummary adalah satu baris daftar pesanan (GET /orders) beserta nama pembuat & ongkir pengantarannya
+type OrderSummary struct {
+	Order
+	CreatedByName *string
+	DeliveryID    *int64
+	ShippingCost  *money.Amount // Kosong jika pesanan tidak diantar
+}
+
+// PaymentSummary adalah satu baris daftar pembayaran (GET /payments) beserta nomor nota induknya
+type PaymentSummary struct {
+	Payment
+	InvoiceNumber string
+}
+
+// OrderState adalah potongan data pesanan yang dibutuhkan untuk validasi transisi status
+type OrderState struct {
+	ID               int64
+	InvoiceNumber    string
+	CustomerName     *string
+	IsDelivery       bool
+	PaymentStatus    string
+	StatusInternal   string
+	EstimatedReadyAt *time.Time
+}
+
+// StatusHistory merepresentasikan struktur tabel 'status_history' di database
+type StatusHistory struct {
+	ID             int64     `db:"id"`
+	OrderID        int64     `db:"order_id"`
+	PreviousStatus *string   `db:"previous_status"`
+	NewStatus      string    `db:"new_status"`
+	ActorID        *int64    `db:"actor_id"`
+	ActorRole      *string   `db:"actor_role"`
+	Notes          *string   `db:"notes"`
+	CreatedAt      time.Time `db:"created_at"`
+}
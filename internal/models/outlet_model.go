@@ -0,0 +1,31 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Outlet merepresentasikan struktur tabel 'outlets' (cabang laundry) di database
+type Outlet struct {
+	ID          int64      `db:"id"`
+	Code        string     `db:"code"` // Kode singkat unik, cth: PUSAT, CBG-02
+	OutletName  string     `db:"outlet_name"`
+	Address     *string    `db:"address"`
+	PhoneNumber *string    `db:"phone_number"`
+	IsActive    bool       `db:"is_active"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+}
+
+// ServiceOutletPrice adalah harga khusus sebuah layanan di satu outlet (tabel 'service_outlet_prices').
+// Tanpa baris ini outlet memakai harga dasar services.price.
+type ServiceOutletPrice struct {
+	ServiceID   int64        `db:"service_id"`
+	OutletID    int64        `db:"outlet_id"`
+	Price       money.Amount `db:"price"`
+	ServiceCode string       `db:"code"`         // Dari JOIN services
+	ServiceName string       `db:"service_name"` // Dari JOIN services
+	BasePrice   money.Amount `db:"base_price"`   // services.price, untuk perbandingan
+	CreatedAt   time.Time    `db:"created_at"`
+	UpdatedAt   *time.Time   `db:"updated_at"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Jenis aturan harga yang didukung oleh tabel 'service_pricing_rules'
+const (
+	PricingRuleMinimum  = "minimum"  // Minimum charge, cth: minimal 3 kg
+	PricingRuleRounding = "rounding" // Pembulatan ke atas, cth: kelipatan 0.5 kg
+	PricingRuleTier     = "tier"     // Harga bertingkat, cth: di atas 10 kg jadi Rp6.000/kg
+)
+
+// ServicePricingRule merepresentasikan struktur tabel 'service_pricing_rules' di database.
+// Kolom yang dipakai bergantung pada RuleType, sisanya bernilai NULL.
+type ServicePricingRule struct {
+	ID           int64         `db:"id"`
+	ServiceID    int64         `db:"service_id"`    // Foreign Key ke tabel services
+	RuleType     string        `db:"rule_type"`     // Enum: 'minimum', 'rounding', 'tier'
+	MinQuantity  *float64      `db:"min_quantity"`  // minimum: jumlah minimal, tier: berlaku jika jumlah di atas nilai ini
+	RoundingStep *float64      `db:"rounding_step"` // rounding: kelipatan pembulatan ke atas
+	UnitPrice    *money.Amount `db:"unit_price"`    // tier: harga per unit pengganti harga dasar
+	IsActive     bool          `db:"is_active"`
+	CreatedAt    time.Time     `db:"created_at"`
+	UpdatedAt    *time.Time    `db:"updated_at"`
+}
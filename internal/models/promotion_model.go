@@ -0,0 +1,69 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Jenis diskon dan cakupan promosi
+const (
+	DiscountPercentage = "percentage" // Persentase, cth: 10% off
+	DiscountFixed      = "fixed"      // Nominal tetap, cth: Rp5.000 off
+
+	PromoScopeAll      = "all"      // Berlaku untuk seluruh item
+	PromoScopeService  = "service"  // Hanya layanan tertentu (promotion_scopes.target_id = service_id)
+	PromoScopeCategory = "category" // Hanya kategori tertentu (promotion_scopes.target_id = category_id)
+)
+
+// Promotion merepresentasikan struktur tabel 'promotions' di database.
+// Code bernilai NULL untuk promo otomatis (cth: "10% off Mondays"), terisi untuk kode voucher.
+type Promotion struct {
+	ID               int64         `db:"id"`
+	Code             *string       `db:"code"`
+	PromoName        string        `db:"promo_name"`
+	Description      *string       `db:"description"`
+	DiscountType     string        `db:"discount_type"`      // Enum: 'percentage' atau 'fixed'
+	DiscountValue    money.Amount  `db:"discount_value"`     // Persen (10 = 10%) atau nominal rupiah
+	MaxDiscount      *money.Amount `db:"max_discount"`       // Batas atas diskon persentase (NULL = tanpa batas)
+	MinSpend         money.Amount  `db:"min_spend"`          // Minimal subtotal keranjang
+	ScopeType        string        `db:"scope_type"`         // Enum: 'all', 'service', 'category'
+	ValidDays        *string       `db:"valid_days"`         // Hari berlaku ISO (1=Senin ... 7=Minggu), cth: "1" atau "6,7"
+	StartsAt         time.Time     `db:"starts_at"`          // Awal periode berlaku
+	EndsAt           *time.Time    `db:"ends_at"`            // Akhir periode berlaku (NULL = tanpa batas)
+	UsageLimit       *int          `db:"usage_limit"`        // Kuota total pemakaian (NULL = tanpa batas)
+	UsageCount       int           `db:"usage_count"`        // Jumlah pemakaian yang sudah terjadi
+	PerCustomerLimit *int          `db:"per_customer_limit"` // Kuota per pelanggan (NULL = tanpa batas)
+	CustomerID       *int64        `db:"customer_id"`        // Voucher khusus satu pelanggan (NULL = semua pelanggan)
+	IsActive         bool          `db:"is_active"`
+	CreatedAt        time.Time     `db:"created_at"`
+	UpdatedAt        *time.Time    `db:"updated_at"`
+}
+
+// PromotionWithScopes menampung promosi beserta daftar target cakupannya
+type PromotionWithScopes struct {
+	Promotion
+	TargetIDs []int64
+}
+
+// PromotionRedemption merepresentasikan struktur tabel 'promotion_redemptions' di database
+type PromotionRedemption struct {
+	ID             int64        `db:"id"`
+	PromotionID    int64        `db:"promotion_id"`
+	OrderID        int64        `db:"order_id"`
+	CustomerID     *int64       `db:"customer_id"`
+	DiscountAmount money.Amount `db:"discount_amount"`
+	RedeemedBy     *int64       `db:"redeemed_by"`
+	CreatedAt      time.Time    `db:"created_at"`
+}
+
+// OrderDiscount merepresentasikan struktur tabel 'order_discounts' di database.
+// Explanation adalah kalimat yang tercetak di nota, cth: "Voucher HEMAT5 (Rp5.000 off above Rp50.000)".
+type OrderDiscount struct {
+	ID          int64        `db:"id"`
+	OrderID     int64        `db:"order_id"`
+	PromotionID *int64       `db:"promotion_id"`
+	Code        *string      `db:"code"`
+	Explanation string       `db:"explanation"`
+	Amount      money.Amount `db:"amount"`
+	CreatedAt   time.Time    `db:"created_at"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Receipt adalah data lengkap satu pesanan untuk dicetak sebagai nota.
+// Data pelanggan diambil dari snapshot di tabel 'orders' agar nota lama tidak berubah.
+type Receipt struct {
+	OrderID            int64
+	InvoiceNumber      string
+	CustomerName       *string
+	CustomerPhone      *string
+	CustomerAddress    *string
+	IsDelivery         bool
+	Subtotal           money.Amount
+	DiscountTotal      money.Amount
+	ServiceChargeTotal money.Amount
+	TaxTotal           money.Amount
+	GrandTotal         money.Amount
+	PaymentStatus      string
+	StatusInternal     string
+	EstimatedReadyAt   *time.Time
+	Notes              *string
+	CreatedByName      *string
+	CreatedAt          time.Time
+
+	Items     []ReceiptItem
+	Discounts []ReceiptDiscount
+	Taxes     []ReceiptTax
+	Payment   *ReceiptPayment // Pembayaran terakhir yang tidak di-void (boleh kosong)
+}
+
+// ReceiptItem adalah satu baris item cucian pada nota
+type ReceiptItem struct {
+	ServiceName string
+	Unit        string  // Enum: 'kg' atau 'pcs'
+	Quantity    float64 // Berat (kg) atau jumlah (pcs) yang ditagih
+	QtyPieces   *int    // Jumlah helai fisik untuk verifikasi
+	UnitPrice   money.Amount
+	Subtotal    money.Amount // Sudah termasuk add-on
+	ItemNotes   *string
+	Addons      []ReceiptItemAddon
+}
+
+// ReceiptItemAddon adalah add-on yang dipilih pada satu item
+type ReceiptItemAddon struct {
+	AddonName string
+	Amount    money.Amount
+}
+
+// ReceiptDiscount adalah satu baris diskon beserta penjelasannya
+type ReceiptDiscount struct {
+	Explanation string
+	Amount      money.Amount
+}
+
+// ReceiptTax adalah satu baris service charge / pajak
+type ReceiptTax struct {
+	TaxName   string
+	TaxKind   string
+	Rate      money.Percent
+	PriceMode string
+	Amount    money.Amount
+}
+
+// ReceiptPayment adalah ringkasan pembayaran yang tercetak di nota
+type ReceiptPayment struct {
+	Method         *string
+	Amount         money.Amount
+	AmountReceived money.Amount
+	AmountChange   money.Amount
+	Status         string
+	CollectedAt    *time.Time
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Jenis entitas hasil pencarian global
+const (
+	SearchTypeOrders    = "orders"
+	SearchTypeCustomers = "customers"
+	SearchTypeServices  = "services"
+)
+
+// SearchOrderHit adalah satu pesanan yang cocok dengan kata kunci.
+type SearchOrderHit struct {
+	ID            int64
+	InvoiceNumber string
+	CustomerName  *string
+	CustomerPhone *string
+	Status        string
+	PaymentStatus string
+	GrandTotal    money.Amount
+	IsDelivery    bool
+	MatchedItem   *string // Catatan item yang cocok (jika pesanan ditemukan lewat catatan item)
+	Score         float64
+	CreatedAt     time.Time
+}
+
+// SearchCustomerHit adalah satu pelanggan yang cocok dengan kata kunci.
+type SearchCustomerHit struct {
+	ID          int64
+	FullName    string
+	PhoneNumber string
+	IsActive    bool
+	Score       float64
+}
+
+// SearchServiceHit adalah satu layanan yang cocok dengan kata kunci.
+type SearchServiceHit struct {
+	ID          int64
+	Code        string
+	ServiceName string
+	Unit        string
+	Price       money.Amount
+	IsActive    bool
+	Score       float64
+}
@@ -1,19 +1,23 @@
 package models
 
-import "time"
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
 
 // Service merepresentasikan struktur tabel 'services' di database
 type Service struct {
-	ID            int64      `db:"id"`
-	Code          string     `db:"code"`
-	ServiceName   string     `db:"service_name"`
-	Unit          string     `db:"unit"`           // Enum: 'kg' atau 'pcs'
-	Price         float64    `db:"price"`          // Menyimpan DECIMAL(15,2)
-	IsActive      bool       `db:"is_active"`      // TINYINT(1) -> true/false
-	CreatedAt     time.Time  `db:"created_at"`     // Tanpa pointer (Selalu ada isinya)
-	UpdatedAt     *time.Time `db:"updated_at"`     // Pakai pointer (Awalnya NULL)
-	CategoryID    int64      `db:"category_id"`    // Foreign Key
-	DurationHours int        `db:"duration_hours"` // Estimasi pengerjaan (jam)
+	ID            int64        `db:"id"`
+	Code          string       `db:"code"`
+	ServiceName   string       `db:"service_name"`
+	Unit          string       `db:"unit"`           // Enum: 'kg' atau 'pcs'
+	Price         money.Amount `db:"price"`          // Menyimpan DECIMAL(15,2)
+	IsActive      bool         `db:"is_active"`      // TINYINT(1) -> true/false
+	CreatedAt     time.Time    `db:"created_at"`     // Tanpa pointer (Selalu ada isinya)
+	UpdatedAt     *time.Time   `db:"updated_at"`     // Pakai pointer (Awalnya NULL)
+	CategoryID    int64        `db:"category_id"`    // Foreign Key
+	DurationHours int          `db:"duration_hours"` // Estimasi pengerjaan (jam)
+	Version       int64        `db:"version"`        // Naik setiap update (ETag / If-Match)
 }
 
 // ServiceWithCategory digunakan untuk menampung hasil query JOIN dengan tabel 'service_categories'
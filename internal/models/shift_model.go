@@ -0,0 +1,51 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Status shift kasir
+const (
+	ShiftStatusOpen   = "open"
+	ShiftStatusClosed = "closed"
+)
+
+// CashierShift merepresentasikan struktur tabel 'cashier_shifts' di database.
+// ExpectedCash, ClosingCounted & Variance baru terisi setelah shift ditutup.
+type CashierShift struct {
+	ID             int64         `db:"id"`
+	OutletID       int64         `db:"outlet_id"`
+	CashierID      int64         `db:"cashier_id"`
+	CashierName    string        `db:"full_name"` // Dari JOIN users
+	Status         string        `db:"status"`    // Enum: 'open', 'closed'
+	OpeningFloat   money.Amount  `db:"opening_float"`
+	CashSales      money.Amount  `db:"cash_sales"`       // SUM(amount_received - amount_change) pembayaran tunai confirmed
+	PettyCashTotal money.Amount  `db:"petty_cash_total"` // SUM(petty_cash_payouts.amount)
+	ExpectedCash   *money.Amount `db:"expected_cash"`
+	ClosingCounted *money.Amount `db:"closing_counted"` // Uang fisik yang dihitung kasir saat tutup
+	Variance       *money.Amount `db:"variance"`        // closing_counted - expected_cash (minus = kurang)
+	PaymentCount   int64         `db:"payment_count"`
+	OpenNote       *string       `db:"open_note"`
+	CloseNote      *string       `db:"close_note"`
+	OpenedAt       time.Time     `db:"opened_at"`
+	ClosedAt       *time.Time    `db:"closed_at"`
+	ClosedBy       *int64        `db:"closed_by"`
+}
+
+// PettyCashPayout merepresentasikan struktur tabel 'petty_cash_payouts' (uang keluar dari laci selama shift)
+type PettyCashPayout struct {
+	ID          int64        `db:"id"`
+	ShiftID     int64        `db:"shift_id"`
+	Amount      money.Amount `db:"amount"`
+	Description string       `db:"description"`
+	CreatedBy   int64        `db:"created_by"`
+	CreatedAt   time.Time    `db:"created_at"`
+}
+
+// ShiftCashTotals adalah hasil agregasi uang masuk & keluar laci untuk satu shift
+type ShiftCashTotals struct {
+	CashSales      money.Amount
+	PaymentCount   int64
+	PettyCashTotal money.Amount
+}
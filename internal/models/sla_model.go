@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// Jenis peringatan SLA pesanan
+const (
+	SLAAlertOverdue   = "overdue"   // Melewati estimated_ready_at tetapi belum siap
+	SLAAlertUnclaimed = "unclaimed" // Siap diambil tetapi belum diambil melewati ambang hari
+)
+
+// SLAAlert merepresentasikan struktur tabel 'order_sla_alerts' di database
+type SLAAlert struct {
+	ID             int64      `db:"id"`
+	OutletID       int64      `db:"outlet_id"`
+	OrderID        int64      `db:"order_id"`
+	AlertType      string     `db:"alert_type"`     // Enum: 'overdue', 'unclaimed'
+	ThresholdDays  int        `db:"threshold_days"` // 0 untuk overdue
+	StatusInternal string     `db:"status_internal"`
+	ReferenceAt    time.Time  `db:"reference_at"` // estimated_ready_at (overdue) / siap diambil sejak (unclaimed)
+	TriggeredAt    time.Time  `db:"triggered_at"`
+	ResolvedAt     *time.Time `db:"resolved_at"`
+	AcknowledgedBy *int64     `db:"acknowledged_by"` // Staff yang menandai sudah ditindaklanjuti
+	AcknowledgedAt *time.Time `db:"acknowledged_at"`
+}
+
+// SLAAlertWithOrder adalah peringatan SLA beserta nomor nota & nama staff yang menindaklanjuti (kotak masuk aplikasi)
+type SLAAlertWithOrder struct {
+	SLAAlert
+	InvoiceNumber      string
+	AcknowledgedByName *string
+}
+
+// OverdueOrder adalah pesanan aktif yang sudah melewati estimated_ready_at
+type OverdueOrder struct {
+	OrderID          int64
+	OutletID         int64
+	InvoiceNumber    string
+	CustomerName     *string
+	CustomerPhone    *string
+	IsDelivery       bool
+	PaymentStatus    string
+	StatusInternal   string
+	EstimatedReadyAt time.Time
+	FlaggedAt        *time.Time // Saat scheduler mencatat peringatan (nil jika belum sempat dievaluasi)
+}
+
+// UnclaimedOrder adalah pesanan ready-pickup beserta sejak kapan menunggu diambil
+type UnclaimedOrder struct {
+	OrderID       int64
+	OutletID      int64
+	InvoiceNumber string
+	CustomerID    *int64 // NULL = pelanggan walk-in tanpa data master
+	CustomerName  *string
+	CustomerPhone *string
+	PaymentStatus string
+	ReadySince    time.Time
+	FlaggedDays   *int       // Ambang hari tertinggi yang sudah dicatat scheduler
+	FlaggedAt     *time.Time // Saat ambang tersebut dicatat
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// OrderItemTag merepresentasikan struktur tabel 'order_item_tags' di database
+type OrderItemTag struct {
+	ID            int64      `db:"id"`
+	OrderID       int64      `db:"order_id"`
+	OrderItemID   int64      `db:"order_item_id"`
+	TagCode       string     `db:"tag_code"`
+	CountedPieces *int       `db:"counted_pieces"` // Hasil hitung staff saat scan (NULL = belum dihitung)
+	CountedBy     *int64     `db:"counted_by"`
+	CountedAt     *time.Time `db:"counted_at"`
+	LastScannedAt *time.Time `db:"last_scanned_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+// OrderItemTagDetail menampung tag beserta data item yang dibutuhkan untuk label & verifikasi helai.
+// Tag bernilai nil jika item belum memiliki tag.
+type OrderItemTagDetail struct {
+	OrderItemID    int64
+	ServiceName    string
+	Unit           string
+	Quantity       float64
+	DeclaredPieces *int // 'order_items.qty_pieces' yang dicatat kasir
+	Tag            *OrderItemTag
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Jenis tarif, mode harga, dan cakupan tarif pajak
+const (
+	TaxKindTax           = "tax"            // Pajak yang disetor ke negara, cth: PPN 11%
+	TaxKindServiceCharge = "service_charge" // Biaya layanan yang menjadi pendapatan outlet
+
+	TaxModeExclusive = "exclusive" // Ditambahkan di atas harga, cth: 100.000 + PPN 11.000
+	TaxModeInclusive = "inclusive" // Sudah termasuk di harga, cth: 111.000 sudah termasuk PPN 11.000
+
+	TaxScopeAll      = "all"      // Berlaku untuk seluruh item kecuali yang dikecualikan
+	TaxScopeSelected = "selected" // Hanya layanan/kategori yang terdaftar di tax_rate_targets
+
+	TaxTargetService  = "service"
+	TaxTargetCategory = "category"
+)
+
+// TaxRate merepresentasikan struktur tabel 'tax_rates' di database
+type TaxRate struct {
+	ID        int64         `db:"id"`
+	Code      string        `db:"code"`       // Kode unik, cth: "PPN", "SC5"
+	TaxName   string        `db:"tax_name"`   // Nama yang tercetak di nota, cth: "PPN 11%"
+	TaxKind   string        `db:"tax_kind"`   // Enum: 'tax' atau 'service_charge'
+	Rate      money.Percent `db:"rate"`       // DECIMAL(5,2), cth: 11.00
+	PriceMode string        `db:"price_mode"` // Enum: 'exclusive' atau 'inclusive'
+	ScopeType string        `db:"scope_type"` // Enum: 'all' atau 'selected'
+	IsActive  bool          `db:"is_active"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt *time.Time    `db:"updated_at"`
+}
+
+// TaxRateTarget merepresentasikan struktur tabel 'tax_rate_targets' di database
+type TaxRateTarget struct {
+	TargetType string `db:"target_type"` // Enum: 'service' atau 'category'
+	TargetID   int64  `db:"target_id"`
+	IsExempt   bool   `db:"is_exempt"` // true = item dikecualikan dari tarif ini
+}
+
+// TaxRateWithTargets menampung tarif pajak beserta cakupan & pengecualiannya
+type TaxRateWithTargets struct {
+	TaxRate
+	Targets []TaxRateTarget
+}
+
+// OrderTax merepresentasikan struktur tabel 'order_taxes' di database
+type OrderTax struct {
+	ID          int64         `db:"id"`
+	OrderID     int64         `db:"order_id"`
+	TaxRateID   *int64        `db:"tax_rate_id"`
+	TaxName     string        `db:"tax_name"`
+	TaxKind     string        `db:"tax_kind"`
+	Rate        money.Percent `db:"rate"`
+	PriceMode   string        `db:"price_mode"`
+	TaxableBase money.Amount  `db:"taxable_base"` // DPP (Dasar Pengenaan Pajak)
+	Amount      money.Amount  `db:"amount"`
+	CreatedAt   time.Time     `db:"created_at"`
+}
+
+// OrderTotals adalah rincian total yang disimpan di nota induk (tabel 'orders')
+type OrderTotals struct {
+	Subtotal           money.Amount `db:"subtotal"`
+	DiscountTotal      money.Amount `db:"discount_total"`
+	ServiceChargeTotal money.Amount `db:"service_charge_total"`
+	TaxTotal           money.Amount `db:"tax_total"`
+	GrandTotal         money.Amount `db:"grand_total"`
+}
+
+// TaxCollection adalah hasil agregasi pajak terkumpul per tarif untuk laporan
+type TaxCollection struct {
+	TaxRateID   *int64        `db:"tax_rate_id"`
+	TaxName     string        `db:"tax_name"`
+	TaxKind     string        `db:"tax_kind"`
+	Rate        money.Percent `db:"rate"`
+	PriceMode   string        `db:"price_mode"`
+	TaxableBase money.Amount  `db:"taxable_base"`
+	Amount      money.Amount  `db:"amount"`
+}
+
+// TaxReportOrder adalah rincian total satu nota lunas untuk export laporan pajak (satu baris per pesanan)
+type TaxReportOrder struct {
+	OrderID       int64     `db:"id"`
+	InvoiceNumber string    `db:"invoice_number"`
+	OutletCode    string    `db:"code"` // Dari JOIN outlets
+	CustomerName  *string   `db:"customer_name"`
+	CreatedAt     time.Time `db:"created_at"`
+	OrderTotals
+}
+
+// OutletOrderTotals adalah agregasi total nota lunas satu outlet untuk laporan konsolidasi
+type OutletOrderTotals struct {
+	OutletID   int64  `db:"outlet_id"`
+	OutletCode string `db:"code"`
+	OutletName string `db:"outlet_name"`
+	OrderCount int64  `db:"order_count"`
+	OrderTotals
+}
@@ -11,9 +11,11 @@ type User struct {
 	Email        string     `json:"email"`         // Email is a unique contact address, also used for login or notifications.
 	PasswordHash string     `json:"-"`             // PasswordHash stores the encrypted password (bcrypt). It uses json:"-" tag to ensure it is never exposed in API responses.
 	Role         string     `json:"role"`          // Role defines the authorization level (e.g., owner, cashier, staff, courier).
+	OutletID     *int64     `json:"outlet_id"`     // OutletID is the home outlet of the employee. NULL is only allowed for owners (not bound to an outlet).
 	PhoneNumber  string     `json:"phone_number"`  // PhoneNumber is the contact number of the user.
 	IsActive     bool       `json:"is_active"`     // IsActive indicates the account status. true = Active, false = Inactive/Soft Deleted.
 	LastLoginAt  *time.Time `json:"last_login_at"` // LastLoginAt records the timestamp of the last successful login. Pointer type (*time.Time) is used to handle NULL values from the database.
 	CreatedAt    time.Time  `json:"created_at"`    // CreatedAt records the timestamp when the user account was created.
 	UpdatedAt    *time.Time `json:"updated_at"`    // UpdatedAt records the timestamp of the last profile update. Pointer type (*time.Time) is used to handle NULL values.
+	Version      int64      `json:"version"`       // Version is incremented on every update and guards concurrent edits (ETag / If-Match).
 }
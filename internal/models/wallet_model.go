@@ -0,0 +1,79 @@
+package models
+
+import (
+	"laundry-backend/pkg/money"
+	"time"
+)
+
+// Jenis & arah mutasi saldo deposit dan poin loyalitas
+const (
+	WalletEntryTopUp        = "topup"        // Pelanggan menyetor deposit
+	WalletEntrySpend        = "spend"        // Saldo dipakai membayar pesanan (metode 'deposit')
+	WalletEntryRefund       = "refund"       // Pengembalian saldo dari pesanan yang dibatalkan
+	WalletEntryAdjustment   = "adjustment"   // Koreksi manual oleh Owner
+	WalletEntryCompensation = "compensation" // Ganti rugi komplain pelanggan
+
+	PointEntryEarn       = "earn"       // Poin didapat dari pesanan lunas
+	PointEntryRedeem     = "redeem"     // Poin ditukar menjadi potongan harga
+	PointEntryAdjustment = "adjustment" // Koreksi manual oleh Owner
+
+	LedgerCredit = "credit" // Menambah saldo
+	LedgerDebit  = "debit"  // Mengurangi saldo
+
+	PaymentMethodDeposit = "deposit" // Metode pembayaran yang memotong saldo deposit
+)
+
+// MembershipTier merepresentasikan struktur tabel 'membership_tiers' di database
+type MembershipTier struct {
+	ID              int64         `db:"id"`
+	TierName        string        `db:"tier_name"`
+	DiscountPercent money.Percent `db:"discount_percent"` // Diskon otomatis (10 = 10%) dari subtotal pesanan
+	MaxDiscount     *money.Amount `db:"max_discount"`     // Batas atas diskon member (NULL = tanpa batas)
+	PointMultiplier float64       `db:"point_multiplier"` // Pengali poin, cth: 1.5 untuk Gold
+	IsActive        bool          `db:"is_active"`
+	CreatedAt       time.Time     `db:"created_at"`
+	UpdatedAt       *time.Time    `db:"updated_at"`
+}
+
+// CustomerWallet adalah ringkasan dompet pelanggan (kolom berjalan di tabel 'customers')
+type CustomerWallet struct {
+	CustomerID       int64        `db:"id"`
+	FullName         string       `db:"full_name"`
+	PhoneNumber      string       `db:"phone_number"`
+	MembershipTierID *int64       `db:"membership_tier_id"`
+	WalletBalance    money.Amount `db:"wallet_balance"`
+	PointsBalance    int          `db:"points_balance"`
+	IsActive         bool         `db:"is_active"`
+}
+
+// WalletEntry merepresentasikan struktur tabel 'wallet_ledger' di database.
+// Amount selalu positif, arah mutasi ditentukan oleh Direction.
+type WalletEntry struct {
+	ID            int64        `db:"id"`
+	CustomerID    int64        `db:"customer_id"`
+	EntryType     string       `db:"entry_type"` // Enum: 'topup', 'spend', 'refund', 'adjustment', 'compensation'
+	Direction     string       `db:"direction"`  // Enum: 'credit', 'debit'
+	Amount        money.Amount `db:"amount"`
+	BalanceBefore money.Amount `db:"balance_before"`
+	BalanceAfter  money.Amount `db:"balance_after"`
+	OrderID       *int64       `db:"order_id"`
+	PaymentMethod *string      `db:"payment_method"` // Cara setor saat top-up (cash, transfer, qris, ewallet)
+	Reason        string       `db:"reason"`
+	ActorID       *int64       `db:"actor_id"`
+	CreatedAt     time.Time    `db:"created_at"`
+}
+
+// PointEntry merepresentasikan struktur tabel 'point_ledger' di database
+type PointEntry struct {
+	ID            int64     `db:"id"`
+	CustomerID    int64     `db:"customer_id"`
+	EntryType     string    `db:"entry_type"` // Enum: 'earn', 'redeem', 'adjustment'
+	Direction     string    `db:"direction"`  // Enum: 'credit', 'debit'
+	Points        int       `db:"points"`
+	BalanceBefore int       `db:"balance_before"`
+	BalanceAfter  int       `db:"balance_after"`
+	OrderID       *int64    `db:"order_id"`
+	Reason        string    `db:"reason"`
+	ActorID       *int64    `db:"actor_id"`
+	CreatedAt     time.Time `db:"created_at"`
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// Status batch mesin
+const (
+	WashBatchRunning  = "running"  // Mesin sedang berjalan
+	WashBatchFinished = "finished" // Siklus selesai, item keluar dari mesin
+)
+
+// WashBatch merepresentasikan struktur tabel 'wash_batches' (satu siklus mesin) di database
+type WashBatch struct {
+	ID          int64      `db:"id"`
+	OutletID    int64      `db:"outlet_id"`
+	MachineID   int64      `db:"machine_id"`
+	BatchStatus string     `db:"batch_status"` // Enum: 'running', 'finished'
+	LoadKg      float64    `db:"load_kg"`
+	Notes       *string    `db:"notes"`
+	StartedBy   int64      `db:"started_by"` // Operator dari JWT
+	StartedAt   time.Time  `db:"started_at"`
+	FinishedBy  *int64     `db:"finished_by"`
+	FinishedAt  *time.Time `db:"finished_at"`
+
+	// Data relasi (JOIN), tidak disimpan di tabel ini
+	MachineName    string
+	MachineType    string
+	StartedByName  string
+	FinishedByName *string
+	ItemCount      int64
+	Items          []WashBatchItem
+}
+
+// WashBatchItem merepresentasikan struktur tabel 'wash_batch_items' di database
+type WashBatchItem struct {
+	ID          int64   `db:"id"`
+	BatchID     int64   `db:"batch_id"`
+	OrderID     int64   `db:"order_id"`
+	OrderItemID int64   `db:"order_item_id"`
+	LoadKg      float64 `db:"load_kg"`
+
+	// Data relasi (JOIN)
+	InvoiceNumber string
+	ServiceName   string
+	Unit          string
+	Quantity      float64
+	TagCode       *string
+}
+
+// WashBatchCandidate adalah item pesanan hasil scan (kode tag / nomor nota) yang akan dimasukkan ke mesin
+type WashBatchCandidate struct {
+	OrderID        int64
+	OrderItemID    int64
+	InvoiceNumber  string
+	StatusInternal string
+	ServiceName    string
+	Unit           string
+	Quantity       float64 // Berat (kg) untuk layanan kg, jumlah untuk layanan pcs
+	RunningBatchID *int64  // Batch 'running' yang sedang memuat item ini
+}
+
+// WashItemProgress adalah progres satu item pesanan di mesin (dasar penurunan status pesanan)
+type WashItemProgress struct {
+	OrderItemID    int64
+	FinishedWasher bool // Pernah selesai di batch mesin cuci
+	FinishedDryer  bool // Pernah selesai di batch mesin pengering
+	InRunningBatch bool // Sedang berada di batch yang masih berjalan
+}
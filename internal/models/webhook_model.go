@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Status baris pengiriman webhook
+const (
+	WebhookPending    = "pending"    // Menunggu dikirim (atau menunggu jadwal retry)
+	WebhookProcessing = "processing" // Sedang diambil worker
+	WebhookDelivered  = "delivered"  // Penerima membalas 2xx
+	WebhookFailed     = "failed"     // Gagal setelah batas percobaan / endpoint nonaktif
+)
+
+// WebhookEndpoint merepresentasikan struktur tabel 'webhook_endpoints' di database
+type WebhookEndpoint struct {
+	ID          int64      `db:"id"`
+	URL         string     `db:"url"`
+	Description *string    `db:"description"`
+	Secret      string     `db:"secret"`
+	Events      []string   // Dari tabel 'webhook_subscriptions'
+	IsActive    bool       `db:"is_active"`
+	CreatedBy   *int64     `db:"created_by"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+}
+
+// WebhookDelivery merepresentasikan struktur tabel 'webhook_deliveries' di database
+type WebhookDelivery struct {
+	ID             int64      `db:"id"`
+	EndpointID     int64      `db:"endpoint_id"`
+	EventID        string     `db:"event_id"` // Sama untuk semua endpoint & replay dari event yang sama
+	EventType      string     `db:"event_type"`
+	Payload        []byte     `db:"payload"` // Body JSON yang dikirim
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	ResponseStatus *int       `db:"response_status"` // Kode HTTP percobaan terakhir
+	ResponseBody   *string    `db:"response_body"`   // Potongan body balasan percobaan terakhir
+	LastError      *string    `db:"last_error"`
+	DurationMs     *int       `db:"duration_ms"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+	ReplayOf       *int64     `db:"replay_of"`
+	CreatedAt      time.Time  `db:"created_at"`
+}
+
+// WebhookJob adalah pengiriman yang diambil worker beserta tujuan & kunci tanda tangannya.
+type WebhookJob struct {
+	WebhookDelivery
+	URL            string
+	Secret         string
+	EndpointActive bool
+}
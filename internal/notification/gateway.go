@@ -0,0 +1,129 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// gatewayTimeout membatasi lama satu request ke gateway agar worker tidak tertahan.
+const gatewayTimeout = 15 * time.Second
+
+// WhatsAppNotifier mengirim pesan lewat HTTP gateway WhatsApp (body JSON).
+//
+//	POST {url}
+//	Authorization: {token}
+//	{"target": "6281234567890", "message": "...", "sender": "..."}
+type WhatsAppNotifier struct {
+	url    string
+	token  string
+	sender string
+	client *http.Client
+}
+
+// NewWhatsAppNotifier creates a Notifier for a WhatsApp HTTP gateway.
+func NewWhatsAppNotifier(gatewayURL, token, sender string) *WhatsAppNotifier {
+	return &WhatsAppNotifier{
+		url:    gatewayURL,
+		token:  token,
+		sender: sender,
+		client: &http.Client{Timeout: gatewayTimeout},
+	}
+}
+
+func (n *WhatsAppNotifier) Channel() string { return ChannelWhatsApp }
+
+// Send posts the message as JSON to the gateway.
+func (n *WhatsAppNotifier) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(map[string]string{
+		"target":  msg.To,
+		"message": msg.Body,
+		"sender":  n.sender,
+	})
+	if err != nil {
+		return fmt.Errorf("whatsapp.Send.Marshal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("whatsapp.Send.Request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doGatewayRequest(n.client, req, n.token, "whatsapp")
+}
+
+// SMSNotifier mengirim pesan lewat HTTP gateway SMS (body form-urlencoded).
+//
+//	POST {url}
+//	Authorization: {token}
+//	to=6281234567890&message=...&sender=...
+type SMSNotifier struct {
+	url    string
+	token  string
+	sender string
+	client *http.Client
+}
+
+// NewSMSNotifier creates a Notifier for an SMS HTTP gateway.
+func NewSMSNotifier(gatewayURL, token, sender string) *SMSNotifier {
+	return &SMSNotifier{
+		url:    gatewayURL,
+		token:  token,
+		sender: sender,
+		client: &http.Client{Timeout: gatewayTimeout},
+	}
+}
+
+func (n *SMSNotifier) Channel() string { return ChannelSMS }
+
+// Send posts the message as a form to the gateway.
+func (n *SMSNotifier) Send(ctx context.Context, msg Message) error {
+	form := url.Values{}
+	form.Set("to", msg.To)
+	form.Set("message", msg.Body)
+	if n.sender != "" {
+		form.Set("sender", n.sender)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("sms.Send.Request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return doGatewayRequest(n.client, req, n.token, "sms")
+}
+
+// doGatewayRequest mengirim request & menerjemahkan status HTTP.
+// 4xx (selain 408 & 429) dianggap permanen (ErrRejected); 5xx & error jaringan boleh dicoba ulang.
+func doGatewayRequest(client *http.Client, req *http.Request, token, name string) error {
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s.Send.Do: %w", name, err)
+	}
+	defer res.Body.Close()
+
+	// Potongan body cukup untuk kolom last_error
+	snippet, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	detail := strings.TrimSpace(string(snippet))
+	if res.StatusCode >= 400 && res.StatusCode < 500 &&
+		res.StatusCode != http.StatusRequestTimeout && res.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %s gateway returned %d: %s", ErrRejected, name, res.StatusCode, detail)
+	}
+	return fmt.Errorf("%s gateway returned %d: %s", name, res.StatusCode, detail)
+}
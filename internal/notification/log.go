@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogNotifier menulis pesan ke file/stdout alih-alih mengirimnya (untuk development & uji template).
+type LogNotifier struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewLogNotifier creates a Notifier that appends messages to path, or to stdout if path is empty.
+func NewLogNotifier(path string) (*LogNotifier, error) {
+	if path == "" {
+		return &LogNotifier{out: os.Stdout}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("notification.NewLogNotifier: %w", err)
+	}
+	return &LogNotifier{out: file}, nil
+}
+
+func (n *LogNotifier) Channel() string { return ChannelLog }
+
+// Send writes one block per message: a header line followed by the indented body.
+func (n *LogNotifier) Send(ctx context.Context, msg Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	body := "    " + strings.ReplaceAll(msg.Body, "\n", "\n    ")
+	if _, err := fmt.Fprintf(n.out, "[NOTIFY] %s to=%s\n%s\n", time.Now().Format(time.RFC3339), msg.To, body); err != nil {
+		return fmt.Errorf("log.Send: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Package notification mengirim pesan ke pelanggan lewat kanal yang bisa diganti
+// (gateway WhatsApp, gateway SMS, atau log/file untuk development).
+//
+// Pesan tidak dikirim langsung dari request: event ditulis ke tabel outbox di dalam
+// transaksi yang sama dengan perubahan data, lalu dikirim oleh worker dengan retry & backoff.
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"laundry-backend/internal/config"
+)
+
+// Nama kanal (disimpan di 'notification_outbox.channel')
+const (
+	ChannelWhatsApp = "whatsapp"
+	ChannelSMS      = "sms"
+	ChannelLog      = "log"
+)
+
+// ErrRejected menandai kegagalan permanen (cth: nomor tidak valid), pesan tidak perlu dicoba ulang.
+var ErrRejected = errors.New("message rejected by gateway")
+
+// Message adalah satu pesan siap kirim.
+type Message struct {
+	To   string // Nomor HP format internasional tanpa '+', cth: 6281234567890
+	Body string
+}
+
+// Notifier adalah kanal pengiriman pesan.
+// Send mengembalikan error yang membungkus ErrRejected jika pesan tidak mungkin berhasil dicoba ulang.
+type Notifier interface {
+	Channel() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// New membuat Notifier sesuai NOTIFICATION_DRIVER.
+func New(cfg config.NotificationConfig) (Notifier, error) {
+	switch cfg.Driver {
+	case ChannelWhatsApp:
+		if cfg.GatewayURL == "" {
+			return nil, fmt.Errorf("notification: NOTIFICATION_GATEWAY_URL is required for driver %q", cfg.Driver)
+		}
+		return NewWhatsAppNotifier(cfg.GatewayURL, cfg.GatewayToken, cfg.Sender), nil
+	case ChannelSMS:
+		if cfg.GatewayURL == "" {
+			return nil, fmt.Errorf("notification: NOTIFICATION_GATEWAY_URL is required for driver %q", cfg.Driver)
+		}
+		return NewSMSNotifier(cfg.GatewayURL, cfg.GatewayToken, cfg.Sender), nil
+	case ChannelLog, "":
+		return NewLogNotifier(cfg.LogFile)
+	default:
+		return nil, fmt.Errorf("notification: unknown driver %q", cfg.Driver)
+	}
+}
+
+// NormalizePhone mengubah nomor HP lokal ke format internasional Indonesia.
+// Contoh: "0812-3456-7890" dan "+62 812 3456 7890" menjadi "6281234567890".
+// Mengembalikan string kosong jika nomor terlalu pendek untuk dikirimi pesan.
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+
+	switch {
+	case strings.HasPrefix(digits, "62"):
+	case strings.HasPrefix(digits, "0"):
+		digits = "62" + digits[1:]
+	case strings.HasPrefix(digits, "8"):
+		digits = "62" + digits
+	}
+
+	if len(digits) < 10 {
+		return ""
+	}
+	return digits
+}
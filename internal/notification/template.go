@@ -0,0 +1,145 @@
+package notification
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+)
+
+// maxMessageLength membatasi panjang pesan hasil render (batas aman gateway WhatsApp & SMS multipart).
+const maxMessageLength = 1000
+
+// ErrInvalidTemplate dikembalikan jika body template tidak bisa di-parse atau dirender.
+var ErrInvalidTemplate = errors.New("invalid notification template")
+
+// Events adalah daftar event yang memiliki template, dalam urutan tampilan.
+var Events = []string{
+	models.NotificationOrderCreated,
+	models.NotificationOrderReadyPickup,
+	models.NotificationOrderReadyDelivery,
+	models.NotificationOrderBeingDelivered,
+	models.NotificationPaymentConfirmed,
+}
+
+// statusEvents memetakan status pengerjaan yang perlu dikabarkan ke pelanggan.
+var statusEvents = map[string]string{
+	models.OrderStatusReadyPickup:    models.NotificationOrderReadyPickup,
+	models.OrderStatusReadyDelivery:  models.NotificationOrderReadyDelivery,
+	models.OrderStatusBeingDelivered: models.NotificationOrderBeingDelivered,
+}
+
+// EventForStatus mengembalikan event notifikasi untuk status baru pesanan (false jika status tidak dikabarkan).
+func EventForStatus(status string) (string, bool) {
+	event, ok := statusEvents[status]
+	return event, ok
+}
+
+// TemplateData adalah placeholder yang tersedia di template, cth: {{.InvoiceNumber}}.
+// Disimpan sebagai JSON di 'notification_outbox.payload' (snapshot saat event terjadi).
+type TemplateData struct {
+	InvoiceNumber    string `json:"invoice_number"`
+	CustomerName     string `json:"customer_name"`
+	Status           string `json:"status"`       // Kode status, cth: ready-pickup
+	StatusLabel      string `json:"status_label"` // Label status, cth: Siap Diambil
+	PaymentStatus    string `json:"payment_status"`
+	GrandTotal       string `json:"grand_total"`        // Sudah diformat, cth: Rp45.000
+	EstimatedReadyAt string `json:"estimated_ready_at"` // Sudah diformat, kosong jika belum ditentukan
+	TrackingURL      string `json:"tracking_url"`
+	ShopName         string `json:"shop_name"`
+	ShopPhone        string `json:"shop_phone"`
+}
+
+// Shop adalah identitas outlet yang ikut diisi ke template.
+type Shop struct {
+	Name            string
+	Phone           string
+	TrackingBaseURL string
+}
+
+// NewTemplateData menyusun placeholder dari data pesanan.
+func NewTemplateData(contact models.NotificationContact, shop Shop) TemplateData {
+	data := TemplateData{
+		InvoiceNumber: contact.InvoiceNumber,
+		CustomerName:  "Pelanggan",
+		Status:        contact.StatusInternal,
+		StatusLabel:   StatusLabel(contact.StatusInternal),
+		PaymentStatus: contact.PaymentStatus,
+		GrandTotal:    contact.GrandTotal.Rupiah(),
+		TrackingURL:   strings.TrimRight(shop.TrackingBaseURL, "/") + "/track/" + url.PathEscape(contact.InvoiceNumber),
+		ShopName:      shop.Name,
+		ShopPhone:     shop.Phone,
+	}
+	if contact.CustomerName != nil && strings.TrimSpace(*contact.CustomerName) != "" {
+		data.CustomerName = strings.TrimSpace(*contact.CustomerName)
+	}
+	if contact.EstimatedReadyAt != nil {
+		data.EstimatedReadyAt = contact.EstimatedReadyAt.Format("02/01/2006 15:04")
+	}
+	return data
+}
+
+// SampleData dipakai untuk validasi & pratinjau template di halaman pengaturan Owner.
+func SampleData(shop Shop) TemplateData {
+	name := "Mpok Romlah"
+	readyAt := time.Date(2026, 1, 8, 13, 0, 0, 0, time.Local)
+	return NewTemplateData(models.NotificationContact{
+		InvoiceNumber:    "INV-260105-001",
+		CustomerName:     &name,
+		GrandTotal:       money.New(45000),
+		PaymentStatus:    models.PaymentStatusUnpaid,
+		StatusInternal:   models.OrderStatusReadyPickup,
+		EstimatedReadyAt: &readyAt,
+	}, shop)
+}
+
+// Render mengisi template dengan data. Placeholder yang tidak dikenal menghasilkan ErrInvalidTemplate.
+func Render(body string, data TemplateData) (string, error) {
+	tmpl, err := template.New("notification").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	message := strings.TrimSpace(buf.String())
+	if message == "" {
+		return "", fmt.Errorf("%w: rendered message is empty", ErrInvalidTemplate)
+	}
+	if len([]rune(message)) > maxMessageLength {
+		return "", fmt.Errorf("%w: rendered message exceeds %d characters", ErrInvalidTemplate, maxMessageLength)
+	}
+	return message, nil
+}
+
+// StatusLabel mengembalikan label status pengerjaan untuk pelanggan.
+func StatusLabel(status string) string {
+	switch status {
+	case models.OrderStatusPending:
+		return "Diterima"
+	case models.OrderStatusInProgress:
+		return "Sedang Diproses"
+	case models.OrderStatusReadyPickup:
+		return "Siap Diambil"
+	case models.OrderStatusReadyDelivery:
+		return "Siap Diantar"
+	case models.OrderStatusBeingDelivered:
+		return "Sedang Diantar"
+	case models.OrderStatusFinishedDelivery:
+		return "Sudah Diantar"
+	case models.OrderStatusPickedUp:
+		return "Sudah Diambil"
+	case models.OrderStatusCancelled:
+		return "Dibatalkan"
+	}
+	return status
+}
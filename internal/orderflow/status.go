@@ -0,0 +1,129 @@
+// Package orderflow berisi state machine status pengerjaan pesanan.
+//
+// Aturan ini dipakai oleh setiap jalur yang mengubah 'orders.status_internal'
+// (PATCH pesanan, scan tag, dsb.) agar validasinya tidak ditulis berulang.
+package orderflow
+
+import (
+	"errors"
+	"fmt"
+
+	"laundry-backend/internal/models"
+)
+
+// ErrInvalidTransition dikembalikan jika transisi status tidak diizinkan untuk pesanan/role tersebut.
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// rank adalah urutan maju status. Status dengan rank sama adalah cabang (ambil sendiri vs antar).
+var rank = map[string]int{
+	models.OrderStatusPending:          0,
+	models.OrderStatusInProgress:       1,
+	models.OrderStatusReadyPickup:      2,
+	models.OrderStatusReadyDelivery:    2,
+	models.OrderStatusBeingDelivered:   3,
+	models.OrderStatusFinishedDelivery: 4,
+	models.OrderStatusPickedUp:         4,
+}
+
+// allStatuses menjaga urutan tampilan daftar status.
+var allStatuses = []string{
+	models.OrderStatusPending,
+	models.OrderStatusInProgress,
+	models.OrderStatusReadyPickup,
+	models.OrderStatusReadyDelivery,
+	models.OrderStatusBeingDelivered,
+	models.OrderStatusFinishedDelivery,
+	models.OrderStatusPickedUp,
+	models.OrderStatusCancelled,
+}
+
+// roleTargets adalah status tujuan yang boleh dipilih role terbatas. Owner & cashier bebas.
+var roleTargets = map[string]map[string]bool{
+	"staff": {
+		models.OrderStatusInProgress:    true,
+		models.OrderStatusReadyPickup:   true,
+		models.OrderStatusReadyDelivery: true,
+	},
+	"courier": {
+		models.OrderStatusBeingDelivered:   true,
+		models.OrderStatusFinishedDelivery: true,
+	},
+}
+
+// ValidateTransition memeriksa apakah role boleh memindahkan pesanan ke status next.
+//
+// Aturan:
+//  1. Status harus dikenal dan berbeda dari status sekarang.
+//  2. Role staff & courier hanya boleh memilih status tujuan miliknya.
+//  3. Status tidak boleh mundur, dan pesanan selesai/batal tidak bisa diubah, kecuali oleh owner.
+//  4. Cabang harus sesuai jenis pesanan: ready-pickup & picked-up untuk ambil sendiri,
+//     ready-delivery s.d. finished-delivery untuk pesanan antar.
+//  5. Kebijakan tanpa hutang: picked-up wajib lunas, finished-delivery wajib lunas atau COD.
+func ValidateTransition(role string, order models.OrderState, next string) error {
+
+	// 1. Status dikenal & berubah
+	if _, ok := rank[next]; !ok && next != models.OrderStatusCancelled {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidTransition, next)
+	}
+	if next == order.StatusInternal {
+		return fmt.Errorf("%w: order is already %s", ErrInvalidTransition, next)
+	}
+
+	// 2. Batasan role
+	if targets, limited := roleTargets[role]; limited && !targets[next] {
+		return fmt.Errorf("%w: role %s cannot set status %s", ErrInvalidTransition, role, next)
+	}
+
+	// 3. Tidak mundur & tidak mengubah pesanan final (kecuali owner)
+	if role != "owner" {
+		if isFinal(order.StatusInternal) {
+			return fmt.Errorf("%w: order is already %s", ErrInvalidTransition, order.StatusInternal)
+		}
+		if next != models.OrderStatusCancelled && rank[next] < rank[order.StatusInternal] {
+			return fmt.Errorf("%w: status cannot move back from %s to %s", ErrInvalidTransition, order.StatusInternal, next)
+		}
+	}
+
+	// 4. Cabang ambil sendiri vs antar
+	switch next {
+	case models.OrderStatusReadyPickup, models.OrderStatusPickedUp:
+		if order.IsDelivery {
+			return fmt.Errorf("%w: %s is only for pickup orders", ErrInvalidTransition, next)
+		}
+	case models.OrderStatusReadyDelivery, models.OrderStatusBeingDelivered, models.OrderStatusFinishedDelivery:
+		if !order.IsDelivery {
+			return fmt.Errorf("%w: %s is only for delivery orders", ErrInvalidTransition, next)
+		}
+	}
+
+	// 5. Barang tidak boleh keluar sebelum lunas
+	switch next {
+	case models.OrderStatusPickedUp:
+		if order.PaymentStatus != models.PaymentStatusPaid {
+			return fmt.Errorf("%w: order must be paid before pickup", ErrInvalidTransition)
+		}
+	case models.OrderStatusFinishedDelivery:
+		if order.PaymentStatus != models.PaymentStatusPaid && order.PaymentStatus != models.PaymentStatusCODPending {
+			return fmt.Errorf("%w: order must be paid or COD before delivery is finished", ErrInvalidTransition)
+		}
+	}
+
+	return nil
+}
+
+// NextStatuses mengembalikan status tujuan yang valid untuk role & pesanan, sesuai urutan workflow.
+func NextStatuses(role string, order models.OrderState) []string {
+	result := []string{}
+	for _, status := range allStatuses {
+		if ValidateTransition(role, order, status) == nil {
+			result = append(result, status)
+		}
+	}
+	return result
+}
+
+func isFinal(status string) bool {
+	return status == models.OrderStatusPickedUp ||
+		status == models.OrderStatusFinishedDelivery ||
+		status == models.OrderStatusCancelled
+}
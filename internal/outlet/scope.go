@@ -0,0 +1,26 @@
+// Package outlet membawa outlet aktif sebuah request di context.Context, sehingga repository
+// bisa membatasi query pesanan, pembayaran, dan pengantaran per outlet tanpa parameter tambahan.
+//
+// Scope dipasang oleh AuthMiddleware dari klaim token. Context tanpa scope (cth: background worker)
+// atau dengan outlet 0 (owner mode konsolidasi) tidak dibatasi.
+package outlet
+
+import "context"
+
+// All adalah nilai outlet untuk mode konsolidasi (semua outlet), hanya untuk owner.
+const All int64 = 0
+
+type scopeKey struct{}
+
+// WithScope mengembalikan context turunan yang dibatasi ke outletID (All = tanpa batas).
+func WithScope(ctx context.Context, outletID int64) context.Context {
+	return context.WithValue(ctx, scopeKey{}, outletID)
+}
+
+// FromContext mengambil outlet aktif dari context. All jika context tidak dibatasi.
+func FromContext(ctx context.Context) int64 {
+	if id, ok := ctx.Value(scopeKey{}).(int64); ok {
+		return id
+	}
+	return All
+}
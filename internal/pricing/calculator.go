@@ -0,0 +1,156 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+)
+
+// ErrInvalidQuantity dikembalikan jika berat/jumlah yang dihitung tidak valid (<= 0).
+var ErrInvalidQuantity = errors.New("quantity must be greater than zero")
+
+// epsilon menoleransi galat pembulatan float dari kolom DECIMAL(8,2).
+const epsilon = 1e-9
+
+// BreakdownLine adalah satu baris penjelasan dari proses perhitungan harga.
+type BreakdownLine struct {
+	Step        string       `json:"step"`        // rounding, minimum, tier, base
+	Description string       `json:"description"` // Kalimat singkat yang bisa ditampilkan ke kasir
+	Quantity    float64      `json:"quantity"`    // Jumlah setelah langkah ini diterapkan
+	UnitPrice   money.Amount `json:"unit_price"`  // Harga per unit setelah langkah ini diterapkan
+}
+
+// Result adalah hasil perhitungan satu baris item pesanan.
+type Result struct {
+	ServiceID        int64           `json:"service_id"`
+	CategoryID       int64           `json:"category_id"`
+	Unit             string          `json:"unit"`
+	ActualQuantity   float64         `json:"actual_quantity"`   // Berat/jumlah asli dari timbangan
+	BillableQuantity float64         `json:"billable_quantity"` // Berat/jumlah yang ditagihkan
+	UnitPrice        money.Amount    `json:"unit_price"`        // Harga per unit yang dipakai
+	Subtotal         money.Amount    `json:"subtotal"`
+	Breakdown        []BreakdownLine `json:"breakdown"`
+}
+
+// Calculate menghitung subtotal satu item berdasarkan harga dasar layanan dan aturan harganya.
+//
+// Urutan penerapan aturan (deterministik):
+//  1. rounding : jumlah dibulatkan ke atas ke kelipatan RoundingStep.
+//  2. minimum  : jika jumlah masih di bawah MinQuantity, jumlah ditagih = MinQuantity.
+//  3. tier     : tier dengan MinQuantity tertinggi yang masih di bawah jumlah ditagih
+//     menggantikan harga dasar untuk SELURUH jumlah (bukan progresif).
+//
+// Aturan yang tidak aktif atau milik layanan lain diabaikan.
+func Calculate(service models.Service, quantity float64, rules []models.ServicePricingRule) (*Result, error) {
+
+	// 1. Validasi input dasar
+	if quantity <= 0 || math.IsNaN(quantity) || math.IsInf(quantity, 0) {
+		return nil, ErrInvalidQuantity
+	}
+
+	// 2. Pisahkan aturan berdasarkan jenisnya
+	var rounding, minimum *models.ServicePricingRule
+	var tiers []models.ServicePricingRule
+	for i := range rules {
+		rule := rules[i]
+		if !rule.IsActive || rule.ServiceID != service.ID {
+			continue
+		}
+		switch rule.RuleType {
+		case models.PricingRuleRounding:
+			if rule.RoundingStep != nil && *rule.RoundingStep > 0 {
+				rounding = &rule
+			}
+		case models.PricingRuleMinimum:
+			if rule.MinQuantity != nil && *rule.MinQuantity > 0 {
+				minimum = &rule
+			}
+		case models.PricingRuleTier:
+			if rule.MinQuantity != nil && rule.UnitPrice != nil {
+				tiers = append(tiers, rule)
+			}
+		}
+	}
+
+	result := &Result{
+		ServiceID:        service.ID,
+		CategoryID:       service.CategoryID,
+		Unit:             service.Unit,
+		ActualQuantity:   quantity,
+		BillableQuantity: quantity,
+		UnitPrice:        service.Price,
+	}
+
+	// 3. Terapkan pembulatan ke atas
+	if rounding != nil {
+		step := *rounding.RoundingStep
+		rounded := math.Ceil(quantity/step-epsilon) * step
+		rounded = roundTo(rounded, 2)
+		if rounded != result.BillableQuantity {
+			result.BillableQuantity = rounded
+			result.Breakdown = append(result.Breakdown, BreakdownLine{
+				Step:        models.PricingRuleRounding,
+				Description: fmt.Sprintf("Rounded up %s %s to the next %s %s", formatQty(quantity), service.Unit, formatQty(step), service.Unit),
+				Quantity:    rounded,
+				UnitPrice:   result.UnitPrice,
+			})
+		}
+	}
+
+	// 4. Terapkan minimum charge
+	if minimum != nil && result.BillableQuantity < *minimum.MinQuantity-epsilon {
+		result.BillableQuantity = *minimum.MinQuantity
+		result.Breakdown = append(result.Breakdown, BreakdownLine{
+			Step:        models.PricingRuleMinimum,
+			Description: fmt.Sprintf("Minimum charge of %s %s applied", formatQty(*minimum.MinQuantity), service.Unit),
+			Quantity:    result.BillableQuantity,
+			UnitPrice:   result.UnitPrice,
+		})
+	}
+
+	// 5. Terapkan harga bertingkat (ambil tier tertinggi yang terlampaui)
+	sort.Slice(tiers, func(i, j int) bool {
+		return *tiers[i].MinQuantity > *tiers[j].MinQuantity
+	})
+	for _, tier := range tiers {
+		if result.BillableQuantity > *tier.MinQuantity+epsilon {
+			result.UnitPrice = *tier.UnitPrice
+			result.Breakdown = append(result.Breakdown, BreakdownLine{
+				Step:        models.PricingRuleTier,
+				Description: fmt.Sprintf("Volume price for more than %s %s", formatQty(*tier.MinQuantity), service.Unit),
+				Quantity:    result.BillableQuantity,
+				UnitPrice:   result.UnitPrice,
+			})
+			break
+		}
+	}
+
+	// 6. Hitung subtotal akhir (dibulatkan HalfUp ke sen sesuai DECIMAL(15,2))
+	result.Subtotal = result.UnitPrice.MulQuantity(result.BillableQuantity, money.HalfUp)
+	result.Breakdown = append(result.Breakdown, BreakdownLine{
+		Step:        "base",
+		Description: fmt.Sprintf("%s %s x %s", formatQty(result.BillableQuantity), service.Unit, result.UnitPrice),
+		Quantity:    result.BillableQuantity,
+		UnitPrice:   result.UnitPrice,
+	})
+
+	return result, nil
+}
+
+// --- HELPER FUNCTION ---
+
+func roundTo(value float64, decimals int) float64 {
+	pow := math.Pow(10, float64(decimals))
+	return math.Round(value*pow) / pow
+}
+
+func formatQty(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.2f", value)
+}
@@ -0,0 +1,196 @@
+package pricing
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+)
+
+func qty(v float64) *float64 { return &v }
+
+func price(rupiah int64) *money.Amount {
+	a := money.New(rupiah)
+	return &a
+}
+
+func roundingRule(step float64) models.ServicePricingRule {
+	return models.ServicePricingRule{ServiceID: 1, RuleType: models.PricingRuleRounding, RoundingStep: qty(step), IsActive: true}
+}
+
+func minimumRule(min float64) models.ServicePricingRule {
+	return models.ServicePricingRule{ServiceID: 1, RuleType: models.PricingRuleMinimum, MinQuantity: qty(min), IsActive: true}
+}
+
+func tierRule(above float64, unitPrice int64) models.ServicePricingRule {
+	return models.ServicePricingRule{ServiceID: 1, RuleType: models.PricingRuleTier, MinQuantity: qty(above), UnitPrice: price(unitPrice), IsActive: true}
+}
+
+func TestCalculate(t *testing.T) {
+
+	// Cuci kiloan Rp7.000/kg
+	kiloan := models.Service{ID: 1, CategoryID: 3, Unit: "kg", Price: money.New(7000)}
+
+	inactive := tierRule(1, 1000)
+	inactive.IsActive = false
+	foreign := tierRule(1, 1000)
+	foreign.ServiceID = 2
+
+	tests := []struct {
+		name         string
+		service      models.Service
+		quantity     float64
+		rules        []models.ServicePricingRule
+		wantBillable float64
+		wantUnit     money.Amount
+		wantSubtotal money.Amount
+		wantSteps    []string
+	}{
+		{
+			name: "no rules charges base price", service: kiloan, quantity: 2.5,
+			wantBillable: 2.5, wantUnit: money.New(7000), wantSubtotal: money.New(17500),
+			wantSteps: []string{"base"},
+		},
+		{
+			name: "rounding up to half kilo", service: kiloan, quantity: 2.1,
+			rules:        []models.ServicePricingRule{roundingRule(0.5)},
+			wantBillable: 2.5, wantUnit: money.New(7000), wantSubtotal: money.New(17500),
+			wantSteps: []string{models.PricingRuleRounding, "base"},
+		},
+		{
+			name: "rounding up to whole kilo", service: kiloan, quantity: 2.01,
+			rules:        []models.ServicePricingRule{roundingRule(1)},
+			wantBillable: 3, wantUnit: money.New(7000), wantSubtotal: money.New(21000),
+			wantSteps: []string{models.PricingRuleRounding, "base"},
+		},
+		{
+			name: "exact multiple is not rounded", service: kiloan, quantity: 2.5,
+			rules:        []models.ServicePricingRule{roundingRule(0.5)},
+			wantBillable: 2.5, wantUnit: money.New(7000), wantSubtotal: money.New(17500),
+			wantSteps: []string{"base"},
+		},
+		{
+			name: "float noise from DECIMAL does not round up", service: kiloan, quantity: 0.1 + 0.2,
+			rules:        []models.ServicePricingRule{roundingRule(0.1)},
+			wantBillable: 0.3, wantUnit: money.New(7000), wantSubtotal: money.New(2100),
+			wantSteps: []string{"base"},
+		},
+		{
+			name: "minimum charge lifts small loads", service: kiloan, quantity: 1.2,
+			rules:        []models.ServicePricingRule{minimumRule(3)},
+			wantBillable: 3, wantUnit: money.New(7000), wantSubtotal: money.New(21000),
+			wantSteps: []string{models.PricingRuleMinimum, "base"},
+		},
+		{
+			name: "load exactly at minimum is not lifted", service: kiloan, quantity: 3,
+			rules:        []models.ServicePricingRule{minimumRule(3)},
+			wantBillable: 3, wantUnit: money.New(7000), wantSubtotal: money.New(21000),
+			wantSteps: []string{"base"},
+		},
+		{
+			name: "rounding runs before minimum", service: kiloan, quantity: 2.2,
+			rules:        []models.ServicePricingRule{minimumRule(3), roundingRule(1)},
+			wantBillable: 3, wantUnit: money.New(7000), wantSubtotal: money.New(21000),
+			wantSteps: []string{models.PricingRuleRounding, "base"},
+		},
+		{
+			name: "tier boundary is exclusive", service: kiloan, quantity: 5,
+			rules:        []models.ServicePricingRule{tierRule(5, 6000)},
+			wantBillable: 5, wantUnit: money.New(7000), wantSubtotal: money.New(35000),
+			wantSteps: []string{"base"},
+		},
+		{
+			name: "tier applies just above boundary to the whole load", service: kiloan, quantity: 5.01,
+			rules:        []models.ServicePricingRule{tierRule(5, 6000)},
+			wantBillable: 5.01, wantUnit: money.New(6000), wantSubtotal: money.New(30060),
+			wantSteps: []string{models.PricingRuleTier, "base"},
+		},
+		{
+			name: "highest exceeded tier wins", service: kiloan, quantity: 12,
+			rules:        []models.ServicePricingRule{tierRule(5, 6000), tierRule(10, 5000), tierRule(20, 4000)},
+			wantBillable: 12, wantUnit: money.New(5000), wantSubtotal: money.New(60000),
+			wantSteps: []string{models.PricingRuleTier, "base"},
+		},
+		{
+			name: "rounding can push load over a tier", service: kiloan, quantity: 4.6,
+			rules:        []models.ServicePricingRule{roundingRule(1), tierRule(4.9, 6000)},
+			wantBillable: 5, wantUnit: money.New(6000), wantSubtotal: money.New(30000),
+			wantSteps: []string{models.PricingRuleRounding, models.PricingRuleTier, "base"},
+		},
+		{
+			name: "minimum can push load over a tier", service: kiloan, quantity: 1,
+			rules:        []models.ServicePricingRule{minimumRule(3), tierRule(2, 6500)},
+			wantBillable: 3, wantUnit: money.New(6500), wantSubtotal: money.New(19500),
+			wantSteps: []string{models.PricingRuleMinimum, models.PricingRuleTier, "base"},
+		},
+		{
+			name: "subtotal rounds half up to the cent", service: models.Service{ID: 1, Unit: "kg", Price: money.MustParse("3333.33")}, quantity: 1.5,
+			wantBillable: 1.5, wantUnit: money.MustParse("3333.33"), wantSubtotal: money.MustParse("5000"),
+			wantSteps: []string{"base"},
+		},
+		{
+			name: "subtotal below half a cent rounds down", service: models.Service{ID: 1, Unit: "kg", Price: money.MustParse("3333.31")}, quantity: 1.5,
+			wantBillable: 1.5, wantUnit: money.MustParse("3333.31"), wantSubtotal: money.MustParse("4999.97"),
+			wantSteps: []string{"base"},
+		},
+		{
+			name: "inactive and foreign rules are ignored", service: kiloan, quantity: 2,
+			rules:        []models.ServicePricingRule{inactive, foreign},
+			wantBillable: 2, wantUnit: money.New(7000), wantSubtotal: money.New(14000),
+			wantSteps: []string{"base"},
+		},
+		{
+			name: "per piece service", service: models.Service{ID: 1, Unit: "pcs", Price: money.New(15000)}, quantity: 3,
+			rules:        []models.ServicePricingRule{tierRule(2, 12000)},
+			wantBillable: 3, wantUnit: money.New(12000), wantSubtotal: money.New(36000),
+			wantSteps: []string{models.PricingRuleTier, "base"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Calculate(tt.service, tt.quantity, tt.rules)
+			if err != nil {
+				t.Fatalf("Calculate: %v", err)
+			}
+			if math.Abs(got.BillableQuantity-tt.wantBillable) > epsilon {
+				t.Errorf("BillableQuantity = %v, want %v", got.BillableQuantity, tt.wantBillable)
+			}
+			if got.UnitPrice != tt.wantUnit {
+				t.Errorf("UnitPrice = %s, want %s", got.UnitPrice, tt.wantUnit)
+			}
+			if got.Subtotal != tt.wantSubtotal {
+				t.Errorf("Subtotal = %s, want %s", got.Subtotal, tt.wantSubtotal)
+			}
+			if got.ActualQuantity != tt.quantity {
+				t.Errorf("ActualQuantity = %v, want %v", got.ActualQuantity, tt.quantity)
+			}
+
+			steps := make([]string, 0, len(got.Breakdown))
+			for _, line := range got.Breakdown {
+				steps = append(steps, line.Step)
+			}
+			if len(steps) != len(tt.wantSteps) {
+				t.Fatalf("Breakdown steps = %v, want %v", steps, tt.wantSteps)
+			}
+			for i := range steps {
+				if steps[i] != tt.wantSteps[i] {
+					t.Fatalf("Breakdown steps = %v, want %v", steps, tt.wantSteps)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateRejectsInvalidQuantity(t *testing.T) {
+
+	service := models.Service{ID: 1, Unit: "kg", Price: money.New(7000)}
+
+	for _, quantity := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if _, err := Calculate(service, quantity, nil); !errors.Is(err, ErrInvalidQuantity) {
+			t.Errorf("Calculate(%v) error = %v, want ErrInvalidQuantity", quantity, err)
+		}
+	}
+}
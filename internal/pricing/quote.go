@@ -0,0 +1,119 @@
+package pricing
+
+import (
+	"fmt"
+	"time"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+)
+
+// AddonCharge adalah biaya satu add-on yang dikenakan pada satu item.
+type AddonCharge struct {
+	AddonID     int64        `json:"addon_id"`
+	AddonName   string       `json:"addon_name"`
+	ChargeType  string       `json:"charge_type"`
+	ChargeValue money.Amount `json:"charge_value"`
+	ChargeBasis string       `json:"charge_basis"`
+	Amount      money.Amount `json:"amount"`
+}
+
+// LineInput adalah data mentah satu item pesanan yang akan dihitung.
+type LineInput struct {
+	Service  models.Service
+	Quantity float64
+	Rules    []models.ServicePricingRule
+	Addons   []models.ServiceAddon
+}
+
+// LineQuote adalah hasil perhitungan satu item beserta add-on-nya.
+type LineQuote struct {
+	Result
+	Addons        []AddonCharge `json:"addons"`
+	AddonTotal    money.Amount  `json:"addon_total"`
+	LineTotal     money.Amount  `json:"line_total"`     // Subtotal + AddonTotal
+	DurationHours int           `json:"duration_hours"` // Durasi setelah dipersingkat add-on Express
+}
+
+// OrderQuote adalah hasil perhitungan seluruh item dalam satu pesanan.
+type OrderQuote struct {
+	Lines         []LineQuote  `json:"lines"`
+	Subtotal      money.Amount `json:"subtotal"`
+	DurationHours int          `json:"duration_hours"` // MAX durasi dari seluruh item
+}
+
+// QuoteOrder menghitung subtotal seluruh item pesanan termasuk add-on.
+//
+// Aturan add-on:
+//   - percentage : persentase dari subtotal item tempat add-on dipilih.
+//   - fixed + per_unit  : nominal dikali jumlah ditagih item tersebut.
+//   - fixed + per_order : nominal dikenakan SATU kali per pesanan, dibebankan ke item
+//     pertama yang memilihnya walaupun dipilih di beberapa item.
+//   - max_duration_hours : durasi item menjadi MIN(durasi layanan, max_duration_hours).
+func QuoteOrder(lines []LineInput) (*OrderQuote, error) {
+
+	quote := &OrderQuote{Lines: make([]LineQuote, 0, len(lines))}
+	chargedPerOrder := make(map[int64]bool)
+
+	for _, line := range lines {
+
+		// 1. Hitung harga dasar item (aturan harga layanan)
+		result, err := Calculate(line.Service, line.Quantity, line.Rules)
+		if err != nil {
+			return nil, fmt.Errorf("service %d: %w", line.Service.ID, err)
+		}
+
+		lineQuote := LineQuote{
+			Result:        *result,
+			Addons:        []AddonCharge{},
+			DurationHours: line.Service.DurationHours,
+		}
+
+		// 2. Hitung biaya setiap add-on yang dipilih
+		for _, addon := range line.Addons {
+			charge := AddonCharge{
+				AddonID:     addon.ID,
+				AddonName:   addon.AddonName,
+				ChargeType:  addon.ChargeType,
+				ChargeValue: addon.ChargeValue,
+				ChargeBasis: addon.ChargeBasis,
+			}
+
+			switch {
+			case addon.ChargeType == models.AddonChargePercentage:
+				charge.Amount = result.Subtotal.MulPercent(money.PercentFromAmount(addon.ChargeValue), money.HalfUp)
+			case addon.ChargeBasis == models.AddonBasisPerOrder:
+				if !chargedPerOrder[addon.ID] {
+					charge.Amount = addon.ChargeValue
+					chargedPerOrder[addon.ID] = true
+				}
+			default:
+				charge.Amount = addon.ChargeValue.MulQuantity(result.BillableQuantity, money.HalfUp)
+			}
+
+			// 3. Add-on Express mempersingkat durasi pengerjaan
+			if addon.MaxDurationHours != nil && *addon.MaxDurationHours < lineQuote.DurationHours {
+				lineQuote.DurationHours = *addon.MaxDurationHours
+			}
+
+			lineQuote.AddonTotal = lineQuote.AddonTotal.Add(charge.Amount)
+			lineQuote.Addons = append(lineQuote.Addons, charge)
+		}
+
+		// 4. Akumulasi ke total pesanan
+		lineQuote.LineTotal = result.Subtotal.Add(lineQuote.AddonTotal)
+		quote.Subtotal = quote.Subtotal.Add(lineQuote.LineTotal)
+		if lineQuote.DurationHours > quote.DurationHours {
+			quote.DurationHours = lineQuote.DurationHours
+		}
+
+		quote.Lines = append(quote.Lines, lineQuote)
+	}
+
+	return quote, nil
+}
+
+// EstimateReadyAt menghitung estimated_ready_at: waktu pesanan dibuat + MAX(duration_hours).
+func EstimateReadyAt(createdAt time.Time, durationHours int) time.Time {
+	return createdAt.Add(time.Duration(durationHours) * time.Hour)
+}
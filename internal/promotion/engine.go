@@ -0,0 +1,331 @@
+package promotion
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+)
+
+// Alasan sebuah promosi tidak bisa dipakai. Dibungkus dengan detail menggunakan %w,
+// sehingga layer Service bisa membedakan "tidak memenuhi syarat" dan "kuota habis".
+var (
+	ErrInactive         = errors.New("promotion is not active")
+	ErrNotStarted       = errors.New("promotion has not started yet")
+	ErrExpired          = errors.New("promotion has expired")
+	ErrWrongDay         = errors.New("promotion is not valid today")
+	ErrMinSpend         = errors.New("minimum spend not reached")
+	ErrOutOfScope       = errors.New("no eligible items in cart")
+	ErrCustomerRequired = errors.New("promotion requires a registered customer")
+	ErrWrongCustomer    = errors.New("promotion is reserved for another customer")
+	ErrExhausted        = errors.New("promotion usage limit reached")
+	ErrCustomerLimit    = errors.New("customer usage limit reached")
+)
+
+// CartLine adalah satu baris keranjang yang sudah dihitung harganya oleh kalkulator harga.
+type CartLine struct {
+	ServiceID  int64
+	CategoryID int64
+	Amount     money.Amount // Total baris termasuk add-on
+}
+
+// Cart adalah keranjang yang dievaluasi terhadap promosi.
+type Cart struct {
+	Lines      []CartLine
+	Subtotal   money.Amount
+	CustomerID *int64
+}
+
+// Candidate adalah promosi beserta data pemakaian yang dibutuhkan untuk evaluasi.
+type Candidate struct {
+	Promotion     models.Promotion
+	TargetIDs     []int64
+	CustomerUsage int // Jumlah pemakaian oleh pelanggan di keranjang (0 jika tanpa pelanggan)
+}
+
+// MemberDiscount adalah diskon otomatis dari level member pelanggan.
+type MemberDiscount struct {
+	TierID          int64
+	TierName        string
+	DiscountPercent money.Percent
+	MaxDiscount     *money.Amount
+}
+
+// Sumber diskon yang diterapkan
+const (
+	SourceMembership = "membership"
+	SourcePromotion  = "promotion"
+	SourceVoucher    = "voucher"
+	SourcePoints     = "points" // Penukaran poin loyalitas saat checkout
+)
+
+// AppliedDiscount adalah diskon yang lolos evaluasi, siap disimpan ke order_discounts.
+// PromotionID bernilai 0 untuk diskon member dan penukaran poin (tidak ada kuota yang perlu dicatat).
+type AppliedDiscount struct {
+	Source      string       `json:"source"`
+	PromotionID int64        `json:"promotion_id"`
+	Code        *string      `json:"code"`
+	PromoName   string       `json:"promo_name"`
+	Amount      money.Amount `json:"amount"`
+	Explanation string       `json:"explanation"`
+}
+
+// Summary adalah hasil akhir penerapan promosi pada satu keranjang.
+type Summary struct {
+	Subtotal      money.Amount      `json:"subtotal"`
+	Discounts     []AppliedDiscount `json:"discounts"`
+	DiscountTotal money.Amount      `json:"discount_total"`
+	Total         money.Amount      `json:"total"`
+}
+
+// Evaluate memeriksa apakah satu promosi berlaku untuk keranjang pada waktu now,
+// lalu menghitung besar diskonnya.
+//
+// Urutan pemeriksaan (deterministik):
+//  1. status aktif, periode berlaku (starts_at/ends_at), dan hari berlaku (valid_days).
+//  2. pemilik voucher (customer_id), kuota total, dan kuota per pelanggan.
+//  3. minimal belanja dibandingkan dengan subtotal SELURUH keranjang.
+//  4. diskon dihitung dari nilai item yang masuk cakupan (scope) saja;
+//     persentase dibatasi max_discount, nominal tetap dibatasi nilai item tersebut.
+//
+// Diskon persentase dibulatkan HalfUp ke sen agar hasilnya sama di setiap perhitungan ulang.
+func Evaluate(c Candidate, cart Cart, now time.Time) (*AppliedDiscount, error) {
+	promo := c.Promotion
+
+	// 1. Status & periode berlaku
+	if !promo.IsActive {
+		return nil, ErrInactive
+	}
+	if now.Before(promo.StartsAt) {
+		return nil, fmt.Errorf("%w: starts at %s", ErrNotStarted, promo.StartsAt.Format("2006-01-02 15:04:05"))
+	}
+	if promo.EndsAt != nil && now.After(*promo.EndsAt) {
+		return nil, fmt.Errorf("%w: ended at %s", ErrExpired, promo.EndsAt.Format("2006-01-02 15:04:05"))
+	}
+	if promo.ValidDays != nil && !ValidOnDay(*promo.ValidDays, now) {
+		return nil, fmt.Errorf("%w: valid on %s", ErrWrongDay, describeDays(*promo.ValidDays))
+	}
+
+	// 2. Pemilik voucher & kuota pemakaian
+	if promo.CustomerID != nil {
+		if cart.CustomerID == nil {
+			return nil, ErrCustomerRequired
+		}
+		if *cart.CustomerID != *promo.CustomerID {
+			return nil, ErrWrongCustomer
+		}
+	}
+	if promo.UsageLimit != nil && promo.UsageCount >= *promo.UsageLimit {
+		return nil, ErrExhausted
+	}
+	if promo.PerCustomerLimit != nil {
+		if cart.CustomerID == nil {
+			return nil, ErrCustomerRequired
+		}
+		if c.CustomerUsage >= *promo.PerCustomerLimit {
+			return nil, ErrCustomerLimit
+		}
+	}
+
+	// 3. Minimal belanja
+	if cart.Subtotal < promo.MinSpend {
+		return nil, fmt.Errorf("%w: spend at least %s", ErrMinSpend, promo.MinSpend.Rupiah())
+	}
+
+	// 4. Nilai item yang masuk cakupan
+	eligible := eligibleAmount(promo.ScopeType, c.TargetIDs, cart.Lines)
+	if !eligible.IsPositive() {
+		return nil, ErrOutOfScope
+	}
+
+	// 5. Hitung besar diskon
+	var amount money.Amount
+	switch promo.DiscountType {
+	case models.DiscountPercentage:
+		amount = eligible.MulPercent(money.PercentFromAmount(promo.DiscountValue), money.HalfUp)
+		if promo.MaxDiscount != nil {
+			amount = money.Min(amount, *promo.MaxDiscount)
+		}
+	default:
+		amount = promo.DiscountValue
+	}
+	amount = money.Min(amount, eligible)
+
+	source := SourcePromotion
+	if promo.Code != nil {
+		source = SourceVoucher
+	}
+
+	return &AppliedDiscount{
+		Source:      source,
+		PromotionID: promo.ID,
+		Code:        promo.Code,
+		PromoName:   promo.PromoName,
+		Amount:      amount,
+		Explanation: Explain(promo),
+	}, nil
+}
+
+// Apply menerapkan diskon ke keranjang dengan urutan: diskon member, SATU promo otomatis
+// (yang diskonnya terbesar), lalu SATU kode voucher. Setiap diskon dihitung dari subtotal asli,
+// tetapi total diskon tidak pernah melebihi subtotal (diskon terakhir yang dipotong).
+//
+// Promo otomatis yang tidak lolos evaluasi dilewati diam-diam, sedangkan voucher yang
+// tidak lolos dikembalikan sebagai error agar kasir tahu alasannya.
+func Apply(cart Cart, member *MemberDiscount, automatic []Candidate, voucher *Candidate, now time.Time) (*Summary, error) {
+
+	summary := &Summary{Subtotal: cart.Subtotal, Discounts: []AppliedDiscount{}}
+	add := func(applied AppliedDiscount) {
+		// Batasi agar total diskon tidak melebihi subtotal
+		if remaining := cart.Subtotal.Sub(summary.DiscountTotal); applied.Amount > remaining {
+			applied.Amount = money.Max(remaining, money.Zero)
+		}
+		summary.Discounts = append(summary.Discounts, applied)
+		summary.DiscountTotal = summary.DiscountTotal.Add(applied.Amount)
+	}
+
+	// 1. Diskon member
+	if member != nil && member.DiscountPercent > 0 && cart.Subtotal.IsPositive() {
+		amount := cart.Subtotal.MulPercent(member.DiscountPercent, money.HalfUp)
+		if member.MaxDiscount != nil {
+			amount = money.Min(amount, *member.MaxDiscount)
+		}
+		add(AppliedDiscount{
+			Source:      SourceMembership,
+			PromoName:   member.TierName,
+			Amount:      amount,
+			Explanation: fmt.Sprintf("Member %s: %s%% off", member.TierName, member.DiscountPercent),
+		})
+	}
+
+	// 2. Pilih promo otomatis terbaik
+	if best := Best(automatic, cart, now); best != nil {
+		add(*best)
+	}
+
+	// 3. Terapkan kode voucher
+	if voucher != nil {
+		applied, err := Evaluate(*voucher, cart, now)
+		if err != nil {
+			return nil, err
+		}
+		add(*applied)
+	}
+
+	// 4. Total akhir
+	summary.Total = cart.Subtotal.Sub(summary.DiscountTotal)
+
+	return summary, nil
+}
+
+// Best mengembalikan diskon terbesar dari daftar promosi yang lolos evaluasi (nil jika tidak ada).
+// Jika nilainya sama, promosi dengan ID terkecil yang dipilih agar hasil stabil.
+func Best(candidates []Candidate, cart Cart, now time.Time) *AppliedDiscount {
+	var best *AppliedDiscount
+	for _, c := range candidates {
+		applied, err := Evaluate(c, cart, now)
+		if err != nil {
+			continue
+		}
+		if best == nil || applied.Amount > best.Amount ||
+			(applied.Amount == best.Amount && applied.PromotionID < best.PromotionID) {
+			best = applied
+		}
+	}
+	return best
+}
+
+// ValidOnDay memeriksa apakah now termasuk dalam daftar hari ISO (1=Senin ... 7=Minggu), cth: "6,7".
+// Hari dihitung di zona bisnis (WIB), bukan zona server.
+func ValidOnDay(validDays string, now time.Time) bool {
+	today := int(now.In(config.Location).Weekday())
+	if today == 0 {
+		today = 7
+	}
+	for _, part := range strings.Split(validDays, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(part))
+		if err == nil && day == today {
+			return true
+		}
+	}
+	return false
+}
+
+// Explain membuat kalimat singkat yang tercetak di nota, cth: "Voucher HEMAT5: Rp5.000 off above Rp50.000".
+func Explain(promo models.Promotion) string {
+	var b strings.Builder
+
+	if promo.Code != nil {
+		fmt.Fprintf(&b, "Voucher %s: ", *promo.Code)
+	} else {
+		fmt.Fprintf(&b, "%s: ", promo.PromoName)
+	}
+
+	if promo.DiscountType == models.DiscountPercentage {
+		fmt.Fprintf(&b, "%s%% off", money.PercentFromAmount(promo.DiscountValue))
+		if promo.MaxDiscount != nil {
+			fmt.Fprintf(&b, " (max %s)", promo.MaxDiscount.Rupiah())
+		}
+	} else {
+		fmt.Fprintf(&b, "%s off", promo.DiscountValue.Rupiah())
+	}
+
+	switch promo.ScopeType {
+	case models.PromoScopeService:
+		b.WriteString(" on selected services")
+	case models.PromoScopeCategory:
+		b.WriteString(" on selected categories")
+	}
+	if promo.MinSpend.IsPositive() {
+		fmt.Fprintf(&b, " above %s", promo.MinSpend.Rupiah())
+	}
+	if promo.ValidDays != nil {
+		fmt.Fprintf(&b, " (%s)", describeDays(*promo.ValidDays))
+	}
+
+	return b.String()
+}
+
+// --- HELPER FUNCTION ---
+
+var dayNames = map[string]string{
+	"1": "Mon", "2": "Tue", "3": "Wed", "4": "Thu", "5": "Fri", "6": "Sat", "7": "Sun",
+}
+
+func describeDays(validDays string) string {
+	var names []string
+	for _, part := range strings.Split(validDays, ",") {
+		if name, ok := dayNames[strings.TrimSpace(part)]; ok {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func eligibleAmount(scopeType string, targetIDs []int64, lines []CartLine) money.Amount {
+	targets := make(map[int64]bool, len(targetIDs))
+	for _, id := range targetIDs {
+		targets[id] = true
+	}
+
+	var total money.Amount
+	for _, line := range lines {
+		switch scopeType {
+		case models.PromoScopeService:
+			if !targets[line.ServiceID] {
+				continue
+			}
+		case models.PromoScopeCategory:
+			if !targets[line.CategoryID] {
+				continue
+			}
+		}
+		total = total.Add(line.Amount)
+	}
+	return total
+}
@@ -0,0 +1,197 @@
+package promotion
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+)
+
+var wib = time.FixedZone("WIB", 7*60*60)
+
+// monday adalah Senin 5 Januari 2026 pukul 10:00 WIB.
+var monday = time.Date(2026, 1, 5, 10, 0, 0, 0, wib)
+
+func intPtr(v int) *int { return &v }
+
+func strPtr(v string) *string { return &v }
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func amountPtr(a money.Amount) *money.Amount { return &a }
+
+// percentOff membuat promosi persentase aktif yang sudah dimulai sebulan lalu.
+func percentOff(id int64, percent int64) models.Promotion {
+	return models.Promotion{
+		ID:            id,
+		PromoName:     "Promo",
+		DiscountType:  models.DiscountPercentage,
+		DiscountValue: money.New(percent),
+		ScopeType:     models.PromoScopeAll,
+		StartsAt:      monday.AddDate(0, -1, 0),
+		IsActive:      true,
+	}
+}
+
+func fixedOff(id int64, rupiah int64) models.Promotion {
+	p := percentOff(id, 0)
+	p.DiscountType, p.DiscountValue = models.DiscountFixed, money.New(rupiah)
+	return p
+}
+
+func testCart() Cart {
+	customerID := int64(101)
+	return Cart{
+		Lines: []CartLine{
+			{ServiceID: 1, CategoryID: 10, Amount: money.New(40000)},
+			{ServiceID: 2, CategoryID: 20, Amount: money.New(10000)},
+		},
+		Subtotal:   money.New(50000),
+		CustomerID: &customerID,
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+
+	with := func(p models.Promotion, edit func(*models.Promotion)) models.Promotion {
+		edit(&p)
+		return p
+	}
+	ended := monday.Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		candidate Candidate
+		cart      Cart
+		want      money.Amount
+		wantErr   error
+	}{
+		{name: "percentage of whole cart", candidate: Candidate{Promotion: percentOff(1, 10)}, cart: testCart(), want: money.New(5000)},
+		{name: "percentage capped by max discount", candidate: Candidate{Promotion: with(percentOff(1, 10), func(p *models.Promotion) { p.MaxDiscount = amountPtr(money.New(3000)) })}, cart: testCart(), want: money.New(3000)},
+		{name: "fixed capped by eligible items", candidate: Candidate{Promotion: with(fixedOff(1, 15000), func(p *models.Promotion) { p.ScopeType = models.PromoScopeService }), TargetIDs: []int64{2}}, cart: testCart(), want: money.New(10000)},
+		{name: "category scope", candidate: Candidate{Promotion: with(percentOff(1, 50), func(p *models.Promotion) { p.ScopeType = models.PromoScopeCategory }), TargetIDs: []int64{10}}, cart: testCart(), want: money.New(20000)},
+		{name: "percentage rounds half up to the cent", candidate: Candidate{Promotion: percentOff(1, 10)}, cart: Cart{Lines: []CartLine{{Amount: money.FromMinor(1005)}}, Subtotal: money.FromMinor(1005)}, want: money.FromMinor(101)},
+		{name: "min spend is inclusive", candidate: Candidate{Promotion: with(fixedOff(1, 5000), func(p *models.Promotion) { p.MinSpend = money.New(50000) })}, cart: testCart(), want: money.New(5000)},
+		{name: "min spend not reached", candidate: Candidate{Promotion: with(fixedOff(1, 5000), func(p *models.Promotion) { p.MinSpend = money.New(50001) })}, cart: testCart(), wantErr: ErrMinSpend},
+		{name: "inactive", candidate: Candidate{Promotion: with(percentOff(1, 10), func(p *models.Promotion) { p.IsActive = false })}, cart: testCart(), wantErr: ErrInactive},
+		{name: "not started", candidate: Candidate{Promotion: with(percentOff(1, 10), func(p *models.Promotion) { p.StartsAt = monday.Add(time.Minute) })}, cart: testCart(), wantErr: ErrNotStarted},
+		{name: "expired", candidate: Candidate{Promotion: with(percentOff(1, 10), func(p *models.Promotion) { p.EndsAt = &ended })}, cart: testCart(), wantErr: ErrExpired},
+		{name: "weekend only on monday", candidate: Candidate{Promotion: with(percentOff(1, 10), func(p *models.Promotion) { p.ValidDays = strPtr("6,7") })}, cart: testCart(), wantErr: ErrWrongDay},
+		{name: "usage limit reached", candidate: Candidate{Promotion: with(percentOff(1, 10), func(p *models.Promotion) { p.UsageLimit, p.UsageCount = intPtr(5), 5 })}, cart: testCart(), wantErr: ErrExhausted},
+		{name: "last remaining use", candidate: Candidate{Promotion: with(fixedOff(1, 1000), func(p *models.Promotion) { p.UsageLimit, p.UsageCount = intPtr(5), 4 })}, cart: testCart(), want: money.New(1000)},
+		{name: "per customer limit reached", candidate: Candidate{Promotion: with(percentOff(1, 10), func(p *models.Promotion) { p.PerCustomerLimit = intPtr(1) }), CustomerUsage: 1}, cart: testCart(), wantErr: ErrCustomerLimit},
+		{name: "per customer limit needs a customer", candidate: Candidate{Promotion: with(percentOff(1, 10), func(p *models.Promotion) { p.PerCustomerLimit = intPtr(1) })}, cart: Cart{Lines: testCart().Lines, Subtotal: money.New(50000)}, wantErr: ErrCustomerRequired},
+		{name: "voucher for the cart's customer", candidate: Candidate{Promotion: with(fixedOff(1, 5000), func(p *models.Promotion) { p.CustomerID = int64Ptr(101) })}, cart: testCart(), want: money.New(5000)},
+		{name: "voucher for another customer", candidate: Candidate{Promotion: with(fixedOff(1, 5000), func(p *models.Promotion) { p.CustomerID = int64Ptr(102) })}, cart: testCart(), wantErr: ErrWrongCustomer},
+		{name: "customer voucher needs a customer", candidate: Candidate{Promotion: with(fixedOff(1, 5000), func(p *models.Promotion) { p.CustomerID = int64Ptr(101) })}, cart: Cart{Lines: testCart().Lines, Subtotal: money.New(50000)}, wantErr: ErrCustomerRequired},
+		{name: "no eligible items", candidate: Candidate{Promotion: with(percentOff(1, 10), func(p *models.Promotion) { p.ScopeType = models.PromoScopeService }), TargetIDs: []int64{99}}, cart: testCart(), wantErr: ErrOutOfScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(tt.candidate, tt.cart, monday)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Evaluate error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if got.Amount != tt.want {
+				t.Fatalf("Evaluate amount = %s, want %s", got.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+
+	voucher := fixedOff(9, 20000)
+	voucher.Code = strPtr("HEMAT20")
+	member := &MemberDiscount{TierID: 1, TierName: "Gold", DiscountPercent: money.NewPercent(10)}
+
+	// Member 10% (5.000) + promo otomatis terbaik (ID 3, 8.000) + voucher 20.000
+	got, err := Apply(testCart(), member,
+		[]Candidate{{Promotion: fixedOff(4, 8000)}, {Promotion: fixedOff(3, 8000)}, {Promotion: fixedOff(2, 1000)}},
+		&Candidate{Promotion: voucher}, monday)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	wantSources := []string{SourceMembership, SourcePromotion, SourceVoucher}
+	if len(got.Discounts) != len(wantSources) {
+		t.Fatalf("Discounts = %+v", got.Discounts)
+	}
+	for i, d := range got.Discounts {
+		if d.Source != wantSources[i] {
+			t.Errorf("Discounts[%d].Source = %q, want %q", i, d.Source, wantSources[i])
+		}
+	}
+	if got.Discounts[1].PromotionID != 3 {
+		t.Errorf("tie between automatic promotions picked ID %d, want 3", got.Discounts[1].PromotionID)
+	}
+	if got.DiscountTotal != money.New(33000) || got.Total != money.New(17000) {
+		t.Fatalf("DiscountTotal = %s, Total = %s", got.DiscountTotal, got.Total)
+	}
+}
+
+func TestApplyNeverDiscountsBelowZero(t *testing.T) {
+
+	voucher := fixedOff(9, 45000)
+	voucher.Code = strPtr("BESAR")
+
+	got, err := Apply(testCart(), nil, []Candidate{{Promotion: fixedOff(1, 10000)}}, &Candidate{Promotion: voucher}, monday)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got.Discounts[1].Amount != money.New(40000) || got.Total != money.Zero {
+		t.Fatalf("Apply = %+v", got)
+	}
+}
+
+func TestApplyRejectsInvalidVoucher(t *testing.T) {
+
+	voucher := fixedOff(9, 5000)
+	voucher.Code = strPtr("HABIS")
+	voucher.UsageLimit, voucher.UsageCount = intPtr(10), 10
+
+	if _, err := Apply(testCart(), nil, nil, &Candidate{Promotion: voucher}, monday); !errors.Is(err, ErrExhausted) {
+		t.Fatalf("Apply error = %v, want ErrExhausted", err)
+	}
+}
+
+// TestValidOnDayUsesBusinessTimeZone: Senin 03:00 WIB masih Minggu malam di UTC.
+// Hari berlaku harus dihitung di WIB, apa pun zona waktu server maupun zona nilai now.
+func TestValidOnDayUsesBusinessTimeZone(t *testing.T) {
+
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = time.UTC
+
+	now := time.Date(2026, 1, 4, 20, 0, 0, 0, time.UTC)
+	if !ValidOnDay("1", now) {
+		t.Errorf("ValidOnDay(\"1\", %s) = false, want true (Monday in WIB)", now)
+	}
+	if ValidOnDay("7", now) {
+		t.Errorf("ValidOnDay(\"7\", %s) = true, want false (Sunday only in UTC)", now)
+	}
+}
+
+func TestExplain(t *testing.T) {
+
+	voucher := fixedOff(1, 5000)
+	voucher.Code, voucher.MinSpend = strPtr("HEMAT5"), money.New(50000)
+	if got, want := Explain(voucher), "Voucher HEMAT5: Rp5.000 off above Rp50.000"; got != want {
+		t.Errorf("Explain = %q, want %q", got, want)
+	}
+
+	weekend := percentOff(2, 15)
+	weekend.PromoName, weekend.MaxDiscount, weekend.ValidDays = "Weekend", amountPtr(money.New(10000)), strPtr("6,7")
+	weekend.ScopeType = models.PromoScopeCategory
+	if got, want := Explain(weekend), "Weekend: 15% off (max Rp10.000) on selected categories (Sat, Sun)"; got != want {
+		t.Errorf("Explain = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,338 @@
+// Package receipt menyusun dan merender nota pesanan ke PDF, ESC/POS (printer thermal), dan teks.
+//
+// Ketiga format memakai tata letak yang sama (lihat layout), sehingga isi nota
+// di printer kasir, file PDF, dan pratinjau teks selalu identik.
+package receipt
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+)
+
+// Format keluaran nota
+const (
+	FormatPDF    = "pdf"
+	FormatESCPOS = "escpos"
+	FormatText   = "text"
+)
+
+// Lebar kertas thermal yang didukung (mm)
+const (
+	Paper58 = 58
+	Paper80 = 80
+)
+
+// ErrUnsupportedPaper dikembalikan jika lebar kertas bukan 58 atau 80 mm.
+var ErrUnsupportedPaper = errors.New("unsupported paper width")
+
+// Header adalah identitas outlet di kepala & kaki nota.
+type Header struct {
+	ShopName string
+	Address  string
+	Phone    string
+	Footer   string
+}
+
+// Document adalah satu nota yang siap dirender.
+type Document struct {
+	Header      Header
+	Receipt     *models.Receipt
+	TrackingURL string // Isi QR code: URL publik pelacakan pesanan
+}
+
+// columns mengembalikan jumlah karakter per baris untuk Font A (12x24 dot).
+func columns(paper int) (int, error) {
+	switch paper {
+	case Paper58:
+		return 32, nil // 384 dot
+	case Paper80:
+		return 48, nil // 576 dot
+	}
+	return 0, fmt.Errorf("%w: %d", ErrUnsupportedPaper, paper)
+}
+
+// --- TATA LETAK ---
+
+type lineKind int
+
+const (
+	lineLeft   lineKind = iota // Teks rata kiri
+	lineCenter                 // Teks rata tengah
+	lineRow                    // Label kiri, nominal kanan
+	lineRule                   // Garis pemisah
+	lineQR                     // QR code pelacakan
+)
+
+type line struct {
+	kind  lineKind
+	text  string
+	right string
+	bold  bool
+	large bool // Tinggi ganda (nama outlet & total)
+}
+
+// layout menyusun baris-baris nota untuk lebar tertentu (dalam karakter).
+func layout(doc *Document, width int) []line {
+	r := doc.Receipt
+	var lines []line
+
+	center := func(text string, bold, large bool) {
+		for _, part := range wrap(text, width) {
+			lines = append(lines, line{kind: lineCenter, text: part, bold: bold, large: large})
+		}
+	}
+	left := func(text string) {
+		for _, part := range wrap(text, width) {
+			lines = append(lines, line{kind: lineLeft, text: part})
+		}
+	}
+	row := func(label, amount string, bold bool) {
+		// Label panjang dipotong ke beberapa baris, nominal di baris terakhir
+		parts := wrap(label, width-len(amount)-1)
+		for i, part := range parts {
+			l := line{kind: lineRow, text: part, bold: bold}
+			if i == len(parts)-1 {
+				l.right = amount
+			}
+			lines = append(lines, l)
+		}
+	}
+	field := func(label, value string) {
+		prefix := fmt.Sprintf("%-9s: ", label)
+		for i, part := range wrap(value, width-len(prefix)) {
+			if i > 0 {
+				prefix = strings.Repeat(" ", len(prefix))
+			}
+			lines = append(lines, line{kind: lineLeft, text: prefix + part})
+		}
+	}
+	rule := func() { lines = append(lines, line{kind: lineRule}) }
+
+	// 1. Kepala nota
+	center(doc.Header.ShopName, true, true)
+	if doc.Header.Address != "" {
+		center(doc.Header.Address, false, false)
+	}
+	if doc.Header.Phone != "" {
+		center("Telp. "+doc.Header.Phone, false, false)
+	}
+	rule()
+
+	// 2. Identitas pesanan & pelanggan
+	field("No. Nota", r.InvoiceNumber)
+	field("Tanggal", formatDateTime(r.CreatedAt))
+	if r.CreatedByName != nil {
+		field("Kasir", *r.CreatedByName)
+	}
+	if r.CustomerName != nil {
+		field("Pelanggan", *r.CustomerName)
+	}
+	if r.CustomerPhone != nil {
+		field("Telp", *r.CustomerPhone)
+	}
+	if r.IsDelivery && r.CustomerAddress != nil {
+		field("Antar ke", *r.CustomerAddress)
+	}
+	rule()
+
+	// 3. Rincian item
+	for _, item := range r.Items {
+		left(item.ServiceName)
+		qty := fmt.Sprintf("  %s %s x %s", formatQuantity(item.Quantity), item.Unit, formatRupiah(item.UnitPrice))
+		if item.QtyPieces != nil && item.Unit == "kg" {
+			qty += fmt.Sprintf(" (%d pcs)", *item.QtyPieces)
+		}
+		row(qty, formatRupiah(item.Subtotal.Sub(addonTotal(item))), false)
+		for _, addon := range item.Addons {
+			row("  + "+addon.AddonName, formatRupiah(addon.Amount), false)
+		}
+		if item.ItemNotes != nil && *item.ItemNotes != "" {
+			left("  Catatan: " + *item.ItemNotes)
+		}
+	}
+	rule()
+
+	// 4. Total: subtotal, diskon, service charge & pajak, grand total
+	row("Subtotal", formatRupiah(r.Subtotal), false)
+	for _, discount := range r.Discounts {
+		row(discount.Explanation, formatRupiah(discount.Amount.Neg()), false)
+	}
+	for _, tax := range r.Taxes {
+		label := fmt.Sprintf("%s %s%%", tax.TaxName, tax.Rate.String())
+		if tax.PriceMode == models.TaxModeInclusive {
+			label += " (termasuk)" // Sudah ada di harga, tidak menambah total
+		}
+		row(label, formatRupiah(tax.Amount), false)
+	}
+	lines = append(lines, line{kind: lineRow, text: "TOTAL", right: formatRupiah(r.GrandTotal), bold: true, large: true})
+
+	// 5. Pembayaran & kembalian
+	if p := r.Payment; p != nil && p.Status == "confirmed" {
+		row("Bayar ("+paymentMethodLabel(p.Method)+")", formatRupiah(p.AmountReceived), false)
+		row("Kembali", formatRupiah(p.AmountChange), false)
+	}
+	center(paymentStatusLabel(r.PaymentStatus), true, false)
+	rule()
+
+	// 6. Estimasi selesai, catatan, QR pelacakan, dan kaki nota
+	if r.EstimatedReadyAt != nil {
+		center("Estimasi selesai:", false, false)
+		center(formatDateTime(*r.EstimatedReadyAt), true, false)
+	}
+	if r.Notes != nil && *r.Notes != "" {
+		left("Catatan: " + *r.Notes)
+	}
+	if doc.TrackingURL != "" {
+		lines = append(lines, line{kind: lineQR})
+		center("Lacak pesanan Anda:", false, false)
+		center(doc.TrackingURL, false, false)
+	}
+	if doc.Header.Footer != "" {
+		center(doc.Header.Footer, false, false)
+	}
+
+	return lines
+}
+
+// plain merender satu baris menjadi teks dengan lebar tetap (tanpa atribut cetak).
+func (l line) plain(width int) string {
+	switch l.kind {
+	case lineCenter:
+		pad := (width - len(l.text)) / 2
+		return strings.Repeat(" ", max(pad, 0)) + l.text
+	case lineRow:
+		if l.right == "" {
+			return l.text
+		}
+		gap := width - len(l.text) - len(l.right)
+		return l.text + strings.Repeat(" ", max(gap, 1)) + l.right
+	case lineRule:
+		return strings.Repeat("-", width)
+	}
+	return l.text
+}
+
+// --- FORMAT NILAI ---
+
+// formatRupiah menampilkan nominal dengan pemisah ribuan. Sen hanya ditampilkan jika ada,
+// agar baris-baris nota tetap menjumlah tepat ke total (cth: Rp2.612,61).
+func formatRupiah(a money.Amount) string {
+	minor := a.Minor()
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	text := sign + money.New(minor/100).Rupiah()
+	if cents := minor % 100; cents != 0 {
+		text += fmt.Sprintf(",%02d", cents)
+	}
+	return text
+}
+
+func formatQuantity(q float64) string {
+	return strconv.FormatFloat(q, 'f', -1, 64)
+}
+
+func formatDateTime(t time.Time) string {
+	return t.Format("02/01/2006 15:04")
+}
+
+func addonTotal(item models.ReceiptItem) money.Amount {
+	total := money.Zero
+	for _, addon := range item.Addons {
+		total = total.Add(addon.Amount)
+	}
+	return total
+}
+
+func paymentMethodLabel(method *string) string {
+	if method == nil {
+		return "-"
+	}
+	switch *method {
+	case "cash":
+		return "Tunai"
+	case "transfer":
+		return "Transfer"
+	case "qris":
+		return "QRIS"
+	case "ewallet":
+		return "E-Wallet"
+	case "deposit":
+		return "Deposit"
+	}
+	return *method
+}
+
+func paymentStatusLabel(status string) string {
+	switch status {
+	case "paid":
+		return "*** LUNAS ***"
+	case "cod_pending":
+		return "*** BAYAR DI TEMPAT (COD) ***"
+	}
+	return "*** BELUM LUNAS ***"
+}
+
+// wrap memecah teks per kata agar muat dalam lebar tertentu. Indentasi di awal teks
+// dipertahankan di setiap baris lanjutan. Kata yang lebih panjang dari satu baris
+// (cth: URL) dipotong paksa. Karakter non-ASCII diganti '?' karena code page printer
+// thermal tidak seragam.
+func wrap(text string, width int) []string {
+	text = asciiOnly(text)
+	body := strings.TrimLeft(text, " ")
+	indent := text[:len(text)-len(body)]
+	width -= len(indent)
+	if width <= 0 {
+		return []string{text}
+	}
+
+	var result []string
+	current := ""
+	for _, word := range strings.Fields(body) {
+		for len(word) > width {
+			if current != "" {
+				result = append(result, indent+current)
+				current = ""
+			}
+			result = append(result, indent+word[:width])
+			word = word[width:]
+		}
+		switch {
+		case word == "":
+		case current == "":
+			current = word
+		case len(current)+1+len(word) <= width:
+			current += " " + word
+		default:
+			result = append(result, indent+current)
+			current = word
+		}
+	}
+	if current != "" || len(result) == 0 {
+		result = append(result, indent+current)
+	}
+	return result
+}
+
+func asciiOnly(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			b.WriteByte(' ')
+		case r < 0x20 || r > 0x7E:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package receipt
+
+import (
+	"bytes"
+
+	"laundry-backend/pkg/qrcode"
+)
+
+// Perintah ESC/POS yang dipakai (subset yang didukung printer thermal Bluetooth umum).
+var (
+	escInit        = []byte{0x1B, 0x40}                               // ESC @ : reset printer
+	escAlignLeft   = []byte{0x1B, 0x61, 0x00}                         // ESC a 0
+	escAlignCenter = []byte{0x1B, 0x61, 0x01}                         // ESC a 1
+	escBoldOn      = []byte{0x1B, 0x45, 0x01}                         // ESC E 1
+	escBoldOff     = []byte{0x1B, 0x45, 0x00}                         // ESC E 0
+	escSizeNormal  = []byte{0x1D, 0x21, 0x00}                         // GS ! 0
+	escSizeTall    = []byte{0x1D, 0x21, 0x01}                         // GS ! 1 : tinggi ganda, lebar tetap
+	escFeedCut     = []byte{0x1B, 0x64, 0x04, 0x1D, 0x56, 0x42, 0x00} // ESC d 4, GS V B 0 : feed & potong sebagian
+)
+
+// quietZone adalah margin putih wajib di sekeliling QR (dalam modul).
+const quietZone = 4
+
+// RenderESCPOS merender nota sebagai byte mentah ESC/POS untuk kertas 58mm atau 80mm.
+//
+// QR dicetak sebagai gambar raster (GS v 0), bukan perintah QR bawaan printer (GS ( k),
+// karena banyak printer thermal murah tidak mendukung perintah tersebut.
+func RenderESCPOS(doc *Document, paper int) ([]byte, error) {
+	width, err := columns(paper)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(escInit)
+
+	for _, l := range layout(doc, width) {
+
+		// 1. QR pelacakan sebagai gambar raster di tengah kertas
+		if l.kind == lineQR {
+			raster, err := qrRaster(doc.TrackingURL, width*12)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(escAlignCenter)
+			buf.Write(raster)
+			buf.Write(escAlignLeft)
+			continue
+		}
+
+		// 2. Atribut cetak: tebal & tinggi ganda tidak mengubah lebar kolom
+		if l.bold {
+			buf.Write(escBoldOn)
+		}
+		if l.large {
+			buf.Write(escSizeTall)
+		}
+
+		buf.WriteString(l.plain(width))
+		buf.WriteByte('\n')
+
+		if l.large {
+			buf.Write(escSizeNormal)
+		}
+		if l.bold {
+			buf.Write(escBoldOff)
+		}
+	}
+
+	buf.Write(escFeedCut)
+
+	return buf.Bytes(), nil
+}
+
+// qrRaster membuat perintah GS v 0 berisi QR dengan ukuran modul menyesuaikan lebar kertas (dot).
+func qrRaster(data string, paperDots int) ([]byte, error) {
+	code, err := qrcode.Encode(data, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skala modul: QR memakai sekitar separuh lebar kertas
+	modules := code.Size + quietZone*2
+	scale := max(paperDots/2/modules, 2)
+	dots := modules * scale
+
+	return rasterImage(dots, dots, func(x, y int) bool {
+		return code.Black(x/scale-quietZone, y/scale-quietZone)
+	}), nil
+}
+
+// rasterImage membuat perintah GS v 0 m xL xH yL yH diikuti bitmap 1 bit per dot (MSB = dot paling kiri).
+func rasterImage(width, height int, black func(x, y int) bool) []byte {
+	bytesPerRow := (width + 7) / 8
+
+	raster := []byte{0x1D, 0x76, 0x30, 0x00,
+		byte(bytesPerRow), byte(bytesPerRow >> 8),
+		byte(height), byte(height >> 8),
+	}
+	for y := 0; y < height; y++ {
+		row := make([]byte, bytesPerRow)
+		for x := 0; x < width; x++ {
+			if black(x, y) {
+				row[x/8] |= 0x80 >> uint(x%8)
+			}
+		}
+		raster = append(raster, row...)
+	}
+
+	return append(raster, '\n')
+}
@@ -0,0 +1,298 @@
+package receipt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/qrcode"
+)
+
+// update menulis ulang file golden: go test ./internal/receipt -run Golden -update
+var update = flag.Bool("update", false, "rewrite testdata golden files")
+
+func strPtr(s string) *string { return &s }
+
+// goldenDocument adalah nota tetap (waktu, item, diskon, pajak, pembayaran, QR) untuk file golden.
+func goldenDocument() *Document {
+	createdAt := time.Date(2026, 1, 5, 9, 30, 0, 0, time.UTC)
+	readyAt := time.Date(2026, 1, 7, 17, 0, 0, 0, time.UTC)
+	pieces := 12
+	cash := models.PaymentMethodCash
+
+	return &Document{
+		Header: Header{
+			ShopName: "Laundry Bersih",
+			Address:  "Jl. Melati No. 5, Bandung",
+			Phone:    "022-555-0101",
+			Footer:   "Terima kasih!",
+		},
+		Receipt: &models.Receipt{
+			OrderID:       1,
+			InvoiceNumber: "INV-260105-001",
+			CustomerName:  strPtr("Siti Aminah"),
+			CustomerPhone: strPtr("081234567890"),
+			Subtotal:      money.New(68000),
+			DiscountTotal: money.New(6800),
+			TaxTotal:      money.New(6732),
+			GrandTotal:    money.New(67932),
+			PaymentStatus: "paid",
+			CreatedByName: strPtr("Budi"),
+			CreatedAt:     createdAt,
+
+			EstimatedReadyAt: &readyAt,
+			Items: []models.ReceiptItem{
+				{
+					ServiceName: "Cuci Kering Setrika",
+					Unit:        "kg",
+					Quantity:    3.5,
+					QtyPieces:   &pieces,
+					UnitPrice:   money.New(8000),
+					Subtotal:    money.New(33000),
+					Addons:      []models.ReceiptItemAddon{{AddonName: "Pewangi Premium", Amount: money.New(5000)}},
+				},
+				{
+					ServiceName: "Bed Cover Besar",
+					Unit:        "pcs",
+					Quantity:    1,
+					UnitPrice:   money.New(35000),
+					Subtotal:    money.New(35000),
+					ItemNotes:   strPtr("Noda kopi di sudut"),
+				},
+			},
+			Discounts: []models.ReceiptDiscount{{Explanation: "Member Gold 10%", Amount: money.New(6800)}},
+			Taxes: []models.ReceiptTax{{
+				TaxName:   "PPN",
+				TaxKind:   "tax",
+				Rate:      money.NewPercent(11),
+				PriceMode: models.TaxModeExclusive,
+				Amount:    money.New(6732),
+			}},
+			Payment: &models.ReceiptPayment{
+				Method:         &cash,
+				Amount:         money.New(67932),
+				AmountReceived: money.New(70000),
+				AmountChange:   money.New(2068),
+				Status:         "confirmed",
+				CollectedAt:    &createdAt,
+			},
+		},
+		TrackingURL: "https://laundry.example.com/t/INV-260105-001",
+	}
+}
+
+func TestRenderESCPOSGolden(t *testing.T) {
+
+	doc := goldenDocument()
+	for _, paper := range []int{Paper58, Paper80} {
+		t.Run(fmt.Sprintf("%dmm", paper), func(t *testing.T) {
+			got, err := RenderESCPOS(doc, paper)
+			if err != nil {
+				t.Fatalf("RenderESCPOS: %v", err)
+			}
+
+			// 1. Byte stream harus sama persis dengan file golden
+			want := golden(t, fmt.Sprintf("receipt_%dmm.escpos", paper), got)
+
+			// 2. Golden juga diperiksa strukturnya, agar file golden yang salah tidak lolos saat -update
+			width, _ := columns(paper)
+			text, raster := parseESCPOS(t, want)
+			plain, err := RenderText(doc, paper)
+			if err != nil {
+				t.Fatalf("RenderText: %v", err)
+			}
+			if text != string(plain) {
+				t.Fatalf("ESC/POS text =\n%s\nwant RenderText\n%s", text, plain)
+			}
+			for _, l := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+				if len(l) > width {
+					t.Fatalf("line %q is wider than %d columns", l, width)
+				}
+			}
+
+			// 3. Raster QR: lebar/tinggi dan setiap dot sesuai modul QR dengan quiet zone
+			code, err := qrcode.Encode(doc.TrackingURL, qrcode.Medium)
+			if err != nil {
+				t.Fatalf("qrcode.Encode: %v", err)
+			}
+			modules := code.Size + quietZone*2
+			scale := max(width*12/2/modules, 2)
+			dots := modules * scale
+			if raster.width != (dots+7)/8 || raster.height != dots {
+				t.Fatalf("raster = %d bytes x %d dots, want %d x %d", raster.width, raster.height, (dots+7)/8, dots)
+			}
+			for y := 0; y < dots; y++ {
+				for x := 0; x < dots; x++ {
+					dark := raster.bits[y*raster.width+x/8]&(0x80>>uint(x%8)) != 0
+					if want := code.Black(x/scale-quietZone, y/scale-quietZone); dark != want {
+						t.Fatalf("raster dot (%d,%d) = %v, want %v", x, y, dark, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRenderTextGolden(t *testing.T) {
+
+	doc := goldenDocument()
+	for _, paper := range []int{Paper58, Paper80} {
+		t.Run(fmt.Sprintf("%dmm", paper), func(t *testing.T) {
+			got, err := RenderText(doc, paper)
+			if err != nil {
+				t.Fatalf("RenderText: %v", err)
+			}
+			want := golden(t, fmt.Sprintf("receipt_%dmm.txt", paper), got)
+
+			// Setiap baris tepat selebar kertas atau kurang, dan total nota tercetak
+			width, _ := columns(paper)
+			for _, l := range strings.Split(strings.TrimSuffix(string(want), "\n"), "\n") {
+				if len(l) > width {
+					t.Fatalf("line %q is wider than %d columns", l, width)
+				}
+			}
+			if !bytes.Contains(want, []byte("67.932")) {
+				t.Fatalf("text receipt does not contain the grand total:\n%s", want)
+			}
+		})
+	}
+}
+
+func TestRenderPDFGolden(t *testing.T) {
+
+	doc := goldenDocument()
+	got, err := RenderPDF(doc)
+	if err != nil {
+		t.Fatalf("RenderPDF: %v", err)
+	}
+
+	// 1. Render ulang harus menghasilkan byte yang sama (tanpa jam server atau ID acak)
+	again, err := RenderPDF(doc)
+	if err != nil {
+		t.Fatalf("RenderPDF: %v", err)
+	}
+	if !bytes.Equal(got, again) {
+		t.Fatal("RenderPDF is not deterministic")
+	}
+	want := golden(t, "receipt.pdf", got)
+
+	// 2. Metadata: CreationDate dari waktu nota, /ID dari isi dokumen
+	if !bytes.Contains(want, []byte("/CreationDate (D:20260105093000+00'00')")) {
+		t.Error("PDF has no CreationDate taken from the receipt creation time")
+	}
+	if !bytes.Contains(want, []byte("/Title (INV-260105-001)")) {
+		t.Error("PDF has no invoice number title")
+	}
+
+	// 3. Struktur: startxref menunjuk ke tabel xref dan setiap offset menunjuk ke objeknya
+	var xref int
+	tail := want[bytes.LastIndex(want, []byte("startxref\n")):]
+	if _, err := fmt.Sscanf(string(tail), "startxref\n%d", &xref); err != nil || !bytes.HasPrefix(want[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table (%v)", xref, err)
+	}
+	var count int
+	if _, err := fmt.Sscanf(string(want[xref:]), "xref\n0 %d\n", &count); err != nil {
+		t.Fatalf("xref header: %v", err)
+	}
+	entries := strings.Split(string(want[xref:]), "\n")[3 : 3+count-1]
+	for i, entry := range entries {
+		var offset int
+		if _, err := fmt.Sscanf(entry, "%010d 00000 n", &offset); err != nil {
+			t.Fatalf("xref entry %q: %v", entry, err)
+		}
+		if prefix := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(want[offset:], []byte(prefix)) {
+			t.Fatalf("xref entry %d points at %q, want %q", i+1, want[offset:offset+len(prefix)], prefix)
+		}
+	}
+	id := md5.Sum(want[:xref])
+	if idRef := fmt.Sprintf("/ID [<%x> <%x>]", id, id); !bytes.Contains(want, []byte(idRef)) {
+		t.Errorf("trailer has no %s", idRef)
+	}
+}
+
+// --- HELPER FUNCTION ---
+
+// golden membandingkan got dengan testdata/name (ditulis ulang dengan -update) dan mengembalikan isi golden.
+func golden(t *testing.T, name string, got []byte) []byte {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden (run with -update to create): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		n := 0
+		for n < len(got) && n < len(want) && got[n] == want[n] {
+			n++
+		}
+		t.Fatalf("output differs from %s at byte %d (got %d bytes, want %d)", path, n, len(got), len(want))
+	}
+	return want
+}
+
+type rasterBlock struct {
+	width, height int // width dalam byte per baris, height dalam dot
+	bits          []byte
+}
+
+// parseESCPOS memisahkan teks yang tercetak dari perintah ESC/POS dan mengambil satu raster GS v 0.
+// Perintah yang tidak dikenal membuat tes gagal agar printer tidak menerima byte asing.
+func parseESCPOS(t *testing.T, data []byte) (string, rasterBlock) {
+	t.Helper()
+
+	if !bytes.HasPrefix(data, escInit) || !bytes.HasSuffix(data, escFeedCut) {
+		t.Fatalf("stream must start with ESC @ and end with feed & cut")
+	}
+	data = data[len(escInit) : len(data)-len(escFeedCut)]
+
+	commands := [][]byte{escAlignLeft, escAlignCenter, escBoldOn, escBoldOff, escSizeNormal, escSizeTall}
+	var text strings.Builder
+	var raster rasterBlock
+	rasters := 0
+
+next:
+	for len(data) > 0 {
+		for _, cmd := range commands {
+			if bytes.HasPrefix(data, cmd) {
+				data = data[len(cmd):]
+				continue next
+			}
+		}
+		if bytes.HasPrefix(data, []byte{0x1D, 0x76, 0x30, 0x00}) && len(data) >= 8 {
+			w := int(data[4]) | int(data[5])<<8
+			h := int(data[6]) | int(data[7])<<8
+			end := 8 + w*h
+			if end >= len(data) || data[end] != '\n' {
+				t.Fatalf("raster %dx%d is truncated or not followed by a line feed", w, h)
+			}
+			raster = rasterBlock{width: w, height: h, bits: data[8:end]}
+			rasters++
+			data = data[end+1:]
+			continue
+		}
+		if c := data[0]; c == 0x1B || c == 0x1D || (c < 0x20 && c != '\n') || c > 0x7E {
+			t.Fatalf("unexpected byte 0x%02X in text (%q)", c, data[:min(len(data), 8)])
+		}
+		text.WriteByte(data[0])
+		data = data[1:]
+	}
+
+	if rasters != 1 {
+		t.Fatalf("found %d raster images, want 1", rasters)
+	}
+	return text.String(), raster
+}
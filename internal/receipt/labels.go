@@ -0,0 +1,169 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"laundry-backend/pkg/barcode"
+	"laundry-backend/pkg/qrcode"
+)
+
+// Simbol yang bisa dicetak di label tag
+const (
+	SymbologyCode128 = "code128"
+	SymbologyQR      = "qr"
+)
+
+// Label adalah isi satu label kantong/item cucian.
+type Label struct {
+	TagCode       string
+	InvoiceNumber string
+	CustomerName  string
+	ServiceName   string
+	Pieces        *int // Jumlah helai yang dicatat kasir
+	Position      int  // Urutan item dalam pesanan (1-based)
+	Total         int  // Jumlah item dalam pesanan
+	ReadyAt       *time.Time
+}
+
+// Ukuran label PDF: 58 x 40 mm, satu label per halaman (umum untuk printer label).
+const (
+	labelWidth    = 164.4
+	labelHeight   = 113.4
+	labelMargin   = 8.0
+	labelFontSize = 7.0
+	labelLeading  = 9.0
+	labelBarWidth = 0.8 // Lebar satu modul Code128 (pt)
+)
+
+// lines mengembalikan teks label (di atas simbol) untuk lebar tertentu (karakter).
+func (l Label) lines(width int) []string {
+	var result []string
+	result = append(result, wrap(l.InvoiceNumber, width)...)
+	if l.CustomerName != "" {
+		result = append(result, wrap(l.CustomerName, width)...)
+	}
+
+	item := fmt.Sprintf("%d/%d %s", l.Position, l.Total, l.ServiceName)
+	if l.Pieces != nil {
+		item += fmt.Sprintf(" - %d pcs", *l.Pieces)
+	}
+	result = append(result, wrap(item, width)...)
+
+	if l.ReadyAt != nil {
+		result = append(result, "Selesai: "+formatDateTime(*l.ReadyAt))
+	}
+	return result
+}
+
+// RenderLabelsPDF merender label sebagai PDF, satu label per halaman 58 x 40 mm.
+func RenderLabelsPDF(labels []Label, symbology string) ([]byte, error) {
+	textWidth := labelWidth - labelMargin*2
+	width := int(textWidth / (labelFontSize * 0.6))
+
+	pages := make([]*pdfPage, 0, len(labels))
+	for _, label := range labels {
+		page := &pdfPage{width: labelWidth, height: labelHeight}
+
+		// 1. Teks identitas di bagian atas
+		y := labelHeight - labelMargin
+		for i, text := range label.lines(width) {
+			y -= labelLeading
+			page.text(i == 0, labelFontSize, labelMargin, y, text)
+		}
+
+		// 2. Simbol di sisa ruang, kode tag tercetak di bawahnya
+		bottom := labelMargin + labelLeading
+		space := y - 4 - bottom
+		switch symbology {
+		case SymbologyQR:
+			code, err := qrcode.Encode(label.TagCode, qrcode.Medium)
+			if err != nil {
+				return nil, err
+			}
+			module := space / float64(code.Size)
+			page.qr(code, (labelWidth-module*float64(code.Size))/2, y-4, module)
+		default:
+			modules, err := barcode.Code128(label.TagCode)
+			if err != nil {
+				return nil, err
+			}
+			page.bars(modules, (labelWidth-labelBarWidth*float64(len(modules)))/2, bottom+2, labelBarWidth, space-2)
+		}
+
+		tagX := (labelWidth - float64(len(label.TagCode))*labelFontSize*0.6) / 2
+		page.text(true, labelFontSize, tagX, labelMargin, label.TagCode)
+		pages = append(pages, page)
+	}
+
+	return writePDF(pages, pdfInfo{}), nil
+}
+
+// RenderLabelsESCPOS merender label sebagai byte ESC/POS berurutan, dipotong per label.
+func RenderLabelsESCPOS(labels []Label, symbology string, paper int) ([]byte, error) {
+	width, err := columns(paper)
+	if err != nil {
+		return nil, err
+	}
+	paperDots := width * 12
+
+	var buf bytes.Buffer
+	buf.Write(escInit)
+	for _, label := range labels {
+
+		// 1. Teks identitas
+		for i, text := range label.lines(width) {
+			if i == 0 {
+				buf.Write(escBoldOn)
+				buf.Write(escSizeTall)
+			}
+			buf.WriteString(text)
+			buf.WriteByte('\n')
+			if i == 0 {
+				buf.Write(escSizeNormal)
+				buf.Write(escBoldOff)
+			}
+		}
+
+		// 2. Simbol sebagai gambar raster di tengah, lalu kode tag
+		var raster []byte
+		switch symbology {
+		case SymbologyQR:
+			raster, err = qrRaster(label.TagCode, paperDots)
+		default:
+			raster, err = code128Raster(label.TagCode, paperDots)
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(escAlignCenter)
+		buf.Write(raster)
+		buf.Write(escBoldOn)
+		buf.WriteString(label.TagCode)
+		buf.WriteByte('\n')
+		buf.Write(escBoldOff)
+		buf.Write(escAlignLeft)
+
+		buf.Write(escFeedCut)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// code128Raster membuat perintah GS v 0 berisi barcode Code128 setinggi 80 dot (10 mm).
+func code128Raster(data string, paperDots int) ([]byte, error) {
+	modules, err := barcode.Code128(data)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(modules) + barcode.QuietZone*2
+	scale := max(paperDots/total, 1)
+	scale = min(scale, 3)
+
+	return rasterImage(total*scale, 80, func(x, _ int) bool {
+		i := x/scale - barcode.QuietZone
+		return i >= 0 && i < len(modules) && modules[i]
+	}), nil
+}
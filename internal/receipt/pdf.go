@@ -0,0 +1,195 @@
+package receipt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"strings"
+	"time"
+
+	"laundry-backend/pkg/qrcode"
+)
+
+// Ukuran halaman PDF (point, 1pt = 1/72 inch). Lebar mengikuti 48 kolom Courier
+// agar tata letaknya sama persis dengan nota thermal 80mm.
+const (
+	pdfColumns   = 48
+	pdfFontSize  = 8.0
+	pdfCharWidth = pdfFontSize * 0.6 // Lebar glyph Courier = 600/1000 em
+	pdfLeading   = 11.0
+	pdfMargin    = 18.0
+	pdfQRModule  = 3.0
+)
+
+// RenderPDF merender nota sebagai dokumen PDF satu halaman dengan tinggi menyesuaikan isi.
+// CreationDate diambil dari waktu nota dibuat (bukan jam server), sehingga nota yang sama selalu
+// menghasilkan byte yang sama.
+func RenderPDF(doc *Document) ([]byte, error) {
+	lines := layout(doc, pdfColumns)
+
+	// 1. Siapkan QR (jika ada) untuk menghitung tinggi halaman
+	var code *qrcode.Code
+	if doc.TrackingURL != "" {
+		var err error
+		if code, err = qrcode.Encode(doc.TrackingURL, qrcode.Medium); err != nil {
+			return nil, err
+		}
+	}
+
+	page := &pdfPage{width: pdfMargin*2 + pdfColumns*pdfCharWidth, height: pdfMargin * 2}
+	for _, l := range lines {
+		if l.kind == lineQR {
+			page.height += float64(code.Size+quietZone*2) * pdfQRModule
+			continue
+		}
+		page.height += pdfLeading
+	}
+
+	// 2. Susun content stream dari atas ke bawah
+	y := page.height - pdfMargin
+	for _, l := range lines {
+		if l.kind == lineQR {
+			qrSize := float64(code.Size+quietZone*2) * pdfQRModule
+			left := (page.width - qrSize) / 2
+			page.qr(code, left+quietZone*pdfQRModule, y-quietZone*pdfQRModule, pdfQRModule)
+			y -= qrSize
+			continue
+		}
+
+		y -= pdfLeading
+		page.text(l.bold || l.large, pdfFontSize, pdfMargin, y+2, l.plain(pdfColumns))
+	}
+
+	info := pdfInfo{}
+	if doc.Receipt != nil {
+		info.Title = doc.Receipt.InvoiceNumber
+		info.CreatedAt = doc.Receipt.CreatedAt
+	}
+	return writePDF([]*pdfPage{page}, info), nil
+}
+
+// --- PENULIS PDF MINIMAL ---
+
+// pdfPage adalah satu halaman beserta content stream-nya (hanya teks Courier & persegi hitam).
+type pdfPage struct {
+	width, height float64
+	content       bytes.Buffer
+}
+
+// text menulis satu baris teks dengan baseline di (x, y).
+func (p *pdfPage) text(bold bool, size, x, y float64, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(&p.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(s))
+}
+
+// qr menggambar modul gelap QR sebagai persegi hitam; (left, top) adalah sudut kiri atas simbol.
+func (p *pdfPage) qr(code *qrcode.Code, left, top, module float64) {
+	p.content.WriteString("0 g\n")
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if code.Black(x, y) {
+				fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f re\n",
+					left+float64(x)*module, top-float64(y+1)*module, module, module)
+			}
+		}
+	}
+	p.content.WriteString("f\n")
+}
+
+// bars menggambar barcode 1D; (left, bottom) adalah sudut kiri bawah barcode.
+func (p *pdfPage) bars(modules []bool, left, bottom, module, height float64) {
+	p.content.WriteString("0 g\n")
+	for i := 0; i < len(modules); {
+		if !modules[i] {
+			i++
+			continue
+		}
+		// Gabungkan bar bersebelahan menjadi satu persegi
+		start := i
+		for i < len(modules) && modules[i] {
+			i++
+		}
+		fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f re\n", left+float64(start)*module, bottom, float64(i-start)*module, height)
+	}
+	p.content.WriteString("f\n")
+}
+
+// pdfInfo adalah metadata dokumen (kamus /Info). CreatedAt kosong berarti CreationDate tidak ditulis.
+type pdfInfo struct {
+	Title     string
+	CreatedAt time.Time
+}
+
+// writePDF menulis halaman-halaman menjadi file PDF 1.4 lengkap dengan tabel xref.
+// /ID di trailer adalah MD5 seluruh objek, jadi isi yang sama selalu mendapat ID yang sama.
+func writePDF(pages []*pdfPage, info pdfInfo) []byte {
+
+	// 1. Objek tetap: catalog, pages, dan dua font bawaan (tidak perlu embed)
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>",
+	}
+
+	// 2. Setiap halaman = objek page + objek content stream
+	for i, page := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				page.width, page.height, 6+i*2),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", page.content.Len(), page.content.String()),
+		)
+	}
+
+	// 3. Metadata dokumen selalu menjadi objek terakhir
+	meta := "<< /Producer (laundry-backend)"
+	if info.Title != "" {
+		meta += fmt.Sprintf(" /Title (%s)", pdfEscape(info.Title))
+	}
+	if !info.CreatedAt.IsZero() {
+		meta += fmt.Sprintf(" /CreationDate (%s)", pdfDate(info.CreatedAt))
+	}
+	objects = append(objects, meta+" >>")
+
+	// 4. Tulis objek beserta offset-nya untuk tabel xref
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	id := md5.Sum(out.Bytes()[:xref])
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R /ID [<%x> <%x>] >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1, len(objects), id, id, xref)
+
+	return out.Bytes()
+}
+
+// pdfDate memformat waktu sebagai tanggal PDF, cth: D:20260105163000+07'00'.
+func pdfDate(t time.Time) string {
+	_, offset := t.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("D:%s%c%02d'%02d'", t.Format("20060102150405"), sign, offset/3600, offset%3600/60)
+}
+
+// pdfEscape meng-escape karakter khusus string literal PDF.
+func pdfEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(text)
+}
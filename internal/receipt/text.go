@@ -0,0 +1,25 @@
+package receipt
+
+import (
+	"bytes"
+)
+
+// RenderText merender nota sebagai teks polos dengan lebar kolom sesuai kertas.
+// Format teks tidak bisa memuat gambar, sehingga QR diganti URL pelacakan di bawahnya.
+func RenderText(doc *Document, paper int) ([]byte, error) {
+	width, err := columns(paper)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, l := range layout(doc, width) {
+		if l.kind == lineQR {
+			continue
+		}
+		buf.WriteString(l.plain(width))
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,366 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+	"strings"
+)
+
+// AddonRepository mendefinisikan semua operasi database untuk katalog add-on layanan.
+type AddonRepository interface {
+
+	// Create Operations
+	InsertAddon(ctx context.Context, addon *models.ServiceAddon, serviceIDs []int64) error
+
+	// Read Operations
+	FindAll(ctx context.Context, limit, offset int, search, status string, serviceID int64) ([]models.ServiceAddon, int64, error)
+	FindByID(ctx context.Context, id int64) (*models.ServiceAddonWithServices, error)
+	FindByCode(ctx context.Context, code string) (*models.ServiceAddon, error)
+	FindForService(ctx context.Context, serviceID int64, addonIDs []int64) ([]models.ServiceAddon, error)
+
+	// Update Operations
+	UpdateAddon(ctx context.Context, addon *models.ServiceAddon, serviceIDs []int64) error
+
+	// Delete Operations (Soft Delete)
+	DeleteAddon(ctx context.Context, id int64) error
+}
+
+// addonRepository is the concrete implementation using sql.DB.
+type addonRepository struct {
+	db *sql.DB
+}
+
+// NewAddonRepository creates a new instance of AddonRepository.
+func NewAddonRepository(db *sql.DB) AddonRepository {
+	return &addonRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// InsertAddon creates a new add-on together with its service links in one transaction.
+func (r *addonRepository) InsertAddon(ctx context.Context, addon *models.ServiceAddon, serviceIDs []int64) error {
+
+	// 1. Mulai transaksi (add-on & relasinya harus tersimpan bersamaan)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("addonRepo.InsertAddon.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Simpan data add-on
+	query := `
+		INSERT INTO service_addons (code, addon_name, charge_type, charge_value, charge_basis, max_duration_hours, is_active, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := tx.ExecContext(ctx, query,
+		addon.Code,
+		addon.AddonName,
+		addon.ChargeType,
+		addon.ChargeValue,
+		addon.ChargeBasis,
+		addon.MaxDurationHours,
+		addon.IsActive,
+		addon.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("addonRepo.InsertAddon.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("addonRepo.InsertAddon.LastInsertId: %w", err)
+	}
+
+	// 3. Simpan relasi ke layanan
+	if err := replaceAddonLinks(ctx, tx, id, serviceIDs); err != nil {
+		return fmt.Errorf("addonRepo.InsertAddon: %w", err)
+	}
+
+	// 4. Commit transaksi
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("addonRepo.InsertAddon.Commit: %w", err)
+	}
+
+	addon.ID = id
+	return nil
+}
+
+// FindAll retrieves a list of add-ons with pagination and filtering support.
+func (r *addonRepository) FindAll(ctx context.Context, limit, offset int, search, status string, serviceID int64) ([]models.ServiceAddon, int64, error) {
+
+	// 1. Inisialisasi query dasar
+	whereClause := "WHERE 1=1"
+	var args []interface{}
+
+	// 2. Terapkan filter pencarian nama atau kode add-on
+	if search != "" {
+		whereClause += " AND (LOWER(a.addon_name) LIKE ? OR LOWER(a.code) LIKE ?)"
+		searchParam := "%" + strings.ToLower(search) + "%"
+		args = append(args, searchParam, searchParam)
+	}
+
+	// 3. Terapkan filter status aktif/non-aktif
+	if status == "1" {
+		whereClause += " AND a.is_active = 1"
+	} else if status == "0" {
+		whereClause += " AND a.is_active = 0"
+	}
+
+	// 4. Terapkan filter layanan (hanya add-on yang boleh dipilih untuk layanan tsb)
+	if serviceID > 0 {
+		whereClause += " AND EXISTS (SELECT 1 FROM service_addon_links l WHERE l.addon_id = a.id AND l.service_id = ?)"
+		args = append(args, serviceID)
+	}
+
+	// 5. Hitung total baris untuk Meta Pagination
+	var totalItems int64
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM service_addons a %s", whereClause)
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalItems); err != nil {
+		return nil, 0, fmt.Errorf("addonRepo.FindAll.Count: %w", err)
+	}
+
+	// 6. Rangkai query utama
+	query := fmt.Sprintf(`
+		SELECT a.id, a.code, a.addon_name, a.charge_type, a.charge_value, a.charge_basis, a.max_duration_hours, a.is_active, a.created_at, a.updated_at
+		FROM service_addons a
+		%s
+		ORDER BY a.addon_name ASC
+		LIMIT ? OFFSET ?`, whereClause)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("addonRepo.FindAll.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 7. Mapping hasil query
+	var addons []models.ServiceAddon
+	for rows.Next() {
+		addon, err := scanAddon(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("addonRepo.FindAll.Scan: %w", err)
+		}
+		addons = append(addons, *addon)
+	}
+
+	return addons, totalItems, nil
+}
+
+// FindByID retrieves a single add-on with the IDs of the services it is linked to.
+func (r *addonRepository) FindByID(ctx context.Context, id int64) (*models.ServiceAddonWithServices, error) {
+
+	// 1. Ambil data add-on
+	query := `
+		SELECT id, code, addon_name, charge_type, charge_value, charge_basis, max_duration_hours, is_active, created_at, updated_at
+		FROM service_addons
+		WHERE id = ?
+	`
+	addon, err := scanAddon(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("addonRepo.FindByID: %w", err)
+	}
+
+	// 2. Ambil daftar layanan yang terhubung
+	rows, err := r.db.QueryContext(ctx, "SELECT service_id FROM service_addon_links WHERE addon_id = ? ORDER BY service_id", id)
+	if err != nil {
+		return nil, fmt.Errorf("addonRepo.FindByID.Links: %w", err)
+	}
+	defer rows.Close()
+
+	result := &models.ServiceAddonWithServices{ServiceAddon: *addon, ServiceIDs: []int64{}}
+	for rows.Next() {
+		var serviceID int64
+		if err := rows.Scan(&serviceID); err != nil {
+			return nil, fmt.Errorf("addonRepo.FindByID.Links.Scan: %w", err)
+		}
+		result.ServiceIDs = append(result.ServiceIDs, serviceID)
+	}
+
+	return result, nil
+}
+
+// FindByCode retrieves a single add-on by its exact code (Useful for duplicate validation).
+func (r *addonRepository) FindByCode(ctx context.Context, code string) (*models.ServiceAddon, error) {
+
+	query := `
+		SELECT id, code, addon_name, charge_type, charge_value, charge_basis, max_duration_hours, is_active, created_at, updated_at
+		FROM service_addons
+		WHERE code = ?
+	`
+	addon, err := scanAddon(r.db.QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("addonRepo.FindByCode: %w", err)
+	}
+
+	return addon, nil
+}
+
+// FindForService retrieves the active add-ons among addonIDs that may be selected for the service.
+// IDs that are inactive or not linked to the service are silently left out; callers compare lengths.
+func (r *addonRepository) FindForService(ctx context.Context, serviceID int64, addonIDs []int64) ([]models.ServiceAddon, error) {
+
+	if len(addonIDs) == 0 {
+		return []models.ServiceAddon{}, nil
+	}
+
+	// 1. Siapkan placeholder IN (?, ?, ...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(addonIDs)), ",")
+	args := []interface{}{serviceID}
+	for _, id := range addonIDs {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT a.id, a.code, a.addon_name, a.charge_type, a.charge_value, a.charge_basis, a.max_duration_hours, a.is_active, a.created_at, a.updated_at
+		FROM service_addons a
+		JOIN service_addon_links l ON l.addon_id = a.id AND l.service_id = ?
+		WHERE a.is_active = 1 AND a.id IN (%s)
+		ORDER BY a.id ASC`, placeholders)
+
+	// 2. Eksekusi query
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("addonRepo.FindForService.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 3. Mapping hasil query
+	addons := []models.ServiceAddon{}
+	for rows.Next() {
+		addon, err := scanAddon(rows)
+		if err != nil {
+			return nil, fmt.Errorf("addonRepo.FindForService.Scan: %w", err)
+		}
+		addons = append(addons, *addon)
+	}
+
+	return addons, rows.Err()
+}
+
+// UpdateAddon updates an add-on; a non-nil serviceIDs replaces all of its service links.
+func (r *addonRepository) UpdateAddon(ctx context.Context, addon *models.ServiceAddon, serviceIDs []int64) error {
+
+	// 1. Mulai transaksi
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("addonRepo.UpdateAddon.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Update data add-on
+	query := `
+		UPDATE service_addons
+		SET code = ?, addon_name = ?, charge_type = ?, charge_value = ?, charge_basis = ?, max_duration_hours = ?, is_active = ?, updated_at = ?
+		WHERE id = ?
+	`
+	res, err := tx.ExecContext(ctx, query,
+		addon.Code,
+		addon.AddonName,
+		addon.ChargeType,
+		addon.ChargeValue,
+		addon.ChargeBasis,
+		addon.MaxDurationHours,
+		addon.IsActive,
+		addon.UpdatedAt,
+		addon.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("addonRepo.UpdateAddon.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("addonRepo.UpdateAddon.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	// 3. Ganti relasi layanan jika dikirim
+	if serviceIDs != nil {
+		if err := replaceAddonLinks(ctx, tx, addon.ID, serviceIDs); err != nil {
+			return fmt.Errorf("addonRepo.UpdateAddon: %w", err)
+		}
+	}
+
+	// 4. Commit transaksi
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("addonRepo.UpdateAddon.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAddon performs a soft delete by setting is_active to false (0).
+func (r *addonRepository) DeleteAddon(ctx context.Context, id int64) error {
+
+	res, err := r.db.ExecContext(ctx, "UPDATE service_addons SET is_active = 0 WHERE id = ? AND is_active = 1", id)
+	if err != nil {
+		return fmt.Errorf("addonRepo.DeleteAddon.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("addonRepo.DeleteAddon.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+// replaceAddonLinks menghapus semua relasi lama lalu menyimpan relasi baru di dalam transaksi yang sama.
+func replaceAddonLinks(ctx context.Context, tx *sql.Tx, addonID int64, serviceIDs []int64) error {
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM service_addon_links WHERE addon_id = ?", addonID); err != nil {
+		return fmt.Errorf("replaceAddonLinks.Delete: %w", err)
+	}
+
+	for _, serviceID := range serviceIDs {
+		if _, err := tx.ExecContext(ctx, "INSERT IGNORE INTO service_addon_links (addon_id, service_id) VALUES (?, ?)", addonID, serviceID); err != nil {
+			return fmt.Errorf("replaceAddonLinks.Insert: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func scanAddon(row rowScanner) (*models.ServiceAddon, error) {
+	var addon models.ServiceAddon
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var maxDurationNull sql.NullInt64
+	var updatedAtNull sql.NullTime
+
+	err := row.Scan(
+		&addon.ID, &addon.Code, &addon.AddonName, &addon.ChargeType, &addon.ChargeValue, &addon.ChargeBasis,
+		&maxDurationNull, &addon.IsActive, &addon.CreatedAt, &updatedAtNull,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxDurationNull.Valid {
+		hours := int(maxDurationNull.Int64)
+		addon.MaxDurationHours = &hours
+	}
+	if updatedAtNull.Valid {
+		addon.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &addon, nil
+}
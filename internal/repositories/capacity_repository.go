@@ -0,0 +1,461 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// CapacityRepository mendefinisikan operasi database untuk model kapasitas workshop:
+// mesin, jam operasional, hari libur, dan antrean pesanan per outlet.
+type CapacityRepository interface {
+
+	// Machines (dibatasi outlet aktif di context)
+	InsertMachine(ctx context.Context, machine *models.Machine) error
+	FindMachines(ctx context.Context, status string) ([]models.Machine, error)
+	FindMachineByID(ctx context.Context, id int64) (*models.Machine, error)
+	FindMachineByName(ctx context.Context, outletID int64, machineName string) (*models.Machine, error)
+	UpdateMachine(ctx context.Context, machine *models.Machine) error
+	DeleteMachine(ctx context.Context, id int64) error
+	UpdateMaintenance(ctx context.Context, machine *models.Machine) error
+	FindActiveMachinesByOutlet(ctx context.Context, outletID int64) ([]models.Machine, error)
+
+	// Operating hours
+	FindOperatingHours(ctx context.Context, outletID int64) ([]models.OperatingHour, error)
+	ReplaceOperatingHours(ctx context.Context, outletID int64, hours []models.OperatingHour) error
+
+	// Holidays
+	InsertHoliday(ctx context.Context, holiday *models.OutletHoliday) error
+	FindHolidays(ctx context.Context, startDate, endDate string) ([]models.OutletHoliday, error)
+	FindHolidayByID(ctx context.Context, id int64) (*models.OutletHoliday, error)
+	FindHolidayDates(ctx context.Context, outletID int64, from time.Time) ([]time.Time, error)
+	DeleteHoliday(ctx context.Context, id int64) error
+
+	// FindBacklog menjumlahkan beban pesanan 'pending' & 'in-progress' di satu outlet.
+	// Item kg memakai beratnya, item pcs memakai jumlah x pieceLoadKg.
+	FindBacklog(ctx context.Context, outletID int64, pieceLoadKg float64) (*models.WorkshopBacklog, error)
+}
+
+// capacityRepository is the concrete implementation using sql.DB.
+type capacityRepository struct {
+	db *sql.DB
+}
+
+// NewCapacityRepository creates a new instance of CapacityRepository.
+func NewCapacityRepository(db *sql.DB) CapacityRepository {
+	return &capacityRepository{db: db}
+}
+
+// --- IMPLEMENTATION: MACHINES ---
+
+const machineColumns = `m.id, m.outlet_id, m.machine_name, m.machine_type, m.capacity_kg, m.cycle_minutes,
+	COALESCE(m.is_active, 1), m.created_at, m.updated_at, m.under_maintenance, m.maintenance_note, m.maintenance_since`
+
+// InsertMachine creates a new machine at an outlet.
+func (r *capacityRepository) InsertMachine(ctx context.Context, machine *models.Machine) error {
+
+	query := `INSERT INTO machines (outlet_id, machine_name, machine_type, capacity_kg, cycle_minutes, is_active, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query,
+		machine.OutletID,
+		machine.MachineName,
+		machine.MachineType,
+		machine.CapacityKg,
+		machine.CycleMinutes,
+		machine.IsActive,
+		machine.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("capacityRepo.InsertMachine.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("capacityRepo.InsertMachine.LastInsertId: %w", err)
+	}
+
+	machine.ID = id
+	return nil
+}
+
+// FindMachines retrieves the machines of the active outlet (every outlet in consolidated mode).
+func (r *capacityRepository) FindMachines(ctx context.Context, status string) ([]models.Machine, error) {
+
+	// 1. Terapkan filter status aktif/non-aktif & outlet aktif
+	whereClause := "WHERE 1=1"
+	if status == "1" {
+		whereClause += " AND COALESCE(m.is_active, 1) = 1"
+	} else if status == "0" {
+		whereClause += " AND m.is_active = 0"
+	}
+	scope, scopeArgs := outletFilter(ctx, "m.outlet_id")
+
+	// 2. Eksekusi query
+	query := "SELECT " + machineColumns + " FROM machines m " + whereClause + scope + " ORDER BY m.outlet_id ASC, m.machine_type ASC, m.machine_name ASC"
+	return r.queryMachines(ctx, "FindMachines", query, scopeArgs...)
+}
+
+// FindMachineByID retrieves a machine of the active outlet.
+func (r *capacityRepository) FindMachineByID(ctx context.Context, id int64) (*models.Machine, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "m.outlet_id")
+	query := "SELECT " + machineColumns + " FROM machines m WHERE m.id = ?" + scope
+
+	machine, err := scanMachine(r.db.QueryRowContext(ctx, query, append([]interface{}{id}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("capacityRepo.FindMachineByID: %w", err)
+	}
+
+	return machine, nil
+}
+
+// FindMachineByName retrieves a machine by its name within one outlet (used for duplicate checks).
+func (r *capacityRepository) FindMachineByName(ctx context.Context, outletID int64, machineName string) (*models.Machine, error) {
+
+	query := "SELECT " + machineColumns + " FROM machines m WHERE m.outlet_id = ? AND m.machine_name = ?"
+
+	machine, err := scanMachine(r.db.QueryRowContext(ctx, query, outletID, machineName))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("capacityRepo.FindMachineByName: %w", err)
+	}
+
+	return machine, nil
+}
+
+// UpdateMachine updates the master data of a machine.
+func (r *capacityRepository) UpdateMachine(ctx context.Context, machine *models.Machine) error {
+
+	query := `UPDATE machines SET machine_name = ?, machine_type = ?, capacity_kg = ?, cycle_minutes = ?, is_active = ?, updated_at = ?
+		WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query,
+		machine.MachineName, machine.MachineType, machine.CapacityKg, machine.CycleMinutes, machine.IsActive, machine.UpdatedAt, machine.ID,
+	); err != nil {
+		return fmt.Errorf("capacityRepo.UpdateMachine.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMachine performs a soft delete; an inactive machine no longer counts towards capacity.
+func (r *capacityRepository) DeleteMachine(ctx context.Context, id int64) error {
+
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	query := "UPDATE machines SET is_active = 0 WHERE id = ? AND COALESCE(is_active, 1) = 1" + scope
+
+	res, err := r.db.ExecContext(ctx, query, append([]interface{}{id}, scopeArgs...)...)
+	if err != nil {
+		return fmt.Errorf("capacityRepo.DeleteMachine.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("capacityRepo.DeleteMachine.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateMaintenance flags or clears the maintenance status of a machine.
+func (r *capacityRepository) UpdateMaintenance(ctx context.Context, machine *models.Machine) error {
+
+	query := `UPDATE machines SET under_maintenance = ?, maintenance_note = ?, maintenance_since = ?, updated_at = ? WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query,
+		machine.UnderMaintenance, machine.MaintenanceNote, machine.MaintenanceSince, machine.UpdatedAt, machine.ID,
+	); err != nil {
+		return fmt.Errorf("capacityRepo.UpdateMaintenance.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// FindActiveMachinesByOutlet retrieves the usable machines of one outlet (input of the ETA calculator).
+// Mesin yang sedang maintenance tidak dihitung sebagai kapasitas.
+func (r *capacityRepository) FindActiveMachinesByOutlet(ctx context.Context, outletID int64) ([]models.Machine, error) {
+
+	query := "SELECT " + machineColumns + " FROM machines m WHERE m.outlet_id = ? AND COALESCE(m.is_active, 1) = 1 AND m.under_maintenance = 0 ORDER BY m.id ASC"
+	return r.queryMachines(ctx, "FindActiveMachinesByOutlet", query, outletID)
+}
+
+func (r *capacityRepository) queryMachines(ctx context.Context, method, query string, args ...interface{}) ([]models.Machine, error) {
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("capacityRepo.%s.Query: %w", method, err)
+	}
+	defer rows.Close()
+
+	machines := []models.Machine{}
+	for rows.Next() {
+		machine, err := scanMachine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("capacityRepo.%s.Scan: %w", method, err)
+		}
+		machines = append(machines, *machine)
+	}
+
+	return machines, rows.Err()
+}
+
+// --- IMPLEMENTATION: OPERATING HOURS ---
+
+// FindOperatingHours retrieves the weekly opening hours of an outlet, ordered Sunday to Saturday.
+func (r *capacityRepository) FindOperatingHours(ctx context.Context, outletID int64) ([]models.OperatingHour, error) {
+
+	query := `
+		SELECT outlet_id, weekday, TIME_FORMAT(open_time, '%H:%i:%s'), TIME_FORMAT(close_time, '%H:%i:%s'), is_closed
+		FROM outlet_operating_hours WHERE outlet_id = ? ORDER BY weekday ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, outletID)
+	if err != nil {
+		return nil, fmt.Errorf("capacityRepo.FindOperatingHours.Query: %w", err)
+	}
+	defer rows.Close()
+
+	hours := []models.OperatingHour{}
+	for rows.Next() {
+		var h models.OperatingHour
+		if err := rows.Scan(&h.OutletID, &h.Weekday, &h.OpenTime, &h.CloseTime, &h.IsClosed); err != nil {
+			return nil, fmt.Errorf("capacityRepo.FindOperatingHours.Scan: %w", err)
+		}
+		hours = append(hours, h)
+	}
+
+	return hours, rows.Err()
+}
+
+// ReplaceOperatingHours replaces the whole weekly schedule of an outlet (empty list = open 24 hours).
+func (r *capacityRepository) ReplaceOperatingHours(ctx context.Context, outletID int64, hours []models.OperatingHour) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("capacityRepo.ReplaceOperatingHours.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 1. Hapus jadwal lama
+	if _, err := tx.ExecContext(ctx, "DELETE FROM outlet_operating_hours WHERE outlet_id = ?", outletID); err != nil {
+		return fmt.Errorf("capacityRepo.ReplaceOperatingHours.Delete: %w", err)
+	}
+
+	// 2. Simpan jadwal baru
+	for _, h := range hours {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO outlet_operating_hours (outlet_id, weekday, open_time, close_time, is_closed) VALUES (?, ?, ?, ?, ?)",
+			outletID, h.Weekday, h.OpenTime, h.CloseTime, h.IsClosed,
+		); err != nil {
+			return fmt.Errorf("capacityRepo.ReplaceOperatingHours.Insert: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("capacityRepo.ReplaceOperatingHours.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// --- IMPLEMENTATION: HOLIDAYS ---
+
+// InsertHoliday creates a new holiday (outlet_id NULL = every outlet).
+func (r *capacityRepository) InsertHoliday(ctx context.Context, holiday *models.OutletHoliday) error {
+
+	query := `INSERT INTO outlet_holidays (outlet_id, holiday_date, description, created_at) VALUES (?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query,
+		holiday.OutletID, // Pointer, aman jika nil
+		holiday.HolidayDate.Format("2006-01-02"),
+		holiday.Description,
+		holiday.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("capacityRepo.InsertHoliday.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("capacityRepo.InsertHoliday.LastInsertId: %w", err)
+	}
+
+	holiday.ID = id
+	return nil
+}
+
+// FindHolidays retrieves the holidays that apply to the active outlet within an optional date range.
+func (r *capacityRepository) FindHolidays(ctx context.Context, startDate, endDate string) ([]models.OutletHoliday, error) {
+
+	// 1. Libur nasional (outlet_id NULL) selalu ikut; libur khusus dibatasi outlet aktif
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	if id := outlet.FromContext(ctx); id != outlet.All {
+		whereClause += " AND (outlet_id IS NULL OR outlet_id = ?)"
+		args = append(args, id)
+	}
+	if startDate != "" {
+		whereClause += " AND holiday_date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		whereClause += " AND holiday_date <= ?"
+		args = append(args, endDate)
+	}
+
+	// 2. Eksekusi query
+	query := "SELECT id, outlet_id, holiday_date, description, created_at FROM outlet_holidays " + whereClause + " ORDER BY holiday_date ASC, id ASC"
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("capacityRepo.FindHolidays.Query: %w", err)
+	}
+	defer rows.Close()
+
+	holidays := []models.OutletHoliday{}
+	for rows.Next() {
+		holiday, err := scanHoliday(rows)
+		if err != nil {
+			return nil, fmt.Errorf("capacityRepo.FindHolidays.Scan: %w", err)
+		}
+		holidays = append(holidays, *holiday)
+	}
+
+	return holidays, rows.Err()
+}
+
+// FindHolidayByID retrieves a holiday visible to the active outlet.
+func (r *capacityRepository) FindHolidayByID(ctx context.Context, id int64) (*models.OutletHoliday, error) {
+
+	query := "SELECT id, outlet_id, holiday_date, description, created_at FROM outlet_holidays WHERE id = ?"
+	args := []interface{}{id}
+	if outletID := outlet.FromContext(ctx); outletID != outlet.All {
+		query += " AND (outlet_id IS NULL OR outlet_id = ?)"
+		args = append(args, outletID)
+	}
+
+	holiday, err := scanHoliday(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("capacityRepo.FindHolidayByID: %w", err)
+	}
+
+	return holiday, nil
+}
+
+// FindHolidayDates retrieves the holiday dates of one outlet (including nationwide ones) from a date onwards.
+func (r *capacityRepository) FindHolidayDates(ctx context.Context, outletID int64, from time.Time) ([]time.Time, error) {
+
+	query := `SELECT holiday_date FROM outlet_holidays WHERE (outlet_id IS NULL OR outlet_id = ?) AND holiday_date >= ? ORDER BY holiday_date ASC`
+	rows, err := r.db.QueryContext(ctx, query, outletID, from.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("capacityRepo.FindHolidayDates.Query: %w", err)
+	}
+	defer rows.Close()
+
+	dates := []time.Time{}
+	for rows.Next() {
+		var d time.Time
+		if err := rows.Scan(&d); err != nil {
+			return nil, fmt.Errorf("capacityRepo.FindHolidayDates.Scan: %w", err)
+		}
+		dates = append(dates, d)
+	}
+
+	return dates, rows.Err()
+}
+
+// DeleteHoliday removes a holiday permanently (it is only planning data).
+func (r *capacityRepository) DeleteHoliday(ctx context.Context, id int64) error {
+
+	res, err := r.db.ExecContext(ctx, "DELETE FROM outlet_holidays WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("capacityRepo.DeleteHoliday.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("capacityRepo.DeleteHoliday.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// --- IMPLEMENTATION: BACKLOG ---
+
+// FindBacklog sums the workload of every queued order at an outlet.
+func (r *capacityRepository) FindBacklog(ctx context.Context, outletID int64, pieceLoadKg float64) (*models.WorkshopBacklog, error) {
+
+	query := `
+		SELECT COUNT(DISTINCT o.id),
+			COALESCE(SUM(CASE WHEN s.unit = 'kg' THEN COALESCE(oi.weight_kg, oi.quantity, 0)
+				ELSE COALESCE(oi.quantity, 0) * ? END), 0)
+		FROM orders o
+		LEFT JOIN order_items oi ON oi.order_id = o.id
+		LEFT JOIN services s ON s.id = oi.service_id
+		WHERE o.outlet_id = ? AND o.status_internal IN (?, ?)`
+
+	var backlog models.WorkshopBacklog
+	if err := r.db.QueryRowContext(ctx, query,
+		pieceLoadKg, outletID, models.OrderStatusPending, models.OrderStatusInProgress,
+	).Scan(&backlog.OrderCount, &backlog.LoadKg); err != nil {
+		return nil, fmt.Errorf("capacityRepo.FindBacklog: %w", err)
+	}
+
+	return &backlog, nil
+}
+
+func scanMachine(row rowScanner) (*models.Machine, error) {
+	var m models.Machine
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var updatedAtNull, maintenanceSinceNull sql.NullTime
+	var maintenanceNoteNull sql.NullString
+
+	if err := row.Scan(
+		&m.ID, &m.OutletID, &m.MachineName, &m.MachineType, &m.CapacityKg, &m.CycleMinutes,
+		&m.IsActive, &m.CreatedAt, &updatedAtNull, &m.UnderMaintenance, &maintenanceNoteNull, &maintenanceSinceNull,
+	); err != nil {
+		return nil, err
+	}
+
+	if updatedAtNull.Valid {
+		m.UpdatedAt = &updatedAtNull.Time
+	}
+	m.MaintenanceNote = nullStringPtr(maintenanceNoteNull)
+	if maintenanceSinceNull.Valid {
+		m.MaintenanceSince = &maintenanceSinceNull.Time
+	}
+
+	return &m, nil
+}
+
+func scanHoliday(row rowScanner) (*models.OutletHoliday, error) {
+	var h models.OutletHoliday
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var outletIDNull sql.NullInt64
+
+	if err := row.Scan(&h.ID, &outletIDNull, &h.HolidayDate, &h.Description, &h.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if outletIDNull.Valid {
+		h.OutletID = &outletIDNull.Int64
+	}
+
+	return &h, nil
+}
@@ -6,8 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"laundry-backend/internal/models"
+	"laundry-backend/pkg/listquery"
 	"laundry-backend/pkg/response"
-	"strings"
+	"time"
 )
 
 // CategoryRepository defines the contract for service category-related database operations.
@@ -17,7 +18,8 @@ type CategoryRepository interface {
 	InsertCategory(ctx context.Context, category *models.ServiceCategory) error
 
 	// Read Operations
-	FindAll(ctx context.Context, limit, offset int, search, status, sortBy, sortOrder string) ([]models.ServiceCategory, int64, error)
+	FindAll(ctx context.Context, params listquery.Params) ([]models.ServiceCategory, *listquery.Result, error)
+	StreamAll(ctx context.Context, params listquery.Params, fn func(*models.ServiceCategory) error) error
 	FindByID(ctx context.Context, id int64) (*models.ServiceCategory, error)
 	FindByName(ctx context.Context, categoryName string) (*models.ServiceCategory, error)
 
@@ -25,7 +27,7 @@ type CategoryRepository interface {
 	UpdateCategory(ctx context.Context, category *models.ServiceCategory) error
 
 	// Delete Operations (Soft Delete)
-	DeleteCategory(ctx context.Context, id int64) error
+	DeleteCategory(ctx context.Context, id, version int64) error
 }
 
 // categoryRepository is the concrete implementation of CategoryRepository using sql.DB.
@@ -42,6 +44,11 @@ func NewCategoryRepository(db *sql.DB) CategoryRepository {
 
 // InsertCategory creates a new service category record in the database.
 func (r *categoryRepository) InsertCategory(ctx context.Context, category *models.ServiceCategory) error {
+	return insertCategory(ctx, r.db, category, "categoryRepo.InsertCategory")
+}
+
+// insertCategory dipakai InsertCategory maupun import massal (di dalam transaksi).
+func insertCategory(ctx context.Context, db execer, category *models.ServiceCategory, op string) error {
 
 	// 1. Persiapkan query SQL
 	query := `
@@ -50,7 +57,7 @@ func (r *categoryRepository) InsertCategory(ctx context.Context, category *model
 	`
 
 	// 2. Eksekusi query dengan context (Pilar O - Optimal Go)
-	res, err := r.db.ExecContext(ctx, query,
+	res, err := db.ExecContext(ctx, query,
 		category.CategoryName,
 		category.Description, // Pointer, aman jika nil
 		category.IsActive,
@@ -60,13 +67,13 @@ func (r *categoryRepository) InsertCategory(ctx context.Context, category *model
 
 	if err != nil {
 		// 3. Bungkus error agar mudah dilacak (Pilar E - Error Handling)
-		return fmt.Errorf("categoryRepo.InsertCategory.Exec: %w", err)
+		return fmt.Errorf("%s.Exec: %w", op, err)
 	}
 
 	// 4. Ambil ID yang baru saja di-generate oleh MySQL (Auto Increment)
 	id, err := res.LastInsertId()
 	if err != nil {
-		return fmt.Errorf("categoryRepo.InsertCategory.LastInsertId: %w", err)
+		return fmt.Errorf("%s.LastInsertId: %w", op, err)
 	}
 
 	// 5. Sematkan ID kembali ke struct pointer
@@ -74,94 +81,150 @@ func (r *categoryRepository) InsertCategory(ctx context.Context, category *model
 	return nil
 }
 
-// FindAll retrieves a list of service categories with pagination, filtering, and sorting support.
-func (r *categoryRepository) FindAll(ctx context.Context, limit, offset int, search, status, sortBy, sortOrder string) ([]models.ServiceCategory, int64, error) {
-
-	// 1. Inisialisasi query dasar menggunakan builder sederhana
-	whereClause := "WHERE 1=1"
-	var args []interface{}
-		
-	// 2. Terapkan filter pencarian nama kategori (LOWER untuk case-insensitive)
-	if search != "" {
-		whereClause += " AND LOWER(category_name) LIKE ?"
-		args = append(args, "%"+strings.ToLower(search)+"%")
-	}
+// categoryListSpec mendeklarasikan pencarian, filter, dan sorting yang diizinkan untuk GET /categories.
+var categoryListSpec = listquery.Spec{
+	Search: []string{"category_name"},
+	Filters: map[string]listquery.Filter{
+		"status": {Column: "is_active", Allowed: []string{"0", "1"}},
+	},
+	Sorts: map[string]string{
+		"category_name": "category_name",
+		"created_at":    "created_at",
+		"id":            "id",
+	},
+	DefaultSort:  "category_name",
+	DefaultOrder: listquery.OrderAsc,
+	IDColumn:     "id",
+}
 
-	// 3. Terapkan filter status aktif/non-aktif
-	if status != "" {
-		if status == "1" {
-			whereClause += " AND is_active = 1"
-		} else if status == "0" {
-			whereClause += " AND is_active = 0"
-		}
-	}
+// categoryListSelect adalah SELECT bersama untuk list & export kategori (tanpa WHERE/ORDER BY/LIMIT).
+const categoryListSelect = "SELECT id, category_name, description, is_active, created_at, updated_at FROM service_categories "
 
-	// 4. Hitung total baris keseluruhan (Count Query) untuk data Meta Pagination
-	var totalItems int64
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM service_categories %s", whereClause)
-	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalItems); err != nil {
-		return nil, 0, fmt.Errorf("categoryRepo.FindAll.Count: %w", err)
-	}
+// FindAll retrieves a list of service categories with pagination (offset or cursor), filtering, and sorting support.
+func (r *categoryRepository) FindAll(ctx context.Context, params listquery.Params) ([]models.ServiceCategory, *listquery.Result, error) {
 
-	// 5. Validasi kolom sorting (Kunci keamanan mencegah SQL Injection via ORDER BY)
-	validSortColumns := map[string]bool{
-		"category_name": true,
-		"created_at":    true,
-		"id":            true,
-	}
-	if !validSortColumns[sortBy] {
-		sortBy = "category_name" // Fallback ke default
+	// 1. Susun WHERE / ORDER BY / LIMIT dari spec (kolom sort sudah di-whitelist)
+	q, err := categoryListSpec.Build(params)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// 6. Validasi arah sorting (ASC/DESC)
-	sortOrder = strings.ToUpper(sortOrder)
-	if sortOrder != "ASC" && sortOrder != "DESC" {
-		sortOrder = "ASC" // Fallback ke default
+	// 2. Hitung total baris (opsional) untuk data Meta Pagination
+	var totalItems *int64
+	if q.Count {
+		where, args := q.Where()
+		var total int64
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM service_categories "+where, args...).Scan(&total); err != nil {
+			return nil, nil, fmt.Errorf("categoryRepo.FindAll.Count: %w", err)
+		}
+		totalItems = &total
 	}
 
-	// 7. Rangkai query utama beserta LIMIT dan OFFSET
-	query := fmt.Sprintf("SELECT id, category_name, description, is_active, created_at, updated_at FROM service_categories %s ORDER BY %s %s LIMIT ? OFFSET ?", whereClause, sortBy, sortOrder)
-	args = append(args, limit, offset)
+	// 3. Rangkai & eksekusi query utama
+	tail, args := q.Tail()
+	query := categoryListSelect + tail
 
-	// 8. Eksekusi query utama
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("categoryRepo.FindAll.Query: %w", err)
+		return nil, nil, fmt.Errorf("categoryRepo.FindAll.Query: %w", err)
 	}
 	defer rows.Close()
 
-	// 9. Parsing (Mapping) hasil query ke dalam slice struct
+	// 4. Parsing (Mapping) hasil query ke dalam slice struct
 	var categories []models.ServiceCategory
 	for rows.Next() {
-		var c models.ServiceCategory
+		c, err := scanCategoryListRow(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("categoryRepo.FindAll.Scan: %w", err)
+		}
+		categories = append(categories, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("categoryRepo.FindAll.Rows: %w", err)
+	}
 
-		// 10. Siapkan wadah perantara untuk menangkap NULL dari database MySQL
-		var descriptionNull sql.NullString
-		var updatedAtNull sql.NullTime
+	// 5. Buang baris ekstra & siapkan cursor halaman berikutnya
+	keep, result := q.Paginate(len(categories), totalItems, func(i int) (interface{}, int64) {
+		return categorySortValue(categories[i], q.SortKey()), categories[i].ID
+	})
 
-		if err := rows.Scan(&c.ID, &c.CategoryName, &descriptionNull, &c.IsActive, &c.CreatedAt, &updatedAtNull); err != nil {
-			return nil, 0, fmt.Errorf("categoryRepo.FindAll.Scan: %w", err)
-		}
+	return categories[:keep], result, nil
+}
 
-		// 11. Pindahkan isi dari wadah perantara ke pointer struct Model jika datanya valid (bukan NULL)
-		if descriptionNull.Valid {
-			c.Description = &descriptionNull.String
+// StreamAll membaca semua kategori yang cocok dengan filter & sorting list (tanpa pagination) baris per baris
+// dan memanggil fn untuk setiap baris.
+func (r *categoryRepository) StreamAll(ctx context.Context, params listquery.Params, fn func(*models.ServiceCategory) error) error {
+
+	// 1. Validasi parameter dengan spec yang sama seperti GET /categories
+	q, err := categoryListSpec.Build(params)
+	if err != nil {
+		return err
+	}
+
+	// 2. Eksekusi query tanpa LIMIT
+	tail, args := q.Unpaged()
+	rows, err := r.db.QueryContext(ctx, categoryListSelect+tail, args...)
+	if err != nil {
+		return fmt.Errorf("categoryRepo.StreamAll.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 3. Kirim ke pemanggil satu per satu
+	for rows.Next() {
+		c, err := scanCategoryListRow(rows)
+		if err != nil {
+			return fmt.Errorf("categoryRepo.StreamAll.Scan: %w", err)
 		}
-		if updatedAtNull.Valid {
-			c.UpdatedAt = &updatedAtNull.Time
+		if err := fn(c); err != nil {
+			return err
 		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("categoryRepo.StreamAll.Rows: %w", err)
+	}
+
+	return nil
+}
+
+// scanCategoryListRow memetakan satu baris categoryListSelect ke model.
+func scanCategoryListRow(rows *sql.Rows) (*models.ServiceCategory, error) {
+	var c models.ServiceCategory
+
+	// Siapkan wadah perantara untuk menangkap NULL dari database MySQL
+	var descriptionNull sql.NullString
+	var updatedAtNull sql.NullTime
 
-		categories = append(categories, c)
+	if err := rows.Scan(&c.ID, &c.CategoryName, &descriptionNull, &c.IsActive, &c.CreatedAt, &updatedAtNull); err != nil {
+		return nil, err
 	}
 
-	return categories, totalItems, nil
+	if descriptionNull.Valid {
+		c.Description = &descriptionNull.String
+	}
+	if updatedAtNull.Valid {
+		c.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &c, nil
+}
+
+// categorySortValue mengambil nilai kolom sort dari baris untuk disimpan di cursor.
+func categorySortValue(c models.ServiceCategory, sortKey string) interface{} {
+	switch sortKey {
+	case "created_at":
+		return c.CreatedAt
+	case "id":
+		return c.ID
+	default:
+		return c.CategoryName
+	}
 }
 
 // FindByID retrieves a single service category's detailed information by ID.
 func (r *categoryRepository) FindByID(ctx context.Context, id int64) (*models.ServiceCategory, error) {
 
 	// 1. Persiapkan query
-	query := `SELECT id, category_name, description, is_active, created_at, updated_at FROM service_categories WHERE id = ?`
+	query := `SELECT id, category_name, description, is_active, created_at, updated_at, version FROM service_categories WHERE id = ?`
 
 	var c models.ServiceCategory
 	var descriptionNull sql.NullString
@@ -169,7 +232,7 @@ func (r *categoryRepository) FindByID(ctx context.Context, id int64) (*models.Se
 
 	// 2. Eksekusi query dan mapping (Scan)
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&c.ID, &c.CategoryName, &descriptionNull, &c.IsActive, &c.CreatedAt, &updatedAtNull,
+		&c.ID, &c.CategoryName, &descriptionNull, &c.IsActive, &c.CreatedAt, &updatedAtNull, &c.Version,
 	)
 
 	// 3. Tangani error, kembalikan Sentinel Error standar VIP jika data kosong
@@ -226,68 +289,39 @@ func (r *categoryRepository) FindByName(ctx context.Context, categoryName string
 }
 
 // UpdateCategory updates an existing service category record.
+// category.Version wajib berisi versi yang dibaca sebelumnya; jika baris sudah diubah request lain
+// hasilnya response.ErrPreconditionFailed.
 func (r *categoryRepository) UpdateCategory(ctx context.Context, category *models.ServiceCategory) error {
 
-	// 1. Persiapkan query UPDATE
-	query := `UPDATE service_categories 
-        SET category_name = ?, description = ?, is_active = ?, updated_at = ? 
-        WHERE id = ?`
-
-	// 2. Eksekusi query
-	res, err := r.db.ExecContext(ctx, query,
+	// 1. Eksekusi UPDATE berversi (Optimistic Lock)
+	// Kolom version selalu naik, jadi data yang sama persis tetap terhitung berubah (tidak lagi dianggap Not Found)
+	version, err := updateVersioned(ctx, r.db, "service_categories",
+		"category_name = ?, description = ?, is_active = ?, updated_at = ?",
+		category.ID, category.Version,
 		category.CategoryName,
 		category.Description,
 		category.IsActive,
 		category.UpdatedAt,
-		category.ID,
 	)
-
-	// 3. Tangani error eksekusi dan bungkus dengan rapi
-	if err != nil {
-		return fmt.Errorf("categoryRepo.UpdateCategory.Exec: %w", err)
-	}
-
-	// --- [CEK TAMBAHAN: SATPAM LOGIKA] ---
-	// Kita cek apakah ada baris yang benar-benar berubah di database
-	rows, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("categoryRepo.UpdateCategory.RowsAffected: %w", err)
-	}
-
-	if rows == 0 {
-		// Jika 0, berarti ID tidak ditemukan atau data yang dikirim sama persis dengan yang lama
-		return response.ErrNotFound
+		return fmt.Errorf("categoryRepo.UpdateCategory: %w", err)
 	}
 
+	// 2. Sematkan versi baru ke struct pointer
+	category.Version = version
 	return nil
 }
 
 // DeleteCategory performs a soft delete by setting is_active to false (0).
-func (r *categoryRepository) DeleteCategory(ctx context.Context, id int64) error {
+// Ikut menaikkan version agar ETag lama tidak bisa dipakai lagi setelah kategori dinonaktifkan.
+func (r *categoryRepository) DeleteCategory(ctx context.Context, id, version int64) error {
 
-	// 1. Persiapkan query soft-delete
-	query := "UPDATE service_categories SET is_active = 0 WHERE id = ? AND is_active = 1"
-
-	// 2. EKSEKUSI (Jalankan)
-	// Kita tangkap hasilnya di variabel 'res'
-	res, err := r.db.ExecContext(ctx, query, id)
-
-	// 3. EVALUASI (Cek Error Teknis)
+	// 1. Soft delete berversi (optimistic lock sama seperti UpdateCategory)
+	_, err := updateVersioned(ctx, r.db, "service_categories", "is_active = 0, updated_at = ?", id, version, time.Now())
 	if err != nil {
-		return fmt.Errorf("categoryRepo.DeleteCategory.Exec: %w", err)
-	}
-
-	// --- [SATPAM TAMBAHAN: RowsAffected] ---
-	rows, err := res.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("categoryRepo.DeleteCategory.RowsAffected: %w", err)
-	}
-
-	if rows == 0 {
-		// Jika 0, berarti ID tidak ditemukan atau kategori sudah non-aktif sebelumnya
-		return response.ErrNotFound
+		return fmt.Errorf("categoryRepo.DeleteCategory: %w", err)
 	}
 
-	// 4. KONKLUSI (Kembalikan)
+	// 2. KONKLUSI (Kembalikan)
 	return nil
 }
@@ -0,0 +1,547 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"strings"
+)
+
+// ComplaintRepository mendefinisikan operasi database untuk komplain pelanggan, foto bukti, riwayat status,
+// dan pesanan cuci ulang (rework). Semua query dibatasi outlet aktif di context.
+type ComplaintRepository interface {
+
+	// Create Operations
+	InsertComplaint(ctx context.Context, complaint *models.Complaint, photos []models.ComplaintPhoto) error
+	InsertPhotos(ctx context.Context, complaintID int64, photos []models.ComplaintPhoto) error
+
+	// Read Operations
+	FindComplaintOrder(ctx context.Context, orderID int64) (*models.ComplaintOrder, error)
+	FindComplaints(ctx context.Context, params listquery.Params) ([]models.Complaint, *listquery.Result, error)
+	FindComplaintByID(ctx context.Context, id int64) (*models.Complaint, error)
+
+	// Transaction Operations (dipanggil di dalam transaksi milik service)
+	LockComplaintTx(ctx context.Context, tx *sql.Tx, id int64) (*models.Complaint, error)
+	UpdateStatusTx(ctx context.Context, tx *sql.Tx, complaint *models.Complaint, history *models.ComplaintStatusHistory) error
+	InsertReworkOrderTx(ctx context.Context, tx *sql.Tx, rework *models.ReworkOrder) error
+}
+
+// complaintRepository is the concrete implementation using sql.DB.
+type complaintRepository struct {
+	db *sql.DB
+}
+
+// NewComplaintRepository creates a new instance of ComplaintRepository.
+func NewComplaintRepository(db *sql.DB) ComplaintRepository {
+	return &complaintRepository{db: db}
+}
+
+// --- IMPLEMENTATION: CREATE ---
+
+// InsertComplaint stores a new complaint with its photos and the first history row in one transaction.
+func (r *complaintRepository) InsertComplaint(ctx context.Context, complaint *models.Complaint, photos []models.ComplaintPhoto) error {
+
+	// 1. Mulai transaksi (komplain, foto, & riwayat harus tersimpan bersamaan)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("complaintRepo.InsertComplaint.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Simpan data komplain
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO complaints (outlet_id, order_id, order_item_id, complaint_type, description, complaint_status, reported_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		complaint.OutletID,
+		complaint.OrderID,
+		complaint.OrderItemID, // Pointer, aman jika nil
+		complaint.ComplaintType,
+		complaint.Description,
+		complaint.ComplaintStatus,
+		complaint.ReportedBy,
+		complaint.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("complaintRepo.InsertComplaint.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("complaintRepo.InsertComplaint.LastInsertId: %w", err)
+	}
+
+	// 3. Simpan foto bukti & riwayat pertama
+	if err := insertComplaintPhotos(ctx, tx, id, photos); err != nil {
+		return fmt.Errorf("complaintRepo.InsertComplaint: %w", err)
+	}
+	if err := insertComplaintHistory(ctx, tx, &models.ComplaintStatusHistory{
+		ComplaintID: id,
+		NewStatus:   complaint.ComplaintStatus,
+		ActorID:     &complaint.ReportedBy,
+		CreatedAt:   complaint.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("complaintRepo.InsertComplaint: %w", err)
+	}
+
+	// 4. Commit transaksi
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("complaintRepo.InsertComplaint.Commit: %w", err)
+	}
+
+	complaint.ID = id
+	return nil
+}
+
+// InsertPhotos appends evidence photos to a complaint of the active outlet.
+func (r *complaintRepository) InsertPhotos(ctx context.Context, complaintID int64, photos []models.ComplaintPhoto) error {
+
+	// 1. Pastikan komplain milik outlet aktif
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	var id int64
+	err := r.db.QueryRowContext(ctx, "SELECT id FROM complaints WHERE id = ?"+scope, append([]interface{}{complaintID}, scopeArgs...)...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return response.ErrNotFound
+		}
+		return fmt.Errorf("complaintRepo.InsertPhotos.Find: %w", err)
+	}
+
+	// 2. Simpan foto
+	if err := insertComplaintPhotos(ctx, r.db, complaintID, photos); err != nil {
+		return fmt.Errorf("complaintRepo.InsertPhotos: %w", err)
+	}
+
+	return nil
+}
+
+// --- IMPLEMENTATION: READ ---
+
+// FindComplaintOrder retrieves the order a complaint is filed against, with the IDs of its items.
+func (r *complaintRepository) FindComplaintOrder(ctx context.Context, orderID int64) (*models.ComplaintOrder, error) {
+
+	// 1. Ambil pesanan di outlet aktif
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	var order models.ComplaintOrder
+	var customerNull sql.NullInt64
+	var statusNull sql.NullString
+
+	err := r.db.QueryRowContext(ctx, "SELECT id, outlet_id, invoice_number, customer_id, status_internal FROM orders WHERE id = ?"+scope,
+		append([]interface{}{orderID}, scopeArgs...)...,
+	).Scan(&order.ID, &order.OutletID, &order.InvoiceNumber, &customerNull, &statusNull)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("complaintRepo.FindComplaintOrder: %w", err)
+	}
+	order.CustomerID = nullInt64Ptr(customerNull)
+	order.StatusInternal = statusNull.String
+
+	// 2. Ambil ID item pesanan
+	rows, err := r.db.QueryContext(ctx, "SELECT id FROM order_items WHERE order_id = ? ORDER BY id ASC", order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("complaintRepo.FindComplaintOrder.Items: %w", err)
+	}
+	defer rows.Close()
+
+	order.ItemIDs = []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("complaintRepo.FindComplaintOrder.ItemScan: %w", err)
+		}
+		order.ItemIDs = append(order.ItemIDs, id)
+	}
+
+	return &order, rows.Err()
+}
+
+// complaintListSpec mendeklarasikan pencarian, filter, dan sorting yang diizinkan untuk GET /complaints.
+var complaintListSpec = listquery.Spec{
+	Search: []string{"o.invoice_number", "c.description"},
+	Filters: map[string]listquery.Filter{
+		"outlet_id": {Column: "c.outlet_id"},
+		"order_id":  {Column: "c.order_id"},
+		"complaint_type": {Column: "c.complaint_type", Allowed: []string{
+			models.ComplaintDamage, models.ComplaintMissing, models.ComplaintRework, models.ComplaintOther,
+		}},
+		"complaint_status": {Column: "c.complaint_status", Allowed: []string{
+			models.ComplaintOpen, models.ComplaintInvestigating, models.ComplaintResolved, models.ComplaintRejected,
+		}},
+		"resolution_type": {Column: "c.resolution_type", Allowed: []string{
+			models.ResolutionRework, models.ResolutionCompensation, models.ResolutionVoucher,
+		}},
+		"reported_by": {Column: "c.reported_by"},
+		"start_date":  {Expr: "c.created_at >= ?"},
+		"end_date":    {Expr: "c.created_at < DATE_ADD(?, INTERVAL 1 DAY)"},
+	},
+	Sorts: map[string]string{
+		"created_at": "c.created_at",
+		"id":         "c.id",
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: listquery.OrderDesc,
+	IDColumn:     "c.id",
+}
+
+const complaintSelect = `
+	SELECT c.id, c.outlet_id, c.order_id, o.invoice_number, o.customer_id, COALESCE(o.customer_name, cu.full_name),
+		c.order_item_id, s.service_name, c.complaint_type, c.description, c.complaint_status,
+		c.resolution_type, c.resolution_note, c.rework_order_id, ro.invoice_number,
+		c.compensation_amount, c.compensation_method, c.expense_id, c.wallet_entry_id, c.voucher_promotion_id, p.code,
+		c.reported_by, COALESCE(ur.full_name, '-'), c.resolved_by, uv.full_name, c.resolved_at,
+		(SELECT COUNT(*) FROM complaint_photos cp WHERE cp.complaint_id = c.id), c.created_at, c.updated_at
+	FROM complaints c
+	JOIN orders o ON o.id = c.order_id
+	LEFT JOIN customers cu ON cu.id = o.customer_id
+	LEFT JOIN order_items oi ON oi.id = c.order_item_id
+	LEFT JOIN services s ON s.id = oi.service_id
+	LEFT JOIN orders ro ON ro.id = c.rework_order_id
+	LEFT JOIN promotions p ON p.id = c.voucher_promotion_id
+	LEFT JOIN users ur ON ur.id = c.reported_by
+	LEFT JOIN users uv ON uv.id = c.resolved_by `
+
+// FindComplaints retrieves complaints of the active outlet with pagination (offset or cursor), filtering, and sorting support.
+func (r *complaintRepository) FindComplaints(ctx context.Context, params listquery.Params) ([]models.Complaint, *listquery.Result, error) {
+
+	// 1. Susun WHERE / ORDER BY / LIMIT dari spec (outlet aktif dipaksa lewat filter outlet_id)
+	q, err := complaintListSpec.Build(outletListParams(ctx, params))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Hitung total baris (opsional) untuk data Meta Pagination
+	var totalItems *int64
+	if q.Count {
+		where, args := q.Where()
+		var total int64
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM complaints c JOIN orders o ON o.id = c.order_id "+where, args...).Scan(&total); err != nil {
+			return nil, nil, fmt.Errorf("complaintRepo.FindComplaints.Count: %w", err)
+		}
+		totalItems = &total
+	}
+
+	// 3. Eksekusi query utama
+	tail, args := q.Tail()
+	rows, err := r.db.QueryContext(ctx, complaintSelect+tail, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("complaintRepo.FindComplaints.Query: %w", err)
+	}
+	defer rows.Close()
+
+	complaints := []models.Complaint{}
+	for rows.Next() {
+		c, err := scanComplaint(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("complaintRepo.FindComplaints.Scan: %w", err)
+		}
+		complaints = append(complaints, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	// 4. Buang baris ekstra & siapkan cursor halaman berikutnya
+	keep, result := q.Paginate(len(complaints), totalItems, func(i int) (interface{}, int64) {
+		if q.SortKey() == "id" {
+			return complaints[i].ID, complaints[i].ID
+		}
+		return complaints[i].CreatedAt, complaints[i].ID
+	})
+
+	return complaints[:keep], result, nil
+}
+
+// FindComplaintByID retrieves a complaint of the active outlet together with its photos and status history.
+func (r *complaintRepository) FindComplaintByID(ctx context.Context, id int64) (*models.Complaint, error) {
+
+	// 1. Ambil data komplain
+	scope, scopeArgs := outletFilter(ctx, "c.outlet_id")
+	complaint, err := scanComplaint(r.db.QueryRowContext(ctx, complaintSelect+"WHERE c.id = ?"+scope, append([]interface{}{id}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("complaintRepo.FindComplaintByID: %w", err)
+	}
+
+	// 2. Ambil foto bukti
+	photoRows, err := r.db.QueryContext(ctx, `
+		SELECT id, complaint_id, photo_url, caption, uploaded_by, created_at
+		FROM complaint_photos WHERE complaint_id = ? ORDER BY id ASC`, complaint.ID)
+	if err != nil {
+		return nil, fmt.Errorf("complaintRepo.FindComplaintByID.Photos: %w", err)
+	}
+	defer photoRows.Close()
+
+	complaint.Photos = []models.ComplaintPhoto{}
+	for photoRows.Next() {
+		var p models.ComplaintPhoto
+		var captionNull sql.NullString
+		if err := photoRows.Scan(&p.ID, &p.ComplaintID, &p.PhotoURL, &captionNull, &p.UploadedBy, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("complaintRepo.FindComplaintByID.PhotoScan: %w", err)
+		}
+		p.Caption = nullStringPtr(captionNull)
+		complaint.Photos = append(complaint.Photos, p)
+	}
+	if err := photoRows.Err(); err != nil {
+		return nil, err
+	}
+
+	// 3. Ambil riwayat status
+	historyRows, err := r.db.QueryContext(ctx, `
+		SELECT h.id, h.complaint_id, h.previous_status, h.new_status, h.actor_id, u.full_name, h.notes, h.created_at
+		FROM complaint_status_history h
+		LEFT JOIN users u ON u.id = h.actor_id
+		WHERE h.complaint_id = ?
+		ORDER BY h.id ASC`, complaint.ID)
+	if err != nil {
+		return nil, fmt.Errorf("complaintRepo.FindComplaintByID.History: %w", err)
+	}
+	defer historyRows.Close()
+
+	complaint.History = []models.ComplaintStatusHistory{}
+	for historyRows.Next() {
+		var h models.ComplaintStatusHistory
+
+		// Wadah perantara untuk menangkap NULL dari database
+		var previousNull, actorNameNull, notesNull sql.NullString
+		var actorNull sql.NullInt64
+
+		if err := historyRows.Scan(&h.ID, &h.ComplaintID, &previousNull, &h.NewStatus, &actorNull, &actorNameNull, &notesNull, &h.CreatedAt); err != nil {
+			return nil, fmt.Errorf("complaintRepo.FindComplaintByID.HistoryScan: %w", err)
+		}
+		h.PreviousStatus = nullStringPtr(previousNull)
+		h.ActorName = nullStringPtr(actorNameNull)
+		h.Notes = nullStringPtr(notesNull)
+		h.ActorID = nullInt64Ptr(actorNull)
+		complaint.History = append(complaint.History, h)
+	}
+
+	return complaint, historyRows.Err()
+}
+
+// --- IMPLEMENTATION: TRANSACTION ---
+
+// LockComplaintTx retrieves a complaint of the active outlet and locks it until the transaction ends.
+func (r *complaintRepository) LockComplaintTx(ctx context.Context, tx *sql.Tx, id int64) (*models.Complaint, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "c.outlet_id")
+	query := complaintSelect + "WHERE c.id = ?" + scope + " FOR UPDATE OF c"
+
+	complaint, err := scanComplaint(tx.QueryRowContext(ctx, query, append([]interface{}{id}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("complaintRepo.LockComplaintTx: %w", err)
+	}
+
+	return complaint, nil
+}
+
+// UpdateStatusTx writes the status and resolution columns of a complaint and appends the matching history row.
+func (r *complaintRepository) UpdateStatusTx(ctx context.Context, tx *sql.Tx, complaint *models.Complaint, history *models.ComplaintStatusHistory) error {
+
+	// 1. Update status & data penyelesaian
+	_, err := tx.ExecContext(ctx, `
+		UPDATE complaints
+		SET complaint_status = ?, resolution_type = ?, resolution_note = ?, rework_order_id = ?, compensation_amount = ?,
+			compensation_method = ?, expense_id = ?, wallet_entry_id = ?, voucher_promotion_id = ?, resolved_by = ?, resolved_at = ?
+		WHERE id = ?`,
+		complaint.ComplaintStatus,
+		complaint.ResolutionType,
+		complaint.ResolutionNote,
+		complaint.ReworkOrderID,
+		complaint.CompensationAmount,
+		complaint.CompensationMethod,
+		complaint.ExpenseID,
+		complaint.WalletEntryID,
+		complaint.VoucherPromotionID,
+		complaint.ResolvedBy,
+		complaint.ResolvedAt,
+		complaint.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("complaintRepo.UpdateStatusTx.Update: %w", err)
+	}
+
+	// 2. Catat riwayat (audit trail)
+	if err := insertComplaintHistory(ctx, tx, history); err != nil {
+		return fmt.Errorf("complaintRepo.UpdateStatusTx: %w", err)
+	}
+
+	return nil
+}
+
+// InsertReworkOrderTx creates a zero-cost child order copying the customer data and the chosen items of its parent.
+// Nomor nota = nota induk + "-R{n}"; pesanan induk harus sudah dikunci pemanggil agar urutan n tidak bentrok.
+func (r *complaintRepository) InsertReworkOrderTx(ctx context.Context, tx *sql.Tx, rework *models.ReworkOrder) error {
+
+	if len(rework.OrderItemIDs) == 0 {
+		return fmt.Errorf("complaintRepo.InsertReworkOrderTx: no items to copy")
+	}
+
+	// 1. Tentukan nomor nota anak berikutnya
+	var parentInvoice string
+	var children int
+	err := tx.QueryRowContext(ctx, `
+		SELECT o.invoice_number, (SELECT COUNT(*) FROM orders ch WHERE ch.parent_order_id = o.id)
+		FROM orders o WHERE o.id = ?`, rework.ParentOrderID,
+	).Scan(&parentInvoice, &children)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return response.ErrNotFound
+		}
+		return fmt.Errorf("complaintRepo.InsertReworkOrderTx.Parent: %w", err)
+	}
+	rework.InvoiceNumber = fmt.Sprintf("%s-R%d", parentInvoice, children+1)
+
+	// 2. Salin data pelanggan dari pesanan induk (semua nominal 0, dianggap lunas karena tidak ditagih)
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO orders (invoice_number, outlet_id, parent_order_id, customer_id, customer_name, customer_phone, customer_address,
+			is_delivery, subtotal, discount_total, service_charge_total, tax_total, grand_total, payment_status, status_internal,
+			estimated_ready_at, notes, created_by, created_at)
+		SELECT ?, outlet_id, id, customer_id, customer_name, customer_phone, customer_address,
+			is_delivery, 0, 0, 0, 0, 0, ?, ?, ?, ?, ?, ?
+		FROM orders WHERE id = ?`,
+		rework.InvoiceNumber,
+		models.PaymentStatusPaid,
+		models.OrderStatusPending,
+		rework.EstimatedReadyAt,
+		rework.Notes,
+		rework.CreatedBy,
+		rework.CreatedAt,
+		rework.ParentOrderID,
+	)
+	if err != nil {
+		return fmt.Errorf("complaintRepo.InsertReworkOrderTx.Order: %w", err)
+	}
+	if rework.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("complaintRepo.InsertReworkOrderTx.LastInsertId: %w", err)
+	}
+
+	// 3. Salin item terpilih dengan harga 0
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(rework.OrderItemIDs)), ",")
+	args := []interface{}{rework.ID, rework.ParentOrderID}
+	for _, id := range rework.OrderItemIDs {
+		args = append(args, id)
+	}
+	res, err = tx.ExecContext(ctx, `
+		INSERT INTO order_items (order_id, service_id, item_notes, quantity, qty_pieces, weight_kg, unit_price, subtotal)
+		SELECT ?, service_id, item_notes, quantity, qty_pieces, weight_kg, 0, 0
+		FROM order_items WHERE order_id = ? AND id IN (`+placeholders+`)
+		ORDER BY id ASC`, args...)
+	if err != nil {
+		return fmt.Errorf("complaintRepo.InsertReworkOrderTx.Items: %w", err)
+	}
+	if copied, _ := res.RowsAffected(); int(copied) != len(rework.OrderItemIDs) {
+		return fmt.Errorf("%w: some items do not belong to order #%d", response.ErrValidation, rework.ParentOrderID)
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+func insertComplaintPhotos(ctx context.Context, db execer, complaintID int64, photos []models.ComplaintPhoto) error {
+	for i := range photos {
+		res, err := db.ExecContext(ctx, `
+			INSERT INTO complaint_photos (complaint_id, photo_url, caption, uploaded_by, created_at)
+			VALUES (?, ?, ?, ?, ?)`,
+			complaintID,
+			photos[i].PhotoURL,
+			photos[i].Caption,
+			photos[i].UploadedBy,
+			photos[i].CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("insertComplaintPhotos.Exec: %w", err)
+		}
+		if photos[i].ID, err = res.LastInsertId(); err != nil {
+			return fmt.Errorf("insertComplaintPhotos.LastInsertId: %w", err)
+		}
+		photos[i].ComplaintID = complaintID
+	}
+	return nil
+}
+
+func insertComplaintHistory(ctx context.Context, db execer, history *models.ComplaintStatusHistory) error {
+
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO complaint_status_history (complaint_id, previous_status, new_status, actor_id, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		history.ComplaintID,
+		history.PreviousStatus,
+		history.NewStatus,
+		history.ActorID,
+		history.Notes,
+		history.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insertComplaintHistory.Exec: %w", err)
+	}
+
+	if history.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("insertComplaintHistory.LastInsertId: %w", err)
+	}
+
+	return nil
+}
+
+func scanComplaint(row rowScanner) (*models.Complaint, error) {
+	var c models.Complaint
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var customerNameNull, serviceNameNull, resolutionTypeNull, resolutionNoteNull, reworkInvoiceNull sql.NullString
+	var compensationMethodNull, voucherCodeNull, resolvedByNameNull sql.NullString
+	var customerIDNull, orderItemNull, reworkOrderNull, expenseNull, walletEntryNull, voucherNull, resolvedByNull sql.NullInt64
+	var compensationNull money.NullAmount
+	var resolvedAtNull, createdAtNull, updatedAtNull sql.NullTime
+
+	if err := row.Scan(
+		&c.ID, &c.OutletID, &c.OrderID, &c.InvoiceNumber, &customerIDNull, &customerNameNull,
+		&orderItemNull, &serviceNameNull, &c.ComplaintType, &c.Description, &c.ComplaintStatus,
+		&resolutionTypeNull, &resolutionNoteNull, &reworkOrderNull, &reworkInvoiceNull,
+		&compensationNull, &compensationMethodNull, &expenseNull, &walletEntryNull, &voucherNull, &voucherCodeNull,
+		&c.ReportedBy, &c.ReportedByName, &resolvedByNull, &resolvedByNameNull, &resolvedAtNull,
+		&c.PhotoCount, &createdAtNull, &updatedAtNull,
+	); err != nil {
+		return nil, err
+	}
+
+	c.CustomerName = nullStringPtr(customerNameNull)
+	c.ServiceName = nullStringPtr(serviceNameNull)
+	c.ResolutionType = nullStringPtr(resolutionTypeNull)
+	c.ResolutionNote = nullStringPtr(resolutionNoteNull)
+	c.ReworkInvoice = nullStringPtr(reworkInvoiceNull)
+	c.CompensationMethod = nullStringPtr(compensationMethodNull)
+	c.VoucherCode = nullStringPtr(voucherCodeNull)
+	c.ResolvedByName = nullStringPtr(resolvedByNameNull)
+	c.CompensationAmount = compensationNull.Ptr()
+
+	c.CustomerID = nullInt64Ptr(customerIDNull)
+	c.OrderItemID = nullInt64Ptr(orderItemNull)
+	c.ReworkOrderID = nullInt64Ptr(reworkOrderNull)
+	c.ExpenseID = nullInt64Ptr(expenseNull)
+	c.WalletEntryID = nullInt64Ptr(walletEntryNull)
+	c.VoucherPromotionID = nullInt64Ptr(voucherNull)
+	c.ResolvedBy = nullInt64Ptr(resolvedByNull)
+
+	if resolvedAtNull.Valid {
+		c.ResolvedAt = &resolvedAtNull.Time
+	}
+	if createdAtNull.Valid {
+		c.CreatedAt = createdAtNull.Time
+	}
+	if updatedAtNull.Valid {
+		c.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &c, nil
+}
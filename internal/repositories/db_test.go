@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// openTestDB membuka database MySQL uji dari TEST_MYSQL_DSN (skema migrations/ sudah diterapkan).
+// Test yang butuh penguncian baris (FOR UPDATE) dilewati jika variabel tersebut kosong.
+//
+// Contoh: TEST_MYSQL_DSN="root:@tcp(127.0.0.1:3306)/laundry_test?parseTime=true" go test ./internal/repositories/
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN is not set")
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		t.Fatalf("db.Ping: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
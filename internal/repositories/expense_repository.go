@@ -0,0 +1,406 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+)
+
+// ExpenseRepository mendefinisikan operasi database untuk kategori biaya & biaya operasional outlet.
+type ExpenseRepository interface {
+
+	// Expense Categories
+	InsertCategory(ctx context.Context, category *models.ExpenseCategory) error
+	FindCategories(ctx context.Context, status string) ([]models.ExpenseCategory, error)
+	FindCategoryByID(ctx context.Context, id int64) (*models.ExpenseCategory, error)
+	FindCategoryByName(ctx context.Context, categoryName string) (*models.ExpenseCategory, error)
+	UpdateCategory(ctx context.Context, category *models.ExpenseCategory) error
+	DeleteCategory(ctx context.Context, id int64) error
+
+	// Expenses (dibatasi outlet aktif di context)
+	InsertExpense(ctx context.Context, expense *models.Expense) error
+	InsertExpenseTx(ctx context.Context, tx *sql.Tx, expense *models.Expense) error
+	FindExpenses(ctx context.Context, params listquery.Params) ([]models.Expense, *listquery.Result, error)
+	FindExpenseByID(ctx context.Context, id int64) (*models.Expense, error)
+	UpdateExpense(ctx context.Context, expense *models.Expense) error
+	DeleteExpense(ctx context.Context, id int64) error
+}
+
+// expenseRepository is the concrete implementation using sql.DB.
+type expenseRepository struct {
+	db *sql.DB
+}
+
+// NewExpenseRepository creates a new instance of ExpenseRepository.
+func NewExpenseRepository(db *sql.DB) ExpenseRepository {
+	return &expenseRepository{db: db}
+}
+
+// --- IMPLEMENTATION: EXPENSE CATEGORIES ---
+
+const expenseCategoryColumns = `id, category_name, description, COALESCE(is_active, 1), created_at, updated_at`
+
+// InsertCategory creates a new expense category.
+func (r *expenseRepository) InsertCategory(ctx context.Context, category *models.ExpenseCategory) error {
+
+	query := `INSERT INTO expense_categories (category_name, description, is_active, created_at) VALUES (?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query,
+		category.CategoryName,
+		category.Description, // Pointer, aman jika nil
+		category.IsActive,
+		category.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("expenseRepo.InsertCategory.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("expenseRepo.InsertCategory.LastInsertId: %w", err)
+	}
+
+	category.ID = id
+	return nil
+}
+
+// FindCategories retrieves every expense category; the list is small so it is not paginated.
+func (r *expenseRepository) FindCategories(ctx context.Context, status string) ([]models.ExpenseCategory, error) {
+
+	// 1. Terapkan filter status aktif/non-aktif
+	whereClause := "WHERE 1=1"
+	if status == "1" {
+		whereClause += " AND is_active = 1"
+	} else if status == "0" {
+		whereClause += " AND is_active = 0"
+	}
+
+	// 2. Eksekusi query
+	query := fmt.Sprintf(`SELECT %s FROM expense_categories %s ORDER BY category_name ASC`, expenseCategoryColumns, whereClause)
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("expenseRepo.FindCategories.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 3. Mapping hasil query
+	categories := []models.ExpenseCategory{}
+	for rows.Next() {
+		c, err := scanExpenseCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("expenseRepo.FindCategories.Scan: %w", err)
+		}
+		categories = append(categories, *c)
+	}
+
+	return categories, rows.Err()
+}
+
+// FindCategoryByID retrieves an expense category by ID.
+func (r *expenseRepository) FindCategoryByID(ctx context.Context, id int64) (*models.ExpenseCategory, error) {
+
+	query := fmt.Sprintf(`SELECT %s FROM expense_categories WHERE id = ?`, expenseCategoryColumns)
+	c, err := scanExpenseCategory(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("expenseRepo.FindCategoryByID: %w", err)
+	}
+
+	return c, nil
+}
+
+// FindCategoryByName retrieves an expense category by its exact name (Useful for duplicate validation).
+func (r *expenseRepository) FindCategoryByName(ctx context.Context, categoryName string) (*models.ExpenseCategory, error) {
+
+	query := fmt.Sprintf(`SELECT %s FROM expense_categories WHERE category_name = ?`, expenseCategoryColumns)
+	c, err := scanExpenseCategory(r.db.QueryRowContext(ctx, query, categoryName))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("expenseRepo.FindCategoryByName: %w", err)
+	}
+
+	return c, nil
+}
+
+// UpdateCategory updates an existing expense category.
+func (r *expenseRepository) UpdateCategory(ctx context.Context, category *models.ExpenseCategory) error {
+
+	query := `UPDATE expense_categories SET category_name = ?, description = ?, is_active = ?, updated_at = ? WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query,
+		category.CategoryName,
+		category.Description,
+		category.IsActive,
+		category.UpdatedAt,
+		category.ID,
+	); err != nil {
+		return fmt.Errorf("expenseRepo.UpdateCategory.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCategory performs a soft delete by setting is_active to false (0).
+func (r *expenseRepository) DeleteCategory(ctx context.Context, id int64) error {
+
+	res, err := r.db.ExecContext(ctx, "UPDATE expense_categories SET is_active = 0 WHERE id = ? AND is_active = 1", id)
+	if err != nil {
+		return fmt.Errorf("expenseRepo.DeleteCategory.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("expenseRepo.DeleteCategory.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// --- IMPLEMENTATION: EXPENSES ---
+
+// expenseListSpec mendeklarasikan pencarian, filter, dan sorting yang diizinkan untuk GET /expenses.
+var expenseListSpec = listquery.Spec{
+	Search: []string{"e.description", "e.attachment_ref"},
+	Filters: map[string]listquery.Filter{
+		"outlet_id":           {Column: "e.outlet_id"},
+		"expense_category_id": {Column: "e.expense_category_id"},
+		"created_by":          {Column: "e.created_by"},
+		"start_date":          {Expr: "e.expense_date >= ?"},
+		"end_date":            {Expr: "e.expense_date <= ?"},
+	},
+	Sorts: map[string]string{
+		"expense_date": "e.expense_date",
+		"amount":       "e.amount",
+		"created_at":   "e.created_at",
+		"id":           "e.id",
+	},
+	DefaultSort:  "expense_date",
+	DefaultOrder: listquery.OrderDesc,
+	IDColumn:     "e.id",
+}
+
+const expenseSelect = `
+	SELECT e.id, e.outlet_id, e.expense_category_id, ec.category_name, e.amount, e.expense_date, e.description,
+		e.attachment_ref, e.created_by, e.updated_by, e.created_at, e.updated_at
+	FROM expenses e
+	JOIN expense_categories ec ON ec.id = e.expense_category_id `
+
+// InsertExpense records a new expense.
+func (r *expenseRepository) InsertExpense(ctx context.Context, expense *models.Expense) error {
+	return insertExpense(ctx, r.db, expense, "expenseRepo.InsertExpense")
+}
+
+// InsertExpenseTx records a new expense inside the caller's transaction (cth: kompensasi komplain).
+func (r *expenseRepository) InsertExpenseTx(ctx context.Context, tx *sql.Tx, expense *models.Expense) error {
+	return insertExpense(ctx, tx, expense, "expenseRepo.InsertExpenseTx")
+}
+
+// FindExpenses retrieves expenses of the active outlet with pagination (offset or cursor), filtering, and sorting support.
+func (r *expenseRepository) FindExpenses(ctx context.Context, params listquery.Params) ([]models.Expense, *listquery.Result, error) {
+
+	// 1. Susun WHERE / ORDER BY / LIMIT dari spec (outlet aktif dipaksa lewat filter outlet_id)
+	q, err := expenseListSpec.Build(outletListParams(ctx, params))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Hitung total baris (opsional) untuk data Meta Pagination
+	var totalItems *int64
+	if q.Count {
+		where, args := q.Where()
+		var total int64
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM expenses e "+where, args...).Scan(&total); err != nil {
+			return nil, nil, fmt.Errorf("expenseRepo.FindExpenses.Count: %w", err)
+		}
+		totalItems = &total
+	}
+
+	// 3. Eksekusi query utama
+	tail, args := q.Tail()
+	rows, err := r.db.QueryContext(ctx, expenseSelect+tail, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("expenseRepo.FindExpenses.Query: %w", err)
+	}
+	defer rows.Close()
+
+	expenses := []models.Expense{}
+	for rows.Next() {
+		e, err := scanExpense(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("expenseRepo.FindExpenses.Scan: %w", err)
+		}
+		expenses = append(expenses, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("expenseRepo.FindExpenses.Rows: %w", err)
+	}
+
+	// 4. Buang baris ekstra & siapkan cursor halaman berikutnya
+	keep, result := q.Paginate(len(expenses), totalItems, func(i int) (interface{}, int64) {
+		return expenseSortValue(expenses[i], q.SortKey()), expenses[i].ID
+	})
+
+	return expenses[:keep], result, nil
+}
+
+// FindExpenseByID retrieves an expense of the active outlet.
+func (r *expenseRepository) FindExpenseByID(ctx context.Context, id int64) (*models.Expense, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "e.outlet_id")
+	query := expenseSelect + "WHERE e.id = ?" + scope
+
+	e, err := scanExpense(r.db.QueryRowContext(ctx, query, append([]interface{}{id}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("expenseRepo.FindExpenseByID: %w", err)
+	}
+
+	return e, nil
+}
+
+// UpdateExpense updates an existing expense of the active outlet.
+func (r *expenseRepository) UpdateExpense(ctx context.Context, expense *models.Expense) error {
+
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	query := `
+		UPDATE expenses
+		SET expense_category_id = ?, amount = ?, expense_date = ?, description = ?, attachment_ref = ?, updated_by = ?, updated_at = ?
+		WHERE id = ?` + scope
+
+	args := []interface{}{
+		expense.ExpenseCategoryID,
+		expense.Amount,
+		expense.ExpenseDate.Format("2006-01-02"),
+		expense.Description,
+		expense.AttachmentRef,
+		expense.UpdatedBy,
+		expense.UpdatedAt,
+		expense.ID,
+	}
+	if _, err := r.db.ExecContext(ctx, query, append(args, scopeArgs...)...); err != nil {
+		return fmt.Errorf("expenseRepo.UpdateExpense.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpense permanently removes an expense of the active outlet.
+func (r *expenseRepository) DeleteExpense(ctx context.Context, id int64) error {
+
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	res, err := r.db.ExecContext(ctx, "DELETE FROM expenses WHERE id = ?"+scope, append([]interface{}{id}, scopeArgs...)...)
+	if err != nil {
+		return fmt.Errorf("expenseRepo.DeleteExpense.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("expenseRepo.DeleteExpense.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+func expenseSortValue(e models.Expense, sortKey string) interface{} {
+	switch sortKey {
+	case "amount":
+		return e.Amount
+	case "created_at":
+		return e.CreatedAt
+	case "id":
+		return e.ID
+	default:
+		return e.ExpenseDate.Format("2006-01-02")
+	}
+}
+
+func scanExpenseCategory(row rowScanner) (*models.ExpenseCategory, error) {
+	var c models.ExpenseCategory
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var descriptionNull sql.NullString
+	var updatedAtNull sql.NullTime
+
+	if err := row.Scan(&c.ID, &c.CategoryName, &descriptionNull, &c.IsActive, &c.CreatedAt, &updatedAtNull); err != nil {
+		return nil, err
+	}
+
+	c.Description = nullStringPtr(descriptionNull)
+	if updatedAtNull.Valid {
+		c.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &c, nil
+}
+
+func scanExpense(row rowScanner) (*models.Expense, error) {
+	var e models.Expense
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var descriptionNull, attachmentNull sql.NullString
+	var updatedByNull sql.NullInt64
+	var updatedAtNull sql.NullTime
+
+	if err := row.Scan(
+		&e.ID, &e.OutletID, &e.ExpenseCategoryID, &e.CategoryName, &e.Amount, &e.ExpenseDate, &descriptionNull,
+		&attachmentNull, &e.CreatedBy, &updatedByNull, &e.CreatedAt, &updatedAtNull,
+	); err != nil {
+		return nil, err
+	}
+
+	e.Description = nullStringPtr(descriptionNull)
+	e.AttachmentRef = nullStringPtr(attachmentNull)
+	if updatedByNull.Valid {
+		e.UpdatedBy = &updatedByNull.Int64
+	}
+	if updatedAtNull.Valid {
+		e.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &e, nil
+}
+
+func insertExpense(ctx context.Context, db execer, expense *models.Expense, op string) error {
+
+	query := `
+		INSERT INTO expenses (outlet_id, expense_category_id, amount, expense_date, description, attachment_ref, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := db.ExecContext(ctx, query,
+		expense.OutletID,
+		expense.ExpenseCategoryID,
+		expense.Amount,
+		expense.ExpenseDate.Format("2006-01-02"),
+		expense.Description,   // Pointer, aman jika nil
+		expense.AttachmentRef, // Pointer, aman jika nil
+		expense.CreatedBy,
+		expense.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s.Exec: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("%s.LastInsertId: %w", op, err)
+	}
+
+	expense.ID = id
+	return nil
+}
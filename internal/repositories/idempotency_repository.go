@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// IdempotencyRepository mendefinisikan operasi database untuk header Idempotency-Key.
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, key *models.IdempotencyKey, now time.Time) (bool, error)
+	FindByKey(ctx context.Context, userID int64, key string) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, id int64, status int, contentType string, body []byte, completedAt, expiresAt time.Time) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// idempotencyRepository is the concrete implementation using sql.DB.
+type idempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository.
+func NewIdempotencyRepository(db *sql.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// Reserve mencoba mengklaim key untuk request yang sedang berjalan.
+// Mengembalikan false jika key sudah dipegang request lain (baik masih berjalan maupun sudah selesai).
+// Unique index (user_id, idempotency_key) menjamin hanya satu dari request kembar yang menang.
+func (r *idempotencyRepository) Reserve(ctx context.Context, key *models.IdempotencyKey, now time.Time) (bool, error) {
+
+	// 1. Buang baris lama yang sudah kedaluwarsa (TTL habis / lock request yang mati di tengah jalan)
+	deleteQuery := `DELETE FROM idempotency_keys WHERE user_id = ? AND idempotency_key = ? AND expires_at <= ?`
+	if _, err := r.db.ExecContext(ctx, deleteQuery, key.UserID, key.Key, now); err != nil {
+		return false, fmt.Errorf("idempotencyRepo.Reserve.DeleteExpired: %w", err)
+	}
+
+	// 2. INSERT biasa: duplikat unique index (error 1062) = key sudah ada.
+	// Bukan INSERT IGNORE, karena IGNORE juga menelan error lain (data terpotong, NOT NULL) menjadi "0 baris".
+	insertQuery := `
+		INSERT INTO idempotency_keys
+			(user_id, idempotency_key, request_method, request_path, request_hash, status, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, insertQuery,
+		key.UserID, key.Key, key.RequestMethod, key.RequestPath, key.RequestHash,
+		models.IdempotencyProcessing, key.ExpiresAt, now,
+	)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("idempotencyRepo.Reserve.Insert: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, fmt.Errorf("idempotencyRepo.Reserve.LastInsertId: %w", err)
+	}
+	key.ID = id
+	key.Status = models.IdempotencyProcessing
+	key.CreatedAt = now
+
+	return true, nil
+}
+
+// FindByKey retrieves the stored record of a user's key.
+func (r *idempotencyRepository) FindByKey(ctx context.Context, userID int64, key string) (*models.IdempotencyKey, error) {
+
+	query := `
+		SELECT id, user_id, idempotency_key, request_method, request_path, request_hash, status,
+			response_status, response_content_type, response_body, expires_at, created_at, completed_at
+		FROM idempotency_keys
+		WHERE user_id = ? AND idempotency_key = ?
+	`
+	var record models.IdempotencyKey
+	err := r.db.QueryRowContext(ctx, query, userID, key).Scan(
+		&record.ID, &record.UserID, &record.Key, &record.RequestMethod, &record.RequestPath, &record.RequestHash, &record.Status,
+		&record.ResponseStatus, &record.ResponseContentType, &record.ResponseBody, &record.ExpiresAt, &record.CreatedAt, &record.CompletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("idempotencyRepo.FindByKey: %w", err)
+	}
+
+	return &record, nil
+}
+
+// Complete menyimpan balasan request pertama dan memperpanjang masa simpan ke TTL.
+func (r *idempotencyRepository) Complete(ctx context.Context, id int64, status int, contentType string, body []byte, completedAt, expiresAt time.Time) error {
+
+	query := `
+		UPDATE idempotency_keys
+		SET status = ?, response_status = ?, response_content_type = ?, response_body = ?,
+			completed_at = ?, expires_at = ?
+		WHERE id = ?
+	`
+	if _, err := r.db.ExecContext(ctx, query, models.IdempotencyCompleted, status, contentType, body, completedAt, expiresAt, id); err != nil {
+		return fmt.Errorf("idempotencyRepo.Complete: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired membersihkan key yang masa simpannya sudah habis.
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= ?`, now)
+	if err != nil {
+		return 0, fmt.Errorf("idempotencyRepo.DeleteExpired: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("idempotencyRepo.DeleteExpired.RowsAffected: %w", err)
+	}
+
+	return affected, nil
+}
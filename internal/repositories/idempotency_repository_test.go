@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/models"
+)
+
+// TestReserveKeysAreCaseSensitive: key yang hanya berbeda huruf besar/kecil adalah dua operasi berbeda,
+// jadi keduanya harus bisa direservasi dan FindByKey tidak boleh tertukar.
+func TestReserveKeysAreCaseSensitive(t *testing.T) {
+
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewIdempotencyRepository(db)
+
+	var userID int64
+	if err := db.QueryRowContext(ctx, "SELECT id FROM users ORDER BY id LIMIT 1").Scan(&userID); err != nil {
+		t.Fatalf("select user: %v", err)
+	}
+
+	now := time.Now()
+	suffix := fmt.Sprintf("%d", now.UnixNano())
+	lower, upper := "case-key-"+suffix, "CASE-KEY-"+suffix
+	t.Cleanup(func() {
+		db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE user_id = ? AND idempotency_key IN (?, ?)", userID, lower, upper)
+	})
+
+	for _, key := range []string{lower, upper} {
+		ok, err := repo.Reserve(ctx, &models.IdempotencyKey{
+			UserID: userID, Key: key, RequestMethod: "POST", RequestPath: "/api/v1/orders",
+			RequestHash: key, ExpiresAt: now.Add(time.Minute),
+		}, now)
+		if err != nil {
+			t.Fatalf("Reserve(%q): %v", key, err)
+		}
+		if !ok {
+			t.Fatalf("Reserve(%q) = false, want a separate key from its other-case twin", key)
+		}
+	}
+
+	found, err := repo.FindByKey(ctx, userID, upper)
+	if err != nil {
+		t.Fatalf("FindByKey: %v", err)
+	}
+	if found.Key != upper || found.RequestHash != upper {
+		t.Fatalf("FindByKey(%q) returned key %q", upper, found.Key)
+	}
+}
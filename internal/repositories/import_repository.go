@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+)
+
+// ImportRepository menulis hasil import massal master data di dalam satu transaksi milik service.
+// Pengecekan duplikasi kategori & layanan memakai CategoryRepository / ServiceRepository yang sudah ada.
+type ImportRepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+
+	// Read Operations
+	FindCustomerByPhone(ctx context.Context, phone string) (*models.Customer, error)
+
+	// Write Operations (di dalam transaksi import)
+	InsertCategoryTx(ctx context.Context, tx *sql.Tx, category *models.ServiceCategory) error
+	InsertServiceTx(ctx context.Context, tx *sql.Tx, service *models.Service) error
+	InsertCustomerTx(ctx context.Context, tx *sql.Tx, customer *models.Customer) error
+}
+
+// importRepository is the concrete implementation using sql.DB.
+type importRepository struct {
+	db *sql.DB
+}
+
+// NewImportRepository creates a new instance of ImportRepository.
+func NewImportRepository(db *sql.DB) ImportRepository {
+	return &importRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// BeginTx starts a transaction owned by the calling service.
+func (r *importRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("importRepo.BeginTx: %w", err)
+	}
+	return tx, nil
+}
+
+// FindCustomerByPhone retrieves a customer by its unique phone number.
+func (r *importRepository) FindCustomerByPhone(ctx context.Context, phone string) (*models.Customer, error) {
+
+	query := `SELECT id, full_name, phone_number, address, COALESCE(is_active, 1), created_at, updated_at FROM customers WHERE phone_number = ?`
+
+	var c models.Customer
+	var addressNull sql.NullString
+	var createdAtNull, updatedAtNull sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, phone).Scan(
+		&c.ID, &c.FullName, &c.PhoneNumber, &addressNull, &c.IsActive, &createdAtNull, &updatedAtNull,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("importRepo.FindCustomerByPhone: %w", err)
+	}
+
+	if addressNull.Valid {
+		c.Address = &addressNull.String
+	}
+	if createdAtNull.Valid {
+		c.CreatedAt = createdAtNull.Time
+	}
+	if updatedAtNull.Valid {
+		c.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &c, nil
+}
+
+// InsertCategoryTx creates a service category inside the import transaction.
+func (r *importRepository) InsertCategoryTx(ctx context.Context, tx *sql.Tx, category *models.ServiceCategory) error {
+	return insertCategory(ctx, tx, category, "importRepo.InsertCategoryTx")
+}
+
+// InsertServiceTx creates a service inside the import transaction.
+func (r *importRepository) InsertServiceTx(ctx context.Context, tx *sql.Tx, service *models.Service) error {
+	return insertService(ctx, tx, service, "importRepo.InsertServiceTx")
+}
+
+// InsertCustomerTx creates a customer inside the import transaction.
+func (r *importRepository) InsertCustomerTx(ctx context.Context, tx *sql.Tx, customer *models.Customer) error {
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO customers (full_name, phone_number, address, is_active, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		customer.FullName,
+		customer.PhoneNumber,
+		customer.Address, // Pointer, aman jika nil
+		customer.IsActive,
+		customer.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("importRepo.InsertCustomerTx.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("importRepo.InsertCustomerTx.LastInsertId: %w", err)
+	}
+
+	customer.ID = id
+	return nil
+}
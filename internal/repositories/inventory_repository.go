@@ -0,0 +1,554 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/quantity"
+	"laundry-backend/pkg/response"
+)
+
+// InventoryRepository mendefinisikan operasi database untuk bahan habis pakai, stok per outlet, ledger mutasi & resep layanan.
+//
+// Stok tidak pernah ditimpa langsung: setiap perubahan adalah baris inventory_movements, dan
+// inventory_stocks hanya nilai berjalan yang diperbarui di transaksi yang sama (PostMovementTx).
+type InventoryRepository interface {
+
+	// Items
+	InsertItem(ctx context.Context, item *models.InventoryItem) error
+	FindItems(ctx context.Context, status string) ([]models.InventoryStock, error)
+	FindItemByID(ctx context.Context, id int64) (*models.InventoryStock, error)
+	FindItemByName(ctx context.Context, itemName string) (*models.InventoryItem, error)
+	UpdateItem(ctx context.Context, item *models.InventoryItem) error
+	DeleteItem(ctx context.Context, id int64) error
+	FindLowStock(ctx context.Context) ([]models.InventoryStock, error)
+
+	// Movements (ledger, dibatasi outlet aktif di context)
+	PostMovement(ctx context.Context, movement *models.InventoryMovement, allowNegative bool) error
+	PostMovementTx(ctx context.Context, tx *sql.Tx, movement *models.InventoryMovement, allowNegative bool) error
+	FindMovements(ctx context.Context, params listquery.Params) ([]models.InventoryMovement, *listquery.Result, error)
+
+	// Recipes
+	FindConsumptionsByService(ctx context.Context, serviceID int64) ([]models.ServiceConsumption, error)
+	ReplaceConsumptions(ctx context.Context, serviceID int64, consumptions []models.ServiceConsumption) error
+
+	// Pemakaian pesanan (dipanggil di dalam transaksi perubahan status)
+	HasOrderConsumptionTx(ctx context.Context, tx *sql.Tx, orderID int64) (bool, error)
+	FindOrderConsumptionsTx(ctx context.Context, tx *sql.Tx, orderID int64) ([]models.OrderConsumption, error)
+}
+
+// inventoryRepository is the concrete implementation using sql.DB.
+type inventoryRepository struct {
+	db *sql.DB
+}
+
+// NewInventoryRepository creates a new instance of InventoryRepository.
+func NewInventoryRepository(db *sql.DB) InventoryRepository {
+	return &inventoryRepository{db: db}
+}
+
+// --- IMPLEMENTATION: ITEMS ---
+
+// inventoryItemSelect mengambil bahan beserta stok outlet aktif (mode konsolidasi: jumlah semua outlet).
+// Argumen scope outlet harus dipasang sebelum argumen WHERE lainnya.
+const inventoryItemSelect = `
+	SELECT i.id, i.item_name, i.unit, i.min_stock, COALESCE(i.is_active, 1), i.created_at, i.updated_at,
+		COALESCE((SELECT SUM(s.quantity) FROM inventory_stocks s WHERE s.inventory_item_id = i.id%s), 0)
+	FROM inventory_items i `
+
+// InsertItem creates a new inventory item.
+func (r *inventoryRepository) InsertItem(ctx context.Context, item *models.InventoryItem) error {
+
+	query := `INSERT INTO inventory_items (item_name, unit, min_stock, is_active, created_at) VALUES (?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query,
+		item.ItemName,
+		item.Unit,
+		item.MinStock,
+		item.IsActive,
+		item.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("inventoryRepo.InsertItem.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("inventoryRepo.InsertItem.LastInsertId: %w", err)
+	}
+
+	item.ID = id
+	return nil
+}
+
+// FindItems retrieves every inventory item with its stock; the catalogue is small so it is not paginated.
+func (r *inventoryRepository) FindItems(ctx context.Context, status string) ([]models.InventoryStock, error) {
+
+	// 1. Terapkan filter status aktif/non-aktif
+	whereClause := "WHERE 1=1"
+	if status == "1" {
+		whereClause += " AND COALESCE(i.is_active, 1) = 1"
+	} else if status == "0" {
+		whereClause += " AND i.is_active = 0"
+	}
+
+	// 2. Eksekusi query
+	scope, scopeArgs := outletFilter(ctx, "s.outlet_id")
+	query := fmt.Sprintf(inventoryItemSelect, scope) + whereClause + " ORDER BY i.item_name ASC"
+	rows, err := r.db.QueryContext(ctx, query, scopeArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("inventoryRepo.FindItems.Query: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.InventoryStock{}
+	for rows.Next() {
+		stock, err := scanInventoryStock(rows)
+		if err != nil {
+			return nil, fmt.Errorf("inventoryRepo.FindItems.Scan: %w", err)
+		}
+		items = append(items, *stock)
+	}
+
+	return items, rows.Err()
+}
+
+// FindItemByID retrieves an inventory item with its stock at the active outlet.
+func (r *inventoryRepository) FindItemByID(ctx context.Context, id int64) (*models.InventoryStock, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "s.outlet_id")
+	query := fmt.Sprintf(inventoryItemSelect, scope) + "WHERE i.id = ?"
+
+	stock, err := scanInventoryStock(r.db.QueryRowContext(ctx, query, append(scopeArgs, id)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("inventoryRepo.FindItemByID: %w", err)
+	}
+
+	return stock, nil
+}
+
+// FindItemByName retrieves an inventory item by its unique name (used for duplicate checks).
+func (r *inventoryRepository) FindItemByName(ctx context.Context, itemName string) (*models.InventoryItem, error) {
+
+	query := fmt.Sprintf(inventoryItemSelect, "") + "WHERE i.item_name = ?"
+
+	stock, err := scanInventoryStock(r.db.QueryRowContext(ctx, query, itemName))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("inventoryRepo.FindItemByName: %w", err)
+	}
+
+	return &stock.Item, nil
+}
+
+// UpdateItem updates the master data of an inventory item (stock is only changed through movements).
+func (r *inventoryRepository) UpdateItem(ctx context.Context, item *models.InventoryItem) error {
+
+	query := `UPDATE inventory_items SET item_name = ?, unit = ?, min_stock = ?, is_active = ?, updated_at = ? WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, item.ItemName, item.Unit, item.MinStock, item.IsActive, item.UpdatedAt, item.ID); err != nil {
+		return fmt.Errorf("inventoryRepo.UpdateItem.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteItem performs a soft delete; ledger history and recipes keep referring to the item.
+func (r *inventoryRepository) DeleteItem(ctx context.Context, id int64) error {
+
+	res, err := r.db.ExecContext(ctx, "UPDATE inventory_items SET is_active = 0 WHERE id = ? AND COALESCE(is_active, 1) = 1", id)
+	if err != nil {
+		return fmt.Errorf("inventoryRepo.DeleteItem.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("inventoryRepo.DeleteItem.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindLowStock retrieves active items whose stock at an active outlet is at or below min_stock, one row per outlet.
+func (r *inventoryRepository) FindLowStock(ctx context.Context) ([]models.InventoryStock, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "o.id")
+	query := `
+		SELECT o.id, o.outlet_name, i.id, i.item_name, i.unit, i.min_stock, COALESCE(i.is_active, 1), i.created_at, i.updated_at,
+			COALESCE(s.quantity, 0)
+		FROM inventory_items i
+		CROSS JOIN outlets o
+		LEFT JOIN inventory_stocks s ON s.inventory_item_id = i.id AND s.outlet_id = o.id
+		WHERE COALESCE(i.is_active, 1) = 1 AND COALESCE(o.is_active, 1) = 1 AND i.min_stock > 0
+			AND COALESCE(s.quantity, 0) <= i.min_stock` + scope + `
+		ORDER BY o.id ASC, (COALESCE(s.quantity, 0) / i.min_stock) ASC, i.item_name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, scopeArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("inventoryRepo.FindLowStock.Query: %w", err)
+	}
+	defer rows.Close()
+
+	stocks := []models.InventoryStock{}
+	for rows.Next() {
+		var s models.InventoryStock
+		var updatedAtNull sql.NullTime
+		if err := rows.Scan(
+			&s.OutletID, &s.OutletName, &s.Item.ID, &s.Item.ItemName, &s.Item.Unit, &s.Item.MinStock, &s.Item.IsActive,
+			&s.Item.CreatedAt, &updatedAtNull, &s.Quantity,
+		); err != nil {
+			return nil, fmt.Errorf("inventoryRepo.FindLowStock.Scan: %w", err)
+		}
+		if updatedAtNull.Valid {
+			s.Item.UpdatedAt = &updatedAtNull.Time
+		}
+		stocks = append(stocks, s)
+	}
+
+	return stocks, rows.Err()
+}
+
+// --- IMPLEMENTATION: MOVEMENTS ---
+
+// PostMovement records a stock movement in its own transaction (stock-in, manual adjustment).
+func (r *inventoryRepository) PostMovement(ctx context.Context, movement *models.InventoryMovement, allowNegative bool) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("inventoryRepo.PostMovement.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.PostMovementTx(ctx, tx, movement, allowNegative); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("inventoryRepo.PostMovement.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// PostMovementTx locks the outlet stock row, applies the movement to the running quantity and appends the ledger line.
+// An outgoing movement larger than the current stock returns ErrInsufficientStock unless allowNegative is set.
+func (r *inventoryRepository) PostMovementTx(ctx context.Context, tx *sql.Tx, movement *models.InventoryMovement, allowNegative bool) error {
+
+	// 1. Pastikan baris stok outlet ada, lalu kunci agar dua transaksi tidak memotong stok yang sama
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO inventory_stocks (outlet_id, inventory_item_id, quantity) VALUES (?, ?, 0) ON DUPLICATE KEY UPDATE quantity = quantity",
+		movement.OutletID, movement.InventoryItemID,
+	); err != nil {
+		return fmt.Errorf("inventoryRepo.PostMovementTx.EnsureStock: %w", err)
+	}
+
+	var balance quantity.Quantity
+	err := tx.QueryRowContext(ctx,
+		"SELECT quantity FROM inventory_stocks WHERE outlet_id = ? AND inventory_item_id = ? FOR UPDATE",
+		movement.OutletID, movement.InventoryItemID,
+	).Scan(&balance)
+	if err != nil {
+		return fmt.Errorf("inventoryRepo.PostMovementTx.Lock: %w", err)
+	}
+
+	// 2. Hitung stok baru (quantity.Quantity eksak 3 desimal, sama dengan kolom DECIMAL(15,3))
+	balanceAfter, err := applyStockMovement(balance, movement.Direction, movement.Quantity, allowNegative)
+	if err != nil {
+		if errors.Is(err, response.ErrInsufficientStock) {
+			return err
+		}
+		return fmt.Errorf("inventoryRepo.PostMovementTx: %w", err)
+	}
+	movement.BalanceBefore = balance
+	movement.BalanceAfter = balanceAfter
+
+	// 3. Update stok berjalan
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE inventory_stocks SET quantity = ? WHERE outlet_id = ? AND inventory_item_id = ?",
+		movement.BalanceAfter, movement.OutletID, movement.InventoryItemID,
+	); err != nil {
+		return fmt.Errorf("inventoryRepo.PostMovementTx.UpdateStock: %w", err)
+	}
+
+	// 4. Simpan baris ledger
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO inventory_movements (outlet_id, inventory_item_id, movement_type, direction, quantity, balance_before, balance_after, order_id, reason, actor_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		movement.OutletID,
+		movement.InventoryItemID,
+		movement.MovementType,
+		movement.Direction,
+		movement.Quantity,
+		movement.BalanceBefore,
+		movement.BalanceAfter,
+		movement.OrderID,
+		movement.Reason,
+		movement.ActorID,
+		movement.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("inventoryRepo.PostMovementTx.Insert: %w", err)
+	}
+
+	if movement.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("inventoryRepo.PostMovementTx.LastInsertId: %w", err)
+	}
+
+	return nil
+}
+
+// inventoryMovementListSpec mendeklarasikan pencarian, filter, dan sorting yang diizinkan untuk GET /inventory/movements.
+var inventoryMovementListSpec = listquery.Spec{
+	Search: []string{"m.reason"},
+	Filters: map[string]listquery.Filter{
+		"outlet_id":         {Column: "m.outlet_id"},
+		"inventory_item_id": {Column: "m.inventory_item_id"},
+		"movement_type":     {Column: "m.movement_type", Allowed: []string{models.InventoryMovementStockIn, models.InventoryMovementConsumption, models.InventoryMovementAdjustment}},
+		"order_id":          {Column: "m.order_id"},
+		"actor_id":          {Column: "m.actor_id"},
+		"start_date":        {Expr: "m.created_at >= ?"},
+		"end_date":          {Expr: "m.created_at < DATE_ADD(?, INTERVAL 1 DAY)"},
+	},
+	Sorts: map[string]string{
+		"id":         "m.id",
+		"created_at": "m.created_at",
+	},
+	DefaultSort:  "id",
+	DefaultOrder: listquery.OrderDesc,
+	IDColumn:     "m.id",
+}
+
+const inventoryMovementSelect = `
+	SELECT m.id, m.outlet_id, m.inventory_item_id, i.item_name, i.unit, m.movement_type, m.direction, m.quantity,
+		m.balance_before, m.balance_after, m.order_id, m.reason, m.actor_id, m.created_at
+	FROM inventory_movements m
+	JOIN inventory_items i ON i.id = m.inventory_item_id `
+
+// FindMovements retrieves the stock ledger of the active outlet with pagination (offset or cursor), filtering, and sorting support.
+func (r *inventoryRepository) FindMovements(ctx context.Context, params listquery.Params) ([]models.InventoryMovement, *listquery.Result, error) {
+
+	// 1. Susun WHERE / ORDER BY / LIMIT dari spec (outlet aktif dipaksa lewat filter outlet_id)
+	q, err := inventoryMovementListSpec.Build(outletListParams(ctx, params))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Hitung total baris (opsional) untuk data Meta Pagination
+	var totalItems *int64
+	if q.Count {
+		where, args := q.Where()
+		var total int64
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM inventory_movements m "+where, args...).Scan(&total); err != nil {
+			return nil, nil, fmt.Errorf("inventoryRepo.FindMovements.Count: %w", err)
+		}
+		totalItems = &total
+	}
+
+	// 3. Eksekusi query utama
+	tail, args := q.Tail()
+	rows, err := r.db.QueryContext(ctx, inventoryMovementSelect+tail, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("inventoryRepo.FindMovements.Query: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []models.InventoryMovement{}
+	for rows.Next() {
+		var m models.InventoryMovement
+		var orderNull, actorNull sql.NullInt64
+		if err := rows.Scan(
+			&m.ID, &m.OutletID, &m.InventoryItemID, &m.ItemName, &m.Unit, &m.MovementType, &m.Direction, &m.Quantity,
+			&m.BalanceBefore, &m.BalanceAfter, &orderNull, &m.Reason, &actorNull, &m.CreatedAt,
+		); err != nil {
+			return nil, nil, fmt.Errorf("inventoryRepo.FindMovements.Scan: %w", err)
+		}
+		if orderNull.Valid {
+			m.OrderID = &orderNull.Int64
+		}
+		if actorNull.Valid {
+			m.ActorID = &actorNull.Int64
+		}
+		movements = append(movements, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("inventoryRepo.FindMovements.Rows: %w", err)
+	}
+
+	// 4. Buang baris ekstra & siapkan cursor halaman berikutnya
+	keep, result := q.Paginate(len(movements), totalItems, func(i int) (interface{}, int64) {
+		if q.SortKey() == "created_at" {
+			return movements[i].CreatedAt, movements[i].ID
+		}
+		return movements[i].ID, movements[i].ID
+	})
+
+	return movements[:keep], result, nil
+}
+
+// --- IMPLEMENTATION: RECIPES ---
+
+// FindConsumptionsByService retrieves the consumption recipe of a service.
+func (r *inventoryRepository) FindConsumptionsByService(ctx context.Context, serviceID int64) ([]models.ServiceConsumption, error) {
+
+	query := `
+		SELECT sc.id, sc.service_id, sc.inventory_item_id, i.item_name, i.unit, sc.quantity_per_unit, sc.created_at
+		FROM service_consumptions sc
+		JOIN inventory_items i ON i.id = sc.inventory_item_id
+		WHERE sc.service_id = ?
+		ORDER BY i.item_name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("inventoryRepo.FindConsumptionsByService.Query: %w", err)
+	}
+	defer rows.Close()
+
+	consumptions := []models.ServiceConsumption{}
+	for rows.Next() {
+		var c models.ServiceConsumption
+		if err := rows.Scan(&c.ID, &c.ServiceID, &c.InventoryItemID, &c.ItemName, &c.Unit, &c.QuantityPerUnit, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("inventoryRepo.FindConsumptionsByService.Scan: %w", err)
+		}
+		consumptions = append(consumptions, c)
+	}
+
+	return consumptions, rows.Err()
+}
+
+// ReplaceConsumptions swaps the whole recipe of a service in one transaction.
+func (r *inventoryRepository) ReplaceConsumptions(ctx context.Context, serviceID int64, consumptions []models.ServiceConsumption) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("inventoryRepo.ReplaceConsumptions.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 1. Hapus resep lama
+	if _, err := tx.ExecContext(ctx, "DELETE FROM service_consumptions WHERE service_id = ?", serviceID); err != nil {
+		return fmt.Errorf("inventoryRepo.ReplaceConsumptions.Delete: %w", err)
+	}
+
+	// 2. Simpan resep baru
+	for i := range consumptions {
+		c := &consumptions[i]
+		res, err := tx.ExecContext(ctx,
+			"INSERT INTO service_consumptions (service_id, inventory_item_id, quantity_per_unit, created_at) VALUES (?, ?, ?, ?)",
+			serviceID, c.InventoryItemID, c.QuantityPerUnit, c.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("inventoryRepo.ReplaceConsumptions.Insert: %w", err)
+		}
+		if c.ID, err = res.LastInsertId(); err != nil {
+			return fmt.Errorf("inventoryRepo.ReplaceConsumptions.LastInsertId: %w", err)
+		}
+		c.ServiceID = serviceID
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("inventoryRepo.ReplaceConsumptions.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// --- IMPLEMENTATION: ORDER CONSUMPTION ---
+
+// HasOrderConsumptionTx reports whether stock has already been deducted for the order.
+func (r *inventoryRepository) HasOrderConsumptionTx(ctx context.Context, tx *sql.Tx, orderID int64) (bool, error) {
+
+	var exists bool
+	err := tx.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM inventory_movements WHERE order_id = ? AND movement_type = ?)",
+		orderID, models.InventoryMovementConsumption,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("inventoryRepo.HasOrderConsumptionTx: %w", err)
+	}
+
+	return exists, nil
+}
+
+// FindOrderConsumptionsTx sums the recipe of every order item (per kg or per pcs) into one quantity per active item.
+// Diurutkan berdasarkan ID bahan agar penguncian stok selalu berurutan sama (menghindari deadlock antar pesanan).
+func (r *inventoryRepository) FindOrderConsumptionsTx(ctx context.Context, tx *sql.Tx, orderID int64) ([]models.OrderConsumption, error) {
+
+	query := `
+		SELECT o.outlet_id, sc.inventory_item_id,
+			ROUND(SUM(sc.quantity_per_unit *
+				CASE WHEN s.unit = 'kg' AND oi.weight_kg IS NOT NULL THEN oi.weight_kg ELSE COALESCE(oi.quantity, 0) END), 3) AS total
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		JOIN services s ON s.id = oi.service_id
+		JOIN service_consumptions sc ON sc.service_id = oi.service_id
+		JOIN inventory_items i ON i.id = sc.inventory_item_id AND COALESCE(i.is_active, 1) = 1
+		WHERE oi.order_id = ?
+		GROUP BY o.outlet_id, sc.inventory_item_id
+		HAVING total > 0
+		ORDER BY sc.inventory_item_id ASC`
+
+	rows, err := tx.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("inventoryRepo.FindOrderConsumptionsTx.Query: %w", err)
+	}
+	defer rows.Close()
+
+	consumptions := []models.OrderConsumption{}
+	for rows.Next() {
+		var c models.OrderConsumption
+		if err := rows.Scan(&c.OutletID, &c.InventoryItemID, &c.Quantity); err != nil {
+			return nil, fmt.Errorf("inventoryRepo.FindOrderConsumptionsTx.Scan: %w", err)
+		}
+		consumptions = append(consumptions, c)
+	}
+
+	return consumptions, rows.Err()
+}
+
+// --- HELPER FUNCTION ---
+
+// applyStockMovement menghitung saldo setelah satu mutasi. Stok keluar melebihi saldo ditolak
+// dengan response.ErrInsufficientStock kecuali allowNegative (pemotongan otomatis pesanan).
+func applyStockMovement(balance quantity.Quantity, direction string, q quantity.Quantity, allowNegative bool) (quantity.Quantity, error) {
+
+	switch direction {
+	case models.InventoryIn:
+		return balance.Add(q), nil
+	case models.InventoryOut:
+		if q > balance && !allowNegative {
+			return 0, response.ErrInsufficientStock
+		}
+		return balance.Sub(q), nil
+	default:
+		return 0, fmt.Errorf("unknown direction %q", direction)
+	}
+}
+
+func scanInventoryStock(row rowScanner) (*models.InventoryStock, error) {
+	var s models.InventoryStock
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var updatedAtNull sql.NullTime
+
+	if err := row.Scan(
+		&s.Item.ID, &s.Item.ItemName, &s.Item.Unit, &s.Item.MinStock, &s.Item.IsActive, &s.Item.CreatedAt, &updatedAtNull,
+		&s.Quantity,
+	); err != nil {
+		return nil, err
+	}
+
+	if updatedAtNull.Valid {
+		s.Item.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &s, nil
+}
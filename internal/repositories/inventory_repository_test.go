@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/quantity"
+	"laundry-backend/pkg/response"
+)
+
+// TestApplyStockMovementManyFractions memastikan ribuan mutasi pecahan tidak menumpuk galat pembulatan.
+func TestApplyStockMovementManyFractions(t *testing.T) {
+
+	balance := quantity.Zero
+
+	// 1. 10.000 x stok masuk 0,1 → tepat 1000
+	for i := 0; i < 10000; i++ {
+		var err error
+		balance, err = applyStockMovement(balance, models.InventoryIn, quantity.FromMilli(100), false)
+		if err != nil {
+			t.Fatalf("stock in #%d: %v", i, err)
+		}
+	}
+	if balance != quantity.New(1000) {
+		t.Fatalf("balance after 10000 x 0.1 = %s, want 1000", balance)
+	}
+
+	// 2. Pemakaian campuran 0,003 / 0,017 / 0,2 / 0,03 / 0,75 sampai habis → tepat nol, tanpa sisa negatif kecil
+	steps := []quantity.Quantity{3, 17, 200, 30, 750}
+	total := quantity.Zero
+	for _, q := range steps {
+		total = total.Add(q)
+	}
+	rounds := int(balance / total)
+	for i := 0; i < rounds; i++ {
+		for _, q := range steps {
+			var err error
+			balance, err = applyStockMovement(balance, models.InventoryOut, q, false)
+			if err != nil {
+				t.Fatalf("round %d consume %s: %v", i, q, err)
+			}
+		}
+	}
+	if !balance.IsZero() {
+		t.Fatalf("balance after consuming everything = %s, want 0", balance)
+	}
+
+	// 3. Stok kosong: manual ditolak, pemotongan otomatis boleh minus
+	if _, err := applyStockMovement(balance, models.InventoryOut, quantity.FromMilli(1), false); !errors.Is(err, response.ErrInsufficientStock) {
+		t.Fatalf("consume from empty stock error = %v, want ErrInsufficientStock", err)
+	}
+	negative, err := applyStockMovement(balance, models.InventoryOut, quantity.FromMilli(1), true)
+	if err != nil || negative.String() != "-0.001" {
+		t.Fatalf("allowNegative consume = %s (%v), want -0.001", negative, err)
+	}
+}
+
+func TestApplyStockMovementUnknownDirection(t *testing.T) {
+	if _, err := applyStockMovement(0, "sideways", 1, false); err == nil {
+		t.Fatal("expected error for unknown direction")
+	}
+}
@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+)
+
+// MediaRepository mendefinisikan operasi database untuk metadata foto (file-nya ada di storage).
+type MediaRepository interface {
+
+	// Read Operations
+	FindOwner(ctx context.Context, ownerType string, ownerID int64) (*models.MediaOwner, error)
+	FindMediaByOwner(ctx context.Context, ownerType string, ownerID int64) ([]models.Media, error)
+	FindMediaByID(ctx context.Context, id int64) (*models.Media, error)
+
+	// Write Operations
+	InsertMedia(ctx context.Context, media *models.Media) error
+	DeleteMedia(ctx context.Context, id int64) error
+}
+
+// mediaRepository is the concrete implementation using sql.DB.
+type mediaRepository struct {
+	db *sql.DB
+}
+
+// NewMediaRepository creates a new instance of MediaRepository.
+func NewMediaRepository(db *sql.DB) MediaRepository {
+	return &mediaRepository{db: db}
+}
+
+const mediaSelect = `
+	SELECT m.id, m.outlet_id, m.owner_type, m.owner_id, m.object_key, m.thumbnail_key, m.content_type, m.size_bytes,
+		m.width, m.height, m.checksum_sha256, m.caption, m.uploaded_by, COALESCE(u.full_name, '-'), m.created_at
+	FROM media m
+	LEFT JOIN users u ON u.id = m.uploaded_by
+`
+
+// --- IMPLEMENTATION: READ ---
+
+// FindOwner retrieves the delivery / order item (outlet aktif) or user that media is attached to.
+func (r *mediaRepository) FindOwner(ctx context.Context, ownerType string, ownerID int64) (*models.MediaOwner, error) {
+
+	// 1. Pilih query sesuai jenis pemilik (pengiriman & item pesanan dibatasi outlet aktif)
+	var query string
+	var args []interface{}
+	switch ownerType {
+	case models.MediaOwnerDelivery:
+		scope, scopeArgs := outletFilter(ctx, "d.outlet_id")
+		query = "SELECT d.id, d.outlet_id, d.courier_id FROM deliveries d WHERE d.id = ?" + scope
+		args = append([]interface{}{ownerID}, scopeArgs...)
+	case models.MediaOwnerOrderItem:
+		scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+		query = "SELECT oi.id, o.outlet_id, NULL FROM order_items oi JOIN orders o ON o.id = oi.order_id WHERE oi.id = ?" + scope
+		args = append([]interface{}{ownerID}, scopeArgs...)
+	case models.MediaOwnerUser:
+		query = "SELECT u.id, u.outlet_id, NULL FROM users u WHERE u.id = ?"
+		args = []interface{}{ownerID}
+	default:
+		return nil, fmt.Errorf("%w: unknown owner_type %q", response.ErrValidation, ownerType)
+	}
+
+	// 2. Eksekusi
+	owner := models.MediaOwner{Type: ownerType}
+	var outletNull, courierNull sql.NullInt64
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&owner.ID, &outletNull, &courierNull)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("mediaRepo.FindOwner: %w", err)
+	}
+	owner.OutletID = nullInt64Ptr(outletNull)
+	owner.CourierID = nullInt64Ptr(courierNull)
+
+	return &owner, nil
+}
+
+// FindMediaByOwner retrieves every photo attached to one owner, newest first.
+func (r *mediaRepository) FindMediaByOwner(ctx context.Context, ownerType string, ownerID int64) ([]models.Media, error) {
+
+	rows, err := r.db.QueryContext(ctx, mediaSelect+"WHERE m.owner_type = ? AND m.owner_id = ? ORDER BY m.created_at DESC, m.id DESC", ownerType, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("mediaRepo.FindMediaByOwner.Query: %w", err)
+	}
+	defer rows.Close()
+
+	media := []models.Media{}
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return nil, fmt.Errorf("mediaRepo.FindMediaByOwner.Scan: %w", err)
+		}
+		media = append(media, *m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("mediaRepo.FindMediaByOwner.Rows: %w", err)
+	}
+
+	return media, nil
+}
+
+// FindMediaByID retrieves one photo. Akses dicek service lewat FindOwner (media user tidak terikat outlet).
+func (r *mediaRepository) FindMediaByID(ctx context.Context, id int64) (*models.Media, error) {
+
+	m, err := scanMedia(r.db.QueryRowContext(ctx, mediaSelect+"WHERE m.id = ?", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("mediaRepo.FindMediaByID: %w", err)
+	}
+
+	return m, nil
+}
+
+// --- IMPLEMENTATION: WRITE ---
+
+// InsertMedia stores the metadata of an uploaded photo.
+func (r *mediaRepository) InsertMedia(ctx context.Context, media *models.Media) error {
+
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO media (outlet_id, owner_type, owner_id, object_key, thumbnail_key, content_type, size_bytes,
+			width, height, checksum_sha256, caption, uploaded_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		media.OutletID, // Pointer, aman jika nil
+		media.OwnerType,
+		media.OwnerID,
+		media.ObjectKey,
+		media.ThumbnailKey,
+		media.ContentType,
+		media.SizeBytes,
+		media.Width,
+		media.Height,
+		media.ChecksumSHA256,
+		media.Caption,
+		media.UploadedBy,
+		media.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("mediaRepo.InsertMedia: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("mediaRepo.InsertMedia.LastInsertId: %w", err)
+	}
+	media.ID = id
+
+	return nil
+}
+
+// DeleteMedia permanently removes the metadata row of a photo.
+func (r *mediaRepository) DeleteMedia(ctx context.Context, id int64) error {
+
+	res, err := r.db.ExecContext(ctx, "DELETE FROM media WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("mediaRepo.DeleteMedia: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("mediaRepo.DeleteMedia.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+func scanMedia(row rowScanner) (*models.Media, error) {
+	var m models.Media
+	var outletNull sql.NullInt64
+	var captionNull sql.NullString
+
+	err := row.Scan(
+		&m.ID, &outletNull, &m.OwnerType, &m.OwnerID, &m.ObjectKey, &m.ThumbnailKey, &m.ContentType, &m.SizeBytes,
+		&m.Width, &m.Height, &m.ChecksumSHA256, &captionNull, &m.UploadedBy, &m.UploadedByName, &m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.OutletID = nullInt64Ptr(outletNull)
+	m.Caption = nullStringPtr(captionNull)
+
+	return &m, nil
+}
@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// MembershipRepository mendefinisikan semua operasi database untuk level member pelanggan.
+type MembershipRepository interface {
+
+	// Create Operations
+	InsertTier(ctx context.Context, tier *models.MembershipTier) error
+
+	// Read Operations
+	FindAll(ctx context.Context, status string) ([]models.MembershipTier, error)
+	FindByID(ctx context.Context, id int64) (*models.MembershipTier, error)
+	FindByName(ctx context.Context, tierName string) (*models.MembershipTier, error)
+	FindCustomerTier(ctx context.Context, customerID int64) (*models.MembershipTier, error)
+
+	// Update Operations
+	UpdateTier(ctx context.Context, tier *models.MembershipTier) error
+	AssignCustomerTier(ctx context.Context, customerID int64, tierID *int64) error
+
+	// Delete Operations (Soft Delete)
+	DeleteTier(ctx context.Context, id int64) error
+}
+
+// membershipRepository is the concrete implementation using sql.DB.
+type membershipRepository struct {
+	db *sql.DB
+}
+
+// NewMembershipRepository creates a new instance of MembershipRepository.
+func NewMembershipRepository(db *sql.DB) MembershipRepository {
+	return &membershipRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// InsertTier creates a new membership tier.
+func (r *membershipRepository) InsertTier(ctx context.Context, tier *models.MembershipTier) error {
+
+	query := `
+		INSERT INTO membership_tiers (tier_name, discount_percent, max_discount, point_multiplier, is_active, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	res, err := r.db.ExecContext(ctx, query,
+		tier.TierName,
+		tier.DiscountPercent,
+		tier.MaxDiscount,
+		tier.PointMultiplier,
+		tier.IsActive,
+		tier.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("membershipRepo.InsertTier.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("membershipRepo.InsertTier.LastInsertId: %w", err)
+	}
+
+	tier.ID = id
+	return nil
+}
+
+// FindAll retrieves every membership tier; the list is small so it is not paginated.
+func (r *membershipRepository) FindAll(ctx context.Context, status string) ([]models.MembershipTier, error) {
+
+	// 1. Terapkan filter status aktif/non-aktif
+	whereClause := "WHERE 1=1"
+	if status == "1" {
+		whereClause += " AND is_active = 1"
+	} else if status == "0" {
+		whereClause += " AND is_active = 0"
+	}
+
+	// 2. Eksekusi query (diskon terkecil di atas)
+	query := fmt.Sprintf(`
+		SELECT id, tier_name, discount_percent, max_discount, point_multiplier, is_active, created_at, updated_at
+		FROM membership_tiers
+		%s
+		ORDER BY discount_percent ASC, id ASC`, whereClause)
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("membershipRepo.FindAll.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 3. Mapping hasil query
+	tiers := []models.MembershipTier{}
+	for rows.Next() {
+		tier, err := scanMembershipTier(rows)
+		if err != nil {
+			return nil, fmt.Errorf("membershipRepo.FindAll.Scan: %w", err)
+		}
+		tiers = append(tiers, *tier)
+	}
+
+	return tiers, rows.Err()
+}
+
+// FindByID retrieves a single membership tier by ID.
+func (r *membershipRepository) FindByID(ctx context.Context, id int64) (*models.MembershipTier, error) {
+
+	query := `
+		SELECT id, tier_name, discount_percent, max_discount, point_multiplier, is_active, created_at, updated_at
+		FROM membership_tiers
+		WHERE id = ?
+	`
+	tier, err := scanMembershipTier(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("membershipRepo.FindByID: %w", err)
+	}
+
+	return tier, nil
+}
+
+// FindByName retrieves a single membership tier by its exact name (Useful for duplicate validation).
+func (r *membershipRepository) FindByName(ctx context.Context, tierName string) (*models.MembershipTier, error) {
+
+	query := `
+		SELECT id, tier_name, discount_percent, max_discount, point_multiplier, is_active, created_at, updated_at
+		FROM membership_tiers
+		WHERE tier_name = ?
+	`
+	tier, err := scanMembershipTier(r.db.QueryRowContext(ctx, query, tierName))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("membershipRepo.FindByName: %w", err)
+	}
+
+	return tier, nil
+}
+
+// FindCustomerTier retrieves the active tier of a customer, or nil when the customer is not a member.
+func (r *membershipRepository) FindCustomerTier(ctx context.Context, customerID int64) (*models.MembershipTier, error) {
+
+	query := `
+		SELECT t.id, t.tier_name, t.discount_percent, t.max_discount, t.point_multiplier, t.is_active, t.created_at, t.updated_at
+		FROM customers c
+		JOIN membership_tiers t ON t.id = c.membership_tier_id
+		WHERE c.id = ? AND t.is_active = 1
+	`
+	tier, err := scanMembershipTier(r.db.QueryRowContext(ctx, query, customerID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("membershipRepo.FindCustomerTier: %w", err)
+	}
+
+	return tier, nil
+}
+
+// UpdateTier updates an existing membership tier.
+func (r *membershipRepository) UpdateTier(ctx context.Context, tier *models.MembershipTier) error {
+
+	query := `
+		UPDATE membership_tiers
+		SET tier_name = ?, discount_percent = ?, max_discount = ?, point_multiplier = ?, is_active = ?, updated_at = ?
+		WHERE id = ?
+	`
+	res, err := r.db.ExecContext(ctx, query,
+		tier.TierName,
+		tier.DiscountPercent,
+		tier.MaxDiscount,
+		tier.PointMultiplier,
+		tier.IsActive,
+		tier.UpdatedAt,
+		tier.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("membershipRepo.UpdateTier.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("membershipRepo.UpdateTier.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// AssignCustomerTier sets (or clears, when tierID is nil) the membership tier of a customer.
+func (r *membershipRepository) AssignCustomerTier(ctx context.Context, customerID int64, tierID *int64) error {
+
+	res, err := r.db.ExecContext(ctx, "UPDATE customers SET membership_tier_id = ? WHERE id = ?", tierID, customerID)
+	if err != nil {
+		return fmt.Errorf("membershipRepo.AssignCustomerTier.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("membershipRepo.AssignCustomerTier.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		// MySQL mengembalikan 0 jika nilainya sama, pastikan pelanggan memang ada
+		var exists int
+		if err := r.db.QueryRowContext(ctx, "SELECT 1 FROM customers WHERE id = ?", customerID).Scan(&exists); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return response.ErrNotFound
+			}
+			return fmt.Errorf("membershipRepo.AssignCustomerTier.Exists: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteTier performs a soft delete by setting is_active to false (0).
+func (r *membershipRepository) DeleteTier(ctx context.Context, id int64) error {
+
+	res, err := r.db.ExecContext(ctx, "UPDATE membership_tiers SET is_active = 0 WHERE id = ? AND is_active = 1", id)
+	if err != nil {
+		return fmt.Errorf("membershipRepo.DeleteTier.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("membershipRepo.DeleteTier.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+func scanMembershipTier(row rowScanner) (*models.MembershipTier, error) {
+	var tier models.MembershipTier
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var maxDiscountNull money.NullAmount
+	var updatedAtNull sql.NullTime
+
+	err := row.Scan(
+		&tier.ID, &tier.TierName, &tier.DiscountPercent, &maxDiscountNull, &tier.PointMultiplier,
+		&tier.IsActive, &tier.CreatedAt, &updatedAtNull,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxDiscountNull.Valid {
+		tier.MaxDiscount = &maxDiscountNull.Amount
+	}
+	if updatedAtNull.Valid {
+		tier.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &tier, nil
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlErrDuplicateEntry adalah kode error MySQL ER_DUP_ENTRY (pelanggaran unique index / primary key).
+const mysqlErrDuplicateEntry = 1062
+
+// isDuplicateEntry melaporkan apakah err berasal dari pelanggaran unique index di MySQL.
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestIsDuplicateEntry(t *testing.T) {
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "duplicate entry", err: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, want: true},
+		{name: "wrapped duplicate entry", err: fmt.Errorf("insert: %w", &mysql.MySQLError{Number: 1062}), want: true},
+		{name: "other mysql error", err: &mysql.MySQLError{Number: 1406, Message: "Data too long"}, want: false},
+		{name: "plain error", err: errors.New("connection refused"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDuplicateEntry(tt.err); got != tt.want {
+				t.Fatalf("isDuplicateEntry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
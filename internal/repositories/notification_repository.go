@@ -0,0 +1,443 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// NotificationRepository mendefinisikan operasi database untuk template pesan & antrean (outbox) notifikasi.
+type NotificationRepository interface {
+
+	// Template
+	FindTemplates(ctx context.Context) ([]models.NotificationTemplate, error)
+	FindTemplate(ctx context.Context, eventType string) (*models.NotificationTemplate, error)
+	UpdateTemplate(ctx context.Context, tmpl *models.NotificationTemplate) error
+
+	// Outbox: penulisan di dalam transaksi milik pemanggil
+	FindContactTx(ctx context.Context, tx *sql.Tx, orderID int64) (*models.NotificationContact, error)
+	EnqueueTx(ctx context.Context, tx *sql.Tx, msg *models.OutboxMessage) error
+
+	// Outbox: worker
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.OutboxMessage, error)
+	MarkSent(ctx context.Context, id int64, channel, message string, sentAt time.Time) error
+	MarkRetry(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	MarkDone(ctx context.Context, id int64, status string, message *string, lastError *string) error
+
+	// Outbox: monitoring Owner
+	FindOutbox(ctx context.Context, status string, orderID int64, limit, offset int) ([]models.OutboxMessage, int64, error)
+	FindOutboxByID(ctx context.Context, id int64) (*models.OutboxMessage, error)
+	Requeue(ctx context.Context, id int64, now time.Time) error
+}
+
+// notificationRepository is the concrete implementation using sql.DB.
+type notificationRepository struct {
+	db *sql.DB
+}
+
+// NewNotificationRepository creates a new instance of NotificationRepository.
+func NewNotificationRepository(db *sql.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+const outboxColumns = `
+	id, event_type, order_id, recipient, payload, status, channel, message,
+	attempts, next_attempt_at, last_error, sent_at, created_at
+`
+
+// FindTemplates retrieves the template of every event.
+func (r *notificationRepository) FindTemplates(ctx context.Context) ([]models.NotificationTemplate, error) {
+
+	query := `
+		SELECT id, event_type, body, is_active, updated_by, created_at, updated_at
+		FROM notification_templates
+		ORDER BY id ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("notificationRepo.FindTemplates.Query: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []models.NotificationTemplate{}
+	for rows.Next() {
+		tmpl, err := scanNotificationTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("notificationRepo.FindTemplates.Scan: %w", err)
+		}
+		templates = append(templates, *tmpl)
+	}
+
+	return templates, rows.Err()
+}
+
+// FindTemplate retrieves the template of one event.
+func (r *notificationRepository) FindTemplate(ctx context.Context, eventType string) (*models.NotificationTemplate, error) {
+
+	query := `
+		SELECT id, event_type, body, is_active, updated_by, created_at, updated_at
+		FROM notification_templates
+		WHERE event_type = ?
+	`
+	tmpl, err := scanNotificationTemplate(r.db.QueryRowContext(ctx, query, eventType))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("notificationRepo.FindTemplate: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// UpdateTemplate saves the body and active flag of a template.
+func (r *notificationRepository) UpdateTemplate(ctx context.Context, tmpl *models.NotificationTemplate) error {
+
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE notification_templates
+		SET body = ?, is_active = ?, updated_by = ?, updated_at = ?
+		WHERE event_type = ?`,
+		tmpl.Body,
+		tmpl.IsActive,
+		tmpl.UpdatedBy,
+		tmpl.UpdatedAt,
+		tmpl.EventType,
+	)
+	if err != nil {
+		return fmt.Errorf("notificationRepo.UpdateTemplate: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("notificationRepo.UpdateTemplate.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindContactTx reads the order and customer contact inside the caller's transaction,
+// so the data reflects changes that are not committed yet (cth: status baru).
+func (r *notificationRepository) FindContactTx(ctx context.Context, tx *sql.Tx, orderID int64) (*models.NotificationContact, error) {
+
+	query := `
+		SELECT o.id, o.invoice_number, COALESCE(NULLIF(o.customer_name, ''), c.full_name),
+			COALESCE(NULLIF(o.customer_phone, ''), c.phone_number), o.is_delivery, o.grand_total,
+			o.payment_status, o.status_internal, o.estimated_ready_at
+		FROM orders o
+		LEFT JOIN customers c ON c.id = o.customer_id
+		WHERE o.id = ?
+	`
+
+	var contact models.NotificationContact
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var nameNull, phoneNull, paymentStatusNull, statusNull sql.NullString
+	var isDeliveryNull sql.NullBool
+	var readyAtNull sql.NullTime
+
+	err := tx.QueryRowContext(ctx, query, orderID).Scan(
+		&contact.OrderID, &contact.InvoiceNumber, &nameNull, &phoneNull, &isDeliveryNull, &contact.GrandTotal,
+		&paymentStatusNull, &statusNull, &readyAtNull,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("notificationRepo.FindContactTx: %w", err)
+	}
+
+	contact.CustomerName = nullStringPtr(nameNull)
+	contact.Phone = nullStringPtr(phoneNull)
+	contact.IsDelivery = isDeliveryNull.Bool
+	contact.PaymentStatus = paymentStatusNull.String
+	contact.StatusInternal = statusNull.String
+	if readyAtNull.Valid {
+		contact.EstimatedReadyAt = &readyAtNull.Time
+	}
+
+	return &contact, nil
+}
+
+// EnqueueTx appends a pending message to the outbox inside the caller's transaction.
+func (r *notificationRepository) EnqueueTx(ctx context.Context, tx *sql.Tx, msg *models.OutboxMessage) error {
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO notification_outbox (event_type, order_id, recipient, payload, status, next_attempt_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		msg.EventType,
+		msg.OrderID,
+		msg.Recipient,
+		msg.Payload,
+		models.OutboxPending,
+		msg.NextAttemptAt,
+		msg.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("notificationRepo.EnqueueTx: %w", err)
+	}
+
+	if msg.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("notificationRepo.EnqueueTx.LastInsertId: %w", err)
+	}
+	msg.Status = models.OutboxPending
+
+	return nil
+}
+
+// ClaimDue locks up to limit due messages for this worker and marks them as processing until leaseUntil.
+//
+// Pesan 'processing' yang masa sewanya habis (worker mati di tengah jalan) ikut diambil ulang.
+// SKIP LOCKED membuat beberapa instance server aman berjalan bersamaan tanpa mengirim pesan ganda.
+func (r *notificationRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.OutboxMessage, error) {
+
+	// 1. Mulai transaksi
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("notificationRepo.ClaimDue.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Kunci pesan yang sudah jatuh tempo
+	query := `SELECT ` + outboxColumns + `
+		FROM notification_outbox
+		WHERE status IN (?, ?) AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC, id ASC
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.QueryContext(ctx, query, models.OutboxPending, models.OutboxProcessing, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("notificationRepo.ClaimDue.Query: %w", err)
+	}
+
+	messages := []models.OutboxMessage{}
+	for rows.Next() {
+		msg, err := scanOutboxMessage(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("notificationRepo.ClaimDue.Scan: %w", err)
+		}
+		messages = append(messages, *msg)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("notificationRepo.ClaimDue.Rows: %w", err)
+	}
+	if len(messages) == 0 {
+		return messages, nil
+	}
+
+	// 3. Tandai sebagai 'processing' & hitung percobaan
+	placeholders := make([]string, len(messages))
+	args := []interface{}{models.OutboxProcessing, leaseUntil}
+	for i := range messages {
+		placeholders[i] = "?"
+		args = append(args, messages[i].ID)
+
+		messages[i].Status = models.OutboxProcessing
+		messages[i].Attempts++
+		messages[i].NextAttemptAt = leaseUntil
+	}
+	update := `
+		UPDATE notification_outbox
+		SET status = ?, attempts = attempts + 1, next_attempt_at = ?
+		WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+	if _, err := tx.ExecContext(ctx, update, args...); err != nil {
+		return nil, fmt.Errorf("notificationRepo.ClaimDue.Update: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("notificationRepo.ClaimDue.Commit: %w", err)
+	}
+
+	return messages, nil
+}
+
+// MarkSent records a successful delivery.
+func (r *notificationRepository) MarkSent(ctx context.Context, id int64, channel, message string, sentAt time.Time) error {
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE notification_outbox
+		SET status = ?, channel = ?, message = ?, last_error = NULL, sent_at = ?
+		WHERE id = ?`,
+		models.OutboxSent, channel, message, sentAt, id,
+	)
+	if err != nil {
+		return fmt.Errorf("notificationRepo.MarkSent: %w", err)
+	}
+
+	return nil
+}
+
+// MarkRetry puts a failed message back to pending until nextAttemptAt.
+func (r *notificationRepository) MarkRetry(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE notification_outbox
+		SET status = ?, last_error = ?, next_attempt_at = ?
+		WHERE id = ?`,
+		models.OutboxPending, lastError, nextAttemptAt, id,
+	)
+	if err != nil {
+		return fmt.Errorf("notificationRepo.MarkRetry: %w", err)
+	}
+
+	return nil
+}
+
+// MarkDone closes a message that will not be retried (failed or skipped).
+func (r *notificationRepository) MarkDone(ctx context.Context, id int64, status string, message *string, lastError *string) error {
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE notification_outbox
+		SET status = ?, message = ?, last_error = ?
+		WHERE id = ?`,
+		status, message, lastError, id,
+	)
+	if err != nil {
+		return fmt.Errorf("notificationRepo.MarkDone: %w", err)
+	}
+
+	return nil
+}
+
+// FindOutbox retrieves outbox messages, newest first. Empty status / zero orderID means no filter.
+func (r *notificationRepository) FindOutbox(ctx context.Context, status string, orderID int64, limit, offset int) ([]models.OutboxMessage, int64, error) {
+
+	// 1. Susun filter dinamis
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	if status != "" {
+		where += " AND status = ?"
+		args = append(args, status)
+	}
+	if orderID > 0 {
+		where += " AND order_id = ?"
+		args = append(args, orderID)
+	}
+
+	// 2. Hitung total baris untuk Meta Pagination
+	var totalItems int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM notification_outbox"+where, args...).Scan(&totalItems); err != nil {
+		return nil, 0, fmt.Errorf("notificationRepo.FindOutbox.Count: %w", err)
+	}
+
+	// 3. Ambil data
+	query := `SELECT ` + outboxColumns + ` FROM notification_outbox` + where + ` ORDER BY id DESC LIMIT ? OFFSET ?`
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("notificationRepo.FindOutbox.Query: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []models.OutboxMessage{}
+	for rows.Next() {
+		msg, err := scanOutboxMessage(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("notificationRepo.FindOutbox.Scan: %w", err)
+		}
+		messages = append(messages, *msg)
+	}
+
+	return messages, totalItems, rows.Err()
+}
+
+// FindOutboxByID retrieves one outbox message.
+func (r *notificationRepository) FindOutboxByID(ctx context.Context, id int64) (*models.OutboxMessage, error) {
+
+	query := `SELECT ` + outboxColumns + ` FROM notification_outbox WHERE id = ?`
+	msg, err := scanOutboxMessage(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("notificationRepo.FindOutboxByID: %w", err)
+	}
+
+	return msg, nil
+}
+
+// Requeue resets a failed or skipped message so the worker sends it again with a fresh attempt counter.
+func (r *notificationRepository) Requeue(ctx context.Context, id int64, now time.Time) error {
+
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE notification_outbox
+		SET status = ?, attempts = 0, next_attempt_at = ?, last_error = NULL
+		WHERE id = ? AND status IN (?, ?)`,
+		models.OutboxPending, now, id, models.OutboxFailed, models.OutboxSkipped,
+	)
+	if err != nil {
+		return fmt.Errorf("notificationRepo.Requeue: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("notificationRepo.Requeue.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+func scanNotificationTemplate(row rowScanner) (*models.NotificationTemplate, error) {
+	var tmpl models.NotificationTemplate
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var isActiveNull sql.NullBool
+	var updatedByNull sql.NullInt64
+	var createdAtNull, updatedAtNull sql.NullTime
+
+	if err := row.Scan(
+		&tmpl.ID, &tmpl.EventType, &tmpl.Body, &isActiveNull, &updatedByNull, &createdAtNull, &updatedAtNull,
+	); err != nil {
+		return nil, err
+	}
+
+	tmpl.IsActive = isActiveNull.Bool
+	if updatedByNull.Valid {
+		tmpl.UpdatedBy = &updatedByNull.Int64
+	}
+	tmpl.CreatedAt = createdAtNull.Time
+	if updatedAtNull.Valid {
+		tmpl.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &tmpl, nil
+}
+
+func scanOutboxMessage(row rowScanner) (*models.OutboxMessage, error) {
+	var msg models.OutboxMessage
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var channelNull, messageNull, lastErrorNull sql.NullString
+	var sentAtNull, createdAtNull sql.NullTime
+
+	if err := row.Scan(
+		&msg.ID, &msg.EventType, &msg.OrderID, &msg.Recipient, &msg.Payload, &msg.Status, &channelNull, &messageNull,
+		&msg.Attempts, &msg.NextAttemptAt, &lastErrorNull, &sentAtNull, &createdAtNull,
+	); err != nil {
+		return nil, err
+	}
+
+	msg.Channel = nullStringPtr(channelNull)
+	msg.Message = nullStringPtr(messageNull)
+	msg.LastError = nullStringPtr(lastErrorNull)
+	if sentAtNull.Valid {
+		msg.SentAt = &sentAtNull.Time
+	}
+	msg.CreatedAt = createdAtNull.Time
+
+	return &msg, nil
+}
@@ -0,0 +1,782 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// OrderRepository defines the contract for order creation and order detail queries.
+type OrderRepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+
+	// Pelanggan (master data)
+	FindCustomerByID(ctx context.Context, id int64) (*models.Customer, error)
+	FindCustomerByPhone(ctx context.Context, phone string) (*models.Customer, error)
+	InsertCustomerTx(ctx context.Context, tx *sql.Tx, customer *models.Customer) error
+
+	// NextInvoiceNumberTx membuat nomor nota harian INV-<kode outlet>-YYMMDD-NNN dari penghitung (outlet, hari).
+	// Baris penghitung terkunci sampai transaksi selesai sehingga dua kasir tidak mendapat nomor yang sama.
+	NextInvoiceNumberTx(ctx context.Context, tx *sql.Tx, outletID int64, day time.Time) (string, error)
+
+	InsertOrderTx(ctx context.Context, tx *sql.Tx, order *models.Order) error
+	InsertItemsTx(ctx context.Context, tx *sql.Tx, orderID int64, items []models.OrderItem) error
+	InsertDeliveryTx(ctx context.Context, tx *sql.Tx, delivery *models.Delivery) error
+	InsertPaymentTx(ctx context.Context, tx *sql.Tx, payment *models.Payment) error
+
+	// FindDetail mengambil pesanan lengkap (pesanan outlet lain di luar outlet aktif dianggap tidak ada).
+	FindDetail(ctx context.Context, id int64) (*models.OrderDetail, error)
+
+	// FindOrders mengambil satu halaman daftar pesanan outlet aktif (GET /orders).
+	FindOrders(ctx context.Context, params listquery.Params) ([]models.OrderSummary, *listquery.Result, error)
+
+	// StreamOrders membaca semua pesanan yang cocok dengan filter & sorting list (tanpa pagination) untuk export.
+	StreamOrders(ctx context.Context, params listquery.Params, fn func(*models.OrderSummary) error) error
+}
+
+// orderRepository is the concrete implementation using sql.DB.
+type orderRepository struct {
+	db *sql.DB
+}
+
+// NewOrderRepository creates a new instance of OrderRepository.
+func NewOrderRepository(db *sql.DB) OrderRepository {
+	return &orderRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// BeginTx starts the order creation transaction.
+func (r *orderRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return r.db.BeginTx(ctx, nil)
+}
+
+// FindCustomerByID retrieves a customer by its ID.
+func (r *orderRepository) FindCustomerByID(ctx context.Context, id int64) (*models.Customer, error) {
+	query := `SELECT id, full_name, phone_number, address, COALESCE(is_active, 1), created_at, updated_at FROM customers WHERE id = ?`
+	customer, err := scanCustomer(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		return nil, fmt.Errorf("orderRepo.FindCustomerByID: %w", err)
+	}
+	return customer, nil
+}
+
+// FindCustomerByPhone retrieves a customer by its unique phone number.
+func (r *orderRepository) FindCustomerByPhone(ctx context.Context, phone string) (*models.Customer, error) {
+	query := `SELECT id, full_name, phone_number, address, COALESCE(is_active, 1), created_at, updated_at FROM customers WHERE phone_number = ?`
+	customer, err := scanCustomer(r.db.QueryRowContext(ctx, query, phone))
+	if err != nil {
+		return nil, fmt.Errorf("orderRepo.FindCustomerByPhone: %w", err)
+	}
+	return customer, nil
+}
+
+// InsertCustomerTx registers a walk-in customer inside the order transaction.
+// Nomor HP yang baru saja didaftarkan transaksi lain dikembalikan sebagai ErrDuplicate.
+func (r *orderRepository) InsertCustomerTx(ctx context.Context, tx *sql.Tx, customer *models.Customer) error {
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO customers (full_name, phone_number, address, is_active, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		customer.FullName,
+		customer.PhoneNumber,
+		customer.Address, // Pointer, aman jika nil
+		customer.IsActive,
+		customer.CreatedAt,
+	)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return response.ErrDuplicate
+		}
+		return fmt.Errorf("orderRepo.InsertCustomerTx.Exec: %w", err)
+	}
+
+	if customer.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("orderRepo.InsertCustomerTx.LastInsertId: %w", err)
+	}
+
+	return nil
+}
+
+// NextInvoiceNumberTx returns the next daily invoice number of an outlet.
+func (r *orderRepository) NextInvoiceNumberTx(ctx context.Context, tx *sql.Tx, outletID int64, day time.Time) (string, error) {
+
+	var code string
+	if err := tx.QueryRowContext(ctx, "SELECT code FROM outlets WHERE id = ?", outletID).Scan(&code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", response.ErrNotFound
+		}
+		return "", fmt.Errorf("orderRepo.NextInvoiceNumberTx.Outlet: %w", err)
+	}
+
+	// 1. Naikkan penghitung hari itu (baris baru mulai dari 1). Hanya baris (outlet, hari) ini yang terkunci,
+	//    jadi tidak ada gap lock di tabel orders yang bisa saling menunggu (deadlock 1213).
+	//    Nota cuci ulang (INV-...-R1) tidak memakai penghitung karena nomornya turunan nota induk.
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO invoice_counters (outlet_id, invoice_day, seq)
+		VALUES (?, ?, LAST_INSERT_ID(1))
+		ON DUPLICATE KEY UPDATE seq = LAST_INSERT_ID(seq + 1)`,
+		outletID, day.Format("2006-01-02"),
+	); err != nil {
+		return "", fmt.Errorf("orderRepo.NextInvoiceNumberTx.Counter: %w", err)
+	}
+
+	// 2. LAST_INSERT_ID() berlaku per koneksi, dan transaksi selalu memakai koneksi yang sama
+	var seq int64
+	if err := tx.QueryRowContext(ctx, "SELECT LAST_INSERT_ID()").Scan(&seq); err != nil {
+		return "", fmt.Errorf("orderRepo.NextInvoiceNumberTx.Seq: %w", err)
+	}
+
+	return fmt.Sprintf("INV-%s-%s-%03d", code, day.Format("060102"), seq), nil
+}
+
+// InsertOrderTx stores the order header.
+func (r *orderRepository) InsertOrderTx(ctx context.Context, tx *sql.Tx, order *models.Order) error {
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO orders (invoice_number, outlet_id, customer_id, customer_name, customer_phone, customer_address,
+			is_delivery, subtotal, discount_total, service_charge_total, tax_total, grand_total, payment_status, status_internal,
+			estimated_ready_at, notes, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		order.InvoiceNumber,
+		order.OutletID,
+		order.CustomerID,
+		order.CustomerName,
+		order.CustomerPhone,
+		order.CustomerAddress,
+		order.IsDelivery,
+		order.Subtotal,
+		order.DiscountTotal,
+		order.ServiceChargeTotal,
+		order.TaxTotal,
+		order.GrandTotal,
+		order.PaymentStatus,
+		order.StatusInternal,
+		order.EstimatedReadyAt,
+		order.Notes,
+		order.CreatedBy,
+		order.CreatedAt,
+	)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return response.ErrDuplicate
+		}
+		return fmt.Errorf("orderRepo.InsertOrderTx.Exec: %w", err)
+	}
+
+	if order.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("orderRepo.InsertOrderTx.LastInsertId: %w", err)
+	}
+
+	return nil
+}
+
+// InsertItemsTx stores every order item and its add-on snapshot.
+func (r *orderRepository) InsertItemsTx(ctx context.Context, tx *sql.Tx, orderID int64, items []models.OrderItem) error {
+
+	for i := range items {
+		item := &items[i]
+		item.OrderID = orderID
+
+		// 1. Simpan item
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO order_items (order_id, service_id, item_notes, quantity, qty_pieces, weight_kg, unit_price, subtotal)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			item.OrderID,
+			item.ServiceID,
+			item.ItemNotes,
+			item.Quantity,
+			item.QtyPieces,
+			item.WeightKg,
+			item.UnitPrice,
+			item.Subtotal,
+		)
+		if err != nil {
+			return fmt.Errorf("orderRepo.InsertItemsTx.Item: %w", err)
+		}
+		if item.ID, err = res.LastInsertId(); err != nil {
+			return fmt.Errorf("orderRepo.InsertItemsTx.LastInsertId: %w", err)
+		}
+
+		// 2. Simpan snapshot add-on item tersebut
+		for j := range item.Addons {
+			addon := &item.Addons[j]
+			addon.OrderItemID = item.ID
+			res, err := tx.ExecContext(ctx, `
+				INSERT INTO order_item_addons (order_item_id, addon_id, addon_name, charge_type, charge_value, charge_basis, amount)
+				VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				addon.OrderItemID,
+				addon.AddonID,
+				addon.AddonName,
+				addon.ChargeType,
+				addon.ChargeValue,
+				addon.ChargeBasis,
+				addon.Amount,
+			)
+			if err != nil {
+				return fmt.Errorf("orderRepo.InsertItemsTx.Addon: %w", err)
+			}
+			if addon.ID, err = res.LastInsertId(); err != nil {
+				return fmt.Errorf("orderRepo.InsertItemsTx.AddonLastInsertId: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// InsertDeliveryTx stores the delivery record of a delivery order.
+func (r *orderRepository) InsertDeliveryTx(ctx context.Context, tx *sql.Tx, delivery *models.Delivery) error {
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO deliveries (order_id, outlet_id, shipping_cost, created_at)
+		VALUES (?, ?, ?, ?)`,
+		delivery.OrderID,
+		delivery.OutletID,
+		delivery.ShippingCost,
+		delivery.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("orderRepo.InsertDeliveryTx.Exec: %w", err)
+	}
+
+	if delivery.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("orderRepo.InsertDeliveryTx.LastInsertId: %w", err)
+	}
+
+	return nil
+}
+
+// InsertPaymentTx stores the bill (and the upfront payment, if any) of an order.
+func (r *orderRepository) InsertPaymentTx(ctx context.Context, tx *sql.Tx, payment *models.Payment) error {
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO payments (order_id, outlet_id, shift_id, method, amount, amount_received, amount_change, reference_no,
+			status, paid_at, created_by, collected_by, collected_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		payment.OrderID,
+		payment.OutletID,
+		payment.ShiftID,
+		payment.Method,
+		payment.Amount,
+		payment.AmountReceived,
+		payment.AmountChange,
+		payment.ReferenceNo,
+		payment.Status,
+		payment.PaidAt,
+		payment.CreatedBy,
+		payment.CollectedBy,
+		payment.CollectedAt,
+		payment.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("orderRepo.InsertPaymentTx.Exec: %w", err)
+	}
+
+	if payment.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("orderRepo.InsertPaymentTx.LastInsertId: %w", err)
+	}
+
+	return nil
+}
+
+// FindDetail retrieves an order with its items, latest payment, delivery and status history.
+func (r *orderRepository) FindDetail(ctx context.Context, id int64) (*models.OrderDetail, error) {
+
+	// 1. Ambil nota induk
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	query := `
+		SELECT o.id, o.invoice_number, o.outlet_id, o.customer_id, o.customer_name, o.customer_phone, o.customer_address,
+			COALESCE(o.is_delivery, 0), o.subtotal, o.discount_total, o.service_charge_total, o.tax_total, o.grand_total,
+			o.payment_status, o.status_internal, o.estimated_ready_at, o.notes, COALESCE(o.created_by, 0), u.full_name,
+			o.created_at, o.updated_at
+		FROM orders o
+		LEFT JOIN users u ON u.id = o.created_by
+		WHERE o.id = ?` + scope
+	var detail models.OrderDetail
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var customerIDNull sql.NullInt64
+	var nameNull, phoneNull, addressNull, notesNull, creatorNull sql.NullString
+	var readyAtNull, createdAtNull, updatedAtNull sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, append([]interface{}{id}, scopeArgs...)...).Scan(
+		&detail.ID, &detail.InvoiceNumber, &detail.OutletID, &customerIDNull, &nameNull, &phoneNull, &addressNull,
+		&detail.IsDelivery, &detail.Subtotal, &detail.DiscountTotal, &detail.ServiceChargeTotal, &detail.TaxTotal, &detail.GrandTotal,
+		&detail.PaymentStatus, &detail.StatusInternal, &readyAtNull, &notesNull, &detail.CreatedBy, &creatorNull,
+		&createdAtNull, &updatedAtNull,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("orderRepo.FindDetail.Order: %w", err)
+	}
+
+	detail.CustomerID = nullInt64Ptr(customerIDNull)
+	detail.CustomerName = nullStringPtr(nameNull)
+	detail.CustomerPhone = nullStringPtr(phoneNull)
+	detail.CustomerAddress = nullStringPtr(addressNull)
+	detail.Notes = nullStringPtr(notesNull)
+	detail.CreatedByName = nullStringPtr(creatorNull)
+	if readyAtNull.Valid {
+		detail.EstimatedReadyAt = &readyAtNull.Time
+	}
+	if createdAtNull.Valid {
+		detail.CreatedAt = createdAtNull.Time
+	}
+	if updatedAtNull.Valid {
+		detail.UpdatedAt = &updatedAtNull.Time
+	}
+
+	// 2. Ambil item, pembayaran, pengantaran, dan riwayat status
+	if detail.Items, err = r.findItems(ctx, id); err != nil {
+		return nil, err
+	}
+	if detail.Payment, err = r.findLatestPayment(ctx, id); err != nil {
+		return nil, err
+	}
+	if detail.IsDelivery {
+		if detail.Delivery, err = r.findDelivery(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	if detail.History, err = r.findHistory(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &detail, nil
+}
+
+// orderListSpec mendeklarasikan pencarian, filter, dan sorting yang diizinkan untuk GET /orders.
+var orderListSpec = listquery.Spec{
+	Search: []string{"o.invoice_number", "o.customer_name"},
+	Filters: map[string]listquery.Filter{
+		"outlet_id": {Column: "o.outlet_id"},
+		"status_internal": {Column: "o.status_internal", Op: listquery.OpIn, Allowed: []string{
+			models.OrderStatusPending, models.OrderStatusInProgress, models.OrderStatusReadyPickup, models.OrderStatusReadyDelivery,
+			models.OrderStatusBeingDelivered, models.OrderStatusFinishedDelivery, models.OrderStatusPickedUp, models.OrderStatusCancelled,
+		}},
+		"payment_status": {Column: "o.payment_status", Allowed: []string{
+			models.PaymentStatusUnpaid, models.PaymentStatusPaid, models.PaymentStatusCODPending,
+		}},
+		"is_delivery": {Column: "o.is_delivery", Allowed: []string{"0", "1"}},
+		"customer_id": {Column: "o.customer_id"},
+		"created_by":  {Column: "o.created_by"},
+		"start_date":  {Expr: "o.created_at >= ?"},
+		"end_date":    {Expr: "o.created_at < DATE_ADD(?, INTERVAL 1 DAY)"},
+	},
+	Sorts: map[string]string{
+		"created_at":     "o.created_at",
+		"invoice_number": "o.invoice_number",
+		"grand_total":    "o.grand_total",
+		"id":             "o.id",
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: listquery.OrderDesc,
+	IDColumn:     "o.id",
+	SkipCount:    true,
+}
+
+const orderSummarySelect = `
+	SELECT o.id, o.invoice_number, o.outlet_id, o.customer_id, o.customer_name, o.customer_phone, o.customer_address,
+		COALESCE(o.is_delivery, 0), o.subtotal, o.discount_total, o.service_charge_total, o.tax_total, o.grand_total,
+		o.payment_status, o.status_internal, o.estimated_ready_at, o.notes, COALESCE(o.created_by, 0), u.full_name,
+		o.created_at, o.updated_at, d.id, d.shipping_cost
+	FROM orders o
+	LEFT JOIN users u ON u.id = o.created_by
+	LEFT JOIN deliveries d ON d.order_id = o.id `
+
+// FindOrders retrieves orders of the active outlet with pagination (offset or cursor), filtering, and sorting support.
+func (r *orderRepository) FindOrders(ctx context.Context, params listquery.Params) ([]models.OrderSummary, *listquery.Result, error) {
+
+	// 1. Susun WHERE / ORDER BY / LIMIT dari spec (outlet aktif dipaksa lewat filter outlet_id)
+	q, err := orderListSpec.Build(outletListParams(ctx, params))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Hitung total baris hanya jika diminta (?with_count=true), tabel orders tumbuh setiap hari
+	var totalItems *int64
+	if q.Count {
+		where, args := q.Where()
+		var total int64
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM orders o "+where, args...).Scan(&total); err != nil {
+			return nil, nil, fmt.Errorf("orderRepo.FindOrders.Count: %w", err)
+		}
+		totalItems = &total
+	}
+
+	// 3. Eksekusi query utama
+	tail, args := q.Tail()
+	rows, err := r.db.QueryContext(ctx, orderSummarySelect+tail, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("orderRepo.FindOrders.Query: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []models.OrderSummary{}
+	for rows.Next() {
+		o, err := scanOrderSummary(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("orderRepo.FindOrders.Scan: %w", err)
+		}
+		orders = append(orders, *o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("orderRepo.FindOrders.Rows: %w", err)
+	}
+
+	// 4. Buang baris ekstra & siapkan cursor halaman berikutnya
+	keep, result := q.Paginate(len(orders), totalItems, func(i int) (interface{}, int64) {
+		return orderSortValue(orders[i], q.SortKey()), orders[i].ID
+	})
+
+	return orders[:keep], result, nil
+}
+
+// StreamOrders reads every order of the active outlet matching the list filters row by row for export.
+func (r *orderRepository) StreamOrders(ctx context.Context, params listquery.Params, fn func(*models.OrderSummary) error) error {
+	q, err := orderListSpec.Build(outletListParams(ctx, params))
+	if err != nil {
+		return err
+	}
+
+	tail, args := q.Unpaged()
+	rows, err := r.db.QueryContext(ctx, orderSummarySelect+tail, args...)
+	if err != nil {
+		return fmt.Errorf("orderRepo.StreamOrders.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		o, err := scanOrderSummary(rows)
+		if err != nil {
+			return fmt.Errorf("orderRepo.StreamOrders.Scan: %w", err)
+		}
+		if err := fn(o); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("orderRepo.StreamOrders.Rows: %w", err)
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+func (r *orderRepository) findItems(ctx context.Context, orderID int64) ([]models.OrderItemDetail, error) {
+
+	// 1. Ambil item beserta nama & satuan layanan
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT oi.id, oi.order_id, COALESCE(oi.service_id, 0), COALESCE(s.service_name, '-'), COALESCE(s.unit, 'pcs'),
+			oi.item_notes, oi.quantity, oi.qty_pieces, oi.weight_kg, oi.unit_price, oi.subtotal
+		FROM order_items oi
+		LEFT JOIN services s ON s.id = oi.service_id
+		WHERE oi.order_id = ?
+		ORDER BY oi.id ASC`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("orderRepo.findItems.Query: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.OrderItemDetail{}
+	indexByID := make(map[int64]int)
+	for rows.Next() {
+		var item models.OrderItemDetail
+		var notesNull sql.NullString
+		var quantityNull, piecesNull sql.NullInt64
+		var weightNull sql.NullFloat64
+
+		if err := rows.Scan(
+			&item.ID, &item.OrderID, &item.ServiceID, &item.ServiceName, &item.Unit,
+			&notesNull, &quantityNull, &piecesNull, &weightNull, &item.UnitPrice, &item.Subtotal,
+		); err != nil {
+			return nil, fmt.Errorf("orderRepo.findItems.Scan: %w", err)
+		}
+
+		item.ItemNotes = nullStringPtr(notesNull)
+		if quantityNull.Valid {
+			quantity := int(quantityNull.Int64)
+			item.Quantity = &quantity
+		}
+		if piecesNull.Valid {
+			pieces := int(piecesNull.Int64)
+			item.QtyPieces = &pieces
+		}
+		if weightNull.Valid {
+			item.WeightKg = &weightNull.Float64
+		}
+		item.Addons = []models.OrderItemAddon{}
+
+		indexByID[item.ID] = len(items)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("orderRepo.findItems.Rows: %w", err)
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	// 2. Ambil add-on per item (satu kueri, lalu dipetakan ke itemnya)
+	addonRows, err := r.db.QueryContext(ctx, `
+		SELECT oia.id, oia.order_item_id, oia.addon_id, oia.addon_name, oia.charge_type, oia.charge_value, oia.charge_basis, oia.amount
+		FROM order_item_addons oia
+		JOIN order_items oi ON oi.id = oia.order_item_id
+		WHERE oi.order_id = ?
+		ORDER BY oia.id ASC`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("orderRepo.findItems.Addons: %w", err)
+	}
+	defer addonRows.Close()
+
+	for addonRows.Next() {
+		var addon models.OrderItemAddon
+		var addonIDNull sql.NullInt64
+		if err := addonRows.Scan(
+			&addon.ID, &addon.OrderItemID, &addonIDNull, &addon.AddonName,
+			&addon.ChargeType, &addon.ChargeValue, &addon.ChargeBasis, &addon.Amount,
+		); err != nil {
+			return nil, fmt.Errorf("orderRepo.findItems.ScanAddon: %w", err)
+		}
+		addon.AddonID = nullInt64Ptr(addonIDNull)
+		if i, ok := indexByID[addon.OrderItemID]; ok {
+			items[i].Addons = append(items[i].Addons, addon)
+		}
+	}
+	if err := addonRows.Err(); err != nil {
+		return nil, fmt.Errorf("orderRepo.findItems.AddonRows: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *orderRepository) findLatestPayment(ctx context.Context, orderID int64) (*models.Payment, error) {
+
+	query := `
+		SELECT id, order_id, outlet_id, shift_id, method, amount, amount_received, amount_change, reference_no,
+			status, paid_at, created_by, collected_by, collected_at, created_at, updated_at
+		FROM payments
+		WHERE order_id = ? AND status <> 'void'
+		ORDER BY id DESC
+		LIMIT 1
+	`
+	payment, err := scanPayment(r.db.QueryRowContext(ctx, query, orderID))
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("orderRepo.findLatestPayment: %w", err)
+	}
+
+	return payment, nil
+}
+
+func (r *orderRepository) findDelivery(ctx context.Context, orderID int64) (*models.Delivery, error) {
+
+	query := `
+		SELECT d.id, d.order_id, d.outlet_id, d.shipping_cost, d.courier_id, u.full_name, u.phone_number,
+			d.courier_departed_at, d.courier_arrived_at, COALESCE(d.cod_collected_amount, 0), d.created_at
+		FROM deliveries d
+		LEFT JOIN users u ON u.id = d.courier_id
+		WHERE d.order_id = ?
+	`
+	var d models.Delivery
+	var courierIDNull sql.NullInt64
+	var courierNameNull, courierPhoneNull sql.NullString
+	var departedNull, arrivedNull, createdAtNull sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, orderID).Scan(
+		&d.ID, &d.OrderID, &d.OutletID, &d.ShippingCost, &courierIDNull, &courierNameNull, &courierPhoneNull,
+		&departedNull, &arrivedNull, &d.CODCollectedAmount, &createdAtNull,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("orderRepo.findDelivery: %w", err)
+	}
+
+	d.CourierID = nullInt64Ptr(courierIDNull)
+	d.CourierName = nullStringPtr(courierNameNull)
+	d.CourierPhone = nullStringPtr(courierPhoneNull)
+	if departedNull.Valid {
+		d.CourierDepartedAt = &departedNull.Time
+	}
+	if arrivedNull.Valid {
+		d.CourierArrivedAt = &arrivedNull.Time
+	}
+	if createdAtNull.Valid {
+		d.CreatedAt = createdAtNull.Time
+	}
+
+	return &d, nil
+}
+
+func (r *orderRepository) findHistory(ctx context.Context, orderID int64) ([]models.StatusHistoryDetail, error) {
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT h.id, h.order_id, h.previous_status, h.new_status, h.actor_id, u.full_name, h.actor_role, h.notes, h.created_at
+		FROM status_history h
+		LEFT JOIN users u ON u.id = h.actor_id
+		WHERE h.order_id = ?
+		ORDER BY h.id ASC`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("orderRepo.findHistory.Query: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.StatusHistoryDetail{}
+	for rows.Next() {
+		var h models.StatusHistoryDetail
+		var previousNull, actorNameNull, roleNull, notesNull sql.NullString
+		var actorIDNull sql.NullInt64
+		var createdAtNull sql.NullTime
+
+		if err := rows.Scan(
+			&h.ID, &h.OrderID, &previousNull, &h.NewStatus, &actorIDNull, &actorNameNull, &roleNull, &notesNull, &createdAtNull,
+		); err != nil {
+			return nil, fmt.Errorf("orderRepo.findHistory.Scan: %w", err)
+		}
+
+		h.PreviousStatus = nullStringPtr(previousNull)
+		h.ActorID = nullInt64Ptr(actorIDNull)
+		h.ActorName = nullStringPtr(actorNameNull)
+		h.ActorRole = nullStringPtr(roleNull)
+		h.Notes = nullStringPtr(notesNull)
+		if createdAtNull.Valid {
+			h.CreatedAt = createdAtNull.Time
+		}
+		history = append(history, h)
+	}
+
+	return history, rows.Err()
+}
+
+func scanCustomer(row rowScanner) (*models.Customer, error) {
+	var customer models.Customer
+	var addressNull sql.NullString
+	var createdAtNull, updatedAtNull sql.NullTime
+
+	err := row.Scan(&customer.ID, &customer.FullName, &customer.PhoneNumber, &addressNull, &customer.IsActive, &createdAtNull, &updatedAtNull)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, err
+	}
+
+	customer.Address = nullStringPtr(addressNull)
+	if createdAtNull.Valid {
+		customer.CreatedAt = createdAtNull.Time
+	}
+	if updatedAtNull.Valid {
+		customer.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &customer, nil
+}
+
+// orderSortValue mengambil nilai kolom sort dari baris untuk disimpan di cursor.
+func orderSortValue(o models.OrderSummary, sortKey string) interface{} {
+	switch sortKey {
+	case "invoice_number":
+		return o.InvoiceNumber
+	case "grand_total":
+		return o.GrandTotal
+	case "id":
+		return o.ID
+	default:
+		return o.CreatedAt
+	}
+}
+
+func scanOrderSummary(row rowScanner) (*models.OrderSummary, error) {
+	var o models.OrderSummary
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var customerIDNull, deliveryIDNull sql.NullInt64
+	var nameNull, phoneNull, addressNull, notesNull, creatorNull sql.NullString
+	var readyAtNull, createdAtNull, updatedAtNull sql.NullTime
+	var shippingCostNull money.NullAmount
+
+	err := row.Scan(
+		&o.ID, &o.InvoiceNumber, &o.OutletID, &customerIDNull, &nameNull, &phoneNull, &addressNull,
+		&o.IsDelivery, &o.Subtotal, &o.DiscountTotal, &o.ServiceChargeTotal, &o.TaxTotal, &o.GrandTotal,
+		&o.PaymentStatus, &o.StatusInternal, &readyAtNull, &notesNull, &o.CreatedBy, &creatorNull,
+		&createdAtNull, &updatedAtNull, &deliveryIDNull, &shippingCostNull,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	o.CustomerID = nullInt64Ptr(customerIDNull)
+	o.CustomerName = nullStringPtr(nameNull)
+	o.CustomerPhone = nullStringPtr(phoneNull)
+	o.CustomerAddress = nullStringPtr(addressNull)
+	o.Notes = nullStringPtr(notesNull)
+	o.CreatedByName = nullStringPtr(creatorNull)
+	o.DeliveryID = nullInt64Ptr(deliveryIDNull)
+	o.ShippingCost = shippingCostNull.Ptr()
+	if readyAtNull.Valid {
+		o.EstimatedReadyAt = &readyAtNull.Time
+	}
+	if createdAtNull.Valid {
+		o.CreatedAt = createdAtNull.Time
+	}
+	if updatedAtNull.Valid {
+		o.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &o, nil
+}
+
+// scanPayment membaca kolom standar tagihan; extra menampung kolom tambahan hasil JOIN di akhir SELECT.
+func scanPayment(row rowScanner, extra ...interface{}) (*models.Payment, error) {
+	var p models.Payment
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var shiftIDNull, collectedByNull sql.NullInt64
+	var methodNull, referenceNull sql.NullString
+	var paidAtNull, collectedAtNull, createdAtNull, updatedAtNull sql.NullTime
+
+	dest := []interface{}{
+		&p.ID, &p.OrderID, &p.OutletID, &shiftIDNull, &methodNull, &p.Amount, &p.AmountReceived, &p.AmountChange, &referenceNull,
+		&p.Status, &paidAtNull, &p.CreatedBy, &collectedByNull, &collectedAtNull, &createdAtNull, &updatedAtNull,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, err
+	}
+
+	p.ShiftID = nullInt64Ptr(shiftIDNull)
+	p.CollectedBy = nullInt64Ptr(collectedByNull)
+	p.Method = nullStringPtr(methodNull)
+	p.ReferenceNo = nullStringPtr(referenceNull)
+	if paidAtNull.Valid {
+		p.PaidAt = &paidAtNull.Time
+	}
+	if collectedAtNull.Valid {
+		p.CollectedAt = &collectedAtNull.Time
+	}
+	if createdAtNull.Valid {
+		p.CreatedAt = createdAtNull.Time
+	}
+	if updatedAtNull.Valid {
+		p.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/outlet"
+	"laundry-backend/pkg/listquery"
+)
+
+// TestNextInvoiceNumberTxConcurrent menjalankan banyak kasir satu outlet yang membuat nota bersamaan.
+// Setiap transaksi harus mendapat nomor berbeda dan berurutan tanpa deadlock.
+func TestNextInvoiceNumberTxConcurrent(t *testing.T) {
+
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewOrderRepository(db)
+
+	const cashiers = 12
+
+	// Hari jauh di masa lalu supaya tidak bentrok dengan penghitung data lain
+	day := time.Date(2001, 1, 1+int(time.Now().UnixNano()%28), 0, 0, 0, 0, time.UTC)
+	t.Cleanup(func() {
+		db.ExecContext(ctx, "DELETE FROM invoice_counters WHERE outlet_id = 1 AND invoice_day = ?", day.Format("2006-01-02"))
+	})
+
+	var wg sync.WaitGroup
+	numbers := make(chan string, cashiers)
+	errs := make(chan error, cashiers)
+	start := make(chan struct{})
+	for i := 0; i < cashiers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer tx.Rollback()
+
+			number, err := repo.NextInvoiceNumberTx(ctx, tx, 1, day)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if err := tx.Commit(); err != nil {
+				errs <- err
+				return
+			}
+			numbers <- number
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(numbers)
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("NextInvoiceNumberTx: %v", err)
+	}
+
+	var got []string
+	for number := range numbers {
+		got = append(got, number)
+	}
+	sort.Strings(got)
+
+	// Outlet 1 adalah outlet PUSAT bawaan migrasi
+	prefix := "INV-PUSAT-" + day.Format("060102") + "-"
+	for i, number := range got {
+		if want := fmt.Sprintf("%s%03d", prefix, i+1); number != want {
+			t.Fatalf("numbers = %v, want %s%03d..%03d without gaps or duplicates", got, prefix, 1, cashiers)
+		}
+	}
+	if len(got) != cashiers {
+		t.Fatalf("got %d numbers, want %d", len(got), cashiers)
+	}
+}
+
+// TestOrderAndPaymentListsForceActiveOutlet: kasir tidak bisa melihat pesanan/pembayaran outlet lain
+// dengan mengirim ?outlet_id= sendiri, dan tabel besar tidak menjalankan COUNT(*) kecuali diminta.
+func TestOrderAndPaymentListsForceActiveOutlet(t *testing.T) {
+
+	ctx := outlet.WithScope(context.Background(), 2)
+	params := listquery.Params{Filters: map[string]string{"outlet_id": "5", "status_internal": "pending,ready-pickup"}}
+
+	for name, spec := range map[string]listquery.Spec{"orders": orderListSpec, "payments": paymentListSpec} {
+		q, err := spec.Build(outletListParams(ctx, params))
+		if err != nil {
+			t.Fatalf("%s: Build: %v", name, err)
+		}
+		if q.Count {
+			t.Errorf("%s: Count = true, want COUNT(*) skipped by default", name)
+		}
+
+		where, args := q.Where()
+		found := false
+		for _, arg := range args {
+			if reflect.DeepEqual(arg, "5") {
+				t.Fatalf("%s: WHERE %q args %v still use the requested outlet 5", name, where, args)
+			}
+			if reflect.DeepEqual(arg, "2") {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("%s: WHERE %q args %v, want active outlet 2", name, where, args)
+		}
+	}
+}
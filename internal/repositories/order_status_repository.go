@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+)
+
+// OrderStatusRepository mendefinisikan operasi database untuk perpindahan status pesanan & riwayatnya.
+//
+// Perubahan status selalu dilakukan di dalam transaksi: baris pesanan dikunci (LockStateTx),
+// divalidasi oleh orderflow, lalu status & riwayat ditulis bersamaan (ChangeStatusTx).
+type OrderStatusRepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+
+	// Read Operations
+	FindState(ctx context.Context, orderID int64) (*models.OrderState, error)
+	LockStateTx(ctx context.Context, tx *sql.Tx, orderID int64) (*models.OrderState, error)
+
+	// Write Operations
+	ChangeStatusTx(ctx context.Context, tx *sql.Tx, history *models.StatusHistory) error
+	InsertHistoryTx(ctx context.Context, tx *sql.Tx, history *models.StatusHistory) error
+}
+
+// orderStatusRepository is the concrete implementation using sql.DB.
+type orderStatusRepository struct {
+	db *sql.DB
+}
+
+// NewOrderStatusRepository creates a new instance of OrderStatusRepository.
+func NewOrderStatusRepository(db *sql.DB) OrderStatusRepository {
+	return &orderStatusRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+const orderStateQuery = `
+	SELECT o.id, o.invoice_number, COALESCE(o.customer_name, c.full_name), o.is_delivery,
+		o.payment_status, o.status_internal, o.estimated_ready_at
+	FROM orders o
+	LEFT JOIN customers c ON c.id = o.customer_id
+	WHERE o.id = ?
+`
+
+// BeginTx starts a transaction owned by the calling service.
+func (r *orderStatusRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("orderStatusRepo.BeginTx: %w", err)
+	}
+	return tx, nil
+}
+
+// FindState retrieves the current status data of an order without locking.
+// Pesanan outlet lain (di luar outlet aktif ctx) dianggap tidak ada.
+func (r *orderStatusRepository) FindState(ctx context.Context, orderID int64) (*models.OrderState, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	state, err := scanOrderState(r.db.QueryRowContext(ctx, orderStateQuery+scope, append([]interface{}{orderID}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("orderStatusRepo.FindState: %w", err)
+	}
+
+	return state, nil
+}
+
+// LockStateTx retrieves the order status data and locks the row until the transaction ends.
+func (r *orderStatusRepository) LockStateTx(ctx context.Context, tx *sql.Tx, orderID int64) (*models.OrderState, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	state, err := scanOrderState(tx.QueryRowContext(ctx, orderStateQuery+scope+" FOR UPDATE", append([]interface{}{orderID}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("orderStatusRepo.LockStateTx: %w", err)
+	}
+
+	return state, nil
+}
+
+// ChangeStatusTx updates orders.status_internal and appends the matching status_history row.
+func (r *orderStatusRepository) ChangeStatusTx(ctx context.Context, tx *sql.Tx, history *models.StatusHistory) error {
+
+	// 1. Update status di nota induk
+	res, err := tx.ExecContext(ctx, "UPDATE orders SET status_internal = ? WHERE id = ?", history.NewStatus, history.OrderID)
+	if err != nil {
+		return fmt.Errorf("orderStatusRepo.ChangeStatusTx.Update: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return response.ErrNotFound
+	}
+
+	// 2. Catat riwayat (audit trail)
+	return r.InsertHistoryTx(ctx, tx, history)
+}
+
+// InsertHistoryTx appends a status_history row (also used for notes without a status change).
+func (r *orderStatusRepository) InsertHistoryTx(ctx context.Context, tx *sql.Tx, history *models.StatusHistory) error {
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO status_history (order_id, previous_status, new_status, actor_id, actor_role, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		history.OrderID,
+		history.PreviousStatus,
+		history.NewStatus,
+		history.ActorID,
+		history.ActorRole,
+		history.Notes,
+		history.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("orderStatusRepo.InsertHistoryTx: %w", err)
+	}
+
+	if history.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("orderStatusRepo.InsertHistoryTx.LastInsertId: %w", err)
+	}
+
+	return nil
+}
+
+func scanOrderState(row rowScanner) (*models.OrderState, error) {
+	var state models.OrderState
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var nameNull, paymentStatusNull, statusNull sql.NullString
+	var isDeliveryNull sql.NullBool
+	var readyAtNull sql.NullTime
+
+	if err := row.Scan(
+		&state.ID, &state.InvoiceNumber, &nameNull, &isDeliveryNull,
+		&paymentStatusNull, &statusNull, &readyAtNull,
+	); err != nil {
+		return nil, err
+	}
+
+	state.CustomerName = nullStringPtr(nameNull)
+	state.IsDelivery = isDeliveryNull.Bool
+	state.PaymentStatus = paymentStatusNull.String
+	state.StatusInternal = statusNull.String
+	if readyAtNull.Valid {
+		state.EstimatedReadyAt = &readyAtNull.Time
+	}
+
+	return &state, nil
+}
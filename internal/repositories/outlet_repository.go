@@ -0,0 +1,328 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+	"strconv"
+)
+
+// OutletRepository mendefinisikan semua operasi database untuk outlet (cabang) dan harga layanan per outlet.
+type OutletRepository interface {
+
+	// Create Operations
+	InsertOutlet(ctx context.Context, o *models.Outlet) error
+
+	// Read Operations
+	FindAll(ctx context.Context, status string) ([]models.Outlet, error)
+	FindByID(ctx context.Context, id int64) (*models.Outlet, error)
+	FindByCode(ctx context.Context, code string) (*models.Outlet, error)
+	CountActiveUsers(ctx context.Context, outletID int64) (int64, error)
+
+	// Update Operations
+	UpdateOutlet(ctx context.Context, o *models.Outlet) error
+
+	// Delete Operations (Soft Delete)
+	DeleteOutlet(ctx context.Context, id int64) error
+
+	// Service Price Overrides
+	FindServicePrices(ctx context.Context, outletID int64) ([]models.ServiceOutletPrice, error)
+	UpsertServicePrice(ctx context.Context, price *models.ServiceOutletPrice) error
+	DeleteServicePrice(ctx context.Context, outletID, serviceID int64) error
+}
+
+// outletRepository is the concrete implementation using sql.DB.
+type outletRepository struct {
+	db *sql.DB
+}
+
+// NewOutletRepository creates a new instance of OutletRepository.
+func NewOutletRepository(db *sql.DB) OutletRepository {
+	return &outletRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+const outletColumns = `id, code, outlet_name, address, phone_number, COALESCE(is_active, 1), created_at, updated_at`
+
+// InsertOutlet creates a new outlet.
+func (r *outletRepository) InsertOutlet(ctx context.Context, o *models.Outlet) error {
+
+	query := `
+		INSERT INTO outlets (code, outlet_name, address, phone_number, is_active, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	res, err := r.db.ExecContext(ctx, query,
+		o.Code,
+		o.OutletName,
+		o.Address,     // Pointer, aman jika nil
+		o.PhoneNumber, // Pointer, aman jika nil
+		o.IsActive,
+		o.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("outletRepo.InsertOutlet.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("outletRepo.InsertOutlet.LastInsertId: %w", err)
+	}
+
+	o.ID = id
+	return nil
+}
+
+// FindAll retrieves every outlet; the list is small so it is not paginated.
+func (r *outletRepository) FindAll(ctx context.Context, status string) ([]models.Outlet, error) {
+
+	// 1. Terapkan filter status aktif/non-aktif
+	whereClause := "WHERE 1=1"
+	if status == "1" {
+		whereClause += " AND is_active = 1"
+	} else if status == "0" {
+		whereClause += " AND is_active = 0"
+	}
+
+	// 2. Eksekusi query
+	query := fmt.Sprintf(`SELECT %s FROM outlets %s ORDER BY id ASC`, outletColumns, whereClause)
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("outletRepo.FindAll.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 3. Mapping hasil query
+	outlets := []models.Outlet{}
+	for rows.Next() {
+		o, err := scanOutlet(rows)
+		if err != nil {
+			return nil, fmt.Errorf("outletRepo.FindAll.Scan: %w", err)
+		}
+		outlets = append(outlets, *o)
+	}
+
+	return outlets, rows.Err()
+}
+
+// FindByID retrieves a single outlet by ID.
+func (r *outletRepository) FindByID(ctx context.Context, id int64) (*models.Outlet, error) {
+
+	o, err := scanOutlet(r.db.QueryRowContext(ctx, "SELECT "+outletColumns+" FROM outlets WHERE id = ?", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("outletRepo.FindByID: %w", err)
+	}
+
+	return o, nil
+}
+
+// FindByCode retrieves a single outlet by its exact code (Useful for duplicate validation).
+func (r *outletRepository) FindByCode(ctx context.Context, code string) (*models.Outlet, error) {
+
+	o, err := scanOutlet(r.db.QueryRowContext(ctx, "SELECT "+outletColumns+" FROM outlets WHERE code = ?", code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("outletRepo.FindByCode: %w", err)
+	}
+
+	return o, nil
+}
+
+// CountActiveUsers counts active employees assigned to an outlet (guard before deactivation).
+func (r *outletRepository) CountActiveUsers(ctx context.Context, outletID int64) (int64, error) {
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users WHERE outlet_id = ? AND is_active = 1", outletID).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("outletRepo.CountActiveUsers: %w", err)
+	}
+
+	return total, nil
+}
+
+// UpdateOutlet updates an existing outlet.
+func (r *outletRepository) UpdateOutlet(ctx context.Context, o *models.Outlet) error {
+
+	query := `
+		UPDATE outlets
+		SET code = ?, outlet_name = ?, address = ?, phone_number = ?, is_active = ?, updated_at = ?
+		WHERE id = ?
+	`
+	res, err := r.db.ExecContext(ctx, query,
+		o.Code,
+		o.OutletName,
+		o.Address,
+		o.PhoneNumber,
+		o.IsActive,
+		o.UpdatedAt,
+		o.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("outletRepo.UpdateOutlet.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("outletRepo.UpdateOutlet.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteOutlet performs a soft delete by setting is_active to false (0).
+func (r *outletRepository) DeleteOutlet(ctx context.Context, id int64) error {
+
+	res, err := r.db.ExecContext(ctx, "UPDATE outlets SET is_active = 0 WHERE id = ? AND is_active = 1", id)
+	if err != nil {
+		return fmt.Errorf("outletRepo.DeleteOutlet.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("outletRepo.DeleteOutlet.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindServicePrices retrieves every service price override of an outlet, with the base price for comparison.
+func (r *outletRepository) FindServicePrices(ctx context.Context, outletID int64) ([]models.ServiceOutletPrice, error) {
+
+	query := `
+		SELECT p.service_id, p.outlet_id, p.price, s.code, s.service_name, s.price, p.created_at, p.updated_at
+		FROM service_outlet_prices p
+		JOIN services s ON s.id = p.service_id
+		WHERE p.outlet_id = ?
+		ORDER BY s.service_name ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, outletID)
+	if err != nil {
+		return nil, fmt.Errorf("outletRepo.FindServicePrices.Query: %w", err)
+	}
+	defer rows.Close()
+
+	prices := []models.ServiceOutletPrice{}
+	for rows.Next() {
+		var p models.ServiceOutletPrice
+		var createdAtNull, updatedAtNull sql.NullTime
+		if err := rows.Scan(
+			&p.ServiceID, &p.OutletID, &p.Price, &p.ServiceCode, &p.ServiceName, &p.BasePrice, &createdAtNull, &updatedAtNull,
+		); err != nil {
+			return nil, fmt.Errorf("outletRepo.FindServicePrices.Scan: %w", err)
+		}
+		if createdAtNull.Valid {
+			p.CreatedAt = createdAtNull.Time
+		}
+		if updatedAtNull.Valid {
+			p.UpdatedAt = &updatedAtNull.Time
+		}
+		prices = append(prices, p)
+	}
+
+	return prices, rows.Err()
+}
+
+// UpsertServicePrice creates or replaces the price override of a service at an outlet.
+func (r *outletRepository) UpsertServicePrice(ctx context.Context, price *models.ServiceOutletPrice) error {
+
+	query := `
+		INSERT INTO service_outlet_prices (service_id, outlet_id, price, created_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE price = VALUES(price), updated_at = VALUES(created_at)
+	`
+	if _, err := r.db.ExecContext(ctx, query, price.ServiceID, price.OutletID, price.Price, price.CreatedAt); err != nil {
+		return fmt.Errorf("outletRepo.UpsertServicePrice: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteServicePrice removes a price override so the outlet falls back to the base service price.
+func (r *outletRepository) DeleteServicePrice(ctx context.Context, outletID, serviceID int64) error {
+
+	res, err := r.db.ExecContext(ctx, "DELETE FROM service_outlet_prices WHERE outlet_id = ? AND service_id = ?", outletID, serviceID)
+	if err != nil {
+		return fmt.Errorf("outletRepo.DeleteServicePrice.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("outletRepo.DeleteServicePrice.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+// outletFilter mengembalikan potongan "AND <column> = ?" untuk outlet aktif di context beserta argumennya.
+// Kosong jika context tidak dibatasi (owner mode konsolidasi / background worker).
+// Dipakai semua query yang membaca pesanan, pembayaran, atau pengantaran.
+func outletFilter(ctx context.Context, column string) (string, []interface{}) {
+	id := outlet.FromContext(ctx)
+	if id == outlet.All {
+		return "", nil
+	}
+	return " AND " + column + " = ?", []interface{}{id}
+}
+
+// outletListParams memaksa filter list "outlet_id" ke outlet aktif di context (untuk tabel yang dipaginasi listquery).
+// Owner mode konsolidasi tetap bebas memakai ?outlet_id= sendiri.
+func outletListParams(ctx context.Context, params listquery.Params) listquery.Params {
+	id := outlet.FromContext(ctx)
+	if id == outlet.All {
+		return params
+	}
+
+	filters := make(map[string]string, len(params.Filters)+1)
+	for name, value := range params.Filters {
+		filters[name] = value
+	}
+	filters["outlet_id"] = strconv.FormatInt(id, 10)
+	params.Filters = filters
+	return params
+}
+
+func scanOutlet(row rowScanner) (*models.Outlet, error) {
+	var o models.Outlet
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var addressNull, phoneNull sql.NullString
+	var createdAtNull, updatedAtNull sql.NullTime
+
+	err := row.Scan(&o.ID, &o.Code, &o.OutletName, &addressNull, &phoneNull, &o.IsActive, &createdAtNull, &updatedAtNull)
+	if err != nil {
+		return nil, err
+	}
+
+	o.Address = nullStringPtr(addressNull)
+	o.PhoneNumber = nullStringPtr(phoneNull)
+	if createdAtNull.Valid {
+		o.CreatedAt = createdAtNull.Time
+	}
+	if updatedAtNull.Valid {
+		o.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &o, nil
+}
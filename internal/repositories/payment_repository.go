@@ -0,0 +1,258 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+)
+
+// PaymentRepository mendefinisikan operasi database untuk pelunasan tagihan pesanan.
+//
+// Pelunasan selalu dilakukan di dalam transaksi: tagihan & nota induk dikunci (LockForSettlementTx),
+// lalu pembayaran dan status bayar nota ditulis bersamaan (ConfirmTx).
+type PaymentRepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+
+	// LockForSettlementTx mengambil tagihan beserta nomor nota & pelanggannya dan mengunci keduanya.
+	// Tagihan outlet lain (di luar outlet aktif ctx) dianggap tidak ada.
+	LockForSettlementTx(ctx context.Context, tx *sql.Tx, id int64) (*models.PaymentSettlement, error)
+
+	// ConfirmTx menyimpan pelunasan tagihan 'pending' dan menandai nota induk 'paid'.
+	ConfirmTx(ctx context.Context, tx *sql.Tx, payment *models.Payment) error
+
+	// FindPayments mengambil satu halaman daftar tagihan & pelunasan outlet aktif (GET /payments).
+	FindPayments(ctx context.Context, params listquery.Params) ([]models.PaymentSummary, *listquery.Result, error)
+
+	// StreamPayments membaca semua pembayaran yang cocok dengan filter & sorting list (tanpa pagination) untuk export.
+	StreamPayments(ctx context.Context, params listquery.Params, fn func(*models.PaymentSummary) error) error
+}
+
+// paymentRepository is the concrete implementation using sql.DB.
+type paymentRepository struct {
+	db *sql.DB
+}
+
+// NewPaymentRepository creates a new instance of PaymentRepository.
+func NewPaymentRepository(db *sql.DB) PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// BeginTx starts the settlement transaction.
+func (r *paymentRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("paymentRepo.BeginTx: %w", err)
+	}
+	return tx, nil
+}
+
+// LockForSettlementTx retrieves a payment and its order, locking both rows until the transaction ends.
+func (r *paymentRepository) LockForSettlementTx(ctx context.Context, tx *sql.Tx, id int64) (*models.PaymentSettlement, error) {
+
+	// 1. Kunci tagihan (dua kasir tidak bisa melunasi tagihan yang sama)
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	query := `
+		SELECT id, order_id, outlet_id, shift_id, method, amount, amount_received, amount_change, reference_no,
+			status, paid_at, created_by, collected_by, collected_at, created_at, updated_at
+		FROM payments
+		WHERE id = ?` + scope + `
+		FOR UPDATE
+	`
+	payment, err := scanPayment(tx.QueryRowContext(ctx, query, append([]interface{}{id}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("paymentRepo.LockForSettlementTx.Payment: %w", err)
+	}
+
+	// 2. Kunci nota induk (status bayar ikut berubah di transaksi ini)
+	settlement := &models.PaymentSettlement{Payment: *payment}
+	var customerIDNull sql.NullInt64
+	err = tx.QueryRowContext(ctx, "SELECT invoice_number, customer_id FROM orders WHERE id = ? FOR UPDATE", payment.OrderID).
+		Scan(&settlement.InvoiceNumber, &customerIDNull)
+	if err != nil {
+		return nil, fmt.Errorf("paymentRepo.LockForSettlementTx.Order: %w", err)
+	}
+	settlement.CustomerID = nullInt64Ptr(customerIDNull)
+
+	return settlement, nil
+}
+
+// ConfirmTx stores the settlement of a pending payment and marks its order as paid.
+func (r *paymentRepository) ConfirmTx(ctx context.Context, tx *sql.Tx, payment *models.Payment) error {
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE payments
+		SET shift_id = ?, method = ?, amount_received = ?, amount_change = ?, reference_no = ?,
+			status = ?, paid_at = ?, collected_by = ?, collected_at = ?
+		WHERE id = ? AND status = 'pending'`,
+		payment.ShiftID,
+		payment.Method,
+		payment.AmountReceived,
+		payment.AmountChange,
+		payment.ReferenceNo,
+		payment.Status,
+		payment.PaidAt,
+		payment.CollectedBy,
+		payment.CollectedAt,
+		payment.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("paymentRepo.ConfirmTx.Payment: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("paymentRepo.ConfirmTx.RowsAffected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: payment %d is no longer pending", response.ErrInvalidTransition, payment.ID)
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE orders SET payment_status = ? WHERE id = ?", models.PaymentStatusPaid, payment.OrderID); err != nil {
+		return fmt.Errorf("paymentRepo.ConfirmTx.Order: %w", err)
+	}
+
+	return nil
+}
+
+// paymentListSpec mendeklarasikan pencarian, filter, dan sorting yang diizinkan untuk GET /payments.
+var paymentListSpec = listquery.Spec{
+	Search: []string{"o.invoice_number", "p.reference_no"},
+	Filters: map[string]listquery.Filter{
+		"outlet_id": {Column: "p.outlet_id"},
+		"order_id":  {Column: "p.order_id"},
+		"shift_id":  {Column: "p.shift_id"},
+		"status": {Column: "p.status", Allowed: []string{
+			models.PaymentPending, models.PaymentConfirmed, models.PaymentVoid,
+		}},
+		"method": {Column: "p.method", Op: listquery.OpIn, Allowed: []string{
+			models.PaymentMethodCash, models.PaymentMethodTransfer, models.PaymentMethodQRIS, models.PaymentMethodEWallet, models.PaymentMethodDeposit,
+		}},
+		"start_date": {Expr: "p.created_at >= ?"},
+		"end_date":   {Expr: "p.created_at < DATE_ADD(?, INTERVAL 1 DAY)"},
+	},
+	Sorts: map[string]string{
+		"created_at": "p.created_at",
+		"amount":     "p.amount",
+		"id":         "p.id",
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: listquery.OrderDesc,
+	IDColumn:     "p.id",
+	SkipCount:    true,
+}
+
+const paymentSummarySelect = `
+	SELECT p.id, p.order_id, p.outlet_id, p.shift_id, p.method, p.amount, p.amount_received, p.amount_change, p.reference_no,
+		p.status, p.paid_at, p.created_by, p.collected_by, p.collected_at, p.created_at, p.updated_at, o.invoice_number
+	FROM payments p
+	JOIN orders o ON o.id = p.order_id `
+
+// FindPayments retrieves payments of the active outlet with pagination (offset or cursor), filtering, and sorting support.
+func (r *paymentRepository) FindPayments(ctx context.Context, params listquery.Params) ([]models.PaymentSummary, *listquery.Result, error) {
+
+	// 1. Susun WHERE / ORDER BY / LIMIT dari spec (outlet aktif dipaksa lewat filter outlet_id)
+	q, err := paymentListSpec.Build(outletListParams(ctx, params))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Hitung total baris hanya jika diminta (?with_count=true)
+	var totalItems *int64
+	if q.Count {
+		where, args := q.Where()
+		var total int64
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM payments p JOIN orders o ON o.id = p.order_id "+where, args...).Scan(&total); err != nil {
+			return nil, nil, fmt.Errorf("paymentRepo.FindPayments.Count: %w", err)
+		}
+		totalItems = &total
+	}
+
+	// 3. Eksekusi query utama
+	tail, args := q.Tail()
+	rows, err := r.db.QueryContext(ctx, paymentSummarySelect+tail, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("paymentRepo.FindPayments.Query: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []models.PaymentSummary{}
+	for rows.Next() {
+		p, err := scanPaymentSummary(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("paymentRepo.FindPayments.Scan: %w", err)
+		}
+		payments = append(payments, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("paymentRepo.FindPayments.Rows: %w", err)
+	}
+
+	// 4. Buang baris ekstra & siapkan cursor halaman berikutnya
+	keep, result := q.Paginate(len(payments), totalItems, func(i int) (interface{}, int64) {
+		return paymentSortValue(payments[i], q.SortKey()), payments[i].ID
+	})
+
+	return payments[:keep], result, nil
+}
+
+// StreamPayments reads every payment of the active outlet matching the list filters row by row for export.
+func (r *paymentRepository) StreamPayments(ctx context.Context, params listquery.Params, fn func(*models.PaymentSummary) error) error {
+	q, err := paymentListSpec.Build(outletListParams(ctx, params))
+	if err != nil {
+		return err
+	}
+
+	tail, args := q.Unpaged()
+	rows, err := r.db.QueryContext(ctx, paymentSummarySelect+tail, args...)
+	if err != nil {
+		return fmt.Errorf("paymentRepo.StreamPayments.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanPaymentSummary(rows)
+		if err != nil {
+			return fmt.Errorf("paymentRepo.StreamPayments.Scan: %w", err)
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("paymentRepo.StreamPayments.Rows: %w", err)
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+// paymentSortValue mengambil nilai kolom sort dari baris untuk disimpan di cursor.
+func paymentSortValue(p models.PaymentSummary, sortKey string) interface{} {
+	switch sortKey {
+	case "amount":
+		return p.Amount
+	case "id":
+		return p.ID
+	default:
+		return p.CreatedAt
+	}
+}
+
+func scanPaymentSummary(row rowScanner) (*models.PaymentSummary, error) {
+	var s models.PaymentSummary
+	payment, err := scanPayment(row, &s.InvoiceNumber)
+	if err != nil {
+		return nil, err
+	}
+	s.Payment = *payment
+	return &s, nil
+}
@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// PricingRuleRepository mendefinisikan semua operasi database untuk aturan harga layanan.
+type PricingRuleRepository interface {
+
+	// Create Operations
+	InsertRule(ctx context.Context, rule *models.ServicePricingRule) error
+
+	// Read Operations
+	FindByService(ctx context.Context, serviceID int64, activeOnly bool) ([]models.ServicePricingRule, error)
+	FindByID(ctx context.Context, serviceID, ruleID int64) (*models.ServicePricingRule, error)
+
+	// Update Operations
+	UpdateRule(ctx context.Context, rule *models.ServicePricingRule) error
+
+	// Delete Operations (Soft Delete)
+	DeleteRule(ctx context.Context, serviceID, ruleID int64) error
+}
+
+// pricingRuleRepository is the concrete implementation using sql.DB.
+type pricingRuleRepository struct {
+	db *sql.DB
+}
+
+// NewPricingRuleRepository creates a new instance of PricingRuleRepository.
+func NewPricingRuleRepository(db *sql.DB) PricingRuleRepository {
+	return &pricingRuleRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// InsertRule creates a new pricing rule record in the database.
+func (r *pricingRuleRepository) InsertRule(ctx context.Context, rule *models.ServicePricingRule) error {
+
+	// 1. Persiapkan query SQL
+	query := `
+		INSERT INTO service_pricing_rules (service_id, rule_type, min_quantity, rounding_step, unit_price, is_active, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	// 2. Eksekusi query (Pointer nil otomatis menjadi NULL)
+	res, err := r.db.ExecContext(ctx, query,
+		rule.ServiceID,
+		rule.RuleType,
+		rule.MinQuantity,
+		rule.RoundingStep,
+		rule.UnitPrice,
+		rule.IsActive,
+		rule.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("pricingRuleRepo.InsertRule.Exec: %w", err)
+	}
+
+	// 3. Ambil ID yang baru saja di-generate oleh MySQL
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("pricingRuleRepo.InsertRule.LastInsertId: %w", err)
+	}
+
+	rule.ID = id
+	return nil
+}
+
+// FindByService retrieves all pricing rules attached to a service.
+func (r *pricingRuleRepository) FindByService(ctx context.Context, serviceID int64, activeOnly bool) ([]models.ServicePricingRule, error) {
+
+	// 1. Persiapkan query dasar
+	query := `
+		SELECT id, service_id, rule_type, min_quantity, rounding_step, unit_price, is_active, created_at, updated_at
+		FROM service_pricing_rules
+		WHERE service_id = ?`
+	if activeOnly {
+		query += " AND is_active = 1"
+	}
+	query += " ORDER BY rule_type ASC, min_quantity ASC, id ASC"
+
+	// 2. Eksekusi query
+	rows, err := r.db.QueryContext(ctx, query, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("pricingRuleRepo.FindByService.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 3. Mapping hasil query ke slice struct
+	var rules []models.ServicePricingRule
+	for rows.Next() {
+		rule, err := scanPricingRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("pricingRuleRepo.FindByService.Scan: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// FindByID retrieves a single pricing rule that belongs to the given service.
+func (r *pricingRuleRepository) FindByID(ctx context.Context, serviceID, ruleID int64) (*models.ServicePricingRule, error) {
+
+	query := `
+		SELECT id, service_id, rule_type, min_quantity, rounding_step, unit_price, is_active, created_at, updated_at
+		FROM service_pricing_rules
+		WHERE id = ? AND service_id = ?
+	`
+
+	rule, err := scanPricingRule(r.db.QueryRowContext(ctx, query, ruleID, serviceID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("pricingRuleRepo.FindByID: %w", err)
+	}
+
+	return rule, nil
+}
+
+// UpdateRule updates an existing pricing rule record.
+func (r *pricingRuleRepository) UpdateRule(ctx context.Context, rule *models.ServicePricingRule) error {
+
+	query := `
+		UPDATE service_pricing_rules
+		SET min_quantity = ?, rounding_step = ?, unit_price = ?, is_active = ?, updated_at = ?
+		WHERE id = ? AND service_id = ?
+	`
+
+	res, err := r.db.ExecContext(ctx, query,
+		rule.MinQuantity,
+		rule.RoundingStep,
+		rule.UnitPrice,
+		rule.IsActive,
+		rule.UpdatedAt,
+		rule.ID,
+		rule.ServiceID,
+	)
+	if err != nil {
+		return fmt.Errorf("pricingRuleRepo.UpdateRule.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("pricingRuleRepo.UpdateRule.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteRule performs a soft delete by setting is_active to false (0).
+func (r *pricingRuleRepository) DeleteRule(ctx context.Context, serviceID, ruleID int64) error {
+
+	query := `UPDATE service_pricing_rules SET is_active = 0 WHERE id = ? AND service_id = ? AND is_active = 1`
+
+	res, err := r.db.ExecContext(ctx, query, ruleID, serviceID)
+	if err != nil {
+		return fmt.Errorf("pricingRuleRepo.DeleteRule.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("pricingRuleRepo.DeleteRule.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+// rowScanner menyatukan *sql.Row dan *sql.Rows agar logika Scan tidak ditulis berulang.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPricingRule(row rowScanner) (*models.ServicePricingRule, error) {
+	var rule models.ServicePricingRule
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var minQtyNull, stepNull sql.NullFloat64
+	var unitPriceNull money.NullAmount
+	var updatedAtNull sql.NullTime
+
+	err := row.Scan(
+		&rule.ID, &rule.ServiceID, &rule.RuleType, &minQtyNull, &stepNull, &unitPriceNull,
+		&rule.IsActive, &rule.CreatedAt, &updatedAtNull,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if minQtyNull.Valid {
+		rule.MinQuantity = &minQtyNull.Float64
+	}
+	if stepNull.Valid {
+		rule.RoundingStep = &stepNull.Float64
+	}
+	if unitPriceNull.Valid {
+		rule.UnitPrice = &unitPriceNull.Amount
+	}
+	if updatedAtNull.Valid {
+		rule.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &rule, nil
+}
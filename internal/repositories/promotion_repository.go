@@ -0,0 +1,529 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// PromotionRepository mendefinisikan semua operasi database untuk promosi & kode voucher.
+type PromotionRepository interface {
+
+	// Create Operations
+	InsertPromotion(ctx context.Context, promo *models.Promotion, targetIDs []int64) error
+	InsertPromotionTx(ctx context.Context, tx *sql.Tx, promo *models.Promotion, targetIDs []int64) error
+
+	// Read Operations
+	FindAll(ctx context.Context, limit, offset int, search, status string) ([]models.Promotion, int64, error)
+	FindByID(ctx context.Context, id int64) (*models.PromotionWithScopes, error)
+	FindByCode(ctx context.Context, code string) (*models.PromotionWithScopes, error)
+	FindActiveAutomatic(ctx context.Context, at time.Time) ([]models.PromotionWithScopes, error)
+	CountCustomerRedemptions(ctx context.Context, promotionID, customerID int64) (int, error)
+
+	// Update Operations
+	UpdatePromotion(ctx context.Context, promo *models.Promotion, targetIDs []int64) error
+
+	// Delete Operations (Soft Delete)
+	DeletePromotion(ctx context.Context, id int64) error
+
+	// RedeemTx mencatat pemakaian promosi di dalam transaksi pembuatan pesanan.
+	// Baris promosi dikunci (SELECT ... FOR UPDATE) sehingga dua kasir yang memakai
+	// voucher terakhir secara bersamaan tidak bisa sama-sama berhasil.
+	RedeemTx(ctx context.Context, tx *sql.Tx, redemption *models.PromotionRedemption, discount *models.OrderDiscount) error
+
+	// InsertDiscountTx menyimpan baris diskon tanpa kuota (cth: diskon member) di dalam transaksi pesanan.
+	InsertDiscountTx(ctx context.Context, tx *sql.Tx, discount *models.OrderDiscount) error
+}
+
+// promotionRepository is the concrete implementation using sql.DB.
+type promotionRepository struct {
+	db *sql.DB
+}
+
+// NewPromotionRepository creates a new instance of PromotionRepository.
+func NewPromotionRepository(db *sql.DB) PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+const promotionColumns = `id, code, promo_name, description, discount_type, discount_value, max_discount, min_spend, scope_type,
+		valid_days, starts_at, ends_at, usage_limit, usage_count, per_customer_limit, customer_id, is_active, created_at, updated_at`
+
+// --- IMPLEMENTATION ---
+
+// InsertPromotion creates a new promotion together with its scope targets in one transaction.
+func (r *promotionRepository) InsertPromotion(ctx context.Context, promo *models.Promotion, targetIDs []int64) error {
+
+	// 1. Mulai transaksi (promosi & cakupannya harus tersimpan bersamaan)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("promotionRepo.InsertPromotion.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Simpan promosi & cakupannya
+	if err := r.InsertPromotionTx(ctx, tx, promo, targetIDs); err != nil {
+		return err
+	}
+
+	// 3. Commit transaksi
+	if err := tx.Commit(); err != nil {
+		promo.ID = 0
+		return fmt.Errorf("promotionRepo.InsertPromotion.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// InsertPromotionTx creates a promotion and its scope targets inside the caller's transaction (cth: voucher komplain).
+func (r *promotionRepository) InsertPromotionTx(ctx context.Context, tx *sql.Tx, promo *models.Promotion, targetIDs []int64) error {
+
+	// 1. Simpan data promosi
+	query := `
+		INSERT INTO promotions (code, promo_name, description, discount_type, discount_value, max_discount, min_spend, scope_type,
+			valid_days, starts_at, ends_at, usage_limit, per_customer_limit, customer_id, is_active, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := tx.ExecContext(ctx, query,
+		promo.Code,
+		promo.PromoName,
+		promo.Description,
+		promo.DiscountType,
+		promo.DiscountValue,
+		promo.MaxDiscount,
+		promo.MinSpend,
+		promo.ScopeType,
+		promo.ValidDays,
+		promo.StartsAt,
+		promo.EndsAt,
+		promo.UsageLimit,
+		promo.PerCustomerLimit,
+		promo.CustomerID,
+		promo.IsActive,
+		promo.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("promotionRepo.InsertPromotionTx.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("promotionRepo.InsertPromotionTx.LastInsertId: %w", err)
+	}
+
+	// 2. Simpan cakupan layanan/kategori
+	if err := replacePromotionScopes(ctx, tx, id, targetIDs); err != nil {
+		return fmt.Errorf("promotionRepo.InsertPromotionTx: %w", err)
+	}
+
+	promo.ID = id
+	return nil
+}
+
+// FindAll retrieves a list of promotions with pagination and filtering support.
+func (r *promotionRepository) FindAll(ctx context.Context, limit, offset int, search, status string) ([]models.Promotion, int64, error) {
+
+	// 1. Inisialisasi query dasar
+	whereClause := "WHERE 1=1"
+	var args []interface{}
+
+	// 2. Terapkan filter pencarian nama atau kode voucher
+	if search != "" {
+		whereClause += " AND (LOWER(promo_name) LIKE ? OR LOWER(code) LIKE ?)"
+		searchParam := "%" + strings.ToLower(search) + "%"
+		args = append(args, searchParam, searchParam)
+	}
+
+	// 3. Terapkan filter status aktif/non-aktif
+	if status == "1" {
+		whereClause += " AND is_active = 1"
+	} else if status == "0" {
+		whereClause += " AND is_active = 0"
+	}
+
+	// 4. Hitung total baris untuk Meta Pagination
+	var totalItems int64
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM promotions %s", whereClause)
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalItems); err != nil {
+		return nil, 0, fmt.Errorf("promotionRepo.FindAll.Count: %w", err)
+	}
+
+	// 5. Rangkai query utama (promosi terbaru di atas)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM promotions
+		%s
+		ORDER BY starts_at DESC, id DESC
+		LIMIT ? OFFSET ?`, promotionColumns, whereClause)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("promotionRepo.FindAll.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 6. Mapping hasil query
+	var promos []models.Promotion
+	for rows.Next() {
+		promo, err := scanPromotion(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("promotionRepo.FindAll.Scan: %w", err)
+		}
+		promos = append(promos, *promo)
+	}
+
+	return promos, totalItems, nil
+}
+
+// FindByID retrieves a single promotion with its scope targets.
+func (r *promotionRepository) FindByID(ctx context.Context, id int64) (*models.PromotionWithScopes, error) {
+
+	query := fmt.Sprintf("SELECT %s FROM promotions WHERE id = ?", promotionColumns)
+	promo, err := scanPromotion(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("promotionRepo.FindByID: %w", err)
+	}
+
+	return r.withScopes(ctx, promo)
+}
+
+// FindByCode retrieves a single promotion by its exact voucher code.
+func (r *promotionRepository) FindByCode(ctx context.Context, code string) (*models.PromotionWithScopes, error) {
+
+	query := fmt.Sprintf("SELECT %s FROM promotions WHERE code = ?", promotionColumns)
+	promo, err := scanPromotion(r.db.QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("promotionRepo.FindByCode: %w", err)
+	}
+
+	return r.withScopes(ctx, promo)
+}
+
+// FindActiveAutomatic retrieves active promotions without a voucher code whose validity window covers at.
+// Pemeriksaan hari, minimal belanja, dan kuota tetap dilakukan oleh engine promosi.
+func (r *promotionRepository) FindActiveAutomatic(ctx context.Context, at time.Time) ([]models.PromotionWithScopes, error) {
+
+	// 1. Ambil promosi otomatis yang periodenya sedang berjalan
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM promotions
+		WHERE code IS NULL AND is_active = 1 AND starts_at <= ? AND (ends_at IS NULL OR ends_at >= ?)
+		ORDER BY id ASC`, promotionColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, at, at)
+	if err != nil {
+		return nil, fmt.Errorf("promotionRepo.FindActiveAutomatic.Query: %w", err)
+	}
+
+	var promos []models.Promotion
+	for rows.Next() {
+		promo, err := scanPromotion(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("promotionRepo.FindActiveAutomatic.Scan: %w", err)
+		}
+		promos = append(promos, *promo)
+	}
+	rows.Close()
+
+	// 2. Lengkapi dengan cakupan masing-masing (setelah rows ditutup agar koneksi tidak tertahan)
+	result := make([]models.PromotionWithScopes, 0, len(promos))
+	for i := range promos {
+		withScopes, err := r.withScopes(ctx, &promos[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *withScopes)
+	}
+
+	return result, nil
+}
+
+// CountCustomerRedemptions counts how many times a customer has used a promotion.
+func (r *promotionRepository) CountCustomerRedemptions(ctx context.Context, promotionID, customerID int64) (int, error) {
+
+	var count int
+	query := "SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = ? AND customer_id = ?"
+	if err := r.db.QueryRowContext(ctx, query, promotionID, customerID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("promotionRepo.CountCustomerRedemptions: %w", err)
+	}
+
+	return count, nil
+}
+
+// UpdatePromotion updates a promotion; a non-nil targetIDs replaces all of its scope targets.
+func (r *promotionRepository) UpdatePromotion(ctx context.Context, promo *models.Promotion, targetIDs []int64) error {
+
+	// 1. Mulai transaksi
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("promotionRepo.UpdatePromotion.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Update data promosi (usage_count sengaja tidak disentuh, hanya diubah oleh RedeemTx)
+	query := `
+		UPDATE promotions
+		SET code = ?, promo_name = ?, description = ?, discount_type = ?, discount_value = ?, max_discount = ?, min_spend = ?,
+			scope_type = ?, valid_days = ?, starts_at = ?, ends_at = ?, usage_limit = ?, per_customer_limit = ?, is_active = ?, updated_at = ?
+		WHERE id = ?
+	`
+	res, err := tx.ExecContext(ctx, query,
+		promo.Code,
+		promo.PromoName,
+		promo.Description,
+		promo.DiscountType,
+		promo.DiscountValue,
+		promo.MaxDiscount,
+		promo.MinSpend,
+		promo.ScopeType,
+		promo.ValidDays,
+		promo.StartsAt,
+		promo.EndsAt,
+		promo.UsageLimit,
+		promo.PerCustomerLimit,
+		promo.IsActive,
+		promo.UpdatedAt,
+		promo.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("promotionRepo.UpdatePromotion.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("promotionRepo.UpdatePromotion.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	// 3. Ganti cakupan jika dikirim
+	if targetIDs != nil {
+		if err := replacePromotionScopes(ctx, tx, promo.ID, targetIDs); err != nil {
+			return fmt.Errorf("promotionRepo.UpdatePromotion: %w", err)
+		}
+	}
+
+	// 4. Commit transaksi
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("promotionRepo.UpdatePromotion.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePromotion performs a soft delete by setting is_active to false (0).
+func (r *promotionRepository) DeletePromotion(ctx context.Context, id int64) error {
+
+	res, err := r.db.ExecContext(ctx, "UPDATE promotions SET is_active = 0 WHERE id = ? AND is_active = 1", id)
+	if err != nil {
+		return fmt.Errorf("promotionRepo.DeletePromotion.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("promotionRepo.DeletePromotion.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// RedeemTx locks the promotion row, re-checks the customer restriction and both usage caps, then records the redemption and the order discount line.
+func (r *promotionRepository) RedeemTx(ctx context.Context, tx *sql.Tx, redemption *models.PromotionRedemption, discount *models.OrderDiscount) error {
+
+	// 1. Kunci baris promosi sampai transaksi pesanan selesai
+	var isActive bool
+	var usageCount int
+	var usageLimit, perCustomerLimit, customerID sql.NullInt64
+	lockQuery := "SELECT is_active, usage_count, usage_limit, per_customer_limit, customer_id FROM promotions WHERE id = ? FOR UPDATE"
+	err := tx.QueryRowContext(ctx, lockQuery, redemption.PromotionID).Scan(&isActive, &usageCount, &usageLimit, &perCustomerLimit, &customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return response.ErrNotFound
+		}
+		return fmt.Errorf("promotionRepo.RedeemTx.Lock: %w", err)
+	}
+
+	// 2. Cek ulang status, pemilik voucher & kuota total (nilai terbaru karena baris sudah terkunci)
+	if !isActive {
+		return response.ErrPromotionNotApplicable
+	}
+
+	// Voucher khusus pelanggan hanya untuk pesanan milik pelanggan tersebut
+	if customerID.Valid && (redemption.CustomerID == nil || *redemption.CustomerID != customerID.Int64) {
+		return response.ErrPromotionNotApplicable
+	}
+	if usageLimit.Valid && int64(usageCount) >= usageLimit.Int64 {
+		return response.ErrPromotionExhausted
+	}
+
+	// 3. Cek ulang kuota per pelanggan
+	if perCustomerLimit.Valid && redemption.CustomerID != nil {
+		var customerCount int64
+		countQuery := "SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = ? AND customer_id = ?"
+		if err := tx.QueryRowContext(ctx, countQuery, redemption.PromotionID, *redemption.CustomerID).Scan(&customerCount); err != nil {
+			return fmt.Errorf("promotionRepo.RedeemTx.CountCustomer: %w", err)
+		}
+		if customerCount >= perCustomerLimit.Int64 {
+			return response.ErrPromotionExhausted
+		}
+	}
+
+	// 4. Naikkan counter pemakaian
+	if _, err := tx.ExecContext(ctx, "UPDATE promotions SET usage_count = usage_count + 1 WHERE id = ?", redemption.PromotionID); err != nil {
+		return fmt.Errorf("promotionRepo.RedeemTx.Increment: %w", err)
+	}
+
+	// 5. Simpan riwayat pemakaian
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO promotion_redemptions (promotion_id, order_id, customer_id, discount_amount, redeemed_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		redemption.PromotionID,
+		redemption.OrderID,
+		redemption.CustomerID,
+		redemption.DiscountAmount,
+		redemption.RedeemedBy,
+		redemption.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("promotionRepo.RedeemTx.InsertRedemption: %w", err)
+	}
+	if redemption.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("promotionRepo.RedeemTx.LastInsertId: %w", err)
+	}
+
+	// 6. Simpan baris penjelasan diskon di pesanan
+	if err := r.InsertDiscountTx(ctx, tx, discount); err != nil {
+		return fmt.Errorf("promotionRepo.RedeemTx: %w", err)
+	}
+
+	return nil
+}
+
+// InsertDiscountTx stores one explanation line in order_discounts.
+func (r *promotionRepository) InsertDiscountTx(ctx context.Context, tx *sql.Tx, discount *models.OrderDiscount) error {
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO order_discounts (order_id, promotion_id, code, explanation, amount, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		discount.OrderID,
+		discount.PromotionID,
+		discount.Code,
+		discount.Explanation,
+		discount.Amount,
+		discount.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("promotionRepo.InsertDiscountTx.Exec: %w", err)
+	}
+
+	if discount.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("promotionRepo.InsertDiscountTx.LastInsertId: %w", err)
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+// withScopes melengkapi promosi dengan daftar target cakupannya.
+func (r *promotionRepository) withScopes(ctx context.Context, promo *models.Promotion) (*models.PromotionWithScopes, error) {
+
+	rows, err := r.db.QueryContext(ctx, "SELECT target_id FROM promotion_scopes WHERE promotion_id = ? ORDER BY target_id", promo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("promotionRepo.withScopes.Query: %w", err)
+	}
+	defer rows.Close()
+
+	result := &models.PromotionWithScopes{Promotion: *promo, TargetIDs: []int64{}}
+	for rows.Next() {
+		var targetID int64
+		if err := rows.Scan(&targetID); err != nil {
+			return nil, fmt.Errorf("promotionRepo.withScopes.Scan: %w", err)
+		}
+		result.TargetIDs = append(result.TargetIDs, targetID)
+	}
+
+	return result, rows.Err()
+}
+
+// replacePromotionScopes menghapus semua cakupan lama lalu menyimpan cakupan baru di dalam transaksi yang sama.
+func replacePromotionScopes(ctx context.Context, tx *sql.Tx, promotionID int64, targetIDs []int64) error {
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM promotion_scopes WHERE promotion_id = ?", promotionID); err != nil {
+		return fmt.Errorf("replacePromotionScopes.Delete: %w", err)
+	}
+
+	for _, targetID := range targetIDs {
+		if _, err := tx.ExecContext(ctx, "INSERT IGNORE INTO promotion_scopes (promotion_id, target_id) VALUES (?, ?)", promotionID, targetID); err != nil {
+			return fmt.Errorf("replacePromotionScopes.Insert: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func scanPromotion(row rowScanner) (*models.Promotion, error) {
+	var promo models.Promotion
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var codeNull, descNull, validDaysNull sql.NullString
+	var maxDiscountNull money.NullAmount
+	var endsAtNull, updatedAtNull sql.NullTime
+	var usageLimitNull, perCustomerNull, customerNull sql.NullInt64
+
+	err := row.Scan(
+		&promo.ID, &codeNull, &promo.PromoName, &descNull, &promo.DiscountType, &promo.DiscountValue, &maxDiscountNull,
+		&promo.MinSpend, &promo.ScopeType, &validDaysNull, &promo.StartsAt, &endsAtNull, &usageLimitNull,
+		&promo.UsageCount, &perCustomerNull, &customerNull, &promo.IsActive, &promo.CreatedAt, &updatedAtNull,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if codeNull.Valid {
+		promo.Code = &codeNull.String
+	}
+	if descNull.Valid {
+		promo.Description = &descNull.String
+	}
+	if validDaysNull.Valid {
+		promo.ValidDays = &validDaysNull.String
+	}
+	if maxDiscountNull.Valid {
+		promo.MaxDiscount = &maxDiscountNull.Amount
+	}
+	if endsAtNull.Valid {
+		promo.EndsAt = &endsAtNull.Time
+	}
+	if usageLimitNull.Valid {
+		limit := int(usageLimitNull.Int64)
+		promo.UsageLimit = &limit
+	}
+	if perCustomerNull.Valid {
+		limit := int(perCustomerNull.Int64)
+		promo.PerCustomerLimit = &limit
+	}
+	promo.CustomerID = nullInt64Ptr(customerNull)
+	if updatedAtNull.Valid {
+		promo.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &promo, nil
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// TestRedeemTxConcurrentUsageLimit menjalankan banyak kasir yang memakai voucher yang sama secara bersamaan.
+// Hanya usage_limit transaksi pertama yang boleh berhasil; sisanya harus gagal dengan ErrPromotionExhausted.
+func TestRedeemTxConcurrentUsageLimit(t *testing.T) {
+
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewPromotionRepository(db)
+
+	const usageLimit, cashiers = 3, 12
+	code := fmt.Sprintf("RACE%d", time.Now().UnixNano()%1e9)
+
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO promotions (code, promo_name, discount_type, discount_value, scope_type, starts_at, usage_limit, is_active)
+		VALUES (?, 'Race test', 'fixed', 1000, 'all', NOW(), ?, 1)`, code, usageLimit)
+	if err != nil {
+		t.Fatalf("insert promotion: %v", err)
+	}
+	promotionID, _ := res.LastInsertId()
+	t.Cleanup(func() {
+		db.ExecContext(ctx, "DELETE FROM order_discounts WHERE promotion_id = ?", promotionID)
+		db.ExecContext(ctx, "DELETE FROM promotion_redemptions WHERE promotion_id = ?", promotionID)
+		db.ExecContext(ctx, "DELETE FROM promotions WHERE id = ?", promotionID)
+	})
+
+	// Setiap goroutine = satu transaksi pesanan. Pesanan fiktif dipakai (FK dimatikan per koneksi),
+	// karena yang diuji hanya penguncian baris promosi.
+	redeem := func(orderID int64) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if _, err := tx.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+			return err
+		}
+
+		promoID := promotionID
+		redeemErr := repo.RedeemTx(ctx, tx,
+			&models.PromotionRedemption{PromotionID: promotionID, OrderID: orderID, DiscountAmount: money.New(1000), CreatedAt: time.Now()},
+			&models.OrderDiscount{OrderID: orderID, PromotionID: &promoID, Code: &code, Explanation: "Race test", Amount: money.New(1000), CreatedAt: time.Now()},
+		)
+
+		// Variabel sesi ikut kembali ke pool, jadi selalu dipulihkan di koneksi yang sama
+		if _, err := tx.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 1"); err != nil {
+			return err
+		}
+		if redeemErr != nil {
+			return redeemErr
+		}
+		return tx.Commit()
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, cashiers)
+	start := make(chan struct{})
+	for i := 0; i < cashiers; i++ {
+		wg.Add(1)
+		go func(orderID int64) {
+			defer wg.Done()
+			<-start
+			errs <- redeem(orderID)
+		}(int64(900000000 + i))
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	succeeded, exhausted := 0, 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, response.ErrPromotionExhausted):
+			exhausted++
+		default:
+			t.Errorf("unexpected redeem error: %v", err)
+		}
+	}
+	if succeeded != usageLimit || exhausted != cashiers-usageLimit {
+		t.Fatalf("succeeded = %d, exhausted = %d, want %d and %d", succeeded, exhausted, usageLimit, cashiers-usageLimit)
+	}
+
+	// usage_count & jumlah riwayat harus sama persis dengan kuota
+	var usageCount, redemptions int
+	db.QueryRowContext(ctx, "SELECT usage_count FROM promotions WHERE id = ?", promotionID).Scan(&usageCount)
+	db.QueryRowContext(ctx, "SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = ?", promotionID).Scan(&redemptions)
+	if usageCount != usageLimit || redemptions != usageLimit {
+		t.Fatalf("usage_count = %d, redemptions = %d, want %d", usageCount, redemptions, usageLimit)
+	}
+}
+
+// TestRedeemTxRejectsOtherCustomer: voucher yang terikat ke satu pelanggan (cth: voucher komplain) tidak bisa
+// dipakai pesanan pelanggan lain atau pesanan tanpa pelanggan, walaupun kodenya diketahui.
+func TestRedeemTxRejectsOtherCustomer(t *testing.T) {
+
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewPromotionRepository(db)
+
+	code := fmt.Sprintf("OWNED%d", time.Now().UnixNano()%1e9)
+	owner, stranger := int64(900000001), int64(900000002)
+
+	// Pelanggan fiktif dipakai (FK dimatikan per koneksi), yang diuji hanya pengecekan pemilik voucher
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("db.Conn: %v", err)
+	}
+	// Variabel sesi ikut kembali ke pool, jadi selalu dipulihkan sebelum koneksi dilepas
+	defer func() {
+		conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 1")
+		conn.Close()
+	}()
+	if _, err := conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+		t.Fatalf("disable FK: %v", err)
+	}
+	res, err := conn.ExecContext(ctx, `
+		INSERT INTO promotions (code, promo_name, discount_type, discount_value, scope_type, starts_at, usage_limit, customer_id, is_active)
+		VALUES (?, 'Owner test', 'fixed', 1000, 'all', NOW(), 1, ?, 1)`, code, owner)
+	if err != nil {
+		t.Fatalf("insert promotion: %v", err)
+	}
+	promotionID, _ := res.LastInsertId()
+	t.Cleanup(func() {
+		db.ExecContext(ctx, "DELETE FROM order_discounts WHERE promotion_id = ?", promotionID)
+		db.ExecContext(ctx, "DELETE FROM promotion_redemptions WHERE promotion_id = ?", promotionID)
+		db.ExecContext(ctx, "DELETE FROM promotions WHERE id = ?", promotionID)
+	})
+
+	redeem := func(orderID int64, customerID *int64) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		promoID := promotionID
+		if err := repo.RedeemTx(ctx, tx,
+			&models.PromotionRedemption{PromotionID: promotionID, OrderID: orderID, CustomerID: customerID, DiscountAmount: money.New(1000), CreatedAt: time.Now()},
+			&models.OrderDiscount{OrderID: orderID, PromotionID: &promoID, Code: &code, Explanation: "Owner test", Amount: money.New(1000), CreatedAt: time.Now()},
+		); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	if err := redeem(900000101, nil); !errors.Is(err, response.ErrPromotionNotApplicable) {
+		t.Fatalf("redeem without customer = %v, want ErrPromotionNotApplicable", err)
+	}
+	if err := redeem(900000102, &stranger); !errors.Is(err, response.ErrPromotionNotApplicable) {
+		t.Fatalf("redeem by another customer = %v, want ErrPromotionNotApplicable", err)
+	}
+	if err := redeem(900000103, &owner); err != nil {
+		t.Fatalf("redeem by owner: %v", err)
+	}
+}
@@ -0,0 +1,281 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+)
+
+// ReceiptRepository mengambil seluruh data yang dibutuhkan untuk mencetak nota pesanan.
+type ReceiptRepository interface {
+	FindOrderReceipt(ctx context.Context, orderID int64) (*models.Receipt, error)
+}
+
+// receiptRepository is the concrete implementation using sql.DB.
+type receiptRepository struct {
+	db *sql.DB
+}
+
+// NewReceiptRepository creates a new instance of ReceiptRepository.
+func NewReceiptRepository(db *sql.DB) ReceiptRepository {
+	return &receiptRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// FindOrderReceipt retrieves an order with its items, add-ons, discounts, taxes and latest payment.
+// Pesanan outlet lain (di luar outlet aktif ctx) dianggap tidak ada.
+func (r *receiptRepository) FindOrderReceipt(ctx context.Context, orderID int64) (*models.Receipt, error) {
+
+	// 1. Ambil nota induk (nama pelanggan memakai snapshot pesanan, fallback ke master pelanggan)
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	query := `
+		SELECT o.id, o.invoice_number,
+			COALESCE(o.customer_name, c.full_name), COALESCE(o.customer_phone, c.phone_number), COALESCE(o.customer_address, c.address),
+			o.is_delivery, o.subtotal, o.discount_total, o.service_charge_total, o.tax_total, o.grand_total,
+			o.payment_status, o.status_internal, o.estimated_ready_at, o.notes, u.full_name, o.created_at
+		FROM orders o
+		LEFT JOIN customers c ON c.id = o.customer_id
+		LEFT JOIN users u ON u.id = o.created_by
+		WHERE o.id = ?` + scope
+	var receipt models.Receipt
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var nameNull, phoneNull, addressNull, notesNull, cashierNull sql.NullString
+	var isDeliveryNull sql.NullBool
+	var paymentStatusNull, statusNull sql.NullString
+	var readyAtNull, createdAtNull sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, append([]interface{}{orderID}, scopeArgs...)...).Scan(
+		&receipt.OrderID, &receipt.InvoiceNumber,
+		&nameNull, &phoneNull, &addressNull,
+		&isDeliveryNull, &receipt.Subtotal, &receipt.DiscountTotal, &receipt.ServiceChargeTotal, &receipt.TaxTotal, &receipt.GrandTotal,
+		&paymentStatusNull, &statusNull, &readyAtNull, &notesNull, &cashierNull, &createdAtNull,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("receiptRepo.FindOrderReceipt.Order: %w", err)
+	}
+
+	receipt.CustomerName = nullStringPtr(nameNull)
+	receipt.CustomerPhone = nullStringPtr(phoneNull)
+	receipt.CustomerAddress = nullStringPtr(addressNull)
+	receipt.Notes = nullStringPtr(notesNull)
+	receipt.CreatedByName = nullStringPtr(cashierNull)
+	receipt.IsDelivery = isDeliveryNull.Bool
+	receipt.PaymentStatus = paymentStatusNull.String
+	receipt.StatusInternal = statusNull.String
+	if readyAtNull.Valid {
+		receipt.EstimatedReadyAt = &readyAtNull.Time
+	}
+	if createdAtNull.Valid {
+		receipt.CreatedAt = createdAtNull.Time
+	}
+
+	// 2. Ambil item, add-on, diskon, pajak, dan pembayaran
+	if receipt.Items, err = r.findItems(ctx, orderID); err != nil {
+		return nil, err
+	}
+	if receipt.Discounts, err = r.findDiscounts(ctx, orderID); err != nil {
+		return nil, err
+	}
+	if receipt.Taxes, err = r.findTaxes(ctx, orderID); err != nil {
+		return nil, err
+	}
+	if receipt.Payment, err = r.findPayment(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	return &receipt, nil
+}
+
+func (r *receiptRepository) findItems(ctx context.Context, orderID int64) ([]models.ReceiptItem, error) {
+
+	// 1. Ambil item beserta nama & satuan layanan
+	query := `
+		SELECT oi.id, COALESCE(s.service_name, '-'), COALESCE(s.unit, 'pcs'),
+			oi.quantity, oi.weight_kg, oi.qty_pieces, oi.unit_price, oi.subtotal, oi.item_notes
+		FROM order_items oi
+		LEFT JOIN services s ON s.id = oi.service_id
+		WHERE oi.order_id = ?
+		ORDER BY oi.id ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("receiptRepo.findItems.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var itemIDs []int64
+	items := []models.ReceiptItem{}
+	for rows.Next() {
+		var id int64
+		var item models.ReceiptItem
+		var quantityNull, piecesNull sql.NullInt64
+		var weightNull sql.NullFloat64
+		var notesNull sql.NullString
+
+		if err := rows.Scan(
+			&id, &item.ServiceName, &item.Unit,
+			&quantityNull, &weightNull, &piecesNull, &item.UnitPrice, &item.Subtotal, &notesNull,
+		); err != nil {
+			return nil, fmt.Errorf("receiptRepo.findItems.Scan: %w", err)
+		}
+
+		// Layanan kiloan ditagih per berat, layanan satuan per jumlah
+		item.Quantity = float64(quantityNull.Int64)
+		if item.Unit == "kg" && weightNull.Valid {
+			item.Quantity = weightNull.Float64
+		}
+		if piecesNull.Valid {
+			pieces := int(piecesNull.Int64)
+			item.QtyPieces = &pieces
+		}
+		item.ItemNotes = nullStringPtr(notesNull)
+
+		itemIDs = append(itemIDs, id)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("receiptRepo.findItems.Rows: %w", err)
+	}
+
+	// 2. Ambil add-on per item (satu kueri, lalu dipetakan ke itemnya)
+	if len(itemIDs) == 0 {
+		return items, nil
+	}
+	addonRows, err := r.db.QueryContext(ctx, `
+		SELECT oia.order_item_id, oia.addon_name, oia.amount
+		FROM order_item_addons oia
+		JOIN order_items oi ON oi.id = oia.order_item_id
+		WHERE oi.order_id = ?
+		ORDER BY oia.id ASC
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("receiptRepo.findItems.Addons: %w", err)
+	}
+	defer addonRows.Close()
+
+	indexByID := make(map[int64]int, len(itemIDs))
+	for i, id := range itemIDs {
+		indexByID[id] = i
+	}
+	for addonRows.Next() {
+		var itemID int64
+		var addon models.ReceiptItemAddon
+		if err := addonRows.Scan(&itemID, &addon.AddonName, &addon.Amount); err != nil {
+			return nil, fmt.Errorf("receiptRepo.findItems.ScanAddon: %w", err)
+		}
+		if i, ok := indexByID[itemID]; ok {
+			items[i].Addons = append(items[i].Addons, addon)
+		}
+	}
+	if err := addonRows.Err(); err != nil {
+		return nil, fmt.Errorf("receiptRepo.findItems.AddonRows: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *receiptRepository) findDiscounts(ctx context.Context, orderID int64) ([]models.ReceiptDiscount, error) {
+
+	rows, err := r.db.QueryContext(ctx, "SELECT explanation, amount FROM order_discounts WHERE order_id = ? ORDER BY id ASC", orderID)
+	if err != nil {
+		return nil, fmt.Errorf("receiptRepo.findDiscounts.Query: %w", err)
+	}
+	defer rows.Close()
+
+	discounts := []models.ReceiptDiscount{}
+	for rows.Next() {
+		var discount models.ReceiptDiscount
+		if err := rows.Scan(&discount.Explanation, &discount.Amount); err != nil {
+			return nil, fmt.Errorf("receiptRepo.findDiscounts.Scan: %w", err)
+		}
+		discounts = append(discounts, discount)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("receiptRepo.findDiscounts.Rows: %w", err)
+	}
+
+	return discounts, nil
+}
+
+func (r *receiptRepository) findTaxes(ctx context.Context, orderID int64) ([]models.ReceiptTax, error) {
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT tax_name, tax_kind, rate, price_mode, amount
+		FROM order_taxes
+		WHERE order_id = ?
+		ORDER BY id ASC
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("receiptRepo.findTaxes.Query: %w", err)
+	}
+	defer rows.Close()
+
+	taxes := []models.ReceiptTax{}
+	for rows.Next() {
+		var tax models.ReceiptTax
+		if err := rows.Scan(&tax.TaxName, &tax.TaxKind, &tax.Rate, &tax.PriceMode, &tax.Amount); err != nil {
+			return nil, fmt.Errorf("receiptRepo.findTaxes.Scan: %w", err)
+		}
+		taxes = append(taxes, tax)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("receiptRepo.findTaxes.Rows: %w", err)
+	}
+
+	return taxes, nil
+}
+
+func (r *receiptRepository) findPayment(ctx context.Context, orderID int64) (*models.ReceiptPayment, error) {
+
+	query := `
+		SELECT method, amount, amount_received, amount_change, status, collected_at
+		FROM payments
+		WHERE order_id = ? AND status <> 'void'
+		ORDER BY id DESC
+		LIMIT 1
+	`
+	var payment models.ReceiptPayment
+	var methodNull sql.NullString
+	var collectedAtNull sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, orderID).Scan(
+		&methodNull, &payment.Amount, &payment.AmountReceived, &payment.AmountChange, &payment.Status, &collectedAtNull,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Pesanan belum punya tagihan
+		}
+		return nil, fmt.Errorf("receiptRepo.findPayment: %w", err)
+	}
+
+	payment.Method = nullStringPtr(methodNull)
+	if collectedAtNull.Valid {
+		payment.CollectedAt = &collectedAtNull.Time
+	}
+
+	return &payment, nil
+}
+
+// nullStringPtr mengubah sql.NullString menjadi *string (nil jika NULL).
+func nullStringPtr(ns sql.NullString) *string {
+	if !ns.Valid {
+		return nil
+	}
+	return &ns.String
+}
+
+// nullInt64Ptr mengubah sql.NullInt64 menjadi *int64 (nil jika NULL).
+func nullInt64Ptr(ni sql.NullInt64) *int64 {
+	if !ni.Valid {
+		return nil
+	}
+	return &ni.Int64
+}
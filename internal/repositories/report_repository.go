@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"laundry-backend/internal/models"
+	"time"
+)
+
+// ReportRepository mendefinisikan query agregasi lintas modul untuk laporan owner (dibatasi outlet aktif di context).
+type ReportRepository interface {
+	SumRevenueByDay(ctx context.Context, start, end time.Time) ([]models.DailyAmount, error)
+	SumExpensesByDay(ctx context.Context, start, end time.Time) ([]models.DailyAmount, error)
+	SumExpensesByCategory(ctx context.Context, start, end time.Time) ([]models.ExpenseCategoryTotal, error)
+	CountEmployeeActivity(ctx context.Context, start, end time.Time) ([]models.EmployeeActivity, error)
+	CountEmployeeComplaints(ctx context.Context, start, end time.Time) ([]models.EmployeeActivity, error)
+}
+
+// reportRepository is the concrete implementation using sql.DB.
+type reportRepository struct {
+	db *sql.DB
+}
+
+// NewReportRepository creates a new instance of ReportRepository.
+func NewReportRepository(db *sql.DB) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// SumRevenueByDay aggregates the net revenue (grand_total - tax_total) of orders paid in [start, end) per day.
+// Aturan sama dengan laporan pajak: hanya payment_status 'paid' dan bukan pesanan batal.
+// Hari ditentukan oleh waktu lunas (payments.paid_at terakhir), bukan waktu nota dibuat;
+// DATE_FORMAT mengikuti time_zone sesi yang dipaku ke config.DBTimeZone.
+func (r *reportRepository) SumRevenueByDay(ctx context.Context, start, end time.Time) ([]models.DailyAmount, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	query := `
+		SELECT DATE_FORMAT(p.paid_at, '%Y-%m-%d') AS day, COUNT(*), COALESCE(SUM(o.grand_total - o.tax_total), 0)
+		FROM orders o
+		JOIN (
+			SELECT order_id, MAX(paid_at) AS paid_at
+			FROM payments
+			WHERE status = 'confirmed' AND paid_at IS NOT NULL
+			GROUP BY order_id
+		) p ON p.order_id = o.id
+		WHERE o.payment_status = 'paid' AND o.status_internal <> 'cancelled' AND p.paid_at >= ? AND p.paid_at < ?` + scope + `
+		GROUP BY day
+		ORDER BY day ASC`
+
+	return r.queryDailyAmounts(ctx, "SumRevenueByDay", query, append([]interface{}{start, end}, scopeArgs...))
+}
+
+// SumExpensesByDay aggregates expenses dated in [start, end) per day.
+func (r *reportRepository) SumExpensesByDay(ctx context.Context, start, end time.Time) ([]models.DailyAmount, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	query := `
+		SELECT DATE_FORMAT(expense_date, '%Y-%m-%d') AS day, COUNT(*), COALESCE(SUM(amount), 0)
+		FROM expenses
+		WHERE expense_date >= ? AND expense_date < ?` + scope + `
+		GROUP BY day
+		ORDER BY day ASC`
+
+	args := append([]interface{}{start.Format("2006-01-02"), end.Format("2006-01-02")}, scopeArgs...)
+	return r.queryDailyAmounts(ctx, "SumExpensesByDay", query, args)
+}
+
+// SumExpensesByCategory aggregates expenses dated in [start, end) per expense category, largest first.
+func (r *reportRepository) SumExpensesByCategory(ctx context.Context, start, end time.Time) ([]models.ExpenseCategoryTotal, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "e.outlet_id")
+	query := `
+		SELECT ec.id, ec.category_name, SUM(e.amount) AS total
+		FROM expenses e
+		JOIN expense_categories ec ON ec.id = e.expense_category_id
+		WHERE e.expense_date >= ? AND e.expense_date < ?` + scope + `
+		GROUP BY ec.id, ec.category_name
+		ORDER BY total DESC, ec.id ASC`
+
+	args := append([]interface{}{start.Format("2006-01-02"), end.Format("2006-01-02")}, scopeArgs...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("reportRepo.SumExpensesByCategory.Query: %w", err)
+	}
+	defer rows.Close()
+
+	totals := []models.ExpenseCategoryTotal{}
+	for rows.Next() {
+		var t models.ExpenseCategoryTotal
+		if err := rows.Scan(&t.ExpenseCategoryID, &t.CategoryName, &t.Amount); err != nil {
+			return nil, fmt.Errorf("reportRepo.SumExpensesByCategory.Scan: %w", err)
+		}
+		totals = append(totals, t)
+	}
+
+	return totals, rows.Err()
+}
+
+// CountEmployeeActivity counts, per employee, the work recorded in [start, end):
+//   - cashier: pesanan yang dibuat (orders.created_by, pesanan cuci ulang tidak dihitung)
+//   - staff: pesanan berbeda yang dipindah ke in-progress / ready-* (status_history)
+//   - courier: pesanan berbeda yang diselesaikan ke finished-delivery (status_history)
+func (r *reportRepository) CountEmployeeActivity(ctx context.Context, start, end time.Time) ([]models.EmployeeActivity, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	query := `
+		SELECT u.id, u.full_name, u.role, COUNT(*)
+		FROM orders o
+		JOIN users u ON u.id = o.created_by
+		WHERE u.role = 'cashier' AND o.parent_order_id IS NULL AND o.created_at >= ? AND o.created_at < ?` + scope + `
+		GROUP BY u.id, u.full_name, u.role
+		UNION ALL
+		SELECT u.id, u.full_name, u.role, COUNT(DISTINCT sh.order_id)
+		FROM status_history sh
+		JOIN orders o ON o.id = sh.order_id
+		JOIN users u ON u.id = sh.actor_id
+		WHERE u.role = 'staff' AND sh.new_status IN ('in-progress', 'ready-pickup', 'ready-delivery')
+			AND sh.created_at >= ? AND sh.created_at < ?` + scope + `
+		GROUP BY u.id, u.full_name, u.role
+		UNION ALL
+		SELECT u.id, u.full_name, u.role, COUNT(DISTINCT sh.order_id)
+		FROM status_history sh
+		JOIN orders o ON o.id = sh.order_id
+		JOIN users u ON u.id = sh.actor_id
+		WHERE u.role = 'courier' AND sh.new_status = 'finished-delivery'
+			AND sh.created_at >= ? AND sh.created_at < ?` + scope + `
+		GROUP BY u.id, u.full_name, u.role`
+
+	var args []interface{}
+	for i := 0; i < 3; i++ {
+		args = append(args, start, end)
+		args = append(args, scopeArgs...)
+	}
+	return r.queryEmployeeActivity(ctx, "CountEmployeeActivity", query, args)
+}
+
+// CountEmployeeComplaints counts, per employee, the complaints filed in [start, end) (kecuali yang ditolak)
+// against orders the employee handled before the complaint, based on status_history.
+func (r *reportRepository) CountEmployeeComplaints(ctx context.Context, start, end time.Time) ([]models.EmployeeActivity, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "c.outlet_id")
+	query := `
+		SELECT u.id, u.full_name, u.role, COUNT(DISTINCT c.id)
+		FROM complaints c
+		JOIN status_history sh ON sh.order_id = c.order_id AND sh.created_at <= c.created_at
+		JOIN users u ON u.id = sh.actor_id
+		WHERE c.complaint_status <> 'rejected' AND u.role IN ('cashier', 'staff', 'courier')
+			AND c.created_at >= ? AND c.created_at < ?` + scope + `
+		GROUP BY u.id, u.full_name, u.role`
+
+	return r.queryEmployeeActivity(ctx, "CountEmployeeComplaints", query, append([]interface{}{start, end}, scopeArgs...))
+}
+
+// --- HELPER FUNCTION ---
+
+func (r *reportRepository) queryDailyAmounts(ctx context.Context, op, query string, args []interface{}) ([]models.DailyAmount, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("reportRepo.%s.Query: %w", op, err)
+	}
+	defer rows.Close()
+
+	amounts := []models.DailyAmount{}
+	for rows.Next() {
+		var d models.DailyAmount
+		if err := rows.Scan(&d.Date, &d.Count, &d.Amount); err != nil {
+			return nil, fmt.Errorf("reportRepo.%s.Scan: %w", op, err)
+		}
+		amounts = append(amounts, d)
+	}
+
+	return amounts, rows.Err()
+}
+
+func (r *reportRepository) queryEmployeeActivity(ctx context.Context, op, query string, args []interface{}) ([]models.EmployeeActivity, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("reportRepo.%s.Query: %w", op, err)
+	}
+	defer rows.Close()
+
+	activities := []models.EmployeeActivity{}
+	for rows.Next() {
+		var a models.EmployeeActivity
+		if err := rows.Scan(&a.UserID, &a.FullName, &a.Role, &a.Count); err != nil {
+			return nil, fmt.Errorf("reportRepo.%s.Scan: %w", op, err)
+		}
+		activities = append(activities, a)
+	}
+
+	return activities, rows.Err()
+}
@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"laundry-backend/internal/models"
+)
+
+// SearchRepository menjalankan pencarian FULLTEXT (parser ngram) untuk kotak pencarian global kasir.
+// Parameter match adalah ekspresi BOOLEAN MODE yang sudah disanitasi service (cth: `+"budi" +"0812"`),
+// sedangkan exact adalah kata kunci mentah untuk menaikkan peringkat hasil yang sama persis.
+type SearchRepository interface {
+	SearchOrders(ctx context.Context, match, exact string, courierID *int64, limit int) ([]models.SearchOrderHit, error)
+	SearchCustomers(ctx context.Context, match, exact string, limit int) ([]models.SearchCustomerHit, error)
+	SearchServices(ctx context.Context, match, exact string, activeOnly bool, limit int) ([]models.SearchServiceHit, error)
+}
+
+// searchRepository is the concrete implementation using sql.DB.
+type searchRepository struct {
+	db *sql.DB
+}
+
+// NewSearchRepository creates a new instance of SearchRepository.
+func NewSearchRepository(db *sql.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// exactMatchBoost memastikan hasil yang sama persis (nomor nota / HP / kode layanan) selalu di urutan teratas.
+const exactMatchBoost = 100
+
+// --- IMPLEMENTATION ---
+
+// SearchOrders mencari pesanan lewat nomor nota, nama & HP pelanggan, catatan pesanan, dan catatan item.
+// Jika courierID diisi, hanya pesanan antar yang ditugaskan ke kurir tersebut yang dikembalikan.
+// Hasil selalu dibatasi ke outlet aktif ctx (kecuali owner mode konsolidasi).
+func (r *searchRepository) SearchOrders(ctx context.Context, match, exact string, courierID *int64, limit int) ([]models.SearchOrderHit, error) {
+
+	// 1. Kumpulkan kandidat dari tiga sumber index, ambil skor tertinggi per pesanan:
+	//    a. kolom pesanan (nota, snapshot nama/HP, catatan)
+	//    b. catatan item
+	//    c. master pelanggan (pesanan lama yang tidak menyimpan snapshot nama/HP)
+	args := []interface{}{exact, match, match, match, match, match, match}
+
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	args = append(args, scopeArgs...)
+
+	courierJoin := ""
+	if courierID != nil {
+		courierJoin = "JOIN deliveries d ON d.order_id = o.id AND d.courier_id = ?"
+		args = append(args, *courierID)
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT o.id, o.invoice_number,
+			COALESCE(o.customer_name, c.full_name), COALESCE(o.customer_phone, c.phone_number),
+			o.status_internal, o.payment_status, o.grand_total, COALESCE(o.is_delivery, 0), m.matched_item,
+			m.score + (o.invoice_number = ?) * %d AS score, o.created_at
+		FROM (
+			SELECT hit.order_id, MAX(hit.score) AS score, MAX(hit.matched_item) AS matched_item
+			FROM (
+				SELECT id AS order_id, MATCH(invoice_number, customer_name, customer_phone, notes) AGAINST (? IN BOOLEAN MODE) AS score, NULL AS matched_item
+				FROM orders
+				WHERE MATCH(invoice_number, customer_name, customer_phone, notes) AGAINST (? IN BOOLEAN MODE)
+				UNION ALL
+				SELECT order_id, MATCH(item_notes) AGAINST (? IN BOOLEAN MODE), item_notes
+				FROM order_items
+				WHERE MATCH(item_notes) AGAINST (? IN BOOLEAN MODE)
+				UNION ALL
+				SELECT ox.id, MATCH(cx.full_name, cx.phone_number) AGAINST (? IN BOOLEAN MODE), NULL
+				FROM customers cx
+				JOIN orders ox ON ox.customer_id = cx.id
+				WHERE MATCH(cx.full_name, cx.phone_number) AGAINST (? IN BOOLEAN MODE)
+			) hit
+			GROUP BY hit.order_id
+		) m
+		JOIN orders o ON o.id = m.order_id%s
+		LEFT JOIN customers c ON c.id = o.customer_id
+		%s
+		ORDER BY score DESC, o.created_at DESC
+		LIMIT ?`, exactMatchBoost, scope, courierJoin)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("searchRepo.SearchOrders.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 2. Mapping hasil
+	hits := make([]models.SearchOrderHit, 0, limit)
+	for rows.Next() {
+		var h models.SearchOrderHit
+		var customerName, customerPhone, matchedItem sql.NullString
+		if err := rows.Scan(
+			&h.ID, &h.InvoiceNumber, &customerName, &customerPhone,
+			&h.Status, &h.PaymentStatus, &h.GrandTotal, &h.IsDelivery, &matchedItem,
+			&h.Score, &h.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("searchRepo.SearchOrders.Scan: %w", err)
+		}
+		if customerName.Valid {
+			h.CustomerName = &customerName.String
+		}
+		if customerPhone.Valid {
+			h.CustomerPhone = &customerPhone.String
+		}
+		if matchedItem.Valid {
+			h.MatchedItem = &matchedItem.String
+		}
+		hits = append(hits, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searchRepo.SearchOrders.Rows: %w", err)
+	}
+
+	return hits, nil
+}
+
+// SearchCustomers mencari pelanggan lewat nama atau potongan nomor HP.
+func (r *searchRepository) SearchCustomers(ctx context.Context, match, exact string, limit int) ([]models.SearchCustomerHit, error) {
+
+	query := fmt.Sprintf(`
+		SELECT id, full_name, phone_number, COALESCE(is_active, 1),
+			MATCH(full_name, phone_number) AGAINST (? IN BOOLEAN MODE) + (phone_number = ?) * %d AS score
+		FROM customers
+		WHERE MATCH(full_name, phone_number) AGAINST (? IN BOOLEAN MODE)
+		ORDER BY score DESC, full_name ASC
+		LIMIT ?`, exactMatchBoost)
+
+	rows, err := r.db.QueryContext(ctx, query, match, exact, match, limit)
+	if err != nil {
+		return nil, fmt.Errorf("searchRepo.SearchCustomers.Query: %w", err)
+	}
+	defer rows.Close()
+
+	hits := make([]models.SearchCustomerHit, 0, limit)
+	for rows.Next() {
+		var h models.SearchCustomerHit
+		if err := rows.Scan(&h.ID, &h.FullName, &h.PhoneNumber, &h.IsActive, &h.Score); err != nil {
+			return nil, fmt.Errorf("searchRepo.SearchCustomers.Scan: %w", err)
+		}
+		hits = append(hits, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searchRepo.SearchCustomers.Rows: %w", err)
+	}
+
+	return hits, nil
+}
+
+// SearchServices mencari layanan lewat nama atau kode. activeOnly menyembunyikan layanan non-aktif (role selain owner).
+func (r *searchRepository) SearchServices(ctx context.Context, match, exact string, activeOnly bool, limit int) ([]models.SearchServiceHit, error) {
+
+	activeFilter := ""
+	if activeOnly {
+		activeFilter = "AND is_active = 1"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, code, service_name, unit, price, is_active,
+			MATCH(service_name, code) AGAINST (? IN BOOLEAN MODE) + (code = ?) * %d AS score
+		FROM services
+		WHERE MATCH(service_name, code) AGAINST (? IN BOOLEAN MODE) %s
+		ORDER BY score DESC, service_name ASC
+		LIMIT ?`, exactMatchBoost, activeFilter)
+
+	rows, err := r.db.QueryContext(ctx, query, match, exact, match, limit)
+	if err != nil {
+		return nil, fmt.Errorf("searchRepo.SearchServices.Query: %w", err)
+	}
+	defer rows.Close()
+
+	hits := make([]models.SearchServiceHit, 0, limit)
+	for rows.Next() {
+		var h models.SearchServiceHit
+		if err := rows.Scan(&h.ID, &h.Code, &h.ServiceName, &h.Unit, &h.Price, &h.IsActive, &h.Score); err != nil {
+			return nil, fmt.Errorf("searchRepo.SearchServices.Scan: %w", err)
+		}
+		hits = append(hits, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searchRepo.SearchServices.Rows: %w", err)
+	}
+
+	return hits, nil
+}
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"laundry-backend/internal/models"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/money"
 	"laundry-backend/pkg/response"
-	"strings"
+	"time"
 )
 
 // ServiceRepository adalah kontrak yang mendefinisikan semua operasi database untuk layanan.
@@ -18,16 +21,18 @@ type ServiceRepository interface {
 	InsertService(ctx context.Context, service *models.Service) error
 
 	// Read Operations
-	FindAll(ctx context.Context, limit, offset int, search, status, sortBy, sortOrder string) ([]models.ServiceWithCategory, int64, error)
+	FindAll(ctx context.Context, params listquery.Params) ([]models.ServiceWithCategory, *listquery.Result, error)
+	StreamAll(ctx context.Context, params listquery.Params, fn func(*models.ServiceWithCategory) error) error
 	FindByID(ctx context.Context, id int64) (*models.ServiceWithCategory, error)
 	FindByCode(ctx context.Context, code string) (*models.ServiceWithCategory, error)
 	FindByName(ctx context.Context, serviceName string) (*models.ServiceWithCategory, error)
+	FindOutletPrice(ctx context.Context, serviceID int64) (*money.Amount, error)
 
 	// Update Operations
 	UpdateService(ctx context.Context, service *models.Service) error
 
 	// Delete Operations (Soft Delete)
-	DeleteService(ctx context.Context, id int64) error
+	DeleteService(ctx context.Context, id, version int64) error
 }
 
 // serviceRepository is the concrete implementation using sql.DB.
@@ -44,6 +49,11 @@ func NewServiceRepository(db *sql.DB) ServiceRepository {
 
 // InsertService creates a new service record in the database.
 func (r *serviceRepository) InsertService(ctx context.Context, service *models.Service) error {
+	return insertService(ctx, r.db, service, "serviceRepo.InsertService")
+}
+
+// insertService dipakai InsertService maupun import massal (di dalam transaksi).
+func insertService(ctx context.Context, db execer, service *models.Service, op string) error {
 
 	// 1. Persiapkan query SQL
 	query := `
@@ -52,7 +62,7 @@ func (r *serviceRepository) InsertService(ctx context.Context, service *models.S
 	`
 
 	// 2. Eksekusi query dengan context
-	res, err := r.db.ExecContext(ctx, query,
+	res, err := db.ExecContext(ctx, query,
 		service.Code,
 		service.ServiceName,
 		service.Unit,
@@ -66,13 +76,13 @@ func (r *serviceRepository) InsertService(ctx context.Context, service *models.S
 
 	// 3. Bungkus error agar mudah dilacak
 	if err != nil {
-		return fmt.Errorf("serviceRepo.InsertService.Exec: %w", err)
+		return fmt.Errorf("%s.Exec: %w", op, err)
 	}
 
 	// 4. Ambil ID yang baru saja di-generate oleh MySQL (Auto Increment)
 	id, err := res.LastInsertId()
 	if err != nil {
-		return fmt.Errorf("serviceRepo.InsertService.LastInsertId: %w", err)
+		return fmt.Errorf("%s.LastInsertId: %w", op, err)
 	}
 
 	// 5. Sematkan ID kembali ke struct Model
@@ -80,106 +90,159 @@ func (r *serviceRepository) InsertService(ctx context.Context, service *models.S
 	return nil
 }
 
-// FindAll retrieves a list of services with pagination, filtering, and sorting support.
-func (r *serviceRepository) FindAll(ctx context.Context, limit, offset int, search, status, sortBy, sortOrder string) ([]models.ServiceWithCategory, int64, error) {
+// serviceListSpec mendeklarasikan pencarian, filter, dan sorting yang diizinkan untuk GET /services.
+var serviceListSpec = listquery.Spec{
+	Search: []string{"s.service_name", "s.code"},
+	Filters: map[string]listquery.Filter{
+		"status": {Column: "s.is_active", Allowed: []string{"0", "1"}},
+	},
+	Sorts: map[string]string{
+		"service_name": "s.service_name",
+		"code":         "s.code",
+		"price":        "s.price",
+		"created_at":   "s.created_at",
+		"id":           "s.id",
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: listquery.OrderDesc,
+	IDColumn:     "s.id",
+}
 
-	// 1. Inisialisasi query dasar
-	whereClause := "WHERE 1=1"
-	var args []interface{}
+// serviceListSelect adalah SELECT bersama untuk list & export layanan (tanpa WHERE/ORDER BY/LIMIT).
+const serviceListSelect = `
+		SELECT 
+			s.id, s.code, s.service_name, s.unit, s.price, s.is_active, s.created_at, s.updated_at, s.category_id, s.duration_hours,
+			c.category_name, c.description AS category_description
+		FROM services s
+		LEFT JOIN service_categories c ON s.category_id = c.id
+		`
+
+// FindAll retrieves a list of services with pagination (offset or cursor), filtering, and sorting support.
+func (r *serviceRepository) FindAll(ctx context.Context, params listquery.Params) ([]models.ServiceWithCategory, *listquery.Result, error) {
 
-	// 2. Terapkan filter pencarian nama atau kode layanan (LOWER untuk case-insensitive)
-	if search != "" {
-		whereClause += " AND (LOWER(s.service_name) LIKE ? OR LOWER(s.code) LIKE ?)"
-		searchParam := "%" + strings.ToLower(search) + "%"
-		args = append(args, searchParam, searchParam)
+	// 1. Susun WHERE / ORDER BY / LIMIT dari spec (kolom sort sudah di-whitelist)
+	q, err := serviceListSpec.Build(params)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// 3. Terapkan filter status aktif/non-aktif
-	if status != "" {
-		if status == "1" {
-			whereClause += " AND s.is_active = 1"
-		} else if status == "0" {
-			whereClause += " AND s.is_active = 0"
+	// 2. Hitung total baris (opsional) untuk data Meta Pagination
+	var totalItems *int64
+	if q.Count {
+		where, args := q.Where()
+		var total int64
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM services s "+where, args...).Scan(&total); err != nil {
+			return nil, nil, fmt.Errorf("serviceRepo.FindAll.Count: %w", err)
 		}
+		totalItems = &total
 	}
 
-	// 4. Hitung total baris keseluruhan (Count Query) untuk data Meta Pagination
-	var totalItems int64
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM services s %s", whereClause)
-	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalItems); err != nil {
-		return nil, 0, fmt.Errorf("serviceRepo.FindAll.Count: %w", err)
+	// 3. Rangkai query utama dengan JOIN ke service_categories
+	tail, args := q.Tail()
+	query := serviceListSelect + tail
+
+	// 4. Eksekusi query utama
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("serviceRepo.FindAll.Query: %w", err)
 	}
+	defer rows.Close()
 
-	// 5. Validasi kolom sorting (Kunci keamanan mencegah SQL Injection)
-	validSortColumns := map[string]bool{
-		"service_name": true,
-		"code":         true,
-		"price":        true,
-		"created_at":   true,
-		"id":           true,
+	// 5. Parsing (Mapping) hasil query ke dalam slice struct
+	var services []models.ServiceWithCategory
+	for rows.Next() {
+		s, err := scanServiceWithCategory(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("serviceRepo.FindAll.Scan %w", err)
+		}
+		services = append(services, *s)
 	}
-	if !validSortColumns[sortBy] {
-		sortBy = "s.created_at" // Fallback ke default
-	} else {
-		sortBy = "s." + sortBy // Tambahkan alias tabel agar tidak ambigu dengan join
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("serviceRepo.FindAll.Rows: %w", err)
 	}
 
-	// 6. Validasi arah sorting (ASC/DESC)
-	sortOrder = strings.ToUpper(sortOrder)
-	if sortOrder != "ASC" && sortOrder != "DESC" {
-		sortOrder = "DESC" // Fallback ke default DESC (terbaru)
-	}
+	// 6. Buang baris ekstra & siapkan cursor halaman berikutnya
+	keep, result := q.Paginate(len(services), totalItems, func(i int) (interface{}, int64) {
+		return serviceSortValue(services[i], q.SortKey()), services[i].ID
+	})
 
-	// 7. Rangkai query utama dengan JOIN ke service_categories
-	query := fmt.Sprintf(`
-		SELECT 
-			s.id, s.code, s.service_name, s.unit, s.price, s.is_active, s.created_at, s.updated_at, s.category_id, s.duration_hours,
-			c.category_name, c.description AS category_description
-		FROM services s
-		LEFT JOIN service_categories c ON s.category_id = c.id
-		%s 
-		ORDER BY %s %s 
-		LIMIT ? OFFSET ?`, whereClause, sortBy, sortOrder)
+	return services[:keep], result, nil
+}
 
-	args = append(args, limit, offset)
+// StreamAll membaca semua layanan yang cocok dengan filter & sorting list (tanpa pagination) baris per baris
+// dan memanggil fn untuk setiap baris, sehingga export tidak perlu menampung seluruh data di memori.
+func (r *serviceRepository) StreamAll(ctx context.Context, params listquery.Params, fn func(*models.ServiceWithCategory) error) error {
 
-	// 8. Eksekusi query utama
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	// 1. Validasi parameter dengan spec yang sama seperti GET /services
+	q, err := serviceListSpec.Build(params)
 	if err != nil {
-		return nil, 0, fmt.Errorf("serviceRepo.FindAll.Query: %w", err)
+		return err
+	}
+
+	// 2. Eksekusi query tanpa LIMIT
+	tail, args := q.Unpaged()
+	rows, err := r.db.QueryContext(ctx, serviceListSelect+tail, args...)
+	if err != nil {
+		return fmt.Errorf("serviceRepo.StreamAll.Query: %w", err)
 	}
 	defer rows.Close()
 
-	// 9. Parsing (Mapping) hasil query ke dalam slice struct
-	var services []models.ServiceWithCategory
+	// 3. Kirim ke pemanggil satu per satu
 	for rows.Next() {
-		var s models.ServiceWithCategory
-
-		// 10. Siapkan wadah perantara untuk menangkap NULL dari database
-		var categoryDescNull sql.NullString
-		var updatedAtNull sql.NullTime
-
-		err := rows.Scan(
-			&s.ID, &s.Code, &s.ServiceName, &s.Unit, &s.Price, &s.IsActive, &s.CreatedAt, &updatedAtNull, &s.CategoryID, &s.DurationHours,
-			&s.CategoryName, &categoryDescNull,
-		)
+		s, err := scanServiceWithCategory(rows)
 		if err != nil {
-			return nil, 0, fmt.Errorf("serviceRepo.FindAll.Scan %w", err)
+			return fmt.Errorf("serviceRepo.StreamAll.Scan: %w", err)
 		}
-
-		// 11. Pindahkan isi dari wadah perantara ke pointer struct Model
-		if categoryDescNull.Valid {
-			s.CategoryDescription = &categoryDescNull.String
-		}
-		if updatedAtNull.Valid {
-			s.UpdatedAt = &updatedAtNull.Time
+		if err := fn(s); err != nil {
+			return err
 		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("serviceRepo.StreamAll.Rows: %w", err)
+	}
+
+	return nil
+}
+
+// scanServiceWithCategory memetakan satu baris serviceListSelect ke model.
+func scanServiceWithCategory(rows *sql.Rows) (*models.ServiceWithCategory, error) {
+	var s models.ServiceWithCategory
+
+	// Siapkan wadah perantara untuk menangkap NULL dari database
+	var categoryDescNull sql.NullString
+	var updatedAtNull sql.NullTime
 
-		services = append(services, s)
+	if err := rows.Scan(
+		&s.ID, &s.Code, &s.ServiceName, &s.Unit, &s.Price, &s.IsActive, &s.CreatedAt, &updatedAtNull, &s.CategoryID, &s.DurationHours,
+		&s.CategoryName, &categoryDescNull,
+	); err != nil {
+		return nil, err
 	}
 
-	return services, totalItems, nil
+	if categoryDescNull.Valid {
+		s.CategoryDescription = &categoryDescNull.String
+	}
+	if updatedAtNull.Valid {
+		s.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &s, nil
+}
 
+// serviceSortValue mengambil nilai kolom sort dari baris untuk disimpan di cursor.
+func serviceSortValue(s models.ServiceWithCategory, sortKey string) interface{} {
+	switch sortKey {
+	case "service_name":
+		return s.ServiceName
+	case "code":
+		return s.Code
+	case "price":
+		return s.Price
+	case "id":
+		return s.ID
+	default:
+		return s.CreatedAt
+	}
 }
 
 // FindByID retrieves a single service's detailed information by ID.
@@ -188,7 +251,7 @@ func (r *serviceRepository) FindByID(ctx context.Context, id int64) (*models.Ser
 	// 1. Persiapkan query JOIN
 	query := `
 		SELECT 
-			s.id, s.code, s.service_name, s.unit, s.price, s.is_active, s.created_at, s.updated_at, s.category_id, s.duration_hours,
+			s.id, s.code, s.service_name, s.unit, s.price, s.is_active, s.created_at, s.updated_at, s.category_id, s.duration_hours, s.version,
 			c.category_name, c.description AS category_description
 		FROM services s
 		LEFT JOIN service_categories c ON s.category_id = c.id
@@ -201,7 +264,7 @@ func (r *serviceRepository) FindByID(ctx context.Context, id int64) (*models.Ser
 
 	// 2. Eksekusi query dan mapping (Scan)
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&s.ID, &s.Code, &s.ServiceName, &s.Unit, &s.Price, &s.IsActive, &s.CreatedAt, &updatedAtNull, &s.CategoryID, &s.DurationHours,
+		&s.ID, &s.Code, &s.ServiceName, &s.Unit, &s.Price, &s.IsActive, &s.CreatedAt, &updatedAtNull, &s.CategoryID, &s.DurationHours, &s.Version,
 		&s.CategoryName, &categoryDescNull,
 	)
 
@@ -308,18 +371,36 @@ func (r *serviceRepository) FindByName(ctx context.Context, serviceName string)
 	return &s, nil
 }
 
+// FindOutletPrice retrieves the price override of a service for the outlet active in ctx.
+// Returns nil when the outlet uses the base price (no override, or ctx is not scoped to an outlet).
+func (r *serviceRepository) FindOutletPrice(ctx context.Context, serviceID int64) (*money.Amount, error) {
+
+	outletID := outlet.FromContext(ctx)
+	if outletID == outlet.All {
+		return nil, nil
+	}
+
+	var price money.Amount
+	err := r.db.QueryRowContext(ctx, "SELECT price FROM service_outlet_prices WHERE service_id = ? AND outlet_id = ?", serviceID, outletID).Scan(&price)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("serviceRepo.FindOutletPrice: %w", err)
+	}
+
+	return &price, nil
+}
+
 // UpdateService updates an existing service record.
+// service.Version wajib berisi versi yang dibaca sebelumnya; jika baris sudah diubah request lain
+// hasilnya response.ErrPreconditionFailed.
 func (r *serviceRepository) UpdateService(ctx context.Context, service *models.Service) error {
 
-	// 1. Persiapkan query UPDATE
-	query := `
-		UPDATE services 
-		SET code = ?, service_name = ?, unit = ?, price = ?, is_active = ?, category_id = ?, duration_hours = ?, updated_at = ? 
-		WHERE id = ?
-	`
-
-	// 2. Eksekusi query
-	res, err := r.db.ExecContext(ctx, query,
+	// 1. Eksekusi UPDATE berversi (Optimistic Lock)
+	version, err := updateVersioned(ctx, r.db, "services",
+		"code = ?, service_name = ?, unit = ?, price = ?, is_active = ?, category_id = ?, duration_hours = ?, updated_at = ?",
+		service.ID, service.Version,
 		service.Code,
 		service.ServiceName,
 		service.Unit,
@@ -328,53 +409,26 @@ func (r *serviceRepository) UpdateService(ctx context.Context, service *models.S
 		service.CategoryID,
 		service.DurationHours,
 		service.UpdatedAt,
-		service.ID,
 	)
-
-	// 3. Tangani error eksekusi
 	if err != nil {
-		return fmt.Errorf("serviceRepo.UpdateService.Exec: %w", err)
-	}
-
-	// --- [CEK TAMBAHAN: SATPAM LOGIKA] ---
-	rows, err := res.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("serviceRepo.UpdateService.RowsAffected: %w", err)
-	}
-
-	if rows == 0 {
-		return response.ErrNotFound
+		return fmt.Errorf("serviceRepo.UpdateService: %w", err)
 	}
 
+	// 2. Sematkan versi baru ke struct Model
+	service.Version = version
 	return nil
 }
 
 // DeleteService performs a soft delete by setting is_active to false (0).
-func (r *serviceRepository) DeleteService(ctx context.Context, id int64) error {
-
-	// 1. Persiapkan query soft-delete
-	query := `
-		UPDATE services SET is_active = 0 WHERE id = ? AND is_active = 1
-	`
-
-	// 2. EKSEKUSI (Jalankan)
-	res, err := r.db.ExecContext(ctx, query, id)
+// Ikut menaikkan version agar ETag lama tidak bisa dipakai lagi setelah layanan dinonaktifkan.
+func (r *serviceRepository) DeleteService(ctx context.Context, id, version int64) error {
 
-	// 3. EVALUASI (Cek Error Teknis)
+	// 1. Soft delete berversi (optimistic lock sama seperti UpdateService)
+	_, err := updateVersioned(ctx, r.db, "services", "is_active = 0, updated_at = ?", id, version, time.Now())
 	if err != nil {
-		return fmt.Errorf("serviceRepo.DeleteService.Exec: %w", err)
-	}
-
-	// --- [SATPAM TAMBAHAN: RowsAffected] ---
-	rows, err := res.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("serviceRepo.DeleteService.RowsAffected: %w", err)
-	}
-
-	if rows == 0 {
-		return response.ErrNotFound
+		return fmt.Errorf("serviceRepo.DeleteService: %w", err)
 	}
 
-	// 4. KONKLUSI
+	// 2. KONKLUSI
 	return nil
 }
@@ -0,0 +1,377 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// ShiftRepository mendefinisikan operasi database untuk shift kasir, kas kecil, dan rekonsiliasi laci.
+//
+// Penutupan shift WAJIB lewat transaksi: LockOpenShiftTx -> LinkShiftPaymentsTx -> SumShiftCashTx -> CloseShiftTx,
+// agar pembayaran & kas kecil yang masuk bersamaan tidak lolos dari perhitungan.
+type ShiftRepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+
+	// Read Operations
+	FindByID(ctx context.Context, id int64) (*models.CashierShift, error)
+	FindOpenShift(ctx context.Context, cashierID int64) (*models.CashierShift, error)
+	FindShifts(ctx context.Context, start, endExclusive time.Time) ([]models.CashierShift, error)
+	FindPayouts(ctx context.Context, shiftID int64) ([]models.PettyCashPayout, error)
+	SumShiftCash(ctx context.Context, shift *models.CashierShift) (*models.ShiftCashTotals, error)
+
+	// FindOpenShiftIDTx dipakai saat pembayaran dibuat untuk mengisi payments.shift_id (nil = kasir tidak membuka shift).
+	FindOpenShiftIDTx(ctx context.Context, tx *sql.Tx, cashierID int64) (*int64, error)
+
+	// Write Operations
+	InsertShift(ctx context.Context, shift *models.CashierShift) error
+	LockOpenShiftTx(ctx context.Context, tx *sql.Tx, cashierID int64) (*models.CashierShift, error)
+	InsertPayoutTx(ctx context.Context, tx *sql.Tx, payout *models.PettyCashPayout) error
+	LinkShiftPaymentsTx(ctx context.Context, tx *sql.Tx, shift *models.CashierShift, until time.Time) error
+	SumShiftCashTx(ctx context.Context, tx *sql.Tx, shift *models.CashierShift) (*models.ShiftCashTotals, error)
+	CloseShiftTx(ctx context.Context, tx *sql.Tx, shift *models.CashierShift) error
+}
+
+// shiftRepository is the concrete implementation using sql.DB.
+type shiftRepository struct {
+	db *sql.DB
+}
+
+// NewShiftRepository creates a new instance of ShiftRepository.
+func NewShiftRepository(db *sql.DB) ShiftRepository {
+	return &shiftRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+const shiftColumns = `cs.id, cs.outlet_id, cs.cashier_id, u.full_name, cs.status, cs.opening_float, cs.cash_sales,
+	cs.petty_cash_total, cs.expected_cash, cs.closing_counted, cs.variance, cs.payment_count,
+	cs.open_note, cs.close_note, cs.opened_at, cs.closed_at, cs.closed_by`
+
+// BeginTx starts a transaction owned by the calling service.
+func (r *shiftRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("shiftRepo.BeginTx: %w", err)
+	}
+	return tx, nil
+}
+
+// FindByID retrieves a shift of the active outlet.
+func (r *shiftRepository) FindByID(ctx context.Context, id int64) (*models.CashierShift, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "cs.outlet_id")
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM cashier_shifts cs
+		JOIN users u ON u.id = cs.cashier_id
+		WHERE cs.id = ?%s
+	`, shiftColumns, scope)
+
+	shift, err := scanShift(r.db.QueryRowContext(ctx, query, append([]interface{}{id}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("shiftRepo.FindByID: %w", err)
+	}
+
+	return shift, nil
+}
+
+// FindOpenShift retrieves the shift currently opened by a cashier.
+func (r *shiftRepository) FindOpenShift(ctx context.Context, cashierID int64) (*models.CashierShift, error) {
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM cashier_shifts cs
+		JOIN users u ON u.id = cs.cashier_id
+		WHERE cs.open_cashier_id = ?
+	`, shiftColumns)
+
+	shift, err := scanShift(r.db.QueryRowContext(ctx, query, cashierID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("shiftRepo.FindOpenShift: %w", err)
+	}
+
+	return shift, nil
+}
+
+// FindShifts retrieves every shift of the active outlet opened within [start, endExclusive), newest first.
+func (r *shiftRepository) FindShifts(ctx context.Context, start, endExclusive time.Time) ([]models.CashierShift, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "cs.outlet_id")
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM cashier_shifts cs
+		JOIN users u ON u.id = cs.cashier_id
+		WHERE cs.opened_at >= ? AND cs.opened_at < ?%s
+		ORDER BY cs.opened_at DESC, cs.id DESC
+	`, shiftColumns, scope)
+
+	rows, err := r.db.QueryContext(ctx, query, append([]interface{}{start, endExclusive}, scopeArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("shiftRepo.FindShifts.Query: %w", err)
+	}
+	defer rows.Close()
+
+	shifts := []models.CashierShift{}
+	for rows.Next() {
+		shift, err := scanShift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("shiftRepo.FindShifts.Scan: %w", err)
+		}
+		shifts = append(shifts, *shift)
+	}
+
+	return shifts, rows.Err()
+}
+
+// FindPayouts retrieves the petty-cash payouts of a shift in recording order.
+func (r *shiftRepository) FindPayouts(ctx context.Context, shiftID int64) ([]models.PettyCashPayout, error) {
+
+	query := `
+		SELECT id, shift_id, amount, description, created_by, created_at
+		FROM petty_cash_payouts
+		WHERE shift_id = ?
+		ORDER BY id ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, shiftID)
+	if err != nil {
+		return nil, fmt.Errorf("shiftRepo.FindPayouts.Query: %w", err)
+	}
+	defer rows.Close()
+
+	payouts := []models.PettyCashPayout{}
+	for rows.Next() {
+		var p models.PettyCashPayout
+		if err := rows.Scan(&p.ID, &p.ShiftID, &p.Amount, &p.Description, &p.CreatedBy, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("shiftRepo.FindPayouts.Scan: %w", err)
+		}
+		payouts = append(payouts, p)
+	}
+
+	return payouts, rows.Err()
+}
+
+// SumShiftCash computes the running cash totals of a shift (used while the shift is still open).
+func (r *shiftRepository) SumShiftCash(ctx context.Context, shift *models.CashierShift) (*models.ShiftCashTotals, error) {
+	return sumShiftCash(ctx, r.db, shift)
+}
+
+// FindOpenShiftIDTx returns the ID of the shift a cashier currently has open, or nil when there is none.
+// Baris shift dikunci FOR SHARE: penutupan shift (FOR UPDATE) menunggu pembayaran ini selesai, dan sebaliknya.
+func (r *shiftRepository) FindOpenShiftIDTx(ctx context.Context, tx *sql.Tx, cashierID int64) (*int64, error) {
+
+	var id int64
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	query := "SELECT id FROM cashier_shifts WHERE open_cashier_id = ?" + scope + " FOR SHARE"
+	err := tx.QueryRowContext(ctx, query, append([]interface{}{cashierID}, scopeArgs...)...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("shiftRepo.FindOpenShiftIDTx: %w", err)
+	}
+
+	return &id, nil
+}
+
+// InsertShift opens a new shift. The unique open_cashier_id index rejects a second open shift per cashier.
+func (r *shiftRepository) InsertShift(ctx context.Context, shift *models.CashierShift) error {
+
+	query := `
+		INSERT INTO cashier_shifts (outlet_id, cashier_id, status, opening_float, open_note, opened_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	res, err := r.db.ExecContext(ctx, query,
+		shift.OutletID,
+		shift.CashierID,
+		shift.Status,
+		shift.OpeningFloat,
+		shift.OpenNote, // Pointer, aman jika nil
+		shift.OpenedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("shiftRepo.InsertShift.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("shiftRepo.InsertShift.LastInsertId: %w", err)
+	}
+
+	shift.ID = id
+	return nil
+}
+
+// LockOpenShiftTx locks the open shift of a cashier (SELECT ... FOR UPDATE).
+func (r *shiftRepository) LockOpenShiftTx(ctx context.Context, tx *sql.Tx, cashierID int64) (*models.CashierShift, error) {
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM cashier_shifts cs
+		JOIN users u ON u.id = cs.cashier_id
+		WHERE cs.open_cashier_id = ?
+		FOR UPDATE
+	`, shiftColumns)
+
+	shift, err := scanShift(tx.QueryRowContext(ctx, query, cashierID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("shiftRepo.LockOpenShiftTx: %w", err)
+	}
+
+	return shift, nil
+}
+
+// InsertPayoutTx records a petty-cash payout taken from the drawer.
+func (r *shiftRepository) InsertPayoutTx(ctx context.Context, tx *sql.Tx, payout *models.PettyCashPayout) error {
+
+	query := `
+		INSERT INTO petty_cash_payouts (shift_id, amount, description, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	res, err := tx.ExecContext(ctx, query, payout.ShiftID, payout.Amount, payout.Description, payout.CreatedBy, payout.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("shiftRepo.InsertPayoutTx.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("shiftRepo.InsertPayoutTx.LastInsertId: %w", err)
+	}
+
+	payout.ID = id
+	return nil
+}
+
+// LinkShiftPaymentsTx attaches payments created by the cashier during the shift that are not linked yet.
+func (r *shiftRepository) LinkShiftPaymentsTx(ctx context.Context, tx *sql.Tx, shift *models.CashierShift, until time.Time) error {
+
+	query := `
+		UPDATE payments
+		SET shift_id = ?
+		WHERE shift_id IS NULL AND created_by = ? AND outlet_id = ? AND created_at >= ? AND created_at <= ?
+	`
+	if _, err := tx.ExecContext(ctx, query, shift.ID, shift.CashierID, shift.OutletID, shift.OpenedAt, until); err != nil {
+		return fmt.Errorf("shiftRepo.LinkShiftPaymentsTx: %w", err)
+	}
+
+	return nil
+}
+
+// SumShiftCashTx computes the final cash totals of a shift inside the closing transaction.
+func (r *shiftRepository) SumShiftCashTx(ctx context.Context, tx *sql.Tx, shift *models.CashierShift) (*models.ShiftCashTotals, error) {
+	return sumShiftCash(ctx, tx, shift)
+}
+
+// CloseShiftTx stores the reconciliation result and closes the shift.
+func (r *shiftRepository) CloseShiftTx(ctx context.Context, tx *sql.Tx, shift *models.CashierShift) error {
+
+	query := `
+		UPDATE cashier_shifts
+		SET status = ?, cash_sales = ?, petty_cash_total = ?, expected_cash = ?, closing_counted = ?, variance = ?,
+			payment_count = ?, close_note = ?, closed_at = ?, closed_by = ?
+		WHERE id = ? AND status = ?
+	`
+	res, err := tx.ExecContext(ctx, query,
+		models.ShiftStatusClosed,
+		shift.CashSales,
+		shift.PettyCashTotal,
+		money.NullFrom(shift.ExpectedCash),
+		money.NullFrom(shift.ClosingCounted),
+		money.NullFrom(shift.Variance),
+		shift.PaymentCount,
+		shift.CloseNote,
+		shift.ClosedAt,
+		shift.ClosedBy,
+		shift.ID,
+		models.ShiftStatusOpen,
+	)
+	if err != nil {
+		return fmt.Errorf("shiftRepo.CloseShiftTx.Exec: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("shiftRepo.CloseShiftTx.RowsAffected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+// sumShiftCash menghitung uang tunai masuk (pembayaran cash confirmed) & keluar (kas kecil) sebuah shift.
+// Pembayaran yang belum tertaut tetapi dibuat kasir yang sama selama shift ikut dihitung,
+// sehingga total berjalan shift terbuka sama dengan hasil saat ditutup.
+func sumShiftCash(ctx context.Context, db queryExecer, shift *models.CashierShift) (*models.ShiftCashTotals, error) {
+	var totals models.ShiftCashTotals
+
+	// 1. Uang tunai bersih yang masuk laci (diterima - kembalian)
+	paymentQuery := `
+		SELECT COALESCE(SUM(CASE WHEN method = 'cash' AND status = 'confirmed' THEN amount_received - amount_change ELSE 0 END), 0),
+			COUNT(*)
+		FROM payments
+		WHERE shift_id = ?
+			OR (shift_id IS NULL AND created_by = ? AND outlet_id = ? AND created_at >= ?)
+	`
+	if err := db.QueryRowContext(ctx, paymentQuery, shift.ID, shift.CashierID, shift.OutletID, shift.OpenedAt).
+		Scan(&totals.CashSales, &totals.PaymentCount); err != nil {
+		return nil, fmt.Errorf("shiftRepo.sumShiftCash.Payments: %w", err)
+	}
+
+	// 2. Kas kecil yang dikeluarkan dari laci
+	payoutQuery := `SELECT COALESCE(SUM(amount), 0) FROM petty_cash_payouts WHERE shift_id = ?`
+	if err := db.QueryRowContext(ctx, payoutQuery, shift.ID).Scan(&totals.PettyCashTotal); err != nil {
+		return nil, fmt.Errorf("shiftRepo.sumShiftCash.Payouts: %w", err)
+	}
+
+	return &totals, nil
+}
+
+func scanShift(row rowScanner) (*models.CashierShift, error) {
+	var s models.CashierShift
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var expectedNull, countedNull, varianceNull money.NullAmount
+	var openNoteNull, closeNoteNull sql.NullString
+	var closedAtNull sql.NullTime
+	var closedByNull sql.NullInt64
+
+	if err := row.Scan(
+		&s.ID, &s.OutletID, &s.CashierID, &s.CashierName, &s.Status, &s.OpeningFloat, &s.CashSales,
+		&s.PettyCashTotal, &expectedNull, &countedNull, &varianceNull, &s.PaymentCount,
+		&openNoteNull, &closeNoteNull, &s.OpenedAt, &closedAtNull, &closedByNull,
+	); err != nil {
+		return nil, err
+	}
+
+	s.ExpectedCash = expectedNull.Ptr()
+	s.ClosingCounted = countedNull.Ptr()
+	s.Variance = varianceNull.Ptr()
+	s.OpenNote = nullStringPtr(openNoteNull)
+	s.CloseNote = nullStringPtr(closeNoteNull)
+	if closedAtNull.Valid {
+		s.ClosedAt = &closedAtNull.Time
+	}
+	if closedByNull.Valid {
+		s.ClosedBy = &closedByNull.Int64
+	}
+
+	return &s, nil
+}
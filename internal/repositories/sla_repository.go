@@ -0,0 +1,421 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// SLARepository defines the contract for overdue/unclaimed order lookups and SLA alert records.
+type SLARepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+
+	// Monitoring (dibatasi outlet aktif)
+	FindOverdue(ctx context.Context, overdueBefore time.Time, status string, limit, offset int) ([]models.OverdueOrder, int64, error)
+	FindUnclaimed(ctx context.Context, readyBefore time.Time, limit, offset int) ([]models.UnclaimedOrder, int64, error)
+
+	// Scheduler (semua outlet): pesanan yang belum punya peringatan terbuka
+	FindNewOverdue(ctx context.Context, overdueBefore time.Time, limit int) ([]models.OverdueOrder, error)
+	FindNewUnclaimed(ctx context.Context, readyBefore time.Time, thresholdDays, limit int) ([]models.UnclaimedOrder, error)
+
+	// UpsertAlertTx mencatat peringatan (membuka ulang baris yang sudah selesai).
+	// Mengembalikan false jika peringatan yang sama masih terbuka.
+	UpsertAlertTx(ctx context.Context, tx *sql.Tx, alert *models.SLAAlert) (bool, error)
+
+	// ResolveAlerts menutup peringatan terbuka yang pesanannya tidak lagi memenuhi aturan.
+	ResolveAlerts(ctx context.Context, overdueBefore, now time.Time) (int64, error)
+
+	// Kotak masuk peringatan di aplikasi (dibatasi outlet aktif)
+	FindOpenAlerts(ctx context.Context, alertType string, acknowledged *bool, limit, offset int) ([]models.SLAAlertWithOrder, int64, error)
+	FindAlertByID(ctx context.Context, id int64) (*models.SLAAlertWithOrder, error)
+	AcknowledgeAlert(ctx context.Context, id, userID int64, now time.Time) error
+}
+
+// slaRepository is the concrete implementation using sql.DB.
+type slaRepository struct {
+	db *sql.DB
+}
+
+// NewSLARepository creates a new instance of SLARepository.
+func NewSLARepository(db *sql.DB) SLARepository {
+	return &slaRepository{db: db}
+}
+
+// BeginTx starts a transaction owned by the calling service.
+func (r *slaRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("slaRepo.BeginTx: %w", err)
+	}
+	return tx, nil
+}
+
+// slaReadySince adalah saat terakhir pesanan berpindah ke ready-pickup.
+// Data lama tanpa riwayat status memakai updated_at / created_at.
+const slaReadySince = `COALESCE(
+	(SELECT MAX(sh.created_at) FROM status_history sh WHERE sh.order_id = o.id AND sh.new_status = 'ready-pickup'),
+	o.updated_at, o.created_at)`
+
+// --- IMPLEMENTATION: OVERDUE ---
+
+const overdueSelect = `
+	SELECT o.id, o.outlet_id, o.invoice_number, COALESCE(NULLIF(o.customer_name, ''), c.full_name),
+		COALESCE(NULLIF(o.customer_phone, ''), c.phone_number), o.is_delivery, o.payment_status,
+		o.status_internal, o.estimated_ready_at, a.triggered_at
+	FROM orders o
+	LEFT JOIN customers c ON c.id = o.customer_id
+	LEFT JOIN order_sla_alerts a ON a.order_id = o.id AND a.alert_type = 'overdue' AND a.threshold_days = 0 AND a.resolved_at IS NULL
+	WHERE o.status_internal IN ('pending', 'in-progress') AND o.estimated_ready_at <= ?`
+
+// FindOverdue retrieves active orders of the active outlet past their ready time, most late first.
+func (r *slaRepository) FindOverdue(ctx context.Context, overdueBefore time.Time, status string, limit, offset int) ([]models.OverdueOrder, int64, error) {
+
+	// 1. Susun filter dinamis
+	where := ""
+	args := []interface{}{overdueBefore}
+	if status != "" {
+		where += " AND o.status_internal = ?"
+		args = append(args, status)
+	}
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	where += scope
+	args = append(args, scopeArgs...)
+
+	// 2. Hitung total baris untuk Meta Pagination
+	var totalItems int64
+	countQuery := `SELECT COUNT(*) FROM orders o WHERE o.status_internal IN ('pending', 'in-progress') AND o.estimated_ready_at <= ?` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalItems); err != nil {
+		return nil, 0, fmt.Errorf("slaRepo.FindOverdue.Count: %w", err)
+	}
+
+	// 3. Ambil data (paling terlambat di atas)
+	query := overdueSelect + where + ` ORDER BY o.estimated_ready_at ASC, o.id ASC LIMIT ? OFFSET ?`
+	orders, err := r.queryOverdue(ctx, "FindOverdue", query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orders, totalItems, nil
+}
+
+// FindNewOverdue retrieves overdue orders of every outlet that have no open overdue alert yet.
+func (r *slaRepository) FindNewOverdue(ctx context.Context, overdueBefore time.Time, limit int) ([]models.OverdueOrder, error) {
+	query := overdueSelect + ` AND a.id IS NULL ORDER BY o.estimated_ready_at ASC, o.id ASC LIMIT ?`
+	return r.queryOverdue(ctx, "FindNewOverdue", query, overdueBefore, limit)
+}
+
+func (r *slaRepository) queryOverdue(ctx context.Context, method, query string, args ...interface{}) ([]models.OverdueOrder, error) {
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("slaRepo.%s.Query: %w", method, err)
+	}
+	defer rows.Close()
+
+	orders := []models.OverdueOrder{}
+	for rows.Next() {
+		var o models.OverdueOrder
+
+		// Wadah perantara untuk menangkap NULL dari database
+		var nameNull, phoneNull, paymentNull sql.NullString
+		var deliveryNull sql.NullBool
+		var flaggedNull sql.NullTime
+
+		if err := rows.Scan(
+			&o.OrderID, &o.OutletID, &o.InvoiceNumber, &nameNull, &phoneNull, &deliveryNull, &paymentNull,
+			&o.StatusInternal, &o.EstimatedReadyAt, &flaggedNull,
+		); err != nil {
+			return nil, fmt.Errorf("slaRepo.%s.Scan: %w", method, err)
+		}
+
+		o.CustomerName = nullStringPtr(nameNull)
+		o.CustomerPhone = nullStringPtr(phoneNull)
+		o.IsDelivery = deliveryNull.Bool
+		o.PaymentStatus = paymentNull.String
+		if flaggedNull.Valid {
+			o.FlaggedAt = &flaggedNull.Time
+		}
+
+		orders = append(orders, o)
+	}
+
+	return orders, rows.Err()
+}
+
+// --- IMPLEMENTATION: UNCLAIMED ---
+
+// unclaimedFrom membungkus pesanan ready-pickup agar ready_since bisa dipakai di WHERE & ORDER BY.
+// %s diisi filter outlet.
+const unclaimedFrom = `
+	FROM (
+		SELECT o.id, o.outlet_id, o.invoice_number, o.customer_id, COALESCE(NULLIF(o.customer_name, ''), c.full_name) AS customer_name,
+			COALESCE(NULLIF(o.customer_phone, ''), c.phone_number) AS customer_phone, o.payment_status,
+			` + slaReadySince + ` AS ready_since
+		FROM orders o
+		LEFT JOIN customers c ON c.id = o.customer_id
+		WHERE o.status_internal = 'ready-pickup'%s
+	) u
+	LEFT JOIN order_sla_alerts a ON a.id = (
+		SELECT a2.id FROM order_sla_alerts a2
+		WHERE a2.order_id = u.id AND a2.alert_type = 'unclaimed' AND a2.resolved_at IS NULL
+		ORDER BY a2.threshold_days DESC LIMIT 1
+	)
+	WHERE u.ready_since <= ?`
+
+const unclaimedColumns = `
+	SELECT u.id, u.outlet_id, u.invoice_number, u.customer_id, u.customer_name, u.customer_phone, u.payment_status,
+		u.ready_since, a.threshold_days, a.triggered_at `
+
+// FindUnclaimed retrieves ready-pickup orders of the active outlet waiting since readyBefore or earlier, oldest first.
+func (r *slaRepository) FindUnclaimed(ctx context.Context, readyBefore time.Time, limit, offset int) ([]models.UnclaimedOrder, int64, error) {
+
+	// 1. Filter outlet aktif di dalam subquery
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	from := fmt.Sprintf(unclaimedFrom, scope)
+	args := append(scopeArgs, readyBefore)
+
+	// 2. Hitung total baris untuk Meta Pagination
+	var totalItems int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) "+from, args...).Scan(&totalItems); err != nil {
+		return nil, 0, fmt.Errorf("slaRepo.FindUnclaimed.Count: %w", err)
+	}
+
+	// 3. Ambil data (paling lama menunggu di atas)
+	query := unclaimedColumns + from + ` ORDER BY u.ready_since ASC, u.id ASC LIMIT ? OFFSET ?`
+	orders, err := r.queryUnclaimed(ctx, "FindUnclaimed", query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orders, totalItems, nil
+}
+
+// FindNewUnclaimed retrieves ready-pickup orders of every outlet that crossed thresholdDays
+// and have no open unclaimed alert at this threshold or higher.
+func (r *slaRepository) FindNewUnclaimed(ctx context.Context, readyBefore time.Time, thresholdDays, limit int) ([]models.UnclaimedOrder, error) {
+	query := unclaimedColumns + fmt.Sprintf(unclaimedFrom, "") +
+		` AND (a.id IS NULL OR a.threshold_days < ?) ORDER BY u.ready_since ASC, u.id ASC LIMIT ?`
+	return r.queryUnclaimed(ctx, "FindNewUnclaimed", query, readyBefore, thresholdDays, limit)
+}
+
+func (r *slaRepository) queryUnclaimed(ctx context.Context, method, query string, args ...interface{}) ([]models.UnclaimedOrder, error) {
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("slaRepo.%s.Query: %w", method, err)
+	}
+	defer rows.Close()
+
+	orders := []models.UnclaimedOrder{}
+	for rows.Next() {
+		var o models.UnclaimedOrder
+
+		// Wadah perantara untuk menangkap NULL dari database
+		var nameNull, phoneNull, paymentNull sql.NullString
+		var customerNull, daysNull sql.NullInt64
+		var flaggedNull sql.NullTime
+
+		if err := rows.Scan(
+			&o.OrderID, &o.OutletID, &o.InvoiceNumber, &customerNull, &nameNull, &phoneNull, &paymentNull,
+			&o.ReadySince, &daysNull, &flaggedNull,
+		); err != nil {
+			return nil, fmt.Errorf("slaRepo.%s.Scan: %w", method, err)
+		}
+
+		o.CustomerID = nullInt64Ptr(customerNull)
+		o.CustomerName = nullStringPtr(nameNull)
+		o.CustomerPhone = nullStringPtr(phoneNull)
+		o.PaymentStatus = paymentNull.String
+		if daysNull.Valid {
+			days := int(daysNull.Int64)
+			o.FlaggedDays = &days
+		}
+		if flaggedNull.Valid {
+			o.FlaggedAt = &flaggedNull.Time
+		}
+
+		orders = append(orders, o)
+	}
+
+	return orders, rows.Err()
+}
+
+// --- IMPLEMENTATION: ALERTS ---
+
+// UpsertAlertTx inserts the alert, or re-opens a resolved one with fresh data.
+// An alert that is still open is left untouched (0 rows affected).
+func (r *slaRepository) UpsertAlertTx(ctx context.Context, tx *sql.Tx, alert *models.SLAAlert) (bool, error) {
+
+	// Urutan SET penting: resolved_at dikosongkan paling akhir setelah kolom lain membaca nilai lamanya
+	query := `
+		INSERT INTO order_sla_alerts (outlet_id, order_id, alert_type, threshold_days, status_internal, reference_at, triggered_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			status_internal = IF(resolved_at IS NULL, status_internal, VALUES(status_internal)),
+			reference_at = IF(resolved_at IS NULL, reference_at, VALUES(reference_at)),
+			triggered_at = IF(resolved_at IS NULL, triggered_at, VALUES(triggered_at)),
+			acknowledged_by = IF(resolved_at IS NULL, acknowledged_by, NULL),
+			acknowledged_at = IF(resolved_at IS NULL, acknowledged_at, NULL),
+			resolved_at = NULL
+	`
+	res, err := tx.ExecContext(ctx, query,
+		alert.OutletID, alert.OrderID, alert.AlertType, alert.ThresholdDays,
+		alert.StatusInternal, alert.ReferenceAt, alert.TriggeredAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("slaRepo.UpsertAlertTx: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("slaRepo.UpsertAlertTx.RowsAffected: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ResolveAlerts closes open alerts whose order is no longer overdue / no longer waiting for pickup.
+// Unclaimed alerts are also closed when the order became ready-pickup again after the alert (cth: dicuci ulang).
+func (r *slaRepository) ResolveAlerts(ctx context.Context, overdueBefore, now time.Time) (int64, error) {
+
+	query := `
+		UPDATE order_sla_alerts a
+		JOIN orders o ON o.id = a.order_id
+		SET a.resolved_at = ?
+		WHERE a.resolved_at IS NULL AND (
+			(a.alert_type = 'overdue' AND (
+				o.status_internal NOT IN ('pending', 'in-progress')
+				OR o.estimated_ready_at IS NULL OR o.estimated_ready_at > ?))
+			OR (a.alert_type = 'unclaimed' AND (
+				o.status_internal <> 'ready-pickup' OR ` + slaReadySince + ` > a.reference_at))
+		)
+	`
+	res, err := r.db.ExecContext(ctx, query, now, overdueBefore)
+	if err != nil {
+		return 0, fmt.Errorf("slaRepo.ResolveAlerts: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("slaRepo.ResolveAlerts.RowsAffected: %w", err)
+	}
+	return affected, nil
+}
+
+// --- IMPLEMENTATION: IN-APP INBOX ---
+
+const slaAlertSelect = `
+	SELECT a.id, a.outlet_id, a.order_id, o.invoice_number, a.alert_type, a.threshold_days, a.status_internal,
+		a.reference_at, a.triggered_at, a.resolved_at, a.acknowledged_by, u.full_name, a.acknowledged_at
+	FROM order_sla_alerts a
+	JOIN orders o ON o.id = a.order_id
+	LEFT JOIN users u ON u.id = a.acknowledged_by`
+
+// FindOpenAlerts retrieves open SLA alerts of the active outlet, newest first.
+// acknowledged nil = semua, true = sudah ditindaklanjuti, false = belum dibaca.
+func (r *slaRepository) FindOpenAlerts(ctx context.Context, alertType string, acknowledged *bool, limit, offset int) ([]models.SLAAlertWithOrder, int64, error) {
+
+	// 1. Susun filter dinamis
+	where := " WHERE a.resolved_at IS NULL"
+	args := []interface{}{}
+	if alertType != "" {
+		where += " AND a.alert_type = ?"
+		args = append(args, alertType)
+	}
+	if acknowledged != nil {
+		if *acknowledged {
+			where += " AND a.acknowledged_at IS NOT NULL"
+		} else {
+			where += " AND a.acknowledged_at IS NULL"
+		}
+	}
+	scope, scopeArgs := outletFilter(ctx, "a.outlet_id")
+	where += scope
+	args = append(args, scopeArgs...)
+
+	// 2. Hitung total baris untuk Meta Pagination (dipakai juga sebagai badge jumlah belum dibaca)
+	var totalItems int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM order_sla_alerts a"+where, args...).Scan(&totalItems); err != nil {
+		return nil, 0, fmt.Errorf("slaRepo.FindOpenAlerts.Count: %w", err)
+	}
+
+	// 3. Ambil data
+	query := slaAlertSelect + where + " ORDER BY a.triggered_at DESC, a.id DESC LIMIT ? OFFSET ?"
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("slaRepo.FindOpenAlerts.Query: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []models.SLAAlertWithOrder{}
+	for rows.Next() {
+		alert, err := scanSLAAlert(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("slaRepo.FindOpenAlerts.Scan: %w", err)
+		}
+		alerts = append(alerts, *alert)
+	}
+
+	return alerts, totalItems, rows.Err()
+}
+
+// FindAlertByID retrieves one SLA alert of the active outlet.
+func (r *slaRepository) FindAlertByID(ctx context.Context, id int64) (*models.SLAAlertWithOrder, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "a.outlet_id")
+	query := slaAlertSelect + " WHERE a.id = ?" + scope
+
+	alert, err := scanSLAAlert(r.db.QueryRowContext(ctx, query, append([]interface{}{id}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("slaRepo.FindAlertByID: %w", err)
+	}
+
+	return alert, nil
+}
+
+// AcknowledgeAlert marks an alert of the active outlet as followed up. The first acknowledgement is kept.
+func (r *slaRepository) AcknowledgeAlert(ctx context.Context, id, userID int64, now time.Time) error {
+
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	query := "UPDATE order_sla_alerts SET acknowledged_by = ?, acknowledged_at = ? WHERE id = ? AND acknowledged_at IS NULL" + scope
+
+	if _, err := r.db.ExecContext(ctx, query, append([]interface{}{userID, now, id}, scopeArgs...)...); err != nil {
+		return fmt.Errorf("slaRepo.AcknowledgeAlert: %w", err)
+	}
+
+	return nil
+}
+
+func scanSLAAlert(row rowScanner) (*models.SLAAlertWithOrder, error) {
+
+	var a models.SLAAlertWithOrder
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var resolvedNull, acknowledgedAtNull sql.NullTime
+	var acknowledgedByNull sql.NullInt64
+	var acknowledgerNull sql.NullString
+
+	if err := row.Scan(
+		&a.ID, &a.OutletID, &a.OrderID, &a.InvoiceNumber, &a.AlertType, &a.ThresholdDays, &a.StatusInternal,
+		&a.ReferenceAt, &a.TriggeredAt, &resolvedNull, &acknowledgedByNull, &acknowledgerNull, &acknowledgedAtNull,
+	); err != nil {
+		return nil, err
+	}
+
+	if resolvedNull.Valid {
+		a.ResolvedAt = &resolvedNull.Time
+	}
+	a.AcknowledgedBy = nullInt64Ptr(acknowledgedByNull)
+	a.AcknowledgedByName = nullStringPtr(acknowledgerNull)
+	if acknowledgedAtNull.Valid {
+		a.AcknowledgedAt = &acknowledgedAtNull.Time
+	}
+
+	return &a, nil
+}
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+)
+
+// TagRepository mendefinisikan operasi database untuk label fisik (tag) item pesanan.
+type TagRepository interface {
+
+	// Read Operations
+	FindItemTags(ctx context.Context, orderID int64) ([]models.OrderItemTagDetail, error)
+	FindByCode(ctx context.Context, tagCode string) (*models.OrderItemTag, error)
+
+	// Write Operations
+	InsertTag(ctx context.Context, tag *models.OrderItemTag) (bool, error)
+	RecordScanTx(ctx context.Context, tx *sql.Tx, tag *models.OrderItemTag) error
+}
+
+// tagRepository is the concrete implementation using sql.DB.
+type tagRepository struct {
+	db *sql.DB
+}
+
+// NewTagRepository creates a new instance of TagRepository.
+func NewTagRepository(db *sql.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// FindItemTags retrieves every item of an order with its tag (nil when the item has not been tagged yet).
+func (r *tagRepository) FindItemTags(ctx context.Context, orderID int64) ([]models.OrderItemTagDetail, error) {
+
+	query := `
+		SELECT oi.id, COALESCE(s.service_name, '-'), COALESCE(s.unit, 'pcs'), oi.quantity, oi.weight_kg, oi.qty_pieces,
+			t.id, t.tag_code, t.counted_pieces, t.counted_by, t.counted_at, t.last_scanned_at, t.created_at
+		FROM order_items oi
+		LEFT JOIN services s ON s.id = oi.service_id
+		LEFT JOIN order_item_tags t ON t.order_item_id = oi.id
+		WHERE oi.order_id = ?
+		ORDER BY oi.id ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("tagRepo.FindItemTags.Query: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.OrderItemTagDetail{}
+	for rows.Next() {
+		var item models.OrderItemTagDetail
+
+		// Wadah perantara untuk menangkap NULL dari database
+		var quantityNull, piecesNull, tagIDNull, countedNull, countedByNull sql.NullInt64
+		var weightNull sql.NullFloat64
+		var tagCodeNull sql.NullString
+		var countedAtNull, scannedAtNull, createdAtNull sql.NullTime
+
+		if err := rows.Scan(
+			&item.OrderItemID, &item.ServiceName, &item.Unit, &quantityNull, &weightNull, &piecesNull,
+			&tagIDNull, &tagCodeNull, &countedNull, &countedByNull, &countedAtNull, &scannedAtNull, &createdAtNull,
+		); err != nil {
+			return nil, fmt.Errorf("tagRepo.FindItemTags.Scan: %w", err)
+		}
+
+		item.Quantity = float64(quantityNull.Int64)
+		if item.Unit == "kg" && weightNull.Valid {
+			item.Quantity = weightNull.Float64
+		}
+		if piecesNull.Valid {
+			pieces := int(piecesNull.Int64)
+			item.DeclaredPieces = &pieces
+		}
+
+		if tagIDNull.Valid {
+			item.Tag = &models.OrderItemTag{
+				ID:          tagIDNull.Int64,
+				OrderID:     orderID,
+				OrderItemID: item.OrderItemID,
+				TagCode:     tagCodeNull.String,
+				CreatedAt:   createdAtNull.Time,
+			}
+			fillTagNullables(item.Tag, countedNull, countedByNull, countedAtNull, scannedAtNull)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// FindByCode retrieves a tag by its printed code.
+func (r *tagRepository) FindByCode(ctx context.Context, tagCode string) (*models.OrderItemTag, error) {
+
+	query := `
+		SELECT id, order_id, order_item_id, tag_code, counted_pieces, counted_by, counted_at, last_scanned_at, created_at
+		FROM order_item_tags
+		WHERE tag_code = ?
+	`
+	var tag models.OrderItemTag
+	var countedNull, countedByNull sql.NullInt64
+	var countedAtNull, scannedAtNull, createdAtNull sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, tagCode).Scan(
+		&tag.ID, &tag.OrderID, &tag.OrderItemID, &tag.TagCode,
+		&countedNull, &countedByNull, &countedAtNull, &scannedAtNull, &createdAtNull,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("tagRepo.FindByCode: %w", err)
+	}
+
+	tag.CreatedAt = createdAtNull.Time
+	fillTagNullables(&tag, countedNull, countedByNull, countedAtNull, scannedAtNull)
+
+	return &tag, nil
+}
+
+// InsertTag creates a tag for an order item. It returns false without error when the item
+// already has a tag or the code is taken (unique index), so concurrent generation stays idempotent.
+func (r *tagRepository) InsertTag(ctx context.Context, tag *models.OrderItemTag) (bool, error) {
+
+	res, err := r.db.ExecContext(ctx, `
+		INSERT IGNORE INTO order_item_tags (order_id, order_item_id, tag_code, created_at)
+		VALUES (?, ?, ?, ?)`,
+		tag.OrderID,
+		tag.OrderItemID,
+		tag.TagCode,
+		tag.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("tagRepo.InsertTag: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("tagRepo.InsertTag.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	if tag.ID, err = res.LastInsertId(); err != nil {
+		return false, fmt.Errorf("tagRepo.InsertTag.LastInsertId: %w", err)
+	}
+
+	return true, nil
+}
+
+// RecordScanTx saves the scan time and, when provided, the counted pieces of a tag.
+func (r *tagRepository) RecordScanTx(ctx context.Context, tx *sql.Tx, tag *models.OrderItemTag) error {
+
+	_, err := tx.ExecContext(ctx, `
+		UPDATE order_item_tags
+		SET counted_pieces = ?, counted_by = ?, counted_at = ?, last_scanned_at = ?
+		WHERE id = ?`,
+		tag.CountedPieces,
+		tag.CountedBy,
+		tag.CountedAt,
+		tag.LastScannedAt,
+		tag.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("tagRepo.RecordScanTx: %w", err)
+	}
+
+	return nil
+}
+
+func fillTagNullables(tag *models.OrderItemTag, counted, countedBy sql.NullInt64, countedAt, scannedAt sql.NullTime) {
+	if counted.Valid {
+		pieces := int(counted.Int64)
+		tag.CountedPieces = &pieces
+	}
+	if countedBy.Valid {
+		tag.CountedBy = &countedBy.Int64
+	}
+	if countedAt.Valid {
+		tag.CountedAt = &countedAt.Time
+	}
+	if scannedAt.Valid {
+		tag.LastScannedAt = &scannedAt.Time
+	}
+}
@@ -0,0 +1,525 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// TaxRepository mendefinisikan semua operasi database untuk tarif pajak & service charge.
+type TaxRepository interface {
+
+	// Create Operations
+	InsertRate(ctx context.Context, rate *models.TaxRate, targets []models.TaxRateTarget) error
+
+	// Read Operations
+	FindAll(ctx context.Context, status string) ([]models.TaxRateWithTargets, error)
+	FindByID(ctx context.Context, id int64) (*models.TaxRateWithTargets, error)
+	FindByCode(ctx context.Context, code string) (*models.TaxRateWithTargets, error)
+	FindActive(ctx context.Context) ([]models.TaxRateWithTargets, error)
+
+	// Update Operations
+	UpdateRate(ctx context.Context, rate *models.TaxRate, targets []models.TaxRateTarget) error
+
+	// Delete Operations (Soft Delete)
+	DeleteRate(ctx context.Context, id int64) error
+
+	// SaveOrderTotalsTx menyimpan rincian total ke nota induk dan baris order_taxes
+	// di dalam transaksi pembuatan/perubahan pesanan (baris pajak lama diganti).
+	SaveOrderTotalsTx(ctx context.Context, tx *sql.Tx, orderID int64, totals models.OrderTotals, taxes []models.OrderTax) error
+
+	// Report Operations (hanya pesanan lunas dalam rentang [start, end))
+	SumOrderTotals(ctx context.Context, start, end time.Time) (*models.OrderTotals, int64, error)
+	SumOrderTotalsByOutlet(ctx context.Context, start, end time.Time) ([]models.OutletOrderTotals, error)
+	SumCollectedTaxes(ctx context.Context, start, end time.Time) ([]models.TaxCollection, error)
+	StreamOrderTotals(ctx context.Context, start, end time.Time, fn func(*models.TaxReportOrder) error) error
+}
+
+// taxRepository is the concrete implementation using sql.DB.
+type taxRepository struct {
+	db *sql.DB
+}
+
+// NewTaxRepository creates a new instance of TaxRepository.
+func NewTaxRepository(db *sql.DB) TaxRepository {
+	return &taxRepository{db: db}
+}
+
+const taxRateColumns = `id, code, tax_name, tax_kind, rate, price_mode, scope_type, is_active, created_at, updated_at`
+
+// --- IMPLEMENTATION ---
+
+// InsertRate creates a new tax rate together with its targets in one transaction.
+func (r *taxRepository) InsertRate(ctx context.Context, rate *models.TaxRate, targets []models.TaxRateTarget) error {
+
+	// 1. Mulai transaksi (tarif & cakupannya harus tersimpan bersamaan)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("taxRepo.InsertRate.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Simpan data tarif
+	query := `
+		INSERT INTO tax_rates (code, tax_name, tax_kind, rate, price_mode, scope_type, is_active, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := tx.ExecContext(ctx, query,
+		rate.Code,
+		rate.TaxName,
+		rate.TaxKind,
+		rate.Rate,
+		rate.PriceMode,
+		rate.ScopeType,
+		rate.IsActive,
+		rate.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("taxRepo.InsertRate.Exec: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("taxRepo.InsertRate.LastInsertId: %w", err)
+	}
+
+	// 3. Simpan cakupan & pengecualian
+	if err := replaceTaxRateTargets(ctx, tx, id, targets); err != nil {
+		return fmt.Errorf("taxRepo.InsertRate: %w", err)
+	}
+
+	// 4. Commit transaksi
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("taxRepo.InsertRate.Commit: %w", err)
+	}
+
+	rate.ID = id
+	return nil
+}
+
+// FindAll retrieves every tax rate; the list is small so it is not paginated.
+func (r *taxRepository) FindAll(ctx context.Context, status string) ([]models.TaxRateWithTargets, error) {
+
+	// 1. Terapkan filter status aktif/non-aktif
+	whereClause := "WHERE 1=1"
+	if status == "1" {
+		whereClause += " AND is_active = 1"
+	} else if status == "0" {
+		whereClause += " AND is_active = 0"
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM tax_rates %s ORDER BY id ASC", taxRateColumns, whereClause)
+	return r.findMany(ctx, "taxRepo.FindAll", query)
+}
+
+// FindByID retrieves a single tax rate with its targets.
+func (r *taxRepository) FindByID(ctx context.Context, id int64) (*models.TaxRateWithTargets, error) {
+
+	query := fmt.Sprintf("SELECT %s FROM tax_rates WHERE id = ?", taxRateColumns)
+	rate, err := scanTaxRate(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("taxRepo.FindByID: %w", err)
+	}
+
+	return r.withTargets(ctx, rate)
+}
+
+// FindByCode retrieves a single tax rate by its exact code (Useful for duplicate validation).
+func (r *taxRepository) FindByCode(ctx context.Context, code string) (*models.TaxRateWithTargets, error) {
+
+	query := fmt.Sprintf("SELECT %s FROM tax_rates WHERE code = ?", taxRateColumns)
+	rate, err := scanTaxRate(r.db.QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("taxRepo.FindByCode: %w", err)
+	}
+
+	return r.withTargets(ctx, rate)
+}
+
+// FindActive retrieves every active tax rate ordered by ID, which is also the order lines appear on the receipt.
+func (r *taxRepository) FindActive(ctx context.Context) ([]models.TaxRateWithTargets, error) {
+
+	query := fmt.Sprintf("SELECT %s FROM tax_rates WHERE is_active = 1 ORDER BY id ASC", taxRateColumns)
+	return r.findMany(ctx, "taxRepo.FindActive", query)
+}
+
+// UpdateRate updates a tax rate; a non-nil targets replaces all of its targets.
+func (r *taxRepository) UpdateRate(ctx context.Context, rate *models.TaxRate, targets []models.TaxRateTarget) error {
+
+	// 1. Mulai transaksi
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("taxRepo.UpdateRate.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Update data tarif
+	query := `
+		UPDATE tax_rates
+		SET code = ?, tax_name = ?, tax_kind = ?, rate = ?, price_mode = ?, scope_type = ?, is_active = ?, updated_at = ?
+		WHERE id = ?
+	`
+	res, err := tx.ExecContext(ctx, query,
+		rate.Code,
+		rate.TaxName,
+		rate.TaxKind,
+		rate.Rate,
+		rate.PriceMode,
+		rate.ScopeType,
+		rate.IsActive,
+		rate.UpdatedAt,
+		rate.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("taxRepo.UpdateRate.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("taxRepo.UpdateRate.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	// 3. Ganti cakupan jika dikirim
+	if targets != nil {
+		if err := replaceTaxRateTargets(ctx, tx, rate.ID, targets); err != nil {
+			return fmt.Errorf("taxRepo.UpdateRate: %w", err)
+		}
+	}
+
+	// 4. Commit transaksi
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("taxRepo.UpdateRate.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRate performs a soft delete by setting is_active to false (0).
+func (r *taxRepository) DeleteRate(ctx context.Context, id int64) error {
+
+	res, err := r.db.ExecContext(ctx, "UPDATE tax_rates SET is_active = 0 WHERE id = ? AND is_active = 1", id)
+	if err != nil {
+		return fmt.Errorf("taxRepo.DeleteRate.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("taxRepo.DeleteRate.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// SaveOrderTotalsTx writes the order totals and replaces its order_taxes lines inside the caller's transaction.
+func (r *taxRepository) SaveOrderTotalsTx(ctx context.Context, tx *sql.Tx, orderID int64, totals models.OrderTotals, taxes []models.OrderTax) error {
+
+	// 1. Update rincian total di nota induk (hanya pesanan milik outlet aktif ctx)
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	query := `
+		UPDATE orders
+		SET subtotal = ?, discount_total = ?, service_charge_total = ?, tax_total = ?, grand_total = ?
+		WHERE id = ?` + scope
+	args := []interface{}{
+		totals.Subtotal,
+		totals.DiscountTotal,
+		totals.ServiceChargeTotal,
+		totals.TaxTotal,
+		totals.GrandTotal,
+		orderID,
+	}
+	res, err := tx.ExecContext(ctx, query, append(args, scopeArgs...)...)
+	if err != nil {
+		return fmt.Errorf("taxRepo.SaveOrderTotalsTx.UpdateOrder: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("taxRepo.SaveOrderTotalsTx.RowsAffected: %w", err)
+	} else if rows == 0 {
+		// MySQL mengembalikan 0 jika nilainya sama, pastikan pesanan memang ada
+		var exists int
+		if err := tx.QueryRowContext(ctx, "SELECT 1 FROM orders WHERE id = ?"+scope, append([]interface{}{orderID}, scopeArgs...)...).Scan(&exists); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return response.ErrNotFound
+			}
+			return fmt.Errorf("taxRepo.SaveOrderTotalsTx.Exists: %w", err)
+		}
+	}
+
+	// 2. Ganti baris rincian pajak (pesanan yang diedit dihitung ulang dari awal)
+	if _, err := tx.ExecContext(ctx, "DELETE FROM order_taxes WHERE order_id = ?", orderID); err != nil {
+		return fmt.Errorf("taxRepo.SaveOrderTotalsTx.DeleteTaxes: %w", err)
+	}
+
+	for i := range taxes {
+		t := &taxes[i]
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO order_taxes (order_id, tax_rate_id, tax_name, tax_kind, rate, price_mode, taxable_base, amount, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			orderID,
+			t.TaxRateID,
+			t.TaxName,
+			t.TaxKind,
+			t.Rate,
+			t.PriceMode,
+			t.TaxableBase,
+			t.Amount,
+			t.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("taxRepo.SaveOrderTotalsTx.InsertTax: %w", err)
+		}
+		if t.ID, err = res.LastInsertId(); err != nil {
+			return fmt.Errorf("taxRepo.SaveOrderTotalsTx.LastInsertId: %w", err)
+		}
+		t.OrderID = orderID
+	}
+
+	return nil
+}
+
+// SumOrderTotals aggregates the totals of paid orders created in [start, end) at the outlet active in ctx.
+func (r *taxRepository) SumOrderTotals(ctx context.Context, start, end time.Time) (*models.OrderTotals, int64, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "outlet_id")
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(subtotal), 0), COALESCE(SUM(discount_total), 0), COALESCE(SUM(service_charge_total), 0),
+			COALESCE(SUM(tax_total), 0), COALESCE(SUM(grand_total), 0)
+		FROM orders
+		WHERE payment_status = 'paid' AND status_internal <> 'cancelled' AND created_at >= ? AND created_at < ?` + scope
+
+	var totals models.OrderTotals
+	var orderCount int64
+	err := r.db.QueryRowContext(ctx, query, append([]interface{}{start, end}, scopeArgs...)...).Scan(
+		&orderCount, &totals.Subtotal, &totals.DiscountTotal, &totals.ServiceChargeTotal, &totals.TaxTotal, &totals.GrandTotal,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("taxRepo.SumOrderTotals: %w", err)
+	}
+
+	return &totals, orderCount, nil
+}
+
+// SumOrderTotalsByOutlet aggregates the totals of paid orders created in [start, end) per outlet
+// (dibatasi outlet aktif ctx jika ada). Dipakai laporan konsolidasi owner.
+func (r *taxRepository) SumOrderTotalsByOutlet(ctx context.Context, start, end time.Time) ([]models.OutletOrderTotals, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	query := `
+		SELECT ot.id, ot.code, ot.outlet_name, COUNT(*), SUM(o.subtotal), SUM(o.discount_total), SUM(o.service_charge_total),
+			SUM(o.tax_total), SUM(o.grand_total)
+		FROM orders o
+		JOIN outlets ot ON ot.id = o.outlet_id
+		WHERE o.payment_status = 'paid' AND o.status_internal <> 'cancelled' AND o.created_at >= ? AND o.created_at < ?` + scope + `
+		GROUP BY ot.id, ot.code, ot.outlet_name
+		ORDER BY ot.id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, append([]interface{}{start, end}, scopeArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("taxRepo.SumOrderTotalsByOutlet.Query: %w", err)
+	}
+	defer rows.Close()
+
+	result := []models.OutletOrderTotals{}
+	for rows.Next() {
+		var t models.OutletOrderTotals
+		if err := rows.Scan(
+			&t.OutletID, &t.OutletCode, &t.OutletName, &t.OrderCount,
+			&t.Subtotal, &t.DiscountTotal, &t.ServiceChargeTotal, &t.TaxTotal, &t.GrandTotal,
+		); err != nil {
+			return nil, fmt.Errorf("taxRepo.SumOrderTotalsByOutlet.Scan: %w", err)
+		}
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+// SumCollectedTaxes aggregates order_taxes of paid orders created in [start, end) at the outlet active in ctx,
+// one row per rate snapshot.
+func (r *taxRepository) SumCollectedTaxes(ctx context.Context, start, end time.Time) ([]models.TaxCollection, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	query := `
+		SELECT t.tax_rate_id, t.tax_name, t.tax_kind, t.rate, t.price_mode, SUM(t.taxable_base), SUM(t.amount)
+		FROM order_taxes t
+		JOIN orders o ON o.id = t.order_id
+		WHERE o.payment_status = 'paid' AND o.status_internal <> 'cancelled' AND o.created_at >= ? AND o.created_at < ?` + scope + `
+		GROUP BY t.tax_rate_id, t.tax_name, t.tax_kind, t.rate, t.price_mode
+		ORDER BY t.tax_kind DESC, t.tax_name ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, append([]interface{}{start, end}, scopeArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("taxRepo.SumCollectedTaxes.Query: %w", err)
+	}
+	defer rows.Close()
+
+	collections := []models.TaxCollection{}
+	for rows.Next() {
+		var c models.TaxCollection
+		var rateIDNull sql.NullInt64
+		if err := rows.Scan(&rateIDNull, &c.TaxName, &c.TaxKind, &c.Rate, &c.PriceMode, &c.TaxableBase, &c.Amount); err != nil {
+			return nil, fmt.Errorf("taxRepo.SumCollectedTaxes.Scan: %w", err)
+		}
+		if rateIDNull.Valid {
+			c.TaxRateID = &rateIDNull.Int64
+		}
+		collections = append(collections, c)
+	}
+
+	return collections, rows.Err()
+}
+
+// StreamOrderTotals reads the totals of every paid order created in [start, end) at the outlet active in ctx
+// row by row (oldest first), calling fn for each order. Dipakai export laporan pajak agar ribuan nota tidak ditampung di memori.
+func (r *taxRepository) StreamOrderTotals(ctx context.Context, start, end time.Time, fn func(*models.TaxReportOrder) error) error {
+
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	query := `
+		SELECT o.id, o.invoice_number, ot.code, COALESCE(o.customer_name, c.full_name), o.created_at,
+			o.subtotal, o.discount_total, o.service_charge_total, o.tax_total, o.grand_total
+		FROM orders o
+		JOIN outlets ot ON ot.id = o.outlet_id
+		LEFT JOIN customers c ON c.id = o.customer_id
+		WHERE o.payment_status = 'paid' AND o.status_internal <> 'cancelled' AND o.created_at >= ? AND o.created_at < ?` + scope + `
+		ORDER BY o.created_at ASC, o.id ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, append([]interface{}{start, end}, scopeArgs...)...)
+	if err != nil {
+		return fmt.Errorf("taxRepo.StreamOrderTotals.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var o models.TaxReportOrder
+		var customerName sql.NullString
+		if err := rows.Scan(
+			&o.OrderID, &o.InvoiceNumber, &o.OutletCode, &customerName, &o.CreatedAt,
+			&o.Subtotal, &o.DiscountTotal, &o.ServiceChargeTotal, &o.TaxTotal, &o.GrandTotal,
+		); err != nil {
+			return fmt.Errorf("taxRepo.StreamOrderTotals.Scan: %w", err)
+		}
+		if customerName.Valid {
+			o.CustomerName = &customerName.String
+		}
+		if err := fn(&o); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("taxRepo.StreamOrderTotals.Rows: %w", err)
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+// findMany menjalankan query daftar tarif lalu melengkapi masing-masing dengan cakupannya.
+func (r *taxRepository) findMany(ctx context.Context, op, query string, args ...interface{}) ([]models.TaxRateWithTargets, error) {
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Query: %w", op, err)
+	}
+
+	var rates []models.TaxRate
+	for rows.Next() {
+		rate, err := scanTaxRate(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s.Scan: %w", op, err)
+		}
+		rates = append(rates, *rate)
+	}
+	rows.Close()
+
+	// Lengkapi dengan cakupan (setelah rows ditutup agar koneksi tidak tertahan)
+	result := make([]models.TaxRateWithTargets, 0, len(rates))
+	for i := range rates {
+		withTargets, err := r.withTargets(ctx, &rates[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *withTargets)
+	}
+
+	return result, nil
+}
+
+// withTargets melengkapi tarif dengan daftar cakupan & pengecualiannya.
+func (r *taxRepository) withTargets(ctx context.Context, rate *models.TaxRate) (*models.TaxRateWithTargets, error) {
+
+	query := "SELECT target_type, target_id, is_exempt FROM tax_rate_targets WHERE tax_rate_id = ? ORDER BY target_type, target_id"
+	rows, err := r.db.QueryContext(ctx, query, rate.ID)
+	if err != nil {
+		return nil, fmt.Errorf("taxRepo.withTargets.Query: %w", err)
+	}
+	defer rows.Close()
+
+	result := &models.TaxRateWithTargets{TaxRate: *rate, Targets: []models.TaxRateTarget{}}
+	for rows.Next() {
+		var target models.TaxRateTarget
+		if err := rows.Scan(&target.TargetType, &target.TargetID, &target.IsExempt); err != nil {
+			return nil, fmt.Errorf("taxRepo.withTargets.Scan: %w", err)
+		}
+		result.Targets = append(result.Targets, target)
+	}
+
+	return result, rows.Err()
+}
+
+// replaceTaxRateTargets menghapus semua cakupan lama lalu menyimpan cakupan baru di dalam transaksi yang sama.
+func replaceTaxRateTargets(ctx context.Context, tx *sql.Tx, taxRateID int64, targets []models.TaxRateTarget) error {
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tax_rate_targets WHERE tax_rate_id = ?", taxRateID); err != nil {
+		return fmt.Errorf("replaceTaxRateTargets.Delete: %w", err)
+	}
+
+	query := `
+		INSERT INTO tax_rate_targets (tax_rate_id, target_type, target_id, is_exempt) VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE is_exempt = VALUES(is_exempt)
+	`
+	for _, target := range targets {
+		if _, err := tx.ExecContext(ctx, query, taxRateID, target.TargetType, target.TargetID, target.IsExempt); err != nil {
+			return fmt.Errorf("replaceTaxRateTargets.Insert: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func scanTaxRate(row rowScanner) (*models.TaxRate, error) {
+	var rate models.TaxRate
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var updatedAtNull sql.NullTime
+
+	err := row.Scan(
+		&rate.ID, &rate.Code, &rate.TaxName, &rate.TaxKind, &rate.Rate, &rate.PriceMode, &rate.ScopeType,
+		&rate.IsActive, &rate.CreatedAt, &updatedAtNull,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedAtNull.Valid {
+		rate.UpdatedAt = &updatedAtNull.Time
+	}
+
+	return &rate, nil
+}
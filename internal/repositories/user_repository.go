@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"laundry-backend/internal/models"
+	"laundry-backend/pkg/listquery"
 	"laundry-backend/pkg/response"
 	"time"
 )
@@ -17,7 +18,8 @@ type UserRepository interface {
 	InsertUser(ctx context.Context, user *models.User) error
 
 	// Read Operations
-	FetchUsers(ctx context.Context, limit, offset int, search, role string, status int) ([]models.User, int64, error)
+	FetchUsers(ctx context.Context, params listquery.Params) ([]models.User, *listquery.Result, error)
+	StreamUsers(ctx context.Context, params listquery.Params, fn func(*models.User) error) error
 	FindByID(ctx context.Context, id int64) (*models.User, error)
 	FindByUsername(ctx context.Context, username string) (*models.User, error)
 
@@ -25,7 +27,7 @@ type UserRepository interface {
 	UpdateUser(ctx context.Context, user *models.User) error
 
 	// Delete Operations (Soft Delete)
-	DeleteUser(ctx context.Context, id int64) error
+	DeleteUser(ctx context.Context, id, version int64) error
 
 	// Validation Helpers
 	IsEmailExists(ctx context.Context, email string, excludeID int64) (bool, error)
@@ -51,12 +53,12 @@ func NewUserRepository(db *sql.DB) UserRepository {
 // InsertUser creates a new user record in the database.
 func (r *userRepository) InsertUser(ctx context.Context, user *models.User) error {
 	query := `
-		INSERT INTO users (full_name, username, email, password_hash, role, phone_number, is_active, created_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO users (full_name, username, email, password_hash, role, outlet_id, phone_number, is_active, created_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	res, err := r.db.ExecContext(ctx, query,
 		user.FullName, user.Username, user.Email, user.PasswordHash,
-		user.Role, user.PhoneNumber, user.IsActive, user.CreatedAt,
+		user.Role, user.OutletID, user.PhoneNumber, user.IsActive, user.CreatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("userRepo.InsertUser: %w", err)
@@ -71,66 +73,134 @@ func (r *userRepository) InsertUser(ctx context.Context, user *models.User) erro
 	return nil
 }
 
-// FetchUsers retrieves a list of users with pagination and filtering support.
-func (r *userRepository) FetchUsers(ctx context.Context, limit, offset int, search, role string, status int) ([]models.User, int64, error) {
-	// [OPTIMASI] Gunakan builder sederhana
-	whereClause := "WHERE 1=1"
-	var args []interface{}
+// userListSpec mendeklarasikan pencarian, filter, dan sorting yang diizinkan untuk GET /users.
+var userListSpec = listquery.Spec{
+	Search: []string{"full_name", "username"},
+	Filters: map[string]listquery.Filter{
+		"role":      {Column: "role"},
+		"status":    {Column: "is_active", Allowed: []string{"0", "1"}},
+		"outlet_id": {Column: "outlet_id"},
+	},
+	Sorts: map[string]string{
+		"full_name":  "full_name",
+		"username":   "username",
+		"created_at": "created_at",
+		"id":         "id",
+	},
+	DefaultSort:  "created_at",
+	DefaultOrder: listquery.OrderDesc,
+	IDColumn:     "id",
+}
 
-	if search != "" {
-		whereClause += " AND (full_name LIKE ? OR username LIKE ?)"
-		searchTerm := "%" + search + "%"
-		args = append(args, searchTerm, searchTerm)
-	}
-	if role != "" {
-		whereClause += " AND role = ?"
-		args = append(args, role)
-	}
-	if status != -1 {
-		whereClause += " AND is_active = ?"
-		args = append(args, status)
+// FetchUsers retrieves a list of users with pagination (offset or cursor) and filtering support.
+func (r *userRepository) FetchUsers(ctx context.Context, params listquery.Params) ([]models.User, *listquery.Result, error) {
+	q, err := userListSpec.Build(params)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Count Query
-	var totalItems int64
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM users %s", whereClause)
-	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalItems); err != nil {
-		return nil, 0, fmt.Errorf("userRepo.FetchUsers.Count: %w", err)
+	// Count Query (opsional)
+	var totalItems *int64
+	if q.Count {
+		where, args := q.Where()
+		var total int64
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users "+where, args...).Scan(&total); err != nil {
+			return nil, nil, fmt.Errorf("userRepo.FetchUsers.Count: %w", err)
+		}
+		totalItems = &total
 	}
 
 	// Data Query
-	query := fmt.Sprintf("SELECT id, full_name, username, role, is_active FROM users %s ORDER BY created_at DESC LIMIT ? OFFSET ?", whereClause)
-	args = append(args, limit, offset)
+	tail, args := q.Tail()
+	query := "SELECT id, full_name, username, role, outlet_id, is_active, created_at FROM users " + tail
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("userRepo.FetchUsers.Query: %w", err)
+		return nil, nil, fmt.Errorf("userRepo.FetchUsers.Query: %w", err)
 	}
 	defer rows.Close()
 
 	var users []models.User
 	for rows.Next() {
 		var u models.User
-		if err := rows.Scan(&u.ID, &u.FullName, &u.Username, &u.Role, &u.IsActive); err != nil {
-			return nil, 0, err
+		if err := rows.Scan(&u.ID, &u.FullName, &u.Username, &u.Role, &u.OutletID, &u.IsActive, &u.CreatedAt); err != nil {
+			return nil, nil, fmt.Errorf("userRepo.FetchUsers.Scan: %w", err)
 		}
 		users = append(users, u)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("userRepo.FetchUsers.Rows: %w", err)
+	}
+
+	keep, result := q.Paginate(len(users), totalItems, func(i int) (interface{}, int64) {
+		return userSortValue(users[i], q.SortKey()), users[i].ID
+	})
+
+	return users[:keep], result, nil
+}
+
+// StreamUsers membaca semua user yang cocok dengan filter & sorting list (tanpa pagination) baris per baris
+// untuk export. Kolom kontak & login terakhir ikut dibaca karena dibutuhkan di file export.
+func (r *userRepository) StreamUsers(ctx context.Context, params listquery.Params, fn func(*models.User) error) error {
+	q, err := userListSpec.Build(params)
+	if err != nil {
+		return err
+	}
+
+	tail, args := q.Unpaged()
+	query := "SELECT id, full_name, username, email, role, outlet_id, phone_number, is_active, last_login_at, created_at FROM users " + tail
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("userRepo.StreamUsers.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u models.User
+		var lastLoginNull sql.NullTime
+		if err := rows.Scan(&u.ID, &u.FullName, &u.Username, &u.Email, &u.Role, &u.OutletID, &u.PhoneNumber, &u.IsActive, &lastLoginNull, &u.CreatedAt); err != nil {
+			return fmt.Errorf("userRepo.StreamUsers.Scan: %w", err)
+		}
+		if lastLoginNull.Valid {
+			u.LastLoginAt = &lastLoginNull.Time
+		}
+		if err := fn(&u); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("userRepo.StreamUsers.Rows: %w", err)
+	}
 
-	return users, totalItems, nil
+	return nil
+}
+
+// userSortValue mengambil nilai kolom sort dari baris untuk disimpan di cursor.
+func userSortValue(u models.User, sortKey string) interface{} {
+	switch sortKey {
+	case "full_name":
+		return u.FullName
+	case "username":
+		return u.Username
+	case "id":
+		return u.ID
+	default:
+		return u.CreatedAt
+	}
 }
 
 // FindByID retrieves a single user's detailed information by ID.
 func (r *userRepository) FindByID(ctx context.Context, id int64) (*models.User, error) {
 
-	query := `SELECT id, full_name, username, email, password_hash, role, phone_number, is_active, last_login_at, created_at, updated_at FROM users WHERE id = ?`
+	query := `SELECT id, full_name, username, email, password_hash, role, outlet_id, phone_number, is_active, last_login_at, created_at, updated_at, version FROM users WHERE id = ?`
 
 	var u models.User
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&u.ID, &u.FullName, &u.Username, &u.Email, &u.PasswordHash,
-		&u.Role, &u.PhoneNumber, &u.IsActive, &u.LastLoginAt,
-		&u.CreatedAt, &u.UpdatedAt,
+		&u.Role, &u.OutletID, &u.PhoneNumber, &u.IsActive, &u.LastLoginAt,
+		&u.CreatedAt, &u.UpdatedAt, &u.Version,
 	)
 
 	if err != nil {
@@ -145,12 +215,12 @@ func (r *userRepository) FindByID(ctx context.Context, id int64) (*models.User,
 // FindByUsername retrieves a user by their username (used for Login).
 func (r *userRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
 
-	query := `SELECT id, full_name, username, password_hash, role, is_active FROM users WHERE username = ?`
+	query := `SELECT id, full_name, username, password_hash, role, outlet_id, is_active FROM users WHERE username = ?`
 
 	var user models.User
 
 	err := r.db.QueryRowContext(ctx, query, username).Scan(
-		&user.ID, &user.FullName, &user.Username, &user.PasswordHash, &user.Role, &user.IsActive,
+		&user.ID, &user.FullName, &user.Username, &user.PasswordHash, &user.Role, &user.OutletID, &user.IsActive,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -162,27 +232,28 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 }
 
 // UpdateUser updates an existing user record.
+// user.Version must hold the version read earlier; a concurrent change yields response.ErrPreconditionFailed.
 func (r *userRepository) UpdateUser(ctx context.Context, user *models.User) error {
 
-	query := `UPDATE users SET full_name=?, username=?, email=?, password_hash=?, role=?, phone_number=?, is_active=?, updated_at=? WHERE id=?`
-
-	_, err := r.db.ExecContext(ctx, query,
+	version, err := updateVersioned(ctx, r.db, "users",
+		"full_name=?, username=?, email=?, password_hash=?, role=?, outlet_id=?, phone_number=?, is_active=?, updated_at=?",
+		user.ID, user.Version,
 		user.FullName, user.Username, user.Email, user.PasswordHash,
-		user.Role, user.PhoneNumber, user.IsActive, user.UpdatedAt, user.ID,
+		user.Role, user.OutletID, user.PhoneNumber, user.IsActive, user.UpdatedAt,
 	)
-
 	if err != nil {
 		return fmt.Errorf("userRepo.UpdateUser: %w", err)
 	}
+
+	user.Version = version
 	return nil
 }
 
 // DeleteUser performs a soft delete by setting is_active to false.
-func (r *userRepository) DeleteUser(ctx context.Context, id int64) error {
-
-	query := "UPDATE users SET is_active = 0 WHERE id = ?"
+// The version is bumped like any other update so stale ETags are rejected afterwards.
+func (r *userRepository) DeleteUser(ctx context.Context, id, version int64) error {
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := updateVersioned(ctx, r.db, "users", "is_active = 0, updated_at = ?", id, version, time.Now())
 	if err != nil {
 		return fmt.Errorf("userRepo.DeleteUser: %w", err)
 	}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"laundry-backend/pkg/response"
+)
+
+// queryExecer dipenuhi *sql.DB maupun *sql.Tx, sehingga update berversi bisa ikut transaksi pemanggil.
+type queryExecer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// updateVersioned menjalankan pola optimistic lock yang dipakai semua modul:
+//
+//	UPDATE {table} SET {setClause}, version = version + 1 WHERE id = ? AND version = ?
+//
+// Jika tidak ada baris yang berubah, baris dicek ulang untuk membedakan
+// response.ErrNotFound (ID tidak ada) dari response.ErrPreconditionFailed (sudah diubah request lain).
+// table & setClause wajib berasal dari konstanta di repository, bukan input user.
+// Mengembalikan versi baru setelah update berhasil.
+func updateVersioned(ctx context.Context, db queryExecer, table, setClause string, id, version int64, args ...interface{}) (int64, error) {
+
+	// 1. Update hanya jika versi masih sama dengan yang dibaca pemanggil
+	query := fmt.Sprintf("UPDATE %s SET %s, version = version + 1 WHERE id = ? AND version = ?", table, setClause)
+	args = append(args, id, version)
+
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("updateVersioned.%s.Exec: %w", table, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("updateVersioned.%s.RowsAffected: %w", table, err)
+	}
+	if rows > 0 {
+		return version + 1, nil
+	}
+
+	// 2. Tidak ada yang berubah: baris hilang atau versinya sudah maju
+	var exists bool
+	existsQuery := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE id = ?)", table)
+	if err := db.QueryRowContext(ctx, existsQuery, id).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("updateVersioned.%s.Exists: %w", table, err)
+	}
+	if !exists {
+		return 0, response.ErrNotFound
+	}
+
+	return 0, response.ErrPreconditionFailed
+}
@@ -0,0 +1,330 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// WalletRepository mendefinisikan semua operasi database untuk saldo deposit & poin loyalitas pelanggan.
+//
+// Setiap perubahan saldo WAJIB lewat Post*Entry agar kolom berjalan di 'customers'
+// dan tabel ledger selalu sinkron. Varian *Tx dipakai di dalam transaksi milik pemanggil
+// (cth: pelunasan pembayaran), sehingga potong saldo & simpan pembayaran sukses/gagal bersamaan.
+type WalletRepository interface {
+
+	// Read Operations
+	FindWallet(ctx context.Context, customerID int64) (*models.CustomerWallet, error)
+	FindWalletEntries(ctx context.Context, customerID int64, limit, offset int) ([]models.WalletEntry, int64, error)
+	FindPointEntries(ctx context.Context, customerID int64, limit, offset int) ([]models.PointEntry, int64, error)
+
+	// Write Operations (Ledger)
+	PostWalletEntry(ctx context.Context, entry *models.WalletEntry) error
+	PostWalletEntryTx(ctx context.Context, tx *sql.Tx, entry *models.WalletEntry) error
+	PostPointEntry(ctx context.Context, entry *models.PointEntry) error
+	PostPointEntryTx(ctx context.Context, tx *sql.Tx, entry *models.PointEntry) error
+}
+
+// walletRepository is the concrete implementation using sql.DB.
+type walletRepository struct {
+	db *sql.DB
+}
+
+// NewWalletRepository creates a new instance of WalletRepository.
+func NewWalletRepository(db *sql.DB) WalletRepository {
+	return &walletRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+// FindWallet retrieves the running wallet balance, points and tier of a customer.
+func (r *walletRepository) FindWallet(ctx context.Context, customerID int64) (*models.CustomerWallet, error) {
+
+	query := `
+		SELECT id, full_name, phone_number, membership_tier_id, wallet_balance, points_balance, is_active
+		FROM customers
+		WHERE id = ?
+	`
+	var wallet models.CustomerWallet
+	var tierIDNull sql.NullInt64
+
+	err := r.db.QueryRowContext(ctx, query, customerID).Scan(
+		&wallet.CustomerID, &wallet.FullName, &wallet.PhoneNumber, &tierIDNull,
+		&wallet.WalletBalance, &wallet.PointsBalance, &wallet.IsActive,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("walletRepo.FindWallet: %w", err)
+	}
+
+	if tierIDNull.Valid {
+		wallet.MembershipTierID = &tierIDNull.Int64
+	}
+
+	return &wallet, nil
+}
+
+// FindWalletEntries retrieves the wallet ledger of a customer, newest first.
+func (r *walletRepository) FindWalletEntries(ctx context.Context, customerID int64, limit, offset int) ([]models.WalletEntry, int64, error) {
+
+	// 1. Hitung total baris untuk Meta Pagination
+	var totalItems int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM wallet_ledger WHERE customer_id = ?", customerID).Scan(&totalItems); err != nil {
+		return nil, 0, fmt.Errorf("walletRepo.FindWalletEntries.Count: %w", err)
+	}
+
+	// 2. Ambil mutasi terbaru
+	query := `
+		SELECT id, customer_id, entry_type, direction, amount, balance_before, balance_after, order_id, payment_method, reason, actor_id, created_at
+		FROM wallet_ledger
+		WHERE customer_id = ?
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, customerID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("walletRepo.FindWalletEntries.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 3. Mapping hasil query
+	entries := []models.WalletEntry{}
+	for rows.Next() {
+		var e models.WalletEntry
+		var orderIDNull, actorIDNull sql.NullInt64
+		var methodNull sql.NullString
+
+		if err := rows.Scan(
+			&e.ID, &e.CustomerID, &e.EntryType, &e.Direction, &e.Amount, &e.BalanceBefore, &e.BalanceAfter,
+			&orderIDNull, &methodNull, &e.Reason, &actorIDNull, &e.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("walletRepo.FindWalletEntries.Scan: %w", err)
+		}
+
+		if orderIDNull.Valid {
+			e.OrderID = &orderIDNull.Int64
+		}
+		if methodNull.Valid {
+			e.PaymentMethod = &methodNull.String
+		}
+		if actorIDNull.Valid {
+			e.ActorID = &actorIDNull.Int64
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, totalItems, rows.Err()
+}
+
+// FindPointEntries retrieves the loyalty point ledger of a customer, newest first.
+func (r *walletRepository) FindPointEntries(ctx context.Context, customerID int64, limit, offset int) ([]models.PointEntry, int64, error) {
+
+	// 1. Hitung total baris untuk Meta Pagination
+	var totalItems int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM point_ledger WHERE customer_id = ?", customerID).Scan(&totalItems); err != nil {
+		return nil, 0, fmt.Errorf("walletRepo.FindPointEntries.Count: %w", err)
+	}
+
+	// 2. Ambil mutasi terbaru
+	query := `
+		SELECT id, customer_id, entry_type, direction, points, balance_before, balance_after, order_id, reason, actor_id, created_at
+		FROM point_ledger
+		WHERE customer_id = ?
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, customerID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("walletRepo.FindPointEntries.Query: %w", err)
+	}
+	defer rows.Close()
+
+	// 3. Mapping hasil query
+	entries := []models.PointEntry{}
+	for rows.Next() {
+		var e models.PointEntry
+		var orderIDNull, actorIDNull sql.NullInt64
+
+		if err := rows.Scan(
+			&e.ID, &e.CustomerID, &e.EntryType, &e.Direction, &e.Points, &e.BalanceBefore, &e.BalanceAfter,
+			&orderIDNull, &e.Reason, &actorIDNull, &e.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("walletRepo.FindPointEntries.Scan: %w", err)
+		}
+
+		if orderIDNull.Valid {
+			e.OrderID = &orderIDNull.Int64
+		}
+		if actorIDNull.Valid {
+			e.ActorID = &actorIDNull.Int64
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, totalItems, rows.Err()
+}
+
+// PostWalletEntry records a wallet movement in its own transaction (top-up, manual adjustment).
+func (r *walletRepository) PostWalletEntry(ctx context.Context, entry *models.WalletEntry) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("walletRepo.PostWalletEntry.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.PostWalletEntryTx(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("walletRepo.PostWalletEntry.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// PostWalletEntryTx locks the customer row, applies the movement to wallet_balance and appends the ledger line.
+// A debit larger than the current balance returns ErrInsufficientBalance and changes nothing.
+func (r *walletRepository) PostWalletEntryTx(ctx context.Context, tx *sql.Tx, entry *models.WalletEntry) error {
+
+	// 1. Kunci baris pelanggan agar dua transaksi tidak memotong saldo yang sama
+	var balance money.Amount
+	err := tx.QueryRowContext(ctx, "SELECT wallet_balance FROM customers WHERE id = ? FOR UPDATE", entry.CustomerID).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return response.ErrNotFound
+		}
+		return fmt.Errorf("walletRepo.PostWalletEntryTx.Lock: %w", err)
+	}
+
+	// 2. Hitung saldo baru sesuai arah mutasi
+	entry.BalanceBefore = balance
+	switch entry.Direction {
+	case models.LedgerCredit:
+		entry.BalanceAfter = balance.Add(entry.Amount)
+	case models.LedgerDebit:
+		if entry.Amount > balance {
+			return response.ErrInsufficientBalance
+		}
+		entry.BalanceAfter = balance.Sub(entry.Amount)
+	default:
+		return fmt.Errorf("walletRepo.PostWalletEntryTx: unknown direction %q", entry.Direction)
+	}
+
+	// 3. Update saldo berjalan
+	if _, err := tx.ExecContext(ctx, "UPDATE customers SET wallet_balance = ? WHERE id = ?", entry.BalanceAfter, entry.CustomerID); err != nil {
+		return fmt.Errorf("walletRepo.PostWalletEntryTx.UpdateBalance: %w", err)
+	}
+
+	// 4. Simpan baris ledger
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO wallet_ledger (customer_id, entry_type, direction, amount, balance_before, balance_after, order_id, payment_method, reason, actor_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.CustomerID,
+		entry.EntryType,
+		entry.Direction,
+		entry.Amount,
+		entry.BalanceBefore,
+		entry.BalanceAfter,
+		entry.OrderID,
+		entry.PaymentMethod,
+		entry.Reason,
+		entry.ActorID,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("walletRepo.PostWalletEntryTx.Insert: %w", err)
+	}
+
+	if entry.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("walletRepo.PostWalletEntryTx.LastInsertId: %w", err)
+	}
+
+	return nil
+}
+
+// PostPointEntry records a point movement in its own transaction (manual adjustment).
+func (r *walletRepository) PostPointEntry(ctx context.Context, entry *models.PointEntry) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("walletRepo.PostPointEntry.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.PostPointEntryTx(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("walletRepo.PostPointEntry.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// PostPointEntryTx locks the customer row, applies the movement to points_balance and appends the ledger line.
+// A debit larger than the current points returns ErrInsufficientPoints and changes nothing.
+func (r *walletRepository) PostPointEntryTx(ctx context.Context, tx *sql.Tx, entry *models.PointEntry) error {
+
+	// 1. Kunci baris pelanggan
+	var points int
+	err := tx.QueryRowContext(ctx, "SELECT points_balance FROM customers WHERE id = ? FOR UPDATE", entry.CustomerID).Scan(&points)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return response.ErrNotFound
+		}
+		return fmt.Errorf("walletRepo.PostPointEntryTx.Lock: %w", err)
+	}
+
+	// 2. Hitung poin baru sesuai arah mutasi
+	entry.BalanceBefore = points
+	switch entry.Direction {
+	case models.LedgerCredit:
+		entry.BalanceAfter = points + entry.Points
+	case models.LedgerDebit:
+		if entry.Points > points {
+			return response.ErrInsufficientPoints
+		}
+		entry.BalanceAfter = points - entry.Points
+	default:
+		return fmt.Errorf("walletRepo.PostPointEntryTx: unknown direction %q", entry.Direction)
+	}
+
+	// 3. Update poin berjalan
+	if _, err := tx.ExecContext(ctx, "UPDATE customers SET points_balance = ? WHERE id = ?", entry.BalanceAfter, entry.CustomerID); err != nil {
+		return fmt.Errorf("walletRepo.PostPointEntryTx.UpdateBalance: %w", err)
+	}
+
+	// 4. Simpan baris ledger
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO point_ledger (customer_id, entry_type, direction, points, balance_before, balance_after, order_id, reason, actor_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.CustomerID,
+		entry.EntryType,
+		entry.Direction,
+		entry.Points,
+		entry.BalanceBefore,
+		entry.BalanceAfter,
+		entry.OrderID,
+		entry.Reason,
+		entry.ActorID,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("walletRepo.PostPointEntryTx.Insert: %w", err)
+	}
+
+	if entry.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("walletRepo.PostPointEntryTx.LastInsertId: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,505 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// WashBatchRepository mendefinisikan operasi database untuk batch mesin (satu siklus cuci/kering)
+// beserta item pesanan di dalamnya. Semua query dibatasi outlet aktif di context.
+type WashBatchRepository interface {
+
+	// Read Operations
+	FindCandidatesByTag(ctx context.Context, tagCode string) ([]models.WashBatchCandidate, error)
+	FindCandidatesByInvoice(ctx context.Context, invoiceNumber string) ([]models.WashBatchCandidate, error)
+	FindBatches(ctx context.Context, params listquery.Params) ([]models.WashBatch, *listquery.Result, error)
+	FindBatchByID(ctx context.Context, id int64) (*models.WashBatch, error)
+	FindBatchesInRange(ctx context.Context, start, end time.Time) ([]models.WashBatch, error)
+
+	// Transaction Operations (dipanggil di dalam transaksi milik service)
+	LockMachineTx(ctx context.Context, tx *sql.Tx, machineID int64) (*models.Machine, error)
+	LockBatchTx(ctx context.Context, tx *sql.Tx, id int64) (*models.WashBatch, error)
+	FindRunningBatchIDTx(ctx context.Context, tx *sql.Tx, machineID int64) (*int64, error)
+	FindRunningItemIDsTx(ctx context.Context, tx *sql.Tx, orderItemIDs []int64) ([]int64, error)
+	FindItemProgressTx(ctx context.Context, tx *sql.Tx, orderID int64) ([]models.WashItemProgress, error)
+	InsertBatchTx(ctx context.Context, tx *sql.Tx, batch *models.WashBatch) error
+	FinishBatchTx(ctx context.Context, tx *sql.Tx, batch *models.WashBatch) error
+}
+
+// washBatchRepository is the concrete implementation using sql.DB.
+type washBatchRepository struct {
+	db *sql.DB
+}
+
+// NewWashBatchRepository creates a new instance of WashBatchRepository.
+func NewWashBatchRepository(db *sql.DB) WashBatchRepository {
+	return &washBatchRepository{db: db}
+}
+
+// --- IMPLEMENTATION: CANDIDATES ---
+
+const washCandidateSelect = `
+	SELECT oi.id, o.id, o.invoice_number, o.status_internal, COALESCE(s.service_name, '-'), COALESCE(s.unit, 'pcs'),
+		oi.quantity, oi.weight_kg,
+		(SELECT wbi.batch_id FROM wash_batch_items wbi
+			JOIN wash_batches wb ON wb.id = wbi.batch_id
+			WHERE wbi.order_item_id = oi.id AND wb.batch_status = 'running' LIMIT 1)
+	FROM order_items oi
+	JOIN orders o ON o.id = oi.order_id
+	LEFT JOIN services s ON s.id = oi.service_id `
+
+// FindCandidatesByTag retrieves the order item of a tag code (one row, or none when the tag is unknown).
+func (r *washBatchRepository) FindCandidatesByTag(ctx context.Context, tagCode string) ([]models.WashBatchCandidate, error) {
+	return r.findCandidates(ctx, "FindCandidatesByTag",
+		"JOIN order_item_tags t ON t.order_item_id = oi.id WHERE t.tag_code = ?", tagCode)
+}
+
+// FindCandidatesByInvoice retrieves every item of an order by its invoice number.
+func (r *washBatchRepository) FindCandidatesByInvoice(ctx context.Context, invoiceNumber string) ([]models.WashBatchCandidate, error) {
+	return r.findCandidates(ctx, "FindCandidatesByInvoice", "WHERE o.invoice_number = ?", invoiceNumber)
+}
+
+func (r *washBatchRepository) findCandidates(ctx context.Context, method, condition string, arg interface{}) ([]models.WashBatchCandidate, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "o.outlet_id")
+	query := washCandidateSelect + condition + scope + " ORDER BY oi.id ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, append([]interface{}{arg}, scopeArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("washBatchRepo.%s.Query: %w", method, err)
+	}
+	defer rows.Close()
+
+	candidates := []models.WashBatchCandidate{}
+	for rows.Next() {
+		var c models.WashBatchCandidate
+
+		// Wadah perantara untuk menangkap NULL dari database
+		var statusNull sql.NullString
+		var quantityNull, runningNull sql.NullInt64
+		var weightNull sql.NullFloat64
+
+		if err := rows.Scan(
+			&c.OrderItemID, &c.OrderID, &c.InvoiceNumber, &statusNull, &c.ServiceName, &c.Unit,
+			&quantityNull, &weightNull, &runningNull,
+		); err != nil {
+			return nil, fmt.Errorf("washBatchRepo.%s.Scan: %w", method, err)
+		}
+
+		c.StatusInternal = statusNull.String
+		c.Quantity = float64(quantityNull.Int64)
+		if c.Unit == "kg" && weightNull.Valid {
+			c.Quantity = weightNull.Float64
+		}
+		if runningNull.Valid {
+			c.RunningBatchID = &runningNull.Int64
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+// --- IMPLEMENTATION: BATCHES ---
+
+// washBatchListSpec mendeklarasikan filter dan sorting yang diizinkan untuk GET /wash-batches.
+var washBatchListSpec = listquery.Spec{
+	Filters: map[string]listquery.Filter{
+		"outlet_id":    {Column: "wb.outlet_id"},
+		"machine_id":   {Column: "wb.machine_id"},
+		"batch_status": {Column: "wb.batch_status", Allowed: []string{models.WashBatchRunning, models.WashBatchFinished}},
+		"started_by":   {Column: "wb.started_by"},
+		"order_id":     {Expr: "EXISTS (SELECT 1 FROM wash_batch_items f WHERE f.batch_id = wb.id AND f.order_id = ?)"},
+		"start_date":   {Expr: "wb.started_at >= ?"},
+		"end_date":     {Expr: "wb.started_at < DATE_ADD(?, INTERVAL 1 DAY)"},
+	},
+	Sorts: map[string]string{
+		"started_at": "wb.started_at",
+		"id":         "wb.id",
+	},
+	DefaultSort:  "started_at",
+	DefaultOrder: listquery.OrderDesc,
+	IDColumn:     "wb.id",
+}
+
+const washBatchSelect = `
+	SELECT wb.id, wb.outlet_id, wb.machine_id, m.machine_name, m.machine_type, wb.batch_status, wb.load_kg, wb.notes,
+		wb.started_by, COALESCE(us.full_name, '-'), wb.started_at, wb.finished_by, uf.full_name, wb.finished_at,
+		(SELECT COUNT(*) FROM wash_batch_items c WHERE c.batch_id = wb.id)
+	FROM wash_batches wb
+	JOIN machines m ON m.id = wb.machine_id
+	LEFT JOIN users us ON us.id = wb.started_by
+	LEFT JOIN users uf ON uf.id = wb.finished_by `
+
+// FindBatches retrieves batches of the active outlet with pagination (offset or cursor) and filters.
+func (r *washBatchRepository) FindBatches(ctx context.Context, params listquery.Params) ([]models.WashBatch, *listquery.Result, error) {
+
+	// 1. Susun WHERE / ORDER BY / LIMIT dari spec (outlet aktif dipaksa lewat filter outlet_id)
+	q, err := washBatchListSpec.Build(outletListParams(ctx, params))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Hitung total baris (opsional) untuk data Meta Pagination
+	var totalItems *int64
+	if q.Count {
+		where, args := q.Where()
+		var total int64
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM wash_batches wb "+where, args...).Scan(&total); err != nil {
+			return nil, nil, fmt.Errorf("washBatchRepo.FindBatches.Count: %w", err)
+		}
+		totalItems = &total
+	}
+
+	// 3. Eksekusi query utama
+	tail, args := q.Tail()
+	batches, err := r.queryBatches(ctx, "FindBatches", washBatchSelect+tail, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 4. Buang baris ekstra & siapkan cursor halaman berikutnya
+	keep, result := q.Paginate(len(batches), totalItems, func(i int) (interface{}, int64) {
+		if q.SortKey() == "id" {
+			return batches[i].ID, batches[i].ID
+		}
+		return batches[i].StartedAt, batches[i].ID
+	})
+
+	return batches[:keep], result, nil
+}
+
+// FindBatchByID retrieves a batch of the active outlet together with its items.
+func (r *washBatchRepository) FindBatchByID(ctx context.Context, id int64) (*models.WashBatch, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "wb.outlet_id")
+	batch, err := scanWashBatch(r.db.QueryRowContext(ctx, washBatchSelect+"WHERE wb.id = ?"+scope, append([]interface{}{id}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("washBatchRepo.FindBatchByID: %w", err)
+	}
+
+	if batch.Items, err = r.findItems(ctx, batch.ID); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// FindBatchesInRange retrieves batches that ran (partly) within [start, end), without items.
+// Batch yang masih berjalan dianggap berjalan sampai sekarang.
+func (r *washBatchRepository) FindBatchesInRange(ctx context.Context, start, end time.Time) ([]models.WashBatch, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "wb.outlet_id")
+	query := washBatchSelect + "WHERE wb.started_at < ? AND (wb.finished_at IS NULL OR wb.finished_at > ?)" + scope + " ORDER BY wb.started_at ASC"
+
+	return r.queryBatches(ctx, "FindBatchesInRange", query, append([]interface{}{end, start}, scopeArgs...)...)
+}
+
+func (r *washBatchRepository) queryBatches(ctx context.Context, method, query string, args ...interface{}) ([]models.WashBatch, error) {
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("washBatchRepo.%s.Query: %w", method, err)
+	}
+	defer rows.Close()
+
+	batches := []models.WashBatch{}
+	for rows.Next() {
+		batch, err := scanWashBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("washBatchRepo.%s.Scan: %w", method, err)
+		}
+		batches = append(batches, *batch)
+	}
+
+	return batches, rows.Err()
+}
+
+// findItems retrieves the items of a batch with their order, service, and tag.
+func (r *washBatchRepository) findItems(ctx context.Context, batchID int64) ([]models.WashBatchItem, error) {
+
+	query := `
+		SELECT wbi.id, wbi.batch_id, wbi.order_id, wbi.order_item_id, wbi.load_kg, o.invoice_number,
+			COALESCE(s.service_name, '-'), COALESCE(s.unit, 'pcs'), oi.quantity, oi.weight_kg, t.tag_code
+		FROM wash_batch_items wbi
+		JOIN orders o ON o.id = wbi.order_id
+		JOIN order_items oi ON oi.id = wbi.order_item_id
+		LEFT JOIN services s ON s.id = oi.service_id
+		LEFT JOIN order_item_tags t ON t.order_item_id = wbi.order_item_id
+		WHERE wbi.batch_id = ?
+		ORDER BY wbi.order_id ASC, wbi.order_item_id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("washBatchRepo.findItems.Query: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.WashBatchItem{}
+	for rows.Next() {
+		var item models.WashBatchItem
+
+		// Wadah perantara untuk menangkap NULL dari database
+		var quantityNull sql.NullInt64
+		var weightNull sql.NullFloat64
+		var tagCodeNull sql.NullString
+
+		if err := rows.Scan(
+			&item.ID, &item.BatchID, &item.OrderID, &item.OrderItemID, &item.LoadKg, &item.InvoiceNumber,
+			&item.ServiceName, &item.Unit, &quantityNull, &weightNull, &tagCodeNull,
+		); err != nil {
+			return nil, fmt.Errorf("washBatchRepo.findItems.Scan: %w", err)
+		}
+
+		item.Quantity = float64(quantityNull.Int64)
+		if item.Unit == "kg" && weightNull.Valid {
+			item.Quantity = weightNull.Float64
+		}
+		item.TagCode = nullStringPtr(tagCodeNull)
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// --- IMPLEMENTATION: TRANSACTION ---
+
+// LockMachineTx retrieves a machine of the active outlet and locks it until the transaction ends.
+func (r *washBatchRepository) LockMachineTx(ctx context.Context, tx *sql.Tx, machineID int64) (*models.Machine, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "m.outlet_id")
+	query := "SELECT " + machineColumns + " FROM machines m WHERE m.id = ?" + scope + " FOR UPDATE"
+
+	machine, err := scanMachine(tx.QueryRowContext(ctx, query, append([]interface{}{machineID}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("washBatchRepo.LockMachineTx: %w", err)
+	}
+
+	return machine, nil
+}
+
+// LockBatchTx retrieves a batch of the active outlet (with its items) and locks it until the transaction ends.
+func (r *washBatchRepository) LockBatchTx(ctx context.Context, tx *sql.Tx, id int64) (*models.WashBatch, error) {
+
+	scope, scopeArgs := outletFilter(ctx, "wb.outlet_id")
+	query := `
+		SELECT wb.id, wb.outlet_id, wb.machine_id, m.machine_name, m.machine_type, wb.batch_status, wb.load_kg, wb.notes,
+			wb.started_by, '-', wb.started_at, wb.finished_by, NULL, wb.finished_at, 0
+		FROM wash_batches wb
+		JOIN machines m ON m.id = wb.machine_id
+		WHERE wb.id = ?` + scope + " FOR UPDATE"
+
+	batch, err := scanWashBatch(tx.QueryRowContext(ctx, query, append([]interface{}{id}, scopeArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("washBatchRepo.LockBatchTx: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT id, batch_id, order_id, order_item_id, load_kg FROM wash_batch_items WHERE batch_id = ? ORDER BY order_id ASC, order_item_id ASC", id)
+	if err != nil {
+		return nil, fmt.Errorf("washBatchRepo.LockBatchTx.Items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.WashBatchItem
+		if err := rows.Scan(&item.ID, &item.BatchID, &item.OrderID, &item.OrderItemID, &item.LoadKg); err != nil {
+			return nil, fmt.Errorf("washBatchRepo.LockBatchTx.ItemScan: %w", err)
+		}
+		batch.Items = append(batch.Items, item)
+	}
+
+	return batch, rows.Err()
+}
+
+// FindRunningBatchIDTx returns the running batch of a machine (nil when the machine is idle).
+func (r *washBatchRepository) FindRunningBatchIDTx(ctx context.Context, tx *sql.Tx, machineID int64) (*int64, error) {
+
+	var id int64
+	err := tx.QueryRowContext(ctx, "SELECT id FROM wash_batches WHERE running_machine_id = ?", machineID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("washBatchRepo.FindRunningBatchIDTx: %w", err)
+	}
+
+	return &id, nil
+}
+
+// FindRunningItemIDsTx returns which of the given order items are already inside a running batch.
+func (r *washBatchRepository) FindRunningItemIDsTx(ctx context.Context, tx *sql.Tx, orderItemIDs []int64) ([]int64, error) {
+
+	if len(orderItemIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(orderItemIDs)), ",")
+	args := make([]interface{}, 0, len(orderItemIDs))
+	for _, id := range orderItemIDs {
+		args = append(args, id)
+	}
+
+	query := `
+		SELECT DISTINCT wbi.order_item_id
+		FROM wash_batch_items wbi
+		JOIN wash_batches wb ON wb.id = wbi.batch_id
+		WHERE wb.batch_status = 'running' AND wbi.order_item_id IN (` + placeholders + `)
+		ORDER BY wbi.order_item_id ASC`
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("washBatchRepo.FindRunningItemIDsTx.Query: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("washBatchRepo.FindRunningItemIDsTx.Scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// FindItemProgressTx summarises, per item of an order, which machine stages it has completed.
+func (r *washBatchRepository) FindItemProgressTx(ctx context.Context, tx *sql.Tx, orderID int64) ([]models.WashItemProgress, error) {
+
+	query := `
+		SELECT oi.id,
+			COALESCE(MAX(wb.batch_status = 'finished' AND m.machine_type = 'washer'), 0),
+			COALESCE(MAX(wb.batch_status = 'finished' AND m.machine_type = 'dryer'), 0),
+			COALESCE(MAX(wb.batch_status = 'running'), 0)
+		FROM order_items oi
+		LEFT JOIN wash_batch_items wbi ON wbi.order_item_id = oi.id
+		LEFT JOIN wash_batches wb ON wb.id = wbi.batch_id
+		LEFT JOIN machines m ON m.id = wb.machine_id
+		WHERE oi.order_id = ?
+		GROUP BY oi.id
+		ORDER BY oi.id ASC`
+
+	rows, err := tx.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("washBatchRepo.FindItemProgressTx.Query: %w", err)
+	}
+	defer rows.Close()
+
+	progress := []models.WashItemProgress{}
+	for rows.Next() {
+		var p models.WashItemProgress
+		if err := rows.Scan(&p.OrderItemID, &p.FinishedWasher, &p.FinishedDryer, &p.InRunningBatch); err != nil {
+			return nil, fmt.Errorf("washBatchRepo.FindItemProgressTx.Scan: %w", err)
+		}
+		progress = append(progress, p)
+	}
+
+	return progress, rows.Err()
+}
+
+// InsertBatchTx creates a running batch and its items.
+// UNIQUE INDEX running_machine_id menjadi pengaman terakhir agar satu mesin tidak menjalankan dua batch.
+func (r *washBatchRepository) InsertBatchTx(ctx context.Context, tx *sql.Tx, batch *models.WashBatch) error {
+
+	// 1. Simpan header batch
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO wash_batches (outlet_id, machine_id, batch_status, load_kg, notes, started_by, started_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		batch.OutletID,
+		batch.MachineID,
+		batch.BatchStatus,
+		batch.LoadKg,
+		batch.Notes, // Pointer, aman jika nil
+		batch.StartedBy,
+		batch.StartedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("washBatchRepo.InsertBatchTx.Exec: %w", err)
+	}
+
+	if batch.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("washBatchRepo.InsertBatchTx.LastInsertId: %w", err)
+	}
+
+	// 2. Simpan item
+	for i := range batch.Items {
+		item := &batch.Items[i]
+		item.BatchID = batch.ID
+
+		res, err := tx.ExecContext(ctx,
+			"INSERT INTO wash_batch_items (batch_id, order_id, order_item_id, load_kg) VALUES (?, ?, ?, ?)",
+			item.BatchID, item.OrderID, item.OrderItemID, item.LoadKg,
+		)
+		if err != nil {
+			return fmt.Errorf("washBatchRepo.InsertBatchTx.Item: %w", err)
+		}
+		if item.ID, err = res.LastInsertId(); err != nil {
+			return fmt.Errorf("washBatchRepo.InsertBatchTx.ItemLastInsertId: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// FinishBatchTx marks a running batch as finished.
+func (r *washBatchRepository) FinishBatchTx(ctx context.Context, tx *sql.Tx, batch *models.WashBatch) error {
+
+	res, err := tx.ExecContext(ctx,
+		"UPDATE wash_batches SET batch_status = ?, finished_by = ?, finished_at = ? WHERE id = ? AND batch_status = ?",
+		models.WashBatchFinished, batch.FinishedBy, batch.FinishedAt, batch.ID, models.WashBatchRunning,
+	)
+	if err != nil {
+		return fmt.Errorf("washBatchRepo.FinishBatchTx.Exec: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return response.ErrWashBatchConflict
+	}
+
+	batch.BatchStatus = models.WashBatchFinished
+	return nil
+}
+
+func scanWashBatch(row rowScanner) (*models.WashBatch, error) {
+	var b models.WashBatch
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var notesNull, finishedByNameNull sql.NullString
+	var finishedByNull sql.NullInt64
+	var finishedAtNull sql.NullTime
+
+	if err := row.Scan(
+		&b.ID, &b.OutletID, &b.MachineID, &b.MachineName, &b.MachineType, &b.BatchStatus, &b.LoadKg, &notesNull,
+		&b.StartedBy, &b.StartedByName, &b.StartedAt, &finishedByNull, &finishedByNameNull, &finishedAtNull,
+		&b.ItemCount,
+	); err != nil {
+		return nil, err
+	}
+
+	b.Notes = nullStringPtr(notesNull)
+	b.FinishedByName = nullStringPtr(finishedByNameNull)
+	if finishedByNull.Valid {
+		b.FinishedBy = &finishedByNull.Int64
+	}
+	if finishedAtNull.Valid {
+		b.FinishedAt = &finishedAtNull.Time
+	}
+
+	return &b, nil
+}
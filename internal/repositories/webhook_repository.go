@@ -0,0 +1,503 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// WebhookRepository mendefinisikan operasi database untuk endpoint webhook & log pengirimannya.
+type WebhookRepository interface {
+
+	// Endpoint
+	FindEndpoints(ctx context.Context) ([]models.WebhookEndpoint, error)
+	FindEndpointByID(ctx context.Context, id int64) (*models.WebhookEndpoint, error)
+	InsertEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, id int64) error
+
+	// Pengiriman: penulisan di dalam transaksi milik pemanggil
+	FindSubscriberIDsTx(ctx context.Context, tx *sql.Tx, eventType string) ([]int64, error)
+	InsertDeliveryTx(ctx context.Context, tx *sql.Tx, delivery *models.WebhookDelivery) error
+
+	// Pengiriman: worker
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.WebhookJob, error)
+	SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery) error
+
+	// Pengiriman: log & replay
+	FindDeliveries(ctx context.Context, endpointID int64, status string, limit, offset int) ([]models.WebhookDelivery, int64, error)
+	FindDeliveryByID(ctx context.Context, id int64) (*models.WebhookDelivery, error)
+	InsertDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+// webhookRepository is the concrete implementation using sql.DB.
+type webhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository.
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// --- IMPLEMENTATION ---
+
+const webhookEndpointColumns = `
+	e.id, e.url, e.description, e.secret, e.is_active, e.created_by, e.created_at, e.updated_at,
+	(SELECT GROUP_CONCAT(s.event_type ORDER BY s.event_type SEPARATOR ',') FROM webhook_subscriptions s WHERE s.endpoint_id = e.id)
+`
+
+const webhookDeliveryColumns = `
+	d.id, d.endpoint_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+	d.response_status, d.response_body, d.last_error, d.duration_ms, d.delivered_at, d.replay_of, d.created_at
+`
+
+// FindEndpoints retrieves every registered endpoint with its subscribed events.
+func (r *webhookRepository) FindEndpoints(ctx context.Context) ([]models.WebhookEndpoint, error) {
+
+	query := `SELECT ` + webhookEndpointColumns + ` FROM webhook_endpoints e ORDER BY e.id ASC`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("webhookRepo.FindEndpoints.Query: %w", err)
+	}
+	defer rows.Close()
+
+	endpoints := []models.WebhookEndpoint{}
+	for rows.Next() {
+		endpoint, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("webhookRepo.FindEndpoints.Scan: %w", err)
+		}
+		endpoints = append(endpoints, *endpoint)
+	}
+
+	return endpoints, rows.Err()
+}
+
+// FindEndpointByID retrieves a single endpoint with its subscribed events.
+func (r *webhookRepository) FindEndpointByID(ctx context.Context, id int64) (*models.WebhookEndpoint, error) {
+
+	query := `SELECT ` + webhookEndpointColumns + ` FROM webhook_endpoints e WHERE e.id = ?`
+	endpoint, err := scanWebhookEndpoint(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("webhookRepo.FindEndpointByID: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// InsertEndpoint saves a new endpoint and its subscriptions in one transaction.
+func (r *webhookRepository) InsertEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+
+	// 1. Mulai transaksi
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("webhookRepo.InsertEndpoint.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Simpan endpoint
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO webhook_endpoints (url, description, secret, is_active, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		endpoint.URL,
+		endpoint.Description,
+		endpoint.Secret,
+		endpoint.IsActive,
+		endpoint.CreatedBy,
+		endpoint.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("webhookRepo.InsertEndpoint.Exec: %w", err)
+	}
+	if endpoint.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("webhookRepo.InsertEndpoint.LastInsertId: %w", err)
+	}
+
+	// 3. Simpan daftar event
+	if err := replaceWebhookSubscriptions(ctx, tx, endpoint.ID, endpoint.Events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("webhookRepo.InsertEndpoint.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateEndpoint saves the endpoint fields and replaces its subscriptions.
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+
+	// 1. Mulai transaksi
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("webhookRepo.UpdateEndpoint.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Update data endpoint
+	res, err := tx.ExecContext(ctx, `
+		UPDATE webhook_endpoints
+		SET url = ?, description = ?, secret = ?, is_active = ?, updated_at = ?
+		WHERE id = ?`,
+		endpoint.URL,
+		endpoint.Description,
+		endpoint.Secret,
+		endpoint.IsActive,
+		endpoint.UpdatedAt,
+		endpoint.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("webhookRepo.UpdateEndpoint.Exec: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("webhookRepo.UpdateEndpoint.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	// 3. Ganti daftar event
+	if err := replaceWebhookSubscriptions(ctx, tx, endpoint.ID, endpoint.Events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("webhookRepo.UpdateEndpoint.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEndpoint removes an endpoint; its subscriptions and delivery log are removed by cascade.
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id int64) error {
+
+	res, err := r.db.ExecContext(ctx, "DELETE FROM webhook_endpoints WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("webhookRepo.DeleteEndpoint: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("webhookRepo.DeleteEndpoint.RowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindSubscriberIDsTx retrieves the active endpoints that listen to an event.
+func (r *webhookRepository) FindSubscriberIDsTx(ctx context.Context, tx *sql.Tx, eventType string) ([]int64, error) {
+
+	query := `
+		SELECT e.id
+		FROM webhook_endpoints e
+		JOIN webhook_subscriptions s ON s.endpoint_id = e.id
+		WHERE s.event_type = ? AND e.is_active = 1
+		ORDER BY e.id ASC
+	`
+	rows, err := tx.QueryContext(ctx, query, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("webhookRepo.FindSubscriberIDsTx.Query: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("webhookRepo.FindSubscriberIDsTx.Scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// InsertDeliveryTx appends a pending delivery inside the caller's transaction.
+func (r *webhookRepository) InsertDeliveryTx(ctx context.Context, tx *sql.Tx, delivery *models.WebhookDelivery) error {
+	return insertWebhookDelivery(ctx, tx, delivery, "webhookRepo.InsertDeliveryTx")
+}
+
+// InsertDelivery appends a pending delivery outside of any transaction (dipakai untuk replay).
+func (r *webhookRepository) InsertDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return insertWebhookDelivery(ctx, r.db, delivery, "webhookRepo.InsertDelivery")
+}
+
+// ClaimDue locks up to limit due deliveries for this worker and marks them as processing until leaseUntil.
+// Pola sama dengan antrean notifikasi: SKIP LOCKED & sewa yang habis diambil ulang.
+func (r *webhookRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.WebhookJob, error) {
+
+	// 1. Mulai transaksi
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("webhookRepo.ClaimDue.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 2. Kunci pengiriman yang sudah jatuh tempo
+	query := `SELECT ` + webhookDeliveryColumns + `, e.url, e.secret, e.is_active
+		FROM webhook_deliveries d
+		JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		WHERE d.status IN (?, ?) AND d.next_attempt_at <= ?
+		ORDER BY d.next_attempt_at ASC, d.id ASC
+		LIMIT ?
+		FOR UPDATE OF d SKIP LOCKED
+	`
+	rows, err := tx.QueryContext(ctx, query, models.WebhookPending, models.WebhookProcessing, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("webhookRepo.ClaimDue.Query: %w", err)
+	}
+
+	jobs := []models.WebhookJob{}
+	for rows.Next() {
+		var job models.WebhookJob
+		var activeNull sql.NullBool
+		if err := scanWebhookDelivery(rows, &job.WebhookDelivery, &job.URL, &job.Secret, &activeNull); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("webhookRepo.ClaimDue.Scan: %w", err)
+		}
+		job.EndpointActive = activeNull.Bool
+		jobs = append(jobs, job)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("webhookRepo.ClaimDue.Rows: %w", err)
+	}
+	if len(jobs) == 0 {
+		return jobs, nil
+	}
+
+	// 3. Tandai sebagai 'processing' & hitung percobaan
+	placeholders := make([]string, len(jobs))
+	args := []interface{}{models.WebhookProcessing, leaseUntil}
+	for i := range jobs {
+		placeholders[i] = "?"
+		args = append(args, jobs[i].ID)
+
+		jobs[i].Status = models.WebhookProcessing
+		jobs[i].Attempts++
+		jobs[i].NextAttemptAt = leaseUntil
+	}
+	update := `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = attempts + 1, next_attempt_at = ?
+		WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+	if _, err := tx.ExecContext(ctx, update, args...); err != nil {
+		return nil, fmt.Errorf("webhookRepo.ClaimDue.Update: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("webhookRepo.ClaimDue.Commit: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// SaveAttempt records the outcome of the latest attempt (status, response, next schedule).
+func (r *webhookRepository) SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = ?, next_attempt_at = ?, response_status = ?, response_body = ?, last_error = ?,
+			duration_ms = ?, delivered_at = ?
+		WHERE id = ?`,
+		delivery.Status,
+		delivery.NextAttemptAt,
+		delivery.ResponseStatus,
+		delivery.ResponseBody,
+		delivery.LastError,
+		delivery.DurationMs,
+		delivery.DeliveredAt,
+		delivery.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("webhookRepo.SaveAttempt: %w", err)
+	}
+
+	return nil
+}
+
+// FindDeliveries retrieves the delivery log of an endpoint, newest first. Empty status means no filter.
+func (r *webhookRepository) FindDeliveries(ctx context.Context, endpointID int64, status string, limit, offset int) ([]models.WebhookDelivery, int64, error) {
+
+	// 1. Susun filter dinamis
+	where := " WHERE d.endpoint_id = ?"
+	args := []interface{}{endpointID}
+	if status != "" {
+		where += " AND d.status = ?"
+		args = append(args, status)
+	}
+
+	// 2. Hitung total baris untuk Meta Pagination
+	var totalItems int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM webhook_deliveries d"+where, args...).Scan(&totalItems); err != nil {
+		return nil, 0, fmt.Errorf("webhookRepo.FindDeliveries.Count: %w", err)
+	}
+
+	// 3. Ambil data
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries d` + where + ` ORDER BY d.id DESC LIMIT ? OFFSET ?`
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("webhookRepo.FindDeliveries.Query: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := scanWebhookDelivery(rows, &delivery); err != nil {
+			return nil, 0, fmt.Errorf("webhookRepo.FindDeliveries.Scan: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, totalItems, rows.Err()
+}
+
+// FindDeliveryByID retrieves a single delivery including its payload.
+func (r *webhookRepository) FindDeliveryByID(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+
+	var delivery models.WebhookDelivery
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries d WHERE d.id = ?`
+	if err := scanWebhookDelivery(r.db.QueryRowContext(ctx, query, id), &delivery); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, response.ErrNotFound
+		}
+		return nil, fmt.Errorf("webhookRepo.FindDeliveryByID: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+// execer adalah kemampuan bersama *sql.DB dan *sql.Tx untuk query tulis.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertWebhookDelivery(ctx context.Context, db execer, delivery *models.WebhookDelivery, op string) error {
+
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload, status, next_attempt_at, replay_of, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		delivery.EndpointID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.Payload,
+		models.WebhookPending,
+		delivery.NextAttemptAt,
+		delivery.ReplayOf,
+		delivery.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if delivery.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("%s.LastInsertId: %w", op, err)
+	}
+	delivery.Status = models.WebhookPending
+
+	return nil
+}
+
+func replaceWebhookSubscriptions(ctx context.Context, tx *sql.Tx, endpointID int64, events []string) error {
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE endpoint_id = ?", endpointID); err != nil {
+		return fmt.Errorf("replaceWebhookSubscriptions.Delete: %w", err)
+	}
+
+	for _, event := range events {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT IGNORE INTO webhook_subscriptions (endpoint_id, event_type) VALUES (?, ?)", endpointID, event,
+		); err != nil {
+			return fmt.Errorf("replaceWebhookSubscriptions.Insert: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func scanWebhookEndpoint(row rowScanner) (*models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var descriptionNull, eventsNull sql.NullString
+	var isActiveNull sql.NullBool
+	var createdByNull sql.NullInt64
+	var createdAtNull, updatedAtNull sql.NullTime
+
+	if err := row.Scan(
+		&endpoint.ID, &endpoint.URL, &descriptionNull, &endpoint.Secret, &isActiveNull, &createdByNull,
+		&createdAtNull, &updatedAtNull, &eventsNull,
+	); err != nil {
+		return nil, err
+	}
+
+	endpoint.Description = nullStringPtr(descriptionNull)
+	endpoint.IsActive = isActiveNull.Bool
+	if createdByNull.Valid {
+		endpoint.CreatedBy = &createdByNull.Int64
+	}
+	endpoint.CreatedAt = createdAtNull.Time
+	if updatedAtNull.Valid {
+		endpoint.UpdatedAt = &updatedAtNull.Time
+	}
+	endpoint.Events = []string{}
+	if eventsNull.Valid && eventsNull.String != "" {
+		endpoint.Events = strings.Split(eventsNull.String, ",")
+	}
+
+	return &endpoint, nil
+}
+
+// scanWebhookDelivery memindai kolom webhookDeliveryColumns ke delivery, diikuti kolom tambahan (extra) jika ada.
+func scanWebhookDelivery(row rowScanner, delivery *models.WebhookDelivery, extra ...interface{}) error {
+
+	// Wadah perantara untuk menangkap NULL dari database
+	var responseStatusNull, durationNull, replayOfNull sql.NullInt64
+	var responseBodyNull, lastErrorNull sql.NullString
+	var deliveredAtNull, createdAtNull sql.NullTime
+
+	dest := []interface{}{
+		&delivery.ID, &delivery.EndpointID, &delivery.EventID, &delivery.EventType, &delivery.Payload, &delivery.Status,
+		&delivery.Attempts, &delivery.NextAttemptAt, &responseStatusNull, &responseBodyNull, &lastErrorNull,
+		&durationNull, &deliveredAtNull, &replayOfNull, &createdAtNull,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	if responseStatusNull.Valid {
+		status := int(responseStatusNull.Int64)
+		delivery.ResponseStatus = &status
+	}
+	delivery.ResponseBody = nullStringPtr(responseBodyNull)
+	delivery.LastError = nullStringPtr(lastErrorNull)
+	if durationNull.Valid {
+		duration := int(durationNull.Int64)
+		delivery.DurationMs = &duration
+	}
+	if deliveredAtNull.Valid {
+		delivery.DeliveredAt = &deliveredAtNull.Time
+	}
+	if replayOfNull.Valid {
+		delivery.ReplayOf = &replayOfNull.Int64
+	}
+	delivery.CreatedAt = createdAtNull.Time
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAddonRoutes mengatur semua endpoint untuk katalog add-on layanan (Express, Pewangi, Hanger).
+func SetupAddonRoutes(router *gin.RouterGroup, addonHandler *handlers.AddonHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/addons
+	addons := router.Group("/addons")
+
+	// Global Auth Middleware: Semua request ke /addons/* wajib bawa JWT valid
+	addons.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	addons.POST("", middleware.RoleMiddleware("owner"), addonHandler.HandleCreateAddon)
+	addons.PUT("/:id", middleware.RoleMiddleware("owner"), addonHandler.HandleUpdateAddon)
+	addons.DELETE("/:id", middleware.RoleMiddleware("owner"), addonHandler.HandleDeleteAddon)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	addons.GET("", middleware.RoleMiddleware("owner", "cashier"), addonHandler.HandleGetAddonList)
+	addons.GET("/:id", middleware.RoleMiddleware("owner", "cashier"), addonHandler.HandleGetAddonDetail)
+}
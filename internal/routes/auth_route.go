@@ -25,6 +25,7 @@ func SetupAuthRoutes(router *gin.RouterGroup, authHandler *handlers.AuthHandler,
 		{
 			protected.POST("/logout", authHandler.Logout)
 			protected.GET("/me", authHandler.GetMe)
+			protected.POST("/switch-outlet", middleware.RoleMiddleware("owner"), authHandler.SwitchOutlet)
 		}
 	}
 }
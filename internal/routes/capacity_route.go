@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupCapacityRoutes mengatur endpoint model kapasitas workshop (mesin, jam operasional, hari libur)
+// serta simulasi checkout dengan estimasi selesai (POST /orders/quote).
+func SetupCapacityRoutes(router *gin.RouterGroup, capacityHandler *handlers.CapacityHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/machines
+	machines := router.Group("/machines")
+
+	// Global Auth Middleware: Semua request ke /machines/* wajib bawa JWT valid
+	machines.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- RESTRICTED ENDPOINTS (Owner, Cashier & Staff: lihat mesin outlet aktif) ---
+	machines.GET("", middleware.RoleMiddleware("owner", "cashier", "staff"), capacityHandler.HandleGetMachineList)
+	machines.GET("/:id", middleware.RoleMiddleware("owner", "cashier", "staff"), capacityHandler.HandleGetMachineDetail)
+
+	// --- OPERATIONAL ENDPOINTS (Owner, Cashier & Staff: tandai mesin rusak/selesai diperbaiki) ---
+	machines.PATCH("/:id/maintenance", middleware.RoleMiddleware("owner", "cashier", "staff"), capacityHandler.HandleSetMachineMaintenance)
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	machines.POST("", middleware.RoleMiddleware("owner"), capacityHandler.HandleCreateMachine)
+	machines.PUT("/:id", middleware.RoleMiddleware("owner"), capacityHandler.HandleUpdateMachine)
+	machines.DELETE("/:id", middleware.RoleMiddleware("owner"), capacityHandler.HandleDeleteMachine)
+
+	// Grouping URL: /api/v1/capacity
+	capacity := router.Group("/capacity")
+
+	// Global Auth Middleware: Semua request ke /capacity/* wajib bawa JWT valid
+	capacity.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- RESTRICTED ENDPOINTS (Owner, Cashier & Staff: jadwal outlet aktif) ---
+	capacity.GET("/operating-hours", middleware.RoleMiddleware("owner", "cashier", "staff"), capacityHandler.HandleGetOperatingHours)
+	capacity.GET("/holidays", middleware.RoleMiddleware("owner", "cashier", "staff"), capacityHandler.HandleGetHolidays)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	capacity.GET("/workload", middleware.RoleMiddleware("owner", "cashier"), capacityHandler.HandleGetWorkload)
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	capacity.PUT("/operating-hours", middleware.RoleMiddleware("owner"), capacityHandler.HandleReplaceOperatingHours)
+	capacity.POST("/holidays", middleware.RoleMiddleware("owner"), capacityHandler.HandleCreateHoliday)
+	capacity.DELETE("/holidays/:id", middleware.RoleMiddleware("owner"), capacityHandler.HandleDeleteHoliday)
+
+	// Grouping URL: /api/v1/orders
+	orders := router.Group("/orders")
+
+	// Global Auth Middleware: Semua request ke /orders/* wajib bawa JWT valid
+	orders.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	orders.POST("/quote", middleware.RoleMiddleware("owner", "cashier"), capacityHandler.HandleQuoteOrder)
+}
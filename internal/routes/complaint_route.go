@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupComplaintRoutes mengatur endpoint komplain pelanggan beserta penyelesaiannya (cuci ulang, kompensasi, voucher).
+func SetupComplaintRoutes(router *gin.RouterGroup, complaintHandler *handlers.ComplaintHandler, authRepo repositories.AuthRepository, idempotencyRepo repositories.IdempotencyRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/complaints
+	complaints := router.Group("/complaints")
+
+	// Global Auth Middleware: Semua request ke /complaints/* wajib bawa JWT valid
+	complaints.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// Idempotency-Key: retry tidak boleh membuat pesanan cuci ulang / kompensasi / voucher dua kali
+	idempotent := middleware.IdempotencyMiddleware(idempotencyRepo, cfg)
+
+	// --- OPERATIONAL ENDPOINTS (Owner, Cashier & Staff workshop boleh melihat & menambah bukti) ---
+	complaints.GET("", middleware.RoleMiddleware("owner", "cashier", "staff"), complaintHandler.HandleGetComplaintList)
+	complaints.GET("/:id", middleware.RoleMiddleware("owner", "cashier", "staff"), complaintHandler.HandleGetComplaintDetail)
+	complaints.POST("/:id/photos", middleware.RoleMiddleware("owner", "cashier", "staff"), complaintHandler.HandleAddComplaintPhotos)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	complaints.POST("", middleware.RoleMiddleware("owner", "cashier"), complaintHandler.HandleCreateComplaint)
+	complaints.PATCH("/:id/status", middleware.RoleMiddleware("owner", "cashier"), complaintHandler.HandleUpdateComplaintStatus)
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner: penyelesaian berdampak ke uang) ---
+	complaints.POST("/:id/resolve", middleware.RoleMiddleware("owner"), idempotent, complaintHandler.HandleResolveComplaint)
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupExpenseRoutes mengatur semua endpoint untuk kategori biaya, biaya operasional outlet, laporan laba, dan laporan karyawan.
+func SetupExpenseRoutes(router *gin.RouterGroup, expenseHandler *handlers.ExpenseHandler, reportHandler *handlers.ReportHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/expense-categories
+	categories := router.Group("/expense-categories")
+	categories.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	categories.GET("", middleware.RoleMiddleware("owner", "cashier"), expenseHandler.HandleGetExpenseCategoryList)
+	categories.GET("/:id", middleware.RoleMiddleware("owner", "cashier"), expenseHandler.HandleGetExpenseCategoryDetail)
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	categories.POST("", middleware.RoleMiddleware("owner"), expenseHandler.HandleCreateExpenseCategory)
+	categories.PUT("/:id", middleware.RoleMiddleware("owner"), expenseHandler.HandleUpdateExpenseCategory)
+	categories.DELETE("/:id", middleware.RoleMiddleware("owner"), expenseHandler.HandleDeleteExpenseCategory)
+
+	// Grouping URL: /api/v1/expenses (kasir hanya boleh mengubah/menghapus biaya yang dicatatnya sendiri)
+	expenses := router.Group("/expenses")
+	expenses.Use(middleware.AuthMiddleware(authRepo, cfg))
+	expenses.POST("", middleware.RoleMiddleware("owner", "cashier"), expenseHandler.HandleCreateExpense)
+	expenses.GET("", middleware.RoleMiddleware("owner", "cashier"), expenseHandler.HandleGetExpenseList)
+	expenses.GET("/:id", middleware.RoleMiddleware("owner", "cashier"), expenseHandler.HandleGetExpenseDetail)
+	expenses.PUT("/:id", middleware.RoleMiddleware("owner", "cashier"), expenseHandler.HandleUpdateExpense)
+	expenses.DELETE("/:id", middleware.RoleMiddleware("owner", "cashier"), expenseHandler.HandleDeleteExpense)
+
+	// Grouping URL: /api/v1/reports (Laporan laba: pendapatan - biaya, & produktivitas karyawan)
+	reports := router.Group("/reports")
+	reports.Use(middleware.AuthMiddleware(authRepo, cfg))
+	reports.GET("/profit", middleware.RoleMiddleware("owner"), reportHandler.HandleGetProfitReport)
+	reports.GET("/employees", middleware.RoleMiddleware("owner"), reportHandler.HandleGetEmployeeReport)
+}
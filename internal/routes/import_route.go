@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupImportRoutes mengatur endpoint import massal master data dari CSV.
+func SetupImportRoutes(router *gin.RouterGroup, importHandler *handlers.ImportHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/imports
+	imports := router.Group("/imports")
+	imports.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- MANAGEMENT ENDPOINTS (Hanya Owner) ---
+	// :entity = categories | services | customers
+	imports.POST("/:entity", middleware.RoleMiddleware("owner"), importHandler.HandleImport)
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupInventoryRoutes mengatur semua endpoint untuk bahan habis pakai, mutasi stok, dan resep pemakaian per layanan.
+func SetupInventoryRoutes(router *gin.RouterGroup, inventoryHandler *handlers.InventoryHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/inventory
+	inventory := router.Group("/inventory")
+
+	// Global Auth Middleware: Semua request ke /inventory/* wajib bawa JWT valid
+	inventory.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- RESTRICTED ENDPOINTS (Owner, Cashier & Staff: cek stok di outlet aktif) ---
+	inventory.GET("/items", middleware.RoleMiddleware("owner", "cashier", "staff"), inventoryHandler.HandleGetItemList)
+	inventory.GET("/items/:id", middleware.RoleMiddleware("owner", "cashier", "staff"), inventoryHandler.HandleGetItemDetail)
+	inventory.GET("/low-stock", middleware.RoleMiddleware("owner", "cashier", "staff"), inventoryHandler.HandleGetLowStock)
+	inventory.GET("/recipes/:service_id", middleware.RoleMiddleware("owner", "cashier", "staff"), inventoryHandler.HandleGetRecipe)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier: barang masuk & riwayat mutasi) ---
+	inventory.POST("/items/:id/stock-in", middleware.RoleMiddleware("owner", "cashier"), inventoryHandler.HandleStockIn)
+	inventory.GET("/movements", middleware.RoleMiddleware("owner", "cashier"), inventoryHandler.HandleGetMovements)
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	inventory.POST("/items", middleware.RoleMiddleware("owner"), inventoryHandler.HandleCreateItem)
+	inventory.PUT("/items/:id", middleware.RoleMiddleware("owner"), inventoryHandler.HandleUpdateItem)
+	inventory.DELETE("/items/:id", middleware.RoleMiddleware("owner"), inventoryHandler.HandleDeleteItem)
+	inventory.POST("/items/:id/adjustments", middleware.RoleMiddleware("owner"), inventoryHandler.HandleAdjustStock)
+	inventory.PUT("/recipes/:service_id", middleware.RoleMiddleware("owner"), inventoryHandler.HandleReplaceRecipe)
+}
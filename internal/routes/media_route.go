@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupMediaRoutes mengatur endpoint upload & akses foto (bukti antar, kondisi item, foto karyawan).
+func SetupMediaRoutes(router *gin.RouterGroup, mediaHandler *handlers.MediaHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// --- PUBLIC ENDPOINT: file lewat URL bertanda tangan (driver local), tanpa JWT ---
+	router.GET("/media/files/*key", mediaHandler.HandleGetSignedFile)
+
+	// Grouping URL: /api/v1/media
+	media := router.Group("/media")
+
+	// Global Auth Middleware: Semua request lain ke /media/* wajib bawa JWT valid
+	media.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- OPERATIONAL ENDPOINTS (Semua peran; hak per pemilik foto dicek di service) ---
+	media.POST("", middleware.RoleMiddleware("owner", "cashier", "staff", "courier"), mediaHandler.HandleUploadMedia)
+	media.GET("", middleware.RoleMiddleware("owner", "cashier", "staff", "courier"), mediaHandler.HandleGetMediaList)
+	media.GET("/:id", middleware.RoleMiddleware("owner", "cashier", "staff", "courier"), mediaHandler.HandleGetMediaDetail)
+	media.DELETE("/:id", middleware.RoleMiddleware("owner", "cashier", "staff", "courier"), mediaHandler.HandleDeleteMedia)
+}
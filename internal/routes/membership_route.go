@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupMembershipRoutes mengatur semua endpoint untuk level member pelanggan.
+func SetupMembershipRoutes(router *gin.RouterGroup, membershipHandler *handlers.MembershipHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/membership-tiers
+	tiers := router.Group("/membership-tiers")
+
+	// Global Auth Middleware: Semua request ke /membership-tiers/* wajib bawa JWT valid
+	tiers.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	tiers.POST("", middleware.RoleMiddleware("owner"), membershipHandler.HandleCreateTier)
+	tiers.PUT("/:id", middleware.RoleMiddleware("owner"), membershipHandler.HandleUpdateTier)
+	tiers.DELETE("/:id", middleware.RoleMiddleware("owner"), membershipHandler.HandleDeleteTier)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	tiers.GET("", middleware.RoleMiddleware("owner", "cashier"), membershipHandler.HandleGetTierList)
+	tiers.GET("/:id", middleware.RoleMiddleware("owner", "cashier"), membershipHandler.HandleGetTierDetail)
+}
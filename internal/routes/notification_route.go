@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupNotificationRoutes mengatur endpoint template pesan pelanggan & pemantauan antrean notifikasi.
+func SetupNotificationRoutes(router *gin.RouterGroup, notificationHandler *handlers.NotificationHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/notification-templates
+	templates := router.Group("/notification-templates")
+
+	// Global Auth Middleware: Semua request ke /notification-templates/* wajib bawa JWT valid
+	templates.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	templates.GET("", middleware.RoleMiddleware("owner"), notificationHandler.HandleGetTemplates)
+	templates.PUT("/:event", middleware.RoleMiddleware("owner"), notificationHandler.HandleUpdateTemplate)
+	templates.POST("/preview", middleware.RoleMiddleware("owner"), notificationHandler.HandlePreviewTemplate)
+
+	// Grouping URL: /api/v1/notifications (Antrean pesan keluar / outbox)
+	notifications := router.Group("/notifications")
+	notifications.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- RESTRICTED ENDPOINTS (Owner & Cashier: memantau pesan, Owner: kirim ulang) ---
+	notifications.GET("", middleware.RoleMiddleware("owner", "cashier"), notificationHandler.HandleGetOutbox)
+	notifications.POST("/:id/retry", middleware.RoleMiddleware("owner"), notificationHandler.HandleRetryNotification)
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupOrderRoutes mengatur endpoint pembuatan pesanan (checkout kasir).
+func SetupOrderRoutes(router *gin.RouterGroup, orderHandler *handlers.OrderHandler, authRepo repositories.AuthRepository, idempotencyRepo repositories.IdempotencyRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/orders
+	orders := router.Group("/orders")
+	orders.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// Idempotency-Key untuk checkout: retry dari Wi-Fi toko tidak boleh membuat nota ganda
+	idempotent := middleware.IdempotencyMiddleware(idempotencyRepo, cfg)
+
+	// --- OPERATIONAL ENDPOINTS (Owner, Cashier, Staff & Courier) ---
+	orders.GET("", middleware.RoleMiddleware("owner", "cashier", "staff", "courier"), orderHandler.HandleGetOrderList)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	orders.POST("", middleware.RoleMiddleware("owner", "cashier"), idempotent, orderHandler.HandleCreateOrder)
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupOutletRoutes mengatur semua endpoint untuk outlet (cabang) & harga layanan per outlet.
+func SetupOutletRoutes(router *gin.RouterGroup, outletHandler *handlers.OutletHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/outlets
+	outlets := router.Group("/outlets")
+
+	// Global Auth Middleware: Semua request ke /outlets/* wajib bawa JWT valid
+	outlets.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	outlets.POST("", middleware.RoleMiddleware("owner"), outletHandler.HandleCreateOutlet)
+	outlets.GET("", middleware.RoleMiddleware("owner"), outletHandler.HandleGetOutletList)
+	outlets.PUT("/:id", middleware.RoleMiddleware("owner"), outletHandler.HandleUpdateOutlet)
+	outlets.DELETE("/:id", middleware.RoleMiddleware("owner"), outletHandler.HandleDeleteOutlet)
+
+	outlets.GET("/:id/service-prices", middleware.RoleMiddleware("owner"), outletHandler.HandleGetServicePrices)
+	outlets.PUT("/:id/service-prices/:serviceId", middleware.RoleMiddleware("owner"), outletHandler.HandleSetServicePrice)
+	outlets.DELETE("/:id/service-prices/:serviceId", middleware.RoleMiddleware("owner"), outletHandler.HandleRemoveServicePrice)
+
+	// --- ALL ROLES (Selain owner hanya outlet tempatnya bertugas) ---
+	outlets.GET("/:id", middleware.RoleMiddleware("owner", "cashier", "staff", "courier"), outletHandler.HandleGetOutletDetail)
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPaymentRoutes mengatur endpoint pelunasan tagihan pesanan.
+func SetupPaymentRoutes(router *gin.RouterGroup, paymentHandler *handlers.PaymentHandler, authRepo repositories.AuthRepository, idempotencyRepo repositories.IdempotencyRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/payments
+	payments := router.Group("/payments")
+	payments.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// Idempotency-Key untuk pelunasan: retry tidak boleh memotong deposit dua kali
+	idempotent := middleware.IdempotencyMiddleware(idempotencyRepo, cfg)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	payments.GET("", middleware.RoleMiddleware("owner", "cashier"), paymentHandler.HandleGetPaymentList)
+	payments.PATCH("/:id", middleware.RoleMiddleware("owner", "cashier"), idempotent, paymentHandler.HandleSettlePayment)
+}
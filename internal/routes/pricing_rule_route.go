@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPricingRuleRoutes mengatur semua endpoint untuk aturan harga layanan (sub-resource dari /services).
+func SetupPricingRuleRoutes(router *gin.RouterGroup, pricingRuleHandler *handlers.PricingRuleHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/services/:id
+	service := router.Group("/services/:id")
+
+	// Global Auth Middleware: Semua request wajib bawa JWT valid
+	service.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	service.GET("/pricing-rules", middleware.RoleMiddleware("owner"), pricingRuleHandler.HandleGetRuleList)
+	service.POST("/pricing-rules", middleware.RoleMiddleware("owner"), pricingRuleHandler.HandleCreateRule)
+	service.PUT("/pricing-rules/:rule_id", middleware.RoleMiddleware("owner"), pricingRuleHandler.HandleUpdateRule)
+	service.DELETE("/pricing-rules/:rule_id", middleware.RoleMiddleware("owner"), pricingRuleHandler.HandleDeleteRule)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	// Simulasi harga sebelum pesanan dibuat
+	service.GET("/price-quote", middleware.RoleMiddleware("owner", "cashier"), pricingRuleHandler.HandleQuotePrice)
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPromotionRoutes mengatur semua endpoint untuk promosi otomatis & kode voucher.
+func SetupPromotionRoutes(router *gin.RouterGroup, promotionHandler *handlers.PromotionHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/promotions
+	promotions := router.Group("/promotions")
+
+	// Global Auth Middleware: Semua request ke /promotions/* wajib bawa JWT valid
+	promotions.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	promotions.POST("", middleware.RoleMiddleware("owner"), promotionHandler.HandleCreatePromotion)
+	promotions.GET("", middleware.RoleMiddleware("owner"), promotionHandler.HandleGetPromotionList)
+	promotions.GET("/:id", middleware.RoleMiddleware("owner"), promotionHandler.HandleGetPromotionDetail)
+	promotions.PUT("/:id", middleware.RoleMiddleware("owner"), promotionHandler.HandleUpdatePromotion)
+	promotions.DELETE("/:id", middleware.RoleMiddleware("owner"), promotionHandler.HandleDeletePromotion)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	promotions.POST("/validate", middleware.RoleMiddleware("owner", "cashier"), promotionHandler.HandleValidatePromotion)
+}
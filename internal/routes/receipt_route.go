@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupReceiptRoutes mengatur endpoint cetak nota pesanan (PDF, thermal ESC/POS, teks).
+func SetupReceiptRoutes(router *gin.RouterGroup, receiptHandler *handlers.ReceiptHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/orders
+	orders := router.Group("/orders")
+
+	// Global Auth Middleware: Semua request ke /orders/* wajib bawa JWT valid
+	orders.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	orders.GET("/:id/receipt", middleware.RoleMiddleware("owner", "cashier"), receiptHandler.HandleGetOrderReceipt)
+}
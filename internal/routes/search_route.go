@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupSearchRoutes mengatur endpoint kotak pencarian global.
+func SetupSearchRoutes(router *gin.RouterGroup, searchHandler *handlers.SearchHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/search
+	search := router.Group("/search")
+	search.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- OPERATIONAL ENDPOINTS (Semua role internal, hasil disaring per role di service) ---
+	search.GET("", middleware.RoleMiddleware("owner", "cashier", "staff", "courier"), searchHandler.HandleSearch)
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupShiftRoutes mengatur semua endpoint untuk shift kasir, kas kecil, dan laporan rekonsiliasi laci kas.
+func SetupShiftRoutes(router *gin.RouterGroup, shiftHandler *handlers.ShiftHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/shifts
+	shifts := router.Group("/shifts")
+
+	// Global Auth Middleware: Semua request ke /shifts/* wajib bawa JWT valid
+	shifts.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier, shift milik user login) ---
+	shifts.POST("/open", middleware.RoleMiddleware("owner", "cashier"), shiftHandler.HandleOpenShift)
+	shifts.GET("/current", middleware.RoleMiddleware("owner", "cashier"), shiftHandler.HandleGetCurrentShift)
+	shifts.POST("/current/payouts", middleware.RoleMiddleware("owner", "cashier"), shiftHandler.HandleCreatePayout)
+	shifts.POST("/current/close", middleware.RoleMiddleware("owner", "cashier"), shiftHandler.HandleCloseShift)
+	shifts.GET("/:id", middleware.RoleMiddleware("owner", "cashier"), shiftHandler.HandleGetShiftDetail)
+
+	// Grouping URL: /api/v1/reports/shifts (Laporan selisih laci kas per shift)
+	reports := router.Group("/reports")
+	reports.Use(middleware.AuthMiddleware(authRepo, cfg))
+	reports.GET("/shifts", middleware.RoleMiddleware("owner"), shiftHandler.HandleGetShiftReport)
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupSLARoutes mengatur endpoint pemantauan SLA pesanan (terlambat & cucian tidak diambil).
+// Evaluasi aturan & pengiriman event berjalan di scheduler server, bukan lewat endpoint.
+func SetupSLARoutes(router *gin.RouterGroup, slaHandler *handlers.SLAHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/orders
+	orders := router.Group("/orders")
+
+	// Global Auth Middleware: Semua request ke /orders/overdue, /orders/unclaimed & /orders/sla-alerts wajib bawa JWT valid
+	orders.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- RESTRICTED ENDPOINTS (Owner, Cashier & Staff: pantau pesanan outlet aktif) ---
+	orders.GET("/overdue", middleware.RoleMiddleware("owner", "cashier", "staff"), slaHandler.HandleGetOverdueOrders)
+	orders.GET("/unclaimed", middleware.RoleMiddleware("owner", "cashier", "staff"), slaHandler.HandleGetUnclaimedOrders)
+
+	// Kotak masuk peringatan SLA di aplikasi (dicatat scheduler bersama webhook)
+	orders.GET("/sla-alerts", middleware.RoleMiddleware("owner", "cashier", "staff"), slaHandler.HandleGetAlerts)
+	orders.PATCH("/sla-alerts/:id/acknowledge", middleware.RoleMiddleware("owner", "cashier", "staff"), slaHandler.HandleAcknowledgeAlert)
+}
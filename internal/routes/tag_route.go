@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupTagRoutes mengatur endpoint tag kantong/item cucian dan scan-to-advance.
+func SetupTagRoutes(router *gin.RouterGroup, tagHandler *handlers.TagHandler, authRepo repositories.AuthRepository, idempotencyRepo repositories.IdempotencyRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/orders/:id/tags
+	orders := router.Group("/orders")
+	orders.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// Idempotency-Key untuk mutasi pesanan: retry tidak boleh membuat tag / memajukan status dua kali
+	idempotent := middleware.IdempotencyMiddleware(idempotencyRepo, cfg)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	orders.POST("/:id/tags", middleware.RoleMiddleware("owner", "cashier"), idempotent, tagHandler.HandleGenerateTags)
+	orders.GET("/:id/tags/labels", middleware.RoleMiddleware("owner", "cashier"), tagHandler.HandleGetTagLabels)
+
+	// --- OPERATIONAL ENDPOINTS (Semua role internal) ---
+	orders.GET("/:id/tags", middleware.RoleMiddleware("owner", "cashier", "staff", "courier"), tagHandler.HandleGetOrderTags)
+
+	// Grouping URL: /api/v1/scan (Dipakai staff workshop dari HP)
+	scan := router.Group("/scan")
+	scan.Use(middleware.AuthMiddleware(authRepo, cfg))
+	scan.POST("/:tag", middleware.RoleMiddleware("owner", "cashier", "staff", "courier"), idempotent, tagHandler.HandleScanTag)
+}
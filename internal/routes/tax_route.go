@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupTaxRoutes mengatur semua endpoint untuk tarif pajak (PPN), service charge, dan laporan pajak.
+func SetupTaxRoutes(router *gin.RouterGroup, taxHandler *handlers.TaxHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/tax-rates
+	taxRates := router.Group("/tax-rates")
+
+	// Global Auth Middleware: Semua request ke /tax-rates/* wajib bawa JWT valid
+	taxRates.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	taxRates.POST("", middleware.RoleMiddleware("owner"), taxHandler.HandleCreateTaxRate)
+	taxRates.PUT("/:id", middleware.RoleMiddleware("owner"), taxHandler.HandleUpdateTaxRate)
+	taxRates.DELETE("/:id", middleware.RoleMiddleware("owner"), taxHandler.HandleDeleteTaxRate)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	taxRates.GET("", middleware.RoleMiddleware("owner", "cashier"), taxHandler.HandleGetTaxRateList)
+	taxRates.GET("/:id", middleware.RoleMiddleware("owner", "cashier"), taxHandler.HandleGetTaxRateDetail)
+	taxRates.POST("/preview", middleware.RoleMiddleware("owner", "cashier"), taxHandler.HandlePreviewOrderTotals)
+
+	// Grouping URL: /api/v1/reports/taxes (Laporan pendapatan bersih vs pajak terkumpul)
+	reports := router.Group("/reports")
+	reports.Use(middleware.AuthMiddleware(authRepo, cfg))
+	reports.GET("/taxes", middleware.RoleMiddleware("owner"), taxHandler.HandleGetTaxReport)
+}
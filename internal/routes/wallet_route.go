@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWalletRoutes mengatur endpoint dompet pelanggan: saldo deposit, poin loyalitas, dan level member.
+func SetupWalletRoutes(router *gin.RouterGroup, walletHandler *handlers.WalletHandler, membershipHandler *handlers.MembershipHandler, authRepo repositories.AuthRepository, idempotencyRepo repositories.IdempotencyRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/customers/:id
+	customer := router.Group("/customers/:id")
+
+	// Global Auth Middleware: Semua request ke /customers/:id/* wajib bawa JWT valid
+	customer.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// Idempotency-Key untuk mutasi saldo/poin: retry dari Wi-Fi toko tidak boleh mencatat dua kali
+	idempotent := middleware.IdempotencyMiddleware(idempotencyRepo, cfg)
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	customer.POST("/wallet/adjustments", middleware.RoleMiddleware("owner"), idempotent, walletHandler.HandleAdjustBalance)
+	customer.POST("/points/adjustments", middleware.RoleMiddleware("owner"), idempotent, walletHandler.HandleAdjustPoints)
+	customer.PUT("/membership", middleware.RoleMiddleware("owner"), membershipHandler.HandleAssignMembership)
+
+	// --- RESTRICTED ENDPOINTS (Hanya Owner & Cashier) ---
+	customer.GET("/wallet", middleware.RoleMiddleware("owner", "cashier"), walletHandler.HandleGetWallet)
+	customer.GET("/wallet/transactions", middleware.RoleMiddleware("owner", "cashier"), walletHandler.HandleGetWalletLedger)
+	customer.GET("/points/transactions", middleware.RoleMiddleware("owner", "cashier"), walletHandler.HandleGetPointLedger)
+	customer.POST("/wallet/top-ups", middleware.RoleMiddleware("owner", "cashier"), idempotent, walletHandler.HandleTopUp)
+}
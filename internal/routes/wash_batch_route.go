@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWashBatchRoutes mengatur endpoint batch mesin (scan item ke washer/dryer) dan laporan utilisasi mesin.
+func SetupWashBatchRoutes(router *gin.RouterGroup, washBatchHandler *handlers.WashBatchHandler, authRepo repositories.AuthRepository, idempotencyRepo repositories.IdempotencyRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/wash-batches (Dipakai staff workshop dari HP)
+	batches := router.Group("/wash-batches")
+
+	// Global Auth Middleware: Semua request ke /wash-batches/* wajib bawa JWT valid
+	batches.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// Idempotency-Key: scan ganda / retry tidak boleh membuat batch atau memajukan status dua kali
+	idempotent := middleware.IdempotencyMiddleware(idempotencyRepo, cfg)
+
+	// --- OPERATIONAL ENDPOINTS (Owner, Cashier & Staff workshop) ---
+	batches.POST("", middleware.RoleMiddleware("owner", "cashier", "staff"), idempotent, washBatchHandler.HandleStartWashBatch)
+	batches.POST("/:id/finish", middleware.RoleMiddleware("owner", "cashier", "staff"), idempotent, washBatchHandler.HandleFinishWashBatch)
+	batches.GET("", middleware.RoleMiddleware("owner", "cashier", "staff"), washBatchHandler.HandleGetWashBatchList)
+	batches.GET("/:id", middleware.RoleMiddleware("owner", "cashier", "staff"), washBatchHandler.HandleGetWashBatchDetail)
+
+	// Grouping URL: /api/v1/reports/machine-utilization (Laporan pemakaian mesin vs jam buka)
+	reports := router.Group("/reports")
+	reports.Use(middleware.AuthMiddleware(authRepo, cfg))
+	reports.GET("/machine-utilization", middleware.RoleMiddleware("owner"), washBatchHandler.HandleGetMachineUtilization)
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/handlers"
+	middleware "laundry-backend/internal/middlewares"
+	"laundry-backend/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWebhookRoutes mengatur endpoint pendaftaran webhook keluar & log pengirimannya.
+func SetupWebhookRoutes(router *gin.RouterGroup, webhookHandler *handlers.WebhookHandler, authRepo repositories.AuthRepository, cfg *config.Config) {
+
+	// Grouping URL: /api/v1/webhooks
+	webhooks := router.Group("/webhooks")
+
+	// Global Auth Middleware: Semua request ke /webhooks/* wajib bawa JWT valid
+	webhooks.Use(middleware.AuthMiddleware(authRepo, cfg))
+
+	// --- STRICT RESTRICTED ENDPOINTS (Hanya Owner) ---
+	webhooks.POST("", middleware.RoleMiddleware("owner"), webhookHandler.HandleCreateWebhook)
+	webhooks.GET("", middleware.RoleMiddleware("owner"), webhookHandler.HandleGetWebhookList)
+	webhooks.GET("/:id", middleware.RoleMiddleware("owner"), webhookHandler.HandleGetWebhookDetail)
+	webhooks.PUT("/:id", middleware.RoleMiddleware("owner"), webhookHandler.HandleUpdateWebhook)
+	webhooks.DELETE("/:id", middleware.RoleMiddleware("owner"), webhookHandler.HandleDeleteWebhook)
+	webhooks.POST("/:id/rotate-secret", middleware.RoleMiddleware("owner"), webhookHandler.HandleRotateWebhookSecret)
+
+	// Log pengiriman & replay
+	webhooks.GET("/:id/deliveries", middleware.RoleMiddleware("owner"), webhookHandler.HandleGetWebhookDeliveries)
+	webhooks.GET("/:id/deliveries/:deliveryId", middleware.RoleMiddleware("owner"), webhookHandler.HandleGetWebhookDeliveryDetail)
+	webhooks.POST("/:id/deliveries/:deliveryId/replay", middleware.RoleMiddleware("owner"), webhookHandler.HandleReplayWebhookDelivery)
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// AddonService defines the contract for business logic related to service add-ons.
+type AddonService interface {
+	CreateAddon(ctx context.Context, req dto.CreateAddonRequest) (*dto.AddonDetailResponse, error)
+	GetAddonList(ctx context.Context, page, perPage int, search, status string, serviceID int64) (*dto.AddonListResponse, error)
+	GetAddonDetail(ctx context.Context, id int64) (*dto.AddonDetailResponse, error)
+
+	// ModifyAddon updates add-on information with validation logic.
+	ModifyAddon(ctx context.Context, targetID int64, req dto.UpdateAddonRequest) (*dto.AddonDetailResponse, error)
+
+	// DeactivateAddon handles soft deletion of an add-on.
+	DeactivateAddon(ctx context.Context, targetID int64) error
+}
+
+type addonService struct {
+	addonRepo   repositories.AddonRepository
+	serviceRepo repositories.ServiceRepository
+}
+
+// NewAddonService creates a new instance of AddonService.
+func NewAddonService(addonRepo repositories.AddonRepository, serviceRepo repositories.ServiceRepository) AddonService {
+	return &addonService{
+		addonRepo:   addonRepo,
+		serviceRepo: serviceRepo,
+	}
+}
+
+// CreateAddon handles the creation of a new add-on.
+func (s *addonService) CreateAddon(ctx context.Context, req dto.CreateAddonRequest) (*dto.AddonDetailResponse, error) {
+
+	// 1. Pengecekan Duplikasi Kode (Harus unik)
+	existingCode, _ := s.addonRepo.FindByCode(ctx, req.Code)
+	if existingCode != nil {
+		return nil, response.ErrDuplicate
+	}
+
+	// 2. Pastikan semua layanan yang dihubungkan memang ada
+	if err := s.validateServiceIDs(ctx, req.ServiceIDs); err != nil {
+		return nil, err
+	}
+
+	// 3. Siapkan Model
+	addonModel := &models.ServiceAddon{
+		Code:             req.Code,
+		AddonName:        req.AddonName,
+		ChargeType:       req.ChargeType,
+		ChargeValue:      req.ChargeValue,
+		ChargeBasis:      req.ChargeBasis,
+		MaxDurationHours: req.MaxDurationHours,
+		IsActive:         true,
+		CreatedAt:        time.Now(),
+	}
+	s.normalizeCharge(addonModel)
+
+	// 4. Insert ke Database (add-on + relasi layanan dalam satu transaksi)
+	if err := s.addonRepo.InsertAddon(ctx, addonModel, req.ServiceIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetAddonDetail(ctx, addonModel.ID)
+}
+
+// GetAddonList fetches a list of add-ons with pagination and filters.
+func (s *addonService) GetAddonList(ctx context.Context, page, perPage int, search, status string, serviceID int64) (*dto.AddonListResponse, error) {
+
+	// 1. Validasi Batas Halaman
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 10
+	}
+	offset := (page - 1) * perPage
+
+	// 2. Panggil Repository
+	addons, totalItems, err := s.addonRepo.FindAll(ctx, perPage, offset, search, status, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Mapping dari Model ke DTO Summary
+	addonResponses := make([]dto.AddonSummaryResponse, 0, len(addons))
+	for _, a := range addons {
+		addonResponses = append(addonResponses, dto.AddonSummaryResponse{
+			ID:               a.ID,
+			Code:             a.Code,
+			AddonName:        a.AddonName,
+			ChargeType:       a.ChargeType,
+			ChargeValue:      a.ChargeValue,
+			ChargeBasis:      a.ChargeBasis,
+			MaxDurationHours: a.MaxDurationHours,
+			IsActive:         a.IsActive,
+		})
+	}
+
+	return &dto.AddonListResponse{
+		Data: addonResponses,
+		Meta: response.NewPageMeta(page, perPage, totalItems),
+	}, nil
+}
+
+// GetAddonDetail retrieves detailed add-on information by ID.
+func (s *addonService) GetAddonDetail(ctx context.Context, id int64) (*dto.AddonDetailResponse, error) {
+
+	addon, err := s.addonRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapToDetailResponse(addon), nil
+}
+
+// ModifyAddon updates add-on data with validation logic.
+func (s *addonService) ModifyAddon(ctx context.Context, targetID int64, req dto.UpdateAddonRequest) (*dto.AddonDetailResponse, error) {
+
+	// 1. Ambil Data Add-on yang Lama
+	existingAddon, err := s.addonRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	addon := existingAddon.ServiceAddon
+
+	// 2. Validasi & Update Kode (Jika dikirim user)
+	if req.Code != nil && *req.Code != addon.Code {
+		duplicateCheck, _ := s.addonRepo.FindByCode(ctx, *req.Code)
+		if duplicateCheck != nil && duplicateCheck.ID != targetID {
+			return nil, response.ErrDuplicate
+		}
+		addon.Code = *req.Code
+	}
+
+	// 3. Update Fields Lainnya (Partial Update)
+	if req.AddonName != nil {
+		addon.AddonName = *req.AddonName
+	}
+	if req.ChargeType != nil {
+		addon.ChargeType = *req.ChargeType
+	}
+	if req.ChargeValue != nil {
+		addon.ChargeValue = *req.ChargeValue
+	}
+	if req.ChargeBasis != nil {
+		addon.ChargeBasis = *req.ChargeBasis
+	}
+	if req.MaxDurationHours != nil {
+		addon.MaxDurationHours = req.MaxDurationHours
+	}
+	if req.IsActive != nil {
+		addon.IsActive = *req.IsActive
+	}
+	s.normalizeCharge(&addon)
+
+	// 4. Validasi daftar layanan baru (Jika dikirim user)
+	if req.ServiceIDs != nil {
+		if err := s.validateServiceIDs(ctx, req.ServiceIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	// 5. Update Waktu (Timestamp)
+	now := time.Now()
+	addon.UpdatedAt = &now
+
+	// 6. Simpan Perubahan ke Database
+	if err := s.addonRepo.UpdateAddon(ctx, &addon, req.ServiceIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetAddonDetail(ctx, targetID)
+}
+
+// DeactivateAddon handles soft deletion of an add-on.
+func (s *addonService) DeactivateAddon(ctx context.Context, targetID int64) error {
+
+	// 1. Cek apakah add-on tersebut ada
+	if _, err := s.addonRepo.FindByID(ctx, targetID); err != nil {
+		return err
+	}
+
+	// 2. Eksekusi Soft Delete
+	return s.addonRepo.DeleteAddon(ctx, targetID)
+}
+
+// --- HELPER FUNCTION ---
+
+// normalizeCharge memastikan kombinasi charge_type & charge_basis masuk akal.
+// Persentase selalu dihitung dari subtotal item, sehingga basis-nya disimpan sebagai per_unit.
+func (s *addonService) normalizeCharge(addon *models.ServiceAddon) {
+	if addon.ChargeBasis == "" || addon.ChargeType == models.AddonChargePercentage {
+		addon.ChargeBasis = models.AddonBasisPerUnit
+	}
+}
+
+func (s *addonService) validateServiceIDs(ctx context.Context, serviceIDs []int64) error {
+	for _, serviceID := range serviceIDs {
+		if _, err := s.serviceRepo.FindByID(ctx, serviceID); err != nil {
+			if errors.Is(err, response.ErrNotFound) {
+				return fmt.Errorf("%w: service %d not found", response.ErrValidation, serviceID)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *addonService) mapToDetailResponse(addon *models.ServiceAddonWithServices) *dto.AddonDetailResponse {
+
+	// 1. Format UpdatedAt menjadi pointer string (jika tidak nil)
+	var updatedAtPtr *string
+	if addon.UpdatedAt != nil {
+		formatted := addon.UpdatedAt.Format("2006-01-02 15:04:05")
+		updatedAtPtr = &formatted
+	}
+
+	return &dto.AddonDetailResponse{
+		ID:               addon.ID,
+		Code:             addon.Code,
+		AddonName:        addon.AddonName,
+		ChargeType:       addon.ChargeType,
+		ChargeValue:      addon.ChargeValue,
+		ChargeBasis:      addon.ChargeBasis,
+		MaxDurationHours: addon.MaxDurationHours,
+		IsActive:         addon.IsActive,
+		ServiceIDs:       addon.ServiceIDs,
+		CreatedAt:        addon.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:        updatedAtPtr,
+	}
+}
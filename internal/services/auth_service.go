@@ -2,11 +2,14 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"laundry-backend/internal/config"
 	"laundry-backend/internal/dto"
 	"laundry-backend/internal/models"
+	"laundry-backend/internal/outlet"
 	"laundry-backend/internal/repositories"
 	"laundry-backend/pkg/response"
 	"laundry-backend/pkg/utils"
@@ -18,21 +21,26 @@ type AuthService interface {
 	RenewUserSession(ctx context.Context, req dto.RefreshTokenRequest) (*dto.RefreshTokenResponse, error)
 	RevokeUserSession(ctx context.Context, refreshToken string, jti string, expiresAt time.Time, userID int64) error
 	GetAccountProfile(ctx context.Context, userID int64) (*dto.AuthMeResponse, error)
+
+	// SwitchOutlet menerbitkan access token baru dengan outlet aktif lain (hanya owner).
+	SwitchOutlet(ctx context.Context, userID int64, req dto.SwitchOutletRequest) (*dto.RefreshTokenResponse, error)
 }
 
 // authService is the concrete implementation combining Auth and User repositories.
 type authService struct {
-	authRepo repositories.AuthRepository
-	userRepo repositories.UserRepository
-	cfg      *config.Config // [BARU] Tambahkan field ini
+	authRepo   repositories.AuthRepository
+	userRepo   repositories.UserRepository
+	outletRepo repositories.OutletRepository
+	cfg        *config.Config // [BARU] Tambahkan field ini
 }
 
 // NewAuthService creates a new instance of AuthService.
-func NewAuthService(authRepo repositories.AuthRepository, userRepo repositories.UserRepository, cfg *config.Config) AuthService {
+func NewAuthService(authRepo repositories.AuthRepository, userRepo repositories.UserRepository, outletRepo repositories.OutletRepository, cfg *config.Config) AuthService {
 	return &authService{
-		authRepo: authRepo,
-		userRepo: userRepo,
-		cfg:      cfg, // [BARU] Simpan config ke struct
+		authRepo:   authRepo,
+		userRepo:   userRepo,
+		outletRepo: outletRepo,
+		cfg:        cfg, // [BARU] Simpan config ke struct
 	}
 }
 
@@ -57,13 +65,19 @@ func (s *authService) AuthenticateUser(ctx context.Context, req dto.LoginRequest
 		return nil, response.ErrAccountInactive
 	}
 
+	// Outlet aktif token = outlet tempat karyawan bertugas (owner tanpa outlet = konsolidasi)
+	outletID, err := s.resolveOutlet(ctx, user, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	// [PERUBAHAN BESAR DISINI]
 	// Kita ambil secret dan expiry dari Config yang sudah di-inject
 	secretKey := []byte(s.cfg.JWT.Secret)
 	tokenExpiry := time.Duration(s.cfg.JWT.ExpiryMin) * time.Minute
 
 	// 4. Generate Access Token (JWT) dengan parameter lengkap
-	accessToken, _, err := utils.GenerateAccessToken(user.ID, user.Username, user.Role, secretKey, tokenExpiry)
+	accessToken, _, err := utils.GenerateAccessToken(user.ID, user.Username, user.Role, outletID, secretKey, tokenExpiry)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +122,7 @@ func (s *authService) AuthenticateUser(ctx context.Context, req dto.LoginRequest
 			FullName: user.FullName,
 			Username: user.Username,
 			Role:     user.Role,
+			OutletID: outletPtr(outletID),
 		},
 	}, nil
 }
@@ -133,22 +148,42 @@ func (s *authService) RenewUserSession(ctx context.Context, req dto.RefreshToken
 		return nil, response.ErrUserNotFound
 	}
 
-	// [PERUBAHAN BESAR DISINI JUGA]
-	secretKey := []byte(s.cfg.JWT.Secret)
-	tokenExpiry := time.Duration(s.cfg.JWT.ExpiryMin) * time.Minute
+	// 4. Tentukan outlet aktif (owner boleh mempertahankan outlet pilihannya, role lain selalu outlet sendiri)
+	outletID, err := s.resolveOutlet(ctx, user, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Generate NEW Access Token & return only the new Access Token
+	return s.issueAccessToken(user, outletID)
+}
 
-	// 4. Generate NEW Access Token
-	newAccessToken, _, err := utils.GenerateAccessToken(user.ID, user.Username, user.Role, secretKey, tokenExpiry)
+// SwitchOutlet issues a new access token scoped to another outlet (0 = all outlets). Owner only.
+// Refresh token tidak berubah; token lama tetap berlaku sampai kedaluwarsa.
+func (s *authService) SwitchOutlet(ctx context.Context, userID int64, req dto.SwitchOutletRequest) (*dto.RefreshTokenResponse, error) {
+
+	// 1. Ambil user & pastikan masih aktif
+	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	if !user.IsActive {
+		return nil, response.ErrAccountInactive
+	}
 
-	// 5. Return only the new Access Token
-	return &dto.RefreshTokenResponse{
-		TokenType:   "Bearer",
-		AccessToken: newAccessToken,
-		ExpiresIn:   int(tokenExpiry.Seconds()), // [FIX] Ambil dari variabel tokenExpiry
-	}, nil
+	// 2. Guard: Hanya owner yang boleh berpindah outlet
+	if user.Role != "owner" {
+		return nil, response.ErrForbidden
+	}
+
+	// 3. Validasi outlet tujuan
+	outletID, err := s.resolveOutlet(ctx, user, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Terbitkan access token baru
+	return s.issueAccessToken(user, outletID)
 }
 
 // RevokeUserSession handles logout by deleting the refresh token and blacklisting the JTI.
@@ -197,8 +232,76 @@ func (s *authService) GetAccountProfile(ctx context.Context, userID int64) (*dto
 		Username:    user.Username,
 		Email:       user.Email, // Added: Now available in DTO
 		Role:        user.Role,
+		OutletID:    user.OutletID,
 		PhoneNumber: user.PhoneNumber, // Added: Now available in DTO
 		IsActive:    user.IsActive,    // Direct Bool (No int conversion needed)
 		CreatedAt:   createdAtStr,
 	}, nil
 }
+
+// --- HELPER FUNCTION ---
+
+// resolveOutlet menentukan outlet aktif untuk token baru.
+// Selain owner selalu outlet tempat bertugas (requested diabaikan) dan wajib ada.
+// Owner memakai requested jika dikirim (0 = semua outlet), jika tidak outlet asalnya (NULL = semua outlet).
+func (s *authService) resolveOutlet(ctx context.Context, user *models.User, requested *int64) (int64, error) {
+
+	// 1. Karyawan outlet: terkunci di outletnya sendiri
+	if user.Role != "owner" {
+		if user.OutletID == nil {
+			return 0, fmt.Errorf("%w: account is not assigned to an outlet", response.ErrForbidden)
+		}
+		return *user.OutletID, nil
+	}
+
+	// 2. Owner tanpa pilihan: outlet asal atau konsolidasi
+	if requested == nil {
+		if user.OutletID == nil {
+			return outlet.All, nil
+		}
+		return *user.OutletID, nil
+	}
+	if *requested == outlet.All {
+		return outlet.All, nil
+	}
+
+	// 3. Owner memilih outlet tertentu: harus ada dan aktif
+	o, err := s.outletRepo.FindByID(ctx, *requested)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return 0, fmt.Errorf("%w: outlet %d not found", response.ErrValidation, *requested)
+		}
+		return 0, err
+	}
+	if !o.IsActive {
+		return 0, fmt.Errorf("%w: outlet %d is not active", response.ErrValidation, o.ID)
+	}
+
+	return o.ID, nil
+}
+
+// issueAccessToken membuat access token baru untuk user dengan outlet aktif outletID.
+func (s *authService) issueAccessToken(user *models.User, outletID int64) (*dto.RefreshTokenResponse, error) {
+	secretKey := []byte(s.cfg.JWT.Secret)
+	tokenExpiry := time.Duration(s.cfg.JWT.ExpiryMin) * time.Minute
+
+	accessToken, _, err := utils.GenerateAccessToken(user.ID, user.Username, user.Role, outletID, secretKey, tokenExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RefreshTokenResponse{
+		TokenType:   "Bearer",
+		AccessToken: accessToken,
+		ExpiresIn:   int(tokenExpiry.Seconds()), // [FIX] Ambil dari variabel tokenExpiry
+		OutletID:    outletPtr(outletID),
+	}, nil
+}
+
+// outletPtr mengubah outlet.All menjadi nil (null di JSON = semua outlet).
+func outletPtr(outletID int64) *int64 {
+	if outletID == outlet.All {
+		return nil
+	}
+	return &outletID
+}
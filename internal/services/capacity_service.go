@@ -0,0 +1,564 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/capacity"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/internal/pricing"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"math"
+	"strings"
+	"time"
+)
+
+// CapacityService defines the contract for workshop capacity planning: machines, opening hours,
+// holidays, and the ETA of new orders scheduled against the outlet backlog.
+type CapacityService interface {
+
+	// Machines
+	CreateMachine(ctx context.Context, req dto.CreateMachineRequest) (*dto.MachineResponse, error)
+	GetMachineList(ctx context.Context, status string) ([]dto.MachineResponse, error)
+	GetMachineDetail(ctx context.Context, id int64) (*dto.MachineResponse, error)
+	ModifyMachine(ctx context.Context, targetID int64, req dto.UpdateMachineRequest) (*dto.MachineResponse, error)
+	DeactivateMachine(ctx context.Context, targetID int64) error
+	SetMachineMaintenance(ctx context.Context, targetID int64, req dto.UpdateMachineMaintenanceRequest) (*dto.MachineResponse, error)
+
+	// Kalender operasional (outlet aktif di context)
+	GetOperatingHours(ctx context.Context) (*dto.OperatingHoursResponse, error)
+	ReplaceOperatingHours(ctx context.Context, req dto.ReplaceOperatingHoursRequest) (*dto.OperatingHoursResponse, error)
+	GetHolidays(ctx context.Context, startDate, endDate string) ([]dto.HolidayResponse, error)
+	CreateHoliday(ctx context.Context, req dto.CreateHolidayRequest) (*dto.HolidayResponse, error)
+	RemoveHoliday(ctx context.Context, targetID int64) error
+
+	// GetWorkload meringkas antrean outlet aktif dan kapan antrean tersebut habis dikerjakan.
+	GetWorkload(ctx context.Context) (*dto.WorkloadResponse, error)
+
+	// QuoteOrder mensimulasikan checkout: total harga (sama dengan /tax-rates/preview) + estimasi selesai.
+	QuoteOrder(ctx context.Context, req dto.OrderTotalsRequest) (*dto.OrderQuoteResponse, error)
+
+	// EstimateReadyAt menjadwalkan keranjang yang sudah dihitung harganya di belakang antrean outlet.
+	// Dipakai oleh QuoteOrder dan pembuatan pesanan agar estimated_ready_at selalu sama dengan yang dijanjikan kasir.
+	EstimateReadyAt(ctx context.Context, outletID int64, now time.Time, quote *pricing.OrderQuote) (*dto.ReadyEstimateResponse, error)
+}
+
+type capacityService struct {
+	capacityRepo       repositories.CapacityRepository
+	pricingRuleService PricingRuleService
+	taxService         TaxService
+	pieceLoadKg        float64
+}
+
+// NewCapacityService creates a new instance of CapacityService.
+func NewCapacityService(capacityRepo repositories.CapacityRepository, pricingRuleService PricingRuleService, taxService TaxService, cfg *config.Config) CapacityService {
+	return &capacityService{
+		capacityRepo:       capacityRepo,
+		pricingRuleService: pricingRuleService,
+		taxService:         taxService,
+		pieceLoadKg:        float64(cfg.CAPACITY.PieceLoadGrams) / 1000,
+	}
+}
+
+// --- MACHINES ---
+
+// CreateMachine handles the registration of a new machine at the active outlet.
+func (s *capacityService) CreateMachine(ctx context.Context, req dto.CreateMachineRequest) (*dto.MachineResponse, error) {
+
+	// 1. Mesin selalu milik satu outlet
+	outletID, err := requireOutlet(ctx, "registering a machine")
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Pengecekan Duplikasi Nama di outlet yang sama
+	name := strings.TrimSpace(req.MachineName)
+	existing, _ := s.capacityRepo.FindMachineByName(ctx, outletID, name)
+	if existing != nil {
+		return nil, response.ErrDuplicate
+	}
+
+	// 3. Simpan
+	machine := &models.Machine{
+		OutletID:     outletID,
+		MachineName:  name,
+		MachineType:  req.MachineType,
+		CapacityKg:   req.CapacityKg,
+		CycleMinutes: req.CycleMinutes,
+		IsActive:     true,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.capacityRepo.InsertMachine(ctx, machine); err != nil {
+		return nil, err
+	}
+
+	return mapMachine(machine), nil
+}
+
+// GetMachineList retrieves the machines of the active outlet.
+func (s *capacityService) GetMachineList(ctx context.Context, status string) ([]dto.MachineResponse, error) {
+
+	machines, err := s.capacityRepo.FindMachines(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	machineResponses := make([]dto.MachineResponse, 0, len(machines))
+	for i := range machines {
+		machineResponses = append(machineResponses, *mapMachine(&machines[i]))
+	}
+
+	return machineResponses, nil
+}
+
+// GetMachineDetail retrieves a machine of the active outlet.
+func (s *capacityService) GetMachineDetail(ctx context.Context, id int64) (*dto.MachineResponse, error) {
+
+	machine, err := s.capacityRepo.FindMachineByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapMachine(machine), nil
+}
+
+// ModifyMachine updates a machine (partial update).
+func (s *capacityService) ModifyMachine(ctx context.Context, targetID int64, req dto.UpdateMachineRequest) (*dto.MachineResponse, error) {
+
+	// 1. Ambil Data Lama
+	machine, err := s.capacityRepo.FindMachineByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi & Update Nama (Jika dikirim user)
+	if req.MachineName != nil {
+		name := strings.TrimSpace(*req.MachineName)
+		if name != machine.MachineName {
+			duplicateCheck, _ := s.capacityRepo.FindMachineByName(ctx, machine.OutletID, name)
+			if duplicateCheck != nil && duplicateCheck.ID != targetID {
+				return nil, response.ErrDuplicate
+			}
+			machine.MachineName = name
+		}
+	}
+
+	// 3. Update Fields Lainnya
+	if req.MachineType != nil {
+		machine.MachineType = *req.MachineType
+	}
+	if req.CapacityKg != nil {
+		machine.CapacityKg = *req.CapacityKg
+	}
+	if req.CycleMinutes != nil {
+		machine.CycleMinutes = *req.CycleMinutes
+	}
+	if req.IsActive != nil {
+		machine.IsActive = *req.IsActive
+	}
+
+	now := time.Now()
+	machine.UpdatedAt = &now
+
+	// 4. Simpan Perubahan
+	if err := s.capacityRepo.UpdateMachine(ctx, machine); err != nil {
+		return nil, err
+	}
+
+	return mapMachine(machine), nil
+}
+
+// DeactivateMachine handles soft deletion of a machine; it stops counting towards capacity.
+func (s *capacityService) DeactivateMachine(ctx context.Context, targetID int64) error {
+	return s.capacityRepo.DeleteMachine(ctx, targetID)
+}
+
+// SetMachineMaintenance flags a machine as under maintenance (or back in service).
+// Mesin maintenance tidak dihitung kapasitas & tidak bisa memulai batch; batch yang sedang berjalan tetap bisa diselesaikan.
+func (s *capacityService) SetMachineMaintenance(ctx context.Context, targetID int64, req dto.UpdateMachineMaintenanceRequest) (*dto.MachineResponse, error) {
+
+	// 1. Ambil Data Lama
+	machine, err := s.capacityRepo.FindMachineByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Terapkan status baru; waktu mulai maintenance tidak berubah jika hanya catatannya yang diperbarui
+	now := time.Now()
+	if *req.UnderMaintenance {
+		if !machine.UnderMaintenance {
+			machine.MaintenanceSince = &now
+		}
+		machine.MaintenanceNote = trimNote(req.Note)
+	} else {
+		machine.MaintenanceSince = nil
+		machine.MaintenanceNote = nil
+	}
+	machine.UnderMaintenance = *req.UnderMaintenance
+	machine.UpdatedAt = &now
+
+	// 3. Simpan Perubahan
+	if err := s.capacityRepo.UpdateMaintenance(ctx, machine); err != nil {
+		return nil, err
+	}
+
+	return mapMachine(machine), nil
+}
+
+// --- OPERATING CALENDAR ---
+
+// GetOperatingHours retrieves the weekly opening hours of the active outlet.
+func (s *capacityService) GetOperatingHours(ctx context.Context) (*dto.OperatingHoursResponse, error) {
+
+	outletID, err := requireOutlet(ctx, "viewing operating hours")
+	if err != nil {
+		return nil, err
+	}
+
+	hours, err := s.capacityRepo.FindOperatingHours(ctx, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapOperatingHours(outletID, hours), nil
+}
+
+// ReplaceOperatingHours replaces the weekly opening hours of the active outlet.
+// Jadwal harus berisi ketujuh hari (boleh ada yang tutup), atau kosong untuk kembali ke buka 24 jam.
+func (s *capacityService) ReplaceOperatingHours(ctx context.Context, req dto.ReplaceOperatingHoursRequest) (*dto.OperatingHoursResponse, error) {
+
+	// 1. Jadwal selalu milik satu outlet
+	outletID, err := requireOutlet(ctx, "changing operating hours")
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi: tujuh hari unik, jam tutup setelah jam buka, minimal satu hari buka
+	if len(req.Days) != 0 && len(req.Days) != 7 {
+		return nil, fmt.Errorf("%w: send all 7 days (or an empty list to reset to 24 hours)", response.ErrValidation)
+	}
+
+	seen := make(map[int]bool, len(req.Days))
+	hours := make([]models.OperatingHour, 0, len(req.Days))
+	openDays := 0
+	for _, day := range req.Days {
+		weekday := *day.Weekday
+		if seen[weekday] {
+			return nil, fmt.Errorf("%w: weekday %d is listed more than once", response.ErrValidation, weekday)
+		}
+		seen[weekday] = true
+
+		hour := models.OperatingHour{OutletID: outletID, Weekday: weekday, OpenTime: "00:00:00", CloseTime: "00:00:00", IsClosed: day.IsClosed}
+		if !day.IsClosed {
+			if day.OpenTime == "" || day.CloseTime == "" {
+				return nil, fmt.Errorf("%w: open_time and close_time are required for weekday %d", response.ErrValidation, weekday)
+			}
+			open, _ := capacity.ParseClock(day.OpenTime)
+			closeAt, _ := capacity.ParseClock(day.CloseTime)
+			if closeAt <= open {
+				return nil, fmt.Errorf("%w: close_time must be after open_time for weekday %d", response.ErrValidation, weekday)
+			}
+			hour.OpenTime = day.OpenTime + ":00"
+			hour.CloseTime = day.CloseTime + ":00"
+			openDays++
+		}
+		hours = append(hours, hour)
+	}
+	if len(hours) > 0 && openDays == 0 {
+		return nil, fmt.Errorf("%w: the outlet must be open on at least one day", response.ErrValidation)
+	}
+
+	// 3. Simpan jadwal baru (menggantikan jadwal lama)
+	if err := s.capacityRepo.ReplaceOperatingHours(ctx, outletID, hours); err != nil {
+		return nil, err
+	}
+
+	return s.GetOperatingHours(ctx)
+}
+
+// GetHolidays retrieves the holidays that apply to the active outlet.
+func (s *capacityService) GetHolidays(ctx context.Context, startDate, endDate string) ([]dto.HolidayResponse, error) {
+
+	// 1. Validasi filter tanggal (opsional)
+	if startDate != "" {
+		if _, err := time.Parse("2006-01-02", startDate); err != nil {
+			return nil, fmt.Errorf("%w: start_date must use format YYYY-MM-DD", response.ErrValidation)
+		}
+	}
+	if endDate != "" {
+		if _, err := time.Parse("2006-01-02", endDate); err != nil {
+			return nil, fmt.Errorf("%w: end_date must use format YYYY-MM-DD", response.ErrValidation)
+		}
+	}
+
+	// 2. Call Repository
+	holidays, err := s.capacityRepo.FindHolidays(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	holidayResponses := make([]dto.HolidayResponse, 0, len(holidays))
+	for i := range holidays {
+		holidayResponses = append(holidayResponses, *mapHoliday(&holidays[i]))
+	}
+
+	return holidayResponses, nil
+}
+
+// CreateHoliday registers a holiday for the active outlet, or for every outlet (all_outlets).
+func (s *capacityService) CreateHoliday(ctx context.Context, req dto.CreateHolidayRequest) (*dto.HolidayResponse, error) {
+
+	// 1. Tentukan cakupan: semua outlet, atau outlet aktif
+	var outletID *int64
+	if !req.AllOutlets {
+		id, err := requireOutlet(ctx, "adding an outlet holiday (or set all_outlets)")
+		if err != nil {
+			return nil, err
+		}
+		outletID = &id
+	}
+
+	holidayDate, err := time.ParseInLocation("2006-01-02", req.HolidayDate, config.Location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: holiday_date must use format YYYY-MM-DD", response.ErrValidation)
+	}
+
+	// 2. Pengecekan Duplikasi: tanggal yang sama dengan cakupan yang sama
+	existing, err := s.capacityRepo.FindHolidays(ctx, req.HolidayDate, req.HolidayDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range existing {
+		if (h.OutletID == nil && outletID == nil) || (h.OutletID != nil && outletID != nil && *h.OutletID == *outletID) {
+			return nil, response.ErrDuplicate
+		}
+	}
+
+	// 3. Simpan
+	holiday := &models.OutletHoliday{
+		OutletID:    outletID,
+		HolidayDate: holidayDate,
+		Description: strings.TrimSpace(req.Description),
+		CreatedAt:   time.Now(),
+	}
+	if err := s.capacityRepo.InsertHoliday(ctx, holiday); err != nil {
+		return nil, err
+	}
+
+	return mapHoliday(holiday), nil
+}
+
+// RemoveHoliday deletes a holiday visible to the active outlet.
+func (s *capacityService) RemoveHoliday(ctx context.Context, targetID int64) error {
+
+	// 1. Pastikan libur terlihat dari outlet aktif (libur outlet lain tidak boleh dihapus)
+	if _, err := s.capacityRepo.FindHolidayByID(ctx, targetID); err != nil {
+		return err
+	}
+
+	// 2. Hapus
+	return s.capacityRepo.DeleteHoliday(ctx, targetID)
+}
+
+// --- WORKLOAD & ETA ---
+
+// GetWorkload summarises the queued workload of the active outlet.
+func (s *capacityService) GetWorkload(ctx context.Context) (*dto.WorkloadResponse, error) {
+
+	outletID, err := requireOutlet(ctx, "viewing the workload")
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Antrean = beban pesanan baru nol; waktu selesai mesinnya adalah waktu antrean habis
+	estimate, backlog, err := s.schedule(ctx, outletID, time.Now(), 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.WorkloadResponse{
+		OutletID:            outletID,
+		QueuedOrders:        backlog.OrderCount,
+		BacklogKg:           math.Round(backlog.LoadKg*100) / 100,
+		ThroughputKgPerHour: estimate.ThroughputKgPerHour,
+		BacklogHours:        estimate.WorkHours,
+		BacklogClearsAt:     formatTimePtr(estimate.ProcessingDoneAt),
+	}, nil
+}
+
+// QuoteOrder previews the checkout totals and the realistic ready time of a cart at the active outlet.
+func (s *capacityService) QuoteOrder(ctx context.Context, req dto.OrderTotalsRequest) (*dto.OrderQuoteResponse, error) {
+
+	// 1. Estimasi selalu untuk satu outlet (antrean & jam buka berbeda per outlet)
+	outletID, err := requireOutlet(ctx, "quoting an order")
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Total harga, diskon, dan pajak (kalkulator yang sama dengan pesanan)
+	totals, err := s.taxService.PreviewOrderTotals(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Durasi & beban keranjang, lalu jadwalkan di belakang antrean
+	quote, err := s.pricingRuleService.QuoteItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	estimate, err := s.EstimateReadyAt(ctx, outletID, time.Now(), quote)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OrderQuoteResponse{
+		Totals:   *totals,
+		Estimate: *estimate,
+	}, nil
+}
+
+// EstimateReadyAt schedules a priced cart behind the backlog of an outlet.
+func (s *capacityService) EstimateReadyAt(ctx context.Context, outletID int64, now time.Time, quote *pricing.OrderQuote) (*dto.ReadyEstimateResponse, error) {
+
+	// 1. Beban keranjang: item kg memakai beratnya, item pcs memakai beban setara per pcs
+	loadKg := 0.0
+	for _, line := range quote.Lines {
+		if line.Unit == "kg" {
+			loadKg += line.ActualQuantity
+		} else {
+			loadKg += line.ActualQuantity * s.pieceLoadKg
+		}
+	}
+	loadKg = math.Round(loadKg*100) / 100
+
+	// 2. Jadwalkan di belakang antrean outlet
+	estimate, backlog, err := s.schedule(ctx, outletID, now, quote.DurationHours, loadKg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ReadyEstimateResponse{
+		DurationHours:       quote.DurationHours,
+		LoadKg:              loadKg,
+		QueuedOrders:        backlog.OrderCount,
+		BacklogKg:           math.Round(backlog.LoadKg*100) / 100,
+		ThroughputKgPerHour: estimate.ThroughputKgPerHour,
+		WorkHours:           estimate.WorkHours,
+		MinimumReadyAt:      estimate.MinimumReadyAt.Format("2006-01-02 15:04:05"),
+		ProcessingDoneAt:    formatTimePtr(estimate.ProcessingDoneAt),
+		EstimatedReadyAt:    estimate.ReadyAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// --- HELPER FUNCTION ---
+
+// schedule memuat model kapasitas outlet (mesin, kalender, antrean) lalu menjalankan kalkulator ETA.
+func (s *capacityService) schedule(ctx context.Context, outletID int64, now time.Time, durationHours int, loadKg float64) (*capacity.Estimate, *models.WorkshopBacklog, error) {
+
+	// 1. Muat model kapasitas outlet
+	machines, err := s.capacityRepo.FindActiveMachinesByOutlet(ctx, outletID)
+	if err != nil {
+		return nil, nil, err
+	}
+	hours, err := s.capacityRepo.FindOperatingHours(ctx, outletID)
+	if err != nil {
+		return nil, nil, err
+	}
+	holidays, err := s.capacityRepo.FindHolidayDates(ctx, outletID, now.In(config.Location))
+	if err != nil {
+		return nil, nil, err
+	}
+	backlog, err := s.capacityRepo.FindBacklog(ctx, outletID, s.pieceLoadKg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Jam operasional & hari libur dibaca di zona bisnis (WIB), bukan zona server.
+	// Jam operasional tersimpan yang tidak valid adalah salah pengaturan outlet, bukan error server.
+	calendar, err := capacity.NewCalendar(hours, holidays, config.Location)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: outlet operating hours are invalid: %w", response.ErrValidation, err)
+	}
+
+	// 2. Hitung estimasi
+	estimate, err := capacity.EstimateReadyAt(capacity.Input{
+		Now:           now,
+		DurationHours: durationHours,
+		BacklogKg:     backlog.LoadKg,
+		LoadKg:        loadKg,
+		Machines:      machines,
+		Calendar:      calendar,
+	})
+	if err != nil {
+		if errors.Is(err, capacity.ErrNoOpeningHours) {
+			return nil, nil, fmt.Errorf("%w: %w", response.ErrValidation, err)
+		}
+		return nil, nil, err
+	}
+
+	return estimate, backlog, nil
+}
+
+// requireOutlet memastikan request berjalan di satu outlet (owner mode konsolidasi harus memilih outlet dulu).
+func requireOutlet(ctx context.Context, action string) (int64, error) {
+	outletID := outlet.FromContext(ctx)
+	if outletID == outlet.All {
+		return 0, fmt.Errorf("%w: switch to an outlet before %s", response.ErrValidation, action)
+	}
+	return outletID, nil
+}
+
+func mapMachine(m *models.Machine) *dto.MachineResponse {
+	return &dto.MachineResponse{
+		ID:           m.ID,
+		OutletID:     m.OutletID,
+		MachineName:  m.MachineName,
+		MachineType:  m.MachineType,
+		CapacityKg:   m.CapacityKg,
+		CycleMinutes: m.CycleMinutes,
+		KgPerHour:    math.Round(m.CapacityKg*60/float64(m.CycleMinutes)*100) / 100,
+		IsActive:     m.IsActive,
+		CreatedAt:    m.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    formatTimePtr(m.UpdatedAt),
+
+		UnderMaintenance: m.UnderMaintenance,
+		MaintenanceNote:  m.MaintenanceNote,
+		MaintenanceSince: formatTimePtr(m.MaintenanceSince),
+	}
+}
+
+func mapOperatingHours(outletID int64, hours []models.OperatingHour) *dto.OperatingHoursResponse {
+	res := &dto.OperatingHoursResponse{
+		OutletID:   outletID,
+		AlwaysOpen: len(hours) == 0,
+		Days:       make([]dto.OperatingHourResponse, 0, len(hours)),
+	}
+	for _, h := range hours {
+		day := dto.OperatingHourResponse{
+			Weekday:  h.Weekday,
+			DayName:  time.Weekday(h.Weekday).String(),
+			IsClosed: h.IsClosed,
+		}
+		if !h.IsClosed {
+			openTime, closeTime := h.OpenTime[:5], h.CloseTime[:5] // HH:MM:SS -> HH:MM
+			day.OpenTime = &openTime
+			day.CloseTime = &closeTime
+		}
+		res.Days = append(res.Days, day)
+	}
+	return res
+}
+
+func mapHoliday(h *models.OutletHoliday) *dto.HolidayResponse {
+	return &dto.HolidayResponse{
+		ID:          h.ID,
+		OutletID:    h.OutletID,
+		HolidayDate: h.HolidayDate.Format("2006-01-02"),
+		Description: h.Description,
+		CreatedAt:   h.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
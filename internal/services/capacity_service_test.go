@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/capacity"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/pricing"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+)
+
+// fakeCapacityRepo hanya mengisi query yang dipakai schedule.
+type fakeCapacityRepo struct {
+	repositories.CapacityRepository
+	hours []models.OperatingHour
+}
+
+func (f *fakeCapacityRepo) FindActiveMachinesByOutlet(ctx context.Context, outletID int64) ([]models.Machine, error) {
+	return nil, nil
+}
+
+func (f *fakeCapacityRepo) FindOperatingHours(ctx context.Context, outletID int64) ([]models.OperatingHour, error) {
+	return f.hours, nil
+}
+
+func (f *fakeCapacityRepo) FindHolidayDates(ctx context.Context, outletID int64, from time.Time) ([]time.Time, error) {
+	return nil, nil
+}
+
+func (f *fakeCapacityRepo) FindBacklog(ctx context.Context, outletID int64, pieceLoadKg float64) (*models.WorkshopBacklog, error) {
+	return &models.WorkshopBacklog{}, nil
+}
+
+func TestEstimateReadyAtMapsCalendarErrorsToValidation(t *testing.T) {
+
+	allClosed := make([]models.OperatingHour, 0, 7)
+	for d := 0; d <= 6; d++ {
+		allClosed = append(allClosed, models.OperatingHour{Weekday: d, IsClosed: true})
+	}
+
+	tests := []struct {
+		name       string
+		hours      []models.OperatingHour
+		wantNoOpen bool
+	}{
+		{name: "outlet closed every day", hours: allClosed, wantNoOpen: true},
+		{name: "stored hours are invalid", hours: []models.OperatingHour{{Weekday: 1, OpenTime: "17:00", CloseTime: "08:00"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewCapacityService(&fakeCapacityRepo{hours: tt.hours}, nil, nil, &config.Config{CAPACITY: config.CapacityConfig{PieceLoadGrams: 200}})
+			quote := &pricing.OrderQuote{DurationHours: 24}
+
+			_, err := svc.EstimateReadyAt(context.Background(), 1, time.Now(), quote)
+			if !errors.Is(err, response.ErrValidation) {
+				t.Fatalf("error = %v, want ErrValidation (400 instead of 500)", err)
+			}
+			if tt.wantNoOpen && !errors.Is(err, capacity.ErrNoOpeningHours) {
+				t.Fatalf("error = %v, want it to wrap ErrNoOpeningHours", err)
+			}
+		})
+	}
+}
+
+func TestEstimateReadyAtUsesBusinessTimeZone(t *testing.T) {
+
+	// Server berjalan di UTC; jam operasional tetap dibaca sebagai jam WIB
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = time.UTC
+
+	open := make([]models.OperatingHour, 0, 7)
+	for d := 0; d <= 6; d++ {
+		open = append(open, models.OperatingHour{Weekday: d, OpenTime: "08:00", CloseTime: "17:00"})
+	}
+	svc := NewCapacityService(&fakeCapacityRepo{hours: open}, nil, nil, &config.Config{CAPACITY: config.CapacityConfig{PieceLoadGrams: 200}})
+
+	// Senin 07:00 WIB (00:00 UTC) + 2 jam = 09:00 WIB, sudah di dalam jam buka
+	now := time.Date(2026, 1, 5, 7, 0, 0, 0, config.Location)
+	res, err := svc.EstimateReadyAt(context.Background(), 1, now, &pricing.OrderQuote{DurationHours: 2})
+	if err != nil {
+		t.Fatalf("EstimateReadyAt: %v", err)
+	}
+	if res.EstimatedReadyAt != "2026-01-05 09:00:00" {
+		t.Fatalf("estimated_ready_at = %s, want 2026-01-05 09:00:00 (WIB)", res.EstimatedReadyAt)
+	}
+}
@@ -3,8 +3,10 @@ package services
 import (
 	"context"
 	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
 	"laundry-backend/internal/models"
 	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/listquery"
 	"laundry-backend/pkg/response"
 	"time"
 )
@@ -12,14 +14,17 @@ import (
 // CategoryService defines the contract for business logic related to service categories.
 type CategoryService interface {
 	CreateCategory(ctx context.Context, req dto.CreateCategoryRequest) (*dto.CategoryDetailResponse, error)
-	GetCategoryList(ctx context.Context, page, perPage int, search, status, sortBy, sortOrder string) (*dto.CategoryListResponse, error)
+	GetCategoryList(ctx context.Context, params listquery.Params) (*dto.CategoryListResponse, error)
+	ExportCategoryList(ctx context.Context, params listquery.Params, w export.Writer) error
 	GetCategoryDetail(ctx context.Context, id int64) (*dto.CategoryDetailResponse, error)
 
 	// ModifyCategoryData updates category information with validation logic.
-	ModifyCategory(ctx context.Context, targetID int64, req dto.UpdateCategoryRequest) (*dto.CategoryDetailResponse, error)
+	// expectedVersion berasal dari header If-Match (nil jika tidak dikirim).
+	ModifyCategory(ctx context.Context, targetID int64, req dto.UpdateCategoryRequest, expectedVersion *int64) (*dto.CategoryDetailResponse, error)
 
 	// DeleteCategory handles soft deletion of a category.
-	DeactivateCategory(ctx context.Context, targetID int64) error
+	// expectedVersion berasal dari header If-Match (nil jika tidak dikirim).
+	DeactivateCategory(ctx context.Context, targetID int64, expectedVersion *int64) error
 }
 
 type categoryService struct {
@@ -46,6 +51,7 @@ func (s *categoryService) CreateCategory(ctx context.Context, req dto.CreateCate
 		Description:  req.Description,
 		IsActive:     true, // Default aktif saat pertama dibuat
 		CreatedAt:    time.Now(),
+		Version:      1, // Sama dengan DEFAULT kolom version
 		// UpdatedAt tidak perlu ditulis nil, karena otomatis nil bawaan Go
 	}
 
@@ -61,29 +67,15 @@ func (s *categoryService) CreateCategory(ctx context.Context, req dto.CreateCate
 }
 
 // GetCategoryList fetches a list of categories with pagination, filters, and sorting.
-func (s *categoryService) GetCategoryList(ctx context.Context, page, perPage int, search, status, sortBy, sortOrder string) (*dto.CategoryListResponse, error) {
+func (s *categoryService) GetCategoryList(ctx context.Context, params listquery.Params) (*dto.CategoryListResponse, error) {
 
-	// 1. Validasi Batas Halaman (Safety check)
-	if page < 1 {
-		page = 1
-	}
-	if perPage < 1 {
-		perPage = 10
-	}
-
-	// --- [PERBAIKAN KUNCI 1: Konversi Page ke Offset] ---
-	// Repository kita (SQL) butuhnya 'limit' dan 'offset', bukan 'page'
-	limit := perPage
-	offset := (page - 1) * perPage
-
-	// 2. Panggil Repository (Gunakan nama fungsi yang benar)
-	// --- [PERBAIKAN KUNCI 2: FetchCategories -> FindAll] ---
-	categories, totalItems, err := s.categoryRepo.FindAll(ctx, limit, offset, search, status, sortBy, sortOrder)
+	// 1. Panggil Repository (batas halaman, filter & sorting divalidasi oleh listquery)
+	categories, page, err := s.categoryRepo.FindAll(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	// 3. Mapping dari Model (Database) ke DTO Summary (Respon JSON)
+	// 2. Mapping dari Model (Database) ke DTO Summary (Respon JSON)
 	var categoryResponses []dto.CategorySummaryResponse
 	for _, c := range categories {
 		categoryResponses = append(categoryResponses, dto.CategorySummaryResponse{
@@ -99,25 +91,31 @@ func (s *categoryService) GetCategoryList(ctx context.Context, page, perPage int
 		categoryResponses = []dto.CategorySummaryResponse{}
 	}
 
-	// 4. Hitung Total Halaman (Total Pages)
-	// Using integer math to avoid float precision issues
-	var totalPages int
-	if perPage > 0 {
-		totalPages = int((totalItems + int64(perPage) - 1) / int64(perPage))
-	}
-
-	// 5. Kembalikan Response Akhir beserta Meta Data
+	// 3. Kembalikan Response Akhir beserta Meta Data
 	return &dto.CategoryListResponse{
 		Data: categoryResponses,
-		Meta: response.MetaData{
-			CurrentPage: page,
-			PerPage:     perPage,
-			TotalItems:  totalItems,
-			TotalPages:  totalPages,
-		},
+		Meta: page.Meta(),
 	}, nil
 }
 
+// categoryExportColumns adalah judul kolom export GET /categories
+var categoryExportColumns = []export.Column{
+	{ID: "ID", EN: "ID"},
+	{ID: "Nama Kategori", EN: "Category Name"},
+	{ID: "Deskripsi", EN: "Description"},
+	{ID: "Aktif", EN: "Active"},
+	{ID: "Dibuat Pada", EN: "Created At"},
+}
+
+// ExportCategoryList streams every category matching the list filters (without pagination) into w.
+func (s *categoryService) ExportCategoryList(ctx context.Context, params listquery.Params, w export.Writer) error {
+	w.SetColumns(categoryExportColumns)
+
+	return s.categoryRepo.StreamAll(ctx, params, func(c *models.ServiceCategory) error {
+		return w.WriteRow(c.ID, c.CategoryName, c.Description, c.IsActive, c.CreatedAt)
+	})
+}
+
 // GetCategoryDetail retrieves detailed category information by ID.
 func (s *categoryService) GetCategoryDetail(ctx context.Context, id int64) (*dto.CategoryDetailResponse, error) {
 
@@ -134,7 +132,7 @@ func (s *categoryService) GetCategoryDetail(ctx context.Context, id int64) (*dto
 }
 
 // ModifyCategoryData updates category profile with validation logic.
-func (s *categoryService) ModifyCategory(ctx context.Context, targetID int64, req dto.UpdateCategoryRequest) (*dto.CategoryDetailResponse, error) {
+func (s *categoryService) ModifyCategory(ctx context.Context, targetID int64, req dto.UpdateCategoryRequest, expectedVersion *int64) (*dto.CategoryDetailResponse, error) {
 
 	// 1. Ambil Data Kategori yang Lama (Gunakan nama fungsi VIP-7: FindByID)
 	existingCategory, err := s.categoryRepo.FindByID(ctx, targetID)
@@ -142,6 +140,11 @@ func (s *categoryService) ModifyCategory(ctx context.Context, targetID int64, re
 		return nil, err
 	}
 
+	// Tolak jika kategori sudah diubah orang lain sejak klien membacanya (If-Match)
+	if err := checkExpectedVersion(existingCategory.Version, expectedVersion); err != nil {
+		return nil, err
+	}
+
 	// 2. Update Fields (Partial Update Logic)
 
 	// Validasi Nama: Cek apakah user mengirim data nama (tidak nil)
@@ -185,19 +188,26 @@ func (s *categoryService) ModifyCategory(ctx context.Context, targetID int64, re
 }
 
 // DeleteCategory handles soft deletion of a category.
-func (s *categoryService) DeactivateCategory(ctx context.Context, targetID int64) error {
+func (s *categoryService) DeactivateCategory(ctx context.Context, targetID int64, expectedVersion *int64) error {
 
 	// 1. Cek apakah kategori tersebut ada di database
 	// [FIX] Gunakan FindByID (sesuai nama fungsi di Repository baru)
-	_, err := s.categoryRepo.FindByID(ctx, targetID)
+	existingCategory, err := s.categoryRepo.FindByID(ctx, targetID)
 	if err != nil {
 		// Jika tidak ditemukan, Repository otomatis mengirim response.ErrNotFound
 		return err
 	}
 
-	// 2. Eksekusi Soft Delete (Mengubah is_active menjadi false)
-	// [FIX] Gunakan Delete (sesuai nama fungsi di Repository baru)
-	err = s.categoryRepo.DeleteCategory(ctx, targetID)
+	// 2. Kategori yang sudah nonaktif dianggap tidak ada; tolak jika sudah diubah orang lain (If-Match)
+	if !existingCategory.IsActive {
+		return response.ErrNotFound
+	}
+	if err := checkExpectedVersion(existingCategory.Version, expectedVersion); err != nil {
+		return err
+	}
+
+	// 3. Eksekusi Soft Delete (Mengubah is_active menjadi false & menaikkan version)
+	err = s.categoryRepo.DeleteCategory(ctx, targetID, existingCategory.Version)
 	if err != nil {
 		return err
 	}
@@ -221,6 +231,7 @@ func (s *categoryService) mapToDetailResponse(category *models.ServiceCategory)
 		CategoryName: category.CategoryName,
 		Description:  category.Description,
 		IsActive:     category.IsActive,
+		Version:      category.Version,
 		CreatedAt:    createdAtStr,
 		UpdatedAt:    updatedAtPtr,
 	}
@@ -0,0 +1,585 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Pengaturan penyelesaian komplain
+const (
+	complaintVoucherValidDays = 30    // Masa berlaku default voucher kompensasi
+	complaintVoucherPrefix    = "KMP" // Awalan kode voucher, cth: KMP7K3M9QXA
+	complaintVoucherLength    = 8
+	complaintVoucherAttempt   = 3
+)
+
+// complaintTransitions memetakan status komplain ke status tujuan yang diizinkan (investigating boleh dilewati).
+var complaintTransitions = map[string][]string{
+	models.ComplaintOpen:          {models.ComplaintInvestigating, models.ComplaintResolved, models.ComplaintRejected},
+	models.ComplaintInvestigating: {models.ComplaintResolved, models.ComplaintRejected},
+}
+
+// ComplaintService defines the contract for customer complaints about an order (noda, rusak, hilang, cuci ulang)
+// and their resolutions: zero-cost rework order, compensation payout, or a single-use voucher.
+type ComplaintService interface {
+	CreateComplaint(ctx context.Context, req dto.CreateComplaintRequest, actorID int64) (*dto.ComplaintResponse, error)
+	GetComplaints(ctx context.Context, params listquery.Params) (*dto.ComplaintListResponse, error)
+	GetComplaintDetail(ctx context.Context, id int64) (*dto.ComplaintResponse, error)
+	AddPhotos(ctx context.Context, id int64, req dto.AddComplaintPhotosRequest, actorID int64) (*dto.ComplaintResponse, error)
+
+	// UpdateStatus memindahkan komplain ke 'investigating' atau menolaknya ('rejected').
+	UpdateStatus(ctx context.Context, id int64, req dto.UpdateComplaintStatusRequest, actorID int64) (*dto.ComplaintResponse, error)
+
+	// ResolveComplaint menutup komplain sekaligus mengeksekusi penyelesaiannya dalam satu transaksi.
+	ResolveComplaint(ctx context.Context, id int64, req dto.ResolveComplaintRequest, actorID int64, actorRole string) (*dto.ComplaintResponse, error)
+}
+
+type complaintService struct {
+	complaintRepo   repositories.ComplaintRepository
+	orderStatusRepo repositories.OrderStatusRepository
+	expenseRepo     repositories.ExpenseRepository
+	promotionRepo   repositories.PromotionRepository
+	walletService   WalletService
+}
+
+// NewComplaintService creates a new instance of ComplaintService.
+func NewComplaintService(complaintRepo repositories.ComplaintRepository, orderStatusRepo repositories.OrderStatusRepository, expenseRepo repositories.ExpenseRepository, promotionRepo repositories.PromotionRepository, walletService WalletService) ComplaintService {
+	return &complaintService{
+		complaintRepo:   complaintRepo,
+		orderStatusRepo: orderStatusRepo,
+		expenseRepo:     expenseRepo,
+		promotionRepo:   promotionRepo,
+		walletService:   walletService,
+	}
+}
+
+// CreateComplaint records a complaint against an order of the active outlet (optionally one of its items).
+func (s *complaintService) CreateComplaint(ctx context.Context, req dto.CreateComplaintRequest, actorID int64) (*dto.ComplaintResponse, error) {
+
+	// 1. Pesanan harus ada di outlet aktif & tidak dibatalkan
+	order, err := s.complaintRepo.FindComplaintOrder(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.StatusInternal == models.OrderStatusCancelled {
+		return nil, fmt.Errorf("%w: order %s is cancelled", response.ErrValidation, order.InvoiceNumber)
+	}
+
+	// 2. Item (jika diisi) harus milik pesanan tersebut
+	if req.OrderItemID != nil && !slices.Contains(order.ItemIDs, *req.OrderItemID) {
+		return nil, fmt.Errorf("%w: order item %d does not belong to order %s", response.ErrValidation, *req.OrderItemID, order.InvoiceNumber)
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, fmt.Errorf("%w: description must not be empty", response.ErrValidation)
+	}
+
+	// 3. Simpan komplain beserta foto bukti
+	now := time.Now()
+	complaint := &models.Complaint{
+		OutletID:        order.OutletID,
+		OrderID:         order.ID,
+		OrderItemID:     req.OrderItemID,
+		ComplaintType:   req.ComplaintType,
+		Description:     description,
+		ComplaintStatus: models.ComplaintOpen,
+		ReportedBy:      actorID,
+		CreatedAt:       now,
+	}
+	if err := s.complaintRepo.InsertComplaint(ctx, complaint, mapPhotoRequests(req.Photos, actorID, now)); err != nil {
+		return nil, err
+	}
+
+	return s.GetComplaintDetail(ctx, complaint.ID)
+}
+
+// GetComplaints retrieves complaints of the active outlet with pagination and filters.
+func (s *complaintService) GetComplaints(ctx context.Context, params listquery.Params) (*dto.ComplaintListResponse, error) {
+
+	// 1. Validasi filter tanggal (nilai lain dibandingkan langsung oleh MySQL)
+	for _, key := range []string{"start_date", "end_date"} {
+		if value, ok := params.Filters[key]; ok {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return nil, fmt.Errorf("%w: %s must use format YYYY-MM-DD", response.ErrValidation, key)
+			}
+		}
+	}
+
+	// 2. Call Repository
+	complaints, page, err := s.complaintRepo.FindComplaints(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Map to DTO
+	complaintResponses := make([]dto.ComplaintResponse, 0, len(complaints))
+	for i := range complaints {
+		complaintResponses = append(complaintResponses, *mapComplaint(&complaints[i]))
+	}
+
+	return &dto.ComplaintListResponse{
+		Data: complaintResponses,
+		Meta: page.Meta(),
+	}, nil
+}
+
+// GetComplaintDetail retrieves a complaint of the active outlet with its photos and status history.
+func (s *complaintService) GetComplaintDetail(ctx context.Context, id int64) (*dto.ComplaintResponse, error) {
+
+	complaint, err := s.complaintRepo.FindComplaintByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapComplaint(complaint), nil
+}
+
+// AddPhotos appends evidence photos to a complaint that is still being handled.
+func (s *complaintService) AddPhotos(ctx context.Context, id int64, req dto.AddComplaintPhotosRequest, actorID int64) (*dto.ComplaintResponse, error) {
+
+	// 1. Komplain yang sudah ditutup tidak menerima bukti baru
+	complaint, err := s.complaintRepo.FindComplaintByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if complaint.ComplaintStatus == models.ComplaintResolved || complaint.ComplaintStatus == models.ComplaintRejected {
+		return nil, fmt.Errorf("%w: complaint #%d is already %s", response.ErrInvalidTransition, complaint.ID, complaint.ComplaintStatus)
+	}
+
+	// 2. Simpan foto
+	if err := s.complaintRepo.InsertPhotos(ctx, complaint.ID, mapPhotoRequests(req.Photos, actorID, time.Now())); err != nil {
+		return nil, err
+	}
+
+	return s.GetComplaintDetail(ctx, complaint.ID)
+}
+
+// UpdateStatus moves a complaint to investigating or rejects it.
+func (s *complaintService) UpdateStatus(ctx context.Context, id int64, req dto.UpdateComplaintStatusRequest, actorID int64) (*dto.ComplaintResponse, error) {
+
+	// 1. Mulai transaksi & kunci komplain
+	tx, err := s.orderStatusRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	complaint, err := s.complaintRepo.LockComplaintTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi alur status
+	previous := complaint.ComplaintStatus
+	if err := validateComplaintTransition(previous, req.Status); err != nil {
+		return nil, err
+	}
+
+	// 3. Simpan status & riwayat
+	complaint.ComplaintStatus = req.Status
+	if err := s.complaintRepo.UpdateStatusTx(ctx, tx, complaint, &models.ComplaintStatusHistory{
+		ComplaintID:    complaint.ID,
+		PreviousStatus: &previous,
+		NewStatus:      req.Status,
+		ActorID:        &actorID,
+		Notes:          trimNote(req.Notes),
+		CreatedAt:      time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("complaintService.UpdateStatus.Commit: %w", err)
+	}
+
+	return s.GetComplaintDetail(ctx, complaint.ID)
+}
+
+// ResolveComplaint closes a complaint and executes its resolution in the same transaction.
+//
+// Aturan:
+//   - Hanya komplain 'open' / 'investigating' yang bisa diselesaikan.
+//   - rework: pesanan anak tanpa biaya (nota induk + "-R{n}", lunas, 'pending') berisi salinan item terpilih
+//     (order_item_ids > item komplain > semua item). Pesanan induk tidak boleh dibatalkan.
+//   - compensation: cash/transfer dicatat sebagai biaya kategori "Kompensasi Pelanggan" di outlet komplain;
+//     deposit menambah saldo pelanggan (pesanan wajib terhubung ke pelanggan terdaftar).
+//   - voucher: kode promo nominal tetap, sekali pakai, berlaku voucher_valid_days hari (default 30), hanya untuk
+//     pelanggan pemilik pesanan (pesanan wajib terhubung ke pelanggan terdaftar).
+func (s *complaintService) ResolveComplaint(ctx context.Context, id int64, req dto.ResolveComplaintRequest, actorID int64, actorRole string) (*dto.ComplaintResponse, error) {
+
+	// 1. Validasi field sesuai jenis penyelesaian
+	var readyAt *time.Time
+	switch req.ResolutionType {
+	case models.ResolutionRework:
+		if req.EstimatedReadyAt != nil {
+			parsed, err := parsePromotionTime("estimated_ready_at", *req.EstimatedReadyAt)
+			if err != nil {
+				return nil, err
+			}
+			readyAt = &parsed
+		}
+	case models.ResolutionCompensation:
+		if req.Amount == nil || req.CompensationMethod == "" {
+			return nil, fmt.Errorf("%w: amount and compensation_method are required for compensation", response.ErrValidation)
+		}
+	case models.ResolutionVoucher:
+		if req.Amount == nil {
+			return nil, fmt.Errorf("%w: amount is required for voucher", response.ErrValidation)
+		}
+	}
+
+	// 2. Kode voucher disiapkan sebelum transaksi (cek bentrok tanpa menahan kunci)
+	var voucherCode string
+	if req.ResolutionType == models.ResolutionVoucher {
+		code, err := s.newVoucherCode(ctx)
+		if err != nil {
+			return nil, err
+		}
+		voucherCode = code
+	}
+
+	// 3. Mulai transaksi, kunci komplain & pesanannya
+	tx, err := s.orderStatusRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	complaint, err := s.complaintRepo.LockComplaintTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	previous := complaint.ComplaintStatus
+	if err := validateComplaintTransition(previous, models.ComplaintResolved); err != nil {
+		return nil, err
+	}
+	state, err := s.orderStatusRepo.LockStateTx(ctx, tx, complaint.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Eksekusi penyelesaian
+	now := time.Now()
+	note := trimNote(req.Notes)
+	resolutionType := req.ResolutionType
+	switch req.ResolutionType {
+	case models.ResolutionRework:
+		if err := s.resolveReworkTx(ctx, tx, complaint, state, req.OrderItemIDs, readyAt, actorID, actorRole, now); err != nil {
+			return nil, err
+		}
+	case models.ResolutionCompensation:
+		if err := s.resolveCompensationTx(ctx, tx, complaint, *req.Amount, req.CompensationMethod, actorID, now); err != nil {
+			return nil, err
+		}
+	case models.ResolutionVoucher:
+		if err := s.resolveVoucherTx(ctx, tx, complaint, *req.Amount, voucherCode, req.VoucherValidDays, now); err != nil {
+			return nil, err
+		}
+	}
+
+	// 5. Tutup komplain & catat riwayat
+	complaint.ComplaintStatus = models.ComplaintResolved
+	complaint.ResolutionType = &resolutionType
+	complaint.ResolutionNote = note
+	complaint.ResolvedBy = &actorID
+	complaint.ResolvedAt = &now
+	if err := s.complaintRepo.UpdateStatusTx(ctx, tx, complaint, &models.ComplaintStatusHistory{
+		ComplaintID:    complaint.ID,
+		PreviousStatus: &previous,
+		NewStatus:      models.ComplaintResolved,
+		ActorID:        &actorID,
+		Notes:          note,
+		CreatedAt:      now,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("complaintService.ResolveComplaint.Commit: %w", err)
+	}
+
+	return s.GetComplaintDetail(ctx, complaint.ID)
+}
+
+// resolveReworkTx creates the zero-cost child order and records it in the status history of both orders.
+func (s *complaintService) resolveReworkTx(ctx context.Context, tx *sql.Tx, complaint *models.Complaint, state *models.OrderState, itemIDs []int64, readyAt *time.Time, actorID int64, actorRole string, now time.Time) error {
+
+	// 1. Pesanan induk yang dibatalkan tidak bisa dicuci ulang
+	if state.StatusInternal == models.OrderStatusCancelled {
+		return fmt.Errorf("%w: order %s is cancelled", response.ErrValidation, state.InvoiceNumber)
+	}
+
+	// 2. Tentukan item yang dicuci ulang
+	if len(itemIDs) == 0 && complaint.OrderItemID != nil {
+		itemIDs = []int64{*complaint.OrderItemID}
+	}
+	if len(itemIDs) == 0 {
+		order, err := s.complaintRepo.FindComplaintOrder(ctx, complaint.OrderID)
+		if err != nil {
+			return err
+		}
+		itemIDs = order.ItemIDs
+	}
+	if len(itemIDs) == 0 {
+		return fmt.Errorf("%w: order %s has no items to rework", response.ErrValidation, state.InvoiceNumber)
+	}
+	unique := make([]int64, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+
+	// 3. Buat pesanan anak
+	reworkNote := fmt.Sprintf("Cuci ulang dari komplain #%d (nota %s)", complaint.ID, state.InvoiceNumber)
+	rework := &models.ReworkOrder{
+		ParentOrderID:    complaint.OrderID,
+		OrderItemIDs:     unique,
+		EstimatedReadyAt: readyAt,
+		Notes:            &reworkNote,
+		CreatedBy:        actorID,
+		CreatedAt:        now,
+	}
+	if err := s.complaintRepo.InsertReworkOrderTx(ctx, tx, rework); err != nil {
+		return err
+	}
+
+	// 4. Riwayat: pesanan anak dibuat 'pending', pesanan induk mendapat catatan (tanpa pindah status)
+	if err := s.orderStatusRepo.InsertHistoryTx(ctx, tx, &models.StatusHistory{
+		OrderID:   rework.ID,
+		NewStatus: models.OrderStatusPending,
+		ActorID:   &actorID,
+		ActorRole: &actorRole,
+		Notes:     &reworkNote,
+		CreatedAt: now,
+	}); err != nil {
+		return err
+	}
+	parentNote := fmt.Sprintf("Komplain #%d diselesaikan dengan cuci ulang %s", complaint.ID, rework.InvoiceNumber)
+	if err := s.orderStatusRepo.InsertHistoryTx(ctx, tx, &models.StatusHistory{
+		OrderID:        complaint.OrderID,
+		PreviousStatus: &state.StatusInternal,
+		NewStatus:      state.StatusInternal,
+		ActorID:        &actorID,
+		ActorRole:      &actorRole,
+		Notes:          &parentNote,
+		CreatedAt:      now,
+	}); err != nil {
+		return err
+	}
+
+	complaint.ReworkOrderID = &rework.ID
+	return nil
+}
+
+// resolveCompensationTx pays the compensation as an outlet expense (cash/transfer) or a wallet credit (deposit).
+func (s *complaintService) resolveCompensationTx(ctx context.Context, tx *sql.Tx, complaint *models.Complaint, amount money.Amount, method string, actorID int64, now time.Time) error {
+
+	complaint.CompensationAmount = &amount
+	complaint.CompensationMethod = &method
+
+	// 1. Deposit: kredit saldo pelanggan
+	if method == models.CompensationDeposit {
+		if complaint.CustomerID == nil {
+			return fmt.Errorf("%w: order %s has no registered customer, use cash or transfer", response.ErrValidation, complaint.InvoiceNumber)
+		}
+		reason := fmt.Sprintf("Compensation for complaint #%d (order %s)", complaint.ID, complaint.InvoiceNumber)
+		entry, err := s.walletService.CompensateToWalletTx(ctx, tx, *complaint.CustomerID, complaint.OrderID, amount, reason, actorID)
+		if err != nil {
+			return err
+		}
+		complaint.WalletEntryID = &entry.ID
+		return nil
+	}
+
+	// 2. Tunai / transfer: catat sebagai biaya operasional outlet
+	category, err := s.expenseRepo.FindCategoryByName(ctx, models.CompensationExpenseCategory)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return fmt.Errorf("%w: expense category %q does not exist", response.ErrValidation, models.CompensationExpenseCategory)
+		}
+		return err
+	}
+	description := fmt.Sprintf("Kompensasi komplain #%d (%s)", complaint.ID, method)
+	expense := &models.Expense{
+		OutletID:          complaint.OutletID,
+		ExpenseCategoryID: category.ID,
+		Amount:            amount,
+		ExpenseDate:       now,
+		Description:       &description,
+		AttachmentRef:     &complaint.InvoiceNumber,
+		CreatedBy:         actorID,
+		CreatedAt:         now,
+	}
+	if err := s.expenseRepo.InsertExpenseTx(ctx, tx, expense); err != nil {
+		return err
+	}
+
+	complaint.ExpenseID = &expense.ID
+	return nil
+}
+
+// resolveVoucherTx issues a single-use fixed-amount voucher code bound to the complaint's customer.
+func (s *complaintService) resolveVoucherTx(ctx context.Context, tx *sql.Tx, complaint *models.Complaint, amount money.Amount, code string, validDays int, now time.Time) error {
+
+	// Voucher hanya bisa dipakai pelanggan pemilik pesanan, jadi pesanan wajib terhubung ke pelanggan terdaftar
+	if complaint.CustomerID == nil {
+		return fmt.Errorf("%w: order %s has no registered customer, use compensation instead", response.ErrValidation, complaint.InvoiceNumber)
+	}
+	if validDays <= 0 {
+		validDays = complaintVoucherValidDays
+	}
+	endsAt := now.AddDate(0, 0, validDays)
+	once := 1
+	description := fmt.Sprintf("Kompensasi komplain #%d (nota %s)", complaint.ID, complaint.InvoiceNumber)
+
+	promo := &models.Promotion{
+		Code:             &code,
+		PromoName:        fmt.Sprintf("Voucher Komplain #%d", complaint.ID),
+		Description:      &description,
+		DiscountType:     models.DiscountFixed,
+		DiscountValue:    amount,
+		ScopeType:        models.PromoScopeAll,
+		StartsAt:         now,
+		EndsAt:           &endsAt,
+		UsageLimit:       &once,
+		PerCustomerLimit: &once,
+		CustomerID:       complaint.CustomerID,
+		IsActive:         true,
+		CreatedAt:        now,
+	}
+	if err := s.promotionRepo.InsertPromotionTx(ctx, tx, promo, nil); err != nil {
+		return err
+	}
+
+	complaint.CompensationAmount = &amount
+	complaint.VoucherPromotionID = &promo.ID
+	return nil
+}
+
+// newVoucherCode membuat kode voucher acak yang belum dipakai promosi lain.
+func (s *complaintService) newVoucherCode(ctx context.Context) (string, error) {
+	for attempt := 0; attempt < complaintVoucherAttempt; attempt++ {
+		random := make([]byte, complaintVoucherLength)
+		if _, err := rand.Read(random); err != nil {
+			return "", fmt.Errorf("complaintService.newVoucherCode: %w", err)
+		}
+
+		code := []byte(complaintVoucherPrefix)
+		for _, b := range random {
+			code = append(code, tagCodeAlphabet[int(b)%len(tagCodeAlphabet)])
+		}
+
+		_, err := s.promotionRepo.FindByCode(ctx, string(code))
+		if errors.Is(err, response.ErrNotFound) {
+			return string(code), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("complaintService.newVoucherCode: no free code after %d attempts", complaintVoucherAttempt)
+}
+
+// --- HELPER FUNCTION ---
+
+// validateComplaintTransition memastikan perpindahan status mengikuti complaintTransitions.
+func validateComplaintTransition(from, to string) error {
+	if !slices.Contains(complaintTransitions[from], to) {
+		return fmt.Errorf("%w: complaint cannot move from %s to %s", response.ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+func mapPhotoRequests(photos []dto.ComplaintPhotoRequest, actorID int64, now time.Time) []models.ComplaintPhoto {
+	result := make([]models.ComplaintPhoto, 0, len(photos))
+	for _, p := range photos {
+		result = append(result, models.ComplaintPhoto{
+			PhotoURL:   strings.TrimSpace(p.PhotoURL),
+			Caption:    trimNote(p.Caption),
+			UploadedBy: actorID,
+			CreatedAt:  now,
+		})
+	}
+	return result
+}
+
+func mapComplaint(c *models.Complaint) *dto.ComplaintResponse {
+	res := &dto.ComplaintResponse{
+		ID:              c.ID,
+		OutletID:        c.OutletID,
+		OrderID:         c.OrderID,
+		InvoiceNumber:   c.InvoiceNumber,
+		CustomerID:      c.CustomerID,
+		CustomerName:    c.CustomerName,
+		OrderItemID:     c.OrderItemID,
+		ServiceName:     c.ServiceName,
+		ComplaintType:   c.ComplaintType,
+		Description:     c.Description,
+		ComplaintStatus: c.ComplaintStatus,
+		PhotoCount:      c.PhotoCount,
+		ReportedBy:      c.ReportedBy,
+		ReportedByName:  c.ReportedByName,
+		CreatedAt:       c.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       formatTimePtr(c.UpdatedAt),
+	}
+
+	if c.ResolutionType != nil {
+		res.Resolution = &dto.ComplaintResolutionResponse{
+			ResolutionType:      *c.ResolutionType,
+			Notes:               c.ResolutionNote,
+			ReworkOrderID:       c.ReworkOrderID,
+			ReworkInvoiceNumber: c.ReworkInvoice,
+			CompensationAmount:  c.CompensationAmount,
+			CompensationMethod:  c.CompensationMethod,
+			ExpenseID:           c.ExpenseID,
+			WalletEntryID:       c.WalletEntryID,
+			VoucherPromotionID:  c.VoucherPromotionID,
+			VoucherCode:         c.VoucherCode,
+			ResolvedBy:          c.ResolvedBy,
+			ResolvedByName:      c.ResolvedByName,
+			ResolvedAt:          formatTimePtr(c.ResolvedAt),
+		}
+	}
+
+	if c.Photos != nil {
+		res.Photos = make([]dto.ComplaintPhotoResponse, 0, len(c.Photos))
+		for _, p := range c.Photos {
+			res.Photos = append(res.Photos, dto.ComplaintPhotoResponse{
+				ID:         p.ID,
+				PhotoURL:   p.PhotoURL,
+				Caption:    p.Caption,
+				UploadedBy: p.UploadedBy,
+				CreatedAt:  p.CreatedAt.Format("2006-01-02 15:04:05"),
+			})
+		}
+	}
+	if c.History != nil {
+		res.History = make([]dto.ComplaintHistoryResponse, 0, len(c.History))
+		for _, h := range c.History {
+			res.History = append(res.History, dto.ComplaintHistoryResponse{
+				PreviousStatus: h.PreviousStatus,
+				NewStatus:      h.NewStatus,
+				ActorID:        h.ActorID,
+				ActorName:      h.ActorName,
+				Notes:          h.Notes,
+				CreatedAt:      h.CreatedAt.Format("2006-01-02 15:04:05"),
+			})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// ExpenseService defines the contract for business logic related to operational expenses.
+type ExpenseService interface {
+
+	// Expense Categories
+	CreateCategory(ctx context.Context, req dto.CreateExpenseCategoryRequest) (*dto.ExpenseCategoryResponse, error)
+	GetCategoryList(ctx context.Context, status string) ([]dto.ExpenseCategoryResponse, error)
+	GetCategoryDetail(ctx context.Context, id int64) (*dto.ExpenseCategoryResponse, error)
+	ModifyCategory(ctx context.Context, targetID int64, req dto.UpdateExpenseCategoryRequest) (*dto.ExpenseCategoryResponse, error)
+	DeactivateCategory(ctx context.Context, targetID int64) error
+
+	// Expenses (outlet aktif di context). Selain owner hanya boleh mengubah/menghapus biaya yang dicatatnya sendiri.
+	RecordExpense(ctx context.Context, req dto.CreateExpenseRequest, requesterID int64) (*dto.ExpenseResponse, error)
+	GetExpenses(ctx context.Context, params listquery.Params) (*dto.ExpenseListResponse, error)
+	GetExpenseDetail(ctx context.Context, id int64) (*dto.ExpenseResponse, error)
+	ModifyExpense(ctx context.Context, targetID int64, req dto.UpdateExpenseRequest, requesterID int64, requesterRole string) (*dto.ExpenseResponse, error)
+	RemoveExpense(ctx context.Context, targetID, requesterID int64, requesterRole string) error
+}
+
+type expenseService struct {
+	expenseRepo repositories.ExpenseRepository
+}
+
+// NewExpenseService creates a new instance of ExpenseService.
+func NewExpenseService(expenseRepo repositories.ExpenseRepository) ExpenseService {
+	return &expenseService{expenseRepo: expenseRepo}
+}
+
+// --- EXPENSE CATEGORIES ---
+
+// CreateCategory handles the creation of a new expense category.
+func (s *expenseService) CreateCategory(ctx context.Context, req dto.CreateExpenseCategoryRequest) (*dto.ExpenseCategoryResponse, error) {
+
+	// 1. Pengecekan Duplikasi Nama
+	name := strings.TrimSpace(req.CategoryName)
+	existing, _ := s.expenseRepo.FindCategoryByName(ctx, name)
+	if existing != nil {
+		return nil, response.ErrDuplicate
+	}
+
+	// 2. Simpan
+	category := &models.ExpenseCategory{
+		CategoryName: name,
+		Description:  req.Description,
+		IsActive:     true,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.expenseRepo.InsertCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return mapExpenseCategory(category), nil
+}
+
+// GetCategoryList retrieves every expense category.
+func (s *expenseService) GetCategoryList(ctx context.Context, status string) ([]dto.ExpenseCategoryResponse, error) {
+
+	categories, err := s.expenseRepo.FindCategories(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryResponses := make([]dto.ExpenseCategoryResponse, 0, len(categories))
+	for i := range categories {
+		categoryResponses = append(categoryResponses, *mapExpenseCategory(&categories[i]))
+	}
+
+	return categoryResponses, nil
+}
+
+// GetCategoryDetail retrieves an expense category by ID.
+func (s *expenseService) GetCategoryDetail(ctx context.Context, id int64) (*dto.ExpenseCategoryResponse, error) {
+
+	category, err := s.expenseRepo.FindCategoryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapExpenseCategory(category), nil
+}
+
+// ModifyCategory updates an expense category (partial update).
+func (s *expenseService) ModifyCategory(ctx context.Context, targetID int64, req dto.UpdateExpenseCategoryRequest) (*dto.ExpenseCategoryResponse, error) {
+
+	// 1. Ambil Data Lama
+	category, err := s.expenseRepo.FindCategoryByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi & Update Nama (Jika dikirim user)
+	if req.CategoryName != nil {
+		name := strings.TrimSpace(*req.CategoryName)
+		if name != category.CategoryName {
+			duplicateCheck, _ := s.expenseRepo.FindCategoryByName(ctx, name)
+			if duplicateCheck != nil && duplicateCheck.ID != targetID {
+				return nil, response.ErrDuplicate
+			}
+			category.CategoryName = name
+		}
+	}
+
+	// 3. Update Fields Lainnya
+	if req.Description != nil {
+		category.Description = req.Description
+	}
+	if req.IsActive != nil {
+		category.IsActive = *req.IsActive
+	}
+
+	now := time.Now()
+	category.UpdatedAt = &now
+
+	// 4. Simpan Perubahan
+	if err := s.expenseRepo.UpdateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return mapExpenseCategory(category), nil
+}
+
+// DeactivateCategory handles soft deletion of an expense category. Biaya lama tetap memakai kategorinya.
+func (s *expenseService) DeactivateCategory(ctx context.Context, targetID int64) error {
+	return s.expenseRepo.DeleteCategory(ctx, targetID)
+}
+
+// --- EXPENSES ---
+
+// RecordExpense records a new expense at the active outlet.
+func (s *expenseService) RecordExpense(ctx context.Context, req dto.CreateExpenseRequest, requesterID int64) (*dto.ExpenseResponse, error) {
+
+	// 1. Biaya selalu milik satu outlet (owner mode konsolidasi harus memilih outlet dulu)
+	outletID := outlet.FromContext(ctx)
+	if outletID == outlet.All {
+		return nil, fmt.Errorf("%w: switch to an outlet before recording an expense", response.ErrValidation)
+	}
+
+	// 2. Validasi kategori & tanggal
+	category, err := s.activeCategory(ctx, req.ExpenseCategoryID)
+	if err != nil {
+		return nil, err
+	}
+	expenseDate, err := parseExpenseDate(req.ExpenseDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Simpan
+	expense := &models.Expense{
+		OutletID:          outletID,
+		ExpenseCategoryID: category.ID,
+		CategoryName:      category.CategoryName,
+		Amount:            req.Amount,
+		ExpenseDate:       expenseDate,
+		Description:       trimNote(req.Description),
+		AttachmentRef:     trimNote(req.AttachmentRef),
+		CreatedBy:         requesterID,
+		CreatedAt:         time.Now(),
+	}
+	if err := s.expenseRepo.InsertExpense(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	return mapExpense(expense), nil
+}
+
+// GetExpenses retrieves expenses of the active outlet with pagination and filters.
+func (s *expenseService) GetExpenses(ctx context.Context, params listquery.Params) (*dto.ExpenseListResponse, error) {
+
+	// 1. Validasi filter tanggal (nilai lain dibandingkan langsung oleh MySQL)
+	for _, key := range []string{"start_date", "end_date"} {
+		if value, ok := params.Filters[key]; ok {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return nil, fmt.Errorf("%w: %s must use format YYYY-MM-DD", response.ErrValidation, key)
+			}
+		}
+	}
+
+	// 2. Call Repository
+	expenses, page, err := s.expenseRepo.FindExpenses(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Map to DTO
+	expenseResponses := make([]dto.ExpenseResponse, 0, len(expenses))
+	for i := range expenses {
+		expenseResponses = append(expenseResponses, *mapExpense(&expenses[i]))
+	}
+
+	return &dto.ExpenseListResponse{
+		Data: expenseResponses,
+		Meta: page.Meta(),
+	}, nil
+}
+
+// GetExpenseDetail retrieves an expense of the active outlet.
+func (s *expenseService) GetExpenseDetail(ctx context.Context, id int64) (*dto.ExpenseResponse, error) {
+
+	expense, err := s.expenseRepo.FindExpenseByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapExpense(expense), nil
+}
+
+// ModifyExpense updates an expense (partial update) and records who changed it.
+func (s *expenseService) ModifyExpense(ctx context.Context, targetID int64, req dto.UpdateExpenseRequest, requesterID int64, requesterRole string) (*dto.ExpenseResponse, error) {
+
+	// 1. Ambil Data Lama (dibatasi outlet aktif)
+	expense, err := s.expenseRepo.FindExpenseByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. SECURITY GUARD: selain owner hanya biaya yang dicatat sendiri
+	if requesterRole != "owner" && expense.CreatedBy != requesterID {
+		return nil, response.ErrForbidden
+	}
+
+	// 3. Update Fields (Partial Update Logic)
+	if req.ExpenseCategoryID != nil && *req.ExpenseCategoryID != expense.ExpenseCategoryID {
+		category, err := s.activeCategory(ctx, *req.ExpenseCategoryID)
+		if err != nil {
+			return nil, err
+		}
+		expense.ExpenseCategoryID = category.ID
+		expense.CategoryName = category.CategoryName
+	}
+	if req.Amount != nil {
+		expense.Amount = *req.Amount
+	}
+	if req.ExpenseDate != nil {
+		expenseDate, err := parseExpenseDate(*req.ExpenseDate)
+		if err != nil {
+			return nil, err
+		}
+		expense.ExpenseDate = expenseDate
+	}
+	if req.Description != nil {
+		expense.Description = trimNote(req.Description)
+	}
+	if req.AttachmentRef != nil {
+		expense.AttachmentRef = trimNote(req.AttachmentRef)
+	}
+
+	// 4. Catat pengubah & waktu
+	now := time.Now()
+	expense.UpdatedBy = &requesterID
+	expense.UpdatedAt = &now
+
+	// 5. Simpan Perubahan
+	if err := s.expenseRepo.UpdateExpense(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	return mapExpense(expense), nil
+}
+
+// RemoveExpense permanently deletes an expense of the active outlet.
+func (s *expenseService) RemoveExpense(ctx context.Context, targetID, requesterID int64, requesterRole string) error {
+
+	// 1. Cek apakah biaya ada (dibatasi outlet aktif)
+	expense, err := s.expenseRepo.FindExpenseByID(ctx, targetID)
+	if err != nil {
+		return err
+	}
+
+	// 2. SECURITY GUARD: selain owner hanya biaya yang dicatat sendiri
+	if requesterRole != "owner" && expense.CreatedBy != requesterID {
+		return response.ErrForbidden
+	}
+
+	// 3. Hapus
+	return s.expenseRepo.DeleteExpense(ctx, targetID)
+}
+
+// --- HELPER FUNCTION ---
+
+// activeCategory memastikan kategori biaya ada dan masih aktif.
+func (s *expenseService) activeCategory(ctx context.Context, id int64) (*models.ExpenseCategory, error) {
+	category, err := s.expenseRepo.FindCategoryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return nil, fmt.Errorf("%w: expense category %d not found", response.ErrValidation, id)
+		}
+		return nil, err
+	}
+	if !category.IsActive {
+		return nil, fmt.Errorf("%w: expense category %d is not active", response.ErrValidation, id)
+	}
+	return category, nil
+}
+
+// parseExpenseDate membaca tanggal biaya (YYYY-MM-DD, zona bisnis WIB) dan menolak tanggal di masa depan.
+func parseExpenseDate(value string) (time.Time, error) {
+	date, err := time.ParseInLocation("2006-01-02", value, config.Location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: expense_date must use format YYYY-MM-DD", response.ErrValidation)
+	}
+	if date.After(time.Now()) {
+		return time.Time{}, fmt.Errorf("%w: expense_date must not be in the future", response.ErrValidation)
+	}
+	return date, nil
+}
+
+func mapExpenseCategory(c *models.ExpenseCategory) *dto.ExpenseCategoryResponse {
+	return &dto.ExpenseCategoryResponse{
+		ID:           c.ID,
+		CategoryName: c.CategoryName,
+		Description:  c.Description,
+		IsActive:     c.IsActive,
+		CreatedAt:    c.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    formatTimePtr(c.UpdatedAt),
+	}
+}
+
+func mapExpense(e *models.Expense) *dto.ExpenseResponse {
+	return &dto.ExpenseResponse{
+		ID:                e.ID,
+		OutletID:          e.OutletID,
+		ExpenseCategoryID: e.ExpenseCategoryID,
+		CategoryName:      e.CategoryName,
+		Amount:            e.Amount,
+		ExpenseDate:       e.ExpenseDate.Format("2006-01-02"),
+		Description:       e.Description,
+		AttachmentRef:     e.AttachmentRef,
+		CreatedBy:         e.CreatedBy,
+		UpdatedBy:         e.UpdatedBy,
+		CreatedAt:         e.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:         formatTimePtr(e.UpdatedAt),
+	}
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"laundry-backend/internal/config"
+)
+
+// TestParseExpenseDateUsesBusinessTimeZone: tanggal hari ini (WIB) harus diterima walaupun
+// zona server tertinggal jauh, sehingga tengah malamnya di zona server jatuh di masa depan.
+func TestParseExpenseDateUsesBusinessTimeZone(t *testing.T) {
+
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = time.FixedZone("UTC-12", -12*60*60)
+
+	today := time.Now().In(config.Location).Format("2006-01-02")
+	date, err := parseExpenseDate(today)
+	if err != nil {
+		t.Fatalf("parseExpenseDate(%q): %v", today, err)
+	}
+	if date.Location() != config.Location {
+		t.Errorf("parseExpenseDate location = %s, want %s", date.Location(), config.Location)
+	}
+
+	tomorrow := time.Now().In(config.Location).AddDate(0, 0, 1).Format("2006-01-02")
+	if _, err := parseExpenseDate(tomorrow); err == nil {
+		t.Errorf("parseExpenseDate(%q) accepted a future date", tomorrow)
+	}
+}
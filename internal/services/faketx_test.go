@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+// fakeTxConnector adalah driver database/sql minimal agar service bisa BeginTx/Commit/Rollback tanpa MySQL.
+// Query apa pun ditolak: semua akses data di test lewat fake repository.
+type fakeTxConnector struct {
+	commits   int
+	rollbacks int
+}
+
+func (c *fakeTxConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeTxConn{c: c}, nil
+}
+func (c *fakeTxConnector) Driver() driver.Driver { return fakeTxDriver{} }
+
+type fakeTxDriver struct{}
+
+func (fakeTxDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("fakeTxDriver: open through the connector")
+}
+
+type fakeTxConn struct{ c *fakeTxConnector }
+
+func (c *fakeTxConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fakeTxConn: queries are not supported")
+}
+func (c *fakeTxConn) Close() error              { return nil }
+func (c *fakeTxConn) Begin() (driver.Tx, error) { return &fakeTx{c: c.c}, nil }
+
+type fakeTx struct{ c *fakeTxConnector }
+
+func (t *fakeTx) Commit() error   { t.c.commits++; return nil }
+func (t *fakeTx) Rollback() error { t.c.rollbacks++; return nil }
+
+// newFakeTxDB membuka *sql.DB di atas fakeTxConnector; connector dipakai untuk memeriksa commit/rollback.
+func newFakeTxDB(t *testing.T) (*sql.DB, *fakeTxConnector) {
+	t.Helper()
+	c := &fakeTxConnector{}
+	db := sql.OpenDB(c)
+	t.Cleanup(func() { db.Close() })
+	return db, c
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/repositories"
+	"time"
+)
+
+// IdempotencyService defines the housekeeping of stored Idempotency-Key responses.
+// Pengecekan per request dilakukan oleh middlewares.IdempotencyMiddleware.
+type IdempotencyService interface {
+
+	// PurgeExpired menghapus key yang masa simpannya sudah habis dan mengembalikan jumlahnya.
+	PurgeExpired(ctx context.Context) (int64, error)
+
+	// RunCleaner menjalankan PurgeExpired berkala sampai ctx dibatalkan.
+	RunCleaner(ctx context.Context)
+}
+
+type idempotencyService struct {
+	idempotencyRepo repositories.IdempotencyRepository
+	cfg             *config.Config
+}
+
+// NewIdempotencyService creates a new instance of IdempotencyService.
+func NewIdempotencyService(idempotencyRepo repositories.IdempotencyRepository, cfg *config.Config) IdempotencyService {
+	return &idempotencyService{idempotencyRepo: idempotencyRepo, cfg: cfg}
+}
+
+// PurgeExpired deletes keys whose TTL (or processing lock) has passed.
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.idempotencyRepo.DeleteExpired(ctx, time.Now())
+}
+
+// RunCleaner purges expired keys until ctx is cancelled.
+func (s *idempotencyService) RunCleaner(ctx context.Context) {
+	interval := time.Duration(s.cfg.IDEMPOTENCY.CleanupIntervalMin) * time.Minute
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.PurgeExpired(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("[ERROR] IdempotencyCleaner: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
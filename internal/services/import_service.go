@@ -0,0 +1,403 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/imports"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ImportService defines the contract for bulk CSV import of master data.
+type ImportService interface {
+	// Import memvalidasi setiap baris file lalu (jika bukan dry run) menulis baris valid dalam satu transaksi.
+	// Report.Committed=false tanpa error berarti import ditolak karena ada baris gagal (on_error=abort).
+	Import(ctx context.Context, entity string, file io.Reader, query dto.ImportQuery) (*dto.ImportReport, error)
+}
+
+type importService struct {
+	importRepo   repositories.ImportRepository
+	categoryRepo repositories.CategoryRepository
+	serviceRepo  repositories.ServiceRepository
+}
+
+// NewImportService creates a new instance of ImportService.
+func NewImportService(importRepo repositories.ImportRepository, categoryRepo repositories.CategoryRepository, serviceRepo repositories.ServiceRepository) ImportService {
+	return &importService{
+		importRepo:   importRepo,
+		categoryRepo: categoryRepo,
+		serviceRepo:  serviceRepo,
+	}
+}
+
+// importColumns adalah kolom wajib di baris judul CSV per entitas (kolom opsional boleh tidak ada)
+var importColumns = map[string][]string{
+	models.ImportEntityCategories: {"category_name"},
+	models.ImportEntityServices:   {"code", "service_name", "category_name", "unit", "price", "duration_hours"},
+	models.ImportEntityCustomers:  {"full_name", "phone_number"},
+}
+
+// importRow adalah satu baris yang sudah diperiksa beserta cara menuliskannya ke database.
+type importRow struct {
+	result dto.ImportRowResult
+	write  func(ctx context.Context, tx *sql.Tx) (int64, error)
+}
+
+// importBatch menyimpan data yang sudah dilihat selama satu import: duplikasi di dalam file
+// dan cache kategori (banyak layanan biasanya merujuk ke kategori yang sama).
+type importBatch struct {
+	seen       map[string]int // "kolom:nilai huruf kecil" -> nomor baris pertama
+	categories map[string]*models.ServiceCategory
+}
+
+// Import validates every CSV row and writes the valid ones in a single transaction unless dry_run is set.
+func (s *importService) Import(ctx context.Context, entity string, file io.Reader, query dto.ImportQuery) (*dto.ImportReport, error) {
+
+	// 1. Validasi entitas & opsi
+	required, ok := importColumns[entity]
+	if !ok {
+		return nil, fmt.Errorf("%w: entity must be one of: categories, services, customers", response.ErrValidation)
+	}
+	onError := query.OnError
+	if onError == "" {
+		onError = models.ImportOnErrorAbort
+	}
+
+	// 2. Baca file (judul kolom wajib, maks. imports.MaxRows baris)
+	rows, err := imports.ReadCSV(file, required)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", response.ErrValidation, err)
+	}
+
+	// 3. Periksa setiap baris dengan aturan yang sama seperti endpoint create satuan
+	batch := &importBatch{seen: map[string]int{}, categories: map[string]*models.ServiceCategory{}}
+	checked := make([]importRow, 0, len(rows))
+	report := &dto.ImportReport{
+		Entity:    entity,
+		DryRun:    query.DryRun,
+		OnError:   onError,
+		TotalRows: len(rows),
+	}
+	for _, row := range rows {
+		item, err := s.checkRow(ctx, entity, row, batch)
+		if err != nil {
+			return nil, err
+		}
+		if item.result.Status == models.ImportRowValid {
+			report.ValidRows++
+		} else {
+			report.InvalidRows++
+		}
+		checked = append(checked, *item)
+	}
+
+	// 4. Dry run atau ditolak (ada baris gagal & on_error=abort): tidak ada yang ditulis
+	if query.DryRun || (report.InvalidRows > 0 && onError == models.ImportOnErrorAbort) {
+		report.Rows = collectImportResults(checked)
+		return report, nil
+	}
+
+	// 5. Tulis semua baris valid dalam satu transaksi
+	tx, err := s.importRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for i := range checked {
+		item := &checked[i]
+		if item.write == nil {
+			item.result.Status = models.ImportRowSkipped
+			continue
+		}
+
+		id, err := item.write(ctx, tx)
+		if err != nil {
+			return nil, fmt.Errorf("import line %d: %w", item.result.Line, err)
+		}
+		item.result.Status = models.ImportRowCreated
+		item.result.ID = &id
+		report.CreatedRows++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("importService.Import.Commit: %w", err)
+	}
+
+	report.Committed = true
+	report.Rows = collectImportResults(checked)
+	return report, nil
+}
+
+// checkRow memvalidasi satu baris sesuai entitas.
+func (s *importService) checkRow(ctx context.Context, entity string, row imports.Row, batch *importBatch) (*importRow, error) {
+	switch entity {
+	case models.ImportEntityCategories:
+		return s.checkCategoryRow(ctx, row, batch)
+	case models.ImportEntityServices:
+		return s.checkServiceRow(ctx, row, batch)
+	default:
+		return s.checkCustomerRow(ctx, row, batch)
+	}
+}
+
+// checkCategoryRow: aturan CreateCategoryRequest + nama kategori unik.
+func (s *importService) checkCategoryRow(ctx context.Context, row imports.Row, batch *importBatch) (*importRow, error) {
+
+	// 1. Aturan binding DTO
+	req := dto.CreateCategoryRequest{
+		CategoryName: row.Get("category_name"),
+		Description:  row.Optional("description"),
+	}
+	errs := newImportErrors()
+	errs.addValidation(imports.Validate(&req))
+
+	// 2. Duplikasi (di file & di database)
+	if req.CategoryName != "" {
+		errs.addDuplicate(batch, "category_name", req.CategoryName, row.Line)
+		existing, err := s.categoryRepo.FindByName(ctx, req.CategoryName)
+		if err != nil && !errors.Is(err, response.ErrNotFound) {
+			return nil, err
+		}
+		if existing != nil {
+			errs.add("category_name", "already exists")
+		}
+	}
+
+	item := newImportRow(row, req.CategoryName, errs)
+	if item.result.Status == models.ImportRowValid {
+		item.write = func(ctx context.Context, tx *sql.Tx) (int64, error) {
+			category := &models.ServiceCategory{
+				CategoryName: req.CategoryName,
+				Description:  req.Description,
+				IsActive:     true,
+				CreatedAt:    time.Now(),
+				Version:      1,
+			}
+			err := s.importRepo.InsertCategoryTx(ctx, tx, category)
+			return category.ID, err
+		}
+	}
+	return item, nil
+}
+
+// checkServiceRow: aturan CreateServiceRequest + kode & nama layanan unik. Kategori dirujuk lewat nama.
+func (s *importService) checkServiceRow(ctx context.Context, row imports.Row, batch *importBatch) (*importRow, error) {
+	errs := newImportErrors()
+
+	// 1. Ubah teks sel menjadi tipe DTO
+	req := dto.CreateServiceRequest{
+		Code:        row.Get("code"),
+		ServiceName: row.Get("service_name"),
+		Unit:        strings.ToLower(row.Get("unit")),
+	}
+	if text := row.Get("price"); text != "" {
+		price, err := money.Parse(text)
+		if err != nil {
+			errs.add("price", "must be a plain number, e.g. 15000 or 15000.50")
+		}
+		req.Price = price
+	}
+	if text := row.Get("duration_hours"); text != "" {
+		hours, err := strconv.Atoi(text)
+		if err != nil {
+			errs.add("duration_hours", "must be a whole number")
+		}
+		req.DurationHours = hours
+	}
+
+	// 2. Rujukan kategori lewat nama (harus ada & aktif)
+	if name := row.Get("category_name"); name == "" {
+		errs.add("category_name", "is required")
+	} else {
+		category, err := s.findCategory(ctx, name, batch)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case category == nil:
+			errs.add("category_name", "category not found")
+		case !category.IsActive:
+			errs.add("category_name", "category is inactive")
+		default:
+			req.CategoryID = category.ID
+		}
+	}
+	errs.skip("category_id") // Sudah dilaporkan lewat category_name
+
+	// 3. Aturan binding DTO
+	errs.addValidation(imports.Validate(&req))
+
+	// 4. Duplikasi kode & nama (di file & di database)
+	if req.Code != "" {
+		errs.addDuplicate(batch, "code", req.Code, row.Line)
+		existing, err := s.serviceRepo.FindByCode(ctx, req.Code)
+		if err != nil && !errors.Is(err, response.ErrNotFound) {
+			return nil, err
+		}
+		if existing != nil {
+			errs.add("code", "already exists")
+		}
+	}
+	if req.ServiceName != "" {
+		errs.addDuplicate(batch, "service_name", req.ServiceName, row.Line)
+		existing, err := s.serviceRepo.FindByName(ctx, req.ServiceName)
+		if err != nil && !errors.Is(err, response.ErrNotFound) {
+			return nil, err
+		}
+		if existing != nil {
+			errs.add("service_name", "already exists")
+		}
+	}
+
+	item := newImportRow(row, req.Code, errs)
+	if item.result.Status == models.ImportRowValid {
+		item.write = func(ctx context.Context, tx *sql.Tx) (int64, error) {
+			service := &models.Service{
+				CategoryID:    req.CategoryID,
+				Code:          req.Code,
+				ServiceName:   req.ServiceName,
+				Unit:          req.Unit,
+				Price:         req.Price,
+				DurationHours: req.DurationHours,
+				IsActive:      true,
+				CreatedAt:     time.Now(),
+				Version:       1,
+			}
+			err := s.importRepo.InsertServiceTx(ctx, tx, service)
+			return service.ID, err
+		}
+	}
+	return item, nil
+}
+
+// checkCustomerRow: aturan ImportCustomerRequest + nomor HP unik.
+func (s *importService) checkCustomerRow(ctx context.Context, row imports.Row, batch *importBatch) (*importRow, error) {
+
+	// 1. Aturan binding DTO
+	req := dto.ImportCustomerRequest{
+		FullName:    row.Get("full_name"),
+		PhoneNumber: row.Get("phone_number"),
+		Address:     row.Optional("address"),
+	}
+	errs := newImportErrors()
+	errs.addValidation(imports.Validate(&req))
+
+	// 2. Duplikasi nomor HP (di file & di database)
+	if req.PhoneNumber != "" {
+		errs.addDuplicate(batch, "phone_number", req.PhoneNumber, row.Line)
+		existing, err := s.importRepo.FindCustomerByPhone(ctx, req.PhoneNumber)
+		if err != nil && !errors.Is(err, response.ErrNotFound) {
+			return nil, err
+		}
+		if existing != nil {
+			errs.add("phone_number", "already exists")
+		}
+	}
+
+	item := newImportRow(row, req.PhoneNumber, errs)
+	if item.result.Status == models.ImportRowValid {
+		item.write = func(ctx context.Context, tx *sql.Tx) (int64, error) {
+			customer := &models.Customer{
+				FullName:    req.FullName,
+				PhoneNumber: req.PhoneNumber,
+				Address:     req.Address,
+				IsActive:    true,
+				CreatedAt:   time.Now(),
+			}
+			err := s.importRepo.InsertCustomerTx(ctx, tx, customer)
+			return customer.ID, err
+		}
+	}
+	return item, nil
+}
+
+// findCategory mencari kategori lewat nama dengan cache per import. Nil jika tidak ditemukan.
+func (s *importService) findCategory(ctx context.Context, name string, batch *importBatch) (*models.ServiceCategory, error) {
+	key := strings.ToLower(name)
+	if category, ok := batch.categories[key]; ok {
+		return category, nil
+	}
+
+	category, err := s.categoryRepo.FindByName(ctx, name)
+	if err != nil && !errors.Is(err, response.ErrNotFound) {
+		return nil, err
+	}
+	batch.categories[key] = category
+	return category, nil
+}
+
+// --- HELPER FUNCTION ---
+
+// importErrors mengumpulkan pesan gagal per baris. Kolom yang sudah punya pesan (cth: harga bukan angka)
+// tidak diberi pesan validator lagi agar laporan tidak berisi dua alasan untuk satu sel.
+type importErrors struct {
+	messages []string
+	columns  map[string]bool
+}
+
+func newImportErrors() *importErrors {
+	return &importErrors{columns: map[string]bool{}}
+}
+
+func (e *importErrors) add(column, message string) {
+	e.messages = append(e.messages, column+": "+message)
+	e.columns[column] = true
+}
+
+// skip menandai kolom yang pesan validatornya tidak perlu ditampilkan.
+func (e *importErrors) skip(column string) {
+	e.columns[column] = true
+}
+
+// addValidation menambahkan pesan imports.Validate ("kolom: pesan") untuk kolom yang belum punya pesan.
+func (e *importErrors) addValidation(messages []string) {
+	for _, msg := range messages {
+		column, _, _ := strings.Cut(msg, ":")
+		if !e.columns[column] {
+			e.messages = append(e.messages, msg)
+			e.columns[column] = true
+		}
+	}
+}
+
+// addDuplicate menandai nilai yang sudah muncul di baris sebelumnya dalam file yang sama
+// (perbandingan tanpa membedakan huruf besar/kecil, sama seperti collation database).
+func (e *importErrors) addDuplicate(batch *importBatch, column, value string, line int) {
+	key := column + ":" + strings.ToLower(value)
+	if first, ok := batch.seen[key]; ok {
+		e.add(column, fmt.Sprintf("duplicates line %d", first))
+		return
+	}
+	batch.seen[key] = line
+}
+
+// newImportRow menyusun hasil baris; write diisi pemanggil hanya jika status valid.
+func newImportRow(row imports.Row, key string, errs *importErrors) *importRow {
+	item := &importRow{result: dto.ImportRowResult{Line: row.Line, Key: key}}
+	if len(errs.messages) > 0 {
+		item.result.Status = models.ImportRowInvalid
+		item.result.Errors = errs.messages
+		return item
+	}
+
+	item.result.Status = models.ImportRowValid
+	return item
+}
+
+func collectImportResults(rows []importRow) []dto.ImportRowResult {
+	results := make([]dto.ImportRowResult, 0, len(rows))
+	for _, r := range rows {
+		results = append(results, r.result)
+	}
+	return results
+}
@@ -0,0 +1,446 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/quantity"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// InventoryService defines the contract for consumables inventory: items, stock movements and per-service recipes.
+type InventoryService interface {
+
+	// Items
+	CreateItem(ctx context.Context, req dto.CreateInventoryItemRequest) (*dto.InventoryItemResponse, error)
+	GetItemList(ctx context.Context, status string) ([]dto.InventoryItemResponse, error)
+	GetItemDetail(ctx context.Context, id int64) (*dto.InventoryItemResponse, error)
+	ModifyItem(ctx context.Context, targetID int64, req dto.UpdateInventoryItemRequest) (*dto.InventoryItemResponse, error)
+	DeactivateItem(ctx context.Context, targetID int64) error
+	GetLowStock(ctx context.Context) ([]dto.LowStockResponse, error)
+
+	// Movements (outlet aktif di context)
+	RecordStockIn(ctx context.Context, itemID int64, req dto.StockInRequest, actorID int64) (*dto.InventoryMovementResponse, error)
+	AdjustStock(ctx context.Context, itemID int64, req dto.StockAdjustmentRequest, actorID int64) (*dto.InventoryMovementResponse, error)
+	GetMovements(ctx context.Context, params listquery.Params) (*dto.InventoryMovementListResponse, error)
+
+	// Recipes
+	GetServiceRecipe(ctx context.Context, serviceID int64) (*dto.ServiceRecipeResponse, error)
+	ReplaceServiceRecipe(ctx context.Context, serviceID int64, req dto.ReplaceServiceConsumptionsRequest) (*dto.ServiceRecipeResponse, error)
+
+	// ConsumeForOrderTx memotong stok sesuai resep layanan saat pesanan mulai dikerjakan (sekali per pesanan).
+	// Dipanggil di dalam transaksi perubahan status; stok boleh minus agar produksi tidak tertahan.
+	ConsumeForOrderTx(ctx context.Context, tx *sql.Tx, orderID, actorID int64) error
+}
+
+type inventoryService struct {
+	inventoryRepo repositories.InventoryRepository
+	serviceRepo   repositories.ServiceRepository
+}
+
+// NewInventoryService creates a new instance of InventoryService.
+func NewInventoryService(inventoryRepo repositories.InventoryRepository, serviceRepo repositories.ServiceRepository) InventoryService {
+	return &inventoryService{
+		inventoryRepo: inventoryRepo,
+		serviceRepo:   serviceRepo,
+	}
+}
+
+// --- ITEMS ---
+
+// CreateItem handles the creation of a new inventory item (stock starts at zero in every outlet).
+func (s *inventoryService) CreateItem(ctx context.Context, req dto.CreateInventoryItemRequest) (*dto.InventoryItemResponse, error) {
+
+	// 1. Pengecekan Duplikasi Nama
+	name := strings.TrimSpace(req.ItemName)
+	existing, _ := s.inventoryRepo.FindItemByName(ctx, name)
+	if existing != nil {
+		return nil, response.ErrDuplicate
+	}
+
+	// 2. Simpan
+	item := &models.InventoryItem{
+		ItemName:  name,
+		Unit:      strings.TrimSpace(req.Unit),
+		MinStock:  req.MinStock,
+		IsActive:  true,
+		CreatedAt: time.Now(),
+	}
+	if err := s.inventoryRepo.InsertItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return mapInventoryItem(&models.InventoryStock{Item: *item}), nil
+}
+
+// GetItemList retrieves every inventory item with its stock.
+func (s *inventoryService) GetItemList(ctx context.Context, status string) ([]dto.InventoryItemResponse, error) {
+
+	stocks, err := s.inventoryRepo.FindItems(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	itemResponses := make([]dto.InventoryItemResponse, 0, len(stocks))
+	for i := range stocks {
+		itemResponses = append(itemResponses, *mapInventoryItem(&stocks[i]))
+	}
+
+	return itemResponses, nil
+}
+
+// GetItemDetail retrieves an inventory item with its stock.
+func (s *inventoryService) GetItemDetail(ctx context.Context, id int64) (*dto.InventoryItemResponse, error) {
+
+	stock, err := s.inventoryRepo.FindItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapInventoryItem(stock), nil
+}
+
+// ModifyItem updates the master data of an inventory item (partial update). Stock only changes through movements.
+func (s *inventoryService) ModifyItem(ctx context.Context, targetID int64, req dto.UpdateInventoryItemRequest) (*dto.InventoryItemResponse, error) {
+
+	// 1. Ambil Data Lama
+	stock, err := s.inventoryRepo.FindItemByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	item := &stock.Item
+
+	// 2. Validasi & Update Nama (Jika dikirim user)
+	if req.ItemName != nil {
+		name := strings.TrimSpace(*req.ItemName)
+		if name != item.ItemName {
+			duplicateCheck, _ := s.inventoryRepo.FindItemByName(ctx, name)
+			if duplicateCheck != nil && duplicateCheck.ID != targetID {
+				return nil, response.ErrDuplicate
+			}
+			item.ItemName = name
+		}
+	}
+
+	// 3. Update Fields Lainnya
+	if req.Unit != nil {
+		item.Unit = strings.TrimSpace(*req.Unit)
+	}
+	if req.MinStock != nil {
+		item.MinStock = *req.MinStock
+	}
+	if req.IsActive != nil {
+		item.IsActive = *req.IsActive
+	}
+
+	now := time.Now()
+	item.UpdatedAt = &now
+
+	// 4. Simpan Perubahan
+	if err := s.inventoryRepo.UpdateItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return mapInventoryItem(stock), nil
+}
+
+// DeactivateItem handles soft deletion of an inventory item. Resep yang memakainya berhenti memotong stok.
+func (s *inventoryService) DeactivateItem(ctx context.Context, targetID int64) error {
+	return s.inventoryRepo.DeleteItem(ctx, targetID)
+}
+
+// GetLowStock retrieves items at or below their minimum stock, per outlet (outlet aktif / semua outlet).
+func (s *inventoryService) GetLowStock(ctx context.Context) ([]dto.LowStockResponse, error) {
+
+	stocks, err := s.inventoryRepo.FindLowStock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lowStock := make([]dto.LowStockResponse, 0, len(stocks))
+	for _, st := range stocks {
+		lowStock = append(lowStock, dto.LowStockResponse{
+			OutletID:   st.OutletID,
+			OutletName: st.OutletName,
+			ItemID:     st.Item.ID,
+			ItemName:   st.Item.ItemName,
+			Unit:       st.Item.Unit,
+			Stock:      st.Quantity,
+			MinStock:   st.Item.MinStock,
+			Shortfall:  st.Item.MinStock.Sub(st.Quantity),
+		})
+	}
+
+	return lowStock, nil
+}
+
+// --- MOVEMENTS ---
+
+// RecordStockIn adds incoming stock of an item to the active outlet.
+func (s *inventoryService) RecordStockIn(ctx context.Context, itemID int64, req dto.StockInRequest, actorID int64) (*dto.InventoryMovementResponse, error) {
+
+	reason := "Barang masuk"
+	if note := trimNote(req.Reason); note != nil {
+		reason = *note
+	}
+
+	return s.postManualMovement(ctx, itemID, models.InventoryMovementStockIn, models.InventoryIn, req.Quantity, reason, actorID)
+}
+
+// AdjustStock corrects the stock of an item at the active outlet (stock opname, damaged, lost).
+// Koreksi pengurangan tidak boleh membuat stok minus.
+func (s *inventoryService) AdjustStock(ctx context.Context, itemID int64, req dto.StockAdjustmentRequest, actorID int64) (*dto.InventoryMovementResponse, error) {
+
+	direction := models.InventoryIn
+	if req.QuantityChange < 0 {
+		direction = models.InventoryOut
+	}
+
+	return s.postManualMovement(ctx, itemID, models.InventoryMovementAdjustment, direction, req.QuantityChange.Abs(), strings.TrimSpace(req.Reason), actorID)
+}
+
+// GetMovements retrieves the stock ledger of the active outlet with pagination and filters.
+func (s *inventoryService) GetMovements(ctx context.Context, params listquery.Params) (*dto.InventoryMovementListResponse, error) {
+
+	// 1. Validasi filter tanggal
+	for _, key := range []string{"start_date", "end_date"} {
+		if value, ok := params.Filters[key]; ok {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return nil, fmt.Errorf("%w: %s must use format YYYY-MM-DD", response.ErrValidation, key)
+			}
+		}
+	}
+
+	// 2. Call Repository
+	movements, page, err := s.inventoryRepo.FindMovements(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Map to DTO
+	movementResponses := make([]dto.InventoryMovementResponse, 0, len(movements))
+	for i := range movements {
+		movementResponses = append(movementResponses, *mapInventoryMovement(&movements[i]))
+	}
+
+	return &dto.InventoryMovementListResponse{
+		Data: movementResponses,
+		Meta: page.Meta(),
+	}, nil
+}
+
+// --- RECIPES ---
+
+// GetServiceRecipe retrieves the consumption recipe of a service.
+func (s *inventoryService) GetServiceRecipe(ctx context.Context, serviceID int64) (*dto.ServiceRecipeResponse, error) {
+
+	service, err := s.serviceRepo.FindByID(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	consumptions, err := s.inventoryRepo.FindConsumptionsByService(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapServiceRecipe(&service.Service, consumptions), nil
+}
+
+// ReplaceServiceRecipe replaces the whole consumption recipe of a service (empty list = no stock deduction).
+func (s *inventoryService) ReplaceServiceRecipe(ctx context.Context, serviceID int64, req dto.ReplaceServiceConsumptionsRequest) (*dto.ServiceRecipeResponse, error) {
+
+	// 1. Pastikan layanan ada
+	service, err := s.serviceRepo.FindByID(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi bahan: harus ada, aktif, dan tidak dobel dalam satu resep
+	now := time.Now()
+	seen := make(map[int64]bool, len(req.Items))
+	consumptions := make([]models.ServiceConsumption, 0, len(req.Items))
+	for _, line := range req.Items {
+		if seen[line.InventoryItemID] {
+			return nil, fmt.Errorf("%w: inventory item %d is listed more than once", response.ErrValidation, line.InventoryItemID)
+		}
+		seen[line.InventoryItemID] = true
+
+		if _, err := s.activeItem(ctx, line.InventoryItemID); err != nil {
+			return nil, err
+		}
+		consumptions = append(consumptions, models.ServiceConsumption{
+			InventoryItemID: line.InventoryItemID,
+			QuantityPerUnit: line.QuantityPerUnit,
+			CreatedAt:       now,
+		})
+	}
+
+	// 3. Simpan resep baru (menggantikan resep lama)
+	if err := s.inventoryRepo.ReplaceConsumptions(ctx, serviceID, consumptions); err != nil {
+		return nil, err
+	}
+
+	// 4. Ambil ulang agar nama & satuan bahan ikut terisi
+	saved, err := s.inventoryRepo.FindConsumptionsByService(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapServiceRecipe(&service.Service, saved), nil
+}
+
+// --- ORDER CONSUMPTION ---
+
+// ConsumeForOrderTx deducts the recipe of every order item from the stock of the order's outlet.
+// Pesanan yang sudah pernah dipotong (mis. status dimundurkan owner lalu dimajukan lagi) dilewati.
+func (s *inventoryService) ConsumeForOrderTx(ctx context.Context, tx *sql.Tx, orderID, actorID int64) error {
+
+	// 1. Sekali per pesanan (baris pesanan sudah dikunci oleh pemanggil)
+	consumed, err := s.inventoryRepo.HasOrderConsumptionTx(ctx, tx, orderID)
+	if err != nil || consumed {
+		return err
+	}
+
+	// 2. Hitung pemakaian dari resep x berat/jumlah item
+	consumptions, err := s.inventoryRepo.FindOrderConsumptionsTx(ctx, tx, orderID)
+	if err != nil {
+		return err
+	}
+
+	// 3. Catat ke ledger (urut ID bahan, stok boleh minus)
+	now := time.Now()
+	reason := fmt.Sprintf("Pemakaian pesanan #%d", orderID)
+	for _, c := range consumptions {
+		movement := &models.InventoryMovement{
+			OutletID:        c.OutletID,
+			InventoryItemID: c.InventoryItemID,
+			MovementType:    models.InventoryMovementConsumption,
+			Direction:       models.InventoryOut,
+			Quantity:        c.Quantity,
+			OrderID:         &orderID,
+			Reason:          reason,
+			ActorID:         &actorID,
+			CreatedAt:       now,
+		}
+		if err := s.inventoryRepo.PostMovementTx(ctx, tx, movement, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+// postManualMovement mencatat barang masuk / koreksi stok di outlet aktif.
+func (s *inventoryService) postManualMovement(ctx context.Context, itemID int64, movementType, direction string, qty quantity.Quantity, reason string, actorID int64) (*dto.InventoryMovementResponse, error) {
+
+	// 1. Stok selalu milik satu outlet (owner mode konsolidasi harus memilih outlet dulu)
+	outletID := outlet.FromContext(ctx)
+	if outletID == outlet.All {
+		return nil, fmt.Errorf("%w: switch to an outlet before changing stock", response.ErrValidation)
+	}
+
+	// 2. Validasi bahan & jumlah (presisi 3 desimal)
+	item, err := s.activeItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if !qty.IsPositive() {
+		return nil, fmt.Errorf("%w: quantity must be at least 0.001", response.ErrValidation)
+	}
+
+	// 3. Catat ke ledger (stok berjalan dikunci & diperbarui di transaksi yang sama)
+	movement := &models.InventoryMovement{
+		OutletID:        outletID,
+		InventoryItemID: item.ID,
+		ItemName:        item.ItemName,
+		Unit:            item.Unit,
+		MovementType:    movementType,
+		Direction:       direction,
+		Quantity:        qty,
+		Reason:          reason,
+		ActorID:         &actorID,
+		CreatedAt:       time.Now(),
+	}
+	if err := s.inventoryRepo.PostMovement(ctx, movement, false); err != nil {
+		return nil, err
+	}
+
+	return mapInventoryMovement(movement), nil
+}
+
+// activeItem memastikan bahan ada dan masih aktif.
+func (s *inventoryService) activeItem(ctx context.Context, id int64) (*models.InventoryItem, error) {
+	stock, err := s.inventoryRepo.FindItemByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return nil, fmt.Errorf("%w: inventory item %d not found", response.ErrValidation, id)
+		}
+		return nil, err
+	}
+	if !stock.Item.IsActive {
+		return nil, fmt.Errorf("%w: inventory item %d is not active", response.ErrValidation, id)
+	}
+	return &stock.Item, nil
+}
+
+func mapInventoryItem(st *models.InventoryStock) *dto.InventoryItemResponse {
+	return &dto.InventoryItemResponse{
+		ID:        st.Item.ID,
+		ItemName:  st.Item.ItemName,
+		Unit:      st.Item.Unit,
+		MinStock:  st.Item.MinStock,
+		Stock:     st.Quantity,
+		IsLow:     st.Item.MinStock > 0 && st.Quantity <= st.Item.MinStock,
+		IsActive:  st.Item.IsActive,
+		CreatedAt: st.Item.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: formatTimePtr(st.Item.UpdatedAt),
+	}
+}
+
+func mapInventoryMovement(m *models.InventoryMovement) *dto.InventoryMovementResponse {
+	return &dto.InventoryMovementResponse{
+		ID:              m.ID,
+		OutletID:        m.OutletID,
+		InventoryItemID: m.InventoryItemID,
+		ItemName:        m.ItemName,
+		Unit:            m.Unit,
+		MovementType:    m.MovementType,
+		Direction:       m.Direction,
+		Quantity:        m.Quantity,
+		BalanceBefore:   m.BalanceBefore,
+		BalanceAfter:    m.BalanceAfter,
+		OrderID:         m.OrderID,
+		Reason:          m.Reason,
+		ActorID:         m.ActorID,
+		CreatedAt:       m.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func mapServiceRecipe(service *models.Service, consumptions []models.ServiceConsumption) *dto.ServiceRecipeResponse {
+	res := &dto.ServiceRecipeResponse{
+		ServiceID:   service.ID,
+		ServiceName: service.ServiceName,
+		ServiceUnit: service.Unit,
+		Items:       make([]dto.ServiceConsumptionResponse, 0, len(consumptions)),
+	}
+	for _, c := range consumptions {
+		res.Items = append(res.Items, dto.ServiceConsumptionResponse{
+			InventoryItemID: c.InventoryItemID,
+			ItemName:        c.ItemName,
+			Unit:            c.Unit,
+			QuantityPerUnit: c.QuantityPerUnit,
+		})
+	}
+	return res
+}
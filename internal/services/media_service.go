@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/media"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/internal/storage"
+	"laundry-backend/pkg/response"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MediaService defines the contract for photos attached to deliveries (bukti antar), order items (kondisi item),
+// and users. File disimpan di storage.Storage, klien hanya menerima URL bertanda tangan yang kedaluwarsa.
+type MediaService interface {
+	UploadMedia(ctx context.Context, req dto.UploadMediaRequest, data []byte, actorID int64, actorRole string) (*dto.MediaResponse, error)
+	GetOwnerMedia(ctx context.Context, query dto.MediaOwnerQuery, actorID int64, actorRole string) ([]dto.MediaResponse, error)
+	GetMediaDetail(ctx context.Context, id, actorID int64, actorRole string) (*dto.MediaResponse, error)
+	RemoveMedia(ctx context.Context, id, actorID int64, actorRole string) error
+
+	// OpenSignedFile membuka file dari URL bertanda tangan driver local (GET /media/files/{key}).
+	OpenSignedFile(ctx context.Context, key string, query dto.SignedFileQuery) (io.ReadCloser, error)
+
+	// MaxUploadBytes adalah batas ukuran satu file (MEDIA_MAX_UPLOAD_MB).
+	MaxUploadBytes() int64
+}
+
+type mediaService struct {
+	mediaRepo repositories.MediaRepository
+	storage   storage.Storage
+	cfg       *config.Config
+}
+
+// NewMediaService creates a new instance of MediaService.
+func NewMediaService(mediaRepo repositories.MediaRepository, store storage.Storage, cfg *config.Config) MediaService {
+	return &mediaService{
+		mediaRepo: mediaRepo,
+		storage:   store,
+		cfg:       cfg,
+	}
+}
+
+// UploadMedia validates an image, stores it with its thumbnail, and attaches it to the owner.
+func (s *mediaService) UploadMedia(ctx context.Context, req dto.UploadMediaRequest, data []byte, actorID int64, actorRole string) (*dto.MediaResponse, error) {
+
+	// 1. Pemilik harus ada (dan di outlet aktif) serta boleh diubah oleh peminta
+	owner, err := s.mediaRepo.FindOwner(ctx, req.OwnerType, req.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeMedia(owner, actorID, actorRole); err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi ukuran, jenis file asli, dan dimensi
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", response.ErrValidation)
+	}
+	if int64(len(data)) > s.MaxUploadBytes() {
+		return nil, fmt.Errorf("%w: file exceeds %d MB", response.ErrValidation, s.cfg.MEDIA.MaxUploadMB)
+	}
+	info, err := media.Inspect(data, s.cfg.MEDIA.MinDimension, s.cfg.MEDIA.MaxDimension)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", response.ErrValidation, err)
+	}
+
+	// 3. Buat thumbnail
+	thumbnail, err := media.Thumbnail(data, s.cfg.MEDIA.ThumbnailSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", response.ErrValidation, err)
+	}
+
+	// 4. Simpan file asli & thumbnail (key acak, tidak bisa ditebak dari ID)
+	name := uuid.New().String()
+	prefix := fmt.Sprintf("%s/%d/", owner.Type, owner.ID)
+	sum := sha256.Sum256(data)
+	m := &models.Media{
+		OutletID:       owner.OutletID,
+		OwnerType:      owner.Type,
+		OwnerID:        owner.ID,
+		ObjectKey:      prefix + name + "." + info.Extension,
+		ThumbnailKey:   prefix + name + "_thumb.jpg",
+		ContentType:    info.ContentType,
+		SizeBytes:      int64(len(data)),
+		Width:          info.Width,
+		Height:         info.Height,
+		ChecksumSHA256: hex.EncodeToString(sum[:]),
+		Caption:        trimNote(req.Caption),
+		UploadedBy:     actorID,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.storage.Put(ctx, m.ObjectKey, data, m.ContentType); err != nil {
+		return nil, err
+	}
+	if err := s.storage.Put(ctx, m.ThumbnailKey, thumbnail, media.ContentTypeJPEG); err != nil {
+		s.deleteObjects(m)
+		return nil, err
+	}
+
+	// 5. Simpan metadata (file dihapus lagi jika gagal agar tidak ada file yatim)
+	if err := s.mediaRepo.InsertMedia(ctx, m); err != nil {
+		s.deleteObjects(m)
+		return nil, err
+	}
+
+	// Ambil ulang untuk nama pengunggah
+	saved, err := s.mediaRepo.FindMediaByID(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapMedia(ctx, saved)
+}
+
+// GetOwnerMedia retrieves every photo of one delivery, order item, or user with fresh signed URLs.
+func (s *mediaService) GetOwnerMedia(ctx context.Context, query dto.MediaOwnerQuery, actorID int64, actorRole string) ([]dto.MediaResponse, error) {
+
+	// 1. Cek akses ke pemilik
+	owner, err := s.mediaRepo.FindOwner(ctx, query.OwnerType, query.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeMedia(owner, actorID, actorRole); err != nil {
+		return nil, err
+	}
+
+	// 2. Ambil data & tandatangani URL
+	list, err := s.mediaRepo.FindMediaByOwner(ctx, owner.Type, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaResponses := make([]dto.MediaResponse, 0, len(list))
+	for i := range list {
+		res, err := s.mapMedia(ctx, &list[i])
+		if err != nil {
+			return nil, err
+		}
+		mediaResponses = append(mediaResponses, *res)
+	}
+
+	return mediaResponses, nil
+}
+
+// GetMediaDetail retrieves one photo with fresh signed URLs.
+func (s *mediaService) GetMediaDetail(ctx context.Context, id, actorID int64, actorRole string) (*dto.MediaResponse, error) {
+
+	m, err := s.accessibleMedia(ctx, id, actorID, actorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapMedia(ctx, m)
+}
+
+// RemoveMedia deletes a photo and its files. Selain owner hanya boleh menghapus foto yang diunggah sendiri.
+func (s *mediaService) RemoveMedia(ctx context.Context, id, actorID int64, actorRole string) error {
+
+	// 1. Cek akses
+	m, err := s.accessibleMedia(ctx, id, actorID, actorRole)
+	if err != nil {
+		return err
+	}
+	if actorRole != "owner" && m.UploadedBy != actorID {
+		return response.ErrForbidden
+	}
+
+	// 2. Hapus metadata dulu (URL lama langsung tidak bisa dibuat lagi), lalu file-nya
+	if err := s.mediaRepo.DeleteMedia(ctx, m.ID); err != nil {
+		return err
+	}
+	s.deleteObjects(m)
+
+	return nil
+}
+
+// OpenSignedFile verifies the local driver signature and opens the file.
+func (s *mediaService) OpenSignedFile(ctx context.Context, key string, query dto.SignedFileQuery) (io.ReadCloser, error) {
+
+	// 1. Hanya driver yang melayani file-nya sendiri (local)
+	verifier, ok := s.storage.(storage.SignatureVerifier)
+	if !ok {
+		return nil, response.ErrNotFound
+	}
+	if err := verifier.VerifySignature(key, query.Expires, query.Signature); err != nil {
+		return nil, response.ErrForbidden
+	}
+
+	// 2. Buka file
+	file, err := s.storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, response.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *mediaService) MaxUploadBytes() int64 {
+	return int64(s.cfg.MEDIA.MaxUploadMB) << 20
+}
+
+// --- HELPER FUNCTION ---
+
+// authorizeMedia memastikan peminta boleh melihat / menambah foto milik owner:
+//   - delivery   : owner, cashier, kurir yang ditugaskan
+//   - order_item : owner, cashier, staff
+//   - user       : owner, atau karyawan itu sendiri
+func authorizeMedia(owner *models.MediaOwner, actorID int64, actorRole string) error {
+	if actorRole == "owner" {
+		return nil
+	}
+
+	switch owner.Type {
+	case models.MediaOwnerDelivery:
+		if actorRole == "cashier" || (actorRole == "courier" && owner.CourierID != nil && *owner.CourierID == actorID) {
+			return nil
+		}
+	case models.MediaOwnerOrderItem:
+		if actorRole == "cashier" || actorRole == "staff" {
+			return nil
+		}
+	case models.MediaOwnerUser:
+		if owner.ID == actorID {
+			return nil
+		}
+	}
+	return response.ErrForbidden
+}
+
+// accessibleMedia mengambil foto lalu mengecek akses lewat pemiliknya (termasuk batas outlet aktif).
+func (s *mediaService) accessibleMedia(ctx context.Context, id, actorID int64, actorRole string) (*models.Media, error) {
+	m, err := s.mediaRepo.FindMediaByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.mediaRepo.FindOwner(ctx, m.OwnerType, m.OwnerID)
+	if err != nil {
+		return nil, err // Pemilik di outlet lain = foto dianggap tidak ada
+	}
+	if err := authorizeMedia(owner, actorID, actorRole); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// deleteObjects menghapus file asli & thumbnail (best effort, kegagalan hanya dicatat).
+func (s *mediaService) deleteObjects(m *models.Media) {
+	ctx := context.Background() // Tetap dihapus walau request sudah dibatalkan klien
+	for _, key := range []string{m.ObjectKey, m.ThumbnailKey} {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			fmt.Printf("[ERROR] MediaStorage.Delete %s: %v\n", key, err)
+		}
+	}
+}
+
+func (s *mediaService) mapMedia(ctx context.Context, m *models.Media) (*dto.MediaResponse, error) {
+	ttl := time.Duration(max(s.cfg.MEDIA.SignedURLTTLMin, 1)) * time.Minute
+	expiresAt := time.Now().Add(ttl)
+
+	url, err := s.storage.SignedURL(ctx, m.ObjectKey, ttl)
+	if err != nil {
+		return nil, err
+	}
+	thumbnailURL, err := s.storage.SignedURL(ctx, m.ThumbnailKey, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MediaResponse{
+		ID:             m.ID,
+		OwnerType:      m.OwnerType,
+		OwnerID:        m.OwnerID,
+		OutletID:       m.OutletID,
+		ContentType:    m.ContentType,
+		SizeBytes:      m.SizeBytes,
+		Width:          m.Width,
+		Height:         m.Height,
+		ChecksumSHA256: m.ChecksumSHA256,
+		Caption:        m.Caption,
+		URL:            url,
+		ThumbnailURL:   thumbnailURL,
+		URLExpiresAt:   expiresAt.Format("2006-01-02 15:04:05"),
+		UploadedBy:     m.UploadedBy,
+		UploadedByName: m.UploadedByName,
+		CreatedAt:      m.CreatedAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// MembershipService defines the contract for business logic related to membership tiers.
+type MembershipService interface {
+	CreateTier(ctx context.Context, req dto.CreateMembershipTierRequest) (*dto.MembershipTierResponse, error)
+	GetTierList(ctx context.Context, status string) ([]dto.MembershipTierResponse, error)
+	GetTierDetail(ctx context.Context, id int64) (*dto.MembershipTierResponse, error)
+	ModifyTier(ctx context.Context, targetID int64, req dto.UpdateMembershipTierRequest) (*dto.MembershipTierResponse, error)
+	DeactivateTier(ctx context.Context, targetID int64) error
+
+	// AssignCustomerTier mengubah (atau mencabut jika nil) level member seorang pelanggan.
+	AssignCustomerTier(ctx context.Context, customerID int64, req dto.AssignMembershipRequest) error
+}
+
+type membershipService struct {
+	membershipRepo repositories.MembershipRepository
+}
+
+// NewMembershipService creates a new instance of MembershipService.
+func NewMembershipService(membershipRepo repositories.MembershipRepository) MembershipService {
+	return &membershipService{membershipRepo: membershipRepo}
+}
+
+// CreateTier handles the creation of a new membership tier.
+func (s *membershipService) CreateTier(ctx context.Context, req dto.CreateMembershipTierRequest) (*dto.MembershipTierResponse, error) {
+
+	// 1. Pengecekan Duplikasi Nama (Harus unik)
+	existingName, _ := s.membershipRepo.FindByName(ctx, req.TierName)
+	if existingName != nil {
+		return nil, response.ErrDuplicate
+	}
+
+	// 2. Siapkan Model (pengali poin default 1x)
+	tierModel := &models.MembershipTier{
+		TierName:        req.TierName,
+		DiscountPercent: req.DiscountPercent,
+		MaxDiscount:     req.MaxDiscount,
+		PointMultiplier: 1,
+		IsActive:        true,
+		CreatedAt:       time.Now(),
+	}
+	if req.PointMultiplier != nil {
+		tierModel.PointMultiplier = *req.PointMultiplier
+	}
+
+	// 3. Insert ke Database
+	if err := s.membershipRepo.InsertTier(ctx, tierModel); err != nil {
+		return nil, err
+	}
+
+	return mapMembershipTier(tierModel), nil
+}
+
+// GetTierList retrieves every membership tier.
+func (s *membershipService) GetTierList(ctx context.Context, status string) ([]dto.MembershipTierResponse, error) {
+
+	tiers, err := s.membershipRepo.FindAll(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	tierResponses := make([]dto.MembershipTierResponse, 0, len(tiers))
+	for i := range tiers {
+		tierResponses = append(tierResponses, *mapMembershipTier(&tiers[i]))
+	}
+
+	return tierResponses, nil
+}
+
+// GetTierDetail retrieves a membership tier by ID.
+func (s *membershipService) GetTierDetail(ctx context.Context, id int64) (*dto.MembershipTierResponse, error) {
+
+	tier, err := s.membershipRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapMembershipTier(tier), nil
+}
+
+// ModifyTier updates membership tier data with validation logic.
+func (s *membershipService) ModifyTier(ctx context.Context, targetID int64, req dto.UpdateMembershipTierRequest) (*dto.MembershipTierResponse, error) {
+
+	// 1. Ambil Data Level yang Lama
+	tier, err := s.membershipRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi & Update Nama (Jika dikirim user)
+	if req.TierName != nil && *req.TierName != tier.TierName {
+		duplicateCheck, _ := s.membershipRepo.FindByName(ctx, *req.TierName)
+		if duplicateCheck != nil && duplicateCheck.ID != targetID {
+			return nil, response.ErrDuplicate
+		}
+		tier.TierName = *req.TierName
+	}
+
+	// 3. Update Fields Lainnya (Partial Update)
+	if req.DiscountPercent != nil {
+		tier.DiscountPercent = *req.DiscountPercent
+	}
+	if req.MaxDiscount != nil {
+		tier.MaxDiscount = req.MaxDiscount
+	}
+	if req.PointMultiplier != nil {
+		tier.PointMultiplier = *req.PointMultiplier
+	}
+	if req.IsActive != nil {
+		tier.IsActive = *req.IsActive
+	}
+
+	// 4. Update Waktu (Timestamp)
+	now := time.Now()
+	tier.UpdatedAt = &now
+
+	// 5. Simpan Perubahan ke Database
+	if err := s.membershipRepo.UpdateTier(ctx, tier); err != nil {
+		return nil, err
+	}
+
+	return mapMembershipTier(tier), nil
+}
+
+// DeactivateTier handles soft deletion of a membership tier.
+// Pelanggan yang masih memakai level ini otomatis tidak mendapat diskon member.
+func (s *membershipService) DeactivateTier(ctx context.Context, targetID int64) error {
+
+	// 1. Cek apakah level tersebut ada
+	if _, err := s.membershipRepo.FindByID(ctx, targetID); err != nil {
+		return err
+	}
+
+	// 2. Eksekusi Soft Delete
+	return s.membershipRepo.DeleteTier(ctx, targetID)
+}
+
+// AssignCustomerTier sets or clears the membership tier of a customer.
+func (s *membershipService) AssignCustomerTier(ctx context.Context, customerID int64, req dto.AssignMembershipRequest) error {
+
+	// 1. Level yang dipilih harus ada dan aktif
+	if req.MembershipTierID != nil {
+		tier, err := s.membershipRepo.FindByID(ctx, *req.MembershipTierID)
+		if err != nil {
+			if errors.Is(err, response.ErrNotFound) {
+				return fmt.Errorf("%w: membership tier %d not found", response.ErrValidation, *req.MembershipTierID)
+			}
+			return err
+		}
+		if !tier.IsActive {
+			return fmt.Errorf("%w: membership tier %d is not active", response.ErrValidation, tier.ID)
+		}
+	}
+
+	// 2. Simpan ke pelanggan
+	return s.membershipRepo.AssignCustomerTier(ctx, customerID, req.MembershipTierID)
+}
+
+// --- HELPER FUNCTION ---
+
+func mapMembershipTier(tier *models.MembershipTier) *dto.MembershipTierResponse {
+	return &dto.MembershipTierResponse{
+		ID:              tier.ID,
+		TierName:        tier.TierName,
+		DiscountPercent: tier.DiscountPercent,
+		MaxDiscount:     tier.MaxDiscount,
+		PointMultiplier: tier.PointMultiplier,
+		IsActive:        tier.IsActive,
+		CreatedAt:       tier.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       formatTimePtr(tier.UpdatedAt),
+	}
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/notification"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"laundry-backend/pkg/utils"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Pengaturan worker notifikasi
+const (
+	notificationLease    = 2 * time.Minute // Lama pesan 'processing' dipegang satu worker sebelum boleh diambil ulang
+	notificationMaxDelay = 6 * time.Hour   // Batas atas jeda retry
+)
+
+// NotificationService defines the contract for customer notification templates and the outbox worker.
+type NotificationService interface {
+	GetTemplates(ctx context.Context) ([]dto.NotificationTemplateResponse, error)
+	UpdateTemplate(ctx context.Context, eventType string, req dto.UpdateNotificationTemplateRequest, actorID int64) (*dto.NotificationTemplateResponse, error)
+	PreviewTemplate(ctx context.Context, req dto.PreviewNotificationTemplateRequest) (*dto.NotificationPreviewResponse, error)
+
+	GetOutbox(ctx context.Context, query dto.NotificationOutboxQuery, page, perPage int) (*dto.OutboxListResponse, error)
+	RetryMessage(ctx context.Context, id int64) (*dto.OutboxMessageResponse, error)
+
+	// --- Hook untuk transaksi pesanan/pembayaran (dipanggil di dalam tx milik pemanggil) ---
+
+	// EnqueueTx menulis event ke outbox. Pesanan tanpa nomor HP dilewati tanpa error.
+	EnqueueTx(ctx context.Context, tx *sql.Tx, orderID int64, eventType string) error
+
+	// EnqueueStatusTx menulis event yang sesuai dengan status baru (tidak melakukan apa-apa untuk status lain).
+	EnqueueStatusTx(ctx context.Context, tx *sql.Tx, orderID int64, status string) error
+
+	// --- Worker ---
+
+	// DispatchDue mengirim satu batch pesan yang jatuh tempo dan mengembalikan jumlah pesan yang diproses.
+	DispatchDue(ctx context.Context) (int, error)
+
+	// RunWorker menjalankan DispatchDue berkala sampai ctx dibatalkan.
+	RunWorker(ctx context.Context)
+}
+
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	notifier         notification.Notifier
+	cfg              *config.Config
+}
+
+// NewNotificationService creates a new instance of NotificationService.
+func NewNotificationService(notificationRepo repositories.NotificationRepository, notifier notification.Notifier, cfg *config.Config) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		notifier:         notifier,
+		cfg:              cfg,
+	}
+}
+
+// GetTemplates retrieves the template of every notification event.
+func (s *notificationService) GetTemplates(ctx context.Context) ([]dto.NotificationTemplateResponse, error) {
+
+	templates, err := s.notificationRepo.FindTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.NotificationTemplateResponse, 0, len(templates))
+	for _, tmpl := range templates {
+		res = append(res, mapToNotificationTemplateResponse(tmpl))
+	}
+
+	return res, nil
+}
+
+// UpdateTemplate changes the body and/or active flag of a template.
+// The body is rendered against sample data first so a broken template never reaches the worker.
+func (s *notificationService) UpdateTemplate(ctx context.Context, eventType string, req dto.UpdateNotificationTemplateRequest, actorID int64) (*dto.NotificationTemplateResponse, error) {
+
+	// 1. Ambil template lama
+	tmpl, err := s.notificationRepo.FindTemplate(ctx, eventType)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi & terapkan perubahan
+	if req.Body != nil {
+		body := strings.TrimSpace(*req.Body)
+		if _, err := notification.Render(body, notification.SampleData(s.shop())); err != nil {
+			return nil, fmt.Errorf("%w: %v", response.ErrValidation, err)
+		}
+		tmpl.Body = body
+	}
+	if req.IsActive != nil {
+		tmpl.IsActive = *req.IsActive
+	}
+
+	now := time.Now()
+	tmpl.UpdatedBy = &actorID
+	tmpl.UpdatedAt = &now
+
+	// 3. Simpan
+	if err := s.notificationRepo.UpdateTemplate(ctx, tmpl); err != nil {
+		return nil, err
+	}
+
+	res := mapToNotificationTemplateResponse(*tmpl)
+	return &res, nil
+}
+
+// PreviewTemplate renders a template body against sample data without saving it.
+func (s *notificationService) PreviewTemplate(ctx context.Context, req dto.PreviewNotificationTemplateRequest) (*dto.NotificationPreviewResponse, error) {
+
+	message, err := notification.Render(req.Body, notification.SampleData(s.shop()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", response.ErrValidation, err)
+	}
+
+	return &dto.NotificationPreviewResponse{Message: message}, nil
+}
+
+// GetOutbox retrieves queued and delivered messages with pagination.
+func (s *notificationService) GetOutbox(ctx context.Context, query dto.NotificationOutboxQuery, page, perPage int) (*dto.OutboxListResponse, error) {
+
+	offset := (page - 1) * perPage
+	messages, totalItems, err := s.notificationRepo.FindOutbox(ctx, query.Status, query.OrderID, perPage, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.OutboxListResponse{
+		Data: make([]dto.OutboxMessageResponse, 0, len(messages)),
+		Meta: response.NewPageMeta(page, perPage, totalItems),
+	}
+	for _, msg := range messages {
+		res.Data = append(res.Data, mapToOutboxMessageResponse(msg))
+	}
+
+	return res, nil
+}
+
+// RetryMessage puts a failed or skipped message back into the queue.
+func (s *notificationService) RetryMessage(ctx context.Context, id int64) (*dto.OutboxMessageResponse, error) {
+
+	// 1. Hanya pesan yang sudah berhenti dicoba yang boleh diantrekan ulang
+	msg, err := s.notificationRepo.FindOutboxByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Status != models.OutboxFailed && msg.Status != models.OutboxSkipped {
+		return nil, fmt.Errorf("%w: only failed or skipped messages can be retried (current status: %s)", response.ErrValidation, msg.Status)
+	}
+
+	// 2. Reset percobaan
+	if err := s.notificationRepo.Requeue(ctx, id, time.Now()); err != nil {
+		return nil, err
+	}
+
+	msg, err = s.notificationRepo.FindOutboxByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := mapToOutboxMessageResponse(*msg)
+	return &res, nil
+}
+
+// EnqueueTx snapshots the order data and appends a message to the outbox inside the caller's transaction.
+func (s *notificationService) EnqueueTx(ctx context.Context, tx *sql.Tx, orderID int64, eventType string) error {
+
+	if !slices.Contains(notification.Events, eventType) {
+		return fmt.Errorf("notificationService.EnqueueTx: unknown event %q", eventType)
+	}
+
+	// 1. Ambil data pesanan terbaru (termasuk perubahan di tx yang sama)
+	contact, err := s.notificationRepo.FindContactTx(ctx, tx, orderID)
+	if err != nil {
+		return err
+	}
+
+	// 2. Pesanan tanpa nomor HP valid tidak bisa dikabari
+	recipient := ""
+	if contact.Phone != nil {
+		recipient = notification.NormalizePhone(*contact.Phone)
+	}
+	if recipient == "" {
+		return nil
+	}
+
+	// 3. Simpan snapshot placeholder sebagai payload
+	payload, err := json.Marshal(notification.NewTemplateData(*contact, s.shop()))
+	if err != nil {
+		return fmt.Errorf("notificationService.EnqueueTx.Marshal: %w", err)
+	}
+
+	now := time.Now()
+	return s.notificationRepo.EnqueueTx(ctx, tx, &models.OutboxMessage{
+		EventType:     eventType,
+		OrderID:       orderID,
+		Recipient:     recipient,
+		Payload:       payload,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+}
+
+// EnqueueStatusTx enqueues the event that belongs to a new order status, if any.
+func (s *notificationService) EnqueueStatusTx(ctx context.Context, tx *sql.Tx, orderID int64, status string) error {
+	eventType, ok := notification.EventForStatus(status)
+	if !ok {
+		return nil
+	}
+	return s.EnqueueTx(ctx, tx, orderID, eventType)
+}
+
+// DispatchDue claims one batch of due messages and delivers them through the configured Notifier.
+func (s *notificationService) DispatchDue(ctx context.Context) (int, error) {
+
+	// 1. Ambil pesan yang jatuh tempo
+	now := time.Now()
+	messages, err := s.notificationRepo.ClaimDue(ctx, now, now.Add(notificationLease), s.batchSize())
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. Template dibaca sekali per batch
+	templates, err := s.notificationRepo.FindTemplates(ctx)
+	if err != nil {
+		return 0, err
+	}
+	byEvent := make(map[string]models.NotificationTemplate, len(templates))
+	for _, tmpl := range templates {
+		byEvent[tmpl.EventType] = tmpl
+	}
+
+	// 3. Kirim satu per satu; kegagalan satu pesan tidak menghentikan batch
+	for _, msg := range messages {
+		if err := s.deliver(ctx, msg, byEvent); err != nil {
+			fmt.Printf("[ERROR] NotificationWorker #%d: %v\n", msg.ID, err)
+		}
+	}
+
+	return len(messages), nil
+}
+
+// RunWorker polls the outbox until ctx is cancelled. A full batch is followed immediately by the next one.
+func (s *notificationService) RunWorker(ctx context.Context) {
+	interval := time.Duration(s.cfg.NOTIFICATION.PollIntervalSec) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			processed, err := s.DispatchDue(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Printf("[ERROR] NotificationWorker: %v\n", err)
+				}
+				break
+			}
+			if processed < s.batchSize() {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliver renders and sends one message, then records the outcome.
+//
+// Hasil akhir:
+//   - template nonaktif/tidak ada → skipped
+//   - template rusak, ditolak gateway, atau batas percobaan habis → failed
+//   - gagal sementara → pending lagi dengan jeda Backoff
+func (s *notificationService) deliver(ctx context.Context, msg models.OutboxMessage, templates map[string]models.NotificationTemplate) error {
+
+	// 1. Pastikan template aktif
+	tmpl, ok := templates[msg.EventType]
+	if !ok || !tmpl.IsActive {
+		reason := "template is inactive"
+		return s.notificationRepo.MarkDone(ctx, msg.ID, models.OutboxSkipped, nil, &reason)
+	}
+
+	// 2. Render pesan dari snapshot payload
+	var data notification.TemplateData
+	if err := json.Unmarshal(msg.Payload, &data); err != nil {
+		reason := fmt.Sprintf("invalid payload: %v", err)
+		return s.notificationRepo.MarkDone(ctx, msg.ID, models.OutboxFailed, nil, &reason)
+	}
+	message, err := notification.Render(tmpl.Body, data)
+	if err != nil {
+		reason := err.Error()
+		return s.notificationRepo.MarkDone(ctx, msg.ID, models.OutboxFailed, nil, &reason)
+	}
+
+	// 3. Kirim
+	sendErr := s.notifier.Send(ctx, notification.Message{To: msg.Recipient, Body: message})
+	if sendErr == nil {
+		return s.notificationRepo.MarkSent(ctx, msg.ID, s.notifier.Channel(), message, time.Now())
+	}
+
+	// 4. Tentukan retry atau berhenti
+	reason := sendErr.Error()
+	if errors.Is(sendErr, notification.ErrRejected) || msg.Attempts >= s.cfg.NOTIFICATION.MaxAttempts {
+		return s.notificationRepo.MarkDone(ctx, msg.ID, models.OutboxFailed, &message, &reason)
+	}
+
+	base := time.Duration(s.cfg.NOTIFICATION.RetryBaseSec) * time.Second
+	return s.notificationRepo.MarkRetry(ctx, msg.ID, reason, time.Now().Add(utils.Backoff(base, msg.Attempts, notificationMaxDelay)))
+}
+
+func (s *notificationService) batchSize() int {
+	if s.cfg.NOTIFICATION.BatchSize < 1 {
+		return 1
+	}
+	return s.cfg.NOTIFICATION.BatchSize
+}
+
+func (s *notificationService) shop() notification.Shop {
+	return notification.Shop{
+		Name:            s.cfg.SHOP.Name,
+		Phone:           s.cfg.SHOP.Phone,
+		TrackingBaseURL: s.cfg.SHOP.TrackingBaseURL,
+	}
+}
+
+func mapToNotificationTemplateResponse(tmpl models.NotificationTemplate) dto.NotificationTemplateResponse {
+	return dto.NotificationTemplateResponse{
+		EventType: tmpl.EventType,
+		Body:      tmpl.Body,
+		IsActive:  tmpl.IsActive,
+		UpdatedBy: tmpl.UpdatedBy,
+		CreatedAt: tmpl.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: formatTimePtr(tmpl.UpdatedAt),
+	}
+}
+
+func mapToOutboxMessageResponse(msg models.OutboxMessage) dto.OutboxMessageResponse {
+	return dto.OutboxMessageResponse{
+		ID:            msg.ID,
+		EventType:     msg.EventType,
+		OrderID:       msg.OrderID,
+		Recipient:     msg.Recipient,
+		Status:        msg.Status,
+		Channel:       msg.Channel,
+		Message:       msg.Message,
+		Attempts:      msg.Attempts,
+		NextAttemptAt: msg.NextAttemptAt.Format("2006-01-02 15:04:05"),
+		LastError:     msg.LastError,
+		SentAt:        formatTimePtr(msg.SentAt),
+		CreatedAt:     msg.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
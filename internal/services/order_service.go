@@ -0,0 +1,703 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/pricing"
+	"laundry-backend/internal/promotion"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/internal/webhook"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// OrderService defines the contract for order creation (checkout at the cashier).
+type OrderService interface {
+
+	// CreateOrder menyimpan pesanan baru beserta pelanggan, item, pengantaran, tagihan, dan riwayat status
+	// dalam satu transaksi. Harga item selalu dihitung ulang oleh kalkulator harga dari data database.
+	CreateOrder(ctx context.Context, req dto.CreateOrderRequest, actorID int64, actorRole string) (*dto.OrderDetailResponse, error)
+
+	// GetOrders mengambil satu halaman daftar pesanan outlet aktif; ExportOrders menulis semua baris yang cocok ke w.
+	GetOrders(ctx context.Context, params listquery.Params) (*dto.OrderListResponse, error)
+	ExportOrders(ctx context.Context, params listquery.Params, w export.Writer) error
+}
+
+type orderService struct {
+	orderRepo           repositories.OrderRepository
+	orderStatusRepo     repositories.OrderStatusRepository
+	shiftRepo           repositories.ShiftRepository
+	pricingRuleService  PricingRuleService
+	promotionService    PromotionService
+	taxService          TaxService
+	capacityService     CapacityService
+	walletService       WalletService
+	notificationService NotificationService
+	webhookService      WebhookService
+	cfg                 *config.Config
+}
+
+// NewOrderService creates a new instance of OrderService.
+func NewOrderService(orderRepo repositories.OrderRepository, orderStatusRepo repositories.OrderStatusRepository, shiftRepo repositories.ShiftRepository, pricingRuleService PricingRuleService, promotionService PromotionService, taxService TaxService, capacityService CapacityService, walletService WalletService, notificationService NotificationService, webhookService WebhookService, cfg *config.Config) OrderService {
+	return &orderService{
+		orderRepo:           orderRepo,
+		orderStatusRepo:     orderStatusRepo,
+		shiftRepo:           shiftRepo,
+		pricingRuleService:  pricingRuleService,
+		promotionService:    promotionService,
+		taxService:          taxService,
+		capacityService:     capacityService,
+		walletService:       walletService,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+		cfg:                 cfg,
+	}
+}
+
+// CreateOrder handles checkout: pricing, ETA, and every row of a new order in one transaction.
+func (s *orderService) CreateOrder(ctx context.Context, req dto.CreateOrderRequest, actorID int64, actorRole string) (*dto.OrderDetailResponse, error) {
+
+	now := time.Now()
+
+	// 1. Pesanan selalu milik satu outlet (antrean, kas, dan nomor nota per outlet aktif)
+	outletID, err := requireOutlet(ctx, "creating an order")
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Cari pelanggan (master) atau siapkan pelanggan baru
+	customer, newCustomer, err := s.resolveCustomer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Hitung harga setiap item dengan kalkulator harga (aturan harga & add-on dari database)
+	quoteItems, err := buildQuoteItems(req.OrderItems)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := s.pricingRuleService.QuoteItems(ctx, quoteItems)
+	if err != nil {
+		return nil, err
+	}
+	items, err := buildOrderItems(req.OrderItems, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Terapkan diskon member, promo otomatis, dan kode voucher (pelanggan baru belum punya riwayat kuota)
+	var discountCustomerID *int64
+	if customer != nil {
+		discountCustomerID = &customer.ID
+	}
+	discounts, err := s.promotionService.ResolveDiscounts(ctx, quote, req.VoucherCode, discountCustomerID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return nil, fmt.Errorf("%w: voucher code not found", response.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	// Penukaran poin mengurangi nilai kena pajak, sama seperti diskon lain
+	if req.RedeemPoints > 0 {
+		if customer == nil {
+			return nil, fmt.Errorf("%w: redeem_points requires a registered customer", response.ErrValidation)
+		}
+		if err := applyPointsDiscount(discounts, req.RedeemPoints, money.New(int64(s.cfg.LOYALTY.PointValue))); err != nil {
+			return nil, err
+		}
+	}
+
+	// 5. Hitung service charge & pajak dari nilai setelah diskon (sama dengan POST /tax-rates/preview)
+	totals, err := s.taxService.ResolveTotals(ctx, quote, discounts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. Validasi pengantaran
+	isDelivery := req.IsDelivery == 1
+	if isDelivery && req.Deliveries == nil {
+		return nil, fmt.Errorf("%w: deliveries.shipping_cost is required when is_delivery is 1", response.ErrValidation)
+	}
+	if isDelivery && req.Deliveries.ShippingCost.IsNegative() {
+		return nil, fmt.Errorf("%w: shipping_cost cannot be negative", response.ErrValidation)
+	}
+
+	// 7. Estimasi selesai dari antrean outlet (sama dengan yang dijanjikan POST /orders/quote)
+	estimate, err := s.capacityService.EstimateReadyAt(ctx, outletID, now, quote)
+	if err != nil {
+		return nil, err
+	}
+	readyAt, err := time.ParseInLocation("2006-01-02 15:04:05", estimate.EstimatedReadyAt, config.Location)
+	if err != nil {
+		return nil, fmt.Errorf("orderService.CreateOrder.ReadyAt: %w", err)
+	}
+
+	// 8. Susun nota induk
+	order := &models.Order{
+		OutletID:           outletID,
+		IsDelivery:         isDelivery,
+		Subtotal:           totals.Subtotal,
+		DiscountTotal:      totals.DiscountTotal,
+		ServiceChargeTotal: totals.ServiceChargeTotal,
+		TaxTotal:           totals.TaxTotal,
+		GrandTotal:         totals.GrandTotal,
+		StatusInternal:     models.OrderStatusPending,
+		EstimatedReadyAt:   &readyAt,
+		Notes:              trimmedOrNil(req.Notes),
+		CreatedBy:          actorID,
+		CreatedAt:          now,
+	}
+	if customer != nil {
+		order.CustomerID = &customer.ID
+		order.CustomerName, order.CustomerPhone, order.CustomerAddress = &customer.FullName, &customer.PhoneNumber, customer.Address
+	}
+	if newCustomer != nil {
+		order.CustomerName, order.CustomerPhone, order.CustomerAddress = &newCustomer.FullName, &newCustomer.PhoneNumber, newCustomer.Address
+	}
+
+	// 9. Tagihan & pembayaran di muka
+	payment, paymentStatus, err := newOrderPayment(req.Payment, order.GrandTotal, isDelivery, actorID, now)
+	if err != nil {
+		return nil, err
+	}
+	order.PaymentStatus = paymentStatus
+	if payment.Method != nil && *payment.Method == models.PaymentMethodDeposit && customer == nil {
+		return nil, fmt.Errorf("%w: deposit payments require a registered customer", response.ErrValidation)
+	}
+
+	// 10. Simpan semuanya dalam satu transaksi
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("orderService.CreateOrder.BeginTx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if newCustomer != nil {
+		if err := s.orderRepo.InsertCustomerTx(ctx, tx, newCustomer); err != nil {
+			return nil, err
+		}
+		order.CustomerID = &newCustomer.ID
+	}
+
+	if order.InvoiceNumber, err = s.orderRepo.NextInvoiceNumberTx(ctx, tx, outletID, now.In(config.Location)); err != nil {
+		return nil, err
+	}
+	if err := s.orderRepo.InsertOrderTx(ctx, tx, order); err != nil {
+		return nil, err
+	}
+	if err := s.orderRepo.InsertItemsTx(ctx, tx, order.ID, items); err != nil {
+		return nil, err
+	}
+	// Rincian pajak per tarif disimpan sebagai snapshot di order_taxes
+	if err := s.taxService.RecordTotalsTx(ctx, tx, order.ID, totals); err != nil {
+		return nil, err
+	}
+
+	// Poin dipotong dengan baris pelanggan terkunci (ErrInsufficientPoints jika saldo poin kurang)
+	if req.RedeemPoints > 0 {
+		if _, err := s.walletService.RedeemPointsTx(ctx, tx, customer.ID, order.ID, req.RedeemPoints, actorID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Kuota promosi dicek ulang dengan baris promosi terkunci (voucher terakhir tidak bisa dipakai dua kasir)
+	if err := s.promotionService.RecordRedemptions(ctx, tx, order.ID, order.CustomerID, actorID, discounts.Discounts); err != nil {
+		return nil, err
+	}
+	if isDelivery {
+		delivery := &models.Delivery{
+			OrderID:      order.ID,
+			OutletID:     outletID,
+			ShippingCost: req.Deliveries.ShippingCost,
+			CreatedAt:    now,
+		}
+		if err := s.orderRepo.InsertDeliveryTx(ctx, tx, delivery); err != nil {
+			return nil, err
+		}
+	}
+
+	// Tagihan tertaut ke shift kasir; uang tunai di muka wajib masuk laci shift yang terbuka
+	payment.OrderID, payment.OutletID = order.ID, outletID
+	if err := assignShiftTx(ctx, tx, s.shiftRepo, payment, actorID); err != nil {
+		return nil, err
+	}
+	if err := s.orderRepo.InsertPaymentTx(ctx, tx, payment); err != nil {
+		return nil, err
+	}
+
+	// Pembayaran di muka: potong deposit & tambah poin loyalitas di transaksi yang sama
+	if payment.Status == models.PaymentConfirmed {
+		if err := settleWalletTx(ctx, tx, s.walletService, order.CustomerID, payment, actorID); err != nil {
+			return nil, err
+		}
+	}
+
+	initialNote := "Initial order creation"
+	if err := s.orderStatusRepo.InsertHistoryTx(ctx, tx, &models.StatusHistory{
+		OrderID:   order.ID,
+		NewStatus: models.OrderStatusPending,
+		ActorID:   &actorID,
+		ActorRole: &actorRole,
+		Notes:     &initialNote,
+		CreatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+
+	// 11. Notifikasi pelanggan & webhook ikut transaksi (hanya terkirim jika pesanan ter-commit)
+	if err := s.notificationService.EnqueueTx(ctx, tx, order.ID, models.NotificationOrderCreated); err != nil {
+		return nil, err
+	}
+	if err := s.webhookService.PublishTx(ctx, tx, webhook.EventOrderCreated, webhook.OrderCreatedData{
+		OrderID:          order.ID,
+		OutletID:         outletID,
+		InvoiceNumber:    order.InvoiceNumber,
+		CustomerID:       order.CustomerID,
+		IsDelivery:       isDelivery,
+		GrandTotal:       order.GrandTotal,
+		PaymentStatus:    order.PaymentStatus,
+		EstimatedReadyAt: order.EstimatedReadyAt,
+		CreatedAt:        now,
+	}); err != nil {
+		return nil, err
+	}
+	if payment.Status == models.PaymentConfirmed {
+		if err := s.notificationService.EnqueueTx(ctx, tx, order.ID, models.NotificationPaymentConfirmed); err != nil {
+			return nil, err
+		}
+		if err := publishPaymentConfirmedTx(ctx, tx, s.webhookService, payment, order.InvoiceNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("orderService.CreateOrder.Commit: %w", err)
+	}
+
+	// 12. Ambil ulang pesanan lengkap untuk balasan
+	detail, err := s.orderRepo.FindDetail(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	return mapOrderDetail(detail), nil
+}
+
+// GetOrders retrieves orders of the active outlet with pagination and filters.
+func (s *orderService) GetOrders(ctx context.Context, params listquery.Params) (*dto.OrderListResponse, error) {
+
+	// 1. Validasi filter tanggal (nilai lain dibandingkan langsung oleh MySQL)
+	if err := validateListDates(params); err != nil {
+		return nil, err
+	}
+
+	// 2. Call Repository
+	orders, page, err := s.orderRepo.FindOrders(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Map to DTO
+	res := make([]dto.OrderSummaryResponse, 0, len(orders))
+	for i := range orders {
+		res = append(res, mapOrderSummary(&orders[i]))
+	}
+
+	return &dto.OrderListResponse{
+		Data: res,
+		Meta: page.Meta(),
+	}, nil
+}
+
+// orderExportColumns adalah judul kolom export GET /orders
+var orderExportColumns = []export.Column{
+	{ID: "No. Nota", EN: "Invoice Number"},
+	{ID: "Outlet", EN: "Outlet"},
+	{ID: "Tanggal", EN: "Date"},
+	{ID: "Pelanggan", EN: "Customer"},
+	{ID: "No. HP", EN: "Phone Number"},
+	{ID: "Antar", EN: "Delivery"},
+	{ID: "Status Proses", EN: "Process Status"},
+	{ID: "Status Bayar", EN: "Payment Status"},
+	{ID: "Subtotal", EN: "Subtotal"},
+	{ID: "Diskon", EN: "Discount"},
+	{ID: "Biaya Layanan", EN: "Service Charge"},
+	{ID: "Pajak", EN: "Tax"},
+	{ID: "Ongkir", EN: "Shipping Cost"},
+	{ID: "Total", EN: "Grand Total"},
+	{ID: "Estimasi Selesai", EN: "Estimated Ready At"},
+	{ID: "Kasir", EN: "Cashier"},
+}
+
+// ExportOrders streams every order of the active outlet matching the list filters (without pagination) into w.
+func (s *orderService) ExportOrders(ctx context.Context, params listquery.Params, w export.Writer) error {
+	if err := validateListDates(params); err != nil {
+		return err
+	}
+	w.SetColumns(orderExportColumns)
+
+	return s.orderRepo.StreamOrders(ctx, params, func(o *models.OrderSummary) error {
+		return w.WriteRow(
+			o.InvoiceNumber, o.OutletID, o.CreatedAt, o.CustomerName, o.CustomerPhone, o.IsDelivery,
+			o.StatusInternal, o.PaymentStatus, o.Subtotal, o.DiscountTotal, o.ServiceChargeTotal, o.TaxTotal,
+			o.ShippingCost, o.GrandTotal, o.EstimatedReadyAt, o.CreatedByName,
+		)
+	})
+}
+
+// --- HELPER FUNCTION ---
+
+// validateListDates memastikan filter start_date & end_date pada list pesanan/pembayaran berformat YYYY-MM-DD.
+func validateListDates(params listquery.Params) error {
+	for _, key := range []string{"start_date", "end_date"} {
+		if value, ok := params.Filters[key]; ok {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return fmt.Errorf("%w: %s must use format YYYY-MM-DD", response.ErrValidation, key)
+			}
+		}
+	}
+	return nil
+}
+
+// applyPointsDiscount menambahkan penukaran poin sebagai baris diskon (points x nilai satu poin).
+// Nilai poin tidak boleh melebihi sisa tagihan setelah diskon lain.
+func applyPointsDiscount(summary *promotion.Summary, points int, pointValue money.Amount) error {
+
+	if !pointValue.IsPositive() {
+		return fmt.Errorf("%w: points redemption is disabled", response.ErrValidation)
+	}
+
+	value := pointValue.MulInt(int64(points))
+	if value > summary.Total {
+		return fmt.Errorf("%w: %d points are worth %s, more than the remaining total of %s", response.ErrValidation, points, value, summary.Total)
+	}
+
+	summary.Discounts = append(summary.Discounts, promotion.AppliedDiscount{
+		Source:      promotion.SourcePoints,
+		Amount:      value,
+		Explanation: fmt.Sprintf("Redeemed %d points", points),
+	})
+	summary.DiscountTotal = summary.DiscountTotal.Add(value)
+	summary.Total = summary.Total.Sub(value)
+
+	return nil
+}
+
+// resolveCustomer mencari pelanggan berdasarkan customer_id atau nomor HP.
+// Pelanggan yang belum terdaftar dikembalikan sebagai newCustomer untuk disimpan di dalam transaksi pesanan.
+func (s *orderService) resolveCustomer(ctx context.Context, req dto.CreateOrderRequest) (customer, newCustomer *models.Customer, err error) {
+
+	// 1. customer_id dikirim: wajib ada & aktif
+	if req.CustomerID != nil {
+		customer, err = s.orderRepo.FindCustomerByID(ctx, *req.CustomerID)
+		if err != nil {
+			if errors.Is(err, response.ErrNotFound) {
+				return nil, nil, fmt.Errorf("%w: customer %d not found", response.ErrValidation, *req.CustomerID)
+			}
+			return nil, nil, err
+		}
+		if !customer.IsActive {
+			return nil, nil, fmt.Errorf("%w: customer %d is not active", response.ErrValidation, *req.CustomerID)
+		}
+		return customer, nil, nil
+	}
+
+	// 2. Tanpa customer_id: nama & nomor HP wajib
+	name, phone := trimmedOrNil(req.CustomerName), trimmedOrNil(req.CustomerPhone)
+	if name == nil || phone == nil {
+		return nil, nil, fmt.Errorf("%w: customer_name and customer_phone are required when customer_id is empty", response.ErrValidation)
+	}
+
+	// 3. Nomor HP yang sudah terdaftar memakai master pelanggan tersebut
+	customer, err = s.orderRepo.FindCustomerByPhone(ctx, *phone)
+	if err == nil {
+		if !customer.IsActive {
+			return nil, nil, fmt.Errorf("%w: customer with phone %s is not active", response.ErrValidation, *phone)
+		}
+		return customer, nil, nil
+	}
+	if !errors.Is(err, response.ErrNotFound) {
+		return nil, nil, err
+	}
+
+	return nil, &models.Customer{
+		FullName:    *name,
+		PhoneNumber: *phone,
+		Address:     trimmedOrNil(req.CustomerAddress),
+		IsActive:    true,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// buildQuoteItems mengubah item pesanan menjadi keranjang kalkulator harga.
+// Jumlah yang ditagih adalah weight_kg (layanan kiloan) atau quantity (layanan satuan), tidak boleh keduanya.
+func buildQuoteItems(items []dto.CreateOrderItemRequest) ([]dto.QuoteItemRequest, error) {
+
+	quoteItems := make([]dto.QuoteItemRequest, 0, len(items))
+	for i, item := range items {
+		var quantity float64
+		switch {
+		case item.WeightKg != nil && item.Quantity != nil:
+			return nil, fmt.Errorf("%w: order_items[%d] must have either weight_kg or quantity, not both", response.ErrValidation, i)
+		case item.WeightKg != nil:
+			quantity = *item.WeightKg
+		case item.Quantity != nil:
+			quantity = float64(*item.Quantity)
+		default:
+			return nil, fmt.Errorf("%w: order_items[%d] requires weight_kg or quantity", response.ErrValidation, i)
+		}
+
+		quoteItems = append(quoteItems, dto.QuoteItemRequest{
+			ServiceID: item.ServiceID,
+			Quantity:  quantity,
+			AddonIDs:  item.AddonIDs,
+		})
+	}
+
+	return quoteItems, nil
+}
+
+// buildOrderItems menyusun baris order_items dari hasil kalkulator harga.
+// Subtotal item = subtotal kalkulator (pembulatan, minimum, tier) + add-on, bukan unit_price x quantity.
+func buildOrderItems(items []dto.CreateOrderItemRequest, quote *pricing.OrderQuote) ([]models.OrderItem, error) {
+
+	if len(items) != len(quote.Lines) {
+		return nil, fmt.Errorf("orderService.buildOrderItems: %d items but %d priced lines", len(items), len(quote.Lines))
+	}
+
+	orderItems := make([]models.OrderItem, 0, len(items))
+	for i, item := range items {
+		line := quote.Lines[i]
+
+		// Satuan tagihan harus cocok dengan satuan layanan
+		if line.Unit == "kg" && item.WeightKg == nil {
+			return nil, fmt.Errorf("%w: order_items[%d] is a per-kg service and requires weight_kg", response.ErrValidation, i)
+		}
+		if line.Unit != "kg" && item.Quantity == nil {
+			return nil, fmt.Errorf("%w: order_items[%d] is a per-piece service and requires quantity", response.ErrValidation, i)
+		}
+
+		orderItem := models.OrderItem{
+			ServiceID: line.ServiceID,
+			ItemNotes: trimmedOrNil(item.ItemNotes),
+			Quantity:  item.Quantity,
+			QtyPieces: item.QtyPieces,
+			WeightKg:  item.WeightKg,
+			UnitPrice: line.UnitPrice,
+			Subtotal:  line.LineTotal,
+			Addons:    make([]models.OrderItemAddon, 0, len(line.Addons)),
+		}
+		for _, charge := range line.Addons {
+			addonID := charge.AddonID
+			orderItem.Addons = append(orderItem.Addons, models.OrderItemAddon{
+				AddonID:     &addonID,
+				AddonName:   charge.AddonName,
+				ChargeType:  charge.ChargeType,
+				ChargeValue: charge.ChargeValue,
+				ChargeBasis: charge.ChargeBasis,
+				Amount:      charge.Amount,
+			})
+		}
+		orderItems = append(orderItems, orderItem)
+	}
+
+	return orderItems, nil
+}
+
+// newOrderPayment menyusun tagihan pesanan baru dan status pembayaran nota induk.
+//
+//   - amount_received = 0 : tagihan 'pending', nota 'unpaid' (atau 'cod_pending' untuk pesanan antar).
+//   - amount_received >= grand_total : tagihan 'confirmed' (lunas), nota 'paid'. Kembalian hanya untuk tunai.
+//   - di antaranya : ditolak, pembayaran sebagian tidak didukung.
+func newOrderPayment(req *dto.CreateOrderPaymentRequest, grandTotal money.Amount, isDelivery bool, actorID int64, now time.Time) (*models.Payment, string, error) {
+
+	payment := &models.Payment{
+		Amount:    grandTotal,
+		Status:    models.PaymentPending,
+		CreatedBy: actorID,
+		CreatedAt: now,
+	}
+	received := money.Zero
+	if req != nil {
+		payment.Method = req.Method
+		payment.ReferenceNo = trimmedOrNil(req.ReferenceNo)
+		received = req.AmountReceived
+	}
+
+	// 1. Belum dibayar (tagihan nol dianggap langsung lunas)
+	if received.IsNegative() {
+		return nil, "", fmt.Errorf("%w: amount_received cannot be negative", response.ErrValidation)
+	}
+	if received.IsZero() && grandTotal.IsPositive() {
+		if isDelivery {
+			return payment, models.PaymentStatusCODPending, nil
+		}
+		return payment, models.PaymentStatusUnpaid, nil
+	}
+
+	// 2. Dibayar di muka: harus lunas
+	if err := confirmPayment(payment, received, actorID, now); err != nil {
+		return nil, "", err
+	}
+
+	return payment, models.PaymentStatusPaid, nil
+}
+
+func mapOrderDetail(d *models.OrderDetail) *dto.OrderDetailResponse {
+
+	res := &dto.OrderDetailResponse{
+		ID:                 d.ID,
+		InvoiceNumber:      d.InvoiceNumber,
+		OutletID:           d.OutletID,
+		Subtotal:           d.Subtotal,
+		DiscountTotal:      d.DiscountTotal,
+		ServiceChargeTotal: d.ServiceChargeTotal,
+		TaxTotal:           d.TaxTotal,
+		GrandTotal:         d.GrandTotal,
+		PaymentStatus:      d.PaymentStatus,
+		StatusInternal:     d.StatusInternal,
+		EstimatedReadyAt:   formatTimePtr(d.EstimatedReadyAt),
+		Notes:              d.Notes,
+		CreatedBy:          d.CreatedBy,
+		CreatedByName:      d.CreatedByName,
+		CreatedAt:          d.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:          formatTimePtr(d.UpdatedAt),
+		Customer: dto.OrderCustomerResponse{
+			ID:      d.CustomerID,
+			Name:    d.CustomerName,
+			Phone:   d.CustomerPhone,
+			Address: d.CustomerAddress,
+		},
+		OrderItems:    make([]dto.OrderItemResponse, 0, len(d.Items)),
+		StatusHistory: make([]dto.OrderStatusHistoryResponse, 0, len(d.History)),
+	}
+	if d.IsDelivery {
+		res.IsDelivery = 1
+	}
+
+	for _, item := range d.Items {
+		itemRes := dto.OrderItemResponse{
+			ID:          item.ID,
+			ServiceID:   item.ServiceID,
+			ServiceName: item.ServiceName,
+			ItemNotes:   item.ItemNotes,
+			Quantity:    item.Quantity,
+			QtyPieces:   item.QtyPieces,
+			WeightKg:    item.WeightKg,
+			Unit:        item.Unit,
+			UnitPrice:   item.UnitPrice,
+			Subtotal:    item.Subtotal,
+			Addons:      make([]dto.OrderItemAddonResponse, 0, len(item.Addons)),
+		}
+		for _, addon := range item.Addons {
+			itemRes.Addons = append(itemRes.Addons, dto.OrderItemAddonResponse{
+				AddonID:   addon.AddonID,
+				AddonName: addon.AddonName,
+				Amount:    addon.Amount,
+			})
+		}
+		res.OrderItems = append(res.OrderItems, itemRes)
+	}
+
+	if d.Payment != nil {
+		res.Payment = mapPayment(d.Payment)
+	}
+
+	if d.Delivery != nil {
+		res.Delivery = &dto.OrderDeliveryResponse{
+			ID:                 d.Delivery.ID,
+			ShippingCost:       d.Delivery.ShippingCost,
+			CourierID:          d.Delivery.CourierID,
+			CourierName:        d.Delivery.CourierName,
+			CourierPhone:       d.Delivery.CourierPhone,
+			CourierDepartedAt:  formatTimePtr(d.Delivery.CourierDepartedAt),
+			CourierArrivedAt:   formatTimePtr(d.Delivery.CourierArrivedAt),
+			CODCollectedAmount: d.Delivery.CODCollectedAmount,
+		}
+	}
+
+	for _, h := range d.History {
+		res.StatusHistory = append(res.StatusHistory, dto.OrderStatusHistoryResponse{
+			ID:             h.ID,
+			PreviousStatus: h.PreviousStatus,
+			NewStatus:      h.NewStatus,
+			ActorName:      h.ActorName,
+			ActorRole:      h.ActorRole,
+			Notes:          h.Notes,
+			CreatedAt:      h.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return res
+}
+
+func mapOrderSummary(o *models.OrderSummary) dto.OrderSummaryResponse {
+	res := dto.OrderSummaryResponse{
+		ID:                 o.ID,
+		InvoiceNumber:      o.InvoiceNumber,
+		OutletID:           o.OutletID,
+		Subtotal:           o.Subtotal,
+		DiscountTotal:      o.DiscountTotal,
+		ServiceChargeTotal: o.ServiceChargeTotal,
+		TaxTotal:           o.TaxTotal,
+		GrandTotal:         o.GrandTotal,
+		PaymentStatus:      o.PaymentStatus,
+		StatusInternal:     o.StatusInternal,
+		EstimatedReadyAt:   formatTimePtr(o.EstimatedReadyAt),
+		CreatedBy:          o.CreatedBy,
+		CreatedByName:      o.CreatedByName,
+		CreatedAt:          o.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:          formatTimePtr(o.UpdatedAt),
+		Customer: dto.OrderCustomerResponse{
+			ID:      o.CustomerID,
+			Name:    o.CustomerName,
+			Phone:   o.CustomerPhone,
+			Address: o.CustomerAddress,
+		},
+	}
+	if o.IsDelivery {
+		res.IsDelivery = 1
+	}
+	if o.DeliveryID != nil && o.ShippingCost != nil {
+		res.Delivery = &dto.OrderSummaryDeliveryResponse{ID: *o.DeliveryID, ShippingCost: *o.ShippingCost}
+	}
+	return res
+}
+
+func mapPayment(p *models.Payment) *dto.PaymentResponse {
+	return &dto.PaymentResponse{
+		ID:             p.ID,
+		OrderID:        p.OrderID,
+		Method:         p.Method,
+		Amount:         p.Amount,
+		AmountReceived: p.AmountReceived,
+		AmountChange:   p.AmountChange,
+		ReferenceNo:    p.ReferenceNo,
+		Status:         p.Status,
+		CreatedBy:      p.CreatedBy,
+		CollectedBy:    p.CollectedBy,
+		CollectedAt:    formatTimePtr(p.CollectedAt),
+		PaidAt:         formatTimePtr(p.PaidAt),
+		CreatedAt:      p.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// trimmedOrNil membuang spasi di tepi teks opsional; teks kosong dianggap tidak diisi.
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/pricing"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+type fakeOrderRepo struct {
+	repositories.OrderRepository
+	orders   []models.OrderSummary
+	streamed bool
+}
+
+func (f *fakeOrderRepo) StreamOrders(ctx context.Context, params listquery.Params, fn func(*models.OrderSummary) error) error {
+	f.streamed = true
+	for i := range f.orders {
+		if err := fn(&f.orders[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestNewOrderPayment(t *testing.T) {
+
+	cash, transfer := models.PaymentMethodCash, models.PaymentMethodTransfer
+	total := money.New(50000)
+
+	tests := []struct {
+		name        string
+		req         *dto.CreateOrderPaymentRequest
+		total       money.Amount
+		isDelivery  bool
+		wantErr     bool
+		wantStatus  string
+		wantPayment string
+		wantChange  money.Amount
+	}{
+		{name: "no payment leaves the bill unpaid", total: total, wantStatus: models.PaymentStatusUnpaid, wantPayment: models.PaymentPending},
+		{name: "unpaid delivery is cash on delivery", total: total, isDelivery: true, wantStatus: models.PaymentStatusCODPending, wantPayment: models.PaymentPending},
+		{name: "zero received is unpaid", req: &dto.CreateOrderPaymentRequest{Method: &cash}, total: total, wantStatus: models.PaymentStatusUnpaid, wantPayment: models.PaymentPending},
+		{name: "exact cash is paid", req: &dto.CreateOrderPaymentRequest{Method: &cash, AmountReceived: total}, total: total, wantStatus: models.PaymentStatusPaid, wantPayment: models.PaymentConfirmed},
+		{name: "cash overpayment returns change", req: &dto.CreateOrderPaymentRequest{Method: &cash, AmountReceived: money.New(100000)}, total: total, wantStatus: models.PaymentStatusPaid, wantPayment: models.PaymentConfirmed, wantChange: money.New(50000)},
+		{name: "exact transfer is paid", req: &dto.CreateOrderPaymentRequest{Method: &transfer, AmountReceived: total}, total: total, wantStatus: models.PaymentStatusPaid, wantPayment: models.PaymentConfirmed},
+		{name: "free order is paid without method", total: money.Zero, wantStatus: models.PaymentStatusPaid, wantPayment: models.PaymentConfirmed},
+		{name: "partial payment is rejected", req: &dto.CreateOrderPaymentRequest{Method: &cash, AmountReceived: money.New(20000)}, total: total, wantErr: true},
+		{name: "negative amount is rejected", req: &dto.CreateOrderPaymentRequest{Method: &cash, AmountReceived: money.New(-1)}, total: total, wantErr: true},
+		{name: "paid without method is rejected", req: &dto.CreateOrderPaymentRequest{AmountReceived: total}, total: total, wantErr: true},
+		{name: "non-cash overpayment is rejected", req: &dto.CreateOrderPaymentRequest{Method: &transfer, AmountReceived: money.New(60000)}, total: total, wantErr: true},
+	}
+
+	now := time.Date(2026, 1, 5, 13, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment, status, err := newOrderPayment(tt.req, tt.total, tt.isDelivery, 2, now)
+			if tt.wantErr {
+				if !errors.Is(err, response.ErrValidation) {
+					t.Fatalf("error = %v, want ErrValidation", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newOrderPayment: %v", err)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("order payment status = %q, want %q", status, tt.wantStatus)
+			}
+			if payment.Status != tt.wantPayment {
+				t.Errorf("payment status = %q, want %q", payment.Status, tt.wantPayment)
+			}
+			if payment.Amount != tt.total {
+				t.Errorf("amount = %s, want %s", payment.Amount, tt.total)
+			}
+			if payment.AmountChange != tt.wantChange {
+				t.Errorf("amount_change = %s, want %s", payment.AmountChange, tt.wantChange)
+			}
+			confirmed := payment.Status == models.PaymentConfirmed
+			if (payment.PaidAt != nil) != confirmed || (payment.CollectedBy != nil) != confirmed {
+				t.Errorf("paid_at/collected_by set = %v/%v, want %v", payment.PaidAt != nil, payment.CollectedBy != nil, confirmed)
+			}
+		})
+	}
+}
+
+func TestBuildQuoteItems(t *testing.T) {
+
+	two, weight := 2, 3.5
+
+	got, err := buildQuoteItems([]dto.CreateOrderItemRequest{
+		{ServiceID: 1, WeightKg: &weight, AddonIDs: []int64{7}},
+		{ServiceID: 2, Quantity: &two},
+	})
+	if err != nil {
+		t.Fatalf("buildQuoteItems: %v", err)
+	}
+	if got[0].Quantity != 3.5 || got[0].AddonIDs[0] != 7 || got[1].Quantity != 2 {
+		t.Fatalf("buildQuoteItems = %+v", got)
+	}
+
+	for name, item := range map[string]dto.CreateOrderItemRequest{
+		"both weight and quantity": {ServiceID: 1, WeightKg: &weight, Quantity: &two},
+		"neither":                  {ServiceID: 1},
+	} {
+		if _, err := buildQuoteItems([]dto.CreateOrderItemRequest{item}); !errors.Is(err, response.ErrValidation) {
+			t.Errorf("%s: error = %v, want ErrValidation", name, err)
+		}
+	}
+}
+
+func TestBuildOrderItemsUsesCalculatorTotals(t *testing.T) {
+
+	weight, two := 4.2, 2
+	quote := &pricing.OrderQuote{Lines: []pricing.LineQuote{
+		{
+			// 4.2 kg dibulatkan ke 5 kg x Rp7.000 + add-on Rp5.000
+			Result:     pricing.Result{ServiceID: 1, Unit: "kg", ActualQuantity: 4.2, BillableQuantity: 5, UnitPrice: money.New(7000), Subtotal: money.New(35000)},
+			Addons:     []pricing.AddonCharge{{AddonID: 9, AddonName: "Express", Amount: money.New(5000)}},
+			AddonTotal: money.New(5000),
+			LineTotal:  money.New(40000),
+		},
+		{
+			Result:    pricing.Result{ServiceID: 2, Unit: "pcs", ActualQuantity: 2, BillableQuantity: 2, UnitPrice: money.New(15000), Subtotal: money.New(30000)},
+			LineTotal: money.New(30000),
+		},
+	}}
+
+	items, err := buildOrderItems([]dto.CreateOrderItemRequest{
+		{ServiceID: 1, WeightKg: &weight},
+		{ServiceID: 2, Quantity: &two},
+	}, quote)
+	if err != nil {
+		t.Fatalf("buildOrderItems: %v", err)
+	}
+	if items[0].Subtotal != money.New(40000) || items[0].UnitPrice != money.New(7000) {
+		t.Errorf("kiloan item = %s x %s, want subtotal 40000 from the calculator", items[0].UnitPrice, items[0].Subtotal)
+	}
+	if len(items[0].Addons) != 1 || *items[0].Addons[0].AddonID != 9 {
+		t.Errorf("kiloan addons = %+v", items[0].Addons)
+	}
+	if items[1].Subtotal != money.New(30000) {
+		t.Errorf("satuan subtotal = %s, want 30000", items[1].Subtotal)
+	}
+
+	// Layanan kiloan dengan quantity (tanpa weight_kg) ditolak
+	if _, err := buildOrderItems([]dto.CreateOrderItemRequest{{ServiceID: 1, Quantity: &two}}, &pricing.OrderQuote{Lines: quote.Lines[:1]}); !errors.Is(err, response.ErrValidation) {
+		t.Errorf("per-kg service with quantity: error = %v, want ErrValidation", err)
+	}
+}
+
+func TestExportOrders(t *testing.T) {
+
+	name, cashier := "Mpok Romlah", "Siti Aminah"
+	shipping := money.New(10000)
+	createdAt := time.Date(2026, 1, 5, 6, 0, 0, 0, time.UTC) // 13:00 WIB
+	repo := &fakeOrderRepo{orders: []models.OrderSummary{
+		{
+			Order: models.Order{
+				InvoiceNumber: "INV-PUSAT-260105-001", OutletID: 1, CustomerName: &name, IsDelivery: true,
+				Subtotal: money.New(50000), GrandTotal: money.New(60000), PaymentStatus: models.PaymentStatusCODPending,
+				StatusInternal: models.OrderStatusPending, CreatedAt: createdAt,
+			},
+			CreatedByName: &cashier, DeliveryID: new(int64), ShippingCost: &shipping,
+		},
+	}}
+	s := &orderService{orderRepo: repo}
+
+	var out bytes.Buffer
+	w, err := export.NewWriter(export.FormatCSV, &out, export.LangID)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	if err := s.ExportOrders(context.Background(), listquery.Params{}, w); err != nil {
+		t.Fatalf("ExportOrders: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(strings.TrimPrefix(out.String(), "\xEF\xBB\xBF")), "\n")
+	want := []string{
+		"No. Nota,Outlet,Tanggal,Pelanggan,No. HP,Antar,Status Proses,Status Bayar,Subtotal,Diskon,Biaya Layanan,Pajak,Ongkir,Total,Estimasi Selesai,Kasir",
+		"INV-PUSAT-260105-001,1,2026-01-05 13:00:00,Mpok Romlah,,Ya,pending,cod_pending,50000,0,0,0,10000,60000,,Siti Aminah",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("CSV = %q, want %d lines", out.String(), len(want))
+	}
+	for i := range want {
+		if strings.TrimSuffix(lines[i], "\r") != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+
+	// Tanggal filter yang salah ditolak sebelum database dibaca, sehingga handler masih bisa membalas JSON 400
+	repo.streamed = false
+	bad := listquery.Params{Filters: map[string]string{"start_date": "05-01-2026"}}
+	if err := s.ExportOrders(context.Background(), bad, w); !errors.Is(err, response.ErrValidation) {
+		t.Fatalf("ExportOrders(bad start_date) error = %v, want ErrValidation", err)
+	}
+	if repo.streamed {
+		t.Fatal("ExportOrders read the database before validating start_date")
+	}
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/internal/webhook"
+	"time"
+)
+
+// orderStatusChanger menulis perubahan status pesanan beserta efek sampingnya di dalam transaksi milik pemanggil:
+// status & riwayat, notifikasi pelanggan, webhook, dan pemotongan bahan habis pakai saat pesanan mulai dikerjakan.
+// Dipakai bersama oleh scan tag dan batch mesin agar efek samping setiap jalur selalu sama.
+// Baris pesanan harus sudah dikunci (LockStateTx) dan transisinya sudah divalidasi orderflow oleh pemanggil.
+type orderStatusChanger struct {
+	orderStatusRepo     repositories.OrderStatusRepository
+	notificationService NotificationService
+	webhookService      WebhookService
+	inventoryService    InventoryService
+}
+
+// changeTx memindahkan pesanan ke history.NewStatus. history wajib berisi ActorID & ActorRole;
+// PreviousStatus diisi dari state, lalu state diperbarui ke status baru.
+func (c *orderStatusChanger) changeTx(ctx context.Context, tx *sql.Tx, state *models.OrderState, history *models.StatusHistory) error {
+
+	// 1. Update status & catat riwayat
+	previous := state.StatusInternal
+	history.PreviousStatus = &previous
+	if err := c.orderStatusRepo.ChangeStatusTx(ctx, tx, history); err != nil {
+		return err
+	}
+	state.StatusInternal = history.NewStatus
+
+	// 2. Notifikasi pelanggan & webhook ikut ter-commit/rollback bersama perubahan status
+	if err := c.notificationService.EnqueueStatusTx(ctx, tx, state.ID, history.NewStatus); err != nil {
+		return err
+	}
+	if err := publishStatusChangedTx(ctx, tx, c.webhookService, state, previous, *history.ActorRole, history.CreatedAt); err != nil {
+		return err
+	}
+
+	// 3. Bahan habis pakai dipotong sesuai resep layanan saat pesanan mulai dikerjakan
+	if history.NewStatus == models.OrderStatusInProgress {
+		if err := c.inventoryService.ConsumeForOrderTx(ctx, tx, state.ID, *history.ActorID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publishStatusChangedTx mengirim order.status_changed, ditambah delivery.finished saat pesanan antar selesai.
+// state harus sudah berisi status baru.
+func publishStatusChangedTx(ctx context.Context, tx *sql.Tx, webhookService WebhookService, state *models.OrderState, previous, actorRole string, changedAt time.Time) error {
+	data := webhook.OrderStatusData{
+		OrderID:        state.ID,
+		InvoiceNumber:  state.InvoiceNumber,
+		PreviousStatus: previous,
+		Status:         state.StatusInternal,
+		PaymentStatus:  state.PaymentStatus,
+		IsDelivery:     state.IsDelivery,
+		ActorRole:      actorRole,
+		ChangedAt:      changedAt,
+	}
+
+	if err := webhookService.PublishTx(ctx, tx, webhook.EventOrderStatusChanged, data); err != nil {
+		return err
+	}
+	if state.StatusInternal == models.OrderStatusFinishedDelivery {
+		return webhookService.PublishTx(ctx, tx, webhook.EventDeliveryFinished, data)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// OutletService defines the contract for business logic related to outlets (branches).
+type OutletService interface {
+	CreateOutlet(ctx context.Context, req dto.CreateOutletRequest) (*dto.OutletResponse, error)
+	GetOutletList(ctx context.Context, status string) ([]dto.OutletResponse, error)
+	GetOutletDetail(ctx context.Context, id int64) (*dto.OutletResponse, error)
+	ModifyOutlet(ctx context.Context, targetID int64, req dto.UpdateOutletRequest) (*dto.OutletResponse, error)
+	DeactivateOutlet(ctx context.Context, targetID int64) error
+
+	// Harga layanan khusus per outlet (tanpa baris = harga dasar layanan)
+	GetServicePrices(ctx context.Context, outletID int64) ([]dto.ServiceOutletPriceResponse, error)
+	SetServicePrice(ctx context.Context, outletID, serviceID int64, req dto.SetServiceOutletPriceRequest) (*dto.ServiceOutletPriceResponse, error)
+	RemoveServicePrice(ctx context.Context, outletID, serviceID int64) error
+}
+
+type outletService struct {
+	outletRepo  repositories.OutletRepository
+	serviceRepo repositories.ServiceRepository
+}
+
+// NewOutletService creates a new instance of OutletService.
+func NewOutletService(outletRepo repositories.OutletRepository, serviceRepo repositories.ServiceRepository) OutletService {
+	return &outletService{outletRepo: outletRepo, serviceRepo: serviceRepo}
+}
+
+// CreateOutlet handles the creation of a new outlet.
+func (s *outletService) CreateOutlet(ctx context.Context, req dto.CreateOutletRequest) (*dto.OutletResponse, error) {
+
+	// 1. Normalisasi & Pengecekan Duplikasi Kode (Harus unik)
+	code := normalizeOutletCode(req.Code)
+	if code == "" {
+		return nil, fmt.Errorf("%w: code must not be blank", response.ErrValidation)
+	}
+	existing, _ := s.outletRepo.FindByCode(ctx, code)
+	if existing != nil {
+		return nil, response.ErrDuplicate
+	}
+
+	// 2. Siapkan Model
+	outletModel := &models.Outlet{
+		Code:        code,
+		OutletName:  req.OutletName,
+		Address:     req.Address,
+		PhoneNumber: req.PhoneNumber,
+		IsActive:    true,
+		CreatedAt:   time.Now(),
+	}
+
+	// 3. Insert ke Database
+	if err := s.outletRepo.InsertOutlet(ctx, outletModel); err != nil {
+		return nil, err
+	}
+
+	return mapOutlet(outletModel), nil
+}
+
+// GetOutletList retrieves every outlet.
+func (s *outletService) GetOutletList(ctx context.Context, status string) ([]dto.OutletResponse, error) {
+
+	outlets, err := s.outletRepo.FindAll(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	outletResponses := make([]dto.OutletResponse, 0, len(outlets))
+	for i := range outlets {
+		outletResponses = append(outletResponses, *mapOutlet(&outlets[i]))
+	}
+
+	return outletResponses, nil
+}
+
+// GetOutletDetail retrieves an outlet by ID.
+func (s *outletService) GetOutletDetail(ctx context.Context, id int64) (*dto.OutletResponse, error) {
+
+	o, err := s.outletRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapOutlet(o), nil
+}
+
+// ModifyOutlet updates outlet data with validation logic.
+func (s *outletService) ModifyOutlet(ctx context.Context, targetID int64, req dto.UpdateOutletRequest) (*dto.OutletResponse, error) {
+
+	// 1. Ambil Data Outlet yang Lama
+	o, err := s.outletRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi & Update Kode (Jika dikirim user)
+	if req.Code != nil {
+		code := normalizeOutletCode(*req.Code)
+		if code == "" {
+			return nil, fmt.Errorf("%w: code must not be blank", response.ErrValidation)
+		}
+		if code != o.Code {
+			duplicateCheck, _ := s.outletRepo.FindByCode(ctx, code)
+			if duplicateCheck != nil && duplicateCheck.ID != targetID {
+				return nil, response.ErrDuplicate
+			}
+			o.Code = code
+		}
+	}
+
+	// 3. Update Fields Lainnya (Partial Update)
+	if req.OutletName != nil {
+		o.OutletName = *req.OutletName
+	}
+	if req.Address != nil {
+		o.Address = req.Address
+	}
+	if req.PhoneNumber != nil {
+		o.PhoneNumber = req.PhoneNumber
+	}
+	if req.IsActive != nil && *req.IsActive != o.IsActive {
+		if !*req.IsActive {
+			if err := s.ensureNoActiveUsers(ctx, targetID); err != nil {
+				return nil, err
+			}
+		}
+		o.IsActive = *req.IsActive
+	}
+
+	// 4. Update Waktu (Timestamp)
+	now := time.Now()
+	o.UpdatedAt = &now
+
+	// 5. Simpan Perubahan ke Database
+	if err := s.outletRepo.UpdateOutlet(ctx, o); err != nil {
+		return nil, err
+	}
+
+	return mapOutlet(o), nil
+}
+
+// DeactivateOutlet handles soft deletion of an outlet.
+// Outlet yang masih punya karyawan aktif ditolak agar tidak ada kasir yang kehilangan akses.
+func (s *outletService) DeactivateOutlet(ctx context.Context, targetID int64) error {
+
+	// 1. Cek apakah outlet tersebut ada
+	if _, err := s.outletRepo.FindByID(ctx, targetID); err != nil {
+		return err
+	}
+
+	// 2. Guard: Karyawan harus dipindahkan dulu
+	if err := s.ensureNoActiveUsers(ctx, targetID); err != nil {
+		return err
+	}
+
+	// 3. Eksekusi Soft Delete
+	return s.outletRepo.DeleteOutlet(ctx, targetID)
+}
+
+// GetServicePrices retrieves every service price override of an outlet.
+func (s *outletService) GetServicePrices(ctx context.Context, outletID int64) ([]dto.ServiceOutletPriceResponse, error) {
+
+	// 1. Pastikan outlet ada
+	if _, err := s.outletRepo.FindByID(ctx, outletID); err != nil {
+		return nil, err
+	}
+
+	// 2. Ambil harga khusus
+	prices, err := s.outletRepo.FindServicePrices(ctx, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	priceResponses := make([]dto.ServiceOutletPriceResponse, 0, len(prices))
+	for i := range prices {
+		priceResponses = append(priceResponses, mapServiceOutletPrice(&prices[i]))
+	}
+
+	return priceResponses, nil
+}
+
+// SetServicePrice creates or replaces the price of a service at an outlet.
+func (s *outletService) SetServicePrice(ctx context.Context, outletID, serviceID int64, req dto.SetServiceOutletPriceRequest) (*dto.ServiceOutletPriceResponse, error) {
+
+	// 1. Pastikan outlet & layanan ada
+	if _, err := s.outletRepo.FindByID(ctx, outletID); err != nil {
+		return nil, err
+	}
+	svc, err := s.serviceRepo.FindByID(ctx, serviceID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return nil, fmt.Errorf("%w: service %d not found", response.ErrValidation, serviceID)
+		}
+		return nil, err
+	}
+
+	// 2. Simpan (insert atau timpa harga lama)
+	price := &models.ServiceOutletPrice{
+		ServiceID:   svc.ID,
+		OutletID:    outletID,
+		Price:       req.Price,
+		ServiceCode: svc.Code,
+		ServiceName: svc.ServiceName,
+		BasePrice:   svc.Price,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.outletRepo.UpsertServicePrice(ctx, price); err != nil {
+		return nil, err
+	}
+
+	res := mapServiceOutletPrice(price)
+	return &res, nil
+}
+
+// RemoveServicePrice deletes a price override so the outlet falls back to the base service price.
+func (s *outletService) RemoveServicePrice(ctx context.Context, outletID, serviceID int64) error {
+	return s.outletRepo.DeleteServicePrice(ctx, outletID, serviceID)
+}
+
+// --- HELPER FUNCTION ---
+
+// ensureNoActiveUsers menolak penonaktifan outlet yang masih punya karyawan aktif.
+func (s *outletService) ensureNoActiveUsers(ctx context.Context, outletID int64) error {
+	total, err := s.outletRepo.CountActiveUsers(ctx, outletID)
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return fmt.Errorf("%w: outlet still has %d active user(s), move them to another outlet first", response.ErrValidation, total)
+	}
+	return nil
+}
+
+// normalizeOutletCode menyeragamkan kode outlet (huruf besar, tanpa spasi di tepi).
+func normalizeOutletCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func mapOutlet(o *models.Outlet) *dto.OutletResponse {
+	return &dto.OutletResponse{
+		ID:          o.ID,
+		Code:        o.Code,
+		OutletName:  o.OutletName,
+		Address:     o.Address,
+		PhoneNumber: o.PhoneNumber,
+		IsActive:    o.IsActive,
+		CreatedAt:   o.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   formatTimePtr(o.UpdatedAt),
+	}
+}
+
+func mapServiceOutletPrice(p *models.ServiceOutletPrice) dto.ServiceOutletPriceResponse {
+	updatedAt := p.CreatedAt
+	if p.UpdatedAt != nil {
+		updatedAt = *p.UpdatedAt
+	}
+	return dto.ServiceOutletPriceResponse{
+		ServiceID:   p.ServiceID,
+		ServiceCode: p.ServiceCode,
+		ServiceName: p.ServiceName,
+		OutletID:    p.OutletID,
+		Price:       p.Price,
+		BasePrice:   p.BasePrice,
+		UpdatedAt:   updatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/internal/webhook"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// PaymentService defines the contract for settling order bills.
+type PaymentService interface {
+
+	// SettlePayment melunasi tagihan 'pending' (PATCH /payments/{id}). Metode 'deposit' memotong saldo pelanggan
+	// dan poin loyalitas ditambahkan di transaksi yang sama; saldo kurang membatalkan seluruh pelunasan.
+	SettlePayment(ctx context.Context, id int64, req dto.SettlePaymentRequest, actorID int64) (*dto.PaymentResponse, error)
+
+	// GetPayments mengambil satu halaman daftar tagihan & pelunasan outlet aktif; ExportPayments menulis semua baris yang cocok ke w.
+	GetPayments(ctx context.Context, params listquery.Params) (*dto.PaymentListResponse, error)
+	ExportPayments(ctx context.Context, params listquery.Params, w export.Writer) error
+}
+
+type paymentService struct {
+	paymentRepo         repositories.PaymentRepository
+	shiftRepo           repositories.ShiftRepository
+	walletService       WalletService
+	notificationService NotificationService
+	webhookService      WebhookService
+}
+
+// NewPaymentService creates a new instance of PaymentService.
+func NewPaymentService(paymentRepo repositories.PaymentRepository, shiftRepo repositories.ShiftRepository, walletService WalletService, notificationService NotificationService, webhookService WebhookService) PaymentService {
+	return &paymentService{
+		paymentRepo:         paymentRepo,
+		shiftRepo:           shiftRepo,
+		walletService:       walletService,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+	}
+}
+
+// SettlePayment confirms a pending payment, debiting the deposit and accruing points in one transaction.
+func (s *paymentService) SettlePayment(ctx context.Context, id int64, req dto.SettlePaymentRequest, actorID int64) (*dto.PaymentResponse, error) {
+
+	now := time.Now()
+
+	tx, err := s.paymentRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// 1. Kunci tagihan & nota induk
+	settlement, err := s.paymentRepo.LockForSettlementTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	payment := &settlement.Payment
+	if payment.Status != models.PaymentPending {
+		return nil, fmt.Errorf("%w: payment %d is already %s", response.ErrInvalidTransition, id, payment.Status)
+	}
+
+	// 2. Validasi nominal & hitung kembalian (aturan sama dengan pembayaran di muka saat checkout)
+	method := req.Method
+	payment.Method = &method
+	payment.ReferenceNo = trimmedOrNil(req.ReferenceNo)
+	if err := confirmPayment(payment, req.AmountReceived, actorID, now); err != nil {
+		return nil, err
+	}
+
+	// 3. Uang masuk ke laci shift kasir yang melunasi
+	if err := assignShiftTx(ctx, tx, s.shiftRepo, payment, actorID); err != nil {
+		return nil, err
+	}
+
+	// 4. Potong deposit & tambah poin loyalitas
+	if err := settleWalletTx(ctx, tx, s.walletService, settlement.CustomerID, payment, actorID); err != nil {
+		return nil, err
+	}
+
+	// 5. Simpan pelunasan & tandai nota lunas
+	if err := s.paymentRepo.ConfirmTx(ctx, tx, payment); err != nil {
+		return nil, err
+	}
+
+	// 6. Notifikasi pelanggan & webhook ikut transaksi
+	if err := s.notificationService.EnqueueTx(ctx, tx, payment.OrderID, models.NotificationPaymentConfirmed); err != nil {
+		return nil, err
+	}
+	if err := publishPaymentConfirmedTx(ctx, tx, s.webhookService, payment, settlement.InvoiceNumber); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("paymentService.SettlePayment.Commit: %w", err)
+	}
+
+	return mapPayment(payment), nil
+}
+
+// GetPayments retrieves payments of the active outlet with pagination and filters.
+func (s *paymentService) GetPayments(ctx context.Context, params listquery.Params) (*dto.PaymentListResponse, error) {
+
+	// 1. Validasi filter tanggal (nilai lain dibandingkan langsung oleh MySQL)
+	if err := validateListDates(params); err != nil {
+		return nil, err
+	}
+
+	// 2. Call Repository
+	payments, page, err := s.paymentRepo.FindPayments(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Map to DTO
+	res := make([]dto.PaymentSummaryResponse, 0, len(payments))
+	for i := range payments {
+		res = append(res, dto.PaymentSummaryResponse{
+			PaymentResponse: *mapPayment(&payments[i].Payment),
+			InvoiceNumber:   payments[i].InvoiceNumber,
+		})
+	}
+
+	return &dto.PaymentListResponse{
+		Data: res,
+		Meta: page.Meta(),
+	}, nil
+}
+
+// paymentExportColumns adalah judul kolom export GET /payments
+var paymentExportColumns = []export.Column{
+	{ID: "ID", EN: "ID"},
+	{ID: "No. Nota", EN: "Invoice Number"},
+	{ID: "Outlet", EN: "Outlet"},
+	{ID: "Shift", EN: "Shift"},
+	{ID: "Metode", EN: "Method"},
+	{ID: "Tagihan", EN: "Amount"},
+	{ID: "Diterima", EN: "Amount Received"},
+	{ID: "Kembalian", EN: "Change"},
+	{ID: "No. Referensi", EN: "Reference No."},
+	{ID: "Status", EN: "Status"},
+	{ID: "Dibayar Pada", EN: "Paid At"},
+	{ID: "Dibuat Pada", EN: "Created At"},
+}
+
+// ExportPayments streams every payment of the active outlet matching the list filters (without pagination) into w.
+func (s *paymentService) ExportPayments(ctx context.Context, params listquery.Params, w export.Writer) error {
+	if err := validateListDates(params); err != nil {
+		return err
+	}
+	w.SetColumns(paymentExportColumns)
+
+	return s.paymentRepo.StreamPayments(ctx, params, func(p *models.PaymentSummary) error {
+		return w.WriteRow(
+			p.ID, p.InvoiceNumber, p.OutletID, p.ShiftID, p.Method, p.Amount, p.AmountReceived, p.AmountChange,
+			p.ReferenceNo, p.Status, p.PaidAt, p.CreatedAt,
+		)
+	})
+}
+
+// --- HELPER FUNCTION ---
+
+// confirmPayment memvalidasi uang yang diterima untuk tagihan payment lalu mengisi data pelunasannya.
+//
+//   - amount_received harus menutup seluruh tagihan (pembayaran sebagian tidak didukung).
+//   - Metode selain tunai harus pas dengan tagihan, kembalian hanya untuk tunai.
+func confirmPayment(payment *models.Payment, received money.Amount, actorID int64, now time.Time) error {
+
+	if received.IsNegative() {
+		return fmt.Errorf("%w: amount_received cannot be negative", response.ErrValidation)
+	}
+	if received < payment.Amount {
+		return fmt.Errorf("%w: amount_received must cover the grand total of %s (partial payments are not supported)", response.ErrValidation, payment.Amount)
+	}
+	if payment.Amount.IsPositive() && payment.Method == nil {
+		return fmt.Errorf("%w: payment.method is required when amount_received is filled", response.ErrValidation)
+	}
+	if payment.Method != nil && *payment.Method != models.PaymentMethodCash && received != payment.Amount {
+		return fmt.Errorf("%w: non-cash payments must equal the grand total of %s", response.ErrValidation, payment.Amount)
+	}
+
+	payment.AmountReceived = received
+	payment.AmountChange = received.Sub(payment.Amount)
+	payment.Status = models.PaymentConfirmed
+	payment.PaidAt = &now
+	payment.CollectedBy = &actorID
+	payment.CollectedAt = &now
+
+	return nil
+}
+
+// assignShiftTx mengisi payments.shift_id dengan shift terbuka kasir yang menulis pembayaran.
+// Uang tunai wajib masuk laci shift yang terbuka, sehingga pembayaran tunai tanpa shift ditolak ErrShiftNotOpen.
+func assignShiftTx(ctx context.Context, tx *sql.Tx, shiftRepo repositories.ShiftRepository, payment *models.Payment, cashierID int64) error {
+
+	shiftID, err := shiftRepo.FindOpenShiftIDTx(ctx, tx, cashierID)
+	if err != nil {
+		return err
+	}
+	isCash := payment.Method != nil && *payment.Method == models.PaymentMethodCash
+	if shiftID == nil && isCash && payment.Status == models.PaymentConfirmed {
+		return fmt.Errorf("%w: open a shift before accepting cash payments", response.ErrShiftNotOpen)
+	}
+
+	payment.ShiftID = shiftID
+	return nil
+}
+
+// settleWalletTx menjalankan efek dompet dari tagihan yang baru lunas: potong saldo untuk metode 'deposit'
+// lalu tambah poin loyalitas pelanggan. Saldo kurang mengembalikan ErrInsufficientBalance.
+func settleWalletTx(ctx context.Context, tx *sql.Tx, walletService WalletService, customerID *int64, payment *models.Payment, actorID int64) error {
+
+	isDeposit := payment.Method != nil && *payment.Method == models.PaymentMethodDeposit
+	if customerID == nil {
+		if isDeposit {
+			return fmt.Errorf("%w: deposit payments require a registered customer", response.ErrValidation)
+		}
+		return nil
+	}
+
+	if isDeposit && payment.Amount.IsPositive() {
+		if _, err := walletService.PayWithDepositTx(ctx, tx, *customerID, payment.OrderID, payment.Amount, actorID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := walletService.AccruePointsTx(ctx, tx, *customerID, payment.OrderID, payment.Amount, actorID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// publishPaymentConfirmedTx mengantrekan webhook payment.confirmed di transaksi pelunasan.
+func publishPaymentConfirmedTx(ctx context.Context, tx *sql.Tx, webhookService WebhookService, payment *models.Payment, invoiceNumber string) error {
+	method := ""
+	if payment.Method != nil {
+		method = *payment.Method
+	}
+	return webhookService.PublishTx(ctx, tx, webhook.EventPaymentConfirmed, webhook.PaymentConfirmedData{
+		PaymentID:     payment.ID,
+		OrderID:       payment.OrderID,
+		OutletID:      payment.OutletID,
+		InvoiceNumber: invoiceNumber,
+		Method:        method,
+		Amount:        payment.Amount,
+		PaidAt:        *payment.PaidAt,
+	})
+}
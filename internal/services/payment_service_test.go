@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/promotion"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+type fakePaymentRepo struct {
+	repositories.PaymentRepository
+	db         *sql.DB
+	settlement models.PaymentSettlement
+	confirmed  *models.Payment
+}
+
+func (f *fakePaymentRepo) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return f.db.BeginTx(ctx, nil)
+}
+
+func (f *fakePaymentRepo) LockForSettlementTx(ctx context.Context, tx *sql.Tx, id int64) (*models.PaymentSettlement, error) {
+	if id != f.settlement.ID {
+		return nil, response.ErrNotFound
+	}
+	settlement := f.settlement
+	return &settlement, nil
+}
+
+func (f *fakePaymentRepo) ConfirmTx(ctx context.Context, tx *sql.Tx, payment *models.Payment) error {
+	confirmed := *payment
+	f.confirmed = &confirmed
+	return nil
+}
+
+type fakeShiftRepo struct {
+	repositories.ShiftRepository
+	openShiftID *int64
+}
+
+func (f *fakeShiftRepo) FindOpenShiftIDTx(ctx context.Context, tx *sql.Tx, cashierID int64) (*int64, error) {
+	return f.openShiftID, nil
+}
+
+type fakeNotificationService struct {
+	NotificationService
+	events []string
+}
+
+func (f *fakeNotificationService) EnqueueTx(ctx context.Context, tx *sql.Tx, orderID int64, eventType string) error {
+	f.events = append(f.events, eventType)
+	return nil
+}
+
+type fakeWebhookService struct {
+	WebhookService
+	events []string
+}
+
+func (f *fakeWebhookService) PublishTx(ctx context.Context, tx *sql.Tx, eventType string, data interface{}) error {
+	f.events = append(f.events, eventType)
+	return nil
+}
+
+func TestSettlePayment(t *testing.T) {
+
+	customerID, shiftID := int64(7), int64(12)
+
+	tests := []struct {
+		name          string
+		customerID    *int64
+		status        string
+		noShift       bool
+		balance       money.Amount
+		tier          *models.MembershipTier
+		req           dto.SettlePaymentRequest
+		wantErr       error
+		wantChange    money.Amount
+		wantBalance   money.Amount
+		wantPoints    int
+		wantSettled   bool
+		wantCommitted bool
+	}{
+		{
+			name:          "cash with change accrues multiplied points",
+			customerID:    &customerID,
+			tier:          &models.MembershipTier{PointMultiplier: 1.5},
+			req:           dto.SettlePaymentRequest{Method: models.PaymentMethodCash, AmountReceived: money.New(100000)},
+			wantChange:    money.New(45000),
+			wantPoints:    8,
+			wantSettled:   true,
+			wantCommitted: true,
+		},
+		{
+			name:          "deposit debits the wallet",
+			customerID:    &customerID,
+			balance:       money.New(60000),
+			req:           dto.SettlePaymentRequest{Method: models.PaymentMethodDeposit, AmountReceived: money.New(55000)},
+			wantBalance:   money.New(5000),
+			wantPoints:    5,
+			wantSettled:   true,
+			wantCommitted: true,
+		},
+		{
+			name:        "deposit with insufficient balance leaves the bill unsettled",
+			customerID:  &customerID,
+			balance:     money.New(50000),
+			req:         dto.SettlePaymentRequest{Method: models.PaymentMethodDeposit, AmountReceived: money.New(55000)},
+			wantErr:     response.ErrInsufficientBalance,
+			wantBalance: money.New(50000),
+		},
+		{
+			name:       "cash without open shift is rejected",
+			customerID: &customerID,
+			noShift:    true,
+			req:        dto.SettlePaymentRequest{Method: models.PaymentMethodCash, AmountReceived: money.New(55000)},
+			wantErr:    response.ErrShiftNotOpen,
+		},
+		{
+			name:          "transfer without open shift is allowed",
+			customerID:    &customerID,
+			noShift:       true,
+			req:           dto.SettlePaymentRequest{Method: models.PaymentMethodTransfer, AmountReceived: money.New(55000)},
+			wantPoints:    5,
+			wantSettled:   true,
+			wantCommitted: true,
+		},
+		{
+			name:    "deposit without customer",
+			req:     dto.SettlePaymentRequest{Method: models.PaymentMethodDeposit, AmountReceived: money.New(55000)},
+			wantErr: response.ErrValidation,
+		},
+		{
+			name:       "partial payment",
+			customerID: &customerID,
+			req:        dto.SettlePaymentRequest{Method: models.PaymentMethodCash, AmountReceived: money.New(50000)},
+			wantErr:    response.ErrValidation,
+		},
+		{
+			name:       "already confirmed",
+			customerID: &customerID,
+			status:     models.PaymentConfirmed,
+			req:        dto.SettlePaymentRequest{Method: models.PaymentMethodCash, AmountReceived: money.New(55000)},
+			wantErr:    response.ErrInvalidTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, conn := newFakeTxDB(t)
+			status := tt.status
+			if status == "" {
+				status = models.PaymentPending
+			}
+			paymentRepo := &fakePaymentRepo{db: db, settlement: models.PaymentSettlement{
+				Payment:       models.Payment{ID: 3, OrderID: 42, OutletID: 1, Amount: money.New(55000), Status: status, CreatedAt: time.Now()},
+				InvoiceNumber: "INV-260105-001",
+				CustomerID:    tt.customerID,
+			}}
+			shiftRepo := &fakeShiftRepo{openShiftID: &shiftID}
+			if tt.noShift {
+				shiftRepo.openShiftID = nil
+			}
+			walletRepo := &fakeWalletRepo{balance: tt.balance}
+			notifications, webhooks := &fakeNotificationService{}, &fakeWebhookService{}
+			svc := NewPaymentService(paymentRepo, shiftRepo, newTestWalletService(walletRepo, tt.tier, 10000, 100), notifications, webhooks)
+
+			res, err := svc.SettlePayment(context.Background(), 3, tt.req, 9)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("SettlePayment: %v", err)
+			}
+
+			if (paymentRepo.confirmed != nil) != tt.wantSettled {
+				t.Fatalf("payment confirmed = %v, want %v", paymentRepo.confirmed != nil, tt.wantSettled)
+			}
+			if (conn.commits == 1) != tt.wantCommitted {
+				t.Fatalf("commits = %d, want committed %v", conn.commits, tt.wantCommitted)
+			}
+			if walletRepo.balance != tt.wantBalance {
+				t.Fatalf("wallet balance = %s, want %s", walletRepo.balance, tt.wantBalance)
+			}
+			if walletRepo.points != tt.wantPoints {
+				t.Fatalf("points = %d, want %d", walletRepo.points, tt.wantPoints)
+			}
+			if !tt.wantSettled {
+				if len(notifications.events) != 0 || len(webhooks.events) != 0 {
+					t.Fatalf("events queued for an unsettled payment: %v %v", notifications.events, webhooks.events)
+				}
+				return
+			}
+
+			if res.Status != models.PaymentConfirmed || res.AmountChange != tt.wantChange || res.PaidAt == nil || *res.CollectedBy != 9 {
+				t.Fatalf("response = %+v, want confirmed with change %s collected by 9", res, tt.wantChange)
+			}
+			if (paymentRepo.confirmed.ShiftID == nil) != tt.noShift || (!tt.noShift && *paymentRepo.confirmed.ShiftID != shiftID) {
+				t.Fatalf("shift_id = %v, want the open shift %d (nil without shift)", paymentRepo.confirmed.ShiftID, shiftID)
+			}
+			if len(notifications.events) != 1 || notifications.events[0] != models.NotificationPaymentConfirmed {
+				t.Fatalf("notifications = %v, want payment_confirmed", notifications.events)
+			}
+			if len(webhooks.events) != 1 {
+				t.Fatalf("webhooks = %v, want payment.confirmed", webhooks.events)
+			}
+		})
+	}
+}
+
+func TestApplyPointsDiscount(t *testing.T) {
+
+	summary := &promotion.Summary{Subtotal: money.New(50000), DiscountTotal: money.New(5000), Total: money.New(45000)}
+
+	if err := applyPointsDiscount(summary, 10, money.Zero); !errors.Is(err, response.ErrValidation) {
+		t.Fatalf("disabled redemption error = %v, want ErrValidation", err)
+	}
+	if err := applyPointsDiscount(summary, 451, money.New(100)); !errors.Is(err, response.ErrValidation) {
+		t.Fatalf("redemption above total error = %v, want ErrValidation", err)
+	}
+
+	if err := applyPointsDiscount(summary, 30, money.New(100)); err != nil {
+		t.Fatalf("applyPointsDiscount: %v", err)
+	}
+	if summary.DiscountTotal != money.New(8000) || summary.Total != money.New(42000) {
+		t.Fatalf("discount_total = %s, total = %s, want 8000 and 42000", summary.DiscountTotal, summary.Total)
+	}
+	last := summary.Discounts[len(summary.Discounts)-1]
+	if last.Source != promotion.SourcePoints || last.Amount != money.New(3000) || last.PromotionID != 0 {
+		t.Fatalf("points discount = %+v, want 3000 from points", last)
+	}
+}
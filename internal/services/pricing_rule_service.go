@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/pricing"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// PricingRuleService defines the contract for business logic related to service pricing rules.
+type PricingRuleService interface {
+	GetRuleList(ctx context.Context, serviceID int64) ([]dto.PricingRuleResponse, error)
+	CreateRule(ctx context.Context, serviceID int64, req dto.CreatePricingRuleRequest) (*dto.PricingRuleResponse, error)
+	ModifyRule(ctx context.Context, serviceID, ruleID int64, req dto.UpdatePricingRuleRequest) (*dto.PricingRuleResponse, error)
+	DeactivateRule(ctx context.Context, serviceID, ruleID int64) error
+
+	// QuotePrice menghitung subtotal satu item (termasuk add-on terpilih) menggunakan kalkulator harga.
+	// Dipakai oleh endpoint simulasi harga.
+	QuotePrice(ctx context.Context, serviceID int64, quantity float64, addonIDs []int64) (*dto.PriceQuoteResponse, error)
+
+	// QuoteItems menghitung seluruh item keranjang sekaligus.
+	// Dipakai oleh pembuatan pesanan dan modul lain yang butuh subtotal (promosi, pajak).
+	QuoteItems(ctx context.Context, items []dto.QuoteItemRequest) (*pricing.OrderQuote, error)
+}
+
+type pricingRuleService struct {
+	ruleRepo    repositories.PricingRuleRepository
+	serviceRepo repositories.ServiceRepository
+	addonRepo   repositories.AddonRepository
+}
+
+// NewPricingRuleService creates a new instance of PricingRuleService.
+func NewPricingRuleService(ruleRepo repositories.PricingRuleRepository, serviceRepo repositories.ServiceRepository, addonRepo repositories.AddonRepository) PricingRuleService {
+	return &pricingRuleService{
+		ruleRepo:    ruleRepo,
+		serviceRepo: serviceRepo,
+		addonRepo:   addonRepo,
+	}
+}
+
+// GetRuleList retrieves every pricing rule (active and inactive) of a service.
+func (s *pricingRuleService) GetRuleList(ctx context.Context, serviceID int64) ([]dto.PricingRuleResponse, error) {
+
+	// 1. Pastikan layanan induk ada
+	if _, err := s.serviceRepo.FindByID(ctx, serviceID); err != nil {
+		return nil, err
+	}
+
+	// 2. Ambil seluruh aturan harga
+	rules, err := s.ruleRepo.FindByService(ctx, serviceID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Mapping ke DTO (Cegah "null" di JSON)
+	ruleResponses := make([]dto.PricingRuleResponse, 0, len(rules))
+	for i := range rules {
+		ruleResponses = append(ruleResponses, *s.mapToResponse(&rules[i]))
+	}
+
+	return ruleResponses, nil
+}
+
+// CreateRule attaches a new pricing rule to a service.
+func (s *pricingRuleService) CreateRule(ctx context.Context, serviceID int64, req dto.CreatePricingRuleRequest) (*dto.PricingRuleResponse, error) {
+
+	// 1. Pastikan layanan induk ada
+	if _, err := s.serviceRepo.FindByID(ctx, serviceID); err != nil {
+		return nil, err
+	}
+
+	// 2. Siapkan Model
+	rule := &models.ServicePricingRule{
+		ServiceID:    serviceID,
+		RuleType:     req.RuleType,
+		MinQuantity:  req.MinQuantity,
+		RoundingStep: req.RoundingStep,
+		UnitPrice:    req.UnitPrice,
+		IsActive:     true,
+		CreatedAt:    time.Now(),
+	}
+
+	// 3. Validasi kelengkapan kolom & bentrokan dengan aturan lain
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	// 4. Insert ke Database
+	if err := s.ruleRepo.InsertRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return s.mapToResponse(rule), nil
+}
+
+// ModifyRule updates a pricing rule with partial update semantics.
+func (s *pricingRuleService) ModifyRule(ctx context.Context, serviceID, ruleID int64, req dto.UpdatePricingRuleRequest) (*dto.PricingRuleResponse, error) {
+
+	// 1. Ambil data aturan yang lama
+	rule, err := s.ruleRepo.FindByID(ctx, serviceID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Update Fields (Partial Update)
+	if req.MinQuantity != nil {
+		rule.MinQuantity = req.MinQuantity
+	}
+	if req.RoundingStep != nil {
+		rule.RoundingStep = req.RoundingStep
+	}
+	if req.UnitPrice != nil {
+		rule.UnitPrice = req.UnitPrice
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	// 3. Validasi ulang hasil akhirnya
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	// 4. Update Waktu (Timestamp)
+	now := time.Now()
+	rule.UpdatedAt = &now
+
+	// 5. Simpan Perubahan ke Database
+	if err := s.ruleRepo.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return s.mapToResponse(rule), nil
+}
+
+// DeactivateRule handles soft deletion of a pricing rule.
+func (s *pricingRuleService) DeactivateRule(ctx context.Context, serviceID, ruleID int64) error {
+
+	// 1. Cek apakah aturan tersebut ada dan milik layanan ini
+	if _, err := s.ruleRepo.FindByID(ctx, serviceID, ruleID); err != nil {
+		return err
+	}
+
+	// 2. Eksekusi Soft Delete
+	return s.ruleRepo.DeleteRule(ctx, serviceID, ruleID)
+}
+
+// QuotePrice calculates the line total of a service (base price, pricing rules and add-ons)
+// for the given weight or quantity, together with the estimated ready time.
+func (s *pricingRuleService) QuotePrice(ctx context.Context, serviceID int64, quantity float64, addonIDs []int64) (*dto.PriceQuoteResponse, error) {
+
+	// 1. Kumpulkan layanan, aturan harga, dan add-on terpilih
+	input, svc, err := s.buildLineInput(ctx, serviceID, quantity, addonIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Hitung menggunakan kalkulator murni
+	quote, err := pricing.QuoteOrder([]pricing.LineInput{*input})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", response.ErrValidation, err)
+	}
+	line := quote.Lines[0]
+
+	// 3. Mapping ke DTO
+	breakdown := make([]dto.PriceBreakdownResponse, 0, len(line.Breakdown))
+	for _, b := range line.Breakdown {
+		breakdown = append(breakdown, dto.PriceBreakdownResponse{
+			Step:        b.Step,
+			Description: b.Description,
+			Quantity:    b.Quantity,
+			UnitPrice:   b.UnitPrice,
+		})
+	}
+
+	addonCharges := make([]dto.AddonChargeResponse, 0, len(line.Addons))
+	for _, a := range line.Addons {
+		addonCharges = append(addonCharges, dto.AddonChargeResponse{
+			AddonID:     a.AddonID,
+			AddonName:   a.AddonName,
+			ChargeType:  a.ChargeType,
+			ChargeValue: a.ChargeValue,
+			ChargeBasis: a.ChargeBasis,
+			Amount:      a.Amount,
+		})
+	}
+
+	readyAt := pricing.EstimateReadyAt(time.Now(), quote.DurationHours)
+
+	return &dto.PriceQuoteResponse{
+		ServiceID:        svc.ID,
+		ServiceName:      svc.ServiceName,
+		Unit:             line.Unit,
+		ActualQuantity:   line.ActualQuantity,
+		BillableQuantity: line.BillableQuantity,
+		UnitPrice:        line.UnitPrice,
+		Subtotal:         line.Subtotal,
+		Breakdown:        breakdown,
+		Addons:           addonCharges,
+		AddonTotal:       line.AddonTotal,
+		LineTotal:        line.LineTotal,
+		DurationHours:    line.DurationHours,
+		EstimatedReadyAt: readyAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// QuoteItems calculates every cart line in one pass so per-order add-ons are charged only once.
+func (s *pricingRuleService) QuoteItems(ctx context.Context, items []dto.QuoteItemRequest) (*pricing.OrderQuote, error) {
+
+	// 1. Validasi keranjang tidak boleh kosong
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: at least one service item is required", response.ErrValidation)
+	}
+
+	// 2. Kumpulkan data setiap item dari database
+	inputs := make([]pricing.LineInput, 0, len(items))
+	for _, item := range items {
+		input, _, err := s.buildLineInput(ctx, item.ServiceID, item.Quantity, item.AddonIDs)
+		if err != nil {
+			// Layanan yang tidak ada di keranjang adalah kesalahan input, bukan resource path
+			if errors.Is(err, response.ErrNotFound) {
+				return nil, fmt.Errorf("%w: service %d not found", response.ErrValidation, item.ServiceID)
+			}
+			return nil, err
+		}
+		inputs = append(inputs, *input)
+	}
+
+	// 3. Hitung seluruh keranjang menggunakan kalkulator murni
+	quote, err := pricing.QuoteOrder(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", response.ErrValidation, err)
+	}
+
+	return quote, nil
+}
+
+// --- HELPER FUNCTION ---
+
+// buildLineInput mengambil layanan (harus aktif), aturan harga aktif, dan add-on terpilih untuk satu item.
+func (s *pricingRuleService) buildLineInput(ctx context.Context, serviceID int64, quantity float64, addonIDs []int64) (*pricing.LineInput, *models.ServiceWithCategory, error) {
+
+	// 1. Ambil layanan beserta harga dasarnya (harga selalu dari database, bukan dari klien)
+	svc, err := s.serviceRepo.FindByID(ctx, serviceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !svc.IsActive {
+		return nil, nil, fmt.Errorf("%w: service %d is not active", response.ErrValidation, serviceID)
+	}
+
+	// Harga khusus outlet aktif (jika ada) menggantikan harga dasar
+	outletPrice, err := s.serviceRepo.FindOutletPrice(ctx, serviceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if outletPrice != nil {
+		svc.Price = *outletPrice
+	}
+
+	// 2. Ambil aturan harga yang aktif saja
+	rules, err := s.ruleRepo.FindByService(ctx, serviceID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 3. Ambil add-on yang dipilih (harus aktif dan terhubung ke layanan ini)
+	addons, err := s.addonRepo.FindForService(ctx, serviceID, addonIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(addons) != len(uniqueIDs(addonIDs)) {
+		return nil, nil, fmt.Errorf("%w: one or more add-ons are not available for service %d", response.ErrValidation, serviceID)
+	}
+
+	return &pricing.LineInput{
+		Service:  svc.Service,
+		Quantity: quantity,
+		Rules:    rules,
+		Addons:   addons,
+	}, svc, nil
+}
+
+// validateRule memastikan kolom wajib sesuai rule_type terisi, membuang kolom yang tidak relevan,
+// dan mencegah dua aturan aktif yang saling bertabrakan pada layanan yang sama.
+func (s *pricingRuleService) validateRule(ctx context.Context, rule *models.ServicePricingRule) error {
+
+	// 1. Kelengkapan kolom berdasarkan jenis aturan
+	switch rule.RuleType {
+	case models.PricingRuleMinimum:
+		if rule.MinQuantity == nil {
+			return fmt.Errorf("%w: min_quantity is required for minimum rule", response.ErrValidation)
+		}
+		rule.RoundingStep, rule.UnitPrice = nil, nil
+	case models.PricingRuleRounding:
+		if rule.RoundingStep == nil {
+			return fmt.Errorf("%w: rounding_step is required for rounding rule", response.ErrValidation)
+		}
+		rule.MinQuantity, rule.UnitPrice = nil, nil
+	case models.PricingRuleTier:
+		if rule.MinQuantity == nil || rule.UnitPrice == nil {
+			return fmt.Errorf("%w: min_quantity and unit_price are required for tier rule", response.ErrValidation)
+		}
+		rule.RoundingStep = nil
+	default:
+		return fmt.Errorf("%w: unknown rule_type %q", response.ErrValidation, rule.RuleType)
+	}
+
+	// 2. Aturan non-aktif tidak perlu dicek bentrokannya
+	if !rule.IsActive {
+		return nil
+	}
+
+	// 3. Cek bentrokan: hanya boleh satu minimum & satu rounding aktif,
+	// dan tier tidak boleh punya min_quantity yang sama.
+	existingRules, err := s.ruleRepo.FindByService(ctx, rule.ServiceID, true)
+	if err != nil {
+		return err
+	}
+	for _, existing := range existingRules {
+		if existing.ID == rule.ID || existing.RuleType != rule.RuleType {
+			continue
+		}
+		if rule.RuleType != models.PricingRuleTier {
+			return response.ErrDuplicate
+		}
+		if existing.MinQuantity != nil && *existing.MinQuantity == *rule.MinQuantity {
+			return response.ErrDuplicate
+		}
+	}
+
+	return nil
+}
+
+func (s *pricingRuleService) mapToResponse(rule *models.ServicePricingRule) *dto.PricingRuleResponse {
+
+	// 1. Format UpdatedAt menjadi pointer string (jika tidak nil)
+	var updatedAtPtr *string
+	if rule.UpdatedAt != nil {
+		formatted := rule.UpdatedAt.Format("2006-01-02 15:04:05")
+		updatedAtPtr = &formatted
+	}
+
+	return &dto.PricingRuleResponse{
+		ID:           rule.ID,
+		ServiceID:    rule.ServiceID,
+		RuleType:     rule.RuleType,
+		MinQuantity:  rule.MinQuantity,
+		RoundingStep: rule.RoundingStep,
+		UnitPrice:    rule.UnitPrice,
+		IsActive:     rule.IsActive,
+		CreatedAt:    rule.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    updatedAtPtr,
+	}
+}
+
+// uniqueIDs membuang ID duplikat agar pengecekan jumlah add-on tidak salah hitung.
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,597 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/pricing"
+	"laundry-backend/internal/promotion"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PromotionService defines the contract for business logic related to promotions and voucher codes.
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, req dto.CreatePromotionRequest) (*dto.PromotionDetailResponse, error)
+	GetPromotionList(ctx context.Context, page, perPage int, search, status string) (*dto.PromotionListResponse, error)
+	GetPromotionDetail(ctx context.Context, id int64) (*dto.PromotionDetailResponse, error)
+
+	// ModifyPromotion updates promotion information with validation logic.
+	ModifyPromotion(ctx context.Context, targetID int64, req dto.UpdatePromotionRequest) (*dto.PromotionDetailResponse, error)
+
+	// DeactivatePromotion handles soft deletion of a promotion.
+	DeactivatePromotion(ctx context.Context, targetID int64) error
+
+	// ValidatePromotion mensimulasikan diskon keranjang saat checkout tanpa mencatat pemakaian.
+	ValidatePromotion(ctx context.Context, req dto.ValidatePromotionRequest) (*dto.PromotionValidationResponse, error)
+
+	// ResolveDiscounts menerapkan diskon member + promo otomatis terbaik + kode voucher ke keranjang yang sudah dihitung.
+	// Dipakai oleh ValidatePromotion dan pembuatan pesanan agar hasilnya selalu sama.
+	ResolveDiscounts(ctx context.Context, quote *pricing.OrderQuote, code *string, customerID *int64) (*promotion.Summary, error)
+
+	// RecordRedemptions mencatat diskon yang diterapkan di dalam transaksi pembuatan pesanan.
+	// Mengembalikan ErrPromotionExhausted jika kuota habis direbut transaksi lain.
+	RecordRedemptions(ctx context.Context, tx *sql.Tx, orderID int64, customerID *int64, redeemedBy int64, discounts []promotion.AppliedDiscount) error
+}
+
+type promotionService struct {
+	promotionRepo      repositories.PromotionRepository
+	membershipRepo     repositories.MembershipRepository
+	serviceRepo        repositories.ServiceRepository
+	categoryRepo       repositories.CategoryRepository
+	pricingRuleService PricingRuleService
+}
+
+// NewPromotionService creates a new instance of PromotionService.
+func NewPromotionService(promotionRepo repositories.PromotionRepository, membershipRepo repositories.MembershipRepository, serviceRepo repositories.ServiceRepository, categoryRepo repositories.CategoryRepository, pricingRuleService PricingRuleService) PromotionService {
+	return &promotionService{
+		promotionRepo:      promotionRepo,
+		membershipRepo:     membershipRepo,
+		serviceRepo:        serviceRepo,
+		categoryRepo:       categoryRepo,
+		pricingRuleService: pricingRuleService,
+	}
+}
+
+// CreatePromotion handles the creation of a new promotion or voucher code.
+func (s *promotionService) CreatePromotion(ctx context.Context, req dto.CreatePromotionRequest) (*dto.PromotionDetailResponse, error) {
+
+	// 1. Normalisasi & cek duplikasi kode voucher (Harus unik)
+	code := normalizeVoucherCode(req.Code)
+	if code != nil {
+		existingCode, _ := s.promotionRepo.FindByCode(ctx, *code)
+		if existingCode != nil {
+			return nil, response.ErrDuplicate
+		}
+	}
+
+	// 2. Parsing periode berlaku
+	startsAt, err := parsePromotionTime("starts_at", req.StartsAt)
+	if err != nil {
+		return nil, err
+	}
+	var endsAt *time.Time
+	if req.EndsAt != nil && *req.EndsAt != "" {
+		parsed, err := parsePromotionTime("ends_at", *req.EndsAt)
+		if err != nil {
+			return nil, err
+		}
+		endsAt = &parsed
+	}
+
+	// 3. Siapkan Model
+	promoModel := &models.Promotion{
+		Code:             code,
+		PromoName:        req.PromoName,
+		Description:      req.Description,
+		DiscountType:     req.DiscountType,
+		DiscountValue:    req.DiscountValue,
+		MaxDiscount:      req.MaxDiscount,
+		MinSpend:         req.MinSpend,
+		ScopeType:        req.ScopeType,
+		ValidDays:        joinValidDays(req.ValidDays),
+		StartsAt:         startsAt,
+		EndsAt:           endsAt,
+		UsageLimit:       req.UsageLimit,
+		PerCustomerLimit: req.PerCustomerLimit,
+		IsActive:         true,
+		CreatedAt:        time.Now(),
+	}
+	if promoModel.ScopeType == "" {
+		promoModel.ScopeType = models.PromoScopeAll
+	}
+
+	// 4. Validasi aturan bisnis & cakupan
+	targetIDs, err := s.validatePromotion(ctx, promoModel, req.TargetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Insert ke Database (promosi + cakupan dalam satu transaksi)
+	if err := s.promotionRepo.InsertPromotion(ctx, promoModel, targetIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetPromotionDetail(ctx, promoModel.ID)
+}
+
+// GetPromotionList fetches a list of promotions with pagination and filters.
+func (s *promotionService) GetPromotionList(ctx context.Context, page, perPage int, search, status string) (*dto.PromotionListResponse, error) {
+
+	// 1. Validasi Batas Halaman
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 10
+	}
+	offset := (page - 1) * perPage
+
+	// 2. Panggil Repository
+	promos, totalItems, err := s.promotionRepo.FindAll(ctx, perPage, offset, search, status)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Mapping dari Model ke DTO Summary
+	promoResponses := make([]dto.PromotionSummaryResponse, 0, len(promos))
+	for _, p := range promos {
+		promoResponses = append(promoResponses, dto.PromotionSummaryResponse{
+			ID:            p.ID,
+			Code:          p.Code,
+			PromoName:     p.PromoName,
+			DiscountType:  p.DiscountType,
+			DiscountValue: p.DiscountValue,
+			ScopeType:     p.ScopeType,
+			StartsAt:      p.StartsAt.Format("2006-01-02 15:04:05"),
+			EndsAt:        formatTimePtr(p.EndsAt),
+			UsageLimit:    p.UsageLimit,
+			UsageCount:    p.UsageCount,
+			IsActive:      p.IsActive,
+		})
+	}
+
+	return &dto.PromotionListResponse{
+		Data: promoResponses,
+		Meta: response.NewPageMeta(page, perPage, totalItems),
+	}, nil
+}
+
+// GetPromotionDetail retrieves detailed promotion information by ID.
+func (s *promotionService) GetPromotionDetail(ctx context.Context, id int64) (*dto.PromotionDetailResponse, error) {
+
+	promo, err := s.promotionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapToDetailResponse(promo), nil
+}
+
+// ModifyPromotion updates promotion data with validation logic.
+func (s *promotionService) ModifyPromotion(ctx context.Context, targetID int64, req dto.UpdatePromotionRequest) (*dto.PromotionDetailResponse, error) {
+
+	// 1. Ambil Data Promosi yang Lama
+	existingPromo, err := s.promotionRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	promo := existingPromo.Promotion
+
+	// 2. Validasi & Update Kode Voucher (Jika dikirim user, string kosong = jadikan promo otomatis)
+	if req.Code != nil {
+		code := normalizeVoucherCode(req.Code)
+		if code != nil {
+			duplicateCheck, _ := s.promotionRepo.FindByCode(ctx, *code)
+			if duplicateCheck != nil && duplicateCheck.ID != targetID {
+				return nil, response.ErrDuplicate
+			}
+		}
+		promo.Code = code
+	}
+
+	// 3. Update Fields Lainnya (Partial Update)
+	if req.PromoName != nil {
+		promo.PromoName = *req.PromoName
+	}
+	if req.Description != nil {
+		promo.Description = req.Description
+	}
+	if req.DiscountType != nil {
+		promo.DiscountType = *req.DiscountType
+	}
+	if req.DiscountValue != nil {
+		promo.DiscountValue = *req.DiscountValue
+	}
+	if req.MaxDiscount != nil {
+		promo.MaxDiscount = req.MaxDiscount
+	}
+	if req.MinSpend != nil {
+		promo.MinSpend = *req.MinSpend
+	}
+	if req.ScopeType != nil {
+		promo.ScopeType = *req.ScopeType
+	}
+	if req.ValidDays != nil {
+		promo.ValidDays = joinValidDays(req.ValidDays)
+	}
+	if req.StartsAt != nil {
+		startsAt, err := parsePromotionTime("starts_at", *req.StartsAt)
+		if err != nil {
+			return nil, err
+		}
+		promo.StartsAt = startsAt
+	}
+	if req.EndsAt != nil {
+		promo.EndsAt = nil
+		if *req.EndsAt != "" {
+			endsAt, err := parsePromotionTime("ends_at", *req.EndsAt)
+			if err != nil {
+				return nil, err
+			}
+			promo.EndsAt = &endsAt
+		}
+	}
+	if req.UsageLimit != nil {
+		promo.UsageLimit = req.UsageLimit
+	}
+	if req.PerCustomerLimit != nil {
+		promo.PerCustomerLimit = req.PerCustomerLimit
+	}
+	if req.IsActive != nil {
+		promo.IsActive = *req.IsActive
+	}
+
+	// 4. Validasi aturan bisnis & cakupan (pakai cakupan lama jika tidak dikirim)
+	targetIDs := req.TargetIDs
+	if targetIDs == nil {
+		targetIDs = existingPromo.TargetIDs
+	}
+	targetIDs, err = s.validatePromotion(ctx, &promo, targetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Update Waktu (Timestamp)
+	now := time.Now()
+	promo.UpdatedAt = &now
+
+	// 6. Simpan Perubahan ke Database
+	if err := s.promotionRepo.UpdatePromotion(ctx, &promo, targetIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetPromotionDetail(ctx, targetID)
+}
+
+// DeactivatePromotion handles soft deletion of a promotion.
+func (s *promotionService) DeactivatePromotion(ctx context.Context, targetID int64) error {
+
+	// 1. Cek apakah promosi tersebut ada
+	if _, err := s.promotionRepo.FindByID(ctx, targetID); err != nil {
+		return err
+	}
+
+	// 2. Eksekusi Soft Delete
+	return s.promotionRepo.DeletePromotion(ctx, targetID)
+}
+
+// ValidatePromotion previews the discounts a cart would receive at checkout.
+func (s *promotionService) ValidatePromotion(ctx context.Context, req dto.ValidatePromotionRequest) (*dto.PromotionValidationResponse, error) {
+
+	// 1. Hitung harga keranjang menggunakan kalkulator yang sama dengan pesanan
+	quote, err := s.pricingRuleService.QuoteItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Terapkan promosi
+	summary, err := s.ResolveDiscounts(ctx, quote, req.Code, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Mapping ke DTO
+	discounts := make([]dto.AppliedDiscountResponse, 0, len(summary.Discounts))
+	for _, d := range summary.Discounts {
+		discounts = append(discounts, dto.AppliedDiscountResponse{
+			Source:      d.Source,
+			PromotionID: d.PromotionID,
+			Code:        d.Code,
+			PromoName:   d.PromoName,
+			Amount:      d.Amount,
+			Explanation: d.Explanation,
+		})
+	}
+
+	return &dto.PromotionValidationResponse{
+		Subtotal:      summary.Subtotal,
+		Discounts:     discounts,
+		DiscountTotal: summary.DiscountTotal,
+		Total:         summary.Total,
+	}, nil
+}
+
+// ResolveDiscounts applies the member discount, the best automatic promotion and an optional voucher code to a priced cart.
+func (s *promotionService) ResolveDiscounts(ctx context.Context, quote *pricing.OrderQuote, code *string, customerID *int64) (*promotion.Summary, error) {
+
+	// Periode & hari berlaku promosi dinilai di zona bisnis (WIB)
+	now := time.Now().In(config.Location)
+
+	// 1. Bentuk keranjang dari hasil kalkulator harga
+	cart := promotion.Cart{
+		Lines:      make([]promotion.CartLine, 0, len(quote.Lines)),
+		Subtotal:   quote.Subtotal,
+		CustomerID: customerID,
+	}
+	for _, line := range quote.Lines {
+		cart.Lines = append(cart.Lines, promotion.CartLine{
+			ServiceID:  line.ServiceID,
+			CategoryID: line.CategoryID,
+			Amount:     line.LineTotal,
+		})
+	}
+
+	// 2. Ambil diskon member (Jika pelanggan punya level member aktif)
+	var member *promotion.MemberDiscount
+	if customerID != nil {
+		tier, err := s.membershipRepo.FindCustomerTier(ctx, *customerID)
+		if err != nil {
+			return nil, err
+		}
+		if tier != nil {
+			member = &promotion.MemberDiscount{
+				TierID:          tier.ID,
+				TierName:        tier.TierName,
+				DiscountPercent: tier.DiscountPercent,
+				MaxDiscount:     tier.MaxDiscount,
+			}
+		}
+	}
+
+	// 3. Ambil kandidat promo otomatis yang periodenya sedang berjalan
+	automaticPromos, err := s.promotionRepo.FindActiveAutomatic(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	automatic := make([]promotion.Candidate, 0, len(automaticPromos))
+	for i := range automaticPromos {
+		candidate, err := s.buildCandidate(ctx, &automaticPromos[i], customerID)
+		if err != nil {
+			return nil, err
+		}
+		automatic = append(automatic, *candidate)
+	}
+
+	// 4. Ambil kode voucher (Jika dikirim kasir)
+	var voucher *promotion.Candidate
+	if normalized := normalizeVoucherCode(code); normalized != nil {
+		promo, err := s.promotionRepo.FindByCode(ctx, *normalized)
+		if err != nil {
+			return nil, err
+		}
+		if voucher, err = s.buildCandidate(ctx, promo, customerID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 5. Jalankan engine promosi & terjemahkan alasan penolakan ke sentinel error
+	summary, err := promotion.Apply(cart, member, automatic, voucher, now)
+	if err != nil {
+		if errors.Is(err, promotion.ErrExhausted) || errors.Is(err, promotion.ErrCustomerLimit) {
+			return nil, fmt.Errorf("%w: %v", response.ErrPromotionExhausted, err)
+		}
+		return nil, fmt.Errorf("%w: %v", response.ErrPromotionNotApplicable, err)
+	}
+
+	return summary, nil
+}
+
+// RecordRedemptions stores every applied discount inside the caller's order transaction.
+func (s *promotionService) RecordRedemptions(ctx context.Context, tx *sql.Tx, orderID int64, customerID *int64, redeemedBy int64, discounts []promotion.AppliedDiscount) error {
+
+	now := time.Now()
+	for _, d := range discounts {
+
+		// Diskon member & penukaran poin tidak punya kuota, cukup simpan baris penjelasannya
+		if d.Source == promotion.SourceMembership || d.Source == promotion.SourcePoints {
+			orderDiscount := &models.OrderDiscount{
+				OrderID:     orderID,
+				Explanation: d.Explanation,
+				Amount:      d.Amount,
+				CreatedAt:   now,
+			}
+			if err := s.promotionRepo.InsertDiscountTx(ctx, tx, orderDiscount); err != nil {
+				return err
+			}
+			continue
+		}
+
+		promotionID := d.PromotionID
+		redemption := &models.PromotionRedemption{
+			PromotionID:    promotionID,
+			OrderID:        orderID,
+			CustomerID:     customerID,
+			DiscountAmount: d.Amount,
+			RedeemedBy:     &redeemedBy,
+			CreatedAt:      now,
+		}
+		orderDiscount := &models.OrderDiscount{
+			OrderID:     orderID,
+			PromotionID: &promotionID,
+			Code:        d.Code,
+			Explanation: d.Explanation,
+			Amount:      d.Amount,
+			CreatedAt:   now,
+		}
+
+		if err := s.promotionRepo.RedeemTx(ctx, tx, redemption, orderDiscount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+// validatePromotion memeriksa aturan bisnis yang tidak bisa dicek oleh binding tag,
+// lalu mengembalikan daftar target cakupan yang akan disimpan.
+func (s *promotionService) validatePromotion(ctx context.Context, promo *models.Promotion, targetIDs []int64) ([]int64, error) {
+
+	// 1. Nilai diskon
+	if promo.DiscountType == models.DiscountPercentage && money.PercentFromAmount(promo.DiscountValue) > money.NewPercent(100) {
+		return nil, fmt.Errorf("%w: percentage discount cannot exceed 100", response.ErrValidation)
+	}
+	if promo.DiscountType == models.DiscountFixed {
+		promo.MaxDiscount = nil
+	}
+
+	// 2. Periode berlaku
+	if promo.EndsAt != nil && !promo.EndsAt.After(promo.StartsAt) {
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", response.ErrValidation)
+	}
+
+	// 3. Cakupan: 'all' tidak butuh target, selain itu target wajib ada di database
+	switch promo.ScopeType {
+	case models.PromoScopeAll:
+		return []int64{}, nil
+	case models.PromoScopeService, models.PromoScopeCategory:
+		if len(targetIDs) == 0 {
+			return nil, fmt.Errorf("%w: target_ids is required for %s scope", response.ErrValidation, promo.ScopeType)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown scope_type %q", response.ErrValidation, promo.ScopeType)
+	}
+
+	for _, targetID := range targetIDs {
+		var err error
+		if promo.ScopeType == models.PromoScopeService {
+			_, err = s.serviceRepo.FindByID(ctx, targetID)
+		} else {
+			_, err = s.categoryRepo.FindByID(ctx, targetID)
+		}
+		if err != nil {
+			if errors.Is(err, response.ErrNotFound) {
+				return nil, fmt.Errorf("%w: %s %d not found", response.ErrValidation, promo.ScopeType, targetID)
+			}
+			return nil, err
+		}
+	}
+
+	return targetIDs, nil
+}
+
+// buildCandidate melengkapi promosi dengan jumlah pemakaian pelanggan (hanya jika ada kuota per pelanggan).
+func (s *promotionService) buildCandidate(ctx context.Context, promo *models.PromotionWithScopes, customerID *int64) (*promotion.Candidate, error) {
+	candidate := &promotion.Candidate{Promotion: promo.Promotion, TargetIDs: promo.TargetIDs}
+
+	if promo.PerCustomerLimit != nil && customerID != nil {
+		count, err := s.promotionRepo.CountCustomerRedemptions(ctx, promo.ID, *customerID)
+		if err != nil {
+			return nil, err
+		}
+		candidate.CustomerUsage = count
+	}
+
+	return candidate, nil
+}
+
+func (s *promotionService) mapToDetailResponse(promo *models.PromotionWithScopes) *dto.PromotionDetailResponse {
+	return &dto.PromotionDetailResponse{
+		ID:               promo.ID,
+		Code:             promo.Code,
+		PromoName:        promo.PromoName,
+		Description:      promo.Description,
+		DiscountType:     promo.DiscountType,
+		DiscountValue:    promo.DiscountValue,
+		MaxDiscount:      promo.MaxDiscount,
+		MinSpend:         promo.MinSpend,
+		ScopeType:        promo.ScopeType,
+		TargetIDs:        promo.TargetIDs,
+		ValidDays:        splitValidDays(promo.ValidDays),
+		StartsAt:         promo.StartsAt.Format("2006-01-02 15:04:05"),
+		EndsAt:           formatTimePtr(promo.EndsAt),
+		UsageLimit:       promo.UsageLimit,
+		UsageCount:       promo.UsageCount,
+		PerCustomerLimit: promo.PerCustomerLimit,
+		CustomerID:       promo.CustomerID,
+		Explanation:      promotion.Explain(promo.Promotion),
+		IsActive:         promo.IsActive,
+		CreatedAt:        promo.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:        formatTimePtr(promo.UpdatedAt),
+	}
+}
+
+// normalizeVoucherCode merapikan kode voucher (trim & huruf besar); kode kosong berarti promo otomatis.
+func normalizeVoucherCode(code *string) *string {
+	if code == nil {
+		return nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(*code))
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
+
+func parsePromotionTime(field, value string) (time.Time, error) {
+	parsed, err := time.ParseInLocation("2006-01-02 15:04:05", value, config.Location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must use format YYYY-MM-DD HH:MM:SS", response.ErrValidation, field)
+	}
+	return parsed, nil
+}
+
+// joinValidDays menyimpan hari berlaku sebagai "1,6,7" (urut & unik); daftar kosong berarti setiap hari.
+func joinValidDays(days []int) *string {
+	if len(days) == 0 {
+		return nil
+	}
+	seen := make(map[int]bool, len(days))
+	var unique []int
+	for _, day := range days {
+		if !seen[day] {
+			seen[day] = true
+			unique = append(unique, day)
+		}
+	}
+	sort.Ints(unique)
+
+	parts := make([]string, 0, len(unique))
+	for _, day := range unique {
+		parts = append(parts, strconv.Itoa(day))
+	}
+	joined := strings.Join(parts, ",")
+	return &joined
+}
+
+func splitValidDays(validDays *string) []int {
+	days := []int{}
+	if validDays == nil {
+		return days
+	}
+	for _, part := range strings.Split(*validDays, ",") {
+		if day, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+func formatTimePtr(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02 15:04:05")
+	return &formatted
+}
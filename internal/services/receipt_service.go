@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/receipt"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"net/url"
+	"strings"
+)
+
+// ReceiptService defines the contract for rendering printable order receipts.
+type ReceiptService interface {
+	RenderOrderReceipt(ctx context.Context, orderID int64, query dto.ReceiptQuery) (*dto.PrintFile, error)
+}
+
+type receiptService struct {
+	receiptRepo repositories.ReceiptRepository
+	cfg         *config.Config
+}
+
+// NewReceiptService creates a new instance of ReceiptService.
+func NewReceiptService(receiptRepo repositories.ReceiptRepository, cfg *config.Config) ReceiptService {
+	return &receiptService{
+		receiptRepo: receiptRepo,
+		cfg:         cfg,
+	}
+}
+
+// RenderOrderReceipt renders the receipt of an order as PDF, raw ESC/POS bytes or plain text.
+func (s *receiptService) RenderOrderReceipt(ctx context.Context, orderID int64, query dto.ReceiptQuery) (*dto.PrintFile, error) {
+
+	// 1. Default: PDF, kertas thermal 58mm
+	format := query.Format
+	if format == "" {
+		format = receipt.FormatPDF
+	}
+	paper := query.Paper
+	if paper == 0 {
+		paper = receipt.Paper58
+	}
+
+	// 2. Ambil data pesanan lengkap
+	data, err := s.receiptRepo.FindOrderReceipt(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &receipt.Document{
+		Header: receipt.Header{
+			ShopName: s.cfg.SHOP.Name,
+			Address:  s.cfg.SHOP.Address,
+			Phone:    s.cfg.SHOP.Phone,
+			Footer:   s.cfg.SHOP.ReceiptFooter,
+		},
+		Receipt:     data,
+		TrackingURL: strings.TrimRight(s.cfg.SHOP.TrackingBaseURL, "/") + "/track/" + url.PathEscape(data.InvoiceNumber),
+	}
+
+	// 3. Render sesuai format
+	file := &dto.PrintFile{}
+	switch format {
+	case receipt.FormatPDF:
+		file.ContentType = "application/pdf"
+		file.FileName = data.InvoiceNumber + ".pdf"
+		file.Content, err = receipt.RenderPDF(doc)
+	case receipt.FormatESCPOS:
+		file.ContentType = "application/octet-stream"
+		file.FileName = fmt.Sprintf("%s-%dmm.bin", data.InvoiceNumber, paper)
+		file.Content, err = receipt.RenderESCPOS(doc, paper)
+	case receipt.FormatText:
+		file.ContentType = "text/plain; charset=utf-8"
+		file.FileName = fmt.Sprintf("%s-%dmm.txt", data.InvoiceNumber, paper)
+		file.Content, err = receipt.RenderText(doc, paper)
+	default:
+		return nil, fmt.Errorf("%w: unsupported receipt format %q", response.ErrValidation, format)
+	}
+	if err != nil {
+		if errors.Is(err, receipt.ErrUnsupportedPaper) {
+			return nil, fmt.Errorf("%w: %v", response.ErrValidation, err)
+		}
+		return nil, fmt.Errorf("receiptService.RenderOrderReceipt: %w", err)
+	}
+
+	return file, nil
+}
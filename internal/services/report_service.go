@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"math"
+	"sort"
+	"time"
+)
+
+// Pengelompokan periode laporan laba
+const (
+	ReportGroupDay   = "day"
+	ReportGroupWeek  = "week"
+	ReportGroupMonth = "month"
+)
+
+// employeeActivityLabels adalah nama aktivitas yang dihitung per peran di laporan karyawan
+var employeeActivityLabels = map[string]string{
+	"cashier": "Orders Created",
+	"staff":   "Orders Processed",
+	"courier": "Deliveries Completed",
+}
+
+// ReportService defines the contract for cross-module owner reports.
+type ReportService interface {
+	GetProfitReport(ctx context.Context, startDate, endDate, groupBy string) (*dto.ProfitReportResponse, error)
+	GetEmployeeReport(ctx context.Context, startDate, endDate string) (*dto.EmployeeReportResponse, error)
+}
+
+type reportService struct {
+	reportRepo repositories.ReportRepository
+}
+
+// NewReportService creates a new instance of ReportService.
+func NewReportService(reportRepo repositories.ReportRepository) ReportService {
+	return &reportService{reportRepo: reportRepo}
+}
+
+// GetProfitReport compares net revenue of paid orders with operational expenses per period (outlet aktif / konsolidasi).
+func (s *reportService) GetProfitReport(ctx context.Context, startDate, endDate, groupBy string) (*dto.ProfitReportResponse, error) {
+
+	// 1. Validasi rentang tanggal & pengelompokan
+	start, endExclusive, err := parseReportRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if groupBy == "" {
+		groupBy = ReportGroupDay
+	}
+	if groupBy != ReportGroupDay && groupBy != ReportGroupWeek && groupBy != ReportGroupMonth {
+		return nil, fmt.Errorf("%w: group_by must be one of day, week, month", response.ErrValidation)
+	}
+
+	// 2. Agregasi harian pendapatan & biaya, serta rincian biaya per kategori
+	revenues, err := s.reportRepo.SumRevenueByDay(ctx, start, endExclusive)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.reportRepo.SumExpensesByDay(ctx, start, endExclusive)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.reportRepo.SumExpensesByCategory(ctx, start, endExclusive)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Siapkan periode kosong (tetap muncul walau tanpa transaksi), dipotong sesuai rentang laporan
+	periods, index := buildReportPeriods(start, endExclusive, groupBy)
+
+	// 4. Masukkan total harian ke periodenya
+	res := &dto.ProfitReportResponse{
+		Period:           dto.ProfitReportPeriod{StartDate: startDate, EndDate: endDate},
+		GroupBy:          groupBy,
+		ExpenseBreakdown: make([]dto.ProfitExpenseCategoryResponse, 0, len(categories)),
+	}
+	if outletID := outlet.FromContext(ctx); outletID != outlet.All {
+		res.OutletID = &outletID
+	}
+	for _, d := range revenues {
+		if i, ok := periodIndex(index, d); ok {
+			periods[i].PaidOrders += d.Count
+			periods[i].Revenue = periods[i].Revenue.Add(d.Amount)
+		}
+		res.PaidOrders += d.Count
+		res.Revenue = res.Revenue.Add(d.Amount)
+	}
+	for _, d := range expenses {
+		if i, ok := periodIndex(index, d); ok {
+			periods[i].Expenses = periods[i].Expenses.Add(d.Amount)
+		}
+		res.Expenses = res.Expenses.Add(d.Amount)
+	}
+	for i := range periods {
+		periods[i].Profit = periods[i].Revenue.Sub(periods[i].Expenses)
+	}
+	res.Profit = res.Revenue.Sub(res.Expenses)
+	res.Periods = periods
+
+	for _, c := range categories {
+		res.ExpenseBreakdown = append(res.ExpenseBreakdown, dto.ProfitExpenseCategoryResponse{
+			ExpenseCategoryID: c.ExpenseCategoryID,
+			CategoryName:      c.CategoryName,
+			Amount:            c.Amount,
+		})
+	}
+
+	return res, nil
+}
+
+// GetEmployeeReport ranks cashier, staff, and courier productivity in the range together with their complaint count.
+func (s *reportService) GetEmployeeReport(ctx context.Context, startDate, endDate string) (*dto.EmployeeReportResponse, error) {
+
+	// 1. Validasi rentang tanggal
+	start, endExclusive, err := parseReportRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	totalDays := int(math.Round(endExclusive.Sub(start).Hours() / 24))
+
+	// 2. Ambil aktivitas & komplain per karyawan
+	activities, err := s.reportRepo.CountEmployeeActivity(ctx, start, endExclusive)
+	if err != nil {
+		return nil, err
+	}
+	complaints, err := s.reportRepo.CountEmployeeComplaints(ctx, start, endExclusive)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Gabungkan: karyawan yang hanya punya komplain tetap muncul dengan aktivitas 0
+	rows := make(map[int64]*dto.EmployeePerformanceResponse)
+	row := func(a models.EmployeeActivity) *dto.EmployeePerformanceResponse {
+		if r, ok := rows[a.UserID]; ok {
+			return r
+		}
+		r := &dto.EmployeePerformanceResponse{
+			EmployeeID: a.UserID,
+			Name:       a.FullName,
+			Role:       a.Role,
+			Activity:   employeeActivityLabels[a.Role],
+		}
+		rows[a.UserID] = r
+		return r
+	}
+	for _, a := range activities {
+		row(a).TotalActivity += a.Count
+	}
+	for _, a := range complaints {
+		row(a).ComplaintCount += a.Count
+	}
+
+	// 4. Kelompokkan per peran, urut aktivitas terbanyak
+	res := &dto.EmployeeReportResponse{
+		ReportPeriod:       dto.EmployeeReportPeriod{StartDate: startDate, EndDate: endDate, TotalDays: totalDays},
+		CashierPerformance: []dto.EmployeePerformanceResponse{},
+		StaffPerformance:   []dto.EmployeePerformanceResponse{},
+		CourierPerformance: []dto.EmployeePerformanceResponse{},
+	}
+	if outletID := outlet.FromContext(ctx); outletID != outlet.All {
+		res.OutletID = &outletID
+	}
+	for _, r := range rows {
+		r.AveragePerDay = math.Round(float64(r.TotalActivity)/float64(totalDays)*10) / 10
+		switch r.Role {
+		case "cashier":
+			res.CashierPerformance = append(res.CashierPerformance, *r)
+		case "staff":
+			res.StaffPerformance = append(res.StaffPerformance, *r)
+		case "courier":
+			res.CourierPerformance = append(res.CourierPerformance, *r)
+		}
+	}
+	for _, list := range [][]dto.EmployeePerformanceResponse{res.CashierPerformance, res.StaffPerformance, res.CourierPerformance} {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].TotalActivity != list[j].TotalActivity {
+				return list[i].TotalActivity > list[j].TotalActivity
+			}
+			return list[i].EmployeeID < list[j].EmployeeID
+		})
+	}
+
+	return res, nil
+}
+
+// --- HELPER FUNCTION ---
+
+// nextPeriodStart mengembalikan awal periode berikutnya: besok, Senin berikutnya, atau tanggal 1 bulan berikutnya.
+func nextPeriodStart(t time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case ReportGroupWeek:
+		daysToMonday := (8 - int(t.Weekday())) % 7
+		if daysToMonday == 0 {
+			daysToMonday = 7
+		}
+		return t.AddDate(0, 0, daysToMonday)
+	case ReportGroupMonth:
+		return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// buildReportPeriods menyusun periode [start, endExclusive) per hari/minggu/bulan beserta indeks tanggal → periode.
+// Periode pertama & terakhir dipotong sesuai rentang laporan.
+func buildReportPeriods(start, endExclusive time.Time, groupBy string) ([]dto.ProfitReportRowResponse, map[string]int) {
+
+	periods := make([]dto.ProfitReportRowResponse, 0)
+	index := make(map[string]int)
+	for cursor := start; cursor.Before(endExclusive); {
+		next := nextPeriodStart(cursor, groupBy)
+		last := next.AddDate(0, 0, -1)
+		if !next.Before(endExclusive) {
+			last = endExclusive.AddDate(0, 0, -1)
+		}
+		for day := cursor; !day.After(last); day = day.AddDate(0, 0, 1) {
+			index[day.Format("2006-01-02")] = len(periods)
+		}
+		periods = append(periods, dto.ProfitReportRowResponse{
+			PeriodStart: cursor.Format("2006-01-02"),
+			PeriodEnd:   last.Format("2006-01-02"),
+		})
+		cursor = next
+	}
+
+	return periods, index
+}
+
+func periodIndex(index map[string]int, d models.DailyAmount) (int, bool) {
+	i, ok := index[d.Date]
+	return i, ok
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+)
+
+func reportDate(t *testing.T, value string) time.Time {
+	t.Helper()
+	d, err := time.ParseInLocation("2006-01-02", value, config.Location)
+	if err != nil {
+		t.Fatalf("parse %q: %v", value, err)
+	}
+	return d
+}
+
+func TestNextPeriodStart(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		from    string
+		groupBy string
+		want    string
+	}{
+		{name: "day within month", from: "2026-01-14", groupBy: ReportGroupDay, want: "2026-01-15"},
+		{name: "day at month end", from: "2026-01-31", groupBy: ReportGroupDay, want: "2026-02-01"},
+		{name: "day at year end", from: "2025-12-31", groupBy: ReportGroupDay, want: "2026-01-01"},
+		{name: "week from thursday", from: "2026-01-01", groupBy: ReportGroupWeek, want: "2026-01-05"},
+		{name: "week from monday", from: "2026-01-05", groupBy: ReportGroupWeek, want: "2026-01-12"},
+		{name: "week from sunday", from: "2026-01-11", groupBy: ReportGroupWeek, want: "2026-01-12"},
+		{name: "week across year", from: "2025-12-31", groupBy: ReportGroupWeek, want: "2026-01-05"},
+		{name: "week across month", from: "2026-01-28", groupBy: ReportGroupWeek, want: "2026-02-02"},
+		{name: "month from first day", from: "2026-01-01", groupBy: ReportGroupMonth, want: "2026-02-01"},
+		{name: "month from last day", from: "2026-01-31", groupBy: ReportGroupMonth, want: "2026-02-01"},
+		{name: "month across year", from: "2025-12-10", groupBy: ReportGroupMonth, want: "2026-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextPeriodStart(reportDate(t, tt.from), tt.groupBy)
+			if got.Format("2006-01-02") != tt.want {
+				t.Fatalf("nextPeriodStart(%s, %s) = %s, want %s", tt.from, tt.groupBy, got.Format("2006-01-02"), tt.want)
+			}
+			if got.Hour() != 0 || got.Minute() != 0 || got.Location() != config.Location {
+				t.Fatalf("nextPeriodStart must return midnight in config.Location, got %v", got)
+			}
+		})
+	}
+}
+
+func TestBuildReportPeriods(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		start     string
+		end       string // Inklusif, seperti end_date di query string
+		groupBy   string
+		want      []dto.ProfitReportRowResponse
+		wantIndex map[string]int
+	}{
+		{
+			name: "days across month end", start: "2026-01-30", end: "2026-02-02", groupBy: ReportGroupDay,
+			want: []dto.ProfitReportRowResponse{
+				{PeriodStart: "2026-01-30", PeriodEnd: "2026-01-30"},
+				{PeriodStart: "2026-01-31", PeriodEnd: "2026-01-31"},
+				{PeriodStart: "2026-02-01", PeriodEnd: "2026-02-01"},
+				{PeriodStart: "2026-02-02", PeriodEnd: "2026-02-02"},
+			},
+			wantIndex: map[string]int{"2026-01-31": 1, "2026-02-01": 2},
+		},
+		{
+			name: "weeks clipped at both ends", start: "2026-01-01", end: "2026-01-20", groupBy: ReportGroupWeek,
+			want: []dto.ProfitReportRowResponse{
+				{PeriodStart: "2026-01-01", PeriodEnd: "2026-01-04"},
+				{PeriodStart: "2026-01-05", PeriodEnd: "2026-01-11"},
+				{PeriodStart: "2026-01-12", PeriodEnd: "2026-01-18"},
+				{PeriodStart: "2026-01-19", PeriodEnd: "2026-01-20"},
+			},
+			wantIndex: map[string]int{"2026-01-04": 0, "2026-01-05": 1, "2026-01-18": 2, "2026-01-19": 3, "2026-01-20": 3},
+		},
+		{
+			name: "week across year end", start: "2025-12-29", end: "2026-01-04", groupBy: ReportGroupWeek,
+			want: []dto.ProfitReportRowResponse{
+				{PeriodStart: "2025-12-29", PeriodEnd: "2026-01-04"},
+			},
+			wantIndex: map[string]int{"2025-12-31": 0, "2026-01-01": 0},
+		},
+		{
+			name: "months clipped at both ends", start: "2026-01-15", end: "2026-03-10", groupBy: ReportGroupMonth,
+			want: []dto.ProfitReportRowResponse{
+				{PeriodStart: "2026-01-15", PeriodEnd: "2026-01-31"},
+				{PeriodStart: "2026-02-01", PeriodEnd: "2026-02-28"},
+				{PeriodStart: "2026-03-01", PeriodEnd: "2026-03-10"},
+			},
+			wantIndex: map[string]int{"2026-01-31": 0, "2026-02-01": 1, "2026-02-28": 1, "2026-03-01": 2},
+		},
+		{
+			name: "leap february", start: "2028-02-10", end: "2028-03-01", groupBy: ReportGroupMonth,
+			want: []dto.ProfitReportRowResponse{
+				{PeriodStart: "2028-02-10", PeriodEnd: "2028-02-29"},
+				{PeriodStart: "2028-03-01", PeriodEnd: "2028-03-01"},
+			},
+			wantIndex: map[string]int{"2028-02-29": 0, "2028-03-01": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, endExclusive, err := parseReportRange(tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("parseReportRange: %v", err)
+			}
+
+			periods, index := buildReportPeriods(start, endExclusive, tt.groupBy)
+			if len(periods) != len(tt.want) {
+				t.Fatalf("got %d periods %+v, want %d", len(periods), periods, len(tt.want))
+			}
+			for i, p := range periods {
+				if p.PeriodStart != tt.want[i].PeriodStart || p.PeriodEnd != tt.want[i].PeriodEnd {
+					t.Fatalf("period %d = %s..%s, want %s..%s", i, p.PeriodStart, p.PeriodEnd, tt.want[i].PeriodStart, tt.want[i].PeriodEnd)
+				}
+			}
+			for day, want := range tt.wantIndex {
+				if got, ok := index[day]; !ok || got != want {
+					t.Fatalf("index[%s] = %d (%v), want %d", day, got, ok, want)
+				}
+			}
+
+			// Setiap hari dalam rentang punya tepat satu periode
+			days := int(endExclusive.Sub(start).Hours() / 24)
+			if len(index) != days {
+				t.Fatalf("index covers %d days, want %d", len(index), days)
+			}
+		})
+	}
+}
+
+func TestParseReportRangeUsesBusinessZone(t *testing.T) {
+
+	start, endExclusive, err := parseReportRange("2026-01-31", "2026-01-31")
+	if err != nil {
+		t.Fatalf("parseReportRange: %v", err)
+	}
+	if start.Location() != config.Location || endExclusive.Location() != config.Location {
+		t.Fatalf("range must be in config.Location, got %v / %v", start.Location(), endExclusive.Location())
+	}
+
+	// 00:30 WIB = 17:30 UTC hari sebelumnya; tetap harus masuk tanggal WIB-nya
+	afterMidnight := time.Date(2026, 1, 30, 17, 30, 0, 0, time.UTC)
+	if afterMidnight.Before(start) || !afterMidnight.Before(endExclusive) {
+		t.Fatalf("%v must fall inside [%v, %v)", afterMidnight, start, endExclusive)
+	}
+	if _, _, err := parseReportRange("2026-02-01", "2026-01-31"); err == nil {
+		t.Fatal("expected validation error when end_date is before start_date")
+	}
+}
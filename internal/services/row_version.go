@@ -0,0 +1,13 @@
+package services
+
+import "laundry-backend/pkg/response"
+
+// checkExpectedVersion mencocokkan versi baris saat ini dengan header If-Match dari klien.
+// expected nil berarti klien tidak mengirim If-Match (atau "*"), sehingga tidak ada syarat tambahan;
+// UPDATE tetap dijaga WHERE version = ? di repository terhadap perubahan di antara baca & tulis.
+func checkExpectedVersion(current int64, expected *int64) error {
+	if expected != nil && *expected != current {
+		return response.ErrPreconditionFailed
+	}
+	return nil
+}
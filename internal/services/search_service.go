@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchService defines the contract for the global search box.
+type SearchService interface {
+	Search(ctx context.Context, query dto.SearchQuery, requesterID int64, requesterRole string) (*dto.SearchResponse, error)
+}
+
+type searchService struct {
+	searchRepo repositories.SearchRepository
+}
+
+// NewSearchService creates a new instance of SearchService.
+func NewSearchService(searchRepo repositories.SearchRepository) SearchService {
+	return &searchService{searchRepo: searchRepo}
+}
+
+// Batas pencarian global
+const (
+	defaultSearchLimit = 5
+	minSearchTermRunes = 2 // Sama dengan ngram_token_size bawaan MySQL
+	maxSearchTerms     = 8
+)
+
+// searchScope adalah batasan pencarian sesuai role peminta.
+type searchScope struct {
+	types              []string
+	courierID          *int64 // Kurir hanya melihat pesanan antar miliknya
+	activeServicesOnly bool   // Selain owner hanya melihat layanan aktif (sama seperti GET /services)
+}
+
+// scopeForRole menentukan jenis entitas yang boleh dicari setiap role.
+func scopeForRole(requesterID int64, requesterRole string) searchScope {
+	switch requesterRole {
+	case "owner":
+		return searchScope{types: []string{models.SearchTypeOrders, models.SearchTypeCustomers, models.SearchTypeServices}}
+	case "cashier":
+		return searchScope{types: []string{models.SearchTypeOrders, models.SearchTypeCustomers, models.SearchTypeServices}, activeServicesOnly: true}
+	case "staff":
+		return searchScope{types: []string{models.SearchTypeOrders, models.SearchTypeServices}, activeServicesOnly: true}
+	case "courier":
+		return searchScope{types: []string{models.SearchTypeOrders}, courierID: &requesterID}
+	default:
+		return searchScope{}
+	}
+}
+
+// Search finds orders, customers and services matching the keyword, ranked and grouped per entity type.
+func (s *searchService) Search(ctx context.Context, query dto.SearchQuery, requesterID int64, requesterRole string) (*dto.SearchResponse, error) {
+
+	// 1. Ubah kata kunci menjadi ekspresi BOOLEAN MODE yang aman
+	keyword := strings.TrimSpace(query.Q)
+	match := buildFulltextQuery(keyword)
+	if match == "" {
+		return nil, fmt.Errorf("%w: q must contain at least one word of %d or more characters", response.ErrValidation, minSearchTermRunes)
+	}
+
+	limit := query.Limit
+	if limit < 1 {
+		limit = defaultSearchLimit
+	}
+
+	// 2. Tentukan jenis entitas: irisan permintaan klien dengan hak akses role
+	scope := scopeForRole(requesterID, requesterRole)
+	types := scope.types
+	if query.Types != "" {
+		requested := strings.Split(query.Types, ",")
+		types = make([]string, 0, len(requested))
+		for _, t := range scope.types {
+			for _, r := range requested {
+				if strings.TrimSpace(r) == t {
+					types = append(types, t)
+					break
+				}
+			}
+		}
+	}
+
+	// 3. Jalankan pencarian per jenis entitas
+	groups := make([]dto.SearchGroup, 0, len(types))
+	for _, t := range types {
+		group, err := s.searchType(ctx, t, match, keyword, scope, limit)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, *group)
+	}
+
+	return &dto.SearchResponse{Query: keyword, Groups: groups}, nil
+}
+
+// searchType menjalankan pencarian satu jenis entitas lalu memetakannya ke DTO.
+func (s *searchService) searchType(ctx context.Context, searchType, match, keyword string, scope searchScope, limit int) (*dto.SearchGroup, error) {
+	switch searchType {
+	case models.SearchTypeOrders:
+		hits, err := s.searchRepo.SearchOrders(ctx, match, keyword, scope.courierID, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dto.SearchOrderResult, 0, len(hits))
+		for _, h := range hits {
+			items = append(items, dto.SearchOrderResult{
+				ID:            h.ID,
+				InvoiceNumber: h.InvoiceNumber,
+				CustomerName:  h.CustomerName,
+				CustomerPhone: h.CustomerPhone,
+				Status:        h.Status,
+				PaymentStatus: h.PaymentStatus,
+				GrandTotal:    h.GrandTotal,
+				IsDelivery:    h.IsDelivery,
+				MatchedItem:   h.MatchedItem,
+				Score:         h.Score,
+				CreatedAt:     h.CreatedAt.Format("2006-01-02 15:04:05"),
+			})
+		}
+		return &dto.SearchGroup{Type: searchType, Count: len(items), Items: items}, nil
+
+	case models.SearchTypeCustomers:
+		hits, err := s.searchRepo.SearchCustomers(ctx, match, keyword, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dto.SearchCustomerResult, 0, len(hits))
+		for _, h := range hits {
+			items = append(items, dto.SearchCustomerResult{
+				ID:          h.ID,
+				FullName:    h.FullName,
+				PhoneNumber: h.PhoneNumber,
+				IsActive:    h.IsActive,
+				Score:       h.Score,
+			})
+		}
+		return &dto.SearchGroup{Type: searchType, Count: len(items), Items: items}, nil
+
+	default:
+		hits, err := s.searchRepo.SearchServices(ctx, match, keyword, scope.activeServicesOnly, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dto.SearchServiceResult, 0, len(hits))
+		for _, h := range hits {
+			items = append(items, dto.SearchServiceResult{
+				ID:          h.ID,
+				Code:        h.Code,
+				ServiceName: h.ServiceName,
+				Unit:        h.Unit,
+				Price:       h.Price,
+				IsActive:    h.IsActive,
+				Score:       h.Score,
+			})
+		}
+		return &dto.SearchGroup{Type: searchType, Count: len(items), Items: items}, nil
+	}
+}
+
+// buildFulltextQuery mengubah kata kunci bebas menjadi ekspresi BOOLEAN MODE: setiap kata wajib ada
+// sebagai frasa (`+"budi" +"inv-260117"`). Karena selalu dibungkus tanda kutip, operator boolean dari user
+// tidak berlaku; tanda kutip di dalam kata dibuang agar frasa tidak bisa ditutup lebih awal.
+// Potongan nomor HP seperti "0812-3456" dicari dalam dua bentuk (apa adanya & angka saja),
+// karena nomor pelanggan tersimpan sesuai ketikan kasir.
+// Mengembalikan string kosong jika tidak ada kata yang cukup panjang untuk index ngram.
+func buildFulltextQuery(keyword string) string {
+	terms := make([]string, 0, maxSearchTerms)
+	for _, word := range strings.Fields(keyword) {
+		if len(terms) == maxSearchTerms {
+			break
+		}
+
+		// 1. Buang tanda kutip agar kata tetap satu frasa
+		word = strings.ReplaceAll(word, `"`, "")
+		if utf8.RuneCountInString(word) < minSearchTermRunes {
+			continue
+		}
+
+		// 2. Nomor HP dengan pemisah: cocokkan salah satu bentuk
+		if digits := phoneDigits(word); digits != word && utf8.RuneCountInString(digits) >= minSearchTermRunes {
+			terms = append(terms, `+("`+word+`" "`+digits+`")`)
+			continue
+		}
+
+		terms = append(terms, `+"`+word+`"`)
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// phoneDigits mengembalikan digit saja jika kata hanya berisi digit dan pemisah nomor telepon (+, -, ., (, )).
+// Kata lain dikembalikan apa adanya.
+func phoneDigits(word string) string {
+	var digits strings.Builder
+	for _, r := range word {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case strings.ContainsRune("+-.()", r):
+		default:
+			return word
+		}
+	}
+	return digits.String()
+}
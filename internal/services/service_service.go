@@ -3,8 +3,10 @@ package services
 import (
 	"context"
 	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
 	"laundry-backend/internal/models"
 	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/listquery"
 	"laundry-backend/pkg/response"
 	"time"
 )
@@ -12,14 +14,17 @@ import (
 // ServiceService defines the contract for business logic related to services.
 type ServiceService interface {
 	CreateService(ctx context.Context, req dto.CreateServiceRequest) (*dto.ServiceDetailResponse, error)
-	GetServiceList(ctx context.Context, page, perPage int, search, status, sortBy, sortOrder string) (*dto.ServiceListResponse, error)
+	GetServiceList(ctx context.Context, params listquery.Params) (*dto.ServiceListResponse, error)
+	ExportServiceList(ctx context.Context, params listquery.Params, w export.Writer) error
 	GetServiceDetail(ctx context.Context, id int64) (*dto.ServiceDetailResponse, error)
 
 	// ModifyService updates service information with validation logic.
-	ModifyService(ctx context.Context, targetID int64, req dto.UpdateServiceRequest) (*dto.ServiceDetailResponse, error)
+	// expectedVersion berasal dari header If-Match (nil jika tidak dikirim).
+	ModifyService(ctx context.Context, targetID int64, req dto.UpdateServiceRequest, expectedVersion *int64) (*dto.ServiceDetailResponse, error)
 
 	// DeactivateService handles soft deletion of a service.
-	DeactivateService(ctx context.Context, targetID int64) error
+	// expectedVersion berasal dari header If-Match (nil jika tidak dikirim).
+	DeactivateService(ctx context.Context, targetID int64, expectedVersion *int64) error
 }
 
 type serviceService struct {
@@ -71,27 +76,15 @@ func (s *serviceService) CreateService(ctx context.Context, req dto.CreateServic
 }
 
 // GetServiceList fetches a list of services with pagination, filters, and sorting.
-func (s *serviceService) GetServiceList(ctx context.Context, page, perPage int, search, status, sortBy, sortOrder string) (*dto.ServiceListResponse, error) {
+func (s *serviceService) GetServiceList(ctx context.Context, params listquery.Params) (*dto.ServiceListResponse, error) {
 
-	// 1. Validasi Batas Halaman
-	if page < 1 {
-		page = 1
-	}
-	if perPage < 1 {
-		perPage = 10
-	}
-
-	// 2. Konversi Page ke Offset untuk SQL
-	limit := perPage
-	offset := (page - 1) * perPage
-
-	// 3. Panggil Repository
-	services, totalItems, err := s.serviceRepo.FindAll(ctx, limit, offset, search, status, sortBy, sortOrder)
+	// 1. Panggil Repository (batas halaman, filter & sorting divalidasi oleh listquery)
+	services, page, err := s.serviceRepo.FindAll(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	// 4. Mapping dari Model ke DTO Summary
+	// 2. Mapping dari Model ke DTO Summary
 	var serviceResponses []dto.ServiceSummaryResponse
 	for _, svc := range services {
 		serviceResponses = append(serviceResponses, dto.ServiceSummaryResponse{
@@ -110,29 +103,43 @@ func (s *serviceService) GetServiceList(ctx context.Context, page, perPage int,
 		})
 	}
 
-	// 5. Cegah nilai "null" di JSON jika database kosong
+	// 3. Cegah nilai "null" di JSON jika database kosong
 	if serviceResponses == nil {
 		serviceResponses = []dto.ServiceSummaryResponse{}
 	}
 
-	// 6. Hitung Total Halaman (Menggunakan integer math standar Kapten)
-	var totalPages int
-	if perPage > 0 {
-		totalPages = int((totalItems + int64(perPage) - 1) / int64(perPage))
-	}
-
-	// 7. Kembalikan Response Akhir
+	// 4. Kembalikan Response Akhir
 	return &dto.ServiceListResponse{
 		Data: serviceResponses,
-		Meta: response.MetaData{
-			CurrentPage: page,
-			PerPage:     perPage,
-			TotalItems:  totalItems,
-			TotalPages:  totalPages,
-		},
+		Meta: page.Meta(),
 	}, nil
 }
 
+// serviceExportColumns adalah judul kolom export GET /services
+var serviceExportColumns = []export.Column{
+	{ID: "ID", EN: "ID"},
+	{ID: "Kode", EN: "Code"},
+	{ID: "Nama Layanan", EN: "Service Name"},
+	{ID: "Kategori", EN: "Category"},
+	{ID: "Satuan", EN: "Unit"},
+	{ID: "Harga", EN: "Price"},
+	{ID: "Estimasi (Jam)", EN: "Duration (Hours)"},
+	{ID: "Aktif", EN: "Active"},
+	{ID: "Dibuat Pada", EN: "Created At"},
+}
+
+// ExportServiceList streams every service matching the list filters (without pagination) into w.
+func (s *serviceService) ExportServiceList(ctx context.Context, params listquery.Params, w export.Writer) error {
+	w.SetColumns(serviceExportColumns)
+
+	return s.serviceRepo.StreamAll(ctx, params, func(svc *models.ServiceWithCategory) error {
+		return w.WriteRow(
+			svc.ID, svc.Code, svc.ServiceName, svc.CategoryName, svc.Unit,
+			svc.Price, svc.DurationHours, svc.IsActive, svc.CreatedAt,
+		)
+	})
+}
+
 // GetServiceDetail retrieves detailed service information by ID.
 func (s *serviceService) GetServiceDetail(ctx context.Context, id int64) (*dto.ServiceDetailResponse, error) {
 
@@ -147,13 +154,16 @@ func (s *serviceService) GetServiceDetail(ctx context.Context, id int64) (*dto.S
 }
 
 // ModifyService updates service profile with validation logic.
-func (s *serviceService) ModifyService(ctx context.Context, targetID int64, req dto.UpdateServiceRequest) (*dto.ServiceDetailResponse, error) {
+func (s *serviceService) ModifyService(ctx context.Context, targetID int64, req dto.UpdateServiceRequest, expectedVersion *int64) (*dto.ServiceDetailResponse, error) {
 
-	// 1. Ambil Data Layanan yang Lama
+	// 1. Ambil Data Layanan yang Lama & pastikan belum diubah orang lain (If-Match)
 	existingService, err := s.serviceRepo.FindByID(ctx, targetID)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkExpectedVersion(existingService.Version, expectedVersion); err != nil {
+		return nil, err
+	}
 
 	// 2. Validasi & Update Kode (Jika dikirim user)
 	if req.Code != nil && *req.Code != existingService.Code {
@@ -205,6 +215,7 @@ func (s *serviceService) ModifyService(ctx context.Context, targetID int64, req
 		DurationHours: existingService.DurationHours,
 		IsActive:      existingService.IsActive,
 		UpdatedAt:     existingService.UpdatedAt,
+		Version:       existingService.Version,
 	}
 
 	// 7. Simpan Perubahan ke Database
@@ -218,16 +229,22 @@ func (s *serviceService) ModifyService(ctx context.Context, targetID int64, req
 }
 
 // DeactivateService handles soft deletion of a service.
-func (s *serviceService) DeactivateService(ctx context.Context, targetID int64) error {
+func (s *serviceService) DeactivateService(ctx context.Context, targetID int64, expectedVersion *int64) error {
 
-	// 1. Cek apakah layanan tersebut ada
-	_, err := s.serviceRepo.FindByID(ctx, targetID)
+	// 1. Cek apakah layanan tersebut ada (yang sudah nonaktif dianggap tidak ada) & belum diubah orang lain
+	existingService, err := s.serviceRepo.FindByID(ctx, targetID)
 	if err != nil {
 		return err
 	}
+	if !existingService.IsActive {
+		return response.ErrNotFound
+	}
+	if err := checkExpectedVersion(existingService.Version, expectedVersion); err != nil {
+		return err
+	}
 
-	// 2. Eksekusi Soft Delete
-	err = s.serviceRepo.DeleteService(ctx, targetID)
+	// 2. Eksekusi Soft Delete berversi
+	err = s.serviceRepo.DeleteService(ctx, targetID, existingService.Version)
 	if err != nil {
 		return err
 	}
@@ -257,6 +274,7 @@ func (s *serviceService) mapToDetailResponse(svc *models.ServiceWithCategory) *d
 		Price:         svc.Price,
 		DurationHours: svc.DurationHours,
 		IsActive:      svc.IsActive,
+		Version:       svc.Version,
 		CreatedAt:     createdAtStr, // Gunakan variabel string yang sudah diformat
 		UpdatedAt:     updatedAtPtr, // Gunakan pointer string yang sudah diformat
 		Category: &dto.NestedCategoryResponse{
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// Status selisih laci kas pada laporan shift
+const (
+	discrepancyBalanced = "balanced"
+	discrepancyShort    = "short"
+	discrepancyOver     = "over"
+)
+
+// ShiftService defines the contract for cashier shifts and end-of-shift cash-drawer reconciliation.
+type ShiftService interface {
+	OpenShift(ctx context.Context, cashierID int64, req dto.OpenShiftRequest) (*dto.ShiftDetailResponse, error)
+	GetCurrentShift(ctx context.Context, cashierID int64) (*dto.ShiftDetailResponse, error)
+	RecordPayout(ctx context.Context, cashierID int64, req dto.CreatePettyCashPayoutRequest) (*dto.PettyCashPayoutResponse, error)
+	CloseShift(ctx context.Context, cashierID int64, req dto.CloseShiftRequest) (*dto.ShiftDetailResponse, error)
+	GetShiftDetail(ctx context.Context, id, requesterID int64, requesterRole string) (*dto.ShiftDetailResponse, error)
+	GetShiftReport(ctx context.Context, startDate, endDate string, tolerance money.Amount) (*dto.ShiftReportResponse, error)
+}
+
+type shiftService struct {
+	shiftRepo repositories.ShiftRepository
+}
+
+// NewShiftService creates a new instance of ShiftService.
+func NewShiftService(shiftRepo repositories.ShiftRepository) ShiftService {
+	return &shiftService{shiftRepo: shiftRepo}
+}
+
+// OpenShift opens a new shift at the active outlet with the declared opening float.
+func (s *shiftService) OpenShift(ctx context.Context, cashierID int64, req dto.OpenShiftRequest) (*dto.ShiftDetailResponse, error) {
+
+	// 1. Shift selalu terikat satu outlet (owner mode konsolidasi harus memilih outlet dulu)
+	outletID := outlet.FromContext(ctx)
+	if outletID == outlet.All {
+		return nil, fmt.Errorf("%w: switch to an outlet before opening a shift", response.ErrValidation)
+	}
+
+	// 2. Satu kasir hanya boleh punya satu shift terbuka
+	if _, err := s.shiftRepo.FindOpenShift(ctx, cashierID); err == nil {
+		return nil, response.ErrShiftAlreadyOpen
+	} else if !errors.Is(err, response.ErrNotFound) {
+		return nil, err
+	}
+
+	// 3. Simpan shift baru
+	shift := &models.CashierShift{
+		OutletID:     outletID,
+		CashierID:    cashierID,
+		Status:       models.ShiftStatusOpen,
+		OpeningFloat: req.OpeningFloat,
+		OpenNote:     trimNote(req.Note),
+		OpenedAt:     time.Now(),
+	}
+	if err := s.shiftRepo.InsertShift(ctx, shift); err != nil {
+		return nil, err
+	}
+
+	// 4. Ambil ulang agar nama kasir ikut terisi
+	return s.GetCurrentShift(ctx, cashierID)
+}
+
+// GetCurrentShift retrieves the caller's open shift with its running cash totals.
+func (s *shiftService) GetCurrentShift(ctx context.Context, cashierID int64) (*dto.ShiftDetailResponse, error) {
+
+	// 1. Ambil shift terbuka
+	shift, err := s.shiftRepo.FindOpenShift(ctx, cashierID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return nil, response.ErrShiftNotOpen
+		}
+		return nil, err
+	}
+
+	// 2. Hitung total berjalan
+	if err := s.applyRunningTotals(ctx, shift); err != nil {
+		return nil, err
+	}
+
+	return s.buildShiftDetail(ctx, shift)
+}
+
+// RecordPayout records petty cash taken out of the drawer during the caller's open shift.
+func (s *shiftService) RecordPayout(ctx context.Context, cashierID int64, req dto.CreatePettyCashPayoutRequest) (*dto.PettyCashPayoutResponse, error) {
+
+	// 1. Mulai transaksi & kunci shift terbuka (agar tidak bentrok dengan penutupan shift)
+	tx, err := s.shiftRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	shift, err := s.shiftRepo.LockOpenShiftTx(ctx, tx, cashierID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return nil, response.ErrShiftNotOpen
+		}
+		return nil, err
+	}
+
+	// 2. Simpan pengeluaran kas kecil
+	payout := &models.PettyCashPayout{
+		ShiftID:     shift.ID,
+		Amount:      req.Amount,
+		Description: strings.TrimSpace(req.Description),
+		CreatedBy:   cashierID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.shiftRepo.InsertPayoutTx(ctx, tx, payout); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("shiftService.RecordPayout.Commit: %w", err)
+	}
+
+	res := mapPettyCashPayout(payout)
+	return &res, nil
+}
+
+// CloseShift closes the caller's open shift and stores the drawer variance.
+// expected_cash = opening_float + pembayaran tunai confirmed selama shift - kas kecil.
+func (s *shiftService) CloseShift(ctx context.Context, cashierID int64, req dto.CloseShiftRequest) (*dto.ShiftDetailResponse, error) {
+
+	// 1. Mulai transaksi & kunci shift terbuka
+	tx, err := s.shiftRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	shift, err := s.shiftRepo.LockOpenShiftTx(ctx, tx, cashierID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return nil, response.ErrShiftNotOpen
+		}
+		return nil, err
+	}
+
+	// 2. Tautkan pembayaran selama shift yang belum punya shift_id, lalu hitung uang masuk & keluar
+	now := time.Now()
+	if err := s.shiftRepo.LinkShiftPaymentsTx(ctx, tx, shift, now); err != nil {
+		return nil, err
+	}
+	totals, err := s.shiftRepo.SumShiftCashTx(ctx, tx, shift)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Rekonsiliasi: bandingkan uang fisik dengan uang yang seharusnya ada di laci
+	expected := shift.OpeningFloat.Add(totals.CashSales).Sub(totals.PettyCashTotal)
+	counted := *req.ClosingCounted
+	variance := counted.Sub(expected)
+
+	shift.Status = models.ShiftStatusClosed
+	shift.CashSales = totals.CashSales
+	shift.PettyCashTotal = totals.PettyCashTotal
+	shift.PaymentCount = totals.PaymentCount
+	shift.ExpectedCash = &expected
+	shift.ClosingCounted = &counted
+	shift.Variance = &variance
+	shift.CloseNote = trimNote(req.Note)
+	shift.ClosedAt = &now
+	shift.ClosedBy = &cashierID
+
+	if err := s.shiftRepo.CloseShiftTx(ctx, tx, shift); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("shiftService.CloseShift.Commit: %w", err)
+	}
+
+	return s.buildShiftDetail(ctx, shift)
+}
+
+// GetShiftDetail retrieves a shift of the active outlet. Non-owners may only see their own shifts.
+func (s *shiftService) GetShiftDetail(ctx context.Context, id, requesterID int64, requesterRole string) (*dto.ShiftDetailResponse, error) {
+
+	// 1. Ambil shift (dibatasi outlet aktif)
+	shift, err := s.shiftRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. SECURITY GUARD: kasir hanya melihat shift miliknya sendiri
+	if requesterRole != "owner" && shift.CashierID != requesterID {
+		return nil, response.ErrNotFound
+	}
+
+	// 3. Shift terbuka: tampilkan total berjalan
+	if shift.Status == models.ShiftStatusOpen {
+		if err := s.applyRunningTotals(ctx, shift); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.buildShiftDetail(ctx, shift)
+}
+
+// GetShiftReport summarizes every shift opened within the date range and highlights drawer discrepancies.
+func (s *shiftService) GetShiftReport(ctx context.Context, startDate, endDate string, tolerance money.Amount) (*dto.ShiftReportResponse, error) {
+
+	// 1. Validasi rentang tanggal & toleransi
+	start, endExclusive, err := parseReportRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if tolerance.IsNegative() {
+		return nil, fmt.Errorf("%w: tolerance must not be negative", response.ErrValidation)
+	}
+
+	// 2. Ambil shift (dibatasi outlet aktif)
+	shifts, err := s.shiftRepo.FindShifts(ctx, start, endExclusive)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Agregasi & tandai selisih
+	res := &dto.ShiftReportResponse{
+		Period:      dto.ShiftReportPeriod{StartDate: startDate, EndDate: endDate},
+		Tolerance:   tolerance,
+		TotalShifts: len(shifts),
+		Shifts:      make([]dto.ShiftResponse, 0, len(shifts)),
+	}
+	if outletID := outlet.FromContext(ctx); outletID != outlet.All {
+		res.OutletID = &outletID
+	}
+
+	for i := range shifts {
+		shift := &shifts[i]
+		if shift.Status == models.ShiftStatusOpen {
+			res.OpenShifts++
+			if err := s.applyRunningTotals(ctx, shift); err != nil {
+				return nil, err
+			}
+		} else if shift.ExpectedCash != nil && shift.ClosingCounted != nil && shift.Variance != nil {
+			res.TotalExpected = res.TotalExpected.Add(*shift.ExpectedCash)
+			res.TotalCounted = res.TotalCounted.Add(*shift.ClosingCounted)
+			if shift.Variance.IsNegative() {
+				res.TotalShort = res.TotalShort.Add(shift.Variance.Abs())
+			} else {
+				res.TotalOver = res.TotalOver.Add(*shift.Variance)
+			}
+		}
+
+		item := mapShift(shift, tolerance)
+		if item.Discrepancy != nil && *item.Discrepancy != discrepancyBalanced {
+			res.DiscrepancyCount++
+		}
+		res.Shifts = append(res.Shifts, item)
+	}
+	res.NetVariance = res.TotalCounted.Sub(res.TotalExpected)
+
+	return res, nil
+}
+
+// --- HELPER FUNCTION ---
+
+// applyRunningTotals mengisi total uang masuk/keluar & expected_cash shift yang masih terbuka.
+func (s *shiftService) applyRunningTotals(ctx context.Context, shift *models.CashierShift) error {
+	totals, err := s.shiftRepo.SumShiftCash(ctx, shift)
+	if err != nil {
+		return err
+	}
+
+	expected := shift.OpeningFloat.Add(totals.CashSales).Sub(totals.PettyCashTotal)
+	shift.CashSales = totals.CashSales
+	shift.PettyCashTotal = totals.PettyCashTotal
+	shift.PaymentCount = totals.PaymentCount
+	shift.ExpectedCash = &expected
+	return nil
+}
+
+func (s *shiftService) buildShiftDetail(ctx context.Context, shift *models.CashierShift) (*dto.ShiftDetailResponse, error) {
+	payouts, err := s.shiftRepo.FindPayouts(ctx, shift.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.ShiftDetailResponse{
+		ShiftResponse: mapShift(shift, 0),
+		Payouts:       make([]dto.PettyCashPayoutResponse, 0, len(payouts)),
+	}
+	for i := range payouts {
+		res.Payouts = append(res.Payouts, mapPettyCashPayout(&payouts[i]))
+	}
+
+	return res, nil
+}
+
+// trimNote membuang spasi di tepi catatan; catatan kosong disimpan sebagai NULL.
+func trimNote(note *string) *string {
+	if note == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*note)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// mapShift memetakan shift ke DTO. Shift tertutup dengan |variance| > tolerance ditandai short/over.
+func mapShift(shift *models.CashierShift, tolerance money.Amount) dto.ShiftResponse {
+	res := dto.ShiftResponse{
+		ID:             shift.ID,
+		OutletID:       shift.OutletID,
+		CashierID:      shift.CashierID,
+		CashierName:    shift.CashierName,
+		Status:         shift.Status,
+		OpeningFloat:   shift.OpeningFloat,
+		CashSales:      shift.CashSales,
+		PettyCashTotal: shift.PettyCashTotal,
+		ClosingCounted: shift.ClosingCounted,
+		Variance:       shift.Variance,
+		PaymentCount:   shift.PaymentCount,
+		OpenNote:       shift.OpenNote,
+		CloseNote:      shift.CloseNote,
+		OpenedAt:       shift.OpenedAt.Format("2006-01-02 15:04:05"),
+		ClosedAt:       formatTimePtr(shift.ClosedAt),
+		ClosedBy:       shift.ClosedBy,
+	}
+	if shift.ExpectedCash != nil {
+		res.ExpectedCash = *shift.ExpectedCash
+	}
+
+	if shift.Variance != nil {
+		discrepancy := discrepancyBalanced
+		switch {
+		case shift.Variance.Abs() <= tolerance:
+		case shift.Variance.IsNegative():
+			discrepancy = discrepancyShort
+		default:
+			discrepancy = discrepancyOver
+		}
+		res.Discrepancy = &discrepancy
+	}
+
+	return res
+}
+
+func mapPettyCashPayout(p *models.PettyCashPayout) dto.PettyCashPayoutResponse {
+	return dto.PettyCashPayoutResponse{
+		ID:          p.ID,
+		ShiftID:     p.ShiftID,
+		Amount:      p.Amount,
+		Description: p.Description,
+		CreatedBy:   p.CreatedBy,
+		CreatedAt:   p.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
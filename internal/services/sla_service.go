@@ -0,0 +1,430 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/internal/webhook"
+	"laundry-backend/pkg/response"
+	"slices"
+	"time"
+)
+
+// SLAService defines the contract for overdue/unclaimed order monitoring and the SLA scheduler.
+type SLAService interface {
+	GetOverdueOrders(ctx context.Context, query dto.OverdueOrderQuery, page, perPage int) (*dto.OverdueOrderListResponse, error)
+	GetUnclaimedOrders(ctx context.Context, query dto.UnclaimedOrderQuery, page, perPage int) (*dto.UnclaimedOrderListResponse, error)
+
+	// Kotak masuk peringatan di aplikasi: setiap peringatan yang dicatat scheduler bisa dilihat & ditindaklanjuti staff.
+	GetAlerts(ctx context.Context, query dto.SLAAlertQuery, page, perPage int) (*dto.SLAAlertListResponse, error)
+	AcknowledgeAlert(ctx context.Context, id, actorID int64) (*dto.SLAAlertResponse, error)
+
+	// --- Scheduler ---
+
+	// Evaluate menjalankan semua aturan SLA sekali dan mengembalikan jumlah event yang dikirim.
+	Evaluate(ctx context.Context) (int, error)
+
+	// RunScheduler menjalankan Evaluate berkala sampai ctx dibatalkan.
+	RunScheduler(ctx context.Context)
+}
+
+type slaService struct {
+	slaRepo         repositories.SLARepository
+	orderStatusRepo repositories.OrderStatusRepository
+	webhookService  WebhookService
+	cfg             *config.Config
+}
+
+// NewSLAService creates a new instance of SLAService.
+func NewSLAService(slaRepo repositories.SLARepository, orderStatusRepo repositories.OrderStatusRepository, webhookService WebhookService, cfg *config.Config) SLAService {
+	return &slaService{
+		slaRepo:         slaRepo,
+		orderStatusRepo: orderStatusRepo,
+		webhookService:  webhookService,
+		cfg:             cfg,
+	}
+}
+
+// GetOverdueOrders retrieves active orders of the active outlet past their ready time, most late first.
+func (s *slaService) GetOverdueOrders(ctx context.Context, query dto.OverdueOrderQuery, page, perPage int) (*dto.OverdueOrderListResponse, error) {
+
+	// 1. Call Repository (dihitung langsung dari data pesanan, tidak menunggu scheduler)
+	now := time.Now()
+	offset := (page - 1) * perPage
+	orders, totalItems, err := s.slaRepo.FindOverdue(ctx, s.overdueBefore(now), query.Status, perPage, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Map to DTO
+	res := &dto.OverdueOrderListResponse{
+		Data: make([]dto.OverdueOrderResponse, 0, len(orders)),
+		Meta: response.NewPageMeta(page, perPage, totalItems),
+	}
+	for _, o := range orders {
+		res.Data = append(res.Data, dto.OverdueOrderResponse{
+			OrderID:          o.OrderID,
+			OutletID:         o.OutletID,
+			InvoiceNumber:    o.InvoiceNumber,
+			CustomerName:     o.CustomerName,
+			CustomerPhone:    o.CustomerPhone,
+			IsDelivery:       o.IsDelivery,
+			PaymentStatus:    o.PaymentStatus,
+			StatusInternal:   o.StatusInternal,
+			EstimatedReadyAt: o.EstimatedReadyAt.Format("2006-01-02 15:04:05"),
+			LateMinutes:      lateMinutes(o.EstimatedReadyAt, now),
+			FlaggedAt:        formatTimePtr(o.FlaggedAt),
+		})
+	}
+
+	return res, nil
+}
+
+// GetUnclaimedOrders retrieves ready-pickup orders of the active outlet waiting at least MinDays, oldest first.
+func (s *slaService) GetUnclaimedOrders(ctx context.Context, query dto.UnclaimedOrderQuery, page, perPage int) (*dto.UnclaimedOrderListResponse, error) {
+
+	// 1. Call Repository
+	now := time.Now()
+	offset := (page - 1) * perPage
+	orders, totalItems, err := s.slaRepo.FindUnclaimed(ctx, now.AddDate(0, 0, -query.MinDays), perPage, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Map to DTO
+	res := &dto.UnclaimedOrderListResponse{
+		Data: make([]dto.UnclaimedOrderResponse, 0, len(orders)),
+		Meta: response.NewPageMeta(page, perPage, totalItems),
+	}
+	for _, o := range orders {
+		days := daysUnclaimed(o.ReadySince, now)
+		res.Data = append(res.Data, dto.UnclaimedOrderResponse{
+			OrderID:          o.OrderID,
+			OutletID:         o.OutletID,
+			InvoiceNumber:    o.InvoiceNumber,
+			CustomerName:     o.CustomerName,
+			CustomerPhone:    o.CustomerPhone,
+			PaymentStatus:    o.PaymentStatus,
+			ReadySince:       o.ReadySince.Format("2006-01-02 15:04:05"),
+			DaysUnclaimed:    days,
+			ThresholdReached: thresholdReached(s.cfg.SLA.UnclaimedDays, days),
+			FlaggedDays:      o.FlaggedDays,
+			FlaggedAt:        formatTimePtr(o.FlaggedAt),
+		})
+	}
+
+	return res, nil
+}
+
+// GetAlerts retrieves the open SLA alerts of the active outlet, newest first.
+func (s *slaService) GetAlerts(ctx context.Context, query dto.SLAAlertQuery, page, perPage int) (*dto.SLAAlertListResponse, error) {
+
+	// 1. Call Repository
+	offset := (page - 1) * perPage
+	alerts, totalItems, err := s.slaRepo.FindOpenAlerts(ctx, query.AlertType, query.Acknowledged, perPage, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Map to DTO
+	res := &dto.SLAAlertListResponse{
+		Data: make([]dto.SLAAlertResponse, 0, len(alerts)),
+		Meta: response.NewPageMeta(page, perPage, totalItems),
+	}
+	for i := range alerts {
+		res.Data = append(res.Data, *mapSLAAlert(&alerts[i]))
+	}
+
+	return res, nil
+}
+
+// AcknowledgeAlert marks an alert of the active outlet as followed up by the actor.
+// Acknowledging twice is harmless: the first acknowledgement is kept.
+func (s *slaService) AcknowledgeAlert(ctx context.Context, id, actorID int64) (*dto.SLAAlertResponse, error) {
+
+	// 1. Pastikan peringatan ada di outlet aktif
+	if _, err := s.slaRepo.FindAlertByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	// 2. Tandai sudah ditindaklanjuti
+	if err := s.slaRepo.AcknowledgeAlert(ctx, id, actorID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// 3. Ambil ulang untuk nama staff
+	alert, err := s.slaRepo.FindAlertByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapSLAAlert(alert), nil
+}
+
+// Evaluate closes alerts that no longer apply, then flags new overdue and unclaimed orders.
+// Each flagged order publishes its webhook event exactly once per alert (baris alert dijaga UNIQUE).
+func (s *slaService) Evaluate(ctx context.Context) (int, error) {
+
+	now := time.Now()
+	overdueBefore := s.overdueBefore(now)
+
+	// 1. Tutup peringatan yang pesanannya sudah siap / sudah diambil
+	if _, err := s.slaRepo.ResolveAlerts(ctx, overdueBefore, now); err != nil {
+		return 0, err
+	}
+
+	// 2. Pesanan terlambat
+	published := 0
+	for {
+		candidates, err := s.slaRepo.FindNewOverdue(ctx, overdueBefore, s.batchSize())
+		if err != nil {
+			return published, err
+		}
+
+		flagged := 0
+		for _, o := range candidates {
+			ok, err := s.flagOverdue(ctx, o, overdueBefore, now)
+			if err != nil {
+				fmt.Printf("[ERROR] SLAScheduler.Overdue #%d: %v\n", o.OrderID, err)
+				continue
+			}
+			if ok {
+				flagged++
+			}
+		}
+		published += flagged
+
+		// Lanjut ke batch berikutnya hanya jika batch penuh & semuanya berhasil dicatat (tidak berputar di baris gagal)
+		if len(candidates) < s.batchSize() || flagged < len(candidates) {
+			break
+		}
+	}
+
+	// 3. Cucian tidak diambil: ambang tertinggi dulu agar pesanan yang sudah sangat lama
+	// hanya mendapat satu event (ambang di bawahnya dilewati)
+	thresholds := slices.Clone(s.cfg.SLA.UnclaimedDays)
+	slices.Sort(thresholds)
+	slices.Reverse(thresholds)
+
+	for _, thresholdDays := range thresholds {
+		readyBefore := now.AddDate(0, 0, -thresholdDays)
+		for {
+			candidates, err := s.slaRepo.FindNewUnclaimed(ctx, readyBefore, thresholdDays, s.batchSize())
+			if err != nil {
+				return published, err
+			}
+
+			flagged := 0
+			for _, o := range candidates {
+				ok, err := s.flagUnclaimed(ctx, o, thresholdDays, now)
+				if err != nil {
+					fmt.Printf("[ERROR] SLAScheduler.Unclaimed #%d: %v\n", o.OrderID, err)
+					continue
+				}
+				if ok {
+					flagged++
+				}
+			}
+			published += flagged
+
+			if len(candidates) < s.batchSize() || flagged < len(candidates) {
+				break
+			}
+		}
+	}
+
+	return published, nil
+}
+
+// RunScheduler evaluates the SLA rules until ctx is cancelled.
+func (s *slaService) RunScheduler(ctx context.Context) {
+	interval := time.Duration(s.cfg.SLA.CheckIntervalMin) * time.Minute
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Evaluate(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("[ERROR] SLAScheduler: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// --- HELPERS ---
+
+// flagOverdue re-checks the order under lock, records the alert and queues order.overdue.
+// Returns false when the order is no longer overdue or was already flagged.
+func (s *slaService) flagOverdue(ctx context.Context, o models.OverdueOrder, overdueBefore, now time.Time) (bool, error) {
+
+	tx, err := s.slaRepo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// 1. Kunci pesanan & pastikan masih terlambat (bisa saja baru di-scan siap)
+	state, err := s.orderStatusRepo.LockStateTx(ctx, tx, o.OrderID)
+	if err != nil {
+		return false, err
+	}
+	if state.StatusInternal != models.OrderStatusPending && state.StatusInternal != models.OrderStatusInProgress {
+		return false, nil
+	}
+	if state.EstimatedReadyAt == nil || state.EstimatedReadyAt.After(overdueBefore) {
+		return false, nil
+	}
+
+	// 2. Catat peringatan (sekali per keterlambatan)
+	created, err := s.slaRepo.UpsertAlertTx(ctx, tx, &models.SLAAlert{
+		OutletID:       o.OutletID,
+		OrderID:        o.OrderID,
+		AlertType:      models.SLAAlertOverdue,
+		StatusInternal: state.StatusInternal,
+		ReferenceAt:    *state.EstimatedReadyAt,
+		TriggeredAt:    now,
+	})
+	if err != nil || !created {
+		return false, err
+	}
+
+	// 3. Event ikut ter-commit bersama peringatan
+	if err := s.webhookService.PublishTx(ctx, tx, webhook.EventOrderOverdue, webhook.OrderOverdueData{
+		OrderID:          o.OrderID,
+		OutletID:         o.OutletID,
+		InvoiceNumber:    state.InvoiceNumber,
+		Status:           state.StatusInternal,
+		EstimatedReadyAt: *state.EstimatedReadyAt,
+		LateMinutes:      lateMinutes(*state.EstimatedReadyAt, now),
+		DetectedAt:       now,
+	}); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("slaService.flagOverdue.Commit: %w", err)
+	}
+	return true, nil
+}
+
+// flagUnclaimed re-checks the order under lock, records the alert for thresholdDays and queues order.unclaimed.
+func (s *slaService) flagUnclaimed(ctx context.Context, o models.UnclaimedOrder, thresholdDays int, now time.Time) (bool, error) {
+
+	tx, err := s.slaRepo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// 1. Kunci pesanan & pastikan masih menunggu diambil
+	state, err := s.orderStatusRepo.LockStateTx(ctx, tx, o.OrderID)
+	if err != nil {
+		return false, err
+	}
+	if state.StatusInternal != models.OrderStatusReadyPickup {
+		return false, nil
+	}
+
+	// 2. Catat peringatan untuk ambang ini
+	created, err := s.slaRepo.UpsertAlertTx(ctx, tx, &models.SLAAlert{
+		OutletID:       o.OutletID,
+		OrderID:        o.OrderID,
+		AlertType:      models.SLAAlertUnclaimed,
+		ThresholdDays:  thresholdDays,
+		StatusInternal: state.StatusInternal,
+		ReferenceAt:    o.ReadySince,
+		TriggeredAt:    now,
+	})
+	if err != nil || !created {
+		return false, err
+	}
+
+	// 3. Event untuk pemberitahuan pembuangan (dikirim sistem lain yang berlangganan)
+	if err := s.webhookService.PublishTx(ctx, tx, webhook.EventOrderUnclaimed, webhook.OrderUnclaimedData{
+		OrderID:       o.OrderID,
+		OutletID:      o.OutletID,
+		InvoiceNumber: state.InvoiceNumber,
+		CustomerID:    o.CustomerID,
+		PaymentStatus: state.PaymentStatus,
+		ReadySince:    o.ReadySince,
+		DaysUnclaimed: daysUnclaimed(o.ReadySince, now),
+		ThresholdDays: thresholdDays,
+		DetectedAt:    now,
+	}); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("slaService.flagUnclaimed.Commit: %w", err)
+	}
+	return true, nil
+}
+
+func mapSLAAlert(a *models.SLAAlertWithOrder) *dto.SLAAlertResponse {
+	return &dto.SLAAlertResponse{
+		ID:                 a.ID,
+		OutletID:           a.OutletID,
+		OrderID:            a.OrderID,
+		InvoiceNumber:      a.InvoiceNumber,
+		AlertType:          a.AlertType,
+		ThresholdDays:      a.ThresholdDays,
+		StatusInternal:     a.StatusInternal,
+		ReferenceAt:        a.ReferenceAt.Format("2006-01-02 15:04:05"),
+		TriggeredAt:        a.TriggeredAt.Format("2006-01-02 15:04:05"),
+		AcknowledgedBy:     a.AcknowledgedBy,
+		AcknowledgedByName: a.AcknowledgedByName,
+		AcknowledgedAt:     formatTimePtr(a.AcknowledgedAt),
+	}
+}
+
+// overdueBefore adalah batas estimated_ready_at pesanan yang dianggap terlambat (setelah toleransi).
+func (s *slaService) overdueBefore(now time.Time) time.Time {
+	return now.Add(-time.Duration(s.cfg.SLA.OverdueGraceMin) * time.Minute)
+}
+
+func (s *slaService) batchSize() int {
+	if s.cfg.SLA.BatchSize > 0 {
+		return s.cfg.SLA.BatchSize
+	}
+	return 100
+}
+
+// lateMinutes adalah lama keterlambatan dari estimated_ready_at (dibulatkan ke bawah).
+func lateMinutes(estimatedReadyAt, now time.Time) int64 {
+	late := int64(now.Sub(estimatedReadyAt) / time.Minute)
+	if late < 0 {
+		return 0
+	}
+	return late
+}
+
+// daysUnclaimed adalah jumlah hari penuh sejak pesanan siap diambil.
+func daysUnclaimed(readySince, now time.Time) int {
+	days := int(now.Sub(readySince) / (24 * time.Hour))
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// thresholdReached mengembalikan ambang tertinggi yang sudah dilewati (nil jika belum ada).
+func thresholdReached(thresholds []int, days int) *int {
+	var reached *int
+	for _, threshold := range thresholds {
+		if days >= threshold && (reached == nil || threshold > *reached) {
+			t := threshold
+			reached = &t
+		}
+	}
+	return reached
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+)
+
+// fakeSLARepo menyimpan peringatan di memori untuk kotak masuk SLA.
+type fakeSLARepo struct {
+	repositories.SLARepository
+	alerts map[int64]*models.SLAAlertWithOrder
+	acks   int
+}
+
+func (f *fakeSLARepo) FindAlertByID(ctx context.Context, id int64) (*models.SLAAlertWithOrder, error) {
+	a, ok := f.alerts[id]
+	if !ok {
+		return nil, response.ErrNotFound
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func (f *fakeSLARepo) AcknowledgeAlert(ctx context.Context, id, userID int64, now time.Time) error {
+	f.acks++
+	a := f.alerts[id]
+	if a.AcknowledgedAt == nil {
+		name := "Siti"
+		a.AcknowledgedBy, a.AcknowledgedByName, a.AcknowledgedAt = &userID, &name, &now
+	}
+	return nil
+}
+
+func TestAcknowledgeAlert(t *testing.T) {
+
+	triggered := time.Date(2026, 2, 10, 10, 20, 0, 0, time.UTC)
+	repo := &fakeSLARepo{alerts: map[int64]*models.SLAAlertWithOrder{
+		7: {SLAAlert: models.SLAAlert{ID: 7, OutletID: 1, OrderID: 98, AlertType: "unclaimed", ThresholdDays: 14, TriggeredAt: triggered, ReferenceAt: triggered}, InvoiceNumber: "INV-260126-001"},
+	}}
+	svc := NewSLAService(repo, nil, nil, nil)
+
+	res, err := svc.AcknowledgeAlert(context.Background(), 7, 3)
+	if err != nil {
+		t.Fatalf("AcknowledgeAlert: %v", err)
+	}
+	if res.AcknowledgedBy == nil || *res.AcknowledgedBy != 3 || res.AcknowledgedAt == nil {
+		t.Fatalf("alert not acknowledged by actor: %+v", res)
+	}
+	first := *res.AcknowledgedAt
+
+	// Mengulang tidak mengganti penindak pertama
+	res, err = svc.AcknowledgeAlert(context.Background(), 7, 5)
+	if err != nil {
+		t.Fatalf("AcknowledgeAlert again: %v", err)
+	}
+	if *res.AcknowledgedBy != 3 || *res.AcknowledgedAt != first {
+		t.Errorf("second acknowledgement overwrote the first: %+v", res)
+	}
+
+	// Peringatan di luar outlet aktif / sudah ditutup
+	if _, err := svc.AcknowledgeAlert(context.Background(), 99, 3); !errors.Is(err, response.ErrNotFound) {
+		t.Errorf("unknown alert err = %v, want ErrNotFound", err)
+	}
+	if repo.acks != 2 {
+		t.Errorf("repository acknowledged %d times, want 2", repo.acks)
+	}
+}
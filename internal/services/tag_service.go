@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/orderflow"
+	"laundry-backend/internal/receipt"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// Format kode tag: prefix + karakter Crockford Base32 (tanpa I, L, O, U agar tidak salah baca).
+const (
+	tagCodePrefix    = "TG"
+	tagCodeLength    = 8
+	tagCodeAlphabet  = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	tagInsertAttempt = 3
+)
+
+// TagService defines the contract for physical item tags and the scan-to-advance workflow.
+type TagService interface {
+	GenerateTags(ctx context.Context, orderID int64) (*dto.OrderTagsResponse, error)
+	GetOrderTags(ctx context.Context, orderID int64) (*dto.OrderTagsResponse, error)
+	RenderLabels(ctx context.Context, orderID int64, query dto.TagLabelQuery) (*dto.PrintFile, error)
+
+	// Scan mencari pesanan dari kode tag, lalu (opsional) mencatat hitungan helai dan memajukan status.
+	Scan(ctx context.Context, tagCode string, req dto.ScanRequest, actorID int64, actorRole string) (*dto.ScanResponse, error)
+}
+
+type tagService struct {
+	tagRepo         repositories.TagRepository
+	orderStatusRepo repositories.OrderStatusRepository
+	statusChanger   *orderStatusChanger
+}
+
+// NewTagService creates a new instance of TagService.
+func NewTagService(tagRepo repositories.TagRepository, orderStatusRepo repositories.OrderStatusRepository, notificationService NotificationService, webhookService WebhookService, inventoryService InventoryService) TagService {
+	return &tagService{
+		tagRepo:         tagRepo,
+		orderStatusRepo: orderStatusRepo,
+		statusChanger: &orderStatusChanger{
+			orderStatusRepo:     orderStatusRepo,
+			notificationService: notificationService,
+			webhookService:      webhookService,
+			inventoryService:    inventoryService,
+		},
+	}
+}
+
+// GenerateTags creates a unique tag for every item of the order that has none yet (idempotent).
+func (s *tagService) GenerateTags(ctx context.Context, orderID int64) (*dto.OrderTagsResponse, error) {
+
+	// 1. Pastikan pesanan ada
+	state, err := s.orderStatusRepo.FindState(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Buat tag untuk item yang belum punya
+	items, err := s.ensureTags(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToOrderTagsResponse(state, items), nil
+}
+
+// GetOrderTags retrieves every item of the order with its tag.
+func (s *tagService) GetOrderTags(ctx context.Context, orderID int64) (*dto.OrderTagsResponse, error) {
+
+	state, err := s.orderStatusRepo.FindState(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.tagRepo.FindItemTags(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToOrderTagsResponse(state, items), nil
+}
+
+// RenderLabels renders one label per order item as PDF or raw ESC/POS, generating missing tags first.
+func (s *tagService) RenderLabels(ctx context.Context, orderID int64, query dto.TagLabelQuery) (*dto.PrintFile, error) {
+
+	// 1. Default: PDF, Code128, kertas 58mm
+	format := query.Format
+	if format == "" {
+		format = receipt.FormatPDF
+	}
+	symbology := query.Symbology
+	if symbology == "" {
+		symbology = receipt.SymbologyCode128
+	}
+	paper := query.Paper
+	if paper == 0 {
+		paper = receipt.Paper58
+	}
+
+	// 2. Ambil pesanan & pastikan semua item sudah punya tag
+	state, err := s.orderStatusRepo.FindState(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.ensureTags(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Susun isi label
+	labels := make([]receipt.Label, 0, len(items))
+	for i, item := range items {
+		label := receipt.Label{
+			TagCode:       item.Tag.TagCode,
+			InvoiceNumber: state.InvoiceNumber,
+			ServiceName:   item.ServiceName,
+			Pieces:        item.DeclaredPieces,
+			Position:      i + 1,
+			Total:         len(items),
+			ReadyAt:       state.EstimatedReadyAt,
+		}
+		if state.CustomerName != nil {
+			label.CustomerName = *state.CustomerName
+		}
+		labels = append(labels, label)
+	}
+
+	// 4. Render sesuai format
+	file := &dto.PrintFile{}
+	switch format {
+	case receipt.FormatPDF:
+		file.ContentType = "application/pdf"
+		file.FileName = state.InvoiceNumber + "-labels.pdf"
+		file.Content, err = receipt.RenderLabelsPDF(labels, symbology)
+	case receipt.FormatESCPOS:
+		file.ContentType = "application/octet-stream"
+		file.FileName = fmt.Sprintf("%s-labels-%dmm.bin", state.InvoiceNumber, paper)
+		file.Content, err = receipt.RenderLabelsESCPOS(labels, symbology, paper)
+	default:
+		return nil, fmt.Errorf("%w: unsupported label format %q", response.ErrValidation, format)
+	}
+	if err != nil {
+		if errors.Is(err, receipt.ErrUnsupportedPaper) {
+			return nil, fmt.Errorf("%w: %v", response.ErrValidation, err)
+		}
+		return nil, fmt.Errorf("tagService.RenderLabels: %w", err)
+	}
+
+	return file, nil
+}
+
+// Scan looks up the order of a tag and optionally records the counted pieces and advances the order status.
+// A difference between counted and declared pieces is written to status_history notes.
+func (s *tagService) Scan(ctx context.Context, tagCode string, req dto.ScanRequest, actorID int64, actorRole string) (*dto.ScanResponse, error) {
+
+	// 1. Cari tag (kode tidak case-sensitive agar aman dari scanner/keyboard HP)
+	tag, err := s.tagRepo.FindByCode(ctx, strings.ToUpper(strings.TrimSpace(tagCode)))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.tagRepo.FindItemTags(ctx, tag.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	var scanned *models.OrderItemTagDetail
+	for i := range items {
+		if items[i].OrderItemID == tag.OrderItemID {
+			scanned = &items[i]
+		}
+	}
+	if scanned == nil {
+		return nil, response.ErrNotFound
+	}
+
+	// 2. Mulai transaksi & kunci baris pesanan
+	tx, err := s.orderStatusRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	state, err := s.orderStatusRepo.LockStateTx(ctx, tx, tag.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tag.LastScannedAt = &now
+	history := &models.StatusHistory{
+		OrderID:   state.ID,
+		ActorID:   &actorID,
+		ActorRole: &actorRole,
+		CreatedAt: now,
+	}
+	notes := []string{fmt.Sprintf("Scan tag %s (%s)", tag.TagCode, scanned.ServiceName)}
+	if req.Notes != nil && strings.TrimSpace(*req.Notes) != "" {
+		notes = append(notes, strings.TrimSpace(*req.Notes))
+	}
+
+	// 3. Catat hitungan helai & bandingkan dengan qty_pieces
+	mismatch := false
+	if req.CountedPieces != nil {
+		tag.CountedPieces = req.CountedPieces
+		tag.CountedBy = &actorID
+		tag.CountedAt = &now
+
+		if scanned.DeclaredPieces != nil && *scanned.DeclaredPieces != *req.CountedPieces {
+			mismatch = true
+			notes = append(notes, fmt.Sprintf("Selisih jumlah helai: dihitung %d, tercatat %d",
+				*req.CountedPieces, *scanned.DeclaredPieces))
+		}
+	}
+	noteText := strings.Join(notes, ". ")
+	history.Notes = &noteText
+
+	// 4. Majukan status (divalidasi state machine), atau catat selisih tanpa mengubah status
+	switch {
+	case req.Status != nil:
+		if err := orderflow.ValidateTransition(actorRole, *state, *req.Status); err != nil {
+			return nil, fmt.Errorf("%w: %v", response.ErrInvalidTransition, err)
+		}
+		history.NewStatus = *req.Status
+		if err := s.statusChanger.changeTx(ctx, tx, state, history); err != nil {
+			return nil, err
+		}
+	case mismatch:
+		previous := state.StatusInternal
+		history.PreviousStatus = &previous
+		history.NewStatus = previous
+		if err := s.orderStatusRepo.InsertHistoryTx(ctx, tx, history); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.tagRepo.RecordScanTx(ctx, tx, tag); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tagService.Scan.Commit: %w", err)
+	}
+
+	// 5. Mapping ke response (tag yang dipindai memakai data terbaru)
+	scanned.Tag = tag
+	res := &dto.ScanResponse{
+		Order:         mapToScanOrderResponse(state),
+		ScannedItem:   mapToItemTagResponse(*scanned),
+		Items:         make([]dto.OrderItemTagResponse, 0, len(items)),
+		NextStatuses:  orderflow.NextStatuses(actorRole, *state),
+		StatusChanged: req.Status != nil,
+		PieceMismatch: mismatch,
+	}
+	for _, item := range items {
+		res.Items = append(res.Items, mapToItemTagResponse(item))
+	}
+
+	return res, nil
+}
+
+// --- HELPER FUNCTION ---
+
+// ensureTags membuat tag untuk item yang belum punya, lalu mengembalikan daftar item terbaru.
+func (s *tagService) ensureTags(ctx context.Context, orderID int64) ([]models.OrderItemTagDetail, error) {
+
+	items, err := s.tagRepo.FindItemTags(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: order has no items to tag", response.ErrValidation)
+	}
+
+	missing := false
+	for _, item := range items {
+		if item.Tag != nil {
+			continue
+		}
+		missing = true
+
+		// Kode bentrok (sangat jarang) atau item sudah diberi tag oleh request lain: coba kode baru
+		for attempt := 0; attempt < tagInsertAttempt; attempt++ {
+			code, err := newTagCode()
+			if err != nil {
+				return nil, err
+			}
+			inserted, err := s.tagRepo.InsertTag(ctx, &models.OrderItemTag{
+				OrderID:     orderID,
+				OrderItemID: item.OrderItemID,
+				TagCode:     code,
+				CreatedAt:   time.Now(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if inserted {
+				break
+			}
+		}
+	}
+	if !missing {
+		return items, nil
+	}
+
+	items, err = s.tagRepo.FindItemTags(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.Tag == nil {
+			return nil, fmt.Errorf("tagService.ensureTags: failed to tag order item %d", item.OrderItemID)
+		}
+	}
+
+	return items, nil
+}
+
+// newTagCode membuat kode tag acak, cth: TG7K3M9QXA.
+func newTagCode() (string, error) {
+	random := make([]byte, tagCodeLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("tagService.newTagCode: %w", err)
+	}
+
+	code := []byte(tagCodePrefix)
+	for _, b := range random {
+		code = append(code, tagCodeAlphabet[int(b)%len(tagCodeAlphabet)])
+	}
+	return string(code), nil
+}
+
+func mapToOrderTagsResponse(state *models.OrderState, items []models.OrderItemTagDetail) *dto.OrderTagsResponse {
+	res := &dto.OrderTagsResponse{
+		OrderID:       state.ID,
+		InvoiceNumber: state.InvoiceNumber,
+		Items:         make([]dto.OrderItemTagResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		res.Items = append(res.Items, mapToItemTagResponse(item))
+	}
+	return res
+}
+
+func mapToItemTagResponse(item models.OrderItemTagDetail) dto.OrderItemTagResponse {
+	res := dto.OrderItemTagResponse{
+		OrderItemID:    item.OrderItemID,
+		ServiceName:    item.ServiceName,
+		Unit:           item.Unit,
+		Quantity:       item.Quantity,
+		DeclaredPieces: item.DeclaredPieces,
+	}
+	if item.Tag != nil {
+		res.TagCode = &item.Tag.TagCode
+		res.CountedPieces = item.Tag.CountedPieces
+		res.CountedAt = formatTimePtr(item.Tag.CountedAt)
+		res.LastScannedAt = formatTimePtr(item.Tag.LastScannedAt)
+	}
+	return res
+}
+
+func mapToScanOrderResponse(state *models.OrderState) dto.ScanOrderResponse {
+	return dto.ScanOrderResponse{
+		ID:               state.ID,
+		InvoiceNumber:    state.InvoiceNumber,
+		CustomerName:     state.CustomerName,
+		IsDelivery:       state.IsDelivery,
+		PaymentStatus:    state.PaymentStatus,
+		StatusInternal:   state.StatusInternal,
+		EstimatedReadyAt: formatTimePtr(state.EstimatedReadyAt),
+	}
+}
@@ -0,0 +1,519 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/internal/pricing"
+	"laundry-backend/internal/promotion"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/internal/tax"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"strings"
+	"time"
+)
+
+// TaxService defines the contract for business logic related to tax rates, service charges and order totals.
+type TaxService interface {
+	CreateTaxRate(ctx context.Context, req dto.CreateTaxRateRequest) (*dto.TaxRateResponse, error)
+	GetTaxRateList(ctx context.Context, status string) ([]dto.TaxRateResponse, error)
+	GetTaxRateDetail(ctx context.Context, id int64) (*dto.TaxRateResponse, error)
+	ModifyTaxRate(ctx context.Context, targetID int64, req dto.UpdateTaxRateRequest) (*dto.TaxRateResponse, error)
+	DeactivateTaxRate(ctx context.Context, targetID int64) error
+
+	// PreviewOrderTotals mensimulasikan total checkout (harga, diskon, service charge, pajak) tanpa menyimpan apa pun.
+	PreviewOrderTotals(ctx context.Context, req dto.OrderTotalsRequest) (*dto.OrderTotalsResponse, error)
+
+	// ResolveTotals menghitung pajak & total akhir dari keranjang yang sudah dihitung harga dan diskonnya.
+	// Dipakai oleh PreviewOrderTotals dan pembuatan pesanan agar hasilnya selalu sama.
+	ResolveTotals(ctx context.Context, quote *pricing.OrderQuote, discounts *promotion.Summary) (*tax.Summary, error)
+
+	// RecordTotalsTx menyimpan subtotal, discount_total, tax_total, grand_total dan rincian order_taxes
+	// di dalam transaksi pembuatan/perubahan pesanan.
+	RecordTotalsTx(ctx context.Context, tx *sql.Tx, orderID int64, summary *tax.Summary) error
+
+	// GetTaxReport memisahkan pendapatan bersih dari pajak terkumpul untuk pesanan lunas dalam rentang tanggal.
+	GetTaxReport(ctx context.Context, startDate, endDate string) (*dto.TaxReportResponse, error)
+
+	// ExportTaxReport menulis rincian per nota lunas dalam rentang tanggal (untuk pembukuan di spreadsheet).
+	ExportTaxReport(ctx context.Context, startDate, endDate string, w export.Writer) error
+}
+
+type taxService struct {
+	taxRepo            repositories.TaxRepository
+	serviceRepo        repositories.ServiceRepository
+	categoryRepo       repositories.CategoryRepository
+	pricingRuleService PricingRuleService
+	promotionService   PromotionService
+}
+
+// NewTaxService creates a new instance of TaxService.
+func NewTaxService(taxRepo repositories.TaxRepository, serviceRepo repositories.ServiceRepository, categoryRepo repositories.CategoryRepository, pricingRuleService PricingRuleService, promotionService PromotionService) TaxService {
+	return &taxService{
+		taxRepo:            taxRepo,
+		serviceRepo:        serviceRepo,
+		categoryRepo:       categoryRepo,
+		pricingRuleService: pricingRuleService,
+		promotionService:   promotionService,
+	}
+}
+
+// CreateTaxRate handles the creation of a new tax rate or service charge.
+func (s *taxService) CreateTaxRate(ctx context.Context, req dto.CreateTaxRateRequest) (*dto.TaxRateResponse, error) {
+
+	// 1. Pengecekan Duplikasi Kode (Harus unik, disimpan huruf besar)
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	existingCode, _ := s.taxRepo.FindByCode(ctx, code)
+	if existingCode != nil {
+		return nil, response.ErrDuplicate
+	}
+
+	// 2. Siapkan Model (default: exclusive & berlaku untuk semua item)
+	rateModel := &models.TaxRate{
+		Code:      code,
+		TaxName:   req.TaxName,
+		TaxKind:   req.TaxKind,
+		Rate:      req.Rate,
+		PriceMode: req.PriceMode,
+		ScopeType: req.ScopeType,
+		IsActive:  true,
+		CreatedAt: time.Now(),
+	}
+	if rateModel.PriceMode == "" {
+		rateModel.PriceMode = models.TaxModeExclusive
+	}
+	if rateModel.ScopeType == "" {
+		rateModel.ScopeType = models.TaxScopeAll
+	}
+
+	// 3. Validasi cakupan & pengecualian
+	targets, err := s.validateTargets(ctx, rateModel.ScopeType, req.Targets)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Insert ke Database (tarif + cakupan dalam satu transaksi)
+	if err := s.taxRepo.InsertRate(ctx, rateModel, targets); err != nil {
+		return nil, err
+	}
+
+	return s.GetTaxRateDetail(ctx, rateModel.ID)
+}
+
+// GetTaxRateList retrieves every tax rate.
+func (s *taxService) GetTaxRateList(ctx context.Context, status string) ([]dto.TaxRateResponse, error) {
+
+	rates, err := s.taxRepo.FindAll(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	rateResponses := make([]dto.TaxRateResponse, 0, len(rates))
+	for i := range rates {
+		rateResponses = append(rateResponses, *s.mapToResponse(&rates[i]))
+	}
+
+	return rateResponses, nil
+}
+
+// GetTaxRateDetail retrieves a single tax rate by ID.
+func (s *taxService) GetTaxRateDetail(ctx context.Context, id int64) (*dto.TaxRateResponse, error) {
+
+	rate, err := s.taxRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapToResponse(rate), nil
+}
+
+// ModifyTaxRate updates tax rate data with validation logic.
+// Pesanan yang sudah dibuat tidak berubah karena order_taxes menyimpan salinan tarifnya.
+func (s *taxService) ModifyTaxRate(ctx context.Context, targetID int64, req dto.UpdateTaxRateRequest) (*dto.TaxRateResponse, error) {
+
+	// 1. Ambil Data Tarif yang Lama
+	existingRate, err := s.taxRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	rate := existingRate.TaxRate
+
+	// 2. Validasi & Update Kode (Jika dikirim user)
+	if req.Code != nil {
+		code := strings.ToUpper(strings.TrimSpace(*req.Code))
+		if code != rate.Code {
+			duplicateCheck, _ := s.taxRepo.FindByCode(ctx, code)
+			if duplicateCheck != nil && duplicateCheck.ID != targetID {
+				return nil, response.ErrDuplicate
+			}
+			rate.Code = code
+		}
+	}
+
+	// 3. Update Fields Lainnya (Partial Update)
+	if req.TaxName != nil {
+		rate.TaxName = *req.TaxName
+	}
+	if req.TaxKind != nil {
+		rate.TaxKind = *req.TaxKind
+	}
+	if req.Rate != nil {
+		rate.Rate = *req.Rate
+	}
+	if req.PriceMode != nil {
+		rate.PriceMode = *req.PriceMode
+	}
+	if req.ScopeType != nil {
+		rate.ScopeType = *req.ScopeType
+	}
+	if req.IsActive != nil {
+		rate.IsActive = *req.IsActive
+	}
+
+	// 4. Validasi cakupan (daftar baru jika dikirim, daftar lama jika scope berubah)
+	var targets []models.TaxRateTarget
+	if req.Targets != nil {
+		if targets, err = s.validateTargets(ctx, rate.ScopeType, req.Targets); err != nil {
+			return nil, err
+		}
+	} else if rate.ScopeType == models.TaxScopeSelected && !hasAppliedTarget(existingRate.Targets) {
+		return nil, fmt.Errorf("%w: scope 'selected' requires at least one non-exempt target", response.ErrValidation)
+	}
+
+	// 5. Update Waktu (Timestamp)
+	now := time.Now()
+	rate.UpdatedAt = &now
+
+	// 6. Simpan Perubahan ke Database
+	if err := s.taxRepo.UpdateRate(ctx, &rate, targets); err != nil {
+		return nil, err
+	}
+
+	return s.GetTaxRateDetail(ctx, targetID)
+}
+
+// DeactivateTaxRate handles soft deletion of a tax rate.
+func (s *taxService) DeactivateTaxRate(ctx context.Context, targetID int64) error {
+
+	// 1. Cek apakah tarif tersebut ada
+	if _, err := s.taxRepo.FindByID(ctx, targetID); err != nil {
+		return err
+	}
+
+	// 2. Eksekusi Soft Delete
+	return s.taxRepo.DeleteRate(ctx, targetID)
+}
+
+// PreviewOrderTotals previews the full checkout totals of a cart.
+func (s *taxService) PreviewOrderTotals(ctx context.Context, req dto.OrderTotalsRequest) (*dto.OrderTotalsResponse, error) {
+
+	// 1. Hitung harga keranjang menggunakan kalkulator yang sama dengan pesanan
+	quote, err := s.pricingRuleService.QuoteItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Terapkan diskon member, promo otomatis, dan kode voucher
+	discounts, err := s.promotionService.ResolveDiscounts(ctx, quote, req.Code, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Hitung service charge, pajak, dan total akhir
+	summary, err := s.ResolveTotals(ctx, quote, discounts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Mapping ke DTO
+	res := &dto.OrderTotalsResponse{
+		Subtotal:           summary.Subtotal,
+		Discounts:          make([]dto.AppliedDiscountResponse, 0, len(discounts.Discounts)),
+		DiscountTotal:      summary.DiscountTotal,
+		Charges:            make([]dto.TaxChargeResponse, 0, len(summary.Charges)),
+		ServiceChargeTotal: summary.ServiceChargeTotal,
+		TaxTotal:           summary.TaxTotal,
+		GrandTotal:         summary.GrandTotal,
+		NetRevenue:         summary.NetRevenue,
+	}
+	for _, d := range discounts.Discounts {
+		res.Discounts = append(res.Discounts, dto.AppliedDiscountResponse{
+			Source:      d.Source,
+			PromotionID: d.PromotionID,
+			Code:        d.Code,
+			PromoName:   d.PromoName,
+			Amount:      d.Amount,
+			Explanation: d.Explanation,
+		})
+	}
+	for _, c := range summary.Charges {
+		rateID := c.TaxRateID
+		res.Charges = append(res.Charges, dto.TaxChargeResponse{
+			TaxRateID:   &rateID,
+			TaxName:     c.TaxName,
+			TaxKind:     c.TaxKind,
+			Rate:        c.Rate,
+			PriceMode:   c.PriceMode,
+			TaxableBase: c.TaxableBase,
+			Amount:      c.Amount,
+		})
+	}
+
+	return res, nil
+}
+
+// ResolveTotals applies every active tax rate to a priced and discounted cart.
+func (s *taxService) ResolveTotals(ctx context.Context, quote *pricing.OrderQuote, discounts *promotion.Summary) (*tax.Summary, error) {
+
+	// 1. Bentuk baris keranjang dari hasil kalkulator harga
+	lines := make([]tax.Line, 0, len(quote.Lines))
+	for _, line := range quote.Lines {
+		lines = append(lines, tax.Line{
+			ServiceID:  line.ServiceID,
+			CategoryID: line.CategoryID,
+			Amount:     line.LineTotal,
+		})
+	}
+
+	// 2. Ambil tarif aktif
+	rates, err := s.taxRepo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Jalankan engine pajak
+	discountTotal := money.Zero
+	if discounts != nil {
+		discountTotal = discounts.DiscountTotal
+	}
+	summary, err := tax.Calculate(lines, discountTotal, rates)
+	if err != nil {
+		if errors.Is(err, tax.ErrDiscountExceedsSubtotal) {
+			return nil, fmt.Errorf("%w: %v", response.ErrValidation, err)
+		}
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// RecordTotalsTx stores the order totals and tax lines inside the caller's order transaction.
+func (s *taxService) RecordTotalsTx(ctx context.Context, tx *sql.Tx, orderID int64, summary *tax.Summary) error {
+
+	now := time.Now()
+	taxes := make([]models.OrderTax, 0, len(summary.Charges))
+	for _, c := range summary.Charges {
+		rateID := c.TaxRateID
+		taxes = append(taxes, models.OrderTax{
+			TaxRateID:   &rateID,
+			TaxName:     c.TaxName,
+			TaxKind:     c.TaxKind,
+			Rate:        c.Rate,
+			PriceMode:   c.PriceMode,
+			TaxableBase: c.TaxableBase,
+			Amount:      c.Amount,
+			CreatedAt:   now,
+		})
+	}
+
+	totals := models.OrderTotals{
+		Subtotal:           summary.Subtotal,
+		DiscountTotal:      summary.DiscountTotal,
+		ServiceChargeTotal: summary.ServiceChargeTotal,
+		TaxTotal:           summary.TaxTotal,
+		GrandTotal:         summary.GrandTotal,
+	}
+
+	return s.taxRepo.SaveOrderTotalsTx(ctx, tx, orderID, totals, taxes)
+}
+
+// GetTaxReport separates net revenue from tax collected for paid orders between startDate and endDate (inclusive).
+func (s *taxService) GetTaxReport(ctx context.Context, startDate, endDate string) (*dto.TaxReportResponse, error) {
+
+	// 1. Validasi rentang tanggal (format YYYY-MM-DD, waktu lokal outlet)
+	start, endExclusive, err := parseReportRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Agregasi total nota & rincian pajak (batas akhir eksklusif = hari berikutnya)
+	totals, orderCount, err := s.taxRepo.SumOrderTotals(ctx, start, endExclusive)
+	if err != nil {
+		return nil, err
+	}
+	collections, err := s.taxRepo.SumCollectedTaxes(ctx, start, endExclusive)
+	if err != nil {
+		return nil, err
+	}
+	perOutlet, err := s.taxRepo.SumOrderTotalsByOutlet(ctx, start, endExclusive)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Mapping ke DTO
+	res := &dto.TaxReportResponse{
+		Period:             dto.TaxReportPeriod{StartDate: startDate, EndDate: endDate},
+		TotalOrdersPaid:    orderCount,
+		GrossSales:         totals.Subtotal,
+		DiscountTotal:      totals.DiscountTotal,
+		ServiceChargeTotal: totals.ServiceChargeTotal,
+		TaxCollected:       totals.TaxTotal,
+		GrandTotal:         totals.GrandTotal,
+		NetRevenue:         totals.GrandTotal.Sub(totals.TaxTotal),
+		Breakdown:          make([]dto.TaxChargeResponse, 0, len(collections)),
+		Outlets:            make([]dto.TaxReportOutletResponse, 0, len(perOutlet)),
+	}
+	if outletID := outlet.FromContext(ctx); outletID != outlet.All {
+		res.OutletID = &outletID
+	}
+	for _, c := range collections {
+		res.Breakdown = append(res.Breakdown, dto.TaxChargeResponse{
+			TaxRateID:   c.TaxRateID,
+			TaxName:     c.TaxName,
+			TaxKind:     c.TaxKind,
+			Rate:        c.Rate,
+			PriceMode:   c.PriceMode,
+			TaxableBase: c.TaxableBase,
+			Amount:      c.Amount,
+		})
+	}
+
+	for _, o := range perOutlet {
+		res.Outlets = append(res.Outlets, dto.TaxReportOutletResponse{
+			OutletID:        o.OutletID,
+			OutletCode:      o.OutletCode,
+			OutletName:      o.OutletName,
+			TotalOrdersPaid: o.OrderCount,
+			GrossSales:      o.Subtotal,
+			TaxCollected:    o.TaxTotal,
+			GrandTotal:      o.GrandTotal,
+			NetRevenue:      o.GrandTotal.Sub(o.TaxTotal),
+		})
+	}
+
+	return res, nil
+}
+
+// taxReportExportColumns adalah judul kolom export GET /reports/taxes (satu baris per nota lunas)
+var taxReportExportColumns = []export.Column{
+	{ID: "No. Nota", EN: "Invoice Number"},
+	{ID: "Outlet", EN: "Outlet"},
+	{ID: "Tanggal", EN: "Date"},
+	{ID: "Pelanggan", EN: "Customer"},
+	{ID: "Subtotal", EN: "Subtotal"},
+	{ID: "Diskon", EN: "Discount"},
+	{ID: "Biaya Layanan", EN: "Service Charge"},
+	{ID: "Pajak", EN: "Tax"},
+	{ID: "Total", EN: "Grand Total"},
+	{ID: "Pendapatan Bersih", EN: "Net Revenue"},
+}
+
+// ExportTaxReport streams the totals of every paid order between startDate and endDate (inclusive) into w.
+// Jumlah kolom Total & Pajak sama dengan ringkasan GET /reports/taxes untuk rentang yang sama.
+func (s *taxService) ExportTaxReport(ctx context.Context, startDate, endDate string, w export.Writer) error {
+
+	// 1. Validasi rentang tanggal (sebelum ada byte yang ditulis ke klien)
+	start, endExclusive, err := parseReportRange(startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	// 2. Tulis satu baris per nota
+	w.SetColumns(taxReportExportColumns)
+	return s.taxRepo.StreamOrderTotals(ctx, start, endExclusive, func(o *models.TaxReportOrder) error {
+		return w.WriteRow(
+			o.InvoiceNumber, o.OutletCode, o.CreatedAt, o.CustomerName,
+			o.Subtotal, o.DiscountTotal, o.ServiceChargeTotal, o.TaxTotal, o.GrandTotal,
+			o.GrandTotal.Sub(o.TaxTotal),
+		)
+	})
+}
+
+// --- HELPER FUNCTION ---
+
+// parseReportRange memvalidasi rentang tanggal laporan (YYYY-MM-DD, waktu lokal outlet)
+// dan mengembalikan batas [start, end) dengan end eksklusif = hari setelah endDate.
+func parseReportRange(startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01-02", startDate, config.Location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: start_date must use format YYYY-MM-DD", response.ErrValidation)
+	}
+	end, err := time.ParseInLocation("2006-01-02", endDate, config.Location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: end_date must use format YYYY-MM-DD", response.ErrValidation)
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: end_date must not be before start_date", response.ErrValidation)
+	}
+
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+// validateTargets memastikan setiap layanan/kategori ada dan scope 'selected' punya minimal satu target yang dikenai.
+func (s *taxService) validateTargets(ctx context.Context, scopeType string, reqTargets []dto.TaxRateTargetRequest) ([]models.TaxRateTarget, error) {
+
+	targets := make([]models.TaxRateTarget, 0, len(reqTargets))
+	for _, t := range reqTargets {
+		switch t.TargetType {
+		case models.TaxTargetService:
+			if _, err := s.serviceRepo.FindByID(ctx, t.TargetID); err != nil {
+				if errors.Is(err, response.ErrNotFound) {
+					return nil, fmt.Errorf("%w: service %d not found", response.ErrValidation, t.TargetID)
+				}
+				return nil, err
+			}
+		case models.TaxTargetCategory:
+			if _, err := s.categoryRepo.FindByID(ctx, t.TargetID); err != nil {
+				if errors.Is(err, response.ErrNotFound) {
+					return nil, fmt.Errorf("%w: category %d not found", response.ErrValidation, t.TargetID)
+				}
+				return nil, err
+			}
+		}
+		targets = append(targets, models.TaxRateTarget{TargetType: t.TargetType, TargetID: t.TargetID, IsExempt: t.IsExempt})
+	}
+
+	if scopeType == models.TaxScopeSelected && !hasAppliedTarget(targets) {
+		return nil, fmt.Errorf("%w: scope 'selected' requires at least one non-exempt target", response.ErrValidation)
+	}
+
+	return targets, nil
+}
+
+func hasAppliedTarget(targets []models.TaxRateTarget) bool {
+	for _, t := range targets {
+		if !t.IsExempt {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *taxService) mapToResponse(rate *models.TaxRateWithTargets) *dto.TaxRateResponse {
+
+	targets := make([]dto.TaxRateTargetResponse, 0, len(rate.Targets))
+	for _, t := range rate.Targets {
+		targets = append(targets, dto.TaxRateTargetResponse{TargetType: t.TargetType, TargetID: t.TargetID, IsExempt: t.IsExempt})
+	}
+
+	return &dto.TaxRateResponse{
+		ID:        rate.ID,
+		Code:      rate.Code,
+		TaxName:   rate.TaxName,
+		TaxKind:   rate.TaxKind,
+		Rate:      rate.Rate,
+		PriceMode: rate.PriceMode,
+		ScopeType: rate.ScopeType,
+		Targets:   targets,
+		IsActive:  rate.IsActive,
+		CreatedAt: rate.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: formatTimePtr(rate.UpdatedAt),
+	}
+}
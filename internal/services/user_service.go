@@ -2,11 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"laundry-backend/internal/dto"
+	"laundry-backend/internal/export"
 	"laundry-backend/internal/models"
 	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/listquery"
 	"laundry-backend/pkg/response"
 	"laundry-backend/pkg/utils"
 )
@@ -14,23 +18,27 @@ import (
 // UserService defines the contract for business logic related to users.
 type UserService interface {
 	RegisterUser(ctx context.Context, req dto.CreateUserRequest) (*dto.UserDetailResponse, error)
-	GetUsers(ctx context.Context, page, perPage int, search, role string, status int) (*dto.UserListResponse, error)
+	GetUsers(ctx context.Context, params listquery.Params) (*dto.UserListResponse, error)
+	ExportUsers(ctx context.Context, params listquery.Params, w export.Writer) error
 	GetUserProfile(ctx context.Context, id int64) (*dto.UserDetailResponse, error)
 
 	// ModifyUserData now requires requester info for authorization logic.
-	ModifyUserData(ctx context.Context, targetID int64, req dto.UpdateUserRequest, requesterID int64, requesterRole string) (*dto.UserDetailResponse, error)
+	// expectedVersion comes from the If-Match header (nil when absent).
+	ModifyUserData(ctx context.Context, targetID int64, req dto.UpdateUserRequest, requesterID int64, requesterRole string, expectedVersion *int64) (*dto.UserDetailResponse, error)
 
 	// DeactivateUserAccount now requires requester info to prevent self-deletion.
-	DeactivateUserAccount(ctx context.Context, targetID int64, requesterID int64) error
+	// expectedVersion comes from the If-Match header (nil when absent).
+	DeactivateUserAccount(ctx context.Context, targetID int64, requesterID int64, expectedVersion *int64) error
 }
 
 type userService struct {
-	userRepo repositories.UserRepository
+	userRepo   repositories.UserRepository
+	outletRepo repositories.OutletRepository
 }
 
 // NewUserService creates a new instance of UserService.
-func NewUserService(userRepo repositories.UserRepository) UserService {
-	return &userService{userRepo: userRepo}
+func NewUserService(userRepo repositories.UserRepository, outletRepo repositories.OutletRepository) UserService {
+	return &userService{userRepo: userRepo, outletRepo: outletRepo}
 }
 
 // RegisterUser handles the registration of a new employee.
@@ -66,6 +74,11 @@ func (s *userService) RegisterUser(ctx context.Context, req dto.CreateUserReques
 		return nil, response.ErrDuplicate
 	}
 
+	// Karyawan selain owner wajib ditempatkan di outlet yang aktif
+	if err := s.validateOutlet(ctx, req.Role, req.OutletID); err != nil {
+		return nil, err
+	}
+
 	// 2. Hash Password
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
@@ -79,9 +92,11 @@ func (s *userService) RegisterUser(ctx context.Context, req dto.CreateUserReques
 		Email:        req.Email,
 		PasswordHash: hashedPassword,
 		Role:         req.Role,
+		OutletID:     req.OutletID,
 		PhoneNumber:  req.PhoneNumber,
 		IsActive:     true, // Default active upon creation
 		CreatedAt:    time.Now(),
+		Version:      1, // Matches the version column default
 	}
 
 	// 4. Insert into DB
@@ -96,31 +111,24 @@ func (s *userService) RegisterUser(ctx context.Context, req dto.CreateUserReques
 		Username:    userModel.Username,
 		Email:       userModel.Email,
 		Role:        userModel.Role,
+		OutletID:    userModel.OutletID,
 		PhoneNumber: userModel.PhoneNumber,
 		IsActive:    userModel.IsActive,
+		Version:     userModel.Version,
 		CreatedAt:   userModel.CreatedAt.Format("2006-01-02 15:04:05"),
 	}, nil
 }
 
 // RetrievedUserDirectory fetches a list of users with pagination and filters.
-func (s *userService) GetUsers(ctx context.Context, page, perPage int, search, role string, status int) (*dto.UserListResponse, error) {
+func (s *userService) GetUsers(ctx context.Context, params listquery.Params) (*dto.UserListResponse, error) {
 
-	// 1. Calculate Offset
-	if page < 1 {
-		page = 1
-	}
-	if perPage < 1 {
-		perPage = 10
-	}
-	offset := (page - 1) * perPage
-
-	// 2. Call Repository
-	users, totalItems, err := s.userRepo.FetchUsers(ctx, perPage, offset, search, role, status)
+	// 1. Call Repository (paging, filters & sorting are validated by listquery)
+	users, page, err := s.userRepo.FetchUsers(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	// 3. Map to DTO Summary
+	// 2. Map to DTO Summary
 	// [OPTIMASI] Pre-allocate slice capacity
 	userResponses := make([]dto.UserSummaryResponse, 0, len(users))
 	for _, u := range users {
@@ -129,27 +137,40 @@ func (s *userService) GetUsers(ctx context.Context, page, perPage int, search, r
 			FullName: u.FullName,
 			Username: u.Username,
 			Role:     u.Role,
+			OutletID: u.OutletID,
 			IsActive: u.IsActive,
 		})
 	}
 
-	// 4. Calculate Total Pages
-	totalPages := 0
-	if totalItems > 0 {
-		totalPages = int((totalItems + int64(perPage) - 1) / int64(perPage))
-	}
-
 	return &dto.UserListResponse{
 		Data: userResponses,
-		Meta: response.MetaData{
-			CurrentPage: page,
-			PerPage:     perPage,
-			TotalItems:  totalItems,
-			TotalPages:  totalPages,
-		},
+		Meta: page.Meta(),
 	}, nil
 }
 
+// userExportColumns adalah judul kolom export GET /users (password hash tidak pernah ikut)
+var userExportColumns = []export.Column{
+	{ID: "ID", EN: "ID"},
+	{ID: "Nama Lengkap", EN: "Full Name"},
+	{ID: "Username", EN: "Username"},
+	{ID: "Email", EN: "Email"},
+	{ID: "Role", EN: "Role"},
+	{ID: "Outlet", EN: "Outlet"},
+	{ID: "No. HP", EN: "Phone Number"},
+	{ID: "Aktif", EN: "Active"},
+	{ID: "Login Terakhir", EN: "Last Login"},
+	{ID: "Dibuat Pada", EN: "Created At"},
+}
+
+// ExportUsers streams every user matching the list filters (without pagination) into w.
+func (s *userService) ExportUsers(ctx context.Context, params listquery.Params, w export.Writer) error {
+	w.SetColumns(userExportColumns)
+
+	return s.userRepo.StreamUsers(ctx, params, func(u *models.User) error {
+		return w.WriteRow(u.ID, u.FullName, u.Username, u.Email, u.Role, u.OutletID, u.PhoneNumber, u.IsActive, u.LastLoginAt, u.CreatedAt)
+	})
+}
+
 // GetUserProfile retrieves detailed user information by ID.
 func (s *userService) GetUserProfile(ctx context.Context, id int64) (*dto.UserDetailResponse, error) {
 
@@ -175,8 +196,10 @@ func (s *userService) GetUserProfile(ctx context.Context, id int64) (*dto.UserDe
 		Username:    user.Username,
 		Email:       user.Email,
 		Role:        user.Role,
+		OutletID:    user.OutletID,
 		PhoneNumber: user.PhoneNumber,
 		IsActive:    user.IsActive,
+		Version:     user.Version,
 		LastLoginAt: lastLoginStr,
 		CreatedAt:   createdAtStr,
 		UpdatedAt:   updatedAtStr,
@@ -184,7 +207,7 @@ func (s *userService) GetUserProfile(ctx context.Context, id int64) (*dto.UserDe
 }
 
 // ModifyUserData updates user profile with strict security checks.
-func (s *userService) ModifyUserData(ctx context.Context, targetID int64, req dto.UpdateUserRequest, requesterID int64, requesterRole string) (*dto.UserDetailResponse, error) {
+func (s *userService) ModifyUserData(ctx context.Context, targetID int64, req dto.UpdateUserRequest, requesterID int64, requesterRole string, expectedVersion *int64) (*dto.UserDetailResponse, error) {
 
 	// 1. Retrieve Existing User
 	existingUser, err := s.userRepo.FindByID(ctx, targetID)
@@ -200,11 +223,17 @@ func (s *userService) ModifyUserData(ctx context.Context, targetID int64, req dt
 			return nil, response.ErrForbidden
 		}
 
-		// Rule B: Non-owners CANNOT change Role or Active Status (Silent Ignore)
+		// Rule B: Non-owners CANNOT change Role, Outlet or Active Status (Silent Ignore)
 		req.Role = ""
+		req.OutletID = nil
 		req.IsActive = nil
 	}
 
+	// CONCURRENCY GUARD: Reject if the profile changed since the client read it (If-Match)
+	if err := checkExpectedVersion(existingUser.Version, expectedVersion); err != nil {
+		return nil, err
+	}
+
 	// 3. Update Fields (Partial Update Logic)
 
 	if req.FullName != "" {
@@ -245,10 +274,18 @@ func (s *userService) ModifyUserData(ctx context.Context, targetID int64, req dt
 		existingUser.PhoneNumber = req.PhoneNumber
 	}
 
-	// Role is only updated if it passed the Security Guard above
+	// Role & Outlet are only updated if they passed the Security Guard above
 	if req.Role != "" {
 		existingUser.Role = req.Role
 	}
+	if req.OutletID != nil {
+		existingUser.OutletID = req.OutletID
+	}
+	if req.Role != "" || req.OutletID != nil {
+		if err := s.validateOutlet(ctx, existingUser.Role, existingUser.OutletID); err != nil {
+			return nil, err
+		}
+	}
 
 	if req.Password != "" {
 		hashedPwd, err := utils.HashPassword(req.Password)
@@ -276,7 +313,7 @@ func (s *userService) ModifyUserData(ctx context.Context, targetID int64, req dt
 }
 
 // DeactivateUserAccount handles soft deletion of a user.
-func (s *userService) DeactivateUserAccount(ctx context.Context, targetID int64, requesterID int64) error {
+func (s *userService) DeactivateUserAccount(ctx context.Context, targetID int64, requesterID int64, expectedVersion *int64) error {
 
 	// 1. SECURITY GUARD: Anti Self-Deletion
 	if targetID == requesterID {
@@ -284,12 +321,41 @@ func (s *userService) DeactivateUserAccount(ctx context.Context, targetID int64,
 		return response.ErrForbidden
 	}
 
-	// 2. Check if user exists
-	_, err := s.userRepo.FindByID(ctx, targetID)
+	// 2. Check if user exists and has not been modified since the client read it (If-Match)
+	existingUser, err := s.userRepo.FindByID(ctx, targetID)
 	if err != nil {
 		return err
 	}
+	if err := checkExpectedVersion(existingUser.Version, expectedVersion); err != nil {
+		return err
+	}
+
+	// 3. Execute versioned Soft Delete
+	return s.userRepo.DeleteUser(ctx, targetID, existingUser.Version)
+}
+
+// --- HELPER FUNCTION ---
+
+// validateOutlet memastikan karyawan selain owner ditempatkan di outlet yang ada dan aktif.
+// Owner boleh tanpa outlet (melihat semua outlet).
+func (s *userService) validateOutlet(ctx context.Context, role string, outletID *int64) error {
+	if outletID == nil {
+		if role == "owner" {
+			return nil
+		}
+		return fmt.Errorf("%w: outlet_id is required for role %s", response.ErrValidation, role)
+	}
+
+	o, err := s.outletRepo.FindByID(ctx, *outletID)
+	if err != nil {
+		if errors.Is(err, response.ErrNotFound) {
+			return fmt.Errorf("%w: outlet %d not found", response.ErrValidation, *outletID)
+		}
+		return err
+	}
+	if !o.IsActive {
+		return fmt.Errorf("%w: outlet %d is not active", response.ErrValidation, o.ID)
+	}
 
-	// 3. Execute Soft Delete
-	return s.userRepo.DeleteUser(ctx, targetID)
+	return nil
 }
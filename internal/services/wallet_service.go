@@ -0,0 +1,434 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+	"time"
+)
+
+// recentLedgerLimit adalah jumlah mutasi terakhir yang ikut tampil di ringkasan dompet.
+const recentLedgerLimit = 10
+
+// WalletService defines the contract for business logic related to customer deposits and loyalty points.
+type WalletService interface {
+	GetWallet(ctx context.Context, customerID int64) (*dto.WalletResponse, error)
+	GetWalletLedger(ctx context.Context, customerID int64, page, perPage int) (*dto.WalletLedgerResponse, error)
+	GetPointLedger(ctx context.Context, customerID int64, page, perPage int) (*dto.PointLedgerResponse, error)
+
+	// TopUp mencatat setoran deposit pelanggan.
+	TopUp(ctx context.Context, customerID int64, req dto.WalletTopUpRequest, actorID int64) (*dto.WalletEntryResponse, error)
+
+	// AdjustBalance & AdjustPoints adalah koreksi manual oleh Owner, wajib disertai alasan.
+	AdjustBalance(ctx context.Context, customerID int64, req dto.WalletAdjustmentRequest, actorID int64) (*dto.WalletEntryResponse, error)
+	AdjustPoints(ctx context.Context, customerID int64, req dto.PointAdjustmentRequest, actorID int64) (*dto.PointEntryResponse, error)
+
+	// --- Hook untuk transaksi pesanan/pembayaran (dipanggil di dalam tx milik pemanggil) ---
+
+	// PayWithDepositTx memotong saldo untuk pembayaran metode 'deposit'.
+	// Mengembalikan ErrInsufficientBalance jika saldo kurang, sehingga pemanggil me-rollback pelunasan.
+	PayWithDepositTx(ctx context.Context, tx *sql.Tx, customerID, orderID int64, amount money.Amount, actorID int64) (*models.WalletEntry, error)
+
+	// RefundToWalletTx mengembalikan saldo deposit dari pesanan yang dibatalkan.
+	RefundToWalletTx(ctx context.Context, tx *sql.Tx, customerID, orderID int64, amount money.Amount, actorID int64) (*models.WalletEntry, error)
+
+	// CompensateToWalletTx menambah saldo deposit sebagai ganti rugi komplain pesanan.
+	CompensateToWalletTx(ctx context.Context, tx *sql.Tx, customerID, orderID int64, amount money.Amount, reason string, actorID int64) (*models.WalletEntry, error)
+
+	// AccruePointsTx menambah poin dari pesanan lunas: floor(paidAmount / EarnAmount x pengali level member).
+	AccruePointsTx(ctx context.Context, tx *sql.Tx, customerID, orderID int64, paidAmount money.Amount, actorID int64) (int, error)
+
+	// RedeemPointsTx menukar poin menjadi potongan harga dan mengembalikan nilai rupiahnya.
+	RedeemPointsTx(ctx context.Context, tx *sql.Tx, customerID, orderID int64, points int, actorID int64) (money.Amount, error)
+}
+
+type walletService struct {
+	walletRepo     repositories.WalletRepository
+	membershipRepo repositories.MembershipRepository
+	cfg            *config.Config
+}
+
+// NewWalletService creates a new instance of WalletService.
+func NewWalletService(walletRepo repositories.WalletRepository, membershipRepo repositories.MembershipRepository, cfg *config.Config) WalletService {
+	return &walletService{
+		walletRepo:     walletRepo,
+		membershipRepo: membershipRepo,
+		cfg:            cfg,
+	}
+}
+
+// GetWallet retrieves the balance, points, membership and recent movements of a customer.
+func (s *walletService) GetWallet(ctx context.Context, customerID int64) (*dto.WalletResponse, error) {
+
+	// 1. Ambil saldo berjalan pelanggan
+	wallet, err := s.walletRepo.FindWallet(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Ambil level member aktif (boleh kosong)
+	tier, err := s.membershipRepo.FindCustomerTier(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Ambil mutasi terakhir saldo & poin
+	walletEntries, _, err := s.walletRepo.FindWalletEntries(ctx, customerID, recentLedgerLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	pointEntries, _, err := s.walletRepo.FindPointEntries(ctx, customerID, recentLedgerLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Mapping ke DTO
+	res := &dto.WalletResponse{
+		CustomerID:         wallet.CustomerID,
+		FullName:           wallet.FullName,
+		PhoneNumber:        wallet.PhoneNumber,
+		Balance:            wallet.WalletBalance,
+		Points:             wallet.PointsBalance,
+		PointValue:         money.New(int64(s.cfg.LOYALTY.PointValue)),
+		PointsWorth:        money.New(int64(s.cfg.LOYALTY.PointValue)).MulInt(int64(wallet.PointsBalance)),
+		RecentTransactions: make([]dto.WalletEntryResponse, 0, len(walletEntries)),
+		RecentPoints:       make([]dto.PointEntryResponse, 0, len(pointEntries)),
+	}
+	if tier != nil {
+		res.Membership = mapMembershipTier(tier)
+	}
+	for i := range walletEntries {
+		res.RecentTransactions = append(res.RecentTransactions, *mapWalletEntry(&walletEntries[i]))
+	}
+	for i := range pointEntries {
+		res.RecentPoints = append(res.RecentPoints, *mapPointEntry(&pointEntries[i]))
+	}
+
+	return res, nil
+}
+
+// GetWalletLedger fetches the wallet ledger of a customer with pagination.
+func (s *walletService) GetWalletLedger(ctx context.Context, customerID int64, page, perPage int) (*dto.WalletLedgerResponse, error) {
+
+	// 1. Validasi Batas Halaman
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 10
+	}
+	offset := (page - 1) * perPage
+
+	// 2. Pastikan pelanggan ada
+	if _, err := s.walletRepo.FindWallet(ctx, customerID); err != nil {
+		return nil, err
+	}
+
+	// 3. Panggil Repository
+	entries, totalItems, err := s.walletRepo.FindWalletEntries(ctx, customerID, perPage, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Mapping & Meta Pagination
+	data := make([]dto.WalletEntryResponse, 0, len(entries))
+	for i := range entries {
+		data = append(data, *mapWalletEntry(&entries[i]))
+	}
+
+	return &dto.WalletLedgerResponse{Data: data, Meta: response.NewPageMeta(page, perPage, totalItems)}, nil
+}
+
+// GetPointLedger fetches the loyalty point ledger of a customer with pagination.
+func (s *walletService) GetPointLedger(ctx context.Context, customerID int64, page, perPage int) (*dto.PointLedgerResponse, error) {
+
+	// 1. Validasi Batas Halaman
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 10
+	}
+	offset := (page - 1) * perPage
+
+	// 2. Pastikan pelanggan ada
+	if _, err := s.walletRepo.FindWallet(ctx, customerID); err != nil {
+		return nil, err
+	}
+
+	// 3. Panggil Repository
+	entries, totalItems, err := s.walletRepo.FindPointEntries(ctx, customerID, perPage, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Mapping & Meta Pagination
+	data := make([]dto.PointEntryResponse, 0, len(entries))
+	for i := range entries {
+		data = append(data, *mapPointEntry(&entries[i]))
+	}
+
+	return &dto.PointLedgerResponse{Data: data, Meta: response.NewPageMeta(page, perPage, totalItems)}, nil
+}
+
+// TopUp records a customer deposit.
+func (s *walletService) TopUp(ctx context.Context, customerID int64, req dto.WalletTopUpRequest, actorID int64) (*dto.WalletEntryResponse, error) {
+
+	// 1. Siapkan alasan default agar ledger selalu punya keterangan
+	reason := req.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("Deposit top-up via %s", req.Method)
+	}
+
+	// 2. Catat mutasi kredit
+	entry := &models.WalletEntry{
+		CustomerID:    customerID,
+		EntryType:     models.WalletEntryTopUp,
+		Direction:     models.LedgerCredit,
+		Amount:        req.Amount,
+		PaymentMethod: &req.Method,
+		Reason:        reason,
+		ActorID:       &actorID,
+		CreatedAt:     time.Now(),
+	}
+	if err := s.walletRepo.PostWalletEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return mapWalletEntry(entry), nil
+}
+
+// AdjustBalance applies a signed manual correction to the wallet balance.
+func (s *walletService) AdjustBalance(ctx context.Context, customerID int64, req dto.WalletAdjustmentRequest, actorID int64) (*dto.WalletEntryResponse, error) {
+
+	// 1. Tentukan arah mutasi dari tanda nominal
+	amount := req.Amount
+	if amount.IsZero() {
+		return nil, fmt.Errorf("%w: amount must not be zero", response.ErrValidation)
+	}
+	direction := models.LedgerCredit
+	if amount.IsNegative() {
+		direction = models.LedgerDebit
+	}
+
+	// 2. Catat mutasi koreksi
+	entry := &models.WalletEntry{
+		CustomerID: customerID,
+		EntryType:  models.WalletEntryAdjustment,
+		Direction:  direction,
+		Amount:     amount.Abs(),
+		Reason:     req.Reason,
+		ActorID:    &actorID,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.walletRepo.PostWalletEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return mapWalletEntry(entry), nil
+}
+
+// AdjustPoints applies a signed manual correction to the point balance.
+func (s *walletService) AdjustPoints(ctx context.Context, customerID int64, req dto.PointAdjustmentRequest, actorID int64) (*dto.PointEntryResponse, error) {
+
+	// 1. Tentukan arah mutasi dari tanda poin
+	direction := models.LedgerCredit
+	points := req.Points
+	if points < 0 {
+		direction = models.LedgerDebit
+		points = -points
+	}
+
+	// 2. Catat mutasi koreksi
+	entry := &models.PointEntry{
+		CustomerID: customerID,
+		EntryType:  models.PointEntryAdjustment,
+		Direction:  direction,
+		Points:     points,
+		Reason:     req.Reason,
+		ActorID:    &actorID,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.walletRepo.PostPointEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return mapPointEntry(entry), nil
+}
+
+// PayWithDepositTx debits the wallet inside the settlement transaction.
+func (s *walletService) PayWithDepositTx(ctx context.Context, tx *sql.Tx, customerID, orderID int64, amount money.Amount, actorID int64) (*models.WalletEntry, error) {
+
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: deposit payment amount must be greater than zero", response.ErrValidation)
+	}
+
+	entry := &models.WalletEntry{
+		CustomerID: customerID,
+		EntryType:  models.WalletEntrySpend,
+		Direction:  models.LedgerDebit,
+		Amount:     amount,
+		OrderID:    &orderID,
+		Reason:     fmt.Sprintf("Payment for order #%d", orderID),
+		ActorID:    &actorID,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.walletRepo.PostWalletEntryTx(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// RefundToWalletTx credits the wallet back for a cancelled order.
+func (s *walletService) RefundToWalletTx(ctx context.Context, tx *sql.Tx, customerID, orderID int64, amount money.Amount, actorID int64) (*models.WalletEntry, error) {
+
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: refund amount must be greater than zero", response.ErrValidation)
+	}
+
+	entry := &models.WalletEntry{
+		CustomerID: customerID,
+		EntryType:  models.WalletEntryRefund,
+		Direction:  models.LedgerCredit,
+		Amount:     amount,
+		OrderID:    &orderID,
+		Reason:     fmt.Sprintf("Refund for order #%d", orderID),
+		ActorID:    &actorID,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.walletRepo.PostWalletEntryTx(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// CompensateToWalletTx credits the wallet as compensation for a complaint about an order.
+func (s *walletService) CompensateToWalletTx(ctx context.Context, tx *sql.Tx, customerID, orderID int64, amount money.Amount, reason string, actorID int64) (*models.WalletEntry, error) {
+
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: compensation amount must be greater than zero", response.ErrValidation)
+	}
+
+	entry := &models.WalletEntry{
+		CustomerID: customerID,
+		EntryType:  models.WalletEntryCompensation,
+		Direction:  models.LedgerCredit,
+		Amount:     amount,
+		OrderID:    &orderID,
+		Reason:     reason,
+		ActorID:    &actorID,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.walletRepo.PostWalletEntryTx(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// AccruePointsTx credits loyalty points for a paid order.
+func (s *walletService) AccruePointsTx(ctx context.Context, tx *sql.Tx, customerID, orderID int64, paidAmount money.Amount, actorID int64) (int, error) {
+
+	// 1. Konversi nominal ke poin (dinonaktifkan jika EarnAmount <= 0)
+	if s.cfg.LOYALTY.EarnAmount <= 0 || !paidAmount.IsPositive() {
+		return 0, nil
+	}
+
+	multiplier := 1.0
+	tier, err := s.membershipRepo.FindCustomerTier(ctx, customerID)
+	if err != nil {
+		return 0, err
+	}
+	if tier != nil {
+		multiplier = tier.PointMultiplier
+	}
+
+	// Pembagian bilangan bulat sen = pembulatan ke bawah, sisa di bawah EarnAmount tidak dihitung
+	weighted := paidAmount.MulQuantity(multiplier, money.Down)
+	points := int(weighted.Minor() / money.New(int64(s.cfg.LOYALTY.EarnAmount)).Minor())
+	if points <= 0 {
+		return 0, nil
+	}
+
+	// 2. Catat mutasi poin (unique index order_id + entry_type mencegah poin ganda)
+	entry := &models.PointEntry{
+		CustomerID: customerID,
+		EntryType:  models.PointEntryEarn,
+		Direction:  models.LedgerCredit,
+		Points:     points,
+		OrderID:    &orderID,
+		Reason:     fmt.Sprintf("Points earned from order #%d", orderID),
+		ActorID:    &actorID,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.walletRepo.PostPointEntryTx(ctx, tx, entry); err != nil {
+		return 0, err
+	}
+
+	return points, nil
+}
+
+// RedeemPointsTx debits loyalty points and returns their rupiah value.
+func (s *walletService) RedeemPointsTx(ctx context.Context, tx *sql.Tx, customerID, orderID int64, points int, actorID int64) (money.Amount, error) {
+
+	if points <= 0 {
+		return 0, fmt.Errorf("%w: points to redeem must be greater than zero", response.ErrValidation)
+	}
+
+	entry := &models.PointEntry{
+		CustomerID: customerID,
+		EntryType:  models.PointEntryRedeem,
+		Direction:  models.LedgerDebit,
+		Points:     points,
+		OrderID:    &orderID,
+		Reason:     fmt.Sprintf("Points redeemed on order #%d", orderID),
+		ActorID:    &actorID,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.walletRepo.PostPointEntryTx(ctx, tx, entry); err != nil {
+		return 0, err
+	}
+
+	return money.New(int64(s.cfg.LOYALTY.PointValue)).MulInt(int64(points)), nil
+}
+
+// --- HELPER FUNCTION ---
+
+func mapWalletEntry(e *models.WalletEntry) *dto.WalletEntryResponse {
+	return &dto.WalletEntryResponse{
+		ID:            e.ID,
+		EntryType:     e.EntryType,
+		Direction:     e.Direction,
+		Amount:        e.Amount,
+		BalanceBefore: e.BalanceBefore,
+		BalanceAfter:  e.BalanceAfter,
+		OrderID:       e.OrderID,
+		PaymentMethod: e.PaymentMethod,
+		Reason:        e.Reason,
+		ActorID:       e.ActorID,
+		CreatedAt:     e.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func mapPointEntry(e *models.PointEntry) *dto.PointEntryResponse {
+	return &dto.PointEntryResponse{
+		ID:            e.ID,
+		EntryType:     e.EntryType,
+		Direction:     e.Direction,
+		Points:        e.Points,
+		BalanceBefore: e.BalanceBefore,
+		BalanceAfter:  e.BalanceAfter,
+		OrderID:       e.OrderID,
+		Reason:        e.Reason,
+		ActorID:       e.ActorID,
+		CreatedAt:     e.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/money"
+	"laundry-backend/pkg/response"
+)
+
+// fakeWalletRepo menyimpan saldo & poin di memori dan menolak debit melebihi saldo, seperti repository MySQL.
+type fakeWalletRepo struct {
+	repositories.WalletRepository
+	balance       money.Amount
+	points        int
+	walletEntries []models.WalletEntry
+	pointEntries  []models.PointEntry
+}
+
+func (f *fakeWalletRepo) PostWalletEntryTx(ctx context.Context, tx *sql.Tx, entry *models.WalletEntry) error {
+	if entry.Direction == models.LedgerDebit {
+		if entry.Amount > f.balance {
+			return response.ErrInsufficientBalance
+		}
+		f.balance = f.balance.Sub(entry.Amount)
+	} else {
+		f.balance = f.balance.Add(entry.Amount)
+	}
+	f.walletEntries = append(f.walletEntries, *entry)
+	return nil
+}
+
+func (f *fakeWalletRepo) PostPointEntryTx(ctx context.Context, tx *sql.Tx, entry *models.PointEntry) error {
+	if entry.Direction == models.LedgerDebit {
+		if entry.Points > f.points {
+			return response.ErrInsufficientPoints
+		}
+		f.points -= entry.Points
+	} else {
+		f.points += entry.Points
+	}
+	f.pointEntries = append(f.pointEntries, *entry)
+	return nil
+}
+
+type fakeMembershipRepo struct {
+	repositories.MembershipRepository
+	tier *models.MembershipTier
+}
+
+func (f *fakeMembershipRepo) FindCustomerTier(ctx context.Context, customerID int64) (*models.MembershipTier, error) {
+	return f.tier, nil
+}
+
+func newTestWalletService(walletRepo *fakeWalletRepo, tier *models.MembershipTier, earnAmount, pointValue int) WalletService {
+	cfg := &config.Config{LOYALTY: config.LoyaltyConfig{EarnAmount: earnAmount, PointValue: pointValue}}
+	return NewWalletService(walletRepo, &fakeMembershipRepo{tier: tier}, cfg)
+}
+
+func TestAccruePointsTxAppliesTierMultiplier(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		tier       *models.MembershipTier
+		earnAmount int
+		paid       money.Amount
+		wantPoints int
+	}{
+		{name: "no tier earns one point per earn amount", paid: money.New(55000), earnAmount: 10000, wantPoints: 5},
+		{name: "gold tier multiplies before flooring", tier: &models.MembershipTier{PointMultiplier: 1.5}, paid: money.New(55000), earnAmount: 10000, wantPoints: 8},
+		{name: "double tier", tier: &models.MembershipTier{PointMultiplier: 2}, paid: money.New(30000), earnAmount: 10000, wantPoints: 6},
+		{name: "below earn amount earns nothing", tier: &models.MembershipTier{PointMultiplier: 1.5}, paid: money.New(6000), earnAmount: 10000, wantPoints: 0},
+		{name: "fractional rupiah is floored", paid: money.MustParse("19999.99"), earnAmount: 10000, wantPoints: 1},
+		{name: "earning disabled", paid: money.New(100000), earnAmount: 0, wantPoints: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeWalletRepo{}
+			svc := newTestWalletService(repo, tt.tier, tt.earnAmount, 100)
+
+			got, err := svc.AccruePointsTx(context.Background(), nil, 7, 42, tt.paid, 1)
+			if err != nil {
+				t.Fatalf("AccruePointsTx: %v", err)
+			}
+			if got != tt.wantPoints {
+				t.Fatalf("points = %d, want %d", got, tt.wantPoints)
+			}
+			if tt.wantPoints == 0 && len(repo.pointEntries) != 0 {
+				t.Fatalf("posted %d point entries, want none", len(repo.pointEntries))
+			}
+			if tt.wantPoints > 0 && (len(repo.pointEntries) != 1 || repo.pointEntries[0].Points != tt.wantPoints || *repo.pointEntries[0].OrderID != 42) {
+				t.Fatalf("point entries = %+v, want one credit of %d for order 42", repo.pointEntries, tt.wantPoints)
+			}
+		})
+	}
+}
+
+func TestPayWithDepositTxInsufficientBalance(t *testing.T) {
+
+	repo := &fakeWalletRepo{balance: money.New(20000)}
+	svc := newTestWalletService(repo, nil, 10000, 100)
+
+	_, err := svc.PayWithDepositTx(context.Background(), nil, 7, 42, money.New(25000), 1)
+	if !errors.Is(err, response.ErrInsufficientBalance) {
+		t.Fatalf("error = %v, want ErrInsufficientBalance", err)
+	}
+	if repo.balance != money.New(20000) || len(repo.walletEntries) != 0 {
+		t.Fatalf("balance = %s with %d entries, want untouched 20000", repo.balance, len(repo.walletEntries))
+	}
+
+	entry, err := svc.PayWithDepositTx(context.Background(), nil, 7, 42, money.New(20000), 1)
+	if err != nil {
+		t.Fatalf("PayWithDepositTx exact balance: %v", err)
+	}
+	if entry.EntryType != models.WalletEntrySpend || entry.Direction != models.LedgerDebit || !repo.balance.IsZero() {
+		t.Fatalf("entry = %+v, balance = %s, want a spend debit leaving zero", entry, repo.balance)
+	}
+}
+
+func TestRedeemPointsTx(t *testing.T) {
+
+	repo := &fakeWalletRepo{points: 30}
+	svc := newTestWalletService(repo, nil, 10000, 100)
+
+	if _, err := svc.RedeemPointsTx(context.Background(), nil, 7, 42, 31, 1); !errors.Is(err, response.ErrInsufficientPoints) {
+		t.Fatalf("error = %v, want ErrInsufficientPoints", err)
+	}
+
+	value, err := svc.RedeemPointsTx(context.Background(), nil, 7, 42, 25, 1)
+	if err != nil {
+		t.Fatalf("RedeemPointsTx: %v", err)
+	}
+	if value != money.New(2500) || repo.points != 5 {
+		t.Fatalf("value = %s, points left = %d, want 2500 and 5", value, repo.points)
+	}
+}
@@ -0,0 +1,614 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"laundry-backend/internal/capacity"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/orderflow"
+	"laundry-backend/internal/outlet"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/pkg/listquery"
+	"laundry-backend/pkg/response"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WashBatchService defines the contract for machine batches: grouping order items into one washer/dryer cycle,
+// deriving order statuses from batch progress, and reporting machine utilisation.
+type WashBatchService interface {
+
+	// StartBatch memasukkan item hasil scan (kode tag / nomor nota) ke mesin; pesanan 'pending' otomatis 'in-progress'.
+	StartBatch(ctx context.Context, req dto.StartWashBatchRequest, actorID int64, actorRole string) (*dto.WashBatchResponse, error)
+
+	// FinishBatch menutup siklus mesin; pesanan yang semua itemnya sudah selesai diproses menjadi 'ready-*'.
+	FinishBatch(ctx context.Context, batchID, actorID int64, actorRole string) (*dto.WashBatchResponse, error)
+
+	GetBatches(ctx context.Context, params listquery.Params) (*dto.WashBatchListResponse, error)
+	GetBatchDetail(ctx context.Context, id int64) (*dto.WashBatchResponse, error)
+
+	// GetMachineUtilization membandingkan lama mesin berjalan dengan jam buka outlet per mesin (outlet aktif / konsolidasi).
+	GetMachineUtilization(ctx context.Context, startDate, endDate string) (*dto.MachineUtilizationReportResponse, error)
+}
+
+type washBatchService struct {
+	washBatchRepo   repositories.WashBatchRepository
+	capacityRepo    repositories.CapacityRepository
+	orderStatusRepo repositories.OrderStatusRepository
+	statusChanger   *orderStatusChanger
+	pieceLoadKg     float64
+}
+
+// NewWashBatchService creates a new instance of WashBatchService.
+func NewWashBatchService(washBatchRepo repositories.WashBatchRepository, capacityRepo repositories.CapacityRepository, orderStatusRepo repositories.OrderStatusRepository, notificationService NotificationService, webhookService WebhookService, inventoryService InventoryService, cfg *config.Config) WashBatchService {
+	return &washBatchService{
+		washBatchRepo:   washBatchRepo,
+		capacityRepo:    capacityRepo,
+		orderStatusRepo: orderStatusRepo,
+		statusChanger: &orderStatusChanger{
+			orderStatusRepo:     orderStatusRepo,
+			notificationService: notificationService,
+			webhookService:      webhookService,
+			inventoryService:    inventoryService,
+		},
+		pieceLoadKg: float64(cfg.CAPACITY.PieceLoadGrams) / 1000,
+	}
+}
+
+// StartBatch loads the scanned order items into an idle machine of the active outlet.
+//
+// Aturan:
+//   - Mesin harus aktif, tidak maintenance, dan tidak sedang menjalankan batch lain.
+//   - Kode tag memasukkan satu item; nomor nota memasukkan semua item pesanan.
+//   - Pesanan harus 'pending' atau 'in-progress', dan item tidak boleh masih berada di batch yang berjalan.
+//   - Total muatan (item kg = berat, item pcs = jumlah x beban per pcs) tidak boleh melebihi capacity_kg mesin.
+//   - Pesanan 'pending' dipindah ke 'in-progress' (notifikasi, webhook, & potong stok ikut berjalan);
+//     pesanan yang sudah 'in-progress' cukup dicatat di status_history.
+func (s *washBatchService) StartBatch(ctx context.Context, req dto.StartWashBatchRequest, actorID int64, actorRole string) (*dto.WashBatchResponse, error) {
+
+	// 1. Batch selalu milik satu outlet
+	outletID, err := requireOutlet(ctx, "starting a wash batch")
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Terjemahkan hasil scan menjadi item pesanan (duplikat diabaikan)
+	candidates, err := s.resolveCodes(ctx, req.Codes)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.WashBatchItem, 0, len(candidates))
+	itemIDs := make([]int64, 0, len(candidates))
+	orderIDs := []int64{}
+	seenOrder := make(map[int64]bool)
+	loadKg := 0.0
+	for _, c := range candidates {
+		if c.StatusInternal != models.OrderStatusPending && c.StatusInternal != models.OrderStatusInProgress {
+			return nil, fmt.Errorf("%w: order %s is %s and cannot enter a machine", response.ErrValidation, c.InvoiceNumber, c.StatusInternal)
+		}
+		if c.RunningBatchID != nil {
+			return nil, fmt.Errorf("%w: %s (%s) is still in running batch #%d", response.ErrWashBatchConflict, c.InvoiceNumber, c.ServiceName, *c.RunningBatchID)
+		}
+
+		itemLoad := s.itemLoadKg(c.Unit, c.Quantity)
+		loadKg += itemLoad
+		items = append(items, models.WashBatchItem{OrderID: c.OrderID, OrderItemID: c.OrderItemID, LoadKg: itemLoad})
+		itemIDs = append(itemIDs, c.OrderItemID)
+		if !seenOrder[c.OrderID] {
+			seenOrder[c.OrderID] = true
+			orderIDs = append(orderIDs, c.OrderID)
+		}
+	}
+	loadKg = math.Round(loadKg*100) / 100
+
+	// 3. Mulai transaksi & kunci mesin (dua staff tidak bisa memakai mesin yang sama bersamaan)
+	tx, err := s.orderStatusRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	machine, err := s.washBatchRepo.LockMachineTx(ctx, tx, req.MachineID)
+	if err != nil {
+		return nil, err
+	}
+	if !machine.IsActive {
+		return nil, fmt.Errorf("%w: machine %s is inactive", response.ErrMachineUnavailable, machine.MachineName)
+	}
+	if machine.UnderMaintenance {
+		return nil, fmt.Errorf("%w: machine %s is under maintenance", response.ErrMachineUnavailable, machine.MachineName)
+	}
+	runningID, err := s.washBatchRepo.FindRunningBatchIDTx(ctx, tx, machine.ID)
+	if err != nil {
+		return nil, err
+	}
+	if runningID != nil {
+		return nil, fmt.Errorf("%w: machine %s is still running batch #%d", response.ErrMachineUnavailable, machine.MachineName, *runningID)
+	}
+	if loadKg > machine.CapacityKg {
+		return nil, fmt.Errorf("%w: load %.2f kg exceeds the %.2f kg capacity of machine %s", response.ErrValidation, loadKg, machine.CapacityKg, machine.MachineName)
+	}
+
+	// 4. Kunci pesanan (urut ID agar tidak deadlock) lalu cek ulang status & batch berjalan
+	sort.Slice(orderIDs, func(i, j int) bool { return orderIDs[i] < orderIDs[j] })
+	states := make([]*models.OrderState, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		state, err := s.orderStatusRepo.LockStateTx(ctx, tx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		if state.StatusInternal != models.OrderStatusPending && state.StatusInternal != models.OrderStatusInProgress {
+			return nil, fmt.Errorf("%w: order %s is %s and cannot enter a machine", response.ErrValidation, state.InvoiceNumber, state.StatusInternal)
+		}
+		states = append(states, state)
+	}
+	running, err := s.washBatchRepo.FindRunningItemIDsTx(ctx, tx, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(running) > 0 {
+		return nil, fmt.Errorf("%w: order item %d was just loaded into another batch", response.ErrWashBatchConflict, running[0])
+	}
+
+	// 5. Simpan batch & item
+	now := time.Now()
+	batch := &models.WashBatch{
+		OutletID:    outletID,
+		MachineID:   machine.ID,
+		BatchStatus: models.WashBatchRunning,
+		LoadKg:      loadKg,
+		Notes:       trimNote(req.Notes),
+		StartedBy:   actorID,
+		StartedAt:   now,
+		Items:       items,
+	}
+	if err := s.washBatchRepo.InsertBatchTx(ctx, tx, batch); err != nil {
+		return nil, err
+	}
+
+	// 6. Turunkan status pesanan dari batch: pending -> in-progress, selain itu cukup dicatat di riwayat
+	note := fmt.Sprintf("Masuk batch #%d (%s)", batch.ID, machine.MachineName)
+	orders := make([]dto.WashBatchOrderResponse, 0, len(states))
+	for _, state := range states {
+		history := &models.StatusHistory{
+			OrderID:   state.ID,
+			ActorID:   &actorID,
+			ActorRole: &actorRole,
+			Notes:     &note,
+			CreatedAt: now,
+		}
+		previous := state.StatusInternal
+
+		if state.StatusInternal == models.OrderStatusPending {
+			if err := orderflow.ValidateTransition(actorRole, *state, models.OrderStatusInProgress); err != nil {
+				return nil, fmt.Errorf("%w: %v", response.ErrInvalidTransition, err)
+			}
+			history.NewStatus = models.OrderStatusInProgress
+			if err := s.statusChanger.changeTx(ctx, tx, state, history); err != nil {
+				return nil, err
+			}
+		} else {
+			history.PreviousStatus = &previous
+			history.NewStatus = previous
+			if err := s.orderStatusRepo.InsertHistoryTx(ctx, tx, history); err != nil {
+				return nil, err
+			}
+		}
+
+		orders = append(orders, mapWashBatchOrder(state, previous))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("washBatchService.StartBatch.Commit: %w", err)
+	}
+
+	// 7. Mapping ke response (data lengkap dengan nama mesin, operator, & tag)
+	res, err := s.GetBatchDetail(ctx, batch.ID)
+	if err != nil {
+		return nil, err
+	}
+	res.Orders = orders
+	return res, nil
+}
+
+// FinishBatch closes a running batch of the active outlet and derives the status of its orders.
+//
+// Pesanan menjadi 'ready-pickup' / 'ready-delivery' jika semua itemnya sudah selesai di tahap akhir
+// (mesin pengering; mesin cuci jika outlet tidak punya pengering aktif) dan tidak ada item di batch yang berjalan.
+// Selain itu status tetap 'in-progress' (scan tag tetap bisa dipakai sebagai override manual).
+func (s *washBatchService) FinishBatch(ctx context.Context, batchID, actorID int64, actorRole string) (*dto.WashBatchResponse, error) {
+
+	// 1. Tentukan tahap akhir outlet aktif
+	if _, err := requireOutlet(ctx, "finishing a wash batch"); err != nil {
+		return nil, err
+	}
+	machines, err := s.capacityRepo.FindMachines(ctx, "1")
+	if err != nil {
+		return nil, err
+	}
+	finalStage := models.MachineWasher
+	for _, m := range machines {
+		if m.MachineType == models.MachineDryer {
+			finalStage = models.MachineDryer
+		}
+	}
+
+	// 2. Mulai transaksi & kunci batch
+	tx, err := s.orderStatusRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	batch, err := s.washBatchRepo.LockBatchTx(ctx, tx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.BatchStatus != models.WashBatchRunning {
+		return nil, fmt.Errorf("%w: batch #%d is already finished", response.ErrWashBatchConflict, batch.ID)
+	}
+
+	now := time.Now()
+	batch.FinishedBy = &actorID
+	batch.FinishedAt = &now
+	if err := s.washBatchRepo.FinishBatchTx(ctx, tx, batch); err != nil {
+		return nil, err
+	}
+
+	// 3. Turunkan status setiap pesanan di batch (urut ID agar tidak deadlock)
+	orderIDs := []int64{}
+	seenOrder := make(map[int64]bool)
+	for _, item := range batch.Items {
+		if !seenOrder[item.OrderID] {
+			seenOrder[item.OrderID] = true
+			orderIDs = append(orderIDs, item.OrderID)
+		}
+	}
+	sort.Slice(orderIDs, func(i, j int) bool { return orderIDs[i] < orderIDs[j] })
+
+	note := fmt.Sprintf("Semua item selesai diproses mesin (batch #%d, %s)", batch.ID, batch.MachineName)
+	orders := make([]dto.WashBatchOrderResponse, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		state, err := s.orderStatusRepo.LockStateTx(ctx, tx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		previous := state.StatusInternal
+
+		progress, err := s.washBatchRepo.FindItemProgressTx(ctx, tx, orderID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Pesanan yang sudah dimajukan/dibatalkan manual tidak disentuh
+		if state.StatusInternal == models.OrderStatusInProgress && washComplete(progress, finalStage) {
+			next := models.OrderStatusReadyPickup
+			if state.IsDelivery {
+				next = models.OrderStatusReadyDelivery
+			}
+			if orderflow.ValidateTransition(actorRole, *state, next) == nil {
+				history := &models.StatusHistory{
+					OrderID:   state.ID,
+					NewStatus: next,
+					ActorID:   &actorID,
+					ActorRole: &actorRole,
+					Notes:     &note,
+					CreatedAt: now,
+				}
+				if err := s.statusChanger.changeTx(ctx, tx, state, history); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		orders = append(orders, mapWashBatchOrder(state, previous))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("washBatchService.FinishBatch.Commit: %w", err)
+	}
+
+	// 4. Mapping ke response
+	res, err := s.GetBatchDetail(ctx, batch.ID)
+	if err != nil {
+		return nil, err
+	}
+	res.Orders = orders
+	return res, nil
+}
+
+// GetBatches retrieves batches of the active outlet with pagination and filters.
+func (s *washBatchService) GetBatches(ctx context.Context, params listquery.Params) (*dto.WashBatchListResponse, error) {
+
+	// 1. Validasi filter tanggal (nilai lain dibandingkan langsung oleh MySQL)
+	for _, key := range []string{"start_date", "end_date"} {
+		if value, ok := params.Filters[key]; ok {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return nil, fmt.Errorf("%w: %s must use format YYYY-MM-DD", response.ErrValidation, key)
+			}
+		}
+	}
+
+	// 2. Call Repository
+	batches, page, err := s.washBatchRepo.FindBatches(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Map to DTO
+	batchResponses := make([]dto.WashBatchResponse, 0, len(batches))
+	for i := range batches {
+		batchResponses = append(batchResponses, *mapWashBatch(&batches[i]))
+	}
+
+	return &dto.WashBatchListResponse{
+		Data: batchResponses,
+		Meta: page.Meta(),
+	}, nil
+}
+
+// GetBatchDetail retrieves a batch of the active outlet with its items.
+func (s *washBatchService) GetBatchDetail(ctx context.Context, id int64) (*dto.WashBatchResponse, error) {
+
+	batch, err := s.washBatchRepo.FindBatchByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapWashBatch(batch), nil
+}
+
+// GetMachineUtilization reports, per machine, how long it ran compared with the outlet opening hours.
+//
+// Aturan:
+//   - busy_minutes = lama batch berjalan yang jatuh di dalam periode (batch berjalan dihitung sampai sekarang).
+//   - open_minutes = jam buka outlet di dalam periode, paling lambat sampai sekarang (hari libur tidak dihitung).
+//   - batch_count, load_kg, & avg_fill_percent hanya menghitung batch yang dimulai di dalam periode.
+//   - Mesin nonaktif hanya ditampilkan jika pernah dipakai dalam periode.
+func (s *washBatchService) GetMachineUtilization(ctx context.Context, startDate, endDate string) (*dto.MachineUtilizationReportResponse, error) {
+
+	// 1. Validasi rentang tanggal
+	start, endExclusive, err := parseReportRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	openUntil := endExclusive
+	if now.Before(openUntil) {
+		openUntil = now
+	}
+
+	// 2. Ambil mesin & batch di periode laporan
+	machines, err := s.capacityRepo.FindMachines(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	batches, err := s.washBatchRepo.FindBatchesInRange(ctx, start, endExclusive)
+	if err != nil {
+		return nil, err
+	}
+	batchesByMachine := make(map[int64][]models.WashBatch)
+	for _, b := range batches {
+		batchesByMachine[b.MachineID] = append(batchesByMachine[b.MachineID], b)
+	}
+
+	// 3. Hitung pemakaian per mesin (kalender dimuat sekali per outlet)
+	res := &dto.MachineUtilizationReportResponse{
+		Period:   dto.MachineUtilizationPeriod{StartDate: startDate, EndDate: endDate},
+		Machines: make([]dto.MachineUtilizationRowResponse, 0, len(machines)),
+	}
+	if outletID := outlet.FromContext(ctx); outletID != outlet.All {
+		res.OutletID = &outletID
+	}
+
+	calendars := make(map[int64]*capacity.Calendar)
+	for _, m := range machines {
+		machineBatches := batchesByMachine[m.ID]
+		if !m.IsActive && len(machineBatches) == 0 {
+			continue
+		}
+
+		calendar, ok := calendars[m.OutletID]
+		if !ok {
+			if calendar, err = s.loadCalendar(ctx, m.OutletID, start); err != nil {
+				return nil, err
+			}
+			calendars[m.OutletID] = calendar
+		}
+
+		row := dto.MachineUtilizationRowResponse{
+			MachineID:        m.ID,
+			OutletID:         m.OutletID,
+			MachineName:      m.MachineName,
+			MachineType:      m.MachineType,
+			IsActive:         m.IsActive,
+			UnderMaintenance: m.UnderMaintenance,
+		}
+		if start.Before(openUntil) {
+			row.OpenMinutes = int64(calendar.OpenDuration(start, openUntil) / time.Minute)
+		}
+
+		busy := time.Duration(0)
+		for _, b := range machineBatches {
+			from, to := b.StartedAt, now
+			if b.FinishedAt != nil {
+				to = *b.FinishedAt
+			}
+			if from.Before(start) {
+				from = start
+			}
+			if to.After(endExclusive) {
+				to = endExclusive
+			}
+			if from.Before(to) {
+				busy += to.Sub(from)
+			}
+
+			if !b.StartedAt.Before(start) {
+				row.BatchCount++
+				row.LoadKg += b.LoadKg
+			}
+		}
+		row.BusyMinutes = int64(busy / time.Minute)
+		row.LoadKg = math.Round(row.LoadKg*100) / 100
+
+		if row.OpenMinutes > 0 {
+			row.UtilizationPercent = math.Round(float64(row.BusyMinutes)/float64(row.OpenMinutes)*10000) / 100
+		}
+		if row.BatchCount > 0 && m.CapacityKg > 0 {
+			row.AvgFillPercent = math.Round(row.LoadKg/(float64(row.BatchCount)*m.CapacityKg)*10000) / 100
+		}
+
+		res.Machines = append(res.Machines, row)
+	}
+
+	return res, nil
+}
+
+// --- HELPER FUNCTION ---
+
+// resolveCodes menerjemahkan hasil scan menjadi item pesanan: kode tag lebih dulu, lalu nomor nota.
+func (s *washBatchService) resolveCodes(ctx context.Context, codes []string) ([]models.WashBatchCandidate, error) {
+
+	candidates := []models.WashBatchCandidate{}
+	seenCode := make(map[string]bool, len(codes))
+	seenItem := make(map[int64]bool)
+
+	for _, raw := range codes {
+		// Kode tidak case-sensitive agar aman dari scanner/keyboard HP
+		code := strings.ToUpper(strings.TrimSpace(raw))
+		if code == "" || seenCode[code] {
+			continue
+		}
+		seenCode[code] = true
+
+		found, err := s.washBatchRepo.FindCandidatesByTag(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			if found, err = s.washBatchRepo.FindCandidatesByInvoice(ctx, code); err != nil {
+				return nil, err
+			}
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("%w: code %s does not match any tag or invoice at this outlet", response.ErrValidation, code)
+		}
+
+		for _, c := range found {
+			if !seenItem[c.OrderItemID] {
+				seenItem[c.OrderItemID] = true
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: scan at least one tag or invoice", response.ErrValidation)
+	}
+	return candidates, nil
+}
+
+// itemLoadKg menghitung muatan satu item: berat untuk layanan kg, jumlah x beban per pcs untuk layanan pcs.
+func (s *washBatchService) itemLoadKg(unit string, quantity float64) float64 {
+	if unit == "kg" {
+		return quantity
+	}
+	return math.Round(quantity*s.pieceLoadKg*100) / 100
+}
+
+// loadCalendar memuat jam operasional & hari libur satu outlet mulai tanggal 'from'.
+func (s *washBatchService) loadCalendar(ctx context.Context, outletID int64, from time.Time) (*capacity.Calendar, error) {
+
+	hours, err := s.capacityRepo.FindOperatingHours(ctx, outletID)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := s.capacityRepo.FindHolidayDates(ctx, outletID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	// Jam operasional dibaca di zona bisnis (WIB), sama dengan kalkulator estimasi selesai
+	calendar, err := capacity.NewCalendar(hours, holidays, config.Location)
+	if err != nil {
+		return nil, fmt.Errorf("washBatchService.loadCalendar: %w", err)
+	}
+	return calendar, nil
+}
+
+// washComplete bernilai true jika setiap item pesanan sudah selesai di tahap akhir dan tidak ada yang masih di mesin.
+func washComplete(progress []models.WashItemProgress, finalStage string) bool {
+	if len(progress) == 0 {
+		return false
+	}
+	for _, p := range progress {
+		if p.InRunningBatch {
+			return false
+		}
+		if finalStage == models.MachineDryer && !p.FinishedDryer {
+			return false
+		}
+		if finalStage == models.MachineWasher && !p.FinishedWasher {
+			return false
+		}
+	}
+	return true
+}
+
+func mapWashBatchOrder(state *models.OrderState, previous string) dto.WashBatchOrderResponse {
+	return dto.WashBatchOrderResponse{
+		OrderID:        state.ID,
+		InvoiceNumber:  state.InvoiceNumber,
+		PreviousStatus: previous,
+		StatusInternal: state.StatusInternal,
+		StatusChanged:  state.StatusInternal != previous,
+	}
+}
+
+func mapWashBatch(b *models.WashBatch) *dto.WashBatchResponse {
+	res := &dto.WashBatchResponse{
+		ID:             b.ID,
+		OutletID:       b.OutletID,
+		MachineID:      b.MachineID,
+		MachineName:    b.MachineName,
+		MachineType:    b.MachineType,
+		BatchStatus:    b.BatchStatus,
+		LoadKg:         b.LoadKg,
+		ItemCount:      b.ItemCount,
+		Notes:          b.Notes,
+		StartedBy:      b.StartedBy,
+		StartedByName:  b.StartedByName,
+		StartedAt:      b.StartedAt.Format("2006-01-02 15:04:05"),
+		FinishedBy:     b.FinishedBy,
+		FinishedByName: b.FinishedByName,
+		FinishedAt:     formatTimePtr(b.FinishedAt),
+	}
+	if b.FinishedAt != nil {
+		minutes := int64(b.FinishedAt.Sub(b.StartedAt) / time.Minute)
+		res.DurationMinutes = &minutes
+	}
+
+	if len(b.Items) > 0 {
+		res.Items = make([]dto.WashBatchItemResponse, 0, len(b.Items))
+		for _, item := range b.Items {
+			res.Items = append(res.Items, dto.WashBatchItemResponse{
+				OrderID:       item.OrderID,
+				InvoiceNumber: item.InvoiceNumber,
+				OrderItemID:   item.OrderItemID,
+				TagCode:       item.TagCode,
+				ServiceName:   item.ServiceName,
+				Unit:          item.Unit,
+				Quantity:      item.Quantity,
+				LoadKg:        item.LoadKg,
+			})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/models"
+)
+
+func TestLoadCalendarUsesBusinessTimeZone(t *testing.T) {
+
+	// Server berjalan di UTC; jam operasional tetap dibaca sebagai jam WIB
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = time.UTC
+
+	open := make([]models.OperatingHour, 0, 7)
+	for d := 0; d <= 6; d++ {
+		open = append(open, models.OperatingHour{Weekday: d, OpenTime: "08:00", CloseTime: "17:00"})
+	}
+	svc := &washBatchService{capacityRepo: &fakeCapacityRepo{hours: open}}
+
+	day := time.Date(2026, 1, 5, 0, 0, 0, 0, config.Location)
+	calendar, err := svc.loadCalendar(context.Background(), 1, day)
+	if err != nil {
+		t.Fatalf("loadCalendar: %v", err)
+	}
+
+	// 08:00-12:00 WIB seluruhnya di dalam jam buka
+	from, to := day.Add(8*time.Hour), day.Add(12*time.Hour)
+	if got := calendar.OpenDuration(from, to); got != 4*time.Hour {
+		t.Fatalf("OpenDuration(08:00-12:00 WIB) = %s, want 4h0m0s", got)
+	}
+}
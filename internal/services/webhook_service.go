@@ -0,0 +1,499 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"laundry-backend/internal/config"
+	"laundry-backend/internal/dto"
+	"laundry-backend/internal/models"
+	"laundry-backend/internal/repositories"
+	"laundry-backend/internal/webhook"
+	"laundry-backend/pkg/response"
+	"laundry-backend/pkg/utils"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pengaturan worker webhook
+const (
+	webhookLease    = 2 * time.Minute // Lama pengiriman 'processing' dipegang satu worker sebelum boleh diambil ulang
+	webhookMaxDelay = 12 * time.Hour  // Batas atas jeda retry
+)
+
+// WebhookService defines the contract for outgoing webhook endpoints, their delivery log and the delivery worker.
+type WebhookService interface {
+	CreateEndpoint(ctx context.Context, req dto.CreateWebhookRequest, actorID int64) (*dto.WebhookSecretResponse, error)
+	GetEndpoints(ctx context.Context) ([]dto.WebhookEndpointResponse, error)
+	GetEndpointDetail(ctx context.Context, id int64) (*dto.WebhookEndpointResponse, error)
+	UpdateEndpoint(ctx context.Context, id int64, req dto.UpdateWebhookRequest) (*dto.WebhookEndpointResponse, error)
+	DeleteEndpoint(ctx context.Context, id int64) error
+	RotateSecret(ctx context.Context, id int64) (*dto.WebhookSecretResponse, error)
+
+	GetDeliveries(ctx context.Context, endpointID int64, query dto.WebhookDeliveryQuery, page, perPage int) (*dto.WebhookDeliveryListResponse, error)
+	GetDeliveryDetail(ctx context.Context, endpointID, deliveryID int64) (*dto.WebhookDeliveryResponse, error)
+
+	// ReplayDelivery mengantrekan ulang payload yang sama (event_id sama) sebagai pengiriman baru.
+	ReplayDelivery(ctx context.Context, endpointID, deliveryID int64) (*dto.WebhookDeliveryResponse, error)
+
+	// --- Hook untuk transaksi pesanan/pembayaran (dipanggil di dalam tx milik pemanggil) ---
+
+	// PublishTx menulis satu pengiriman untuk setiap endpoint aktif yang mendengarkan event tersebut.
+	PublishTx(ctx context.Context, tx *sql.Tx, eventType string, data interface{}) error
+
+	// --- Worker ---
+
+	// DispatchDue mengirim satu batch pengiriman yang jatuh tempo dan mengembalikan jumlah yang diproses.
+	DispatchDue(ctx context.Context) (int, error)
+
+	// RunWorker menjalankan DispatchDue berkala sampai ctx dibatalkan.
+	RunWorker(ctx context.Context)
+}
+
+type webhookService struct {
+	webhookRepo repositories.WebhookRepository
+	sender      *webhook.Sender
+	cfg         *config.Config
+}
+
+// NewWebhookService creates a new instance of WebhookService.
+func NewWebhookService(webhookRepo repositories.WebhookRepository, cfg *config.Config) WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		sender:      webhook.NewSender(time.Duration(cfg.WEBHOOK.TimeoutSec) * time.Second),
+		cfg:         cfg,
+	}
+}
+
+// CreateEndpoint registers a new endpoint and returns its signing secret (shown only once).
+func (s *webhookService) CreateEndpoint(ctx context.Context, req dto.CreateWebhookRequest, actorID int64) (*dto.WebhookSecretResponse, error) {
+
+	// 1. Validasi URL tujuan
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	// 2. Buat secret tanda tangan
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Simpan endpoint beserta daftar event
+	endpoint := &models.WebhookEndpoint{
+		URL:         strings.TrimSpace(req.URL),
+		Description: req.Description,
+		Secret:      secret,
+		Events:      uniqueEvents(req.Events),
+		IsActive:    true,
+		CreatedBy:   &actorID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.webhookRepo.InsertEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	return &dto.WebhookSecretResponse{
+		WebhookEndpointResponse: mapToWebhookEndpointResponse(*endpoint),
+		Secret:                  secret,
+	}, nil
+}
+
+// GetEndpoints retrieves every registered endpoint.
+func (s *webhookService) GetEndpoints(ctx context.Context) ([]dto.WebhookEndpointResponse, error) {
+
+	endpoints, err := s.webhookRepo.FindEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.WebhookEndpointResponse, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		res = append(res, mapToWebhookEndpointResponse(endpoint))
+	}
+
+	return res, nil
+}
+
+// GetEndpointDetail retrieves a single endpoint.
+func (s *webhookService) GetEndpointDetail(ctx context.Context, id int64) (*dto.WebhookEndpointResponse, error) {
+
+	endpoint, err := s.webhookRepo.FindEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := mapToWebhookEndpointResponse(*endpoint)
+	return &res, nil
+}
+
+// UpdateEndpoint changes the URL, description, events or active flag of an endpoint.
+func (s *webhookService) UpdateEndpoint(ctx context.Context, id int64, req dto.UpdateWebhookRequest) (*dto.WebhookEndpointResponse, error) {
+
+	// 1. Ambil data lama
+	endpoint, err := s.webhookRepo.FindEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Terapkan perubahan (Partial Update)
+	if req.URL != nil {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		endpoint.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Description != nil {
+		endpoint.Description = req.Description
+	}
+	if req.Events != nil {
+		endpoint.Events = uniqueEvents(req.Events)
+	}
+	if req.IsActive != nil {
+		endpoint.IsActive = *req.IsActive
+	}
+	now := time.Now()
+	endpoint.UpdatedAt = &now
+
+	// 3. Simpan
+	if err := s.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	res := mapToWebhookEndpointResponse(*endpoint)
+	return &res, nil
+}
+
+// DeleteEndpoint removes an endpoint together with its delivery log.
+func (s *webhookService) DeleteEndpoint(ctx context.Context, id int64) error {
+	return s.webhookRepo.DeleteEndpoint(ctx, id)
+}
+
+// RotateSecret replaces the signing secret. Deliveries sent after this use the new secret.
+func (s *webhookService) RotateSecret(ctx context.Context, id int64) (*dto.WebhookSecretResponse, error) {
+
+	endpoint, err := s.webhookRepo.FindEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	endpoint.Secret = secret
+	endpoint.UpdatedAt = &now
+
+	if err := s.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	return &dto.WebhookSecretResponse{
+		WebhookEndpointResponse: mapToWebhookEndpointResponse(*endpoint),
+		Secret:                  secret,
+	}, nil
+}
+
+// GetDeliveries retrieves the delivery log of an endpoint with pagination.
+func (s *webhookService) GetDeliveries(ctx context.Context, endpointID int64, query dto.WebhookDeliveryQuery, page, perPage int) (*dto.WebhookDeliveryListResponse, error) {
+
+	// 1. Pastikan endpoint ada
+	if _, err := s.webhookRepo.FindEndpointByID(ctx, endpointID); err != nil {
+		return nil, err
+	}
+
+	// 2. Ambil log
+	offset := (page - 1) * perPage
+	deliveries, totalItems, err := s.webhookRepo.FindDeliveries(ctx, endpointID, query.Status, perPage, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.WebhookDeliveryListResponse{
+		Data: make([]dto.WebhookDeliveryResponse, 0, len(deliveries)),
+		Meta: response.NewPageMeta(page, perPage, totalItems),
+	}
+	for _, delivery := range deliveries {
+		res.Data = append(res.Data, mapToWebhookDeliveryResponse(delivery, false))
+	}
+
+	return res, nil
+}
+
+// GetDeliveryDetail retrieves one delivery with the exact payload that was sent.
+func (s *webhookService) GetDeliveryDetail(ctx context.Context, endpointID, deliveryID int64) (*dto.WebhookDeliveryResponse, error) {
+
+	delivery, err := s.findEndpointDelivery(ctx, endpointID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := mapToWebhookDeliveryResponse(*delivery, true)
+	return &res, nil
+}
+
+// ReplayDelivery queues the same payload again as a new delivery to the same endpoint.
+func (s *webhookService) ReplayDelivery(ctx context.Context, endpointID, deliveryID int64) (*dto.WebhookDeliveryResponse, error) {
+
+	// 1. Ambil pengiriman asal
+	original, err := s.findEndpointDelivery(ctx, endpointID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Salin payload & event_id (penerima bisa dedup berdasarkan event_id)
+	now := time.Now()
+	replay := &models.WebhookDelivery{
+		EndpointID:    original.EndpointID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		NextAttemptAt: now,
+		ReplayOf:      &original.ID,
+		CreatedAt:     now,
+	}
+	if err := s.webhookRepo.InsertDelivery(ctx, replay); err != nil {
+		return nil, err
+	}
+
+	res := mapToWebhookDeliveryResponse(*replay, false)
+	return &res, nil
+}
+
+// PublishTx snapshots the event body and queues one delivery per subscribed endpoint inside the caller's transaction.
+func (s *webhookService) PublishTx(ctx context.Context, tx *sql.Tx, eventType string, data interface{}) error {
+
+	if !slices.Contains(webhook.Events, eventType) {
+		return fmt.Errorf("webhookService.PublishTx: unknown event %q", eventType)
+	}
+
+	// 1. Cari endpoint yang mendengarkan event ini
+	endpointIDs, err := s.webhookRepo.FindSubscriberIDsTx(ctx, tx, eventType)
+	if err != nil {
+		return err
+	}
+	if len(endpointIDs) == 0 {
+		return nil
+	}
+
+	// 2. Susun body sekali, dipakai semua endpoint
+	now := time.Now()
+	eventID := uuid.New().String()
+	body, err := webhook.NewEnvelope(eventID, eventType, now, data)
+	if err != nil {
+		return err
+	}
+
+	// 3. Antrekan pengiriman
+	for _, endpointID := range endpointIDs {
+		delivery := &models.WebhookDelivery{
+			EndpointID:    endpointID,
+			EventID:       eventID,
+			EventType:     eventType,
+			Payload:       body,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		if err := s.webhookRepo.InsertDeliveryTx(ctx, tx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DispatchDue claims one batch of due deliveries and posts them to their endpoints.
+func (s *webhookService) DispatchDue(ctx context.Context) (int, error) {
+
+	// 1. Ambil pengiriman yang jatuh tempo
+	now := time.Now()
+	jobs, err := s.webhookRepo.ClaimDue(ctx, now, now.Add(webhookLease), s.batchSize())
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. Kirim satu per satu; kegagalan satu pengiriman tidak menghentikan batch
+	for _, job := range jobs {
+		if err := s.deliver(ctx, job); err != nil {
+			fmt.Printf("[ERROR] WebhookWorker #%d: %v\n", job.ID, err)
+		}
+	}
+
+	return len(jobs), nil
+}
+
+// RunWorker polls the delivery queue until ctx is cancelled. A full batch is followed immediately by the next one.
+func (s *webhookService) RunWorker(ctx context.Context) {
+	interval := time.Duration(s.cfg.WEBHOOK.PollIntervalSec) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			processed, err := s.DispatchDue(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Printf("[ERROR] WebhookWorker: %v\n", err)
+				}
+				break
+			}
+			if processed < s.batchSize() {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliver posts one delivery and records the outcome.
+//
+// Hasil akhir:
+//   - balasan 2xx → delivered
+//   - endpoint nonaktif atau batas percobaan habis → failed (bisa di-replay)
+//   - selain itu → pending lagi dengan jeda Backoff
+func (s *webhookService) deliver(ctx context.Context, job models.WebhookJob) error {
+	delivery := job.WebhookDelivery
+
+	// 1. Endpoint dinonaktifkan setelah event diantrekan
+	if !job.EndpointActive {
+		reason := "endpoint is inactive"
+		delivery.Status = models.WebhookFailed
+		delivery.LastError = &reason
+		return s.webhookRepo.SaveAttempt(ctx, &delivery)
+	}
+
+	// 2. Kirim dengan tanda tangan
+	result := s.sender.Send(ctx, webhook.Request{
+		URL:        job.URL,
+		Secret:     job.Secret,
+		DeliveryID: delivery.ID,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Body:       delivery.Payload,
+	})
+
+	// 3. Catat balasan percobaan ini
+	durationMs := int(result.Duration.Milliseconds())
+	delivery.DurationMs = &durationMs
+	delivery.ResponseStatus = nil
+	if result.StatusCode != 0 {
+		delivery.ResponseStatus = &result.StatusCode
+	}
+	delivery.ResponseBody = &result.Body
+
+	// 4. Tentukan status berikutnya
+	now := time.Now()
+	switch {
+	case result.Err == nil:
+		delivery.Status = models.WebhookDelivered
+		delivery.LastError = nil
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.cfg.WEBHOOK.MaxAttempts:
+		reason := result.Err.Error()
+		delivery.Status = models.WebhookFailed
+		delivery.LastError = &reason
+	default:
+		reason := result.Err.Error()
+		base := time.Duration(s.cfg.WEBHOOK.RetryBaseSec) * time.Second
+		delivery.Status = models.WebhookPending
+		delivery.LastError = &reason
+		delivery.NextAttemptAt = now.Add(utils.Backoff(base, delivery.Attempts, webhookMaxDelay))
+	}
+
+	return s.webhookRepo.SaveAttempt(ctx, &delivery)
+}
+
+// findEndpointDelivery memastikan pengiriman memang milik endpoint pada URL.
+func (s *webhookService) findEndpointDelivery(ctx context.Context, endpointID, deliveryID int64) (*models.WebhookDelivery, error) {
+	delivery, err := s.webhookRepo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.EndpointID != endpointID {
+		return nil, response.ErrNotFound
+	}
+	return delivery, nil
+}
+
+func (s *webhookService) batchSize() int {
+	if s.cfg.WEBHOOK.BatchSize < 1 {
+		return 1
+	}
+	return s.cfg.WEBHOOK.BatchSize
+}
+
+// validateWebhookURL hanya menerima URL http/https absolut.
+func validateWebhookURL(raw string) error {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", response.ErrValidation)
+	}
+	return nil
+}
+
+// uniqueEvents membuang duplikat sambil menjaga urutan kiriman.
+func uniqueEvents(events []string) []string {
+	unique := make([]string, 0, len(events))
+	for _, event := range events {
+		if !slices.Contains(unique, event) {
+			unique = append(unique, event)
+		}
+	}
+	return unique
+}
+
+func mapToWebhookEndpointResponse(endpoint models.WebhookEndpoint) dto.WebhookEndpointResponse {
+	hint := endpoint.Secret
+	if len(hint) > 4 {
+		hint = "…" + hint[len(hint)-4:]
+	}
+
+	return dto.WebhookEndpointResponse{
+		ID:          endpoint.ID,
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		Events:      endpoint.Events,
+		IsActive:    endpoint.IsActive,
+		SecretHint:  hint,
+		CreatedBy:   endpoint.CreatedBy,
+		CreatedAt:   endpoint.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   formatTimePtr(endpoint.UpdatedAt),
+	}
+}
+
+func mapToWebhookDeliveryResponse(delivery models.WebhookDelivery, withPayload bool) dto.WebhookDeliveryResponse {
+	res := dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt.Format("2006-01-02 15:04:05"),
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		DurationMs:     delivery.DurationMs,
+		DeliveredAt:    formatTimePtr(delivery.DeliveredAt),
+		ReplayOf:       delivery.ReplayOf,
+		CreatedAt:      delivery.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if withPayload {
+		res.Payload = delivery.Payload
+	}
+	return res
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalStorage menyimpan file di disk server dan membagikannya lewat endpoint API
+// GET {baseURL}/{key}?expires={unix}&signature={hmac}.
+type LocalStorage struct {
+	root    string
+	baseURL string
+	secret  []byte
+	now     func() time.Time
+}
+
+// NewLocalStorage creates a Storage on the local disk, creating the root folder if needed.
+func NewLocalStorage(root, baseURL, secret string) (*LocalStorage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("local.New: %w", err)
+	}
+	return &LocalStorage{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  []byte(secret),
+		now:     time.Now,
+	}, nil
+}
+
+func (s *LocalStorage) Driver() string { return DriverLocal }
+
+// Put writes the file atomically (temporary file + rename), so readers never see half-written photos.
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("local.Put.Mkdir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("local.Put.CreateTemp: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op setelah rename berhasil
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("local.Put.Write: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("local.Put.Close: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("local.Put.Chmod: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("local.Put.Rename: %w", err)
+	}
+	return nil
+}
+
+// Get opens the file for reading. The caller must close it.
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("local.Get: %w", err)
+	}
+	return f, nil
+}
+
+// Delete removes the file. Missing files are not an error.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("local.Delete: %w", err)
+	}
+	return nil
+}
+
+// SignedURL returns an API URL for the file that expires after ttl.
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	expires := s.now().Add(clampTTL(ttl)).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(key, expires))
+
+	return s.baseURL + "/" + escapeKey(key) + "?" + query.Encode(), nil
+}
+
+// VerifySignature checks a signature produced by SignedURL and rejects expired URLs.
+func (s *LocalStorage) VerifySignature(key string, expires int64, signature string) error {
+	if validateKey(key) != nil || s.now().Unix() > expires {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// --- HELPER FUNCTION ---
+
+func (s *LocalStorage) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
+
+// sign = hex(HMAC-SHA256(secret, key + "\n" + expires))
+func (s *LocalStorage) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// escapeKey meng-encode setiap segmen key tanpa mengubah pemisah '/'.
+func escapeKey(key string) string {
+	parts := strings.Split(key, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocal(t *testing.T, now time.Time) *LocalStorage {
+	t.Helper()
+	s, err := NewLocalStorage(t.TempDir(), "https://api.example.com/api/v1/media/files/", "secret")
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestValidateKey(t *testing.T) {
+
+	for _, key := range []string{"delivery/12/a.jpg", "a.jpg", "customer/..hidden/b.png"} {
+		if err := validateKey(key); err != nil {
+			t.Errorf("validateKey(%q) = %v, want nil", key, err)
+		}
+	}
+	for _, key := range []string{"", "/etc/passwd", "../etc/passwd", "delivery/../../x", "delivery//x", "delivery/./x", `delivery\x`, "delivery/"} {
+		if err := validateKey(key); err == nil {
+			t.Errorf("validateKey(%q) = nil, want error", key)
+		}
+	}
+}
+
+func TestClampTTL(t *testing.T) {
+
+	tests := map[time.Duration]time.Duration{
+		0:                   15 * time.Minute,
+		-time.Hour:          15 * time.Minute,
+		time.Hour:           time.Hour,
+		30 * 24 * time.Hour: maxSignedURLTTL,
+	}
+	for ttl, want := range tests {
+		if got := clampTTL(ttl); got != want {
+			t.Errorf("clampTTL(%v) = %v, want %v", ttl, got, want)
+		}
+	}
+}
+
+func TestLocalPutGetDelete(t *testing.T) {
+
+	ctx := context.Background()
+	s := newTestLocal(t, time.Now())
+
+	if err := s.Put(ctx, "delivery/12/photo.jpg", []byte("jpeg-bytes"), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	rc, err := s.Get(ctx, "delivery/12/photo.jpg")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "jpeg-bytes" {
+		t.Fatalf("Get = %q", data)
+	}
+
+	if err := s.Delete(ctx, "delivery/12/photo.jpg"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete(ctx, "delivery/12/photo.jpg"); err != nil {
+		t.Fatalf("Delete missing file: %v", err)
+	}
+	if _, err := s.Get(ctx, "delivery/12/photo.jpg"); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("Get after delete error = %v, want ErrObjectNotFound", err)
+	}
+	if err := s.Put(ctx, "../escape.jpg", []byte("x"), "image/jpeg"); err == nil {
+		t.Fatal("Put outside root succeeded, want error")
+	}
+}
+
+func TestLocalSignedURL(t *testing.T) {
+
+	now := time.Unix(1767600000, 0)
+	s := newTestLocal(t, now)
+	key := "customer/7/foto baju.jpg"
+
+	signed, err := s.SignedURL(context.Background(), key, time.Hour)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	if !strings.HasPrefix(signed, "https://api.example.com/api/v1/media/files/customer/7/foto%20baju.jpg?") {
+		t.Fatalf("SignedURL = %s", signed)
+	}
+
+	u, _ := url.Parse(signed)
+	expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	signature := u.Query().Get("signature")
+	if expires != now.Add(time.Hour).Unix() {
+		t.Fatalf("expires = %d, want %d", expires, now.Add(time.Hour).Unix())
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		expires   int64
+		signature string
+		at        time.Time
+		wantErr   bool
+	}{
+		{name: "valid", key: key, expires: expires, signature: signature, at: now},
+		{name: "valid at the last second", key: key, expires: expires, signature: signature, at: now.Add(time.Hour)},
+		{name: "expired", key: key, expires: expires, signature: signature, at: now.Add(time.Hour + time.Second), wantErr: true},
+		{name: "other key", key: "customer/8/foto baju.jpg", expires: expires, signature: signature, at: now, wantErr: true},
+		{name: "extended expiry", key: key, expires: expires + 3600, signature: signature, at: now, wantErr: true},
+		{name: "tampered signature", key: key, expires: expires, signature: strings.Repeat("0", len(signature)), at: now, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.now = func() time.Time { return tt.at }
+			err := s.VerifySignature(tt.key, tt.expires, tt.signature)
+			if tt.wantErr != errors.Is(err, ErrInvalidSignature) || (!tt.wantErr && err != nil) {
+				t.Fatalf("VerifySignature error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
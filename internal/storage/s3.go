@@ -0,0 +1,300 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// s3Timeout membatasi lama satu request ke object storage.
+const s3Timeout = 30 * time.Second
+
+// unsignedPayload dipakai presigned URL (isi body tidak ikut ditandatangani).
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Storage menyimpan file di bucket S3-compatible (AWS S3, MinIO, R2, dll).
+// Request ditandatangani dengan AWS Signature Version 4 tanpa SDK:
+//
+//	PUT    {endpoint}/{bucket}/{key}   (path-style)   atau   {bucket}.{host}/{key} (virtual-hosted)
+//	GET    ... (presigned: X-Amz-Algorithm, X-Amz-Credential, X-Amz-Date, X-Amz-Expires, X-Amz-Signature)
+//	DELETE ...
+type S3Storage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewS3Storage creates a Storage for an S3-compatible bucket.
+func NewS3Storage(endpoint, region, bucket, accessKey, secretKey string, pathStyle bool) (*S3Storage, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("s3.New: invalid endpoint %q", endpoint)
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &S3Storage{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		pathStyle: pathStyle,
+		client:    &http.Client{Timeout: s3Timeout},
+		now:       time.Now,
+	}, nil
+}
+
+func (s *S3Storage) Driver() string { return DriverS3 }
+
+// Put uploads the object with its SHA-256 payload hash signed.
+func (s *S3Storage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("s3.Put.Request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	sum := sha256.Sum256(data)
+	s.signRequest(req, hex.EncodeToString(sum[:]), s.now())
+
+	resp, err := s.do(req, "Put")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get downloads the object. The caller must close it.
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("s3.Get.Request: %w", err)
+	}
+	s.signRequest(req, emptyPayloadHash(), s.now())
+
+	resp, err := s.do(req, "Get")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the object. S3 answers 204 for missing objects too.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return fmt.Errorf("s3.Delete.Request: %w", err)
+	}
+	s.signRequest(req, emptyPayloadHash(), s.now())
+
+	resp, err := s.do(req, "Delete")
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// SignedURL returns a presigned GET URL (query-string SigV4) that expires after ttl.
+func (s *S3Storage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return s.presign(http.MethodGet, key, clampTTL(ttl), s.now()), nil
+}
+
+// --- SIGNATURE V4 ---
+
+// signRequest menambahkan header x-amz-date, x-amz-content-sha256 & Authorization.
+// Semua header yang sudah ada di request (ditambah host) ikut ditandatangani.
+func (s *S3Storage) signRequest(req *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// 1. Header kanonik (nama lowercase, urut abjad)
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	// 2. Canonical request -> string to sign -> signature
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := s.scope(now)
+	signature := s.signature(now, amzDate, scope, canonicalRequest)
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+// presign membuat URL dengan tanda tangan di query string (hanya header host yang ditandatangani).
+func (s *S3Storage) presign(method, key string, ttl time.Duration, now time.Time) string {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.scope(now)
+
+	u, _ := url.Parse(s.objectURL(key))
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", s.accessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalURI(u),
+		canonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+	signature := s.signature(now, amzDate, scope, canonicalRequest)
+
+	return u.Scheme + "://" + u.Host + canonicalURI(u) + "?" + canonicalQuery(query) + "&X-Amz-Signature=" + signature
+}
+
+// signature = hex(HMAC(signingKey, stringToSign)), signingKey diturunkan dari secret/tanggal/region/service.
+func (s *S3Storage) signature(now time.Time, amzDate, scope, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func (s *S3Storage) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+// --- HELPER FUNCTION ---
+
+// objectURL menyusun URL objek path-style atau virtual-hosted.
+func (s *S3Storage) objectURL(key string) string {
+	if s.pathStyle {
+		return s.endpoint.Scheme + "://" + s.endpoint.Host + "/" + s.bucket + "/" + uriEncode(key, false)
+	}
+	return s.endpoint.Scheme + "://" + s.bucket + "." + s.endpoint.Host + "/" + uriEncode(key, false)
+}
+
+// do mengirim request dan mengubah status non-2xx menjadi error.
+func (s *S3Storage) do(req *http.Request, op string) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3.%s: %w", op, err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrObjectNotFound
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return nil, fmt.Errorf("s3.%s: status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func canonicalURI(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// canonicalQuery meng-encode & mengurutkan parameter query sesuai aturan SigV4.
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode meng-encode semua karakter selain A-Z a-z 0-9 - _ . ~ (dan '/' jika encodeSlash = false).
+func uriEncode(value string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func emptyPayloadHash() string {
+	sum := sha256.Sum256(nil)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,94 @@
+// Package storage menyimpan file media (foto) di tempat yang bisa diganti lewat MEDIA_DRIVER:
+// disk lokal server, atau object storage S3-compatible (AWS S3, MinIO, Cloudflare R2, dll).
+//
+// Klien tidak pernah menerima path / key mentah: file dibagikan lewat URL bertanda tangan
+// yang kedaluwarsa (SignedURL), sehingga foto bukti antar & foto pelanggan tidak bisa ditebak.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"laundry-backend/internal/config"
+)
+
+// Nama driver (MEDIA_DRIVER)
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// Batas masa berlaku URL bertanda tangan (batas AWS SigV4 presigned URL = 7 hari)
+const maxSignedURLTTL = 7 * 24 * time.Hour
+
+var (
+	// ErrObjectNotFound menandai key yang tidak ada di storage.
+	ErrObjectNotFound = errors.New("storage object not found")
+
+	// ErrInvalidSignature menandai URL bertanda tangan yang salah atau sudah kedaluwarsa.
+	ErrInvalidSignature = errors.New("invalid or expired signature")
+)
+
+// Storage adalah tempat penyimpanan file media.
+// Key berupa path relatif dipisah '/', cth: delivery/12/5b0c...e1.jpg
+type Storage interface {
+	Driver() string
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL mengembalikan URL baca file yang berlaku selama ttl.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// SignatureVerifier diimplementasikan driver yang file-nya dilayani sendiri oleh API (driver local).
+// Driver S3 tidak membutuhkannya karena URL bertanda tangan langsung menuju bucket.
+type SignatureVerifier interface {
+	VerifySignature(key string, expires int64, signature string) error
+}
+
+// New membuat Storage sesuai MEDIA_DRIVER.
+func New(cfg config.MediaConfig) (Storage, error) {
+	switch cfg.Driver {
+	case DriverLocal, "":
+		if cfg.SigningSecret == "" {
+			return nil, fmt.Errorf("storage: MEDIA_SIGNING_SECRET (or JWT_SECRET) is required for driver %q", DriverLocal)
+		}
+		return NewLocalStorage(cfg.LocalDir, cfg.PublicBaseURL, cfg.SigningSecret)
+	case DriverS3:
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" || cfg.S3AccessKey == "" || cfg.S3SecretKey == "" {
+			return nil, fmt.Errorf("storage: MEDIA_S3_ENDPOINT, MEDIA_S3_BUCKET, MEDIA_S3_ACCESS_KEY and MEDIA_S3_SECRET_KEY are required for driver %q", DriverS3)
+		}
+		return NewS3Storage(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3PathStyle)
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", cfg.Driver)
+	}
+}
+
+// validateKey menolak key kosong, absolut, atau yang keluar dari root (cth: "../etc/passwd").
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("storage: invalid key %q", key)
+		}
+	}
+	return nil
+}
+
+// clampTTL membatasi masa berlaku URL ke rentang (0, 7 hari].
+func clampTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return 15 * time.Minute
+	}
+	if ttl > maxSignedURLTTL {
+		return maxSignedURLTTL
+	}
+	return ttl
+}
@@ -0,0 +1,201 @@
+package tax
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+)
+
+// ErrDiscountExceedsSubtotal dikembalikan jika total diskon lebih besar dari subtotal keranjang.
+var ErrDiscountExceedsSubtotal = errors.New("discount total exceeds subtotal")
+
+// hundredPercent adalah 100% dalam satuan money.Percent.
+const hundredPercent = 100 * 100
+
+// Line adalah satu baris keranjang yang sudah dihitung harganya (termasuk add-on, sebelum diskon).
+type Line struct {
+	ServiceID  int64
+	CategoryID int64
+	Amount     money.Amount
+}
+
+// Charge adalah total satu tarif (pajak atau service charge) untuk seluruh pesanan.
+type Charge struct {
+	TaxRateID   int64         `json:"tax_rate_id"`
+	Code        string        `json:"code"`
+	TaxName     string        `json:"tax_name"`
+	TaxKind     string        `json:"tax_kind"`
+	Rate        money.Percent `json:"rate"`
+	PriceMode   string        `json:"price_mode"`
+	TaxableBase money.Amount  `json:"taxable_base"` // DPP: nilai item setelah diskon, di luar pajak inclusive
+	Amount      money.Amount  `json:"amount"`
+}
+
+// Summary adalah rincian total satu pesanan.
+//
+// Rekonsiliasi yang selalu berlaku (eksak sampai sen):
+//
+//	GrandTotal = Subtotal - DiscountTotal + SUM(Charge.Amount dengan mode exclusive)
+//	ServiceChargeTotal + TaxTotal = SUM(Charge.Amount)
+//	NetRevenue = GrandTotal - TaxTotal
+type Summary struct {
+	Subtotal           money.Amount `json:"subtotal"`
+	DiscountTotal      money.Amount `json:"discount_total"`
+	Charges            []Charge     `json:"charges"`
+	ServiceChargeTotal money.Amount `json:"service_charge_total"`
+	TaxTotal           money.Amount `json:"tax_total"`
+	GrandTotal         money.Amount `json:"grand_total"`
+	NetRevenue         money.Amount `json:"net_revenue"` // Pendapatan bersih outlet (di luar pajak yang disetor)
+}
+
+// Applies memeriksa apakah satu tarif dikenakan pada satu baris keranjang.
+//
+// Urutan penentuan (deterministik):
+//  1. baris target layanan yang cocok menentukan hasil (is_exempt = tidak dikenakan).
+//  2. jika tidak ada, baris target kategori yang cocok menentukan hasil.
+//  3. jika tidak ada keduanya, tarif berlaku hanya jika scope_type = 'all'.
+func Applies(rate models.TaxRateWithTargets, line Line) bool {
+	if !rate.IsActive {
+		return false
+	}
+
+	var categoryMatch *models.TaxRateTarget
+	for i, target := range rate.Targets {
+		switch {
+		case target.TargetType == models.TaxTargetService && target.TargetID == line.ServiceID:
+			return !target.IsExempt
+		case target.TargetType == models.TaxTargetCategory && target.TargetID == line.CategoryID:
+			categoryMatch = &rate.Targets[i]
+		}
+	}
+	if categoryMatch != nil {
+		return !categoryMatch.IsExempt
+	}
+
+	return rate.ScopeType == models.TaxScopeAll
+}
+
+// Calculate menghitung service charge, pajak, dan total akhir sebuah pesanan.
+//
+// Langkah perhitungan:
+//  1. discountTotal dibagi ke setiap baris secara proporsional (money.Allocate) sehingga
+//     pajak dihitung dari nilai setelah diskon.
+//  2. baris dikelompokkan berdasarkan kombinasi tarif yang berlaku, lalu nilainya dijumlahkan.
+//  3. per kelompok, tarif inclusive diekstrak dari harga: DPP = nilai x 100 / (100 + total tarif inclusive).
+//     Sisa pembulatan diberikan ke tarif inclusive terakhir agar DPP + pajak = nilai kelompok.
+//  4. tarif exclusive dihitung SEKALI dari total DPP seluruh kelompok (pembulatan HalfUp ke sen).
+//
+// Service charge dan pajak sama-sama dihitung dari DPP (service charge tidak ikut dikenai pajak).
+func Calculate(lines []Line, discountTotal money.Amount, rates []models.TaxRateWithTargets) (*Summary, error) {
+
+	// 1. Hitung subtotal & bagi diskon ke setiap baris
+	amounts := make([]money.Amount, len(lines))
+	for i, line := range lines {
+		amounts[i] = line.Amount
+	}
+	subtotal := money.Sum(amounts...)
+	if discountTotal > subtotal {
+		return nil, ErrDiscountExceedsSubtotal
+	}
+	shares := money.Allocate(discountTotal, amounts)
+
+	// 2. Kelompokkan baris berdasarkan kombinasi tarif yang berlaku
+	type group struct {
+		rateIndexes []int
+		gross       money.Amount
+	}
+	var groups []*group
+	groupByKey := make(map[string]*group)
+	for i, line := range lines {
+		var indexes []int
+		var keyParts []string
+		for r, rate := range rates {
+			if Applies(rate, line) {
+				indexes = append(indexes, r)
+				keyParts = append(keyParts, strconv.Itoa(r))
+			}
+		}
+
+		key := strings.Join(keyParts, ",")
+		g, ok := groupByKey[key]
+		if !ok {
+			g = &group{rateIndexes: indexes}
+			groupByKey[key] = g
+			groups = append(groups, g)
+		}
+		g.gross = g.gross.Add(line.Amount.Sub(shares[i]))
+	}
+
+	charges := make([]Charge, len(rates))
+	for r, rate := range rates {
+		charges[r] = Charge{
+			TaxRateID: rate.ID,
+			Code:      rate.Code,
+			TaxName:   rate.TaxName,
+			TaxKind:   rate.TaxKind,
+			Rate:      rate.Rate,
+			PriceMode: rate.PriceMode,
+		}
+	}
+
+	// 3. Ekstrak tarif inclusive per kelompok
+	for _, g := range groups {
+		var inclusive []int
+		var inclusiveRate money.Percent
+		for _, r := range g.rateIndexes {
+			if rates[r].PriceMode == models.TaxModeInclusive {
+				inclusive = append(inclusive, r)
+				inclusiveRate += rates[r].Rate
+			}
+		}
+
+		base := g.gross
+		if inclusiveRate > 0 {
+			base = g.gross.MulRatio(hundredPercent, int64(hundredPercent+inclusiveRate), money.HalfUp)
+		}
+
+		extracted := money.Zero
+		for i, r := range inclusive {
+			amount := base.MulPercent(rates[r].Rate, money.HalfUp)
+			if i == len(inclusive)-1 {
+				amount = g.gross.Sub(base).Sub(extracted)
+			}
+			extracted = extracted.Add(amount)
+			charges[r].Amount = charges[r].Amount.Add(amount)
+		}
+
+		for _, r := range g.rateIndexes {
+			charges[r].TaxableBase = charges[r].TaxableBase.Add(base)
+		}
+	}
+
+	// 4. Hitung tarif exclusive dari total DPP & susun ringkasan
+	summary := &Summary{
+		Subtotal:      subtotal,
+		DiscountTotal: discountTotal,
+		Charges:       []Charge{},
+		GrandTotal:    subtotal.Sub(discountTotal),
+	}
+	for r := range charges {
+		if charges[r].TaxableBase.IsZero() {
+			continue
+		}
+		if charges[r].PriceMode == models.TaxModeExclusive {
+			charges[r].Amount = charges[r].TaxableBase.MulPercent(charges[r].Rate, money.HalfUp)
+			summary.GrandTotal = summary.GrandTotal.Add(charges[r].Amount)
+		}
+
+		if charges[r].TaxKind == models.TaxKindServiceCharge {
+			summary.ServiceChargeTotal = summary.ServiceChargeTotal.Add(charges[r].Amount)
+		} else {
+			summary.TaxTotal = summary.TaxTotal.Add(charges[r].Amount)
+		}
+		summary.Charges = append(summary.Charges, charges[r])
+	}
+	summary.NetRevenue = summary.GrandTotal.Sub(summary.TaxTotal)
+
+	return summary, nil
+}
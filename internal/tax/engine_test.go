@@ -0,0 +1,176 @@
+package tax
+
+import (
+	"errors"
+	"testing"
+
+	"laundry-backend/internal/models"
+	"laundry-backend/pkg/money"
+)
+
+func rate(id int64, kind, mode, scope string, percent int64, targets ...models.TaxRateTarget) models.TaxRateWithTargets {
+	return models.TaxRateWithTargets{
+		TaxRate: models.TaxRate{ID: id, Code: "R", TaxName: "Rate", TaxKind: kind, Rate: money.NewPercent(percent), PriceMode: mode, ScopeType: scope, IsActive: true},
+		Targets: targets,
+	}
+}
+
+func TestApplies(t *testing.T) {
+
+	line := Line{ServiceID: 7, CategoryID: 2, Amount: money.New(10000)}
+	exemptCategory := models.TaxRateTarget{TargetType: models.TaxTargetCategory, TargetID: 2, IsExempt: true}
+	includeService := models.TaxRateTarget{TargetType: models.TaxTargetService, TargetID: 7}
+	includeCategory := models.TaxRateTarget{TargetType: models.TaxTargetCategory, TargetID: 2}
+	otherService := models.TaxRateTarget{TargetType: models.TaxTargetService, TargetID: 8}
+
+	inactive := rate(1, models.TaxKindTax, models.TaxModeExclusive, models.TaxScopeAll, 11)
+	inactive.IsActive = false
+
+	tests := []struct {
+		name string
+		rate models.TaxRateWithTargets
+		want bool
+	}{
+		{name: "scope all without targets", rate: rate(1, models.TaxKindTax, models.TaxModeExclusive, models.TaxScopeAll, 11), want: true},
+		{name: "inactive rate never applies", rate: inactive, want: false},
+		{name: "scope selected without match", rate: rate(1, models.TaxKindTax, models.TaxModeExclusive, models.TaxScopeSelected, 11, otherService), want: false},
+		{name: "scope selected by category", rate: rate(1, models.TaxKindTax, models.TaxModeExclusive, models.TaxScopeSelected, 11, includeCategory), want: true},
+		{name: "category exemption under scope all", rate: rate(1, models.TaxKindTax, models.TaxModeExclusive, models.TaxScopeAll, 11, exemptCategory), want: false},
+		{name: "service target overrides category exemption", rate: rate(1, models.TaxKindTax, models.TaxModeExclusive, models.TaxScopeAll, 11, exemptCategory, includeService), want: true},
+	}
+
+	for _, tt := range tests {
+		if got := Applies(tt.rate, line); got != tt.want {
+			t.Errorf("%s: Applies = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCalculateWithoutRates(t *testing.T) {
+
+	lines := []Line{{ServiceID: 1, Amount: money.New(30000)}, {ServiceID: 2, Amount: money.New(20000)}}
+
+	got, err := Calculate(lines, money.New(5000), nil)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if got.Subtotal != money.New(50000) || got.GrandTotal != money.New(45000) || got.NetRevenue != money.New(45000) || len(got.Charges) != 0 {
+		t.Fatalf("Calculate = %+v", got)
+	}
+}
+
+func TestCalculateDiscountIsTaxedAfterAllocation(t *testing.T) {
+
+	// PPN 11% exclusive hanya untuk layanan 1; diskon Rp10.000 dibagi 60:40 sehingga DPP = 60.000 - 6.000
+	ppn := rate(1, models.TaxKindTax, models.TaxModeExclusive, models.TaxScopeSelected, 11,
+		models.TaxRateTarget{TargetType: models.TaxTargetService, TargetID: 1})
+	lines := []Line{{ServiceID: 1, Amount: money.New(60000)}, {ServiceID: 2, Amount: money.New(40000)}}
+
+	got, err := Calculate(lines, money.New(10000), []models.TaxRateWithTargets{ppn})
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if len(got.Charges) != 1 || got.Charges[0].TaxableBase != money.New(54000) || got.Charges[0].Amount != money.New(5940) {
+		t.Fatalf("Charges = %+v", got.Charges)
+	}
+	if got.GrandTotal != money.New(95940) || got.TaxTotal != money.New(5940) {
+		t.Fatalf("GrandTotal = %s, TaxTotal = %s", got.GrandTotal, got.TaxTotal)
+	}
+}
+
+func TestCalculateRejectsDiscountAboveSubtotal(t *testing.T) {
+
+	_, err := Calculate([]Line{{Amount: money.New(1000)}}, money.New(1001), nil)
+	if !errors.Is(err, ErrDiscountExceedsSubtotal) {
+		t.Fatalf("error = %v, want ErrDiscountExceedsSubtotal", err)
+	}
+}
+
+func TestCalculateReconciliation(t *testing.T) {
+
+	ppnExclusive := rate(1, models.TaxKindTax, models.TaxModeExclusive, models.TaxScopeAll, 11)
+	ppnInclusive := rate(1, models.TaxKindTax, models.TaxModeInclusive, models.TaxScopeAll, 11)
+	scExclusive := rate(2, models.TaxKindServiceCharge, models.TaxModeExclusive, models.TaxScopeAll, 5)
+	scInclusive := rate(2, models.TaxKindServiceCharge, models.TaxModeInclusive, models.TaxScopeAll, 5)
+
+	tests := []struct {
+		name           string
+		lines          []money.Amount
+		discount       money.Amount
+		rates          []models.TaxRateWithTargets
+		wantBase       money.Amount
+		wantSC         money.Amount
+		wantTax        money.Amount
+		wantGrand      money.Amount
+		wantNetRevenue money.Amount
+	}{
+		{
+			name: "exclusive PPN is added on top", lines: []money.Amount{money.New(100000)}, rates: []models.TaxRateWithTargets{ppnExclusive},
+			wantBase: money.New(100000), wantTax: money.New(11000), wantGrand: money.New(111000), wantNetRevenue: money.New(100000),
+		},
+		{
+			name: "inclusive PPN is extracted from the price", lines: []money.Amount{money.New(111000)}, rates: []models.TaxRateWithTargets{ppnInclusive},
+			wantBase: money.New(100000), wantTax: money.New(11000), wantGrand: money.New(111000), wantNetRevenue: money.New(100000),
+		},
+		{
+			name: "inclusive keeps the shelf price when extraction is not exact", lines: []money.Amount{money.New(50000)}, rates: []models.TaxRateWithTargets{ppnInclusive},
+			wantBase: money.FromMinor(4504505), wantTax: money.FromMinor(495495), wantGrand: money.New(50000), wantNetRevenue: money.FromMinor(4504505),
+		},
+		{
+			name: "exclusive rounds half up once on the whole order", lines: []money.Amount{money.FromMinor(33333), money.FromMinor(33333), money.FromMinor(33334)}, rates: []models.TaxRateWithTargets{ppnExclusive},
+			wantBase: money.New(1000), wantTax: money.New(110), wantGrand: money.New(1110), wantNetRevenue: money.New(1000),
+		},
+		{
+			name: "exclusive service charge and PPN share the same base", lines: []money.Amount{money.New(100000)}, rates: []models.TaxRateWithTargets{scExclusive, ppnExclusive},
+			wantBase: money.New(100000), wantSC: money.New(5000), wantTax: money.New(11000), wantGrand: money.New(116000), wantNetRevenue: money.New(105000),
+		},
+		{
+			name: "inclusive service charge and PPN are extracted together", lines: []money.Amount{money.New(116000)}, rates: []models.TaxRateWithTargets{scInclusive, ppnInclusive},
+			wantBase: money.New(100000), wantSC: money.New(5000), wantTax: money.New(11000), wantGrand: money.New(116000), wantNetRevenue: money.New(105000),
+		},
+		{
+			name: "inclusive service charge with exclusive PPN", lines: []money.Amount{money.New(105000)}, rates: []models.TaxRateWithTargets{scInclusive, ppnExclusive},
+			wantBase: money.New(100000), wantSC: money.New(5000), wantTax: money.New(11000), wantGrand: money.New(116000), wantNetRevenue: money.New(105000),
+		},
+		{
+			name: "discount is removed before inclusive extraction", lines: []money.Amount{money.New(122100)}, discount: money.New(11100), rates: []models.TaxRateWithTargets{ppnInclusive},
+			wantBase: money.New(100000), wantTax: money.New(11000), wantGrand: money.New(111000), wantNetRevenue: money.New(100000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := make([]Line, 0, len(tt.lines))
+			for i, amount := range tt.lines {
+				lines = append(lines, Line{ServiceID: int64(i + 1), Amount: amount})
+			}
+
+			got, err := Calculate(lines, tt.discount, tt.rates)
+			if err != nil {
+				t.Fatalf("Calculate: %v", err)
+			}
+			if got.ServiceChargeTotal != tt.wantSC || got.TaxTotal != tt.wantTax || got.GrandTotal != tt.wantGrand || got.NetRevenue != tt.wantNetRevenue {
+				t.Fatalf("Calculate = sc %s, tax %s, grand %s, net %s; want sc %s, tax %s, grand %s, net %s",
+					got.ServiceChargeTotal, got.TaxTotal, got.GrandTotal, got.NetRevenue, tt.wantSC, tt.wantTax, tt.wantGrand, tt.wantNetRevenue)
+			}
+
+			// Rekonsiliasi yang dijanjikan Summary harus eksak sampai sen
+			exclusive, charges := money.Zero, money.Zero
+			for _, c := range got.Charges {
+				if c.TaxableBase != tt.wantBase {
+					t.Errorf("%s taxable base = %s, want %s", c.TaxKind, c.TaxableBase, tt.wantBase)
+				}
+				if c.PriceMode == models.TaxModeExclusive {
+					exclusive = exclusive.Add(c.Amount)
+				}
+				charges = charges.Add(c.Amount)
+			}
+			if got.GrandTotal != got.Subtotal.Sub(got.DiscountTotal).Add(exclusive) {
+				t.Errorf("GrandTotal %s != Subtotal %s - Discount %s + exclusive %s", got.GrandTotal, got.Subtotal, got.DiscountTotal, exclusive)
+			}
+			if got.ServiceChargeTotal.Add(got.TaxTotal) != charges {
+				t.Errorf("ServiceChargeTotal + TaxTotal = %s, want %s", got.ServiceChargeTotal.Add(got.TaxTotal), charges)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// Package webhook menyusun, menandatangani, dan mengirim event ke endpoint eksternal
+// (skrip spreadsheet akuntansi, aplikasi pelanggan, dsb.).
+//
+// Setiap pengiriman berisi header:
+//
+//	X-Webhook-Id:        ID baris pengiriman (berbeda untuk setiap percobaan replay)
+//	X-Webhook-Event:     jenis event, cth: order.status_changed
+//	X-Webhook-Event-Id:  ID event (sama untuk semua endpoint & replay, dipakai penerima untuk dedup)
+//	X-Webhook-Timestamp: waktu kirim (Unix detik)
+//	X-Webhook-Signature: sha256=hex(HMAC-SHA256(secret, "{timestamp}.{body}"))
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"laundry-backend/pkg/money"
+)
+
+// Jenis event yang bisa didengarkan endpoint
+const (
+	EventOrderCreated       = "order.created"
+	EventOrderStatusChanged = "order.status_changed"
+	EventPaymentConfirmed   = "payment.confirmed"
+	EventDeliveryFinished   = "delivery.finished"
+	EventOrderOverdue       = "order.overdue"
+	EventOrderUnclaimed     = "order.unclaimed"
+)
+
+// Events adalah daftar semua event dalam urutan tampilan.
+var Events = []string{
+	EventOrderCreated,
+	EventOrderStatusChanged,
+	EventPaymentConfirmed,
+	EventDeliveryFinished,
+	EventOrderOverdue,
+	EventOrderUnclaimed,
+}
+
+// Nama header pengiriman
+const (
+	HeaderDeliveryID = "X-Webhook-Id"
+	HeaderEvent      = "X-Webhook-Event"
+	HeaderEventID    = "X-Webhook-Event-Id"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+// signaturePrefix menandai algoritma tanda tangan agar bisa diganti tanpa memutus penerima lama.
+const signaturePrefix = "sha256="
+
+// maxResponseBody membatasi potongan body balasan yang disimpan di log pengiriman.
+const maxResponseBody = 1024
+
+// Error verifikasi tanda tangan (untuk penerima yang ditulis dengan Go)
+var (
+	ErrInvalidSignature = errors.New("webhook signature mismatch")
+	ErrExpiredTimestamp = errors.New("webhook timestamp outside tolerance")
+)
+
+// Envelope adalah body JSON setiap pengiriman.
+type Envelope struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// OrderStatusData adalah isi 'data' untuk order.status_changed dan delivery.finished.
+type OrderStatusData struct {
+	OrderID        int64     `json:"order_id"`
+	InvoiceNumber  string    `json:"invoice_number"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	PaymentStatus  string    `json:"payment_status"`
+	IsDelivery     bool      `json:"is_delivery"`
+	ActorRole      string    `json:"actor_role"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+// OrderCreatedData adalah isi 'data' untuk order.created.
+type OrderCreatedData struct {
+	OrderID          int64        `json:"order_id"`
+	OutletID         int64        `json:"outlet_id"`
+	InvoiceNumber    string       `json:"invoice_number"`
+	CustomerID       *int64       `json:"customer_id"`
+	IsDelivery       bool         `json:"is_delivery"`
+	GrandTotal       money.Amount `json:"grand_total"`
+	PaymentStatus    string       `json:"payment_status"`
+	EstimatedReadyAt *time.Time   `json:"estimated_ready_at"`
+	CreatedAt        time.Time    `json:"created_at"`
+}
+
+// PaymentConfirmedData adalah isi 'data' untuk payment.confirmed.
+type PaymentConfirmedData struct {
+	PaymentID     int64        `json:"payment_id"`
+	OrderID       int64        `json:"order_id"`
+	OutletID      int64        `json:"outlet_id"`
+	InvoiceNumber string       `json:"invoice_number"`
+	Method        string       `json:"method"`
+	Amount        money.Amount `json:"amount"`
+	PaidAt        time.Time    `json:"paid_at"`
+}
+
+// OrderOverdueData adalah isi 'data' untuk order.overdue (pesanan melewati estimasi selesai).
+type OrderOverdueData struct {
+	OrderID          int64     `json:"order_id"`
+	OutletID         int64     `json:"outlet_id"`
+	InvoiceNumber    string    `json:"invoice_number"`
+	Status           string    `json:"status"`
+	EstimatedReadyAt time.Time `json:"estimated_ready_at"`
+	LateMinutes      int64     `json:"late_minutes"`
+	DetectedAt       time.Time `json:"detected_at"`
+}
+
+// OrderUnclaimedData adalah isi 'data' untuk order.unclaimed (cucian siap ambil melewati ambang hari).
+// Data pribadi pelanggan (nama, nomor HP) sengaja tidak dikirim ke sistem luar; penerima yang berwenang
+// mencarinya sendiri lewat customer_id (null untuk pelanggan walk-in tanpa data master).
+type OrderUnclaimedData struct {
+	OrderID       int64     `json:"order_id"`
+	OutletID      int64     `json:"outlet_id"`
+	InvoiceNumber string    `json:"invoice_number"`
+	CustomerID    *int64    `json:"customer_id"`
+	PaymentStatus string    `json:"payment_status"`
+	ReadySince    time.Time `json:"ready_since"`
+	DaysUnclaimed int       `json:"days_unclaimed"`
+	ThresholdDays int       `json:"threshold_days"`
+	DetectedAt    time.Time `json:"detected_at"`
+}
+
+// GenerateSecret membuat kunci tanda tangan acak untuk endpoint baru.
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("webhook.GenerateSecret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// Sign menghasilkan nilai header X-Webhook-Signature.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify memeriksa tanda tangan & umur timestamp sebuah pengiriman.
+// tolerance membatasi selisih waktu agar request lama tidak bisa diputar ulang pihak lain.
+func Verify(secret, timestampHeader, signatureHeader string, body []byte, tolerance time.Duration, now time.Time) error {
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", ErrInvalidSignature)
+	}
+
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrExpiredTimestamp
+	}
+
+	expected := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(signatureHeader)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Request adalah satu pengiriman yang siap dikirim.
+type Request struct {
+	URL        string
+	Secret     string
+	DeliveryID int64
+	EventID    string
+	EventType  string
+	Body       []byte
+}
+
+// Result adalah hasil satu percobaan pengiriman.
+type Result struct {
+	StatusCode int // 0 jika tidak ada balasan HTTP (timeout, DNS, dsb.)
+	Body       string
+	Duration   time.Duration
+	Err        error // nil hanya jika penerima membalas 2xx
+}
+
+// Sender mengirim pengiriman webhook lewat HTTP POST.
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender creates a Sender with the given per-request timeout.
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{client: &http.Client{Timeout: timeout}}
+}
+
+// Send signs and posts the body. Any non-2xx reply is returned as an error in Result.Err.
+func (s *Sender) Send(ctx context.Context, req Request) Result {
+	start := time.Now()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return Result{Err: fmt.Errorf("webhook.Send.Request: %w", err)}
+	}
+
+	timestamp := start.Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "VIPLaundry-Webhook/1.0")
+	httpReq.Header.Set(HeaderDeliveryID, strconv.FormatInt(req.DeliveryID, 10))
+	httpReq.Header.Set(HeaderEvent, req.EventType)
+	httpReq.Header.Set(HeaderEventID, req.EventID)
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Body))
+
+	res, err := s.client.Do(httpReq)
+	if err != nil {
+		return Result{Duration: time.Since(start), Err: fmt.Errorf("webhook.Send.Do: %w", err)}
+	}
+	defer res.Body.Close()
+
+	snippet, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseBody))
+	result := Result{
+		StatusCode: res.StatusCode,
+		Body:       strings.ToValidUTF8(string(snippet), ""),
+		Duration:   time.Since(start),
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		result.Err = fmt.Errorf("receiver returned %d", res.StatusCode)
+	}
+	return result
+}
+
+// NewEnvelope menyusun body JSON sebuah event.
+func NewEnvelope(eventID, eventType string, createdAt time.Time, data interface{}) ([]byte, error) {
+	body, err := json.Marshal(Envelope{ID: eventID, Type: eventType, CreatedAt: createdAt, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("webhook.NewEnvelope: %w", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOrderUnclaimedDataCarriesNoCustomerContact(t *testing.T) {
+
+	customerID := int64(42)
+	raw, err := json.Marshal(OrderUnclaimedData{OrderID: 98, CustomerID: &customerID, ReadySince: time.Now(), DetectedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, key := range []string{"customer_name", "customer_phone"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("payload exposes %q", key)
+		}
+	}
+	if fields["customer_id"] != float64(42) {
+		t.Errorf("customer_id = %v, want 42", fields["customer_id"])
+	}
+}
+
+func TestSenderDeliveryVerifiesAtReceiver(t *testing.T) {
+
+	const secret = "whsec_test"
+	body, err := NewEnvelope("evt_1", EventPaymentConfirmed, time.Now(), PaymentConfirmedData{PaymentID: 7, OrderID: 98})
+	if err != nil {
+		t.Fatalf("NewEnvelope: %v", err)
+	}
+
+	// Penerima memverifikasi seperti integrasi pihak ketiga: header timestamp + signature atas body mentah
+	var received http.Header
+	var verifyErr error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		received = r.Header.Clone()
+		verifyErr = Verify(secret, r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), raw, 5*time.Minute, time.Now())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	result := NewSender(5*time.Second).Send(context.Background(), Request{
+		URL: server.URL, Secret: secret, DeliveryID: 12, EventID: "evt_1", EventType: EventPaymentConfirmed, Body: body,
+	})
+
+	if result.Err != nil || result.StatusCode != http.StatusNoContent {
+		t.Fatalf("Send = %d, %v, want 204 without error", result.StatusCode, result.Err)
+	}
+	if verifyErr != nil {
+		t.Fatalf("receiver Verify: %v", verifyErr)
+	}
+	for header, want := range map[string]string{
+		HeaderDeliveryID: "12",
+		HeaderEvent:      EventPaymentConfirmed,
+		HeaderEventID:    "evt_1",
+		"Content-Type":   "application/json",
+	} {
+		if got := received.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+	if !strings.HasPrefix(received.Get(HeaderSignature), signaturePrefix) {
+		t.Errorf("%s = %q, want %s prefix", HeaderSignature, received.Get(HeaderSignature), signaturePrefix)
+	}
+}
+
+func TestVerify(t *testing.T) {
+
+	const secret = "whsec_test"
+	now := time.Unix(1767600000, 0)
+	body := []byte(`{"id":"evt_1"}`)
+	signedAt := func(ts time.Time) (string, string) {
+		return strconv.FormatInt(ts.Unix(), 10), Sign(secret, ts.Unix(), body)
+	}
+
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		mutate    func(ts, sig string, body []byte) (string, string, string, []byte)
+		want      error
+	}{
+		{name: "fresh delivery", timestamp: now},
+		{name: "at tolerance edge", timestamp: now.Add(-5 * time.Minute)},
+		{name: "slightly ahead of receiver clock", timestamp: now.Add(30 * time.Second)},
+		{name: "older than tolerance", timestamp: now.Add(-5*time.Minute - time.Second), want: ErrExpiredTimestamp},
+		{name: "too far in the future", timestamp: now.Add(6 * time.Minute), want: ErrExpiredTimestamp},
+		{
+			name: "tampered body", timestamp: now, want: ErrInvalidSignature,
+			mutate: func(ts, sig string, _ []byte) (string, string, string, []byte) {
+				return secret, ts, sig, []byte(`{"id":"evt_2"}`)
+			},
+		},
+		{
+			name: "wrong secret", timestamp: now, want: ErrInvalidSignature,
+			mutate: func(ts, sig string, body []byte) (string, string, string, []byte) {
+				return "whsec_other", ts, sig, body
+			},
+		},
+		{
+			name: "timestamp swapped after signing", timestamp: now, want: ErrInvalidSignature,
+			mutate: func(_, sig string, body []byte) (string, string, string, []byte) {
+				return secret, strconv.FormatInt(now.Unix()-1, 10), sig, body
+			},
+		},
+		{
+			name: "missing algorithm prefix", timestamp: now, want: ErrInvalidSignature,
+			mutate: func(ts, sig string, body []byte) (string, string, string, []byte) {
+				return secret, ts, strings.TrimPrefix(sig, signaturePrefix), body
+			},
+		},
+		{
+			name: "non-numeric timestamp", timestamp: now, want: ErrInvalidSignature,
+			mutate: func(_, sig string, body []byte) (string, string, string, []byte) {
+				return secret, "yesterday", sig, body
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, sig := signedAt(tt.timestamp)
+			key, payload := secret, body
+			if tt.mutate != nil {
+				key, ts, sig, payload = tt.mutate(ts, sig, body)
+			}
+			if err := Verify(key, ts, sig, payload, 5*time.Minute, now); !errors.Is(err, tt.want) {
+				t.Fatalf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSenderReportsNon2xx(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantErr  bool
+		wantBody string
+	}{
+		{name: "ok", status: http.StatusOK, body: "received", wantBody: "received"},
+		{name: "accepted", status: http.StatusAccepted},
+		{name: "bad request", status: http.StatusBadRequest, body: "invalid signature", wantErr: true, wantBody: "invalid signature"},
+		{name: "gone", status: http.StatusGone, wantErr: true},
+		{name: "server error", status: http.StatusInternalServerError, body: "boom", wantErr: true, wantBody: "boom"},
+		{name: "long body is truncated", status: http.StatusBadGateway, body: strings.Repeat("x", 5000), wantErr: true, wantBody: strings.Repeat("x", maxResponseBody)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			result := NewSender(5*time.Second).Send(context.Background(), Request{URL: server.URL, Secret: "s", Body: []byte("{}")})
+
+			if result.StatusCode != tt.status {
+				t.Fatalf("StatusCode = %d, want %d", result.StatusCode, tt.status)
+			}
+			if (result.Err != nil) != tt.wantErr {
+				t.Fatalf("Err = %v, wantErr %v", result.Err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(result.Err.Error(), strconv.Itoa(tt.status)) {
+				t.Fatalf("Err = %v, want it to name status %d", result.Err, tt.status)
+			}
+			if result.Body != tt.wantBody {
+				t.Fatalf("Body has %d bytes, want %d", len(result.Body), len(tt.wantBody))
+			}
+		})
+	}
+
+	// Penerima tidak bisa dihubungi: tanpa status HTTP, tetap error
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	if result := NewSender(time.Second).Send(context.Background(), Request{URL: url, Body: []byte("{}")}); result.Err == nil || result.StatusCode != 0 {
+		t.Fatalf("unreachable receiver = %d, %v, want status 0 with error", result.StatusCode, result.Err)
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS service_pricing_rules;
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- 12. Tabel SERVICE PRICING RULES (Minimum Charge, Pembulatan, Harga Bertingkat)
+CREATE TABLE `service_pricing_rules` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`service_id` BIGINT(19) NOT NULL,
+	`rule_type` ENUM('minimum','rounding','tier') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`min_quantity` DECIMAL(8,2) NULL DEFAULT NULL,
+	`rounding_step` DECIMAL(8,2) NULL DEFAULT NULL,
+	`unit_price` DECIMAL(15,2) NULL DEFAULT NULL,
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_pricing_rules_service` (`service_id`, `is_active`) USING BTREE,
+	CONSTRAINT `fk_pricing_rules_service` FOREIGN KEY (`service_id`) REFERENCES `services` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS order_item_addons;
+DROP TABLE IF EXISTS service_addon_links;
+DROP TABLE IF EXISTS service_addons;
\ No newline at end of file
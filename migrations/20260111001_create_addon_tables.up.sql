@@ -0,0 +1,52 @@
+-- 13. Tabel SERVICE ADDONS (Katalog Tambahan: Express, Pewangi Premium, Hanger)
+CREATE TABLE `service_addons` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`code` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`addon_name` VARCHAR(150) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`charge_type` ENUM('percentage','fixed') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`charge_value` DECIMAL(15,2) NOT NULL DEFAULT '0.00',
+	`charge_basis` ENUM('per_unit','per_order') NOT NULL DEFAULT 'per_unit' COLLATE 'utf8mb4_0900_ai_ci',
+	`max_duration_hours` INT(10) NULL DEFAULT NULL,
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `code` (`code`) USING BTREE,
+	INDEX `idx_addons_name` (`addon_name`) USING BTREE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 14. Tabel SERVICE ADDON LINKS (Add-on apa saja yang boleh dipilih untuk layanan tertentu)
+CREATE TABLE `service_addon_links` (
+	`addon_id` BIGINT(19) NOT NULL,
+	`service_id` BIGINT(19) NOT NULL,
+	PRIMARY KEY (`addon_id`, `service_id`) USING BTREE,
+	INDEX `idx_addon_links_service` (`service_id`) USING BTREE,
+	CONSTRAINT `fk_addon_links_addon` FOREIGN KEY (`addon_id`) REFERENCES `service_addons` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_addon_links_service` FOREIGN KEY (`service_id`) REFERENCES `services` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 15. Tabel ORDER ITEM ADDONS (Snapshot add-on yang dipilih per item pesanan)
+CREATE TABLE `order_item_addons` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`order_item_id` BIGINT(19) NOT NULL,
+	`addon_id` BIGINT(19) NULL DEFAULT NULL,
+	`addon_name` VARCHAR(150) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`charge_type` ENUM('percentage','fixed') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`charge_value` DECIMAL(15,2) NOT NULL,
+	`charge_basis` ENUM('per_unit','per_order') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`amount` DECIMAL(15,2) NOT NULL DEFAULT '0.00',
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_item_addons_item` (`order_item_id`) USING BTREE,
+	INDEX `idx_item_addons_addon` (`addon_id`) USING BTREE,
+	CONSTRAINT `fk_item_addons_item` FOREIGN KEY (`order_item_id`) REFERENCES `order_items` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_item_addons_addon` FOREIGN KEY (`addon_id`) REFERENCES `service_addons` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,5 @@
+ALTER TABLE orders DROP COLUMN discount_total;
+DROP TABLE IF EXISTS order_discounts;
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS promotion_scopes;
+DROP TABLE IF EXISTS promotions;
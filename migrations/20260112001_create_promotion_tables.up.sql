@@ -0,0 +1,81 @@
+-- 16. Tabel PROMOTIONS (Promo Otomatis & Kode Voucher)
+CREATE TABLE `promotions` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`code` VARCHAR(50) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`promo_name` VARCHAR(150) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`description` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`discount_type` ENUM('percentage','fixed') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`discount_value` DECIMAL(15,2) NOT NULL DEFAULT '0.00',
+	`max_discount` DECIMAL(15,2) NULL DEFAULT NULL,
+	`min_spend` DECIMAL(15,2) NOT NULL DEFAULT '0.00',
+	`scope_type` ENUM('all','service','category') NOT NULL DEFAULT 'all' COLLATE 'utf8mb4_0900_ai_ci',
+	`valid_days` VARCHAR(20) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`starts_at` DATETIME NOT NULL,
+	`ends_at` DATETIME NULL DEFAULT NULL,
+	`usage_limit` INT(10) NULL DEFAULT NULL,
+	`usage_count` INT(10) NOT NULL DEFAULT '0',
+	`per_customer_limit` INT(10) NULL DEFAULT NULL,
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `code` (`code`) USING BTREE,
+	INDEX `idx_promotions_window` (`is_active`, `starts_at`, `ends_at`) USING BTREE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 17. Tabel PROMOTION SCOPES (Layanan / Kategori yang berhak mendapat diskon)
+CREATE TABLE `promotion_scopes` (
+	`promotion_id` BIGINT(19) NOT NULL,
+	`target_id` BIGINT(19) NOT NULL,
+	PRIMARY KEY (`promotion_id`, `target_id`) USING BTREE,
+	CONSTRAINT `fk_promotion_scopes_promotion` FOREIGN KEY (`promotion_id`) REFERENCES `promotions` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 18. Tabel PROMOTION REDEMPTIONS (Riwayat Pemakaian Promo, dasar kuota per pelanggan)
+CREATE TABLE `promotion_redemptions` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`promotion_id` BIGINT(19) NOT NULL,
+	`order_id` BIGINT(19) NOT NULL,
+	`customer_id` BIGINT(19) NULL DEFAULT NULL,
+	`discount_amount` DECIMAL(15,2) NOT NULL,
+	`redeemed_by` BIGINT(19) NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_promotion_order` (`promotion_id`, `order_id`) USING BTREE,
+	INDEX `idx_redemptions_customer` (`promotion_id`, `customer_id`) USING BTREE,
+	CONSTRAINT `fk_redemptions_promotion` FOREIGN KEY (`promotion_id`) REFERENCES `promotions` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_redemptions_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_redemptions_customer` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+	CONSTRAINT `fk_redemptions_user` FOREIGN KEY (`redeemed_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 19. Tabel ORDER DISCOUNTS (Baris penjelasan diskon yang tercetak di nota)
+CREATE TABLE `order_discounts` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`order_id` BIGINT(19) NOT NULL,
+	`promotion_id` BIGINT(19) NULL DEFAULT NULL,
+	`code` VARCHAR(50) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`explanation` VARCHAR(255) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`amount` DECIMAL(15,2) NOT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_order_discounts_order` (`order_id`) USING BTREE,
+	CONSTRAINT `fk_order_discounts_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_order_discounts_promotion` FOREIGN KEY (`promotion_id`) REFERENCES `promotions` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- Total diskon disimpan di nota induk agar laporan tidak perlu JOIN
+ALTER TABLE `orders`
+	ADD COLUMN `discount_total` DECIMAL(15,2) NOT NULL DEFAULT '0.00' AFTER `total_price`;
@@ -0,0 +1,6 @@
+ALTER TABLE payments MODIFY COLUMN method ENUM('cash','transfer','qris','ewallet') NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci';
+DROP TABLE IF EXISTS point_ledger;
+DROP TABLE IF EXISTS wallet_ledger;
+ALTER TABLE customers DROP FOREIGN KEY fk_customers_membership_tier;
+ALTER TABLE customers DROP COLUMN points_balance, DROP COLUMN wallet_balance, DROP COLUMN membership_tier_id;
+DROP TABLE IF EXISTS membership_tiers;
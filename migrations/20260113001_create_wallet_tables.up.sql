@@ -0,0 +1,77 @@
+-- 20. Tabel MEMBERSHIP TIERS (Level Member & Diskon Otomatis)
+CREATE TABLE `membership_tiers` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`tier_name` VARCHAR(100) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`discount_percent` DECIMAL(5,2) NOT NULL DEFAULT '0.00',
+	`max_discount` DECIMAL(15,2) NULL DEFAULT NULL,
+	`point_multiplier` DECIMAL(5,2) NOT NULL DEFAULT '1.00',
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `tier_name` (`tier_name`) USING BTREE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- Saldo deposit, poin, dan level member disimpan di pelanggan sebagai nilai berjalan.
+-- Sumber kebenaran tetap tabel ledger di bawah (saldo = SUM credit - SUM debit).
+ALTER TABLE `customers`
+	ADD COLUMN `membership_tier_id` BIGINT(19) NULL DEFAULT NULL AFTER `address`,
+	ADD COLUMN `wallet_balance` DECIMAL(15,2) NOT NULL DEFAULT '0.00' AFTER `membership_tier_id`,
+	ADD COLUMN `points_balance` INT(10) NOT NULL DEFAULT '0' AFTER `wallet_balance`,
+	ADD CONSTRAINT `fk_customers_membership_tier` FOREIGN KEY (`membership_tier_id`) REFERENCES `membership_tiers` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL;
+
+-- 21. Tabel WALLET LEDGER (Mutasi Saldo Deposit)
+CREATE TABLE `wallet_ledger` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`customer_id` BIGINT(19) NOT NULL,
+	`entry_type` ENUM('topup','spend','refund','adjustment') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`direction` ENUM('credit','debit') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`amount` DECIMAL(15,2) NOT NULL,
+	`balance_before` DECIMAL(15,2) NOT NULL,
+	`balance_after` DECIMAL(15,2) NOT NULL,
+	`order_id` BIGINT(19) NULL DEFAULT NULL,
+	`payment_method` ENUM('cash','transfer','qris','ewallet') NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`reason` VARCHAR(255) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`actor_id` BIGINT(19) NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_wallet_ledger_customer` (`customer_id`, `id`) USING BTREE,
+	INDEX `idx_wallet_ledger_order` (`order_id`) USING BTREE,
+	CONSTRAINT `fk_wallet_ledger_customer` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_wallet_ledger_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+	CONSTRAINT `fk_wallet_ledger_actor` FOREIGN KEY (`actor_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 22. Tabel POINT LEDGER (Mutasi Poin Loyalitas)
+CREATE TABLE `point_ledger` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`customer_id` BIGINT(19) NOT NULL,
+	`entry_type` ENUM('earn','redeem','adjustment') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`direction` ENUM('credit','debit') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`points` INT(10) NOT NULL,
+	`balance_before` INT(10) NOT NULL,
+	`balance_after` INT(10) NOT NULL,
+	`order_id` BIGINT(19) NULL DEFAULT NULL,
+	`reason` VARCHAR(255) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`actor_id` BIGINT(19) NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_point_ledger_customer` (`customer_id`, `id`) USING BTREE,
+	UNIQUE INDEX `unique_point_earn_order` (`order_id`, `entry_type`) USING BTREE,
+	CONSTRAINT `fk_point_ledger_customer` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_point_ledger_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+	CONSTRAINT `fk_point_ledger_actor` FOREIGN KEY (`actor_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- Metode pembayaran baru: potong saldo deposit pelanggan
+ALTER TABLE `payments`
+	MODIFY COLUMN `method` ENUM('cash','transfer','qris','ewallet','deposit') NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci';
@@ -0,0 +1,5 @@
+ALTER TABLE orders CHANGE COLUMN grand_total total_price DECIMAL(15,2) NULL DEFAULT '0.00' AFTER is_delivery;
+ALTER TABLE orders DROP COLUMN tax_total, DROP COLUMN service_charge_total, DROP COLUMN subtotal;
+DROP TABLE IF EXISTS order_taxes;
+DROP TABLE IF EXISTS tax_rate_targets;
+DROP TABLE IF EXISTS tax_rates;
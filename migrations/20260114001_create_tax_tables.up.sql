@@ -0,0 +1,72 @@
+-- 23. Tabel TAX RATES (PPN & Service Charge)
+CREATE TABLE `tax_rates` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`code` VARCHAR(30) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`tax_name` VARCHAR(100) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`tax_kind` ENUM('tax','service_charge') NOT NULL DEFAULT 'tax' COLLATE 'utf8mb4_0900_ai_ci',
+	`rate` DECIMAL(5,2) NOT NULL,
+	`price_mode` ENUM('exclusive','inclusive') NOT NULL DEFAULT 'exclusive' COLLATE 'utf8mb4_0900_ai_ci',
+	`scope_type` ENUM('all','selected') NOT NULL DEFAULT 'all' COLLATE 'utf8mb4_0900_ai_ci',
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_tax_rate_code` (`code`) USING BTREE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 24. Tabel TAX RATE TARGETS (Cakupan & Pengecualian per Layanan/Kategori)
+-- Baris layanan mengalahkan baris kategori. is_exempt = 1 berarti item TIDAK dikenai tarif ini.
+CREATE TABLE `tax_rate_targets` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`tax_rate_id` BIGINT(19) NOT NULL,
+	`target_type` ENUM('service','category') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`target_id` BIGINT(19) NOT NULL,
+	`is_exempt` TINYINT(1) NOT NULL DEFAULT '0',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_tax_rate_target` (`tax_rate_id`, `target_type`, `target_id`) USING BTREE,
+	CONSTRAINT `fk_tax_rate_targets_rate` FOREIGN KEY (`tax_rate_id`) REFERENCES `tax_rates` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 25. Tabel ORDER TAXES (Rincian Pajak & Service Charge per Pesanan)
+-- Nama, tarif, dan mode disalin saat pesanan dibuat agar nota lama tidak berubah jika tarif diganti.
+CREATE TABLE `order_taxes` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`order_id` BIGINT(19) NOT NULL,
+	`tax_rate_id` BIGINT(19) NULL DEFAULT NULL,
+	`tax_name` VARCHAR(100) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`tax_kind` ENUM('tax','service_charge') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`rate` DECIMAL(5,2) NOT NULL,
+	`price_mode` ENUM('exclusive','inclusive') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`taxable_base` DECIMAL(15,2) NOT NULL,
+	`amount` DECIMAL(15,2) NOT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_order_taxes_order` (`order_id`) USING BTREE,
+	INDEX `idx_order_taxes_rate` (`tax_rate_id`) USING BTREE,
+	CONSTRAINT `fk_order_taxes_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_order_taxes_rate` FOREIGN KEY (`tax_rate_id`) REFERENCES `tax_rates` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- Nota induk menyimpan rincian total menggantikan kolom tunggal total_price:
+-- grand_total = subtotal - discount_total + (service charge & pajak mode exclusive)
+UPDATE `orders` SET `total_price` = 0 WHERE `total_price` IS NULL;
+
+ALTER TABLE `orders`
+	ADD COLUMN `subtotal` DECIMAL(15,2) NOT NULL DEFAULT '0.00' AFTER `is_delivery`,
+	ADD COLUMN `service_charge_total` DECIMAL(15,2) NOT NULL DEFAULT '0.00' AFTER `discount_total`,
+	ADD COLUMN `tax_total` DECIMAL(15,2) NOT NULL DEFAULT '0.00' AFTER `service_charge_total`;
+
+ALTER TABLE `orders`
+	CHANGE COLUMN `total_price` `grand_total` DECIMAL(15,2) NOT NULL DEFAULT '0.00' AFTER `tax_total`;
+
+-- Pesanan lama belum mengenal pajak: subtotal = total akhir + diskon
+UPDATE `orders` SET `subtotal` = `grand_total` + `discount_total`;
@@ -0,0 +1,23 @@
+-- 26. Tabel ORDER ITEM TAGS (Label Fisik Kantong/Item Cucian)
+-- Satu tag per item pesanan. tag_code dicetak sebagai Code128/QR dan dipindai staff untuk menemukan nota.
+CREATE TABLE `order_item_tags` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`order_id` BIGINT(19) NOT NULL,
+	`order_item_id` BIGINT(19) NOT NULL,
+	`tag_code` VARCHAR(20) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`counted_pieces` INT(10) NULL DEFAULT NULL,
+	`counted_by` BIGINT(19) NULL DEFAULT NULL,
+	`counted_at` TIMESTAMP NULL DEFAULT NULL,
+	`last_scanned_at` TIMESTAMP NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_tag_code` (`tag_code`) USING BTREE,
+	UNIQUE INDEX `unique_tag_order_item` (`order_item_id`) USING BTREE,
+	INDEX `idx_tags_order` (`order_id`) USING BTREE,
+	CONSTRAINT `fk_tags_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_tags_order_item` FOREIGN KEY (`order_item_id`) REFERENCES `order_items` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_tags_counted_by` FOREIGN KEY (`counted_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
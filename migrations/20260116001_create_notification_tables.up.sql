@@ -0,0 +1,50 @@
+-- 27. Tabel NOTIFICATION TEMPLATES (Template Pesan Pelanggan)
+-- Satu template per jenis event. Body memakai sintaks text/template Go, cth: {{.InvoiceNumber}}.
+CREATE TABLE `notification_templates` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`event_type` ENUM('order_created','order_ready_pickup','order_ready_delivery','order_being_delivered','payment_confirmed') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`body` TEXT NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`updated_by` BIGINT(19) NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_template_event` (`event_type`) USING BTREE,
+	CONSTRAINT `fk_templates_updated_by` FOREIGN KEY (`updated_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+INSERT INTO `notification_templates` (`event_type`, `body`) VALUES
+	('order_created', 'Halo {{.CustomerName}}, pesanan {{.InvoiceNumber}} sudah kami terima. Total {{.GrandTotal}}{{if .EstimatedReadyAt}}, estimasi selesai {{.EstimatedReadyAt}}{{end}}. Lacak: {{.TrackingURL}}\n\n{{.ShopName}}'),
+	('order_ready_pickup', 'Halo {{.CustomerName}}, cucian {{.InvoiceNumber}} sudah selesai dan siap diambil. {{if eq .PaymentStatus "unpaid"}}Total tagihan {{.GrandTotal}}. {{end}}Terima kasih!\n\n{{.ShopName}} {{.ShopPhone}}'),
+	('order_ready_delivery', 'Halo {{.CustomerName}}, cucian {{.InvoiceNumber}} sudah selesai dan akan segera kami antar ke alamat Anda.\n\n{{.ShopName}}'),
+	('order_being_delivered', 'Halo {{.CustomerName}}, kurir kami sedang dalam perjalanan mengantar cucian {{.InvoiceNumber}}. Lacak: {{.TrackingURL}}\n\n{{.ShopName}}'),
+	('payment_confirmed', 'Terima kasih {{.CustomerName}}, pembayaran pesanan {{.InvoiceNumber}} sebesar {{.GrandTotal}} sudah kami terima.\n\n{{.ShopName}}');
+
+-- 28. Tabel NOTIFICATION OUTBOX (Antrean Pesan Keluar)
+-- Ditulis di dalam transaksi yang sama dengan perubahan status/pembayaran, lalu dikirim oleh worker.
+-- payload menyimpan snapshot data pesanan saat event terjadi; pesan dirender saat dikirim.
+CREATE TABLE `notification_outbox` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`event_type` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`order_id` BIGINT(19) NOT NULL,
+	`recipient` VARCHAR(30) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`payload` JSON NOT NULL,
+	`status` ENUM('pending','processing','sent','failed','skipped') NOT NULL DEFAULT 'pending' COLLATE 'utf8mb4_0900_ai_ci',
+	`channel` VARCHAR(20) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`message` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`attempts` INT(10) NOT NULL DEFAULT '0',
+	`next_attempt_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	`last_error` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`sent_at` TIMESTAMP NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_outbox_due` (`status`, `next_attempt_at`) USING BTREE,
+	INDEX `idx_outbox_order` (`order_id`) USING BTREE,
+	CONSTRAINT `fk_outbox_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,59 @@
+-- 29. Tabel WEBHOOK ENDPOINTS (Penerima Event Eksternal)
+-- secret dipakai untuk tanda tangan HMAC-SHA256 setiap pengiriman.
+CREATE TABLE `webhook_endpoints` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`url` VARCHAR(500) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`description` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`secret` VARCHAR(100) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`created_by` BIGINT(19) NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	CONSTRAINT `fk_webhooks_created_by` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 30. Tabel WEBHOOK SUBSCRIPTIONS (Event yang Didengarkan Endpoint)
+CREATE TABLE `webhook_subscriptions` (
+	`endpoint_id` BIGINT(19) NOT NULL,
+	`event_type` ENUM('order.created','order.status_changed','payment.confirmed','delivery.finished') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	PRIMARY KEY (`endpoint_id`, `event_type`) USING BTREE,
+	INDEX `idx_subscriptions_event` (`event_type`) USING BTREE,
+	CONSTRAINT `fk_subscriptions_endpoint` FOREIGN KEY (`endpoint_id`) REFERENCES `webhook_endpoints` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 31. Tabel WEBHOOK DELIVERIES (Log & Antrean Pengiriman per Endpoint)
+-- Ditulis di dalam transaksi yang sama dengan event. payload adalah body JSON persis yang dikirim.
+-- Replay membuat baris baru dengan event_id & payload yang sama (replay_of menunjuk baris asal).
+CREATE TABLE `webhook_deliveries` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`endpoint_id` BIGINT(19) NOT NULL,
+	`event_id` VARCHAR(36) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`event_type` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`payload` JSON NOT NULL,
+	`status` ENUM('pending','processing','delivered','failed') NOT NULL DEFAULT 'pending' COLLATE 'utf8mb4_0900_ai_ci',
+	`attempts` INT(10) NOT NULL DEFAULT '0',
+	`next_attempt_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	`response_status` INT(10) NULL DEFAULT NULL,
+	`response_body` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`last_error` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`duration_ms` INT(10) NULL DEFAULT NULL,
+	`delivered_at` TIMESTAMP NULL DEFAULT NULL,
+	`replay_of` BIGINT(19) NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_webhook_deliveries_due` (`status`, `next_attempt_at`) USING BTREE,
+	INDEX `idx_webhook_deliveries_endpoint` (`endpoint_id`, `id`) USING BTREE,
+	INDEX `idx_webhook_deliveries_event` (`event_id`) USING BTREE,
+	CONSTRAINT `fk_deliveries_endpoint` FOREIGN KEY (`endpoint_id`) REFERENCES `webhook_endpoints` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_deliveries_replay_of` FOREIGN KEY (`replay_of`) REFERENCES `webhook_deliveries` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
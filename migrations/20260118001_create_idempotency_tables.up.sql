@@ -0,0 +1,26 @@
+-- 32. Tabel IDEMPOTENCY KEYS (Anti Dobel Request dari Retry Aplikasi Kasir)
+-- Satu baris per (user, Idempotency-Key). Selama request pertama berjalan status = 'processing';
+-- setelah selesai balasan disimpan utuh agar retry identik mendapat balasan yang sama.
+-- expires_at: batas kunci 'processing' (lock) atau masa simpan balasan (TTL).
+CREATE TABLE `idempotency_keys` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`user_id` BIGINT(19) NOT NULL,
+	`idempotency_key` VARCHAR(255) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`request_method` VARCHAR(10) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`request_path` VARCHAR(500) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`request_hash` CHAR(64) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`status` ENUM('processing','completed') NOT NULL DEFAULT 'processing' COLLATE 'utf8mb4_0900_ai_ci',
+	`response_status` INT(10) NULL DEFAULT NULL,
+	`response_content_type` VARCHAR(100) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`response_body` MEDIUMBLOB NULL DEFAULT NULL,
+	`expires_at` TIMESTAMP NOT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`completed_at` TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `uq_idempotency_user_key` (`user_id`, `idempotency_key`) USING BTREE,
+	INDEX `idx_idempotency_expires` (`expires_at`) USING BTREE,
+	CONSTRAINT `fk_idempotency_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
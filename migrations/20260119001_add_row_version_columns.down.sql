@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP COLUMN version;
+ALTER TABLE services DROP COLUMN version;
+ALTER TABLE service_categories DROP COLUMN version;
+ALTER TABLE users DROP COLUMN version;
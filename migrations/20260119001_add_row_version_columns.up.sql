@@ -0,0 +1,15 @@
+-- 33. Kolom VERSION (Optimistic Concurrency Control)
+-- Naik 1 setiap UPDATE: UPDATE ... SET ..., version = version + 1 WHERE id = ? AND version = ?
+-- Dipakai sebagai ETag pada endpoint detail dan dicocokkan dengan header If-Match saat PUT/PATCH.
+ALTER TABLE `users`
+	ADD COLUMN `version` INT(10) UNSIGNED NOT NULL DEFAULT '1' AFTER `updated_at`;
+
+ALTER TABLE `service_categories`
+	ADD COLUMN `version` INT(10) UNSIGNED NOT NULL DEFAULT '1' AFTER `updated_at`;
+
+ALTER TABLE `services`
+	ADD COLUMN `version` INT(10) UNSIGNED NOT NULL DEFAULT '1' AFTER `updated_at`;
+
+-- Disiapkan untuk edit pesanan agar dua kasir tidak saling menimpa
+ALTER TABLE `orders`
+	ADD COLUMN `version` INT(10) UNSIGNED NOT NULL DEFAULT '1' AFTER `updated_at`;
@@ -0,0 +1,4 @@
+ALTER TABLE `services` DROP INDEX `ft_services_search`;
+ALTER TABLE `customers` DROP INDEX `ft_customers_search`;
+ALTER TABLE `order_items` DROP INDEX `ft_order_items_notes`;
+ALTER TABLE `orders` DROP INDEX `ft_orders_search`;
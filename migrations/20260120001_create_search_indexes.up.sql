@@ -0,0 +1,14 @@
+-- 34. Index FULLTEXT untuk pencarian global (GET /search)
+-- Parser ngram (ngram_token_size bawaan = 2) memecah teks menjadi potongan 2 karakter,
+-- sehingga potongan nomor nota ("260117"), sebagian nomor HP ("3456"), maupun sebagian nama tetap ditemukan.
+ALTER TABLE `orders`
+	ADD FULLTEXT INDEX `ft_orders_search` (`invoice_number`, `customer_name`, `customer_phone`, `notes`) WITH PARSER ngram;
+
+ALTER TABLE `order_items`
+	ADD FULLTEXT INDEX `ft_order_items_notes` (`item_notes`) WITH PARSER ngram;
+
+ALTER TABLE `customers`
+	ADD FULLTEXT INDEX `ft_customers_search` (`full_name`, `phone_number`) WITH PARSER ngram;
+
+ALTER TABLE `services`
+	ADD FULLTEXT INDEX `ft_services_search` (`service_name`, `code`) WITH PARSER ngram;
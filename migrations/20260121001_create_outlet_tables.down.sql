@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS service_outlet_prices;
+ALTER TABLE deliveries DROP FOREIGN KEY fk_deliveries_outlet, DROP INDEX idx_deliveries_outlet, DROP COLUMN outlet_id;
+ALTER TABLE payments DROP FOREIGN KEY fk_payments_outlet, DROP INDEX idx_payments_outlet_created_at, DROP COLUMN outlet_id;
+ALTER TABLE orders DROP FOREIGN KEY fk_orders_outlet, DROP INDEX idx_orders_outlet_created_at, DROP COLUMN outlet_id;
+ALTER TABLE users DROP FOREIGN KEY fk_users_outlet, DROP COLUMN outlet_id;
+DROP TABLE IF EXISTS outlets;
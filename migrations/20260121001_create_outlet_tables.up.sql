@@ -0,0 +1,70 @@
+-- 35. Tabel OUTLETS (Cabang Laundry)
+-- Outlet pertama (kode PUSAT) dibuat otomatis agar seluruh data lama punya pemilik outlet.
+CREATE TABLE `outlets` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`code` VARCHAR(20) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`outlet_name` VARCHAR(150) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`address` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`phone_number` VARCHAR(30) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `code` (`code`) USING BTREE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+INSERT INTO `outlets` (`id`, `code`, `outlet_name`) VALUES (1, 'PUSAT', 'Outlet Pusat');
+
+-- 36. Kolom OUTLET pada pengguna
+-- Kasir, staff, dan kurir wajib terikat satu outlet. NULL hanya untuk owner (tidak terikat outlet).
+ALTER TABLE `users`
+	ADD COLUMN `outlet_id` BIGINT(19) NULL DEFAULT NULL AFTER `role`,
+	ADD CONSTRAINT `fk_users_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT;
+
+UPDATE `users` SET `outlet_id` = 1;
+
+-- 37. Kolom OUTLET pada transaksi (pesanan, pembayaran, pengantaran)
+-- outlet_id pembayaran & pengantaran disalin dari pesanannya agar laporan per outlet tidak perlu JOIN.
+ALTER TABLE `orders`
+	ADD COLUMN `outlet_id` BIGINT(19) NULL DEFAULT NULL AFTER `invoice_number`;
+UPDATE `orders` SET `outlet_id` = 1;
+ALTER TABLE `orders`
+	MODIFY COLUMN `outlet_id` BIGINT(19) NOT NULL,
+	ADD INDEX `idx_orders_outlet_created_at` (`outlet_id`, `created_at`) USING BTREE,
+	ADD CONSTRAINT `fk_orders_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT;
+
+ALTER TABLE `payments`
+	ADD COLUMN `outlet_id` BIGINT(19) NULL DEFAULT NULL AFTER `order_id`;
+UPDATE `payments` p JOIN `orders` o ON o.id = p.order_id SET p.outlet_id = o.outlet_id;
+ALTER TABLE `payments`
+	MODIFY COLUMN `outlet_id` BIGINT(19) NOT NULL,
+	ADD INDEX `idx_payments_outlet_created_at` (`outlet_id`, `created_at`) USING BTREE,
+	ADD CONSTRAINT `fk_payments_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT;
+
+ALTER TABLE `deliveries`
+	ADD COLUMN `outlet_id` BIGINT(19) NULL DEFAULT NULL AFTER `order_id`;
+UPDATE `deliveries` d JOIN `orders` o ON o.id = d.order_id SET d.outlet_id = o.outlet_id;
+ALTER TABLE `deliveries`
+	MODIFY COLUMN `outlet_id` BIGINT(19) NOT NULL,
+	ADD INDEX `idx_deliveries_outlet` (`outlet_id`) USING BTREE,
+	ADD CONSTRAINT `fk_deliveries_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT;
+
+-- 38. Tabel SERVICE OUTLET PRICES (Harga Layanan Khusus per Outlet)
+-- Opsional: tanpa baris di sini, outlet memakai services.price.
+CREATE TABLE `service_outlet_prices` (
+	`service_id` BIGINT(19) NOT NULL,
+	`outlet_id` BIGINT(19) NOT NULL,
+	`price` DECIMAL(15,2) NOT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`service_id`, `outlet_id`) USING BTREE,
+	INDEX `fk_service_outlet_prices_outlet` (`outlet_id`) USING BTREE,
+	CONSTRAINT `fk_service_outlet_prices_service` FOREIGN KEY (`service_id`) REFERENCES `services` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_service_outlet_prices_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,3 @@
+ALTER TABLE payments DROP FOREIGN KEY fk_payments_shift, DROP INDEX idx_payments_shift, DROP COLUMN shift_id;
+DROP TABLE IF EXISTS petty_cash_payouts;
+DROP TABLE IF EXISTS cashier_shifts;
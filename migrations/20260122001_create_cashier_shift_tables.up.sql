@@ -0,0 +1,58 @@
+-- 39. Tabel CASHIER_SHIFTS (Shift Kasir & Rekonsiliasi Laci Kas)
+-- Kolom expected_cash & variance diisi saat shift ditutup:
+-- expected_cash = opening_float + cash_sales - petty_cash_total, variance = closing_counted - expected_cash.
+-- open_cashier_id hanya terisi selama shift terbuka sehingga satu kasir maksimal punya satu shift terbuka.
+CREATE TABLE `cashier_shifts` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`outlet_id` BIGINT(19) NOT NULL,
+	`cashier_id` BIGINT(19) NOT NULL,
+	`status` ENUM('open','closed') NOT NULL DEFAULT 'open' COLLATE 'utf8mb4_0900_ai_ci',
+	`opening_float` DECIMAL(15,2) NOT NULL DEFAULT '0.00',
+	`cash_sales` DECIMAL(15,2) NOT NULL DEFAULT '0.00',
+	`petty_cash_total` DECIMAL(15,2) NOT NULL DEFAULT '0.00',
+	`expected_cash` DECIMAL(15,2) NULL DEFAULT NULL,
+	`closing_counted` DECIMAL(15,2) NULL DEFAULT NULL,
+	`variance` DECIMAL(15,2) NULL DEFAULT NULL,
+	`payment_count` INT NOT NULL DEFAULT '0',
+	`open_note` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`close_note` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`opened_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	`closed_at` TIMESTAMP NULL DEFAULT NULL,
+	`closed_by` BIGINT(19) NULL DEFAULT NULL,
+	`open_cashier_id` BIGINT(19) AS (IF(`status` = 'open', `cashier_id`, NULL)) STORED,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `uq_cashier_shifts_open_cashier` (`open_cashier_id`) USING BTREE,
+	INDEX `idx_cashier_shifts_outlet_opened_at` (`outlet_id`, `opened_at`) USING BTREE,
+	INDEX `idx_cashier_shifts_cashier` (`cashier_id`) USING BTREE,
+	CONSTRAINT `fk_cashier_shifts_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_cashier_shifts_cashier` FOREIGN KEY (`cashier_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_cashier_shifts_closer` FOREIGN KEY (`closed_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 40. Tabel PETTY_CASH_PAYOUTS (Pengeluaran Kas Kecil dari Laci selama Shift)
+CREATE TABLE `petty_cash_payouts` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`shift_id` BIGINT(19) NOT NULL,
+	`amount` DECIMAL(15,2) NOT NULL,
+	`description` VARCHAR(255) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`created_by` BIGINT(19) NOT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_petty_cash_payouts_shift` (`shift_id`) USING BTREE,
+	CONSTRAINT `fk_petty_cash_payouts_shift` FOREIGN KEY (`shift_id`) REFERENCES `cashier_shifts` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_petty_cash_payouts_creator` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 41. Kolom SHIFT pada pembayaran
+-- Diisi saat pembayaran dibuat kasir yang sedang membuka shift; pembayaran yang belum tertaut
+-- (dibuat selama shift) ditautkan otomatis saat shift ditutup.
+ALTER TABLE `payments`
+	ADD COLUMN `shift_id` BIGINT(19) NULL DEFAULT NULL AFTER `outlet_id`,
+	ADD INDEX `idx_payments_shift` (`shift_id`) USING BTREE,
+	ADD CONSTRAINT `fk_payments_shift` FOREIGN KEY (`shift_id`) REFERENCES `cashier_shifts` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL;
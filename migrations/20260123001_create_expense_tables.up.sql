@@ -0,0 +1,47 @@
+-- 42. Tabel EXPENSE_CATEGORIES (Kategori Biaya Operasional)
+CREATE TABLE `expense_categories` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`category_name` VARCHAR(100) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`description` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `category_name` (`category_name`) USING BTREE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+INSERT INTO `expense_categories` (`category_name`, `description`) VALUES
+	('Deterjen & Bahan', 'Deterjen, pewangi, pelembut, plastik kemasan'),
+	('Listrik', 'Tagihan listrik outlet'),
+	('Gas', 'Gas untuk pengering & setrika uap'),
+	('BBM Kurir', 'Bahan bakar kendaraan antar jemput'),
+	('Lain-lain', NULL);
+
+-- 43. Tabel EXPENSES (Pengeluaran Operasional per Outlet)
+-- attachment_ref menyimpan referensi bukti (nomor nota / URL file), bukan file-nya.
+CREATE TABLE `expenses` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`outlet_id` BIGINT(19) NOT NULL,
+	`expense_category_id` BIGINT(19) NOT NULL,
+	`amount` DECIMAL(15,2) NOT NULL,
+	`expense_date` DATE NOT NULL,
+	`description` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`attachment_ref` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`created_by` BIGINT(19) NOT NULL,
+	`updated_by` BIGINT(19) NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_expenses_outlet_date` (`outlet_id`, `expense_date`) USING BTREE,
+	INDEX `idx_expenses_category` (`expense_category_id`) USING BTREE,
+	CONSTRAINT `fk_expenses_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_expenses_category` FOREIGN KEY (`expense_category_id`) REFERENCES `expense_categories` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_expenses_creator` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_expenses_updater` FOREIGN KEY (`updated_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS service_consumptions;
+DROP TABLE IF EXISTS inventory_movements;
+DROP TABLE IF EXISTS inventory_stocks;
+DROP TABLE IF EXISTS inventory_items;
@@ -0,0 +1,78 @@
+-- 44. Tabel INVENTORY_ITEMS (Katalog Bahan Habis Pakai)
+-- min_stock adalah batas peringatan stok menipis, berlaku untuk setiap outlet.
+CREATE TABLE `inventory_items` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`item_name` VARCHAR(100) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`unit` VARCHAR(20) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`min_stock` DECIMAL(15,3) NOT NULL DEFAULT '0.000',
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `item_name` (`item_name`) USING BTREE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 45. Tabel INVENTORY_STOCKS (Stok Berjalan per Outlet)
+-- Nilai berjalan saja; sumber kebenaran tetap tabel inventory_movements (stok = SUM in - SUM out).
+CREATE TABLE `inventory_stocks` (
+	`outlet_id` BIGINT(19) NOT NULL,
+	`inventory_item_id` BIGINT(19) NOT NULL,
+	`quantity` DECIMAL(15,3) NOT NULL DEFAULT '0.000',
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`outlet_id`, `inventory_item_id`) USING BTREE,
+	INDEX `idx_inventory_stocks_item` (`inventory_item_id`) USING BTREE,
+	CONSTRAINT `fk_inventory_stocks_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_inventory_stocks_item` FOREIGN KEY (`inventory_item_id`) REFERENCES `inventory_items` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 46. Tabel INVENTORY_MOVEMENTS (Ledger Mutasi Stok)
+-- Satu pesanan hanya memotong stok satu kali per bahan (unique_inventory_consumption_order).
+CREATE TABLE `inventory_movements` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`outlet_id` BIGINT(19) NOT NULL,
+	`inventory_item_id` BIGINT(19) NOT NULL,
+	`movement_type` ENUM('stock_in','consumption','adjustment') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`direction` ENUM('in','out') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`quantity` DECIMAL(15,3) NOT NULL,
+	`balance_before` DECIMAL(15,3) NOT NULL,
+	`balance_after` DECIMAL(15,3) NOT NULL,
+	`order_id` BIGINT(19) NULL DEFAULT NULL,
+	`reason` VARCHAR(255) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`actor_id` BIGINT(19) NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_inventory_movements_stock` (`outlet_id`, `inventory_item_id`, `id`) USING BTREE,
+	INDEX `idx_inventory_movements_item` (`inventory_item_id`) USING BTREE,
+	UNIQUE INDEX `unique_inventory_consumption_order` (`order_id`, `inventory_item_id`, `movement_type`) USING BTREE,
+	CONSTRAINT `fk_inventory_movements_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_inventory_movements_item` FOREIGN KEY (`inventory_item_id`) REFERENCES `inventory_items` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_inventory_movements_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+	CONSTRAINT `fk_inventory_movements_actor` FOREIGN KEY (`actor_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 47. Tabel SERVICE_CONSUMPTIONS (Resep Pemakaian Bahan per Layanan)
+-- quantity_per_unit dikalikan berat (kg) atau jumlah (pcs) item pesanan, cth: 30 ml deterjen per kg.
+CREATE TABLE `service_consumptions` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`service_id` BIGINT(19) NOT NULL,
+	`inventory_item_id` BIGINT(19) NOT NULL,
+	`quantity_per_unit` DECIMAL(15,3) NOT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_service_consumption` (`service_id`, `inventory_item_id`) USING BTREE,
+	INDEX `idx_service_consumptions_item` (`inventory_item_id`) USING BTREE,
+	CONSTRAINT `fk_service_consumptions_service` FOREIGN KEY (`service_id`) REFERENCES `services` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_service_consumptions_item` FOREIGN KEY (`inventory_item_id`) REFERENCES `inventory_items` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS outlet_holidays;
+DROP TABLE IF EXISTS outlet_operating_hours;
+DROP TABLE IF EXISTS machines;
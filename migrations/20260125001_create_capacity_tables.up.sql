@@ -0,0 +1,52 @@
+-- 48. Tabel MACHINES (Mesin Cuci & Pengering per Outlet)
+-- Kapasitas workshop = SUM(capacity_kg x 60 / cycle_minutes) mesin aktif; jenis mesin paling lambat menjadi batasnya.
+CREATE TABLE `machines` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`outlet_id` BIGINT(19) NOT NULL,
+	`machine_name` VARCHAR(100) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`machine_type` ENUM('washer','dryer') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`capacity_kg` DECIMAL(8,2) NOT NULL,
+	`cycle_minutes` INT(10) NOT NULL,
+	`is_active` TINYINT(1) NULL DEFAULT '1',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_machine_name_outlet` (`outlet_id`, `machine_name`) USING BTREE,
+	CONSTRAINT `fk_machines_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 49. Tabel OUTLET_OPERATING_HOURS (Jam Operasional per Hari)
+-- weekday mengikuti time.Weekday Go: 0 = Minggu ... 6 = Sabtu.
+-- Outlet tanpa baris di sini dianggap buka 24 jam setiap hari.
+CREATE TABLE `outlet_operating_hours` (
+	`outlet_id` BIGINT(19) NOT NULL,
+	`weekday` TINYINT(3) NOT NULL,
+	`open_time` TIME NOT NULL DEFAULT '00:00:00',
+	`close_time` TIME NOT NULL DEFAULT '00:00:00',
+	`is_closed` TINYINT(1) NOT NULL DEFAULT '0',
+	PRIMARY KEY (`outlet_id`, `weekday`) USING BTREE,
+	CONSTRAINT `fk_outlet_operating_hours_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 50. Tabel OUTLET_HOLIDAYS (Hari Libur)
+-- outlet_id NULL = libur berlaku untuk semua outlet (cth: libur nasional).
+CREATE TABLE `outlet_holidays` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`outlet_id` BIGINT(19) NULL DEFAULT NULL,
+	`holiday_date` DATE NOT NULL,
+	`description` VARCHAR(150) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_outlet_holidays_date` (`holiday_date`) USING BTREE,
+	INDEX `idx_outlet_holidays_outlet` (`outlet_id`) USING BTREE,
+	CONSTRAINT `fk_outlet_holidays_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS wash_batch_items;
+DROP TABLE IF EXISTS wash_batches;
+ALTER TABLE machines DROP COLUMN maintenance_since, DROP COLUMN maintenance_note, DROP COLUMN under_maintenance;
@@ -0,0 +1,54 @@
+-- 51. Kolom MAINTENANCE pada MACHINES
+-- Mesin yang sedang diperbaiki tidak dihitung sebagai kapasitas workshop dan tidak bisa memulai batch.
+ALTER TABLE `machines`
+	ADD COLUMN `under_maintenance` TINYINT(1) NOT NULL DEFAULT '0' AFTER `is_active`,
+	ADD COLUMN `maintenance_note` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci' AFTER `under_maintenance`,
+	ADD COLUMN `maintenance_since` TIMESTAMP NULL DEFAULT NULL AFTER `maintenance_note`;
+
+-- 52. Tabel WASH_BATCHES (Satu Siklus Mesin Cuci/Pengering)
+-- Satu mesin hanya boleh menjalankan satu batch: running_machine_id terisi selama batch 'running'
+-- dan dijaga UNIQUE INDEX (NULL boleh berulang untuk batch yang sudah selesai).
+CREATE TABLE `wash_batches` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`outlet_id` BIGINT(19) NOT NULL,
+	`machine_id` BIGINT(19) NOT NULL,
+	`batch_status` ENUM('running','finished') NOT NULL DEFAULT 'running' COLLATE 'utf8mb4_0900_ai_ci',
+	`running_machine_id` BIGINT(19) GENERATED ALWAYS AS (IF(`batch_status` = 'running', `machine_id`, NULL)) STORED,
+	`load_kg` DECIMAL(8,2) NOT NULL DEFAULT '0.00',
+	`notes` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`started_by` BIGINT(19) NOT NULL,
+	`started_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	`finished_by` BIGINT(19) NULL DEFAULT NULL,
+	`finished_at` TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_running_machine` (`running_machine_id`) USING BTREE,
+	INDEX `idx_wash_batches_outlet_started` (`outlet_id`, `started_at`) USING BTREE,
+	INDEX `idx_wash_batches_machine_started` (`machine_id`, `started_at`) USING BTREE,
+	CONSTRAINT `fk_wash_batches_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_wash_batches_machine` FOREIGN KEY (`machine_id`) REFERENCES `machines` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_wash_batches_started_by` FOREIGN KEY (`started_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_wash_batches_finished_by` FOREIGN KEY (`finished_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 53. Tabel WASH_BATCH_ITEMS (Item Pesanan di dalam Batch)
+-- Satu item bisa masuk beberapa batch berurutan (cuci lalu kering), tetapi hanya sekali per batch.
+CREATE TABLE `wash_batch_items` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`batch_id` BIGINT(19) NOT NULL,
+	`order_id` BIGINT(19) NOT NULL,
+	`order_item_id` BIGINT(19) NOT NULL,
+	`load_kg` DECIMAL(8,2) NOT NULL DEFAULT '0.00',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_batch_order_item` (`batch_id`, `order_item_id`) USING BTREE,
+	INDEX `idx_wash_batch_items_order` (`order_id`) USING BTREE,
+	INDEX `idx_wash_batch_items_order_item` (`order_item_id`) USING BTREE,
+	CONSTRAINT `fk_wash_batch_items_batch` FOREIGN KEY (`batch_id`) REFERENCES `wash_batches` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_wash_batch_items_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_wash_batch_items_order_item` FOREIGN KEY (`order_item_id`) REFERENCES `order_items` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP INDEX idx_orders_status_ready;
+DELETE FROM webhook_subscriptions WHERE event_type IN ('order.overdue', 'order.unclaimed');
+ALTER TABLE webhook_subscriptions MODIFY COLUMN event_type ENUM('order.created','order.status_changed','payment.confirmed','delivery.finished') NOT NULL COLLATE 'utf8mb4_0900_ai_ci';
+DROP TABLE IF EXISTS order_sla_alerts;
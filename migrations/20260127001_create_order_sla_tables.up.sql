@@ -0,0 +1,33 @@
+-- 54. Tabel ORDER SLA ALERTS (Pesanan Terlambat & Cucian Tidak Diambil)
+-- Ditulis scheduler SLA. Satu baris per (pesanan, jenis, ambang hari); baris terbuka selama resolved_at NULL.
+-- overdue   : melewati estimated_ready_at tetapi belum siap (threshold_days selalu 0).
+-- unclaimed : sudah ready-pickup lebih dari threshold_days hari (dasar pemberitahuan pembuangan).
+-- reference_at = estimated_ready_at (overdue) atau saat pesanan siap diambil (unclaimed).
+CREATE TABLE `order_sla_alerts` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`outlet_id` BIGINT(19) NOT NULL,
+	`order_id` BIGINT(19) NOT NULL,
+	`alert_type` ENUM('overdue','unclaimed') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`threshold_days` INT(10) NOT NULL DEFAULT '0',
+	`status_internal` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`reference_at` TIMESTAMP NOT NULL,
+	`triggered_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	`resolved_at` TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_sla_alert` (`order_id`, `alert_type`, `threshold_days`) USING BTREE,
+	INDEX `idx_sla_alerts_open` (`alert_type`, `resolved_at`) USING BTREE,
+	INDEX `idx_sla_alerts_outlet` (`outlet_id`, `triggered_at`) USING BTREE,
+	CONSTRAINT `fk_sla_alerts_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_sla_alerts_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 55. Event webhook SLA
+ALTER TABLE `webhook_subscriptions`
+	MODIFY COLUMN `event_type` ENUM('order.created','order.status_changed','payment.confirmed','delivery.finished','order.overdue','order.unclaimed') NOT NULL COLLATE 'utf8mb4_0900_ai_ci';
+
+-- 56. Index ORDERS untuk pencarian pesanan aktif yang melewati estimasi selesai
+ALTER TABLE `orders`
+	ADD INDEX `idx_orders_status_ready` (`status_internal`, `estimated_ready_at`) USING BTREE;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS complaint_status_history;
+DROP TABLE IF EXISTS complaint_photos;
+DROP TABLE IF EXISTS complaints;
+DELETE FROM wallet_ledger WHERE entry_type = 'compensation';
+ALTER TABLE wallet_ledger MODIFY COLUMN entry_type ENUM('topup','spend','refund','adjustment') NOT NULL COLLATE 'utf8mb4_0900_ai_ci';
+ALTER TABLE orders DROP FOREIGN KEY fk_orders_parent;
+ALTER TABLE orders DROP INDEX fk_orders_parent;
+ALTER TABLE orders DROP COLUMN parent_order_id;
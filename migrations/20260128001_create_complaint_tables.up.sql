@@ -0,0 +1,104 @@
+-- 57. Tabel COMPLAINTS (Komplain Pelanggan: Noda, Rusak, Hilang, Cuci Ulang)
+-- Terikat ke satu pesanan dan (opsional) satu baris order_items.
+-- Alur status: open -> investigating -> resolved / rejected (investigating boleh dilewati).
+-- Kolom resolution_* diisi saat resolved:
+--   rework       : rework_order_id = pesanan anak tanpa biaya (orders.parent_order_id = order_id)
+--   compensation : compensation_amount + compensation_method; cash/transfer -> expense_id, deposit -> wallet_entry_id
+--   voucher      : voucher_promotion_id = kode voucher sekali pakai di tabel promotions
+CREATE TABLE `complaints` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`outlet_id` BIGINT(19) NOT NULL,
+	`order_id` BIGINT(19) NOT NULL,
+	`order_item_id` BIGINT(19) NULL DEFAULT NULL,
+	`complaint_type` ENUM('damage','missing','rework','other') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`description` TEXT NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`complaint_status` ENUM('open','investigating','resolved','rejected') NOT NULL DEFAULT 'open' COLLATE 'utf8mb4_0900_ai_ci',
+	`resolution_type` ENUM('rework','compensation','voucher') NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`resolution_note` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`rework_order_id` BIGINT(19) NULL DEFAULT NULL,
+	`compensation_amount` DECIMAL(15,2) NULL DEFAULT NULL,
+	`compensation_method` ENUM('cash','transfer','deposit') NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`expense_id` BIGINT(19) NULL DEFAULT NULL,
+	`wallet_entry_id` BIGINT(19) NULL DEFAULT NULL,
+	`voucher_promotion_id` BIGINT(19) NULL DEFAULT NULL,
+	`reported_by` BIGINT(19) NOT NULL,
+	`resolved_by` BIGINT(19) NULL DEFAULT NULL,
+	`resolved_at` TIMESTAMP NULL DEFAULT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `idx_complaints_outlet_created_at` (`outlet_id`, `created_at`) USING BTREE,
+	INDEX `idx_complaints_status` (`complaint_status`) USING BTREE,
+	INDEX `fk_complaints_order` (`order_id`) USING BTREE,
+	INDEX `fk_complaints_order_item` (`order_item_id`) USING BTREE,
+	INDEX `fk_complaints_rework_order` (`rework_order_id`) USING BTREE,
+	INDEX `fk_complaints_expense` (`expense_id`) USING BTREE,
+	INDEX `fk_complaints_wallet_entry` (`wallet_entry_id`) USING BTREE,
+	INDEX `fk_complaints_voucher` (`voucher_promotion_id`) USING BTREE,
+	INDEX `fk_complaints_reporter` (`reported_by`) USING BTREE,
+	INDEX `fk_complaints_resolver` (`resolved_by`) USING BTREE,
+	CONSTRAINT `fk_complaints_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_complaints_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_complaints_order_item` FOREIGN KEY (`order_item_id`) REFERENCES `order_items` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+	CONSTRAINT `fk_complaints_rework_order` FOREIGN KEY (`rework_order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+	CONSTRAINT `fk_complaints_expense` FOREIGN KEY (`expense_id`) REFERENCES `expenses` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+	CONSTRAINT `fk_complaints_wallet_entry` FOREIGN KEY (`wallet_entry_id`) REFERENCES `wallet_ledger` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+	CONSTRAINT `fk_complaints_voucher` FOREIGN KEY (`voucher_promotion_id`) REFERENCES `promotions` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+	CONSTRAINT `fk_complaints_reporter` FOREIGN KEY (`reported_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_complaints_resolver` FOREIGN KEY (`resolved_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 58. Tabel COMPLAINT_PHOTOS (Foto Bukti Komplain)
+-- photo_url menyimpan referensi foto (URL / path), bukan file-nya.
+CREATE TABLE `complaint_photos` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`complaint_id` BIGINT(19) NOT NULL,
+	`photo_url` VARCHAR(500) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`caption` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`uploaded_by` BIGINT(19) NOT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `fk_complaint_photos_complaint` (`complaint_id`) USING BTREE,
+	INDEX `fk_complaint_photos_uploader` (`uploaded_by`) USING BTREE,
+	CONSTRAINT `fk_complaint_photos_complaint` FOREIGN KEY (`complaint_id`) REFERENCES `complaints` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_complaint_photos_uploader` FOREIGN KEY (`uploaded_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 59. Tabel COMPLAINT_STATUS_HISTORY (Audit Trail Status Komplain)
+CREATE TABLE `complaint_status_history` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`complaint_id` BIGINT(19) NOT NULL,
+	`previous_status` VARCHAR(20) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`new_status` VARCHAR(20) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`actor_id` BIGINT(19) NULL DEFAULT NULL,
+	`notes` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `fk_complaint_history_complaint` (`complaint_id`) USING BTREE,
+	INDEX `fk_complaint_history_actor` (`actor_id`) USING BTREE,
+	CONSTRAINT `fk_complaint_history_complaint` FOREIGN KEY (`complaint_id`) REFERENCES `complaints` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+	CONSTRAINT `fk_complaint_history_actor` FOREIGN KEY (`actor_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
+
+-- 60. Pesanan cuci ulang (rework) menunjuk pesanan asalnya
+ALTER TABLE `orders`
+	ADD COLUMN `parent_order_id` BIGINT(19) NULL DEFAULT NULL AFTER `outlet_id`,
+	ADD INDEX `fk_orders_parent` (`parent_order_id`) USING BTREE,
+	ADD CONSTRAINT `fk_orders_parent` FOREIGN KEY (`parent_order_id`) REFERENCES `orders` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT;
+
+-- 61. Kompensasi komplain ke saldo deposit pelanggan
+ALTER TABLE `wallet_ledger`
+	MODIFY COLUMN `entry_type` ENUM('topup','spend','refund','adjustment','compensation') NOT NULL COLLATE 'utf8mb4_0900_ai_ci';
+
+-- 62. Kategori biaya untuk kompensasi tunai / transfer
+INSERT IGNORE INTO `expense_categories` (`category_name`, `description`) VALUES
+	('Kompensasi Pelanggan', 'Ganti rugi komplain pelanggan (tunai / transfer)');
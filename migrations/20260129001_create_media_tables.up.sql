@@ -0,0 +1,30 @@
+-- 63. Tabel MEDIA (Foto Bukti Antar, Foto Kondisi Item, Foto Karyawan)
+-- File-nya disimpan di storage (MEDIA_DRIVER: disk lokal / S3-compatible), tabel ini hanya menyimpan key objek.
+-- owner_type + owner_id menunjuk ke deliveries.id, order_items.id, atau users.id (tanpa FK, seperti tax_rate_targets).
+-- outlet_id disalin dari pemilik saat upload (NULL untuk foto owner yang tidak terikat outlet).
+CREATE TABLE `media` (
+	`id` BIGINT(19) NOT NULL AUTO_INCREMENT,
+	`outlet_id` BIGINT(19) NULL DEFAULT NULL,
+	`owner_type` ENUM('delivery','order_item','user') NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`owner_id` BIGINT(19) NOT NULL,
+	`object_key` VARCHAR(255) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`thumbnail_key` VARCHAR(255) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`content_type` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`size_bytes` BIGINT(19) NOT NULL,
+	`width` INT(10) NOT NULL,
+	`height` INT(10) NOT NULL,
+	`checksum_sha256` CHAR(64) NOT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`caption` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_0900_ai_ci',
+	`uploaded_by` BIGINT(19) NOT NULL,
+	`created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE INDEX `unique_media_object_key` (`object_key`) USING BTREE,
+	INDEX `idx_media_owner` (`owner_type`, `owner_id`, `created_at`) USING BTREE,
+	INDEX `fk_media_outlet` (`outlet_id`) USING BTREE,
+	INDEX `fk_media_uploader` (`uploaded_by`) USING BTREE,
+	CONSTRAINT `fk_media_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT,
+	CONSTRAINT `fk_media_uploader` FOREIGN KEY (`uploaded_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1 @@
+ALTER TABLE payments DROP INDEX idx_payments_outlet_paid_at, DROP COLUMN paid_at;
@@ -0,0 +1,11 @@
+-- 64. Kolom PAID_AT pada pembayaran
+-- Waktu pembayaran dikonfirmasi (lunas). Laporan pendapatan membagi hari berdasarkan kolom ini,
+-- bukan waktu nota dibuat, sehingga pelunasan nota lama masuk ke hari uangnya diterima.
+ALTER TABLE `payments`
+	ADD COLUMN `paid_at` TIMESTAMP NULL DEFAULT NULL AFTER `status`,
+	ADD INDEX `idx_payments_outlet_paid_at` (`outlet_id`, `paid_at`) USING BTREE;
+
+-- Pembayaran lama yang sudah confirmed: pakai waktu terima COD, lalu waktu update terakhir
+UPDATE `payments`
+SET `paid_at` = COALESCE(`collected_at`, `updated_at`, `created_at`)
+WHERE `status` = 'confirmed';
@@ -0,0 +1 @@
+ALTER TABLE order_sla_alerts DROP FOREIGN KEY fk_sla_alerts_acknowledger, DROP INDEX idx_sla_alerts_inbox, DROP COLUMN acknowledged_at, DROP COLUMN acknowledged_by;
@@ -0,0 +1,8 @@
+-- 65. Kolom ACKNOWLEDGED pada ORDER SLA ALERTS
+-- Peringatan SLA juga tampil di aplikasi (GET /orders/sla-alerts) untuk owner/kasir/staff outlet.
+-- Staff menandai peringatan sudah ditindaklanjuti; peringatan yang dibuka ulang scheduler kembali belum dibaca.
+ALTER TABLE `order_sla_alerts`
+	ADD COLUMN `acknowledged_by` BIGINT(19) NULL DEFAULT NULL AFTER `resolved_at`,
+	ADD COLUMN `acknowledged_at` TIMESTAMP NULL DEFAULT NULL AFTER `acknowledged_by`,
+	ADD INDEX `idx_sla_alerts_inbox` (`outlet_id`, `resolved_at`, `acknowledged_at`) USING BTREE,
+	ADD CONSTRAINT `fk_sla_alerts_acknowledger` FOREIGN KEY (`acknowledged_by`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL;
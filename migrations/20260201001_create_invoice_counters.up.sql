@@ -0,0 +1,14 @@
+-- 66. Tabel INVOICE COUNTERS (Penghitung nomor nota per outlet per hari)
+-- Satu baris per (outlet, hari kalender WIB). Nomor berikutnya diambil dengan
+-- INSERT ... ON DUPLICATE KEY UPDATE seq = LAST_INSERT_ID(seq + 1): hanya baris penghitung itu yang terkunci
+-- sampai transaksi pesanan selesai, tanpa gap lock pada tabel orders.
+CREATE TABLE `invoice_counters` (
+	`outlet_id` BIGINT(19) NOT NULL,
+	`invoice_day` DATE NOT NULL,
+	`seq` INT(10) UNSIGNED NOT NULL,
+	PRIMARY KEY (`outlet_id`, `invoice_day`) USING BTREE,
+	CONSTRAINT `fk_invoice_counters_outlet` FOREIGN KEY (`outlet_id`) REFERENCES `outlets` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+)
+COLLATE='utf8mb4_0900_ai_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1 @@
+ALTER TABLE idempotency_keys MODIFY COLUMN idempotency_key VARCHAR(255) NOT NULL COLLATE 'utf8mb4_0900_ai_ci';
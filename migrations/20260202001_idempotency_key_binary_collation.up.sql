@@ -0,0 +1,7 @@
+-- 67. Kolom IDEMPOTENCY_KEY dibandingkan persis (case-sensitive)
+-- Dengan collation utf8mb4_0900_ai_ci key "abc" dan "ABC" (atau "é" dan "e") dianggap sama, sehingga
+-- dua operasi berbeda bisa saling memutar ulang balasan. utf8mb4_bin membandingkan byte per byte.
+-- Baris lama tidak perlu diubah: key yang unik secara ci pasti juga unik secara bin. Rollback (down) gagal
+-- jika sudah ada key yang hanya berbeda huruf besar/kecil; hapus baris tersebut lebih dulu.
+ALTER TABLE `idempotency_keys`
+	MODIFY COLUMN `idempotency_key` VARCHAR(255) NOT NULL COLLATE 'utf8mb4_bin';
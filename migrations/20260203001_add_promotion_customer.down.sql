@@ -0,0 +1,2 @@
+ALTER TABLE `promotions` DROP FOREIGN KEY `fk_promotions_customer`;
+ALTER TABLE `promotions` DROP INDEX `idx_promotions_customer`, DROP COLUMN `customer_id`;
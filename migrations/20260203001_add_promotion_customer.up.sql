@@ -0,0 +1,7 @@
+-- 68. Kolom CUSTOMER_ID di PROMOTIONS (voucher khusus satu pelanggan)
+-- NULL = boleh dipakai semua pelanggan. Terisi untuk voucher kompensasi komplain, sehingga kode yang
+-- bocor tidak bisa dipakai pelanggan lain; RedeemTx menolak pesanan dengan pelanggan berbeda / tanpa pelanggan.
+ALTER TABLE `promotions`
+	ADD COLUMN `customer_id` BIGINT(19) NULL DEFAULT NULL AFTER `per_customer_limit`,
+	ADD INDEX `idx_promotions_customer` (`customer_id`) USING BTREE,
+	ADD CONSTRAINT `fk_promotions_customer` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`) ON UPDATE NO ACTION ON DELETE RESTRICT;
@@ -0,0 +1,83 @@
+package listquery
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cursor adalah isi token ?cursor= (JSON lalu base64url). Bagi klien token ini opaque.
+type cursor struct {
+	Sort   string `json:"s"`
+	Order  string `json:"o"`
+	Value  string `json:"v"`
+	ID     int64  `json:"id"`
+	Filter string `json:"f"`
+}
+
+// errInvalidCursor dipetakan ke response.ErrValidation oleh Build.
+var errInvalidCursor = errors.New("invalid cursor")
+
+func encodeCursor(c *cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(token string) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID <= 0 {
+		return nil, errInvalidCursor
+	}
+	return &c, nil
+}
+
+// cursorValue menyimpan nilai kolom sort sebagai teks yang bisa dibandingkan kembali oleh MySQL
+// (DATETIME dari string "2006-01-02 15:04:05", angka & DECIMAL dari teks angka, VARCHAR apa adanya).
+func cursorValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.000000")
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format("2006-01-02 15:04:05.000000")
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// signature mengikat cursor ke pencarian & filter yang sama, agar halaman lanjutan tidak tercampur
+// dengan hasil filter lain.
+func signature(search string, applied []string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(search) + "\n" + strings.Join(applied, "&")))
+	return hex.EncodeToString(hash[:6])
+}
+
+func sortedKeys(filters map[string]Filter) []string {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
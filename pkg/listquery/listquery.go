@@ -0,0 +1,390 @@
+// Package listquery menyusun bagian WHERE / ORDER BY / LIMIT untuk endpoint list secara deklaratif.
+//
+// Setiap repository cukup mendeklarasikan Spec (kolom pencarian, filter, dan kolom sorting yang
+// diizinkan), lalu Build mengubah parameter request menjadi potongan SQL yang aman:
+// nama kolom hanya diambil dari Spec, semua nilai dari user selalu dikirim sebagai argumen "?".
+//
+// Dua mode paginasi didukung:
+//   - offset (page & per_page): cocok untuk tabel kecil yang butuh total_pages
+//   - cursor/keyset (cursor & per_page): cepat di tabel besar karena tidak memakai OFFSET
+//
+// Pada kedua mode, data diambil per_page+1 baris untuk mengetahui ada halaman berikutnya atau tidak,
+// sehingga COUNT(*) bisa dilewati pada tabel besar.
+package listquery
+
+import (
+	"fmt"
+	"laundry-backend/pkg/response"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Batas bawaan jumlah baris per halaman
+const (
+	DefaultPerPage = 10
+	MaxPerPage     = 100
+)
+
+// Arah sorting
+const (
+	OrderAsc  = "ASC"
+	OrderDesc = "DESC"
+)
+
+// Op menentukan cara nilai filter dibandingkan dengan kolom.
+type Op string
+
+const (
+	// OpEqual: kolom = nilai
+	OpEqual Op = "eq"
+	// OpIn: kolom IN (nilai1, nilai2, ...) dari nilai yang dipisah koma
+	OpIn Op = "in"
+)
+
+// Filter mendeklarasikan satu query param yang boleh dipakai untuk menyaring data.
+type Filter struct {
+	Column  string   // Kolom SQL, cth: "s.is_active"
+	Op      Op       // Default OpEqual
+	Allowed []string // Nilai yang diizinkan; nilai lain diabaikan (kosong = semua nilai diterima)
+
+	// Expr dipakai jika filter tidak cukup satu kolom, cth: "EXISTS (... WHERE l.service_id = ?)".
+	// Setiap "?" diisi nilai filter. Jika diisi, Column & Op diabaikan.
+	Expr string
+}
+
+// Spec adalah deklarasi kemampuan list sebuah tabel.
+type Spec struct {
+	Search  []string          // Kolom yang dicari ?search= (LIKE, case-insensitive)
+	Filters map[string]Filter // Nama query param -> filter
+	Sorts   map[string]string // Nilai ?sort_by= -> kolom SQL
+
+	DefaultSort  string // Key di Sorts yang dipakai jika sort_by kosong/tidak dikenal
+	DefaultOrder string // OrderAsc / OrderDesc
+
+	// IDColumn adalah kolom unik (biasanya primary key) sebagai pemecah seri sorting & bagian dari cursor
+	IDColumn string
+
+	// SkipCount: jangan hitung COUNT(*) secara default (tabel besar seperti orders/payments).
+	// Klien tetap bisa meminta total dengan ?with_count=true.
+	SkipCount bool
+}
+
+// Params adalah parameter list dari request.
+type Params struct {
+	Page      int
+	PerPage   int
+	Cursor    string
+	Search    string
+	Filters   map[string]string
+	SortBy    string
+	SortOrder string
+	WithCount *bool // nil = ikuti default Spec & mode paginasi
+}
+
+// ParseParams membaca parameter list standar dari query string:
+// page, per_page, cursor, search, sort_by, sort_order (alias lama: order), with_count,
+// serta query param filter yang namanya disebutkan.
+func ParseParams(values url.Values, filterNames ...string) Params {
+	params := Params{
+		Cursor:    values.Get("cursor"),
+		Search:    values.Get("search"),
+		SortBy:    values.Get("sort_by"),
+		SortOrder: values.Get("sort_order"),
+		Filters:   make(map[string]string, len(filterNames)),
+	}
+	if params.SortOrder == "" {
+		params.SortOrder = values.Get("order")
+	}
+
+	// Angka yang tidak valid jatuh ke nilai default (sama seperti handler lama)
+	params.Page, _ = strconv.Atoi(values.Get("page"))
+	params.PerPage, _ = strconv.Atoi(values.Get("per_page"))
+
+	if raw := values.Get("with_count"); raw != "" {
+		if withCount, err := strconv.ParseBool(raw); err == nil {
+			params.WithCount = &withCount
+		}
+	}
+
+	for _, name := range filterNames {
+		if value := values.Get(name); value != "" {
+			params.Filters[name] = value
+		}
+	}
+
+	return params
+}
+
+// Query adalah hasil Build yang siap dirangkai repository.
+type Query struct {
+	Page     int // 0 pada mode cursor
+	PerPage  int
+	Count    bool // true jika repository perlu menjalankan COUNT(*)
+	IsCursor bool
+
+	where     string
+	args      []interface{}
+	sortKey   string
+	sortCol   string
+	order     string
+	idColumn  string
+	filterSig string
+	after     *cursor
+}
+
+// Build memvalidasi parameter terhadap Spec dan menyiapkan potongan SQL.
+// Cursor yang rusak atau dipakai dengan filter berbeda menghasilkan response.ErrValidation.
+func (s Spec) Build(p Params) (*Query, error) {
+	q := &Query{
+		Page:     p.Page,
+		PerPage:  p.PerPage,
+		idColumn: s.IDColumn,
+	}
+
+	// 1. Batas halaman
+	if q.PerPage < 1 {
+		q.PerPage = DefaultPerPage
+	}
+	if q.PerPage > MaxPerPage {
+		q.PerPage = MaxPerPage
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+
+	// 2. Filter & pencarian (kolom dari Spec, nilai sebagai argumen)
+	conditions := []string{"1=1"}
+	if p.Search != "" && len(s.Search) > 0 {
+		likes := make([]string, 0, len(s.Search))
+		term := "%" + strings.ToLower(p.Search) + "%"
+		for _, column := range s.Search {
+			likes = append(likes, fmt.Sprintf("LOWER(%s) LIKE ?", column))
+			q.args = append(q.args, term)
+		}
+		conditions = append(conditions, "("+strings.Join(likes, " OR ")+")")
+	}
+
+	applied := make([]string, 0, len(s.Filters))
+	for _, name := range sortedKeys(s.Filters) {
+		value := p.Filters[name]
+		if value == "" {
+			continue
+		}
+		filter := s.Filters[name]
+		condition, args := filter.build(value)
+		if condition == "" {
+			continue
+		}
+		conditions = append(conditions, condition)
+		q.args = append(q.args, args...)
+		applied = append(applied, name+"="+value)
+	}
+	q.where = "WHERE " + strings.Join(conditions, " AND ")
+	q.filterSig = signature(p.Search, applied)
+
+	// 3. Sorting dari whitelist
+	q.sortKey, q.order = p.SortBy, strings.ToUpper(p.SortOrder)
+
+	// 4. Cursor membawa sorting-nya sendiri agar halaman berikutnya konsisten
+	if p.Cursor != "" {
+		after, err := decodeCursor(p.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor", response.ErrValidation)
+		}
+		if after.Filter != q.filterSig {
+			return nil, fmt.Errorf("%w: cursor was issued for different search or filter parameters", response.ErrValidation)
+		}
+		q.sortKey, q.order, q.after = after.Sort, after.Order, after
+		q.IsCursor = true
+	}
+
+	if _, ok := s.Sorts[q.sortKey]; !ok {
+		if q.after != nil {
+			return nil, fmt.Errorf("%w: invalid cursor", response.ErrValidation)
+		}
+		q.sortKey = s.DefaultSort
+	}
+	q.sortCol = s.Sorts[q.sortKey]
+	if q.order != OrderAsc && q.order != OrderDesc {
+		q.order = s.DefaultOrder
+	}
+
+	// 5. COUNT(*): default hanya pada mode offset & tabel kecil
+	q.Count = !s.SkipCount && !q.IsCursor
+	if p.WithCount != nil {
+		q.Count = *p.WithCount
+	}
+	if q.IsCursor {
+		q.Page = 0
+	}
+
+	return q, nil
+}
+
+// build menerjemahkan satu nilai filter menjadi kondisi SQL. Nilai yang tidak diizinkan diabaikan.
+func (f Filter) build(value string) (string, []interface{}) {
+	values := []string{value}
+	if f.Op == OpIn {
+		values = strings.Split(value, ",")
+	}
+
+	// 1. Buang nilai di luar whitelist
+	accepted := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || (len(f.Allowed) > 0 && !contains(f.Allowed, v)) {
+			continue
+		}
+		accepted = append(accepted, v)
+	}
+	if len(accepted) == 0 {
+		return "", nil
+	}
+
+	// 2. Ekspresi bebas: setiap "?" diisi nilai pertama
+	if f.Expr != "" {
+		args := make([]interface{}, strings.Count(f.Expr, "?"))
+		for i := range args {
+			args[i] = accepted[0]
+		}
+		return f.Expr, args
+	}
+
+	if f.Op == OpIn {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(accepted)), ",")
+		return fmt.Sprintf("%s IN (%s)", f.Column, placeholders), accepted
+	}
+	return fmt.Sprintf("%s = ?", f.Column), accepted[:1]
+}
+
+// Where mengembalikan klausa WHERE filter (tanpa kondisi cursor) untuk COUNT(*).
+func (q *Query) Where() (string, []interface{}) {
+	return q.where, append([]interface{}(nil), q.args...)
+}
+
+// Tail mengembalikan WHERE (termasuk kondisi cursor), ORDER BY, dan LIMIT untuk query data.
+// Repository cukup menulis "SELECT ... FROM ... " + tail.
+func (q *Query) Tail() (string, []interface{}) {
+	return q.tail(true)
+}
+
+// Unpaged sama seperti Tail tetapi tanpa LIMIT/OFFSET, untuk export yang dibaca baris per baris.
+func (q *Query) Unpaged() (string, []interface{}) {
+	return q.tail(false)
+}
+
+func (q *Query) tail(paged bool) (string, []interface{}) {
+	where, args := q.Where()
+
+	// 1. Keyset: lanjutkan tepat setelah baris terakhir halaman sebelumnya
+	if q.after != nil {
+		op := ">"
+		if q.order == OrderDesc {
+			op = "<"
+		}
+		if q.sortCol == q.idColumn {
+			where += fmt.Sprintf(" AND %s %s ?", q.idColumn, op)
+			args = append(args, q.after.ID)
+		} else {
+			where += fmt.Sprintf(" AND (%s %s ? OR (%s = ? AND %s %s ?))", q.sortCol, op, q.sortCol, q.idColumn, op)
+			args = append(args, q.after.Value, q.after.Value, q.after.ID)
+		}
+	}
+
+	// 2. Urutan selalu dipecah dengan ID agar stabil untuk keyset
+	orderBy := fmt.Sprintf("ORDER BY %s %s", q.sortCol, q.order)
+	if q.sortCol != q.idColumn {
+		orderBy += fmt.Sprintf(", %s %s", q.idColumn, q.order)
+	}
+
+	if !paged {
+		return fmt.Sprintf("%s %s", where, orderBy), args
+	}
+
+	// 3. Ambil satu baris ekstra untuk mendeteksi halaman berikutnya
+	limit := "LIMIT ?"
+	args = append(args, q.PerPage+1)
+	if !q.IsCursor {
+		limit += " OFFSET ?"
+		args = append(args, (q.Page-1)*q.PerPage)
+	}
+
+	return fmt.Sprintf("%s %s %s", where, orderBy, limit), args
+}
+
+// SortKey adalah key sort_by yang berlaku (setelah fallback / dari cursor).
+func (q *Query) SortKey() string {
+	return q.sortKey
+}
+
+// Result adalah info paginasi yang dikembalikan repository ke service.
+type Result struct {
+	query      *Query
+	TotalItems *int64
+	HasMore    bool
+	NextCursor string
+}
+
+// Paginate memotong baris ekstra dari hasil query dan menyiapkan cursor halaman berikutnya.
+// n adalah jumlah baris yang terbaca; last mengembalikan nilai kolom sort & ID baris ke-i.
+// Mengembalikan jumlah baris yang dipakai (maksimal PerPage).
+func (q *Query) Paginate(n int, totalItems *int64, last func(i int) (interface{}, int64)) (int, *Result) {
+	result := &Result{query: q, TotalItems: totalItems}
+
+	keep := n
+	if n > q.PerPage {
+		keep = q.PerPage
+		result.HasMore = true
+	}
+
+	// Cursor juga diberikan pada mode offset agar klien bisa pindah ke keyset kapan saja
+	if result.HasMore && keep > 0 {
+		value, id := last(keep - 1)
+		result.NextCursor = encodeCursor(&cursor{
+			Sort:   q.sortKey,
+			Order:  q.order,
+			Value:  cursorValue(value),
+			ID:     id,
+			Filter: q.filterSig,
+		})
+	}
+
+	return keep, result
+}
+
+// Meta mengubah Result menjadi response.MetaData.
+func (r *Result) Meta() response.MetaData {
+	q := r.query
+
+	// 1. Mode offset dengan COUNT: bentuk meta sama seperti sebelumnya (+ next_cursor jika ada)
+	if !q.IsCursor && r.TotalItems != nil {
+		meta := response.NewPageMeta(q.Page, q.PerPage, *r.TotalItems)
+		meta.NextCursor = r.NextCursor
+		return meta
+	}
+
+	// 2. Mode cursor / tanpa COUNT
+	hasMore := r.HasMore
+	meta := response.MetaData{
+		CurrentPage: q.Page,
+		PerPage:     q.PerPage,
+		TotalItems:  r.TotalItems,
+		NextCursor:  r.NextCursor,
+		HasMore:     &hasMore,
+	}
+	if r.TotalItems != nil {
+		totalPages := response.TotalPages(*r.TotalItems, q.PerPage)
+		meta.TotalPages = &totalPages
+	}
+	return meta
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
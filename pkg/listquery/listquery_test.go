@@ -0,0 +1,180 @@
+package listquery
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"laundry-backend/pkg/response"
+)
+
+var testSpec = Spec{
+	Search: []string{"o.invoice_number", "o.customer_name"},
+	Filters: map[string]Filter{
+		"payment_status":  {Column: "o.payment_status", Allowed: []string{"paid", "unpaid"}},
+		"status_internal": {Column: "o.status_internal", Op: OpIn},
+		"tag":             {Expr: "EXISTS (SELECT 1 FROM order_tags t WHERE t.order_id = o.id AND t.code = ?)"},
+	},
+	Sorts:        map[string]string{"created_at": "o.created_at", "id": "o.id"},
+	DefaultSort:  "created_at",
+	DefaultOrder: OrderDesc,
+	IDColumn:     "o.id",
+}
+
+func TestParseParams(t *testing.T) {
+
+	values := url.Values{
+		"page": {"2"}, "per_page": {"abc"}, "search": {"Romlah"}, "order": {"asc"},
+		"with_count": {"false"}, "payment_status": {"paid"}, "ignored": {"x"},
+	}
+	p := ParseParams(values, "payment_status", "status_internal")
+
+	if p.Page != 2 || p.PerPage != 0 || p.Search != "Romlah" || p.SortOrder != "asc" {
+		t.Fatalf("ParseParams = %+v", p)
+	}
+	if p.WithCount == nil || *p.WithCount {
+		t.Fatalf("WithCount = %v, want false", p.WithCount)
+	}
+	if !reflect.DeepEqual(p.Filters, map[string]string{"payment_status": "paid"}) {
+		t.Fatalf("Filters = %v", p.Filters)
+	}
+}
+
+func TestBuildOffset(t *testing.T) {
+
+	q, err := testSpec.Build(Params{
+		Page:      3,
+		PerPage:   500,
+		Search:    "ROM",
+		SortBy:    "drop table",
+		SortOrder: "sideways",
+		Filters: map[string]string{
+			"payment_status":  "refunded", // di luar whitelist: diabaikan
+			"status_internal": "pending, in-progress,",
+			"tag":             "A1",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if q.PerPage != MaxPerPage || q.Page != 3 || !q.Count || q.IsCursor {
+		t.Fatalf("Build = page %d per_page %d count %v cursor %v", q.Page, q.PerPage, q.Count, q.IsCursor)
+	}
+
+	sql, args := q.Tail()
+	wantSQL := "WHERE 1=1 AND (LOWER(o.invoice_number) LIKE ? OR LOWER(o.customer_name) LIKE ?)" +
+		" AND o.status_internal IN (?,?)" +
+		" AND EXISTS (SELECT 1 FROM order_tags t WHERE t.order_id = o.id AND t.code = ?)" +
+		" ORDER BY o.created_at DESC, o.id DESC LIMIT ? OFFSET ?"
+	if sql != wantSQL {
+		t.Fatalf("Tail SQL =\n%s\nwant\n%s", sql, wantSQL)
+	}
+	wantArgs := []interface{}{"%rom%", "%rom%", "pending", "in-progress", "A1", MaxPerPage + 1, 2 * MaxPerPage}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Fatalf("Tail args = %v, want %v", args, wantArgs)
+	}
+
+	// COUNT(*) tidak ikut LIMIT
+	where, countArgs := q.Where()
+	if len(countArgs) != 5 || where+" ORDER BY o.created_at DESC, o.id DESC LIMIT ? OFFSET ?" != sql {
+		t.Fatalf("Where = %s %v", where, countArgs)
+	}
+}
+
+func TestBuildDefaults(t *testing.T) {
+
+	q, err := Spec{Sorts: map[string]string{"id": "id"}, DefaultSort: "id", DefaultOrder: OrderAsc, IDColumn: "id", SkipCount: true}.Build(Params{})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if q.Page != 1 || q.PerPage != DefaultPerPage || q.Count {
+		t.Fatalf("Build = page %d per_page %d count %v", q.Page, q.PerPage, q.Count)
+	}
+	if sql, _ := q.Unpaged(); sql != "WHERE 1=1 ORDER BY id ASC" {
+		t.Fatalf("Unpaged = %q", sql)
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+
+	first, err := testSpec.Build(Params{PerPage: 2, SortBy: "created_at", SortOrder: "asc", Search: "rom"})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	// 3 baris terbaca untuk per_page 2: ada halaman berikutnya
+	createdAt := time.Date(2026, 1, 5, 13, 0, 0, 0, time.UTC)
+	keep, result := first.Paginate(3, nil, func(i int) (interface{}, int64) {
+		return createdAt, int64(10 + i)
+	})
+	if keep != 2 || !result.HasMore || result.NextCursor == "" {
+		t.Fatalf("Paginate = %d, %+v", keep, result)
+	}
+
+	// Halaman kedua mewarisi sorting dari cursor walaupun sort_by berbeda
+	next, err := testSpec.Build(Params{PerPage: 2, Cursor: result.NextCursor, SortBy: "id", Search: "ROM"})
+	if err != nil {
+		t.Fatalf("Build with cursor: %v", err)
+	}
+	if !next.IsCursor || next.Page != 0 || next.Count || next.SortKey() != "created_at" {
+		t.Fatalf("cursor query = %+v", next)
+	}
+
+	sql, args := next.Tail()
+	wantSQL := "WHERE 1=1 AND (LOWER(o.invoice_number) LIKE ? OR LOWER(o.customer_name) LIKE ?)" +
+		" AND (o.created_at > ? OR (o.created_at = ? AND o.id > ?))" +
+		" ORDER BY o.created_at ASC, o.id ASC LIMIT ?"
+	if sql != wantSQL {
+		t.Fatalf("Tail SQL =\n%s\nwant\n%s", sql, wantSQL)
+	}
+	wantArgs := []interface{}{"%rom%", "%rom%", "2026-01-05 13:00:00.000000", "2026-01-05 13:00:00.000000", int64(11), 3}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Fatalf("Tail args = %v, want %v", args, wantArgs)
+	}
+
+	meta := result.Meta()
+	if meta.HasMore == nil || !*meta.HasMore || meta.NextCursor != result.NextCursor || meta.TotalItems != nil {
+		t.Fatalf("Meta = %+v", meta)
+	}
+}
+
+func TestCursorRejected(t *testing.T) {
+
+	q, _ := testSpec.Build(Params{PerPage: 1, Filters: map[string]string{"payment_status": "paid"}})
+	_, result := q.Paginate(2, nil, func(i int) (interface{}, int64) { return "2026-01-05", 7 })
+
+	tests := []struct {
+		name   string
+		params Params
+	}{
+		{name: "garbage token", params: Params{Cursor: "!!!"}},
+		{name: "valid base64 but not a cursor", params: Params{Cursor: encodeCursor(&cursor{})}},
+		{name: "different filter", params: Params{Cursor: result.NextCursor, Filters: map[string]string{"payment_status": "unpaid"}}},
+		{name: "unknown sort inside cursor", params: Params{Cursor: encodeCursor(&cursor{Sort: "secret", ID: 1, Filter: signature("", nil)})}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := testSpec.Build(tt.params); !errors.Is(err, response.ErrValidation) {
+				t.Fatalf("Build error = %v, want ErrValidation", err)
+			}
+		})
+	}
+}
+
+func TestMetaOffsetWithCount(t *testing.T) {
+
+	q, _ := testSpec.Build(Params{Page: 2, PerPage: 10})
+	total := int64(25)
+	keep, result := q.Paginate(5, &total, func(i int) (interface{}, int64) { return nil, 0 })
+	if keep != 5 || result.HasMore {
+		t.Fatalf("Paginate = %d, %+v", keep, result)
+	}
+
+	meta := result.Meta()
+	if meta.CurrentPage != 2 || meta.TotalPages == nil || *meta.TotalPages != 3 || meta.HasMore != nil {
+		t.Fatalf("Meta = %+v", meta)
+	}
+}
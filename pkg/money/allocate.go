@@ -0,0 +1,65 @@
+package money
+
+import (
+	"math/big"
+	"sort"
+)
+
+// Allocate membagi total secara proporsional terhadap weights (cth: diskon pesanan ke setiap item)
+// dengan metode largest remainder, sehingga jumlah hasilnya selalu sama persis dengan total.
+// Sisa sen diberikan ke bobot dengan sisa pembagian terbesar; jika seri, indeks terkecil didahulukan.
+func Allocate(total Amount, weights []Amount) []Amount {
+	shares := make([]Amount, len(weights))
+	if len(weights) == 0 {
+		return shares
+	}
+
+	// 1. Jumlahkan bobot (bobot negatif dianggap nol)
+	var sum int64
+	for _, w := range weights {
+		if w > 0 {
+			sum += int64(w)
+		}
+	}
+	if sum == 0 {
+		shares[0] = total
+		return shares
+	}
+
+	// 2. Bagian dasar dibulatkan ke bawah (big.Int agar total x bobot tidak overflow)
+	type remainder struct {
+		index int
+		value *big.Int
+	}
+	remainders := make([]remainder, 0, len(weights))
+	bigTotal, bigSum := big.NewInt(int64(total.Abs())), big.NewInt(sum)
+
+	var allocated Amount
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		product := new(big.Int).Mul(bigTotal, big.NewInt(int64(w)))
+		quotient, rem := new(big.Int).QuoRem(product, bigSum, new(big.Int))
+		shares[i] = Amount(quotient.Int64())
+		allocated += shares[i]
+		remainders = append(remainders, remainder{index: i, value: rem})
+	}
+
+	// 3. Bagikan sisa sen satu per satu
+	sort.SliceStable(remainders, func(a, b int) bool {
+		return remainders[a].value.Cmp(remainders[b].value) > 0
+	})
+	for i := 0; allocated < total.Abs(); i++ {
+		shares[remainders[i%len(remainders)].index]++
+		allocated++
+	}
+
+	// 4. Kembalikan tanda total
+	if total < 0 {
+		for i := range shares {
+			shares[i] = -shares[i]
+		}
+	}
+	return shares
+}
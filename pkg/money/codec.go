@@ -0,0 +1,123 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// --- JSON ---
+
+// MarshalJSON menulis nominal sebagai angka JSON biasa agar format wire tetap sama dengan float64 sebelumnya.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON menerima angka JSON (63000, 1250.5) maupun string angka ("63000").
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+
+	parsed, err := parseNumber(string(data))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// --- DATABASE ---
+
+// Scan mengimplementasikan sql.Scanner. Driver MySQL mengirim DECIMAL sebagai []byte,
+// sehingga nilai dibaca langsung dari teks desimal tanpa melalui float.
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = 0
+		return nil
+	case []byte:
+		parsed, err := Parse(string(v))
+		if err != nil {
+			return err
+		}
+		*a = parsed
+	case string:
+		parsed, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*a = parsed
+	case int64:
+		*a = New(v)
+	case float64:
+		*a = FromFloat(v)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidAmount, src)
+	}
+	return nil
+}
+
+// Value mengimplementasikan driver.Valuer dan menulis nominal sebagai teks desimal eksak.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// NullAmount adalah Amount untuk kolom DECIMAL yang boleh NULL (mirip sql.NullFloat64).
+type NullAmount struct {
+	Amount Amount
+	Valid  bool
+}
+
+// Scan mengimplementasikan sql.Scanner.
+func (n *NullAmount) Scan(src interface{}) error {
+	if src == nil {
+		n.Amount, n.Valid = 0, false
+		return nil
+	}
+	n.Valid = true
+	return n.Amount.Scan(src)
+}
+
+// Value mengimplementasikan driver.Valuer.
+func (n NullAmount) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Amount.Value()
+}
+
+// Ptr mengembalikan pointer ke nominal, atau nil jika NULL.
+func (n NullAmount) Ptr() *Amount {
+	if !n.Valid {
+		return nil
+	}
+	value := n.Amount
+	return &value
+}
+
+// NullFrom membungkus pointer Amount menjadi NullAmount untuk parameter query.
+func NullFrom(a *Amount) NullAmount {
+	if a == nil {
+		return NullAmount{}
+	}
+	return NullAmount{Amount: *a, Valid: true}
+}
+
+// parseNumber menerima notasi angka JSON termasuk eksponen (cth: 1e3) yang tidak ditangani Parse.
+func parseNumber(text string) (Amount, error) {
+	parsed, err := Parse(text)
+	if err == nil {
+		return parsed, nil
+	}
+	value, floatErr := strconv.ParseFloat(text, 64)
+	if floatErr != nil {
+		return 0, err
+	}
+	return FromFloat(value), nil
+}
@@ -0,0 +1,244 @@
+// Package money menyediakan tipe nominal uang yang presisi untuk kolom DECIMAL(15,2).
+//
+// Amount disimpan sebagai bilangan bulat dalam satuan sen (1/100 rupiah), sehingga
+// penjumlahan ribuan item di laporan tidak mengalami galat float. Format JSON tetap
+// berupa angka biasa (cth: 63000 atau 1250.5) agar kontrak API tidak berubah.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Amount adalah nominal uang dalam satuan sen (100 = Rp1).
+type Amount int64
+
+// Zero adalah nominal nol.
+const Zero Amount = 0
+
+// scale adalah jumlah satuan sen dalam 1 rupiah (2 digit desimal).
+const scale = 100
+
+// ErrInvalidAmount dikembalikan jika teks tidak bisa dibaca sebagai nominal uang.
+var ErrInvalidAmount = errors.New("invalid money amount")
+
+// New membuat Amount dari rupiah utuh, cth: New(5000) = Rp5.000.
+func New(rupiah int64) Amount {
+	return Amount(rupiah * scale)
+}
+
+// FromMinor membuat Amount langsung dari satuan sen.
+func FromMinor(minor int64) Amount {
+	return Amount(minor)
+}
+
+// FromFloat mengonversi float64 ke Amount dengan pembulatan HalfUp ke 2 desimal.
+// Hanya dipakai di batas sistem (cth: query parameter), bukan untuk perhitungan.
+func FromFloat(value float64) Amount {
+	return Amount(math.Round(value * scale))
+}
+
+// Parse membaca teks desimal ("12500", "12500.5", "-3.25") secara eksak tanpa melalui float.
+// Digit di belakang 2 desimal dibulatkan HalfUp.
+func Parse(text string) (Amount, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, ErrInvalidAmount
+	}
+
+	// 1. Pisahkan tanda
+	negative := false
+	switch text[0] {
+	case '-':
+		negative = true
+		text = text[1:]
+	case '+':
+		text = text[1:]
+	}
+
+	// 2. Pisahkan bagian bulat & desimal
+	whole, frac, _ := strings.Cut(text, ".")
+	if whole == "" && frac == "" {
+		return 0, ErrInvalidAmount
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if !isDigits(whole) || (frac != "" && !isDigits(frac)) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, text)
+	}
+
+	wholeValue, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || wholeValue > math.MaxInt64/scale-1 {
+		return 0, fmt.Errorf("%w: %q out of range", ErrInvalidAmount, text)
+	}
+
+	// 3. Ambil 2 digit desimal, digit ketiga menentukan pembulatan HalfUp
+	padded := frac + "00"
+	minor := wholeValue*scale + int64(padded[0]-'0')*10 + int64(padded[1]-'0')
+	if len(frac) > 2 && frac[2] >= '5' {
+		minor++
+	}
+
+	if negative {
+		minor = -minor
+	}
+	return Amount(minor), nil
+}
+
+// MustParse sama seperti Parse tetapi panic jika gagal (hanya untuk konstanta/seed).
+func MustParse(text string) Amount {
+	a, err := Parse(text)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// Minor mengembalikan nilai dalam satuan sen.
+func (a Amount) Minor() int64 {
+	return int64(a)
+}
+
+// Float64 mengonversi ke float64. Hanya untuk tampilan/ekspor, jangan dipakai menghitung.
+func (a Amount) Float64() float64 {
+	return float64(a) / scale
+}
+
+// String memformat nominal sebagai desimal tanpa nol berlebih, cth: "63000", "1250.5", "-3.25".
+func (a Amount) String() string {
+	minor := int64(a)
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	whole, frac := minor/scale, minor%scale
+	if frac == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	if frac%10 == 0 {
+		return fmt.Sprintf("%s%d.%d", sign, whole, frac/10)
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, whole, frac)
+}
+
+// Rupiah memformat nominal dengan pemisah ribuan titik (dibulatkan ke rupiah), cth: "Rp50.000".
+func (a Amount) Rupiah() string {
+	rounded := a.RoundTo(New(1), HalfUp)
+	whole := int64(rounded) / scale
+
+	sign := ""
+	if whole < 0 {
+		sign = "-"
+		whole = -whole
+	}
+
+	digits := strconv.FormatInt(whole, 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+
+	return sign + "Rp" + b.String()
+}
+
+// --- ARITMATIKA ---
+
+// Add menjumlahkan dua nominal.
+func (a Amount) Add(b Amount) Amount { return a + b }
+
+// Sub mengurangi nominal.
+func (a Amount) Sub(b Amount) Amount { return a - b }
+
+// Neg membalik tanda nominal.
+func (a Amount) Neg() Amount { return -a }
+
+// Abs mengembalikan nilai absolut.
+func (a Amount) Abs() Amount {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// IsZero, IsPositive, IsNegative adalah pembanding singkat terhadap nol.
+func (a Amount) IsZero() bool     { return a == 0 }
+func (a Amount) IsPositive() bool { return a > 0 }
+func (a Amount) IsNegative() bool { return a < 0 }
+
+// Min & Max mengembalikan nominal terkecil / terbesar.
+func Min(a, b Amount) Amount {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func Max(a, b Amount) Amount {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Sum menjumlahkan banyak nominal sekaligus.
+func Sum(amounts ...Amount) Amount {
+	var total Amount
+	for _, a := range amounts {
+		total += a
+	}
+	return total
+}
+
+// MulInt mengalikan nominal dengan bilangan bulat (cth: harga x jumlah pcs). Selalu eksak.
+func (a Amount) MulInt(n int64) Amount {
+	return a * Amount(n)
+}
+
+// MulQuantity mengalikan harga per unit dengan jumlah ber-desimal 2 digit (cth: 3.5 kg),
+// lalu membulatkan hasilnya ke sen dengan mode yang dipilih.
+func (a Amount) MulQuantity(quantity float64, mode RoundingMode) Amount {
+	hundredths := int64(math.Round(quantity * 100))
+	return Amount(divRound(int64(a)*hundredths, 100, mode))
+}
+
+// MulPercent menghitung p persen dari nominal (cth: diskon 10%, PPN 11%),
+// dengan pembulatan ke sen sesuai mode. p memakai 2 desimal (12.5% = Percent 1250).
+func (a Amount) MulPercent(p Percent, mode RoundingMode) Amount {
+	return Amount(divRound(int64(a)*int64(p), 100*100, mode))
+}
+
+// MulRatio menghitung a x num / den dengan pembulatan sesuai mode (cth: alokasi proporsional).
+func (a Amount) MulRatio(num, den int64, mode RoundingMode) Amount {
+	if den == 0 {
+		return 0
+	}
+	return Amount(divRound(int64(a)*num, den, mode))
+}
+
+// RoundTo membulatkan nominal ke kelipatan unit (cth: RoundTo(New(100), HalfUp) untuk pembulatan ke Rp100).
+func (a Amount) RoundTo(unit Amount, mode RoundingMode) Amount {
+	if unit <= 0 {
+		return a
+	}
+	return Amount(divRound(int64(a), int64(unit), mode)) * unit
+}
+
+// --- HELPER FUNCTION ---
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
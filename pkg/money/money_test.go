@@ -0,0 +1,202 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+
+	tests := []struct {
+		text    string
+		want    Amount
+		wantErr bool
+	}{
+		{text: "12500", want: New(12500)},
+		{text: "12500.5", want: FromMinor(1250050)},
+		{text: "-3.25", want: FromMinor(-325)},
+		{text: "+7", want: New(7)},
+		{text: ".5", want: FromMinor(50)},
+		{text: " 10.00 ", want: New(10)},
+		{text: "0.004", want: 0},
+		{text: "0.005", want: FromMinor(1)},
+		{text: "-0.005", want: FromMinor(-1)},
+		{text: "", wantErr: true},
+		{text: "-", wantErr: true},
+		{text: ".", wantErr: true},
+		{text: "1,000", wantErr: true},
+		{text: "1e3", wantErr: true},
+		{text: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.text)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidAmount) {
+				t.Errorf("Parse(%q) error = %v, want ErrInvalidAmount", tt.text, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %d, %v, want %d", tt.text, got, err, tt.want)
+		}
+	}
+}
+
+func TestStringAndRupiah(t *testing.T) {
+
+	tests := []struct {
+		amount     Amount
+		wantString string
+		wantRupiah string
+	}{
+		{amount: New(63000), wantString: "63000", wantRupiah: "Rp63.000"},
+		{amount: FromMinor(125050), wantString: "1250.5", wantRupiah: "Rp1.251"},
+		{amount: FromMinor(-325), wantString: "-3.25", wantRupiah: "-Rp3"},
+		{amount: FromMinor(5), wantString: "0.05", wantRupiah: "Rp0"},
+		{amount: New(1234567), wantString: "1234567", wantRupiah: "Rp1.234.567"},
+		{amount: Zero, wantString: "0", wantRupiah: "Rp0"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.String(); got != tt.wantString {
+			t.Errorf("String(%d) = %q, want %q", tt.amount, got, tt.wantString)
+		}
+		if got := tt.amount.Rupiah(); got != tt.wantRupiah {
+			t.Errorf("Rupiah(%d) = %q, want %q", tt.amount, got, tt.wantRupiah)
+		}
+	}
+}
+
+func TestRoundingModes(t *testing.T) {
+
+	tests := []struct {
+		name string
+		num  int64
+		den  int64
+		want map[RoundingMode]int64
+	}{
+		{name: "exact", num: 10, den: 5, want: map[RoundingMode]int64{HalfUp: 2, HalfEven: 2, Down: 2, Up: 2}},
+		{name: "below half", num: 14, den: 10, want: map[RoundingMode]int64{HalfUp: 1, HalfEven: 1, Down: 1, Up: 2}},
+		{name: "half to odd", num: 15, den: 10, want: map[RoundingMode]int64{HalfUp: 2, HalfEven: 2, Down: 1, Up: 2}},
+		{name: "half to even", num: 25, den: 10, want: map[RoundingMode]int64{HalfUp: 3, HalfEven: 2, Down: 2, Up: 3}},
+		{name: "above half", num: 26, den: 10, want: map[RoundingMode]int64{HalfUp: 3, HalfEven: 3, Down: 2, Up: 3}},
+		{name: "negative half away from zero", num: -25, den: 10, want: map[RoundingMode]int64{HalfUp: -3, HalfEven: -2, Down: -2, Up: -3}},
+		{name: "negative denominator", num: 25, den: -10, want: map[RoundingMode]int64{HalfUp: -3, HalfEven: -2, Down: -2, Up: -3}},
+	}
+
+	for _, tt := range tests {
+		for mode, want := range tt.want {
+			if got := divRound(tt.num, tt.den, mode); got != want {
+				t.Errorf("%s: divRound(%d, %d, %d) = %d, want %d", tt.name, tt.num, tt.den, mode, got, want)
+			}
+		}
+	}
+}
+
+func TestMultiplication(t *testing.T) {
+
+	// PPN 11% dari Rp10.050 = Rp1.105,50
+	if got := New(10050).MulPercent(NewPercent(11), HalfUp); got != FromMinor(110550) {
+		t.Errorf("MulPercent = %s, want 1105.5", got)
+	}
+	// 12.5% dari Rp0,99 = 0,12375 sen -> Down 12 sen, Up 13 sen
+	if got := FromMinor(99).MulPercent(Percent(1250), Down); got != FromMinor(12) {
+		t.Errorf("MulPercent Down = %s, want 0.12", got)
+	}
+	if got := FromMinor(99).MulPercent(Percent(1250), Up); got != FromMinor(13) {
+		t.Errorf("MulPercent Up = %s, want 0.13", got)
+	}
+	// 3.5 kg x Rp7.000 tidak boleh terkena galat float
+	if got := New(7000).MulQuantity(3.5, HalfUp); got != New(24500) {
+		t.Errorf("MulQuantity = %s, want 24500", got)
+	}
+	if got := New(100).MulRatio(1, 3, HalfUp); got != FromMinor(3333) {
+		t.Errorf("MulRatio = %s, want 33.33", got)
+	}
+	if got := New(100).MulRatio(1, 0, HalfUp); got != Zero {
+		t.Errorf("MulRatio by zero = %s, want 0", got)
+	}
+	if got := FromMinor(12350).RoundTo(New(100), HalfUp); got != New(100) {
+		t.Errorf("RoundTo = %s, want 100", got)
+	}
+}
+
+func TestAllocate(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		total   Amount
+		weights []Amount
+		want    []Amount
+	}{
+		{name: "even split", total: New(30), weights: []Amount{New(1), New(1), New(1)}, want: []Amount{New(10), New(10), New(10)}},
+		{name: "leftover cent goes to the first tie", total: FromMinor(100), weights: []Amount{New(1), New(1), New(1)}, want: []Amount{FromMinor(34), FromMinor(33), FromMinor(33)}},
+		{name: "largest remainder wins", total: FromMinor(10), weights: []Amount{FromMinor(1), FromMinor(2), FromMinor(4)}, want: []Amount{FromMinor(1), FromMinor(3), FromMinor(6)}},
+		{name: "non-positive weights get nothing", total: New(10), weights: []Amount{0, New(5), FromMinor(-3)}, want: []Amount{0, New(10), 0}},
+		{name: "all zero weights go to the first", total: New(10), weights: []Amount{0, 0}, want: []Amount{New(10), 0}},
+		{name: "negative total keeps sign", total: FromMinor(-100), weights: []Amount{New(1), New(2)}, want: []Amount{FromMinor(-33), FromMinor(-67)}},
+		{name: "empty weights", total: New(10), weights: nil, want: []Amount{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Allocate(tt.total, tt.weights)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Allocate = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Allocate = %v, want %v", got, tt.want)
+				}
+			}
+			if len(tt.weights) > 0 && Sum(got...) != tt.total {
+				t.Fatalf("Allocate sum = %s, want %s", Sum(got...), tt.total)
+			}
+		})
+	}
+}
+
+func TestJSONAndScan(t *testing.T) {
+
+	var body struct {
+		Price   Amount  `json:"price"`
+		Text    Amount  `json:"text"`
+		Exp     Amount  `json:"exp"`
+		Rate    Percent `json:"rate"`
+		Missing Amount  `json:"missing"`
+	}
+	if err := json.Unmarshal([]byte(`{"price": 1250.5, "text": "63000", "exp": 1e3, "rate": 12.5, "missing": null}`), &body); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if body.Price != FromMinor(125050) || body.Text != New(63000) || body.Exp != New(1000) || body.Rate != Percent(1250) || body.Missing != 0 {
+		t.Fatalf("Unmarshal = %+v", body)
+	}
+
+	out, err := json.Marshal(map[string]Amount{"total": FromMinor(125050)})
+	if err != nil || string(out) != `{"total":1250.5}` {
+		t.Fatalf("Marshal = %s, %v", out, err)
+	}
+
+	var scanned Amount
+	for src, want := range map[interface{}]Amount{"1250.50": FromMinor(125050), int64(5): New(5), 2.5: FromMinor(250)} {
+		if err := scanned.Scan(src); err != nil || scanned != want {
+			t.Errorf("Scan(%v) = %s, %v, want %s", src, scanned, err, want)
+		}
+	}
+	if err := scanned.Scan([]byte("10.05")); err != nil || scanned != FromMinor(1005) {
+		t.Errorf("Scan([]byte) = %s, %v", scanned, err)
+	}
+	if err := scanned.Scan(true); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("Scan(bool) error = %v, want ErrInvalidAmount", err)
+	}
+
+	var null NullAmount
+	if err := null.Scan(nil); err != nil || null.Valid || null.Ptr() != nil {
+		t.Errorf("NullAmount.Scan(nil) = %+v, %v", null, err)
+	}
+	if v, _ := NullFrom(nil).Value(); v != nil {
+		t.Errorf("NullFrom(nil).Value() = %v, want nil", v)
+	}
+}
@@ -0,0 +1,58 @@
+package money
+
+import "database/sql/driver"
+
+// Percent adalah persentase dengan 2 desimal dalam satuan seperseratus persen
+// (basis point), cth: 10% = 1000, 12.5% = 1250, PPN 11% = 1100.
+type Percent int64
+
+// NewPercent membuat Percent dari persen utuh, cth: NewPercent(11) = 11%.
+func NewPercent(percent int64) Percent {
+	return Percent(percent * 100)
+}
+
+// PercentFromAmount menafsirkan nilai DECIMAL(x,2) yang dipakai bersama untuk persen & nominal
+// (cth: charge_value add-on, discount_value promosi) sebagai persen.
+func PercentFromAmount(a Amount) Percent {
+	return Percent(a)
+}
+
+// Float64 mengonversi ke persen float (10% -> 10). Hanya untuk tampilan.
+func (p Percent) Float64() float64 {
+	return float64(p) / 100
+}
+
+// String memformat persen tanpa nol berlebih, cth: "10", "12.5".
+func (p Percent) String() string {
+	return Amount(p).String()
+}
+
+// MarshalJSON menulis persen sebagai angka biasa (10% -> 10).
+func (p Percent) MarshalJSON() ([]byte, error) {
+	return Amount(p).MarshalJSON()
+}
+
+// UnmarshalJSON membaca angka JSON (10, 12.5, "11") secara eksak.
+func (p *Percent) UnmarshalJSON(data []byte) error {
+	var a Amount
+	if err := a.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*p = Percent(a)
+	return nil
+}
+
+// Scan mengimplementasikan sql.Scanner untuk kolom DECIMAL(5,2).
+func (p *Percent) Scan(src interface{}) error {
+	var a Amount
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+	*p = Percent(a)
+	return nil
+}
+
+// Value mengimplementasikan driver.Valuer.
+func (p Percent) Value() (driver.Value, error) {
+	return Amount(p).Value()
+}
@@ -0,0 +1,52 @@
+package money
+
+// RoundingMode menentukan cara membulatkan sisa pembagian ke sen terdekat.
+type RoundingMode int
+
+const (
+	// HalfUp membulatkan 0.5 menjauhi nol (pembulatan kasir pada umumnya).
+	HalfUp RoundingMode = iota
+	// HalfEven membulatkan 0.5 ke angka genap terdekat (banker's rounding) untuk agregat laporan.
+	HalfEven
+	// Down memotong sisa menuju nol (cth: diskon tidak boleh lebih besar dari hak pelanggan).
+	Down
+	// Up membulatkan sisa menjauhi nol (cth: pajak yang tidak boleh kurang bayar).
+	Up
+)
+
+// divRound menghitung num / den dan membulatkan hasilnya sesuai mode secara deterministik.
+func divRound(num, den int64, mode RoundingMode) int64 {
+	if den < 0 {
+		num, den = -num, -den
+	}
+
+	q, r := num/den, num%den
+	if r == 0 {
+		return q
+	}
+
+	// Arah pembulatan mengikuti tanda hasil
+	sign := int64(1)
+	if num < 0 {
+		sign = -1
+		r = -r
+	}
+
+	switch mode {
+	case Down:
+		return q
+	case Up:
+		return q + sign
+	case HalfEven:
+		twice := 2 * r
+		if twice > den || (twice == den && q%2 != 0) {
+			return q + sign
+		}
+		return q
+	default: // HalfUp
+		if 2*r >= den {
+			return q + sign
+		}
+		return q
+	}
+}
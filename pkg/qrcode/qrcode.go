@@ -0,0 +1,446 @@
+// Package qrcode membuat simbol QR Code (ISO/IEC 18004) mode byte tanpa dependensi eksternal.
+//
+// Dipakai untuk mencetak tautan pelacakan pesanan di nota (PDF, thermal, teks).
+// Hanya versi 1-10 yang didukung (maksimal 213 byte pada level M), cukup untuk URL pendek.
+package qrcode
+
+import (
+	"errors"
+)
+
+// ErrTooLong dikembalikan jika data melebihi kapasitas versi 10 pada level koreksi yang dipilih.
+var ErrTooLong = errors.New("qrcode: data too long")
+
+// Level adalah tingkat koreksi kesalahan (error correction level).
+type Level int
+
+const (
+	Low      Level = iota // ~7% data dapat dipulihkan
+	Medium                // ~15%
+	Quartile              // ~25%
+	High                  // ~30%
+)
+
+const maxVersion = 10
+
+// formatBits adalah nilai 2-bit level koreksi pada format information (urutan L, M, Q, H).
+var formatBits = [4]int{1, 0, 3, 2}
+
+// eccCodewordsPerBlock & numBlocks diindeks [level][versi], versi 0 tidak dipakai.
+var eccCodewordsPerBlock = [4][maxVersion + 1]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28},
+}
+
+var numBlocks = [4][maxVersion + 1]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8},
+}
+
+// Code adalah matriks modul QR yang sudah jadi. Tidak termasuk quiet zone.
+type Code struct {
+	Version int
+	Size    int
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Black mengembalikan true jika modul pada kolom x, baris y berwarna gelap.
+// Koordinat di luar simbol (quiet zone) selalu terang.
+func (c *Code) Black(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// Encode membuat QR Code dengan versi terkecil yang muat untuk data.
+func Encode(data string, level Level) (*Code, error) {
+
+	// 1. Pilih versi terkecil yang cukup menampung data
+	payload := []byte(data)
+	version := 0
+	for v := 1; v <= maxVersion; v++ {
+		if 4+countBits(v)+len(payload)*8 <= numDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	// 2. Susun bit data: mode byte, panjang, isi, terminator, lalu byte pengisi
+	capacity := numDataCodewords(version, level) * 8
+	var bb bitBuffer
+	bb.append(0x4, 4)
+	bb.append(len(payload), countBits(version))
+	for _, b := range payload {
+		bb.append(int(b), 8)
+	}
+	bb.append(0, min(4, capacity-len(bb)))
+	bb.append(0, (8-len(bb)%8)%8)
+	for pad := 0xEC; len(bb) < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	codewords := make([]byte, len(bb)/8)
+	for i, bit := range bb {
+		if bit {
+			codewords[i>>3] |= 1 << (7 - uint(i&7))
+		}
+	}
+
+	// 3. Tambahkan Reed-Solomon per blok & interleave
+	all := addEccAndInterleave(codewords, version, level)
+
+	// 4. Gambar pola fungsi & modul data
+	size := version*4 + 17
+	c := &Code{Version: version, Size: size}
+	c.modules = make([][]bool, size)
+	c.isFunction = make([][]bool, size)
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.isFunction[i] = make([]bool, size)
+	}
+	c.drawFunctionPatterns(level)
+	c.drawCodewords(all)
+
+	// 5. Pilih mask dengan penalti terkecil
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(level, mask)
+		penalty := c.penaltyScore()
+		if bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask) // XOR dua kali = kembali semula
+	}
+	c.applyMask(best)
+	c.drawFormatBits(level, best)
+
+	return c, nil
+}
+
+// countBits adalah lebar field panjang data mode byte.
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// numRawDataModules menghitung modul yang tersedia untuk data + ECC pada satu versi.
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+func numDataCodewords(version int, level Level) int {
+	return numRawDataModules(version)/8 - eccCodewordsPerBlock[level][version]*numBlocks[level][version]
+}
+
+func addEccAndInterleave(data []byte, version int, level Level) []byte {
+	blocks := numBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := blocks - rawCodewords%blocks
+	shortBlockLen := rawCodewords / blocks
+
+	// 1. Pecah data menjadi blok (blok panjang mendapat 1 byte lebih)
+	divisor := reedSolomonDivisor(eccLen)
+	split := make([][]byte, blocks)
+	k := 0
+	for i := 0; i < blocks; i++ {
+		n := shortBlockLen - eccLen
+		if i >= numShortBlocks {
+			n++
+		}
+		dat := append([]byte(nil), data[k:k+n]...)
+		k += n
+		ecc := reedSolomonRemainder(dat, divisor)
+		if i < numShortBlocks {
+			dat = append(dat, 0) // placeholder agar semua blok sama panjang
+		}
+		split[i] = append(dat, ecc...)
+	}
+
+	// 2. Interleave kolom per kolom, lewati placeholder
+	result := make([]byte, 0, rawCodewords)
+	for i := range split[0] {
+		for j := range split {
+			if i != shortBlockLen-eccLen || j >= numShortBlocks {
+				result = append(result, split[j][i])
+			}
+		}
+	}
+	return result
+}
+
+// alignmentPositions mengembalikan koordinat pusat alignment pattern.
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	numAlign := version/7 + 2
+	step := (version*8 + numAlign*3 + 5) / (numAlign*4 - 4) * 2
+	result := make([]int, numAlign)
+	result[0] = 6
+	for i, pos := numAlign-1, version*4+17-7; i >= 1; i, pos = i-1, pos-step {
+		result[i] = pos
+	}
+	return result
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns(level Level) {
+
+	// 1. Timing pattern
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	// 2. Finder pattern di tiga sudut (termasuk separator)
+	for _, center := range [][2]int{{3, 3}, {c.Size - 4, 3}, {3, c.Size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := center[0]+dx, center[1]+dy
+				if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+					continue
+				}
+				dist := max(abs(dx), abs(dy))
+				c.setFunction(x, y, dist != 2 && dist != 4)
+			}
+		}
+	}
+
+	// 3. Alignment pattern (kecuali yang bertumpuk dengan finder)
+	positions := alignmentPositions(c.Version)
+	last := len(positions) - 1
+	for i, px := range positions {
+		for j, py := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					c.setFunction(px+dx, py+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// 4. Cadangkan area format (diisi ulang per mask) & tulis informasi versi
+	c.drawFormatBits(level, 0)
+	if c.Version >= 7 {
+		rem := c.Version
+		for i := 0; i < 12; i++ {
+			rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+		}
+		bits := c.Version<<12 | rem
+		for i := 0; i < 18; i++ {
+			dark := (bits>>uint(i))&1 != 0
+			a, b := c.Size-11+i%3, i/3
+			c.setFunction(a, b, dark)
+			c.setFunction(b, a, dark)
+		}
+	}
+}
+
+// formatInfo menghitung 15 bit format information (level + mask, BCH, XOR 0x5412).
+func formatInfo(level Level, mask int) int {
+	data := formatBits[level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (c *Code) drawFormatBits(level Level, mask int) {
+	bits := formatInfo(level, mask)
+	bit := func(i int) bool { return (bits>>uint(i))&1 != 0 }
+
+	// 1. Salinan pertama di sekitar finder kiri atas
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	// 2. Salinan kedua di finder kanan atas & kiri bawah, plus dark module
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(i))
+	}
+	c.setFunction(8, c.Size-8, true)
+}
+
+// drawCodewords menempatkan bit data secara zig-zag dua kolom dari kanan bawah.
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // lewati kolom timing vertikal
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.isFunction[y][x] && i < len(data)*8 {
+					c.modules[y][x] = (data[i>>3]>>(7-uint(i&7)))&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !c.isFunction[y][x] {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// penaltyScore menghitung skor penalti mask sesuai aturan N1-N4 standar.
+func (c *Code) penaltyScore() int {
+	const n1, n2, n3, n4 = 3, 3, 40, 10
+	size := c.Size
+	score := 0
+
+	// N1 & N3: deretan warna sama & pola mirip finder (baris lalu kolom)
+	line := make([]bool, size)
+	for pass := 0; pass < 2; pass++ {
+		for a := 0; a < size; a++ {
+			for b := 0; b < size; b++ {
+				if pass == 0 {
+					line[b] = c.modules[a][b]
+				} else {
+					line[b] = c.modules[b][a]
+				}
+			}
+			run := 1
+			for b := 1; b <= size; b++ {
+				if b < size && line[b] == line[b-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += n1 + run - 5
+				}
+				run = 1
+			}
+			score += n3 * finderLikeCount(line)
+		}
+	}
+
+	// N2: blok 2x2 berwarna sama
+	for y := 0; y < size-1; y++ {
+		for x := 0; x < size-1; x++ {
+			dark := c.modules[y][x]
+			if dark == c.modules[y][x+1] && dark == c.modules[y+1][x] && dark == c.modules[y+1][x+1] {
+				score += n2
+			}
+		}
+	}
+
+	// N4: keseimbangan modul gelap/terang
+	dark := 0
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+		}
+	}
+	total := size * size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	score += max(k, 0) * n4
+
+	return score
+}
+
+// finderLikeCount menghitung pola 1:1:3:1:1 dengan 4 modul terang di salah satu sisi.
+func finderLikeCount(line []bool) int {
+	pattern := []bool{true, false, true, true, true, false, true}
+	at := func(i int) bool { return i >= 0 && i < len(line) && line[i] }
+	count := 0
+	for i := 0; i+len(pattern) <= len(line); i++ {
+		match := true
+		for j, p := range pattern {
+			if line[i+j] != p {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		lightBefore, lightAfter := true, true
+		for j := 1; j <= 4; j++ {
+			lightBefore = lightBefore && !at(i-j)
+			lightAfter = lightAfter && !at(i+len(pattern)-1+j)
+		}
+		if lightBefore || lightAfter {
+			count++
+		}
+	}
+	return count
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
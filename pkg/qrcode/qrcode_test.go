@@ -0,0 +1,331 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// goldenCodes adalah matriks emas hasil Encode (# = modul gelap). Setiap matriks juga didekode ulang
+// oleh decodeForTest, jadi perubahan encoder harus tetap menghasilkan simbol yang terbaca.
+var goldenCodes = []struct {
+	data   string
+	level  Level
+	matrix []string
+}{
+	{
+		data:  "LAUNDRY",
+		level: Medium,
+		matrix: []string{
+			"#######..##.#.#######",
+			"#.....#.##..#.#.....#",
+			"#.###.#..##...#.###.#",
+			"#.###.#..#....#.###.#",
+			"#.###.#.#...#.#.###.#",
+			"#.....#...#.#.#.....#",
+			"#######.#.#.#.#######",
+			".........#.##........",
+			"#.#.#.#....#....#..#.",
+			"##......#.#...##.#.#.",
+			"#.##.##.##..#...#####",
+			"..#.#..#.#....#....#.",
+			"#.#.#.###...#.#..###.",
+			"........#..#.#.....#.",
+			"#######..###.########",
+			"#.....#....###.##...#",
+			"#.###.#.#..#.##.#.###",
+			"#.###.#..#....#...##.",
+			"#.###.#.#.#.#..##...#",
+			"#.....#.......##...#.",
+			"#######.##..#.###.###",
+		},
+	},
+	{
+		data:  "https://laundry.example.com/t/INV-260105-001",
+		level: Medium,
+		matrix: []string{
+			"#######.#...##...####.##..#######",
+			"#.....#.#.##.#....#.####..#.....#",
+			"#.###.#..#.#.....#.#.##.#.#.###.#",
+			"#.###.#.####..###...###.#.#.###.#",
+			"#.###.#...#..##.....#..#..#.###.#",
+			"#.....#.....#.#...#####.#.#.....#",
+			"#######.#.#.#.#.#.#.#.#.#.#######",
+			"........#..#.....#..#..#.........",
+			"#.##.###....###..#####....#..#.##",
+			"#..###.#........#.######..##.##.#",
+			"#.###.#####...#.##.....#..####..#",
+			"...#......######..###..###.#.#.##",
+			"#...#####.#.#.....##.#.#....##.#.",
+			"....##.#..###...###..##..#....##.",
+			"#.#.###..#.#..###.#...#...####...",
+			"###....##..#.##.#..####.####..#..",
+			"..######.#..##.#...###.#.##.#.#..",
+			"##...#...#.#.#.###..#######.##...",
+			".###..###.###..###..#.#..#.##.##.",
+			"#.##....#.####..#...##.####.#....",
+			".##.#.#...#.####..###...##.#.##.#",
+			"#.#.#.......#.##.##....##.##..#.#",
+			"...#..##.##..##.#.#..###.#...####",
+			".#.#.#.###...#..#..#..#..#..#..#.",
+			"#.#..##.#..#..##.#....#.######.##",
+			"........#.#.######.#....#...##..#",
+			"#######.##.........#..###.#.#....",
+			"#.....#.#...###.#....####...####.",
+			"#.###.#...#....#.#.##..######.#..",
+			"#.###.#.##.#.##...###..#...#.#.##",
+			"#.###.#.#..###...###.##.#.##.....",
+			"#.....#..##...######..#.##.###..#",
+			"#######.###..#.####.##......###..",
+		},
+	},
+}
+
+func TestEncodeGolden(t *testing.T) {
+
+	for _, tt := range goldenCodes {
+		t.Run(tt.data, func(t *testing.T) {
+			code, err := Encode(tt.data, tt.level)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			if code.Size != len(tt.matrix) || code.Size != code.Version*4+17 {
+				t.Fatalf("size = %d (version %d), want %d", code.Size, code.Version, len(tt.matrix))
+			}
+			for y, want := range tt.matrix {
+				if got := matrixRow(code, y); got != want {
+					t.Fatalf("row %d =\n%s\nwant\n%s", y, got, want)
+				}
+			}
+
+			got, err := decodeForTest(code, tt.level)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got != tt.data {
+				t.Fatalf("decoded %q, want %q", got, tt.data)
+			}
+		})
+	}
+}
+
+// TestReedSolomonKnownVectors memakai contoh 1-M dari ISO/IEC 18004 Annex I ("01234567")
+// dan contoh "HELLO WORLD" 1-M yang umum dijadikan referensi.
+func TestReedSolomonKnownVectors(t *testing.T) {
+
+	tests := []struct {
+		name string
+		data []byte
+		want []byte
+	}{
+		{
+			name: "ISO 18004 Annex I",
+			data: []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11},
+			want: []byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55},
+		},
+		{
+			name: "HELLO WORLD",
+			data: []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17},
+			want: []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reedSolomonRemainder(tt.data, reedSolomonDivisor(len(tt.want))); !bytes.Equal(got, tt.want) {
+				t.Fatalf("ecc = % X, want % X", got, tt.want)
+			}
+		})
+	}
+}
+
+// formatTable adalah 15 bit format information standar (sesudah XOR 0x5412) untuk mask 0-7.
+var formatTable = map[Level][8]string{
+	Low:    {"111011111000100", "111001011110011", "111110110101010", "111100010011101", "110011000101111", "110001100011000", "110110001000001", "110100101110110"},
+	Medium: {"101010000010010", "101000100100101", "101111001111100", "101101101001011", "100010111111001", "100000011001110", "100111110010111", "100101010100000"},
+}
+
+func TestFormatInfo(t *testing.T) {
+
+	for level, masks := range formatTable {
+		for mask, want := range masks {
+			if got := fmt.Sprintf("%015b", formatInfo(level, mask)); got != want {
+				t.Errorf("formatInfo(%d, %d) = %s, want %s", level, mask, got, want)
+			}
+		}
+	}
+}
+
+func TestVersionInformation(t *testing.T) {
+
+	// 18 bit informasi versi standar (6 bit versi + 12 bit BCH)
+	want := map[int]int{7: 0x07C94, 8: 0x085BC, 9: 0x09A99, 10: 0x0A4D3}
+
+	seen := map[int]bool{}
+	for n := 100; n <= 210; n += 5 {
+		code, err := Encode(strings.Repeat("a", n), Medium)
+		if err != nil {
+			t.Fatalf("Encode(%d bytes): %v", n, err)
+		}
+		bits, ok := want[code.Version]
+		if !ok {
+			continue
+		}
+		seen[code.Version] = true
+		for i := 0; i < 18; i++ {
+			dark := (bits>>uint(i))&1 != 0
+			a, b := code.Size-11+i%3, i/3
+			if code.Black(a, b) != dark || code.Black(b, a) != dark {
+				t.Fatalf("version %d bit %d = %v, want %v", code.Version, i, !dark, dark)
+			}
+		}
+	}
+	if len(seen) != len(want) {
+		t.Fatalf("covered versions %v, want 7-10", seen)
+	}
+}
+
+func TestEncodeCapacity(t *testing.T) {
+
+	if _, err := Encode(strings.Repeat("a", 214), Medium); !errors.Is(err, ErrTooLong) {
+		t.Fatalf("214 bytes error = %v, want ErrTooLong", err)
+	}
+	code, err := Encode(strings.Repeat("a", 213), Medium)
+	if err != nil {
+		t.Fatalf("213 bytes: %v", err)
+	}
+	if code.Version != maxVersion {
+		t.Fatalf("213 bytes version = %d, want %d", code.Version, maxVersion)
+	}
+	if got, err := decodeForTest(code, Medium); err != nil || got != strings.Repeat("a", 213) {
+		t.Fatalf("decode version 10 = %q, %v", got, err)
+	}
+}
+
+// --- HELPER FUNCTION ---
+
+func matrixRow(code *Code, y int) string {
+	var b strings.Builder
+	for x := 0; x < code.Size; x++ {
+		if code.Black(x, y) {
+			b.WriteByte('#')
+		} else {
+			b.WriteByte('.')
+		}
+	}
+	return b.String()
+}
+
+// decodeForTest membaca ulang simbol seperti pemindai: format information, buka mask,
+// ambil codeword zig-zag, periksa ECC setiap blok, lalu urai isi mode byte.
+func decodeForTest(code *Code, level Level) (string, error) {
+
+	// 1. Format information salinan pertama harus ada di tabel standar
+	var format int
+	for i := 0; i < 15; i++ {
+		var dark bool
+		switch {
+		case i <= 5:
+			dark = code.Black(8, i)
+		case i == 6:
+			dark = code.Black(8, 7)
+		case i == 7:
+			dark = code.Black(8, 8)
+		case i == 8:
+			dark = code.Black(7, 8)
+		default:
+			dark = code.Black(14-i, 8)
+		}
+		if dark {
+			format |= 1 << uint(i)
+		}
+	}
+	mask := -1
+	for m, bits := range formatTable[level] {
+		if fmt.Sprintf("%015b", format) == bits {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		return "", fmt.Errorf("format bits %015b do not match level %d", format, level)
+	}
+
+	// 2. Buka mask pada salinan matriks lalu baca bit zig-zag
+	clone := &Code{Version: code.Version, Size: code.Size, isFunction: code.isFunction}
+	clone.modules = make([][]bool, code.Size)
+	for y := range clone.modules {
+		clone.modules[y] = append([]bool(nil), code.modules[y]...)
+	}
+	clone.applyMask(mask)
+
+	raw := make([]byte, numRawDataModules(code.Version)/8)
+	i := 0
+	for right := code.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < code.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = code.Size - 1 - vert
+				}
+				if !clone.isFunction[y][x] && i < len(raw)*8 {
+					if clone.modules[y][x] {
+						raw[i>>3] |= 1 << (7 - uint(i&7))
+					}
+					i++
+				}
+			}
+		}
+	}
+
+	// 3. Pisahkan blok, periksa ECC, gabungkan data
+	blocks := numBlocks[level][code.Version]
+	eccLen := eccCodewordsPerBlock[level][code.Version]
+	numShort := blocks - len(raw)%blocks
+	shortData := len(raw)/blocks - eccLen
+	data := make([][]byte, blocks)
+	ecc := make([][]byte, blocks)
+	k := 0
+	for col := 0; col <= shortData; col++ {
+		for b := 0; b < blocks; b++ {
+			if col < shortData || b >= numShort {
+				data[b] = append(data[b], raw[k])
+				k++
+			}
+		}
+	}
+	for col := 0; col < eccLen; col++ {
+		for b := 0; b < blocks; b++ {
+			ecc[b] = append(ecc[b], raw[k])
+			k++
+		}
+	}
+	var payload []byte
+	for b := range data {
+		if want := reedSolomonRemainder(data[b], reedSolomonDivisor(eccLen)); !bytes.Equal(ecc[b], want) {
+			return "", fmt.Errorf("block %d ecc mismatch", b)
+		}
+		payload = append(payload, data[b]...)
+	}
+
+	// 4. Mode byte (0100), panjang, isi
+	read := func(pos, length int) int {
+		v := 0
+		for j := pos; j < pos+length; j++ {
+			v = v<<1 | int(payload[j>>3]>>(7-uint(j&7)))&1
+		}
+		return v
+	}
+	if mode := read(0, 4); mode != 0x4 {
+		return "", fmt.Errorf("mode %04b, want byte mode", mode)
+	}
+	start := 4 + countBits(code.Version)
+	out := make([]byte, read(4, countBits(code.Version)))
+	for j := range out {
+		out[j] = byte(read(start+j*8, 8))
+	}
+	return string(out), nil
+}
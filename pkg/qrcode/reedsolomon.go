@@ -0,0 +1,50 @@
+package qrcode
+
+// bitBuffer menampung bit data sebelum dipadatkan menjadi codeword.
+type bitBuffer []bool
+
+func (bb *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*bb = append(*bb, (value>>uint(i))&1 != 0)
+	}
+}
+
+// reedSolomonDivisor membuat polinomial generator berderajat degree di GF(2^8/0x11D).
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder menghitung codeword koreksi kesalahan untuk satu blok data.
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
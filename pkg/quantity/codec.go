@@ -0,0 +1,82 @@
+package quantity
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// --- JSON ---
+
+// MarshalJSON menulis jumlah sebagai angka JSON biasa agar format wire tetap sama dengan float64 sebelumnya.
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalJSON menerima angka JSON (30, 12.5) maupun string angka ("30").
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+
+	parsed, err := parseNumber(string(data))
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
+
+// --- DATABASE ---
+
+// Scan mengimplementasikan sql.Scanner. Driver MySQL mengirim DECIMAL sebagai []byte,
+// sehingga nilai dibaca langsung dari teks desimal tanpa melalui float.
+func (q *Quantity) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*q = 0
+		return nil
+	case []byte:
+		parsed, err := Parse(string(v))
+		if err != nil {
+			return err
+		}
+		*q = parsed
+	case string:
+		parsed, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*q = parsed
+	case int64:
+		*q = New(v)
+	case float64:
+		*q = FromFloat(v)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidQuantity, src)
+	}
+	return nil
+}
+
+// Value mengimplementasikan driver.Valuer dan menulis jumlah sebagai teks desimal eksak.
+func (q Quantity) Value() (driver.Value, error) {
+	return q.String(), nil
+}
+
+// parseNumber menerima notasi angka JSON termasuk eksponen (cth: 1e3) yang tidak ditangani Parse.
+func parseNumber(text string) (Quantity, error) {
+	parsed, err := Parse(text)
+	if err == nil {
+		return parsed, nil
+	}
+	value, floatErr := strconv.ParseFloat(text, 64)
+	if floatErr != nil {
+		return 0, err
+	}
+	return FromFloat(value), nil
+}
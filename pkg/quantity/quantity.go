@@ -0,0 +1,151 @@
+// Package quantity menyediakan tipe jumlah stok yang presisi untuk kolom DECIMAL(15,3).
+//
+// Quantity disimpan sebagai bilangan bulat dalam satuan per seribu (1.250 kg = 1250), sehingga
+// ribuan mutasi pecahan (cth: 0,1 liter deterjen) tidak menumpuk galat float. Format JSON tetap
+// berupa angka biasa (cth: 12.5 atau 30) agar kontrak API tidak berubah.
+package quantity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Quantity adalah jumlah dalam satuan per seribu (1000 = 1 unit).
+type Quantity int64
+
+// Zero adalah jumlah nol.
+const Zero Quantity = 0
+
+// scale adalah jumlah satuan per seribu dalam 1 unit (3 digit desimal).
+const scale = 1000
+
+// ErrInvalidQuantity dikembalikan jika teks tidak bisa dibaca sebagai jumlah.
+var ErrInvalidQuantity = errors.New("invalid quantity")
+
+// New membuat Quantity dari unit utuh, cth: New(30) = 30 ml.
+func New(units int64) Quantity {
+	return Quantity(units * scale)
+}
+
+// FromMilli membuat Quantity langsung dari satuan per seribu.
+func FromMilli(milli int64) Quantity {
+	return Quantity(milli)
+}
+
+// FromFloat mengonversi float64 ke Quantity dengan pembulatan HalfUp ke 3 desimal.
+// Hanya dipakai di batas sistem, bukan untuk perhitungan.
+func FromFloat(value float64) Quantity {
+	return Quantity(math.Round(value * scale))
+}
+
+// Parse membaca teks desimal ("12", "12.5", "-0.250") secara eksak tanpa melalui float.
+// Digit di belakang 3 desimal dibulatkan HalfUp.
+func Parse(text string) (Quantity, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, ErrInvalidQuantity
+	}
+
+	// 1. Pisahkan tanda
+	negative := false
+	switch text[0] {
+	case '-':
+		negative = true
+		text = text[1:]
+	case '+':
+		text = text[1:]
+	}
+
+	// 2. Pisahkan bagian bulat & desimal
+	whole, frac, _ := strings.Cut(text, ".")
+	if whole == "" && frac == "" {
+		return 0, ErrInvalidQuantity
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if !isDigits(whole) || (frac != "" && !isDigits(frac)) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidQuantity, text)
+	}
+
+	wholeValue, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || wholeValue > math.MaxInt64/scale-1 {
+		return 0, fmt.Errorf("%w: %q out of range", ErrInvalidQuantity, text)
+	}
+
+	// 3. Ambil 3 digit desimal, digit keempat menentukan pembulatan HalfUp
+	padded := frac + "000"
+	milli := wholeValue*scale + int64(padded[0]-'0')*100 + int64(padded[1]-'0')*10 + int64(padded[2]-'0')
+	if len(frac) > 3 && frac[3] >= '5' {
+		milli++
+	}
+
+	if negative {
+		milli = -milli
+	}
+	return Quantity(milli), nil
+}
+
+// Milli mengembalikan nilai dalam satuan per seribu.
+func (q Quantity) Milli() int64 {
+	return int64(q)
+}
+
+// Float64 mengonversi ke float64. Hanya untuk tampilan/ekspor, jangan dipakai menghitung.
+func (q Quantity) Float64() float64 {
+	return float64(q) / scale
+}
+
+// String memformat jumlah sebagai desimal tanpa nol berlebih, cth: "30", "12.5", "-0.005".
+func (q Quantity) String() string {
+	milli := int64(q)
+	sign := ""
+	if milli < 0 {
+		sign = "-"
+		milli = -milli
+	}
+
+	whole, frac := milli/scale, milli%scale
+	if frac == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	return strings.TrimRight(fmt.Sprintf("%s%d.%03d", sign, whole, frac), "0")
+}
+
+// --- ARITMATIKA ---
+
+// Add menjumlahkan dua jumlah.
+func (q Quantity) Add(b Quantity) Quantity { return q + b }
+
+// Sub mengurangi jumlah.
+func (q Quantity) Sub(b Quantity) Quantity { return q - b }
+
+// Neg membalik tanda jumlah.
+func (q Quantity) Neg() Quantity { return -q }
+
+// Abs mengembalikan nilai absolut.
+func (q Quantity) Abs() Quantity {
+	if q < 0 {
+		return -q
+	}
+	return q
+}
+
+// IsZero, IsPositive, IsNegative adalah pembanding singkat terhadap nol.
+func (q Quantity) IsZero() bool     { return q == 0 }
+func (q Quantity) IsPositive() bool { return q > 0 }
+func (q Quantity) IsNegative() bool { return q < 0 }
+
+// --- HELPER FUNCTION ---
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package quantity
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+
+	tests := []struct {
+		text    string
+		want    Quantity
+		wantErr bool
+	}{
+		{text: "0.000", want: 0},
+		{text: "12.500", want: 12500},
+		{text: "12.5", want: 12500},
+		{text: "7", want: New(7)},
+		{text: "-0.250", want: -250},
+		{text: ".5", want: 500},
+		{text: "999999999999.999", want: 999999999999999},
+		{text: "0.0004", want: 0},
+		{text: "0.0005", want: 1},
+		{text: "", wantErr: true},
+		{text: "-", wantErr: true},
+		{text: "abc", wantErr: true},
+		{text: "1,5", wantErr: true},
+		{text: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.text)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidQuantity) {
+				t.Errorf("Parse(%q) error = %v, want ErrInvalidQuantity", tt.text, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %d, %v, want %d", tt.text, got, err, tt.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+
+	tests := map[Quantity]string{
+		0:      "0",
+		5:      "0.005",
+		12500:  "12.5",
+		30000:  "30",
+		-250:   "-0.25",
+		-1001:  "-1.001",
+		123456: "123.456",
+	}
+	for q, want := range tests {
+		if got := q.String(); got != want {
+			t.Errorf("Quantity(%d).String() = %q, want %q", q, got, want)
+		}
+		back, err := Parse(want)
+		if err != nil || back != q {
+			t.Errorf("round trip %q = %d (%v), want %d", want, back, err, q)
+		}
+	}
+}
+
+// TestSumManyFractions memastikan ribuan penjumlahan pecahan tetap eksak (float64 akan bergeser).
+func TestSumManyFractions(t *testing.T) {
+
+	step := FromMilli(100)
+	total := Zero
+	var floatTotal float64
+	for i := 0; i < 10000; i++ {
+		total = total.Add(step)
+		floatTotal += 0.1
+	}
+	if total != New(1000) {
+		t.Fatalf("10000 x 0.1 = %s, want 1000", total)
+	}
+	if floatTotal == 1000 {
+		t.Fatalf("float64 sanity check: expected drift when summing 0.1 in float64")
+	}
+	if got := total.Sub(New(1000)).Add(step.Neg()).Abs(); got != step {
+		t.Fatalf("|1000 - 1000 - 0.1| = %s, want 0.1", got)
+	}
+}
+
+func TestJSONAndScan(t *testing.T) {
+
+	var body struct {
+		Stock   Quantity `json:"stock"`
+		Text    Quantity `json:"text"`
+		Exp     Quantity `json:"exp"`
+		Missing Quantity `json:"missing"`
+	}
+	if err := json.Unmarshal([]byte(`{"stock": 12.5, "text": "30", "exp": 1e3, "missing": null}`), &body); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if body.Stock != 12500 || body.Text != New(30) || body.Exp != New(1000) || body.Missing != 0 {
+		t.Fatalf("Unmarshal = %+v", body)
+	}
+
+	out, err := json.Marshal(map[string]Quantity{"stock": 12500})
+	if err != nil || string(out) != `{"stock":12.5}` {
+		t.Fatalf("Marshal = %s, %v", out, err)
+	}
+
+	var scanned Quantity
+	for src, want := range map[interface{}]Quantity{"12.500": 12500, int64(5): New(5), 2.5: 2500} {
+		if err := scanned.Scan(src); err != nil || scanned != want {
+			t.Errorf("Scan(%v) = %s, %v, want %s", src, scanned, err, want)
+		}
+	}
+	if err := scanned.Scan([]byte("-0.250")); err != nil || scanned != -250 {
+		t.Errorf("Scan([]byte) = %s, %v", scanned, err)
+	}
+	if err := scanned.Scan(true); !errors.Is(err, ErrInvalidQuantity) {
+		t.Errorf("Scan(bool) error = %v, want ErrInvalidQuantity", err)
+	}
+	if v, _ := Quantity(-1001).Value(); v != "-1.001" {
+		t.Errorf("Value() = %v, want -1.001", v)
+	}
+}
@@ -21,6 +21,24 @@ const (
 	CodeTokenExpired       = "TOKEN_EXPIRED"
 	CodeInvalidToken       = "INVALID_TOKEN"
 	CodeUserNotFound       = "USER_NOT_FOUND"
+
+	CodePromotionNotApplicable = "PROMOTION_NOT_APPLICABLE"
+	CodePromotionExhausted     = "PROMOTION_QUOTA_EXCEEDED"
+	CodeInsufficientBalance    = "INSUFFICIENT_BALANCE"
+	CodeInsufficientPoints     = "INSUFFICIENT_POINTS"
+	CodeInvalidTransition      = "INVALID_STATUS_TRANSITION"
+
+	CodeIdempotencyMismatch   = "IDEMPOTENCY_KEY_MISMATCH"
+	CodeIdempotencyInProgress = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	CodePreconditionFailed    = "PRECONDITION_FAILED"
+
+	CodeShiftAlreadyOpen = "SHIFT_ALREADY_OPEN"
+	CodeShiftNotOpen     = "SHIFT_NOT_OPEN"
+
+	CodeInsufficientStock = "INSUFFICIENT_STOCK"
+
+	CodeMachineUnavailable = "MACHINE_UNAVAILABLE"
+	CodeWashBatchConflict  = "WASH_BATCH_CONFLICT"
 )
 
 // ============================================
@@ -42,4 +60,22 @@ var (
 	ErrTokenExpired       = errors.New(CodeTokenExpired)
 	ErrInvalidToken       = errors.New(CodeInvalidToken)
 	ErrUserNotFound       = errors.New(CodeUserNotFound)
+
+	ErrPromotionNotApplicable = errors.New(CodePromotionNotApplicable)
+	ErrPromotionExhausted     = errors.New(CodePromotionExhausted)
+	ErrInsufficientBalance    = errors.New(CodeInsufficientBalance)
+	ErrInsufficientPoints     = errors.New(CodeInsufficientPoints)
+	ErrInvalidTransition      = errors.New(CodeInvalidTransition)
+
+	ErrIdempotencyMismatch   = errors.New(CodeIdempotencyMismatch)
+	ErrIdempotencyInProgress = errors.New(CodeIdempotencyInProgress)
+	ErrPreconditionFailed    = errors.New(CodePreconditionFailed)
+
+	ErrShiftAlreadyOpen = errors.New(CodeShiftAlreadyOpen)
+	ErrShiftNotOpen     = errors.New(CodeShiftNotOpen)
+
+	ErrInsufficientStock = errors.New(CodeInsufficientStock)
+
+	ErrMachineUnavailable = errors.New(CodeMachineUnavailable)
+	ErrWashBatchConflict  = errors.New(CodeWashBatchConflict)
 )
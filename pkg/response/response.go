@@ -21,11 +21,35 @@ type BaseResponse struct {
 
 // MetaData defines the structure for pagination details.
 // It is used within the 'Meta' field of BaseResponse.
+//
+// Mode offset mengisi current_page, total_items & total_pages. Mode cursor (keyset) mengisi
+// next_cursor & has_more; total hanya ada jika klien meminta ?with_count=true.
 type MetaData struct {
-	CurrentPage int   `json:"current_page"`
-	PerPage     int   `json:"per_page"`
-	TotalItems  int64 `json:"total_items"`
-	TotalPages  int   `json:"total_pages"`
+	CurrentPage int    `json:"current_page,omitempty"`
+	PerPage     int    `json:"per_page"`
+	TotalItems  *int64 `json:"total_items,omitempty"`
+	TotalPages  *int   `json:"total_pages,omitempty"`
+	NextCursor  string `json:"next_cursor,omitempty"` // Kosong = tidak ada halaman berikutnya
+	HasMore     *bool  `json:"has_more,omitempty"`
+}
+
+// NewPageMeta builds offset pagination metadata from the total row count.
+func NewPageMeta(page, perPage int, totalItems int64) MetaData {
+	totalPages := TotalPages(totalItems, perPage)
+	return MetaData{
+		CurrentPage: page,
+		PerPage:     perPage,
+		TotalItems:  &totalItems,
+		TotalPages:  &totalPages,
+	}
+}
+
+// TotalPages menghitung jumlah halaman dengan integer math (tanpa galat float).
+func TotalPages(totalItems int64, perPage int) int {
+	if perPage < 1 {
+		return 0
+	}
+	return int((totalItems + int64(perPage) - 1) / int64(perPage))
 }
 
 // ErrorResponseData defines the structure for detailed error reporting.
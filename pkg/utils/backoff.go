@@ -0,0 +1,20 @@
+package utils
+
+import "time"
+
+// Backoff menghitung jeda retry eksponensial: base x 2^(attempt-1), dibatasi maxDelay.
+// attempt dimulai dari 1 (percobaan pertama yang gagal).
+func Backoff(base time.Duration, attempt int, maxDelay time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return min(delay, maxDelay)
+}
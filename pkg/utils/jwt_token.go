@@ -13,11 +13,12 @@ type Claims struct {
 	UserID   int64  `json:"user_id"` // Explicit int64 prevents float64 unmarshaling panic
 	Username string `json:"username"`
 	Role     string `json:"role"`
+	OutletID int64  `json:"outlet_id"` // Outlet aktif; 0 = semua outlet (hanya owner, mode konsolidasi)
 	jwt.RegisteredClaims
 }
 
-// GenerateAccessToken creates a new HS256 JWT. Returns the token string and JTI.
-func GenerateAccessToken(userID int64, username, role string, secretKey []byte, expiry time.Duration) (string, string, error) {
+// GenerateAccessToken creates a new HS256 JWT scoped to outletID. Returns the token string and JTI.
+func GenerateAccessToken(userID int64, username, role string, outletID int64, secretKey []byte, expiry time.Duration) (string, string, error) {
 	jti := uuid.New().String()
 	expirationTime := time.Now().Add(expiry)
 
@@ -25,6 +26,7 @@ func GenerateAccessToken(userID int64, username, role string, secretKey []byte,
 		UserID:   userID,
 		Username: username,
 		Role:     role,
+		OutletID: outletID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(expirationTime),